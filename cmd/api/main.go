@@ -1,9 +1,22 @@
 package main
 
 import (
-	"go_di_architecture/internal/app/router"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
-	"github.com/gin-gonic/gin"
+	"go_di_architecture/internal/app/bootstrap"
+	"go_di_architecture/internal/app/container"
+	"go_di_architecture/internal/app/wiring"
+	"go_di_architecture/internal/config"
+	"go_di_architecture/internal/domain/models/backup"
+	backupService "go_di_architecture/internal/domain/service/backup"
+	projectionService "go_di_architecture/internal/domain/service/projection"
 )
 
 // @title Module API
@@ -27,15 +40,259 @@ import (
 // @securityDefinitions.apikey ApiKeyAuth
 // @in header
 // @name X-API-Key
+// @description API key configured in AUTH_API_KEYS; grants the scopes configured with it
+//
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description "Bearer <token>" with an HS256 JWT signed with AUTH_JWT_SECRET; the "scope" claim lists the granted scopes (modules:read, modules:write, modules:approve, admin). The scopes an endpoint requires are listed in its security requirements; admin implies every scope.
 //
 // @x-logo {"url": "https://example.com/logo.png", "backgroundColor": "#FFFFFF"}
 
+// shutdownTimeout bounds how long components get to stop gracefully.
+const shutdownTimeout = 10 * time.Second
+
+// application is the common surface of the runtime container and compile-time wiring.
+type application interface {
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+}
+
 func main() {
-	r := gin.Default()
+	di := flag.String("di", "container", "dependency injection mode: container (runtime) or wire (compile-time)")
+	dumpGraph := flag.String("dump-graph", "", "print the dependency graph (json or dot) and exit")
+	verify := flag.Bool("verify", false, "check the dependency wiring without building components and exit")
+	runBackup := flag.Bool("backup", false, "write a backup archive of the module data to object storage and exit")
+	restoreKey := flag.String("restore", "", "restore the module data from the backup archive with this key and exit")
+	conflict := flag.String("conflict", backup.ConflictFail, "conflict policy of -restore: skip, overwrite or fail")
+	replay := flag.Bool("replay", false, "rebuild the module_summaries read model from the change history and exit")
+	flag.Parse()
+
+	// Wire all components
+	var app application
+	switch *di {
+	case "container":
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Printf("[ERROR] Invalid configuration: %v\n", err)
+			os.Exit(1)
+		}
+
+		c, err := bootstrap.NewContainer(cfg)
+		if err != nil {
+			fmt.Printf("[ERROR] Failed to configure container: %v\n", err)
+			os.Exit(1)
+		}
+
+		// Dump the wiring instead of running the server
+		if *dumpGraph != "" {
+			if err := printGraph(c, *dumpGraph); err != nil {
+				fmt.Printf("[ERROR] Failed to dump dependency graph: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+
+		// Check the wiring instead of running the server
+		if *verify {
+			if err := c.Verify(); err != nil {
+				fmt.Printf("[ERROR] %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("[INFO] Wiring of %d components verified\n", len(c.Graph().Nodes))
+			return
+		}
+
+		// Run a one-off backup or restore instead of the server
+		if *runBackup || *restoreKey != "" {
+			if err := runBackupCommand(cfg, c, *restoreKey, *conflict); err != nil {
+				fmt.Printf("[ERROR] %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+
+		// Rebuild the read models instead of running the server
+		if *replay {
+			if err := runReplayCommand(cfg, c); err != nil {
+				fmt.Printf("[ERROR] %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+		app = c
+
+	case "wire":
+		if *runBackup || *restoreKey != "" || *replay || *verify {
+			fmt.Println("[ERROR] -backup, -restore, -replay and -verify need the runtime container (-di container)")
+			os.Exit(1)
+		}
+		wired, err := wiring.InitializeApplication()
+		if err != nil {
+			fmt.Printf("[ERROR] Failed to wire application: %v\n", err)
+			os.Exit(1)
+		}
+		app = wired
+
+	default:
+		fmt.Printf("[ERROR] Unknown dependency injection mode %q\n", *di)
+		os.Exit(1)
+	}
+
+	// Start components in dependency order
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := app.Start(ctx); err != nil {
+		fmt.Printf("[ERROR] Failed to start application: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Wait for a shutdown signal
+	<-ctx.Done()
+
+	// Stop components in reverse order
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := app.Stop(shutdownCtx); err != nil {
+		fmt.Printf("[ERROR] Graceful shutdown failed: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// printGraph writes the dependency graph to stdout.
+//
+// Components are not built, so the output only contains the declared wiring
+// and is safe to pipe into other tools.
+//
+// Parameters:
+//   - app: The configured container
+//   - format: Output format, either "json" or "dot"
+//
+// Returns:
+//   - error: Error if the format is unknown
+func printGraph(app *container.Container, format string) error {
+	graph := app.Graph()
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(graph)
+	case "dot":
+		_, err := fmt.Print(graph.DOT())
+		return err
+	default:
+		return fmt.Errorf("unsupported graph format %q", format)
+	}
+}
+
+// backupActor is recorded in the change history of modules restored from the command line.
+const backupActor = "cli"
+
+// runBackupCommand writes a backup, or restores one when restoreKey is set,
+// and prints the result as JSON.
+//
+// Only the components the backup service depends on are built and started,
+// so the command can run next to a live server sharing the database and the
+// object storage.
+//
+// Parameters:
+//   - cfg: Loaded configuration (the in-memory driver is rejected)
+//   - c: The configured container
+//   - restoreKey: Archive to restore, or empty to write a backup
+//   - conflict: Conflict policy of the restore
+//
+// Returns:
+//   - error: Error if the components cannot start or the operation fails
+func runBackupCommand(cfg *config.Config, c *container.Container, restoreKey, conflict string) error {
+	if cfg.Database.Driver == config.DriverMemory {
+		return fmt.Errorf("backup and restore need a database; DB_DRIVER=%s keeps no data between runs", config.DriverMemory)
+	}
+
+	if err := c.Verify(); err != nil {
+		return err
+	}
+	service, err := container.Resolve[*backupService.BackupService](c, bootstrap.BackupService)
+	if err != nil {
+		return fmt.Errorf("failed to build backup service: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := c.Lifecycle().Start(ctx); err != nil {
+		return fmt.Errorf("failed to start: %w", err)
+	}
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		c.Stop(shutdownCtx)
+	}()
+
+	var result any
+	if restoreKey != "" {
+		result, err = service.Restore(ctx, restoreKey, conflict, backupActor)
+	} else {
+		result, err = service.Backup(ctx)
+	}
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
+
+// runReplayCommand rebuilds the module summaries from the change history.
+//
+// Progress is printed after every batch; the report is written to stdout as
+// JSON once the replay completes. The replay is idempotent, so an
+// interrupted run is simply started again.
+//
+// Parameters:
+//   - cfg: Loaded configuration (the in-memory driver is rejected)
+//   - c: The configured container
+//
+// Returns:
+//   - error: Error if the components cannot start or the replay fails
+func runReplayCommand(cfg *config.Config, c *container.Container) error {
+	if cfg.Database.Driver == config.DriverMemory {
+		return fmt.Errorf("replay needs a database; DB_DRIVER=%s keeps no history between runs", config.DriverMemory)
+	}
+
+	if err := c.Verify(); err != nil {
+		return err
+	}
+	service, err := container.Resolve[*projectionService.ProjectionService](c, bootstrap.ProjectionService)
+	if err != nil {
+		return fmt.Errorf("failed to build projection service: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := c.Lifecycle().Start(ctx); err != nil {
+		return fmt.Errorf("failed to start: %w", err)
+	}
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		c.Stop(shutdownCtx)
+	}()
 
-	// Setup routes
-	router.SetupRouter(r)
+	// Progress goes to stderr, so stdout holds only the report
+	report, err := service.RebuildSummaries(ctx, func(p projectionService.Progress) {
+		percent := 100.0
+		if p.Total > 0 && p.Replayed < p.Total {
+			percent = float64(p.Replayed) * 100 / float64(p.Total)
+		}
+		fmt.Fprintf(os.Stderr, "[INFO] Replayed %d/%d revisions (%.0f%%) of %d modules\n", p.Replayed, p.Total, percent, p.Modules)
+	})
+	if err != nil {
+		return fmt.Errorf("replay stopped after %d revisions: %w", report.Revisions, err)
+	}
 
-	// Run the server
-	r.Run(":8080")
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
 }
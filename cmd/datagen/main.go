@@ -0,0 +1,103 @@
+// Command datagen fills a database with a large synthetic dataset.
+//
+// It creates modules with realistic names and descriptions, creation times
+// spread over a period and a skewed set of tags, for performance testing of
+// pagination, search and export against realistic table sizes:
+//
+//	DB_DRIVER=postgres DB_DSN=... datagen -count 100000 -tags 20 -span 17520h
+//
+// The database settings come from the environment like for cmd/api; the
+// migrations run first, so an empty database works. The in-memory driver is
+// refused, since the data would be gone when the command exits. The result
+// is printed as JSON.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"go_di_architecture/internal/app/bootstrap"
+	"go_di_architecture/internal/app/container"
+	"go_di_architecture/internal/app/seed"
+	"go_di_architecture/internal/config"
+	moduleService "go_di_architecture/internal/domain/service/module"
+	tagService "go_di_architecture/internal/domain/service/tag"
+)
+
+// shutdownTimeout bounds how long components get to stop gracefully.
+const shutdownTimeout = 10 * time.Second
+
+func main() {
+	count := flag.Int("count", 10000, "number of modules to create")
+	tags := flag.Int("tags", 20, "number of distinct tags attached to the modules (0 attaches none)")
+	span := flag.Duration("span", 2*365*24*time.Hour, "period before now the creation times are spread over")
+	randomSeed := flag.Uint64("seed", 1, "seed of the random choices; the same seed creates the same dataset")
+	flag.Parse()
+
+	if err := run(seed.GenerateOptions{Count: *count, Tags: *tags, Span: *span, Seed: *randomSeed}); err != nil {
+		fmt.Printf("[ERROR] %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run builds the components the generator depends on, generates the dataset
+// and prints the result as JSON.
+//
+// Only those components are built and started, so the command can run next
+// to a live server sharing the database.
+//
+// Parameters:
+//   - opts: Size and shape of the dataset
+//
+// Returns:
+//   - error: Error if the configuration is invalid, the components cannot
+//     start or the generation fails
+func run(opts seed.GenerateOptions) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+	if cfg.Database.Driver == config.DriverMemory {
+		return fmt.Errorf("datagen needs a database; DB_DRIVER=%s keeps no data between runs", config.DriverMemory)
+	}
+
+	c, err := bootstrap.NewContainer(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to configure container: %w", err)
+	}
+	modules, err := container.Resolve[*moduleService.ModuleService](c, bootstrap.ModuleService)
+	if err != nil {
+		return fmt.Errorf("failed to build module service: %w", err)
+	}
+	tagRepo, err := container.Resolve[tagService.TagRepository](c, bootstrap.TagRepository)
+	if err != nil {
+		return fmt.Errorf("failed to build tag repository: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := c.Lifecycle().Start(ctx); err != nil {
+		return fmt.Errorf("failed to start: %w", err)
+	}
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		c.Stop(shutdownCtx)
+	}()
+
+	result, err := seed.NewGenerator(modules, tagRepo).Generate(ctx, opts)
+	if result != nil {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if encodeErr := encoder.Encode(result); encodeErr != nil && err == nil {
+			err = encodeErr
+		}
+	}
+	return err
+}
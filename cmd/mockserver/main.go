@@ -0,0 +1,122 @@
+// Command mockserver serves the API for consumer development and testing.
+//
+// It runs the same routes, handlers and response envelopes as cmd/api, but
+// backed entirely by the in-memory repository with sample modules, so
+// front-end teams can develop against realistic responses without a
+// database. Latency and canned errors are injected with the chaos rules of
+// the API (see middleware.ParseChaosRules):
+//
+//	mockserver -latency 50ms-400ms -faults "POST /api/v1/modules=fail:RESOURCE_CONFLICT@0.2;GET /api/v1/modules/:id=error:0.05"
+//
+// The remaining settings come from the environment like for cmd/api, with
+// the development profile (APP_ENV=development) by default; the production
+// profile is refused. Without AUTH_API_KEYS and AUTH_JWT_SECRET every
+// request is granted AUTH_ANONYMOUS_SCOPES, all scopes in development.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"go_di_architecture/internal/app/bootstrap"
+	"go_di_architecture/internal/config"
+	"go_di_architecture/internal/middleware"
+)
+
+// shutdownTimeout bounds how long components get to stop gracefully.
+const shutdownTimeout = 10 * time.Second
+
+func main() {
+	latency := flag.String("latency", "", "delay added to every response, fixed (200ms) or a range (50ms-400ms)")
+	faults := flag.String("faults", "", "chaos rules injecting errors per route, e.g. \"POST /api/v1/modules=fail:RESOURCE_CONFLICT@0.5\"")
+	seed := flag.Bool("seed", true, "create the sample modules on start")
+	flag.Parse()
+
+	cfg, err := mockConfig(*latency, *faults, *seed)
+	if err != nil {
+		fmt.Printf("[ERROR] Invalid configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	c, err := bootstrap.NewContainer(cfg)
+	if err != nil {
+		fmt.Printf("[ERROR] Failed to configure container: %v\n", err)
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := c.Start(ctx); err != nil {
+		fmt.Printf("[ERROR] Failed to start mock server: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("[INFO] Mock server ready: in-memory data, %d chaos rule(s)\n", len(cfg.Chaos.Rules))
+
+	<-ctx.Done()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := c.Stop(shutdownCtx); err != nil {
+		fmt.Printf("[ERROR] Graceful shutdown failed: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// mockConfig loads the configuration and replaces the storage, notification
+// and fault settings with those of the mock server.
+//
+// Parameters:
+//   - latency: Delay of every response, e.g. "50ms-400ms" (empty adds none)
+//   - faults: Chaos rules of individual routes (empty injects none)
+//   - seed: Whether to create the sample modules
+//
+// Returns:
+//   - *config.Config: The configuration of the mock server
+//   - error: Error if the environment or a flag is invalid
+func mockConfig(latency, faults string, seed bool) (*config.Config, error) {
+	// Step 1: Default to the development profile
+	if os.Getenv("APP_ENV") == "" {
+		os.Setenv("APP_ENV", config.EnvDevelopment)
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Environment == config.EnvProduction {
+		return nil, errors.New("the mock server cannot run with APP_ENV=production")
+	}
+
+	// Step 2: Keep everything in memory and deliver nothing
+	cfg.Database.Driver = config.DriverMemory
+	cfg.Notifications.Fake = true
+	cfg.Seed.Enabled = seed
+
+	// Step 3: Route faults first, since the first matching rule wins, then
+	// the latency of every other route
+	rules, err := middleware.ParseChaosRules(faults)
+	if err != nil {
+		return nil, fmt.Errorf("invalid -faults: %w", err)
+	}
+	if latency != "" {
+		all, err := middleware.ParseChaosRules("*=latency:" + latency)
+		if err != nil {
+			return nil, fmt.Errorf("invalid -latency: %w", err)
+		}
+		for i := range rules {
+			if rules[i].MinLatency == 0 && rules[i].MaxLatency == 0 {
+				rules[i].MinLatency, rules[i].MaxLatency = all[0].MinLatency, all[0].MaxLatency
+			}
+		}
+		rules = append(rules, all...)
+	}
+	cfg.Chaos.Rules = append(rules, cfg.Chaos.Rules...)
+	return cfg, nil
+}
@@ -0,0 +1,36 @@
+package adminui
+
+import (
+	"embed"
+	"io/fs"
+)
+
+// static holds the dashboard page and its assets.
+//
+//go:embed static
+var static embed.FS
+
+// Assets returns the files of the admin dashboard.
+//
+// The dashboard is a single page (index.html) with its script and style
+// sheet. It holds no data itself: the script reads everything from the API
+// in the browser, with the API key or bearer token the operator enters:
+//   - GET /health and GET /ready: Liveness and readiness
+//   - GET /admin/api/overview: Errors, slow requests, objectives, caches,
+//     queues and jobs
+//   - GET /admin/metrics: Memory and outbound HTTP client counters
+//   - GET /api/v1/modules/stats: Module statistics
+//
+// The credentials are kept in the session storage of the browser tab and
+// sent as X-API-Key or Authorization header, never as a cookie, so the page
+// adds no cross-site request risk to the admin API.
+//
+// Returns:
+//   - fs.FS: The files, index.html at the root
+func Assets() fs.FS {
+	assets, err := fs.Sub(static, "static")
+	if err != nil {
+		panic(err)
+	}
+	return assets
+}
@@ -0,0 +1,282 @@
+// Admin dashboard: reads the health, admin and module statistics endpoints
+// with the credential entered by the operator and renders them as plain DOM
+// (text only, never HTML from responses).
+"use strict";
+
+const REFRESH_INTERVAL_MS = 10000;
+const STORAGE_KEY = "admin-dashboard-credential";
+
+let timer = null;
+
+// credential returns the saved scheme and secret of this browser tab.
+function credential() {
+  try {
+    return JSON.parse(sessionStorage.getItem(STORAGE_KEY)) || { scheme: "apikey", secret: "" };
+  } catch (e) {
+    return { scheme: "apikey", secret: "" };
+  }
+}
+
+// request fetches an endpoint and returns its payload, unwrapping the API
+// envelope; failures throw with the status and error code.
+async function request(path, enveloped = true) {
+  const { scheme, secret } = credential();
+  const headers = { Accept: "application/json" };
+  if (secret && scheme === "bearer") {
+    headers.Authorization = "Bearer " + secret;
+  } else if (secret) {
+    headers["X-API-Key"] = secret;
+  }
+
+  const response = await fetch(path, { headers, cache: "no-store" });
+  let body = null;
+  try {
+    body = await response.json();
+  } catch (e) {
+    // Non-JSON bodies are reported by status only
+  }
+  if (!response.ok && !(path === "/ready" && response.status === 503)) {
+    const code = body && body.error && body.error.code;
+    const error = new Error(response.status + (code ? " " + code : ""));
+    error.status = response.status;
+    throw error;
+  }
+  return enveloped && body && "data" in body ? body.data : body;
+}
+
+// el creates an element with text content and optional class.
+function el(tag, text, className) {
+  const node = document.createElement(tag);
+  if (text !== undefined && text !== null) {
+    node.textContent = String(text);
+  }
+  if (className) {
+    node.className = className;
+  }
+  return node;
+}
+
+function card(label, value, className) {
+  const node = el("div", null, "card");
+  node.append(el("div", label, "label"), el("div", value, "value " + (className || "")));
+  return node;
+}
+
+// table renders rows as a table with the given columns: [header, row => cell].
+function table(columns, rows, empty) {
+  if (!rows || rows.length === 0) {
+    return el("p", empty || "None", "muted");
+  }
+  const node = el("table");
+  const head = el("tr");
+  columns.forEach(([header]) => head.append(el("th", header)));
+  node.append(head);
+  rows.forEach((row) => {
+    const tr = el("tr");
+    columns.forEach(([, cell]) => {
+      const value = cell(row);
+      const td = el("td");
+      if (value instanceof Node) {
+        td.append(value);
+      } else {
+        td.textContent = value === undefined || value === null ? "" : String(value);
+      }
+      tr.append(td);
+    });
+    node.append(tr);
+  });
+  return node;
+}
+
+function section(id) {
+  const node = document.getElementById(id);
+  return { cards: node.querySelector(".cards"), content: node.querySelector(".content") };
+}
+
+function replace(container, ...children) {
+  if (container) {
+    container.replaceChildren(...children);
+  }
+}
+
+function time(value) {
+  return value ? new Date(value).toLocaleString() : "never";
+}
+
+function bytes(value) {
+  const units = ["B", "KiB", "MiB", "GiB"];
+  let unit = 0;
+  while (value >= 1024 && unit < units.length - 1) {
+    value /= 1024;
+    unit++;
+  }
+  return value.toFixed(unit === 0 ? 0 : 1) + " " + units[unit];
+}
+
+function failed(id, error) {
+  const { cards, content } = section(id);
+  replace(cards);
+  const message = error.status === 401 || error.status === 403
+    ? "Not authorized (" + error.message + "): enter an admin credential."
+    : "Unavailable: " + error.message;
+  replace(content || cards, el("p", message, "bad"));
+}
+
+async function renderHealth() {
+  const { cards } = section("health");
+  const [health, ready] = await Promise.allSettled([request("/health", false), request("/ready", false)]);
+  const status = (result) => (result.status === "fulfilled" ? result.value.status : "unreachable");
+  const live = status(health);
+  const readiness = status(ready);
+  replace(cards,
+    card("Liveness", live, live === "ok" ? "ok" : "bad"),
+    card("Readiness", readiness, readiness === "ready" ? "ok" : "bad"));
+}
+
+async function renderModules() {
+  const { cards, content } = section("modules");
+  const stats = await request("/api/v1/modules/stats");
+  replace(cards,
+    card("Total", stats.totalModules),
+    card("Active", stats.activeModules, "ok"),
+    card("Inactive", stats.inactiveModules, "muted"));
+
+  const days = stats.createdPerDay || [];
+  const max = Math.max(1, ...days.map((day) => day.count));
+  const bar = (count) => {
+    const node = el("span");
+    node.append(el("span", null, "bar"), document.createTextNode(count));
+    node.firstChild.style.width = (count / max) * 12 + "rem";
+    return node;
+  };
+  replace(content,
+    el("h3", "Created per day"),
+    table([["Date", (day) => day.date], ["Modules", (day) => bar(day.count)]], days),
+    el("p", "Computed " + time(stats.generatedAt), "muted"));
+}
+
+async function renderMetrics() {
+  const { cards, content } = section("metrics");
+  const metrics = await request("/admin/metrics", false);
+  const memory = metrics.memstats || {};
+  replace(cards,
+    card("Heap in use", bytes(memory.HeapInuse || 0)),
+    card("Allocated", bytes(memory.Alloc || 0)),
+    card("System", bytes(memory.Sys || 0)),
+    card("GC cycles", memory.NumGC || 0));
+
+  const clients = Object.entries(metrics.http_client || {});
+  replace(content,
+    el("h3", "Outbound HTTP clients"),
+    table([
+      ["Client", ([name]) => name],
+      ["Requests", ([, c]) => c.requests || 0],
+      ["Retries", ([, c]) => c.retries || 0],
+      ["Errors", ([, c]) => c.errors || 0],
+      ["Rejected", ([, c]) => c.rejected || 0],
+      ["5xx", ([, c]) => c["5xx"] || 0],
+    ], clients, "No outbound calls yet"));
+}
+
+function requestTable(requests, empty) {
+  return table([
+    ["Finished", (r) => time(r.finishedAt)],
+    ["Request", (r) => r.method + " " + (r.route || r.path)],
+    ["Status", (r) => r.status],
+    ["Duration", (r) => r.durationMs.toFixed(1) + " ms"],
+    ["Error", (r) => r.error],
+    ["Request ID", (r) => r.requestId],
+  ], requests, empty);
+}
+
+async function renderOverview() {
+  const overview = await request("/admin/api/overview");
+
+  const queues = section("queues");
+  const jobs = overview.backgroundJobs;
+  replace(queues.cards,
+    card("Jobs queued", jobs.queued),
+    card("Jobs running", jobs.running),
+    card("Jobs succeeded", jobs.succeeded, "ok"),
+    card("Jobs failed", jobs.failed, jobs.failed > 0 ? "bad" : ""));
+  replace(queues.content,
+    table([
+      ["Queue", (q) => q.name],
+      ["Depth", (q) => q.depth],
+      ["Capacity", (q) => (q.capacity > 0 ? q.capacity : "unbounded")],
+      ["Running", (q) => q.running],
+    ], overview.queues, "No queues"),
+    el("h3", "Periodic jobs"),
+    table([
+      ["Job", (j) => j.name],
+      ["Every", (j) => j.interval],
+      ["Runs", (j) => j.runs],
+      ["Failures", (j) => j.failures],
+      ["Last run", (j) => time(j.lastRunAt) + (j.running ? " (running)" : "")],
+      ["Last success", (j) => time(j.lastSuccessAt)],
+      ["Last error", (j) => j.lastError],
+    ], overview.scheduledJobs, "No periodic jobs"));
+
+  const burn = (window, key) => {
+    const rate = window ? window[key] : 0;
+    return el("span", rate.toFixed(2), rate > 1 ? "bad" : "");
+  };
+  const windowOf = (o, name) => o.windows.find((w) => w.window === name);
+  replace(section("objectives").content, table([
+    ["Route", (o) => o.method + " " + o.route],
+    ["Objective", (o) => (o.latencyMs > 0 ? (o.latencyTarget * 100) + "% < " + o.latencyMs + " ms, " : "") +
+      (o.availabilityTarget * 100) + "% no 5xx"],
+    ["Requests (1h)", (o) => windowOf(o, "1h").requests],
+    ["Errors burn 5m", (o) => burn(windowOf(o, "5m"), "availabilityBurnRate")],
+    ["Errors burn 1h", (o) => burn(windowOf(o, "1h"), "availabilityBurnRate")],
+    ["Latency burn 5m", (o) => burn(windowOf(o, "5m"), "latencyBurnRate")],
+    ["Latency burn 1h", (o) => burn(windowOf(o, "1h"), "latencyBurnRate")],
+  ], overview.objectives, "No requests in the last hour"));
+
+  replace(section("caches").content, table([
+    ["Cache", (c) => c.name],
+    ["Counters", (c) => Object.entries(c.stats).map(([k, v]) => k + ": " + v).join(", ")],
+  ], overview.caches, "No caches"));
+
+  replace(section("errors").content, requestTable(overview.errors, "No server errors"));
+  replace(section("slow").content, requestTable(overview.slowRequests, "No slow requests"));
+}
+
+async function refresh() {
+  const results = await Promise.allSettled([
+    renderHealth(),
+    renderModules().catch((error) => failed("modules", error)),
+    renderMetrics().catch((error) => failed("metrics", error)),
+    renderOverview().catch((error) => ["queues", "objectives", "caches", "errors", "slow"].forEach((id) => failed(id, error))),
+  ]);
+  const broken = results.filter((result) => result.status === "rejected").length;
+  document.getElementById("status").textContent =
+    "Updated " + new Date().toLocaleTimeString() + (broken ? " with " + broken + " errors" : "");
+}
+
+function schedule() {
+  clearInterval(timer);
+  timer = null;
+  if (document.getElementById("autorefresh").checked) {
+    timer = setInterval(refresh, REFRESH_INTERVAL_MS);
+  }
+}
+
+document.addEventListener("DOMContentLoaded", () => {
+  const saved = credential();
+  document.getElementById("scheme").value = saved.scheme;
+  document.getElementById("secret").value = saved.secret;
+
+  document.getElementById("credentials").addEventListener("submit", (event) => {
+    event.preventDefault();
+    sessionStorage.setItem(STORAGE_KEY, JSON.stringify({
+      scheme: document.getElementById("scheme").value,
+      secret: document.getElementById("secret").value,
+    }));
+    refresh();
+  });
+  document.getElementById("autorefresh").addEventListener("change", schedule);
+
+  refresh();
+  schedule();
+});
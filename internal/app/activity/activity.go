@@ -0,0 +1,134 @@
+package activity
+
+import (
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"go_di_architecture/internal/domain/models/dashboard"
+)
+
+// DefaultCapacity is the number of requests kept per list.
+const DefaultCapacity = 100
+
+// DefaultSlowThreshold is the duration from which a request counts as slow.
+const DefaultSlowThreshold = time.Second
+
+// Recorder keeps the most recent server errors and slow requests, and the
+// compliance of the routes with their service level objectives (see
+// DeclareObjective).
+//
+// Both lists hold at most their capacity, dropping the oldest requests first,
+// so the memory used stays constant; objectives keep an hour of per-minute
+// counts per route. Everything is kept per instance and lost on restart; the
+// access log remains the complete record.
+//
+// Usage Example:
+//
+//	recorder := activity.NewRecorder(activity.DefaultCapacity, 2*time.Second)
+//	engine.Use(middleware.ActivityHandler(recorder))
+//	recent := recorder.Errors()
+type Recorder struct {
+	capacity      int
+	slowThreshold time.Duration
+
+	mu     sync.Mutex
+	errors []dashboard.Request
+	slow   []dashboard.Request
+
+	objectives objectives
+}
+
+// NewRecorder creates an empty recorder.
+//
+// Parameters:
+//   - capacity: Requests kept per list (DefaultCapacity when not positive)
+//   - slowThreshold: Duration from which a request counts as slow
+//     (DefaultSlowThreshold when not positive)
+//
+// Returns:
+//   - *Recorder: A new recorder
+func NewRecorder(capacity int, slowThreshold time.Duration) *Recorder {
+	if capacity <= 0 {
+		capacity = DefaultCapacity
+	}
+	if slowThreshold <= 0 {
+		slowThreshold = DefaultSlowThreshold
+	}
+	return &Recorder{
+		capacity:      capacity,
+		slowThreshold: slowThreshold,
+		objectives:    objectives{routes: make(map[string]*routeObjective)},
+	}
+}
+
+// Record counts a finished request against the objective of its route and
+// keeps it if it failed with a server error or was slow.
+//
+// Parameters:
+//   - request: The finished request
+func (r *Recorder) Record(request dashboard.Request) {
+	r.objectives.observe(request)
+
+	serverError := request.Status >= http.StatusInternalServerError
+	slow := time.Duration(request.DurationMs*float64(time.Millisecond)) >= r.slowThreshold
+	if !serverError && !slow {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if serverError {
+		r.errors = r.keep(r.errors, request)
+	}
+	if slow {
+		r.slow = r.keep(r.slow, request)
+	}
+}
+
+// Errors returns the kept server errors.
+//
+// Returns:
+//   - []dashboard.Request: The requests, newest first
+func (r *Recorder) Errors() []dashboard.Request {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return newestFirst(r.errors)
+}
+
+// SlowRequests returns the kept slow requests.
+//
+// Returns:
+//   - []dashboard.Request: The requests, newest first
+func (r *Recorder) SlowRequests() []dashboard.Request {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return newestFirst(r.slow)
+}
+
+// SlowThreshold returns the duration from which a request counts as slow.
+func (r *Recorder) SlowThreshold() time.Duration {
+	return r.slowThreshold
+}
+
+// keep appends a request to a list, dropping the oldest beyond the capacity;
+// the caller must hold the lock.
+func (r *Recorder) keep(list []dashboard.Request, request dashboard.Request) []dashboard.Request {
+	list = append(list, request)
+	if len(list) > r.capacity {
+		list = slices.Delete(list, 0, len(list)-r.capacity)
+	}
+	return list
+}
+
+// newestFirst returns a reversed copy of a list kept oldest first.
+func newestFirst(list []dashboard.Request) []dashboard.Request {
+	reversed := slices.Clone(list)
+	slices.Reverse(reversed)
+	if reversed == nil {
+		reversed = []dashboard.Request{}
+	}
+	return reversed
+}
@@ -0,0 +1,221 @@
+package activity
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"go_di_architecture/internal/domain/models/dashboard"
+)
+
+// Objective is the service level objective (SLO) of a route: the share of
+// its requests to answer without a server error and the share to answer
+// within a latency.
+//
+// The rest of each share is the error budget of the route. The burn rate
+// reports how fast a window of requests spends it: 1 spends exactly the
+// budget, 14.4 over an hour spends 2% of a 30-day budget, the usual
+// threshold for paging.
+type Objective struct {
+	// Duration within which a request counts as answered in time (zero
+	// tracks no latency, e.g. for streamed responses)
+	Latency time.Duration
+
+	// Share of requests to answer within Latency, e.g. 0.99
+	LatencyTarget float64
+
+	// Share of requests to answer without a server error, e.g. 0.999
+	AvailabilityTarget float64
+}
+
+// DefaultObjective applies to the routes of the versioned and public API
+// that declare no objective of their own.
+var DefaultObjective = Objective{Latency: 500 * time.Millisecond, LatencyTarget: 0.99, AvailabilityTarget: 0.999}
+
+// Validate checks that the targets are shares between 0 and 1, excluding
+// both: a target of 1 leaves no budget to burn.
+//
+// Returns:
+//   - error: Error naming the invalid target
+func (o Objective) Validate() error {
+	if o.AvailabilityTarget <= 0 || o.AvailabilityTarget >= 1 {
+		return fmt.Errorf("availability target %v outside (0, 1)", o.AvailabilityTarget)
+	}
+	if o.Latency < 0 {
+		return fmt.Errorf("negative latency %s", o.Latency)
+	}
+	if o.Latency > 0 && (o.LatencyTarget <= 0 || o.LatencyTarget >= 1) {
+		return fmt.Errorf("latency target %v outside (0, 1)", o.LatencyTarget)
+	}
+	return nil
+}
+
+// objectiveWindows are the windows burn rates are reported over: the short
+// one shows a fast burn going on, the long one a slow burn adding up.
+var objectiveWindows = []struct {
+	name    string
+	minutes int64
+}{
+	{"5m", 5},
+	{"1h", 60},
+}
+
+// objectiveBuckets is the number of one-minute buckets kept per route,
+// enough for the longest window.
+const objectiveBuckets = 60
+
+// objectiveBucket counts the requests of a route finished in one minute.
+type objectiveBucket struct {
+	minute       int64
+	requests     int64
+	serverErrors int64
+	slow         int64
+}
+
+// routeObjective tracks the compliance of one route.
+type routeObjective struct {
+	method    string
+	route     string
+	objective Objective
+	buckets   [objectiveBuckets]objectiveBucket
+}
+
+// objectives tracks the compliance of the routes with an objective.
+type objectives struct {
+	mu     sync.Mutex
+	routes map[string]*routeObjective
+}
+
+// DeclareObjective tracks a route against an objective; declaring a route
+// again replaces its objective and keeps its counts.
+//
+// Parameters:
+//   - method: HTTP method of the route
+//   - route: Route pattern as gin reports it, e.g. "/api/v1/modules/:id"
+//   - objective: Objective of the route, assumed valid (see Validate)
+func (r *Recorder) DeclareObjective(method, route string, objective Objective) {
+	r.objectives.mu.Lock()
+	defer r.objectives.mu.Unlock()
+
+	key := method + " " + route
+	if tracked, ok := r.objectives.routes[key]; ok {
+		tracked.objective = objective
+		return
+	}
+	r.objectives.routes[key] = &routeObjective{method: method, route: route, objective: objective}
+}
+
+// Objectives reports the compliance of the routes that served requests
+// within the longest window.
+//
+// Returns:
+//   - []dashboard.RouteObjective: The routes, fastest burning first
+func (r *Recorder) Objectives() []dashboard.RouteObjective {
+	now := time.Now().Unix() / 60
+
+	r.objectives.mu.Lock()
+	defer r.objectives.mu.Unlock()
+
+	report := []dashboard.RouteObjective{}
+	for _, tracked := range r.objectives.routes {
+		windows := make([]dashboard.ObjectiveWindow, len(objectiveWindows))
+		for i, window := range objectiveWindows {
+			windows[i] = tracked.window(window.name, now-window.minutes, now)
+		}
+		if windows[len(windows)-1].Requests == 0 {
+			continue
+		}
+
+		status := dashboard.RouteObjective{
+			Method:             tracked.method,
+			Route:              tracked.route,
+			AvailabilityTarget: tracked.objective.AvailabilityTarget,
+			Windows:            windows,
+		}
+		if tracked.objective.Latency > 0 {
+			status.LatencyMs = float64(tracked.objective.Latency.Microseconds()) / 1000
+			status.LatencyTarget = tracked.objective.LatencyTarget
+		}
+		report = append(report, status)
+	}
+
+	slices.SortFunc(report, func(a, b dashboard.RouteObjective) int {
+		return cmp.Or(
+			cmp.Compare(maxBurnRate(b), maxBurnRate(a)),
+			cmp.Compare(a.Route, b.Route),
+			cmp.Compare(a.Method, b.Method),
+		)
+	})
+	return report
+}
+
+// observe counts a finished request against the objective of its route.
+func (o *objectives) observe(request dashboard.Request) {
+	if request.Route == "" {
+		return
+	}
+	minute := request.FinishedAt.Unix() / 60
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	tracked, ok := o.routes[request.Method+" "+request.Route]
+	if !ok {
+		return
+	}
+	bucket := &tracked.buckets[minute%objectiveBuckets]
+	if bucket.minute != minute {
+		*bucket = objectiveBucket{minute: minute}
+	}
+	bucket.requests++
+	if request.Status >= http.StatusInternalServerError {
+		bucket.serverErrors++
+	}
+	latency := tracked.objective.Latency
+	if latency > 0 && time.Duration(request.DurationMs*float64(time.Millisecond)) > latency {
+		bucket.slow++
+	}
+}
+
+// window sums the buckets of the minutes after from up to and including to;
+// the caller must hold the lock.
+func (t *routeObjective) window(name string, from, to int64) dashboard.ObjectiveWindow {
+	var requests, serverErrors, slow int64
+	for _, bucket := range t.buckets {
+		if bucket.minute > from && bucket.minute <= to {
+			requests += bucket.requests
+			serverErrors += bucket.serverErrors
+			slow += bucket.slow
+		}
+	}
+
+	window := dashboard.ObjectiveWindow{Window: name, Requests: requests, ServerErrors: serverErrors, SlowRequests: slow}
+	if requests == 0 {
+		return window
+	}
+	window.AvailabilityBurnRate = burnRate(serverErrors, requests, t.objective.AvailabilityTarget)
+	if t.objective.Latency > 0 {
+		window.LatencyBurnRate = burnRate(slow, requests, t.objective.LatencyTarget)
+	}
+	return window
+}
+
+// burnRate divides the share of bad requests by the error budget, rounded
+// to three decimals.
+func burnRate(bad, requests int64, target float64) float64 {
+	rate := float64(bad) / float64(requests) / (1 - target)
+	return math.Round(rate*1000) / 1000
+}
+
+// maxBurnRate returns the highest burn rate of a route in any window.
+func maxBurnRate(status dashboard.RouteObjective) float64 {
+	highest := 0.0
+	for _, window := range status.Windows {
+		highest = max(highest, window.AvailabilityBurnRate, window.LatencyBurnRate)
+	}
+	return highest
+}
@@ -0,0 +1,191 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Scopes granted to principals and required by routes
+const (
+	// ScopeModulesRead allows reading modules and everything attached to them
+	ScopeModulesRead = "modules:read"
+
+	// ScopeModulesWrite allows creating, changing and deleting modules and
+	// everything attached to them
+	ScopeModulesWrite = "modules:write"
+
+	// ScopeModulesApprove allows approving and rejecting modules submitted
+	// for approval
+	ScopeModulesApprove = "modules:approve"
+
+	// ScopeAdmin allows the operational routes and implies every other scope
+	ScopeAdmin = "admin"
+)
+
+// IsScope reports whether the value names a supported scope.
+//
+// Parameters:
+//   - scope: Scope name to check
+//
+// Returns:
+//   - bool: True for the Scope constants
+func IsScope(scope string) bool {
+	switch scope {
+	case ScopeModulesRead, ScopeModulesWrite, ScopeModulesApprove, ScopeAdmin:
+		return true
+	}
+	return false
+}
+
+// principalKey is the Gin context key of the authenticated principal.
+const principalKey = "auth.principal"
+
+// Policy errors
+var (
+	ErrUnauthenticated   = errors.New("authentication required")
+	ErrInsufficientScope = errors.New("insufficient scope")
+)
+
+// InsufficientScopeError reports the scopes a principal lacks for a route.
+//
+// The error wraps ErrInsufficientScope; Missing lists the required scopes the
+// principal was not granted, so clients know which credentials to request.
+type InsufficientScopeError struct {
+	// Required scopes not granted to the principal
+	Missing []string
+}
+
+// Error returns the message of the wrapped ErrInsufficientScope and the missing scopes.
+func (e *InsufficientScopeError) Error() string {
+	return ErrInsufficientScope.Error() + ": requires " + strings.Join(e.Missing, ", ")
+}
+
+// Unwrap exposes ErrInsufficientScope to errors.Is.
+func (e *InsufficientScopeError) Unwrap() error {
+	return ErrInsufficientScope
+}
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	// Name identifying the caller (token subject or API key name)
+	Name string
+
+	// Name of the API key the caller authenticated with (empty for tokens)
+	APIKey string
+
+	// Scopes granted to the caller
+	Scopes []string
+
+	// Teams the caller belongs to, matched against "team:<name>" entries of
+	// module access control lists
+	Teams []string
+
+	// Whether the caller is the anonymous principal of an API without
+	// authentication, which identifies itself through request headers
+	Anonymous bool
+}
+
+// AnonymousScopes are the scopes of the anonymous principal unless
+// configured otherwise: reading, but no changes.
+var AnonymousScopes = []string{ScopeModulesRead}
+
+// NewAnonymous creates the principal of every request when no authenticator
+// is configured.
+//
+// Parameters:
+//   - scopes: Scopes granted to every request (nil grants AnonymousScopes)
+//
+// Returns:
+//   - *Principal: The anonymous principal
+func NewAnonymous(scopes []string) *Principal {
+	if scopes == nil {
+		scopes = AnonymousScopes
+	}
+	return &Principal{Name: "anonymous", Scopes: scopes, Anonymous: true}
+}
+
+// HasScope reports whether the principal was granted a scope.
+//
+// Parameters:
+//   - scope: Scope to check
+//
+// Returns:
+//   - bool: True if the scope or the admin scope was granted
+func (p *Principal) HasScope(scope string) bool {
+	for _, granted := range p.Scopes {
+		if granted == scope || granted == ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// Authenticator identifies the principal of a request from its credentials.
+type Authenticator interface {
+	// Authenticate returns the principal of the request, nil when the request
+	// carries no credentials, or an error when the credentials are invalid
+	Authenticate(r *http.Request) (*Principal, error)
+}
+
+// Policy declares what a route requires of its caller.
+//
+// Usage Example:
+//
+//	policy := auth.Policy{Scopes: []string{auth.ScopeModulesWrite}}
+//	if err := policy.Authorize(principal); err != nil { ... }
+type Policy struct {
+	// Scopes the principal must all hold
+	Scopes []string
+}
+
+// Authorize checks a principal against the policy.
+//
+// Parameters:
+//   - principal: The caller, or nil for unauthenticated requests
+//
+// Returns:
+//   - error: Error if the principal does not satisfy the policy
+//
+// Error Types:
+//   - ErrUnauthenticated: When there is no principal
+//   - *InsufficientScopeError: When required scopes are missing
+func (p Policy) Authorize(principal *Principal) error {
+	if principal == nil {
+		return ErrUnauthenticated
+	}
+
+	var missing []string
+	for _, scope := range p.Scopes {
+		if !principal.HasScope(scope) {
+			missing = append(missing, scope)
+		}
+	}
+	if len(missing) > 0 {
+		return &InsufficientScopeError{Missing: missing}
+	}
+	return nil
+}
+
+// SetPrincipal attaches the authenticated principal to a request.
+//
+// Parameters:
+//   - ctx: Gin context for the request
+//   - principal: The authenticated caller
+func SetPrincipal(ctx *gin.Context, principal *Principal) {
+	ctx.Set(principalKey, principal)
+}
+
+// PrincipalFrom returns the principal attached to a request.
+//
+// Parameters:
+//   - ctx: Gin context for the request
+//
+// Returns:
+//   - *Principal: The authenticated caller, or nil when unauthenticated
+func PrincipalFrom(ctx *gin.Context) *Principal {
+	principal, _ := ctx.Value(principalKey).(*Principal)
+	return principal
+}
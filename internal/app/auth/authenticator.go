@@ -0,0 +1,262 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// APIKeyHeader names the header carrying an API key.
+const APIKeyHeader = "X-API-Key"
+
+// ErrInvalidCredentials is returned for malformed, forged or expired credentials.
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+// APIKey is a static credential granting a set of scopes.
+type APIKey struct {
+	// Name identifying the key holder
+	Name string
+
+	// Secret sent in the X-API-Key header
+	Key string
+
+	// Scopes granted to the key holder
+	Scopes []string
+
+	// Teams the key holder belongs to
+	Teams []string
+}
+
+// ParseAPIKeys parses API keys of the form "ci=modules:read,modules:write@s3cr3t;ops=admin@t0p".
+//
+// Entries are separated by semicolons. Each entry names the holder, lists the
+// granted scopes comma-separated and ends with the key after the first "@".
+//
+// Parameters:
+//   - spec: The key specification (empty for none)
+//
+// Returns:
+//   - []APIKey: The keys in specification order
+//   - error: Error naming the first malformed entry
+func ParseAPIKeys(spec string) ([]APIKey, error) {
+	var keys []APIKey
+	names := make(map[string]bool)
+	secrets := make(map[string]bool)
+	for _, entry := range strings.Split(spec, ";") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+
+		name, rest, ok := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		scopeList, key, hasKey := strings.Cut(rest, "@")
+		if !ok || !hasKey || name == "" || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("key %q must look like <name>=<scope>,<scope>@<key>", name)
+		}
+		if names[name] {
+			return nil, fmt.Errorf("key %q is configured twice", name)
+		}
+		names[name] = true
+		key = strings.TrimSpace(key)
+		if secrets[key] {
+			return nil, fmt.Errorf("key %q reuses the secret of another key", name)
+		}
+		secrets[key] = true
+
+		apiKey := APIKey{Name: name, Key: key}
+		for _, scope := range strings.Split(scopeList, ",") {
+			if scope = strings.TrimSpace(scope); scope == "" {
+				continue
+			}
+			if !IsScope(scope) {
+				return nil, fmt.Errorf("key %q: unknown scope %q", name, scope)
+			}
+			apiKey.Scopes = append(apiKey.Scopes, scope)
+		}
+		if len(apiKey.Scopes) == 0 {
+			return nil, fmt.Errorf("key %q grants no scope", name)
+		}
+		keys = append(keys, apiKey)
+	}
+	return keys, nil
+}
+
+// ParseTeams parses team memberships of the form "bob=core,infra;ci=platform".
+//
+// Entries are separated by semicolons. Each entry names a member and lists
+// its teams comma-separated.
+//
+// Parameters:
+//   - spec: The membership specification (empty for none)
+//
+// Returns:
+//   - map[string][]string: Teams by member name
+//   - error: Error naming the first malformed entry
+func ParseTeams(spec string) (map[string][]string, error) {
+	teams := make(map[string][]string)
+	for _, entry := range strings.Split(spec, ";") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+
+		name, list, ok := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("entry %q must look like <name>=<team>,<team>", entry)
+		}
+		if _, seen := teams[name]; seen {
+			return nil, fmt.Errorf("%q is listed twice", name)
+		}
+		teams[name] = []string{}
+		for _, team := range strings.Split(list, ",") {
+			if team = strings.TrimSpace(team); team != "" {
+				teams[name] = append(teams[name], team)
+			}
+		}
+	}
+	return teams, nil
+}
+
+// tokenClaims are the JWT claims read by the authenticator.
+type tokenClaims struct {
+	Subject   string   `json:"sub"`
+	Scope     string   `json:"scope"`
+	Teams     []string `json:"teams"`
+	ExpiresAt *float64 `json:"exp"`
+	NotBefore *float64 `json:"nbf"`
+}
+
+// TokenAuthenticator accepts HS256 bearer tokens and API keys.
+//
+// Tokens are JWTs signed with a shared secret. They must name the caller in
+// "sub", expire ("exp") and list the granted scopes space-separated in
+// "scope" (RFC 9068); unknown scopes are ignored. The teams of the caller
+// come from the optional "teams" claim, an array of names. API keys are sent
+// in the X-API-Key header and grant the scopes and teams they were
+// configured with.
+//
+// Usage Example:
+//
+//	authenticator := auth.NewTokenAuthenticator([]byte(secret), keys)
+//	opts := router.Options{Authenticator: authenticator}
+type TokenAuthenticator struct {
+	secret []byte
+	keys   map[[sha256.Size]byte]APIKey
+}
+
+// NewTokenAuthenticator creates a new instance of TokenAuthenticator.
+//
+// Parameters:
+//   - secret: HMAC secret of bearer tokens (empty rejects all tokens)
+//   - keys: Accepted API keys
+//
+// Returns:
+//   - *TokenAuthenticator: A new authenticator instance
+func NewTokenAuthenticator(secret []byte, keys []APIKey) *TokenAuthenticator {
+	byHash := make(map[[sha256.Size]byte]APIKey, len(keys))
+	for _, key := range keys {
+		byHash[sha256.Sum256([]byte(key.Key))] = key
+	}
+	return &TokenAuthenticator{secret: secret, keys: byHash}
+}
+
+// Authenticate identifies the caller from the Authorization or X-API-Key header.
+//
+// Parameters:
+//   - r: The request
+//
+// Returns:
+//   - *Principal: The caller, or nil when the request carries no credentials
+//   - error: Error wrapping ErrInvalidCredentials for rejected credentials
+func (a *TokenAuthenticator) Authenticate(r *http.Request) (*Principal, error) {
+	if header := r.Header.Get("Authorization"); header != "" {
+		scheme, token, _ := strings.Cut(header, " ")
+		if !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+			return nil, fmt.Errorf("%w: expected a Bearer token", ErrInvalidCredentials)
+		}
+		return a.verifyToken(strings.TrimSpace(token), time.Now())
+	}
+
+	if key := r.Header.Get(APIKeyHeader); key != "" {
+		// Keys are looked up by hash so the comparison does not leak their prefix
+		hash := sha256.Sum256([]byte(key))
+		apiKey, ok := a.keys[hash]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown API key", ErrInvalidCredentials)
+		}
+		return &Principal{Name: apiKey.Name, APIKey: apiKey.Name, Scopes: apiKey.Scopes, Teams: apiKey.Teams}, nil
+	}
+
+	return nil, nil
+}
+
+// verifyToken checks the signature and lifetime of an HS256 token.
+func (a *TokenAuthenticator) verifyToken(token string, now time.Time) (*Principal, error) {
+	// Step 1: Split and check the header
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", ErrInvalidCredentials)
+	}
+	var header struct {
+		Algorithm string `json:"alg"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil || header.Algorithm != "HS256" {
+		return nil, fmt.Errorf("%w: token must be signed with HS256", ErrInvalidCredentials)
+	}
+
+	// Step 2: Verify the signature
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || len(a.secret) == 0 {
+		return nil, fmt.Errorf("%w: invalid token signature", ErrInvalidCredentials)
+	}
+	mac := hmac.New(sha256.New, a.secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if subtle.ConstantTimeCompare(signature, mac.Sum(nil)) != 1 {
+		return nil, fmt.Errorf("%w: invalid token signature", ErrInvalidCredentials)
+	}
+
+	// Step 3: Check the claims
+	var claims tokenClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: malformed token claims", ErrInvalidCredentials)
+	}
+	switch {
+	case strings.TrimSpace(claims.Subject) == "":
+		return nil, fmt.Errorf("%w: token has no subject", ErrInvalidCredentials)
+	case claims.ExpiresAt == nil:
+		return nil, fmt.Errorf("%w: token has no expiry", ErrInvalidCredentials)
+	case now.Unix() >= int64(*claims.ExpiresAt):
+		return nil, fmt.Errorf("%w: token expired", ErrInvalidCredentials)
+	case claims.NotBefore != nil && now.Unix() < int64(*claims.NotBefore):
+		return nil, fmt.Errorf("%w: token not valid yet", ErrInvalidCredentials)
+	}
+
+	principal := &Principal{Name: strings.TrimSpace(claims.Subject)}
+	for _, team := range claims.Teams {
+		if team = strings.TrimSpace(team); team != "" {
+			principal.Teams = append(principal.Teams, team)
+		}
+	}
+	for _, scope := range strings.Fields(claims.Scope) {
+		if IsScope(scope) {
+			principal.Scopes = append(principal.Scopes, scope)
+		}
+	}
+	return principal, nil
+}
+
+// decodeSegment decodes a base64url-encoded JSON token segment.
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,2213 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"go_di_architecture/internal/app/activity"
+	"go_di_architecture/internal/app/auth"
+	"go_di_architecture/internal/app/commands"
+	"go_di_architecture/internal/app/container"
+	"go_di_architecture/internal/app/decorators"
+	"go_di_architecture/internal/app/handlers"
+	"go_di_architecture/internal/app/jobs"
+	"go_di_architecture/internal/app/leader"
+	"go_di_architecture/internal/app/lifecycle"
+	"go_di_architecture/internal/app/notifier"
+	"go_di_architecture/internal/app/router"
+	"go_di_architecture/internal/app/saga"
+	"go_di_architecture/internal/app/scheduler"
+	"go_di_architecture/internal/app/seed"
+	"go_di_architecture/internal/app/server"
+	"go_di_architecture/internal/config"
+	"go_di_architecture/internal/domain/cdn"
+	"go_di_architecture/internal/domain/clock"
+	"go_di_architecture/internal/domain/events"
+	"go_di_architecture/internal/domain/lock"
+	"go_di_architecture/internal/domain/metrics"
+	"go_di_architecture/internal/domain/models/response"
+	"go_di_architecture/internal/domain/notification"
+	"go_di_architecture/internal/domain/provisioning"
+	"go_di_architecture/internal/domain/queue"
+	backupService "go_di_architecture/internal/domain/service/backup"
+	deadLetterService "go_di_architecture/internal/domain/service/deadletter"
+	dependencyService "go_di_architecture/internal/domain/service/dependency"
+	exportService "go_di_architecture/internal/domain/service/export"
+	moduleService "go_di_architecture/internal/domain/service/module"
+	noteService "go_di_architecture/internal/domain/service/note"
+	privacyService "go_di_architecture/internal/domain/service/privacy"
+	projectionService "go_di_architecture/internal/domain/service/projection"
+	quotaService "go_di_architecture/internal/domain/service/quota"
+	retentionService "go_di_architecture/internal/domain/service/retention"
+	settingService "go_di_architecture/internal/domain/service/setting"
+	tagService "go_di_architecture/internal/domain/service/tag"
+	templateService "go_di_architecture/internal/domain/service/template"
+	usageService "go_di_architecture/internal/domain/service/usage"
+	workflowService "go_di_architecture/internal/domain/service/workflow"
+	"go_di_architecture/internal/domain/storage"
+	"go_di_architecture/internal/i18n"
+	cdnInfra "go_di_architecture/internal/infra/cdn"
+	"go_di_architecture/internal/infra/db"
+	moduleRepo "go_di_architecture/internal/infra/db/module"
+	"go_di_architecture/internal/infra/httpclient"
+	lockInfra "go_di_architecture/internal/infra/lock"
+	metricsInfra "go_di_architecture/internal/infra/metrics"
+	"go_di_architecture/internal/infra/notify"
+	provisioningInfra "go_di_architecture/internal/infra/provisioning"
+	"go_di_architecture/internal/infra/pubsub"
+	queueInfra "go_di_architecture/internal/infra/queue"
+	objectStorage "go_di_architecture/internal/infra/storage"
+	"go_di_architecture/internal/logging"
+	"go_di_architecture/internal/middleware"
+	"go_di_architecture/internal/templating"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+// logger writes what the components report while they are assembled and run.
+var logger = logging.New("bootstrap")
+
+// Component names registered in the container
+const (
+	Config               = "config"
+	Database             = "db"
+	DatabaseWatchdog     = "db.watchdog"
+	QueryCounter         = "db.querycounter"
+	StatementTracer      = "db.statementtracer"
+	IDGenerator          = "db.idgenerator"
+	Locks                = "locks"
+	LeaderElector        = "leader.elector"
+	ModuleRepository     = "module.repository"
+	ModuleNameFilter     = "module.namefilter"
+	NameFilterScheduler  = "module.namefilter.scheduler"
+	ModuleService        = "module.service"
+	ModuleHandler        = "module.handler"
+	ModuleScheduler      = "module.scheduler"
+	Notifier             = "notifier"
+	Templates            = "templates"
+	Messages             = "messages"
+	RevisionRepository   = "revision.repository"
+	ACLRepository        = "acl.repository"
+	TransferRepository   = "transfer.repository"
+	StarRepository       = "star.repository"
+	TagRepository        = "tag.repository"
+	TagService           = "tag.service"
+	TagHandler           = "tag.handler"
+	DependencyRepository = "dependency.repository"
+	DependencyService    = "dependency.service"
+	DependencyHandler    = "dependency.handler"
+	SettingSchemas       = "setting.schemas"
+	SettingRepository    = "setting.repository"
+	SettingService       = "setting.service"
+	SettingHandler       = "setting.handler"
+	NoteRepository       = "note.repository"
+	NoteService          = "note.service"
+	NoteHandler          = "note.handler"
+	TemplateRepository   = "template.repository"
+	TemplateService      = "template.service"
+	TemplateHandler      = "template.handler"
+	AdminHandler         = "admin.handler"
+	ActivityRecorder     = "activity.recorder"
+	DashboardHandler     = "dashboard.handler"
+	ObjectStorage        = "storage"
+	JobRunner            = "jobs"
+	ExportService        = "export.service"
+	ExportHandler        = "export.handler"
+	JobHandler           = "job.handler"
+	BackupService        = "backup.service"
+	BackupHandler        = "backup.handler"
+	RetentionRepository  = "retention.repository"
+	RetentionService     = "retention.service"
+	RetentionScheduler   = "retention.scheduler"
+	RetentionHandler     = "retention.handler"
+	SummaryRepository    = "summary.repository"
+	ProjectionService    = "projection.service"
+	UserDataRepository   = "userdata.repository"
+	PrivacyService       = "privacy.service"
+	PrivacyHandler       = "privacy.handler"
+	UsageRepository      = "usage.repository"
+	UsageService         = "usage.service"
+	UsageScheduler       = "usage.scheduler"
+	UsageHandler         = "usage.handler"
+	QuotaRepository      = "quota.repository"
+	QuotaService         = "quota.service"
+	QuotaScheduler       = "quota.scheduler"
+	AccountHandler       = "account.handler"
+	EventBus             = "events.bus"
+	CDNPurger            = "cdn.purger"
+	CommandQueue         = "commands.queue"
+	CommandConsumer      = "commands.consumer"
+	DeadLetterService    = "deadletter.service"
+	DeadLetterHandler    = "deadletter.handler"
+	WorkflowRepository   = "workflow.repository"
+	Allocator            = "provisioning.allocator"
+	WorkflowService      = "workflow.service"
+	SagaOrchestrator     = "saga.orchestrator"
+	WorkflowHandler      = "workflow.handler"
+	Metrics              = "metrics"
+	Clock                = "clock"
+	Seeder               = "seeder"
+	HTTPRouter           = "http.router"
+	HTTPServer           = "http.server"
+
+	// Request-scoped components
+	RequestID      = middleware.RequestIDComponent
+	Principal      = middleware.PrincipalComponent
+	ResponseMapper = handlers.MapperComponent
+	UnitOfWork     = middleware.UnitOfWorkComponent
+)
+
+// RoutableTag marks the handlers whose routes the router registers (see handlers.Routable).
+const RoutableTag = "http.routes"
+
+// ginModes selects the gin mode of each environment unless GIN_MODE is set;
+// only development prints gin's route table and debug warnings.
+var ginModes = map[string]string{
+	config.EnvDevelopment: gin.DebugMode,
+	config.EnvTest:        gin.TestMode,
+	config.EnvStaging:     gin.ReleaseMode,
+	config.EnvProduction:  gin.ReleaseMode,
+}
+
+// cachePreloadLimit bounds how many modules are listed to warm the module cache.
+const cachePreloadLimit = 100
+
+// NewContainer creates a container with every application component registered.
+//
+// Components are grouped by layer:
+//   - Infrastructure: configuration, database, repositories and object storage
+//   - Domain: event bus, clock and business services
+//   - Application: background scheduler, job runner, notifier, sample data seeder, handlers, router and HTTP server
+//   - Request scope: request ID, principal and response mapper
+//
+// The components named in the DECORATORS setting are wrapped in their
+// decorator chains.
+//
+// The configured log levels and format apply from here on, so the startup is
+// logged with them already.
+//
+// Parameters:
+//   - cfg: Application configuration selecting the storage backend
+//
+// Returns:
+//   - *container.Container: A container ready to be started
+//   - error: Error if a provider cannot be registered
+func NewContainer(cfg *config.Config) (*container.Container, error) {
+	logging.SetFormat(cfg.Logging.Format)
+	logging.SetDefaultLevel(cfg.Logging.Level)
+	for name, level := range cfg.Logging.Levels {
+		logging.SetLevel(name, level)
+	}
+	if os.Getenv(gin.EnvGinMode) == "" {
+		gin.SetMode(ginModes[cfg.Environment])
+	}
+
+	c := container.New()
+
+	providers := infraProviders(cfg)
+	providers = append(providers, []container.Provider{
+		{
+			Name: EventBus,
+			Factory: func(container.Resolver) (any, error) {
+				return events.NewBus(events.Log), nil
+			},
+		},
+		{
+			Name: Metrics,
+			Factory: func(container.Resolver) (any, error) {
+				return metrics.Metrics(metricsInfra.NewExpvarMetrics("business")), nil
+			},
+		},
+		{
+			Name:         Clock,
+			Dependencies: []string{Config},
+			Factory:      provideClock,
+		},
+		{
+			Name:         ModuleService,
+			Dependencies: []string{ModuleRepository, RevisionRepository, ACLRepository, TransferRepository, StarRepository, EventBus, Metrics},
+			Factory:      provideModuleService,
+		},
+		{
+			Name:         ModuleScheduler,
+			Dependencies: []string{Config, ModuleService, Locks, LeaderElector},
+			Factory:      provideModuleScheduler,
+		},
+		{
+			Name:         Templates,
+			Dependencies: []string{Config},
+			Factory:      provideTemplates,
+		},
+		{
+			Name:         Messages,
+			Dependencies: []string{Config},
+			Factory:      provideMessages,
+		},
+		{
+			Name:         Notifier,
+			Dependencies: []string{Config, EventBus, Templates},
+			Factory:      provideNotifier,
+		},
+		{
+			Name:         ModuleHandler,
+			Dependencies: []string{ModuleService, Templates},
+			Factory:      provideModuleHandler,
+			Tags:         []string{RoutableTag},
+		},
+		{
+			Name:         TagService,
+			Dependencies: []string{TagRepository, ModuleService},
+			Factory:      provideTagService,
+		},
+		{
+			Name:         TagHandler,
+			Dependencies: []string{TagService},
+			Factory:      provideTagHandler,
+			Tags:         []string{RoutableTag},
+		},
+		{
+			Name:         DependencyService,
+			Dependencies: []string{DependencyRepository, ModuleService},
+			Factory:      provideDependencyService,
+		},
+		{
+			Name:         DependencyHandler,
+			Dependencies: []string{DependencyService},
+			Factory:      provideDependencyHandler,
+			Tags:         []string{RoutableTag},
+		},
+		{
+			Name: SettingSchemas,
+			Factory: func(container.Resolver) (any, error) {
+				return settingService.NewDefaultSchemaRegistry()
+			},
+		},
+		{
+			Name:         SettingService,
+			Dependencies: []string{SettingRepository, ModuleService, SettingSchemas},
+			Factory:      provideSettingService,
+		},
+		{
+			Name:         SettingHandler,
+			Dependencies: []string{SettingService},
+			Factory:      provideSettingHandler,
+			Tags:         []string{RoutableTag},
+		},
+		{
+			Name:         NoteService,
+			Dependencies: []string{NoteRepository, ModuleService},
+			Factory:      provideNoteService,
+		},
+		{
+			Name:         NoteHandler,
+			Dependencies: []string{NoteService},
+			Factory:      provideNoteHandler,
+			Tags:         []string{RoutableTag},
+		},
+		{
+			Name:         TemplateService,
+			Dependencies: []string{TemplateRepository, ModuleService, SettingService},
+			Factory:      provideTemplateService,
+		},
+		{
+			Name:         TemplateHandler,
+			Dependencies: []string{TemplateService},
+			Factory:      provideTemplateHandler,
+			Tags:         []string{RoutableTag},
+		},
+		{
+			Name:         ObjectStorage,
+			Dependencies: []string{Config},
+			Factory:      provideObjectStorage,
+		},
+		{
+			Name: JobRunner,
+			Factory: func(r container.Resolver) (any, error) {
+				return jobs.New(r.Lifecycle()), nil
+			},
+		},
+		{
+			Name:         ExportService,
+			Dependencies: []string{ModuleService, ObjectStorage, Config},
+			Factory:      provideExportService,
+		},
+		{
+			Name:         ExportHandler,
+			Dependencies: []string{ExportService, JobRunner, ObjectStorage},
+			Factory:      provideExportHandler,
+			Tags:         []string{RoutableTag},
+		},
+		{
+			Name:         JobHandler,
+			Dependencies: []string{JobRunner},
+			Factory:      provideJobHandler,
+			Tags:         []string{RoutableTag},
+		},
+		{
+			Name:         BackupService,
+			Dependencies: []string{ModuleRepository, ModuleService, TagRepository, SettingRepository, ObjectStorage, Locks, Metrics},
+			Factory:      provideBackupService,
+		},
+		{
+			Name:         BackupHandler,
+			Dependencies: []string{BackupService, JobRunner},
+			Factory:      provideBackupHandler,
+			Tags:         []string{RoutableTag},
+		},
+		{
+			Name:         RetentionService,
+			Dependencies: []string{RetentionRepository, Config},
+			Factory:      provideRetentionService,
+		},
+		{
+			Name:         ProjectionService,
+			Dependencies: []string{SummaryRepository},
+			Factory:      provideProjectionService,
+		},
+		{
+			Name:         RetentionScheduler,
+			Dependencies: []string{Config, RetentionService, Locks, LeaderElector},
+			Factory:      provideRetentionScheduler,
+		},
+		{
+			Name:         RetentionHandler,
+			Dependencies: []string{RetentionService, JobRunner},
+			Factory:      provideRetentionHandler,
+			Tags:         []string{RoutableTag},
+		},
+		{
+			Name:         PrivacyService,
+			Dependencies: []string{UserDataRepository},
+			Factory:      providePrivacyService,
+		},
+		{
+			Name:         PrivacyHandler,
+			Dependencies: []string{PrivacyService},
+			Factory:      providePrivacyHandler,
+			Tags:         []string{RoutableTag},
+		},
+		{
+			Name:         UsageService,
+			Dependencies: []string{UsageRepository, ModuleService},
+			Factory:      provideUsageService,
+		},
+		{
+			Name:         UsageScheduler,
+			Dependencies: []string{Config, UsageService},
+			Factory:      provideUsageScheduler,
+		},
+		{
+			Name:         UsageHandler,
+			Dependencies: []string{UsageService},
+			Factory:      provideUsageHandler,
+			Tags:         []string{RoutableTag},
+		},
+		{
+			Name:         QuotaService,
+			Dependencies: []string{QuotaRepository, Config},
+			Factory:      provideQuotaService,
+		},
+		{
+			Name:         QuotaScheduler,
+			Dependencies: []string{Config, QuotaService},
+			Factory:      provideQuotaScheduler,
+		},
+		{
+			Name:         AccountHandler,
+			Dependencies: []string{QuotaService},
+			Factory:      provideAccountHandler,
+			Tags:         []string{RoutableTag},
+		},
+		{
+			Name: AdminHandler,
+			Factory: func(container.Resolver) (any, error) {
+				return handlers.NewAdminHandler(c), nil
+			},
+			Tags: []string{RoutableTag},
+		},
+		{
+			Name:         ActivityRecorder,
+			Dependencies: []string{Config},
+			Factory:      provideActivityRecorder,
+		},
+		{
+			Name:         DashboardHandler,
+			Dependencies: []string{ActivityRecorder, JobRunner, Notifier},
+			Factory:      provideDashboardHandler,
+			Tags:         []string{RoutableTag},
+		},
+		{
+			Name:         CDNPurger,
+			Dependencies: []string{Config, EventBus},
+			Factory:      provideCDNPurger,
+		},
+		{
+			Name:         CommandQueue,
+			Dependencies: []string{Config},
+			Factory:      provideCommandQueue,
+		},
+		{
+			Name:         CommandConsumer,
+			Dependencies: []string{Config, CommandQueue, ModuleService, CDNPurger, Metrics},
+			Factory:      provideCommandConsumer,
+		},
+		{
+			Name:         DeadLetterService,
+			Dependencies: []string{CommandQueue, Metrics},
+			Factory:      provideDeadLetterService,
+		},
+		{
+			Name:         DeadLetterHandler,
+			Dependencies: []string{DeadLetterService},
+			Factory:      provideDeadLetterHandler,
+			Tags:         []string{RoutableTag},
+		},
+		{
+			Name:         Allocator,
+			Dependencies: []string{Config},
+			Factory:      provideAllocator,
+		},
+		{
+			Name:         WorkflowService,
+			Dependencies: []string{WorkflowRepository, ModuleService, Allocator, EventBus},
+			Factory:      provideWorkflowService,
+		},
+		{
+			Name:         SagaOrchestrator,
+			Dependencies: []string{WorkflowService},
+			Factory:      provideSagaOrchestrator,
+		},
+		{
+			Name:         WorkflowHandler,
+			Dependencies: []string{SagaOrchestrator, WorkflowService},
+			Factory:      provideWorkflowHandler,
+			Tags:         []string{RoutableTag},
+		},
+		{
+			Name:         Seeder,
+			Dependencies: []string{Config, ModuleService},
+			Factory:      provideSeeder,
+		},
+		{
+			Name:         HTTPRouter,
+			Dependencies: []string{Config, Messages, ActivityRecorder, CDNPurger, UsageService, QuotaService, DatabaseWatchdog, QueryCounter, StatementTracer},
+			Factory: func(r container.Resolver) (any, error) {
+				return provideRouter(r, c)
+			},
+		},
+		{
+			Name:         HTTPServer,
+			Dependencies: []string{HTTPRouter},
+			Factory:      provideServer,
+		},
+		{
+			Name:     RequestID,
+			Lifetime: container.Scoped,
+			Factory:  container.Supplied(RequestID),
+		},
+		{
+			Name:     Principal,
+			Lifetime: container.Scoped,
+			Factory:  container.Supplied(Principal),
+		},
+		{
+			Name:         ResponseMapper,
+			Lifetime:     container.Scoped,
+			Dependencies: []string{Config, Clock, RequestID},
+			Factory:      provideResponseMapper,
+		},
+	}...)
+
+	for _, provider := range providers {
+		if err := c.Provide(provider); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := applyDecorators(c, cfg); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// applyDecorators wraps the components named in the DECORATORS setting in
+// their decorator chains.
+//
+// Decorators are implemented per interface; the table lists the components
+// that have them and the decorators each one supports, whatever its type.
+func applyDecorators(c *container.Container, cfg *config.Config) error {
+	available := map[string]decorators.Chain{
+		Allocator: decorators.AllocatorSet(Metrics, cfg.Provisioning.Plans),
+	}
+
+	for component, names := range cfg.Decorators {
+		chain, ok := available[component]
+		if !ok {
+			return fmt.Errorf("invalid DECORATORS: component %q has no decorators", component)
+		}
+		if err := chain.Decorate(c, component, names); err != nil {
+			return fmt.Errorf("invalid DECORATORS for %s: %w", component, err)
+		}
+	}
+	return nil
+}
+
+// infraProviders registers the storage backend selected by the configuration.
+//
+// The in-memory backend needs no database component; SQL backends get a
+// database connection whose lifecycle hooks run migrations on start and close
+// the connection pool on stop.
+func infraProviders(cfg *config.Config) []container.Provider {
+	providers := []container.Provider{
+		{
+			Name: Config,
+			Factory: func(container.Resolver) (any, error) {
+				return cfg, nil
+			},
+		},
+		// Both backends take module IDs from the configured generator
+		{
+			Name:         IDGenerator,
+			Dependencies: []string{Config},
+			Factory:      provideIDGenerator,
+		},
+	}
+
+	if cfg.Database.Driver == config.DriverMemory {
+		return append(providers,
+			container.Provider{
+				Name:         ModuleRepository,
+				Dependencies: []string{IDGenerator},
+				Factory:      provideInMemoryModuleRepository,
+			},
+			// In-memory data lives in one process, so in-process locks suffice
+			container.Provider{
+				Name: Locks,
+				Factory: func(container.Resolver) (any, error) {
+					return lockInfra.NewLocalLock(), nil
+				},
+			},
+			container.Provider{
+				Name:         LeaderElector,
+				Dependencies: []string{Config},
+				Factory:      provideLeaderElector,
+			},
+			// Nothing to ping without a database
+			container.Provider{
+				Name: DatabaseWatchdog,
+				Factory: func(container.Resolver) (any, error) {
+					return (*db.Watchdog)(nil), nil
+				},
+			},
+			container.Provider{
+				Name: QueryCounter,
+				Factory: func(container.Resolver) (any, error) {
+					return (*db.QueryCounter)(nil), nil
+				},
+			},
+			container.Provider{
+				Name: StatementTracer,
+				Factory: func(container.Resolver) (any, error) {
+					return (*db.StatementTracer)(nil), nil
+				},
+			},
+			// Summaries are a read model of a database; the replay command
+			// rejects the in-memory driver, so nothing resolves them
+			container.Provider{
+				Name: SummaryRepository,
+				Factory: func(container.Resolver) (any, error) {
+					return (*moduleRepo.SummaryRepository)(nil), nil
+				},
+			},
+			// The in-memory store keeps revisions, ACLs, transfers, stars, tags, dependencies, settings, notes, templates, the archive and usage and quota counters next to modules, so all share one instance
+			container.Provider{
+				Name:         RevisionRepository,
+				Dependencies: []string{ModuleRepository},
+				Factory:      provideSharedInMemoryRepository,
+			},
+			container.Provider{
+				Name:         ACLRepository,
+				Dependencies: []string{ModuleRepository},
+				Factory:      provideSharedInMemoryRepository,
+			},
+			container.Provider{
+				Name:         TransferRepository,
+				Dependencies: []string{ModuleRepository},
+				Factory:      provideSharedInMemoryRepository,
+			},
+			container.Provider{
+				Name:         StarRepository,
+				Dependencies: []string{ModuleRepository},
+				Factory:      provideSharedInMemoryRepository,
+			},
+			container.Provider{
+				Name:         TagRepository,
+				Dependencies: []string{ModuleRepository},
+				Factory:      provideSharedInMemoryRepository,
+			},
+			container.Provider{
+				Name:         DependencyRepository,
+				Dependencies: []string{ModuleRepository},
+				Factory:      provideSharedInMemoryRepository,
+			},
+			container.Provider{
+				Name:         SettingRepository,
+				Dependencies: []string{ModuleRepository},
+				Factory:      provideSharedInMemoryRepository,
+			},
+			container.Provider{
+				Name:         NoteRepository,
+				Dependencies: []string{ModuleRepository},
+				Factory:      provideSharedInMemoryRepository,
+			},
+			container.Provider{
+				Name:         TemplateRepository,
+				Dependencies: []string{ModuleRepository},
+				Factory:      provideSharedInMemoryRepository,
+			},
+			container.Provider{
+				Name:         RetentionRepository,
+				Dependencies: []string{ModuleRepository},
+				Factory:      provideSharedInMemoryRepository,
+			},
+			container.Provider{
+				Name:         UserDataRepository,
+				Dependencies: []string{ModuleRepository},
+				Factory:      provideSharedInMemoryRepository,
+			},
+			container.Provider{
+				Name:         UsageRepository,
+				Dependencies: []string{ModuleRepository},
+				Factory:      provideSharedInMemoryRepository,
+			},
+			container.Provider{
+				Name:         QuotaRepository,
+				Dependencies: []string{ModuleRepository},
+				Factory:      provideSharedInMemoryRepository,
+			},
+			container.Provider{
+				Name:         WorkflowRepository,
+				Dependencies: []string{ModuleRepository},
+				Factory:      provideSharedInMemoryRepository,
+			},
+		)
+	}
+
+	return append(providers,
+		container.Provider{
+			Name:         Database,
+			Dependencies: []string{Config},
+			Factory:      provideDatabase,
+		},
+		container.Provider{
+			Name:         DatabaseWatchdog,
+			Dependencies: []string{Config, Database},
+			Factory:      provideDatabaseWatchdog,
+		},
+		// Write requests run in the transaction of their scope
+		container.Provider{
+			Name:         UnitOfWork,
+			Lifetime:     container.Scoped,
+			Dependencies: []string{Database},
+			Factory:      provideUnitOfWork,
+		},
+		container.Provider{
+			Name:         QueryCounter,
+			Dependencies: []string{Config, Database},
+			Factory:      provideQueryCounter,
+		},
+		container.Provider{
+			Name:         StatementTracer,
+			Dependencies: []string{Config, Database},
+			Factory:      provideStatementTracer,
+		},
+		container.Provider{
+			Name:         Locks,
+			Dependencies: []string{Config, Database},
+			Factory:      provideLocks,
+		},
+		container.Provider{
+			Name:         LeaderElector,
+			Dependencies: []string{Config, Database},
+			Factory:      provideLeaderElector,
+		},
+		container.Provider{
+			Name:         ModuleNameFilter,
+			Dependencies: []string{Database, Config},
+			Factory:      provideModuleNameFilter,
+		},
+		container.Provider{
+			Name:         NameFilterScheduler,
+			Dependencies: []string{Config, ModuleNameFilter},
+			Factory:      provideNameFilterScheduler,
+		},
+		container.Provider{
+			Name:         ModuleRepository,
+			Dependencies: []string{Database, Config, ModuleNameFilter, IDGenerator},
+			Factory:      provideSQLModuleRepository,
+		},
+		container.Provider{
+			Name:         RevisionRepository,
+			Dependencies: []string{Database},
+			Factory:      provideSQLRevisionRepository,
+		},
+		container.Provider{
+			Name:         SummaryRepository,
+			Dependencies: []string{Database},
+			Factory:      provideSQLSummaryRepository,
+		},
+		container.Provider{
+			Name:         ACLRepository,
+			Dependencies: []string{Database},
+			Factory:      provideSQLACLRepository,
+		},
+		container.Provider{
+			Name:         TransferRepository,
+			Dependencies: []string{Database},
+			Factory:      provideSQLTransferRepository,
+		},
+		container.Provider{
+			Name:         StarRepository,
+			Dependencies: []string{Database},
+			Factory:      provideSQLStarRepository,
+		},
+		container.Provider{
+			Name:         TagRepository,
+			Dependencies: []string{Database},
+			Factory:      provideSQLTagRepository,
+		},
+		container.Provider{
+			Name:         DependencyRepository,
+			Dependencies: []string{Database},
+			Factory:      provideSQLDependencyRepository,
+		},
+		container.Provider{
+			Name:         SettingRepository,
+			Dependencies: []string{Database},
+			Factory:      provideSQLSettingRepository,
+		},
+		container.Provider{
+			Name:         NoteRepository,
+			Dependencies: []string{Database},
+			Factory:      provideSQLNoteRepository,
+		},
+		container.Provider{
+			Name:         TemplateRepository,
+			Dependencies: []string{Database},
+			Factory:      provideSQLTemplateRepository,
+		},
+		container.Provider{
+			Name:         RetentionRepository,
+			Dependencies: []string{Database, ModuleRepository},
+			Factory:      provideSQLRetentionRepository,
+		},
+		container.Provider{
+			Name:         UserDataRepository,
+			Dependencies: []string{Database, ModuleRepository},
+			Factory:      provideSQLUserDataRepository,
+		},
+		container.Provider{
+			Name:         UsageRepository,
+			Dependencies: []string{Database},
+			Factory:      provideSQLUsageRepository,
+		},
+		container.Provider{
+			Name:         QuotaRepository,
+			Dependencies: []string{Database},
+			Factory:      provideSQLQuotaRepository,
+		},
+		container.Provider{
+			Name:         WorkflowRepository,
+			Dependencies: []string{Database},
+			Factory:      provideSQLWorkflowRepository,
+		},
+	)
+}
+
+// provideDatabase opens the connection and registers migrate/close hooks.
+func provideDatabase(r container.Resolver) (any, error) {
+	cfg, err := container.Resolve[*config.Config](r, Config)
+	if err != nil {
+		return nil, err
+	}
+
+	database, err := db.Open(cfg.Database)
+	if err != nil {
+		return nil, err
+	}
+
+	r.Lifecycle().Append(lifecycle.Hook{
+		Name: Database,
+		OnStart: func(ctx context.Context) error {
+			sqlDB, err := database.DB()
+			if err != nil {
+				return err
+			}
+			if err := sqlDB.PingContext(ctx); err != nil {
+				return err
+			}
+			return db.Migrate(database.WithContext(ctx))
+		},
+		OnWarmUp: func(ctx context.Context) error {
+			return db.Prime(ctx, database, db.PrimeConnections)
+		},
+		OnStop: func(ctx context.Context) error {
+			sqlDB, err := database.DB()
+			if err != nil {
+				return err
+			}
+			return sqlDB.Close()
+		},
+	})
+
+	return database, nil
+}
+
+// provideDatabaseWatchdog pings the database while the application runs;
+// without a health interval it is disabled.
+// provideUnitOfWork creates the unit of work of a request; the scope disposes
+// it, rolling back what the request did not complete.
+func provideUnitOfWork(r container.Resolver) (any, error) {
+	database, err := container.Resolve[*gorm.DB](r, Database)
+	if err != nil {
+		return nil, err
+	}
+	return db.NewUnitOfWork(database), nil
+}
+
+func provideDatabaseWatchdog(r container.Resolver) (any, error) {
+	cfg, err := container.Resolve[*config.Config](r, Config)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Database.HealthInterval <= 0 {
+		return (*db.Watchdog)(nil), nil
+	}
+	database, err := container.Resolve[*gorm.DB](r, Database)
+	if err != nil {
+		return nil, err
+	}
+	sqlDB, err := database.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	watchdog := db.NewWatchdog(sqlDB, cfg.Database.HealthInterval)
+	r.Lifecycle().Append(lifecycle.Hook{
+		Name:    DatabaseWatchdog,
+		OnStart: watchdog.Start,
+		OnStop:  watchdog.Stop,
+	})
+	return watchdog, nil
+}
+
+// provideQueryCounter counts the statements of every request against the
+// query budget; without a budget it is disabled.
+func provideQueryCounter(r container.Resolver) (any, error) {
+	cfg, err := container.Resolve[*config.Config](r, Config)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Database.QueryBudget <= 0 {
+		return (*db.QueryCounter)(nil), nil
+	}
+	database, err := container.Resolve[*gorm.DB](r, Database)
+	if err != nil {
+		return nil, err
+	}
+
+	counter := db.NewQueryCounter()
+	if err := counter.Register(database); err != nil {
+		return nil, err
+	}
+	return counter, nil
+}
+
+// provideStatementTracer reports the statements of requests for the
+// breakdown of slow requests; without a slow request threshold it is
+// disabled.
+func provideStatementTracer(r container.Resolver) (any, error) {
+	cfg, err := container.Resolve[*config.Config](r, Config)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Logging.SlowRequestThreshold <= 0 {
+		return (*db.StatementTracer)(nil), nil
+	}
+	database, err := container.Resolve[*gorm.DB](r, Database)
+	if err != nil {
+		return nil, err
+	}
+
+	tracer := db.NewStatementTracer()
+	if err := tracer.Register(database); err != nil {
+		return nil, err
+	}
+	return tracer, nil
+}
+
+// provideIDGenerator selects how new modules get their IDs.
+func provideIDGenerator(r container.Resolver) (any, error) {
+	cfg, err := container.Resolve[*config.Config](r, Config)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Database.IDGenerator == config.IDGeneratorSnowflake {
+		return db.NewSnowflake(cfg.Database.NodeID)
+	}
+	return db.AutoIncrement{}, nil
+}
+
+// newRedisClient creates a go-redis client for a redis:// or rediss:// URL.
+func newRedisClient(rawURL string) (*redis.Client, error) {
+	options, err := redis.ParseURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	return redis.NewClient(options), nil
+}
+
+// provideLocks selects the lock implementation shared by the instances.
+func provideLocks(r container.Resolver) (any, error) {
+	cfg, err := container.Resolve[*config.Config](r, Config)
+	if err != nil {
+		return nil, err
+	}
+
+	switch cfg.Locks.Backend {
+	case config.LockRedis:
+		client, err := newRedisClient(cfg.Locks.RedisURL)
+		if err != nil {
+			return nil, err
+		}
+		r.Lifecycle().Append(lifecycle.Hook{
+			Name: Locks,
+			OnStop: func(context.Context) error {
+				return client.Close()
+			},
+		})
+		return lock.Lock(lockInfra.NewRedisLock(client)), nil
+
+	case config.LockPostgres:
+		database, err := container.Resolve[*gorm.DB](r, Database)
+		if err != nil {
+			return nil, err
+		}
+		return lock.Lock(lockInfra.NewPostgresLock(database)), nil
+
+	default:
+		return lock.Lock(lockInfra.NewLocalLock()), nil
+	}
+}
+
+// provideLeaderElector elects the instance running the scheduled jobs on the
+// configured lease backend.
+func provideLeaderElector(r container.Resolver) (any, error) {
+	cfg, err := container.Resolve[*config.Config](r, Config)
+	if err != nil {
+		return nil, err
+	}
+
+	var lease leader.Lease
+	switch cfg.Leader.Backend {
+	case config.LeaderRedis:
+		client, err := newRedisClient(cfg.Leader.RedisURL)
+		if err != nil {
+			return nil, err
+		}
+		r.Lifecycle().Append(lifecycle.Hook{
+			Name: LeaderElector,
+			OnStop: func(context.Context) error {
+				return client.Close()
+			},
+		})
+		lease = lockInfra.NewRedisLease(client)
+
+	case config.LeaderDatabase:
+		database, err := container.Resolve[*gorm.DB](r, Database)
+		if err != nil {
+			return nil, err
+		}
+		lease = moduleRepo.NewLeaseRepository(database)
+
+	default:
+		lease = lockInfra.NewLocalLease()
+	}
+
+	return leader.New(r.Lifecycle(), lease, "scheduler", leader.NewInstanceID(), cfg.Leader.LeaseTTL), nil
+}
+
+// provideSQLModuleRepository puts the name filter, when enabled, and the
+// read-through cache in front of the module table.
+func provideSQLModuleRepository(r container.Resolver) (any, error) {
+	database, err := container.Resolve[*gorm.DB](r, Database)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := container.Resolve[*config.Config](r, Config)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := container.Resolve[*moduleRepo.NameFilter](r, ModuleNameFilter)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := container.Resolve[db.IDGenerator](r, IDGenerator)
+	if err != nil {
+		return nil, err
+	}
+
+	var repo moduleService.ModuleRepository = moduleRepo.NewModuleRepository(database, ids)
+	if filter != nil {
+		repo = moduleRepo.NewNameFilteredModuleRepository(repo, filter)
+	}
+	cached := moduleRepo.NewCachedModuleRepository(repo, cfg.Database.CacheTTL, cfg.Database.CacheNotFoundTTL)
+
+	hook := lifecycle.Hook{
+		Name: "module.cache",
+		OnWarmUp: func(ctx context.Context) error {
+			count, err := cached.Preload(ctx, cachePreloadLimit)
+			if count > 0 {
+				logger.Infof("Preloaded %d active module(s) into the cache", count)
+			}
+			return err
+		},
+	}
+
+	// Broadcast module changes to the caches of the other instances
+	if cfg.Database.CacheInvalidationURL != "" {
+		client, err := newRedisClient(cfg.Database.CacheInvalidationURL)
+		if err != nil {
+			return nil, err
+		}
+		invalidations := pubsub.NewInvalidations(client, cfg.Database.CacheInvalidationChannel)
+		cached.OnInvalidate(func(keys []string) {
+			if err := invalidations.Publish(context.Background(), keys); err != nil {
+				logger.Warnf("Failed to broadcast cache invalidation of %v: %v", keys, err)
+			}
+		})
+
+		var cancel context.CancelFunc
+		hook.OnStart = func(context.Context) error {
+			var ctx context.Context
+			ctx, cancel = context.WithCancel(context.Background())
+			go invalidations.Listen(ctx, cached)
+			return nil
+		}
+		hook.OnStop = func(context.Context) error {
+			cancel()
+			return client.Close()
+		}
+	}
+
+	r.Lifecycle().Append(hook)
+	return cached, nil
+}
+
+// provideModuleNameFilter creates the module name filter; without a refresh interval it is disabled.
+func provideModuleNameFilter(r container.Resolver) (any, error) {
+	cfg, err := container.Resolve[*config.Config](r, Config)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Database.NameFilterRefresh <= 0 {
+		return (*moduleRepo.NameFilter)(nil), nil
+	}
+	database, err := container.Resolve[*gorm.DB](r, Database)
+	if err != nil {
+		return nil, err
+	}
+	// The filter only reads names, so the ID generator is never used
+	return moduleRepo.NewNameFilter(moduleRepo.NewModuleRepository(database, db.AutoIncrement{})), nil
+}
+
+// provideNameFilterScheduler rebuilds the module name filter on its refresh interval, starting at startup.
+func provideNameFilterScheduler(r container.Resolver) (any, error) {
+	cfg, err := container.Resolve[*config.Config](r, Config)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := container.Resolve[*moduleRepo.NameFilter](r, ModuleNameFilter)
+	if err != nil {
+		return nil, err
+	}
+	if filter == nil {
+		return (*scheduler.Scheduler)(nil), nil
+	}
+	return scheduler.New(r.Lifecycle(), cfg.Database.NameFilterRefresh, scheduler.Job{Name: "module.namefilter.refresh", Run: filter.Refresh}), nil
+}
+
+func provideSQLRevisionRepository(r container.Resolver) (any, error) {
+	database, err := container.Resolve[*gorm.DB](r, Database)
+	if err != nil {
+		return nil, err
+	}
+	return moduleRepo.NewRevisionRepository(database), nil
+}
+
+func provideInMemoryModuleRepository(r container.Resolver) (any, error) {
+	ids, err := container.Resolve[db.IDGenerator](r, IDGenerator)
+	if err != nil {
+		return nil, err
+	}
+	return moduleRepo.NewInMemoryModuleRepository(ids), nil
+}
+
+func provideSQLSummaryRepository(r container.Resolver) (any, error) {
+	database, err := container.Resolve[*gorm.DB](r, Database)
+	if err != nil {
+		return nil, err
+	}
+	return moduleRepo.NewSummaryRepository(database), nil
+}
+
+func provideSQLTagRepository(r container.Resolver) (any, error) {
+	database, err := container.Resolve[*gorm.DB](r, Database)
+	if err != nil {
+		return nil, err
+	}
+	return moduleRepo.NewTagRepository(database), nil
+}
+
+func provideSQLDependencyRepository(r container.Resolver) (any, error) {
+	database, err := container.Resolve[*gorm.DB](r, Database)
+	if err != nil {
+		return nil, err
+	}
+	return moduleRepo.NewDependencyRepository(database), nil
+}
+
+func provideSQLACLRepository(r container.Resolver) (any, error) {
+	database, err := container.Resolve[*gorm.DB](r, Database)
+	if err != nil {
+		return nil, err
+	}
+	return moduleRepo.NewACLRepository(database), nil
+}
+
+func provideSQLTransferRepository(r container.Resolver) (any, error) {
+	database, err := container.Resolve[*gorm.DB](r, Database)
+	if err != nil {
+		return nil, err
+	}
+	return moduleRepo.NewTransferRepository(database), nil
+}
+
+func provideSQLStarRepository(r container.Resolver) (any, error) {
+	database, err := container.Resolve[*gorm.DB](r, Database)
+	if err != nil {
+		return nil, err
+	}
+	return moduleRepo.NewStarRepository(database), nil
+}
+
+func provideSQLSettingRepository(r container.Resolver) (any, error) {
+	database, err := container.Resolve[*gorm.DB](r, Database)
+	if err != nil {
+		return nil, err
+	}
+	return moduleRepo.NewSettingRepository(database), nil
+}
+
+func provideSQLNoteRepository(r container.Resolver) (any, error) {
+	database, err := container.Resolve[*gorm.DB](r, Database)
+	if err != nil {
+		return nil, err
+	}
+	return moduleRepo.NewNoteRepository(database), nil
+}
+
+func provideSQLTemplateRepository(r container.Resolver) (any, error) {
+	database, err := container.Resolve[*gorm.DB](r, Database)
+	if err != nil {
+		return nil, err
+	}
+	return moduleRepo.NewTemplateRepository(database), nil
+}
+
+// provideSQLRetentionRepository drops archived modules from the module cache.
+func provideSQLRetentionRepository(r container.Resolver) (any, error) {
+	database, err := container.Resolve[*gorm.DB](r, Database)
+	if err != nil {
+		return nil, err
+	}
+	cached, err := container.Resolve[*moduleRepo.CachedModuleRepository](r, ModuleRepository)
+	if err != nil {
+		return nil, err
+	}
+	repo := moduleRepo.NewRetentionRepository(database)
+	repo.OnModulesChanged(cached.Invalidate)
+	return repo, nil
+}
+
+// provideSQLUserDataRepository drops the modules an erasure rewrites from the
+// module cache.
+func provideSQLUserDataRepository(r container.Resolver) (any, error) {
+	database, err := container.Resolve[*gorm.DB](r, Database)
+	if err != nil {
+		return nil, err
+	}
+	cached, err := container.Resolve[*moduleRepo.CachedModuleRepository](r, ModuleRepository)
+	if err != nil {
+		return nil, err
+	}
+	repo := moduleRepo.NewUserDataRepository(database)
+	repo.OnModulesChanged(cached.Invalidate)
+	return repo, nil
+}
+
+func provideSQLUsageRepository(r container.Resolver) (any, error) {
+	database, err := container.Resolve[*gorm.DB](r, Database)
+	if err != nil {
+		return nil, err
+	}
+	return moduleRepo.NewUsageRepository(database), nil
+}
+
+func provideSQLQuotaRepository(r container.Resolver) (any, error) {
+	database, err := container.Resolve[*gorm.DB](r, Database)
+	if err != nil {
+		return nil, err
+	}
+	return moduleRepo.NewQuotaRepository(database), nil
+}
+
+func provideSQLWorkflowRepository(r container.Resolver) (any, error) {
+	database, err := container.Resolve[*gorm.DB](r, Database)
+	if err != nil {
+		return nil, err
+	}
+	return moduleRepo.NewWorkflowRepository(database), nil
+}
+
+// provideSharedInMemoryRepository exposes the in-memory module repository under another repository name.
+func provideSharedInMemoryRepository(r container.Resolver) (any, error) {
+	return container.Resolve[*moduleRepo.InMemoryModuleRepository](r, ModuleRepository)
+}
+
+func provideModuleService(r container.Resolver) (any, error) {
+	repo, err := container.Resolve[moduleService.ModuleRepository](r, ModuleRepository)
+	if err != nil {
+		return nil, err
+	}
+	revisions, err := container.Resolve[moduleService.RevisionRepository](r, RevisionRepository)
+	if err != nil {
+		return nil, err
+	}
+	acl, err := container.Resolve[moduleService.ACLRepository](r, ACLRepository)
+	if err != nil {
+		return nil, err
+	}
+	transfers, err := container.Resolve[moduleService.TransferRepository](r, TransferRepository)
+	if err != nil {
+		return nil, err
+	}
+	stars, err := container.Resolve[moduleService.StarRepository](r, StarRepository)
+	if err != nil {
+		return nil, err
+	}
+	bus, err := container.Resolve[*events.Bus](r, EventBus)
+	if err != nil {
+		return nil, err
+	}
+	m, err := container.Resolve[metrics.Metrics](r, Metrics)
+	if err != nil {
+		return nil, err
+	}
+	return moduleService.NewModuleService(repo, revisions, acl, transfers, stars, bus, m)
+}
+
+func provideModuleScheduler(r container.Resolver) (any, error) {
+	cfg, err := container.Resolve[*config.Config](r, Config)
+	if err != nil {
+		return nil, err
+	}
+	service, err := container.Resolve[*moduleService.ModuleService](r, ModuleService)
+	if err != nil {
+		return nil, err
+	}
+	locks, err := container.Resolve[lock.Lock](r, Locks)
+	if err != nil {
+		return nil, err
+	}
+	elector, err := container.Resolve[*leader.Elector](r, LeaderElector)
+	if err != nil {
+		return nil, err
+	}
+	return scheduler.New(r.Lifecycle(), cfg.Scheduler.Interval, scheduler.LeaderOnly(elector, scheduler.Exclusive(locks, cfg.Scheduler.Interval, moduleScheduleJob(service)))), nil
+}
+
+func provideNotifier(r container.Resolver) (any, error) {
+	cfg, err := container.Resolve[*config.Config](r, Config)
+	if err != nil {
+		return nil, err
+	}
+	bus, err := container.Resolve[*events.Bus](r, EventBus)
+	if err != nil {
+		return nil, err
+	}
+	templates, err := container.Resolve[*templating.Engine](r, Templates)
+	if err != nil {
+		return nil, err
+	}
+	return notifier.New(r.Lifecycle(), bus, cfg.Notifications.Routes, notifiers(cfg.Notifications, cfg.HTTPClient), templates), nil
+}
+
+func provideTemplates(r container.Resolver) (any, error) {
+	cfg, err := container.Resolve[*config.Config](r, Config)
+	if err != nil {
+		return nil, err
+	}
+	return templating.New(cfg.Templates.Dir)
+}
+
+// provideMessages loads the message bundles and hooks them into the request validator.
+func provideMessages(r container.Resolver) (any, error) {
+	cfg, err := container.Resolve[*config.Config](r, Config)
+	if err != nil {
+		return nil, err
+	}
+	bundle, err := i18n.New(cfg.I18n.Dir)
+	if err != nil {
+		return nil, err
+	}
+	if err := bundle.RegisterTranslations(binding.Validator.Engine().(*validator.Validate)); err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
+
+// notifiers builds a notifier for every channel used by the routing rules;
+// with NOTIFY_FAKE every channel gets a fake notifier recording the messages.
+func notifiers(cfg config.NotificationConfig, clientCfg config.HTTPClientConfig) map[string]notification.Notifier {
+	used := cfg.Routes.Used()
+	built := make(map[string]notification.Notifier)
+	if cfg.Fake {
+		for channel := range used {
+			built[channel] = notify.NewFakeNotifier(channel)
+		}
+		return built
+	}
+	if used[notification.ChannelEmail] {
+		built[notification.ChannelEmail] = notify.NewSMTPNotifier(notify.SMTPConfig{
+			Addr:     cfg.SMTPAddr,
+			From:     cfg.SMTPFrom,
+			To:       cfg.SMTPTo,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+		})
+	}
+	if used[notification.ChannelWebhook] {
+		built[notification.ChannelWebhook] = notify.NewWebhookNotifier(cfg.WebhookURL, httpClient("webhook", clientCfg))
+	}
+	if used[notification.ChannelSlack] {
+		built[notification.ChannelSlack] = notify.NewSlackNotifier(cfg.SlackWebhookURL, httpClient("slack", clientCfg))
+	}
+	return built
+}
+
+// provideCDNPurger creates the CDN purger; without a purge URL purges are discarded.
+//
+// Scheduled module transitions are purged through the event bus, since they
+// do not pass the purge middleware of write requests.
+func provideCDNPurger(r container.Resolver) (any, error) {
+	cfg, err := container.Resolve[*config.Config](r, Config)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.CDN.PurgeURL == "" {
+		return cdn.Discard, nil
+	}
+	bus, err := container.Resolve[*events.Bus](r, EventBus)
+	if err != nil {
+		return nil, err
+	}
+
+	purger := cdnInfra.NewHTTPPurger(cfg.CDN.PurgeURL, cfg.CDN.PurgeToken, httpClient("cdn", cfg.HTTPClient))
+	bus.Subscribe(cdn.EventSubscriber(purger))
+	return cdn.Purger(purger), nil
+}
+
+// commandQueue is the broker queue of the command consumer.
+type commandQueue interface {
+	queue.Consumer
+	queue.Publisher
+	Close() error
+}
+
+// commandQueueRef holds the command queue and its dead-letter queue; it is
+// nil without a queue URL.
+type commandQueueRef struct {
+	queue       commandQueue
+	deadLetters queue.DeadLetters
+}
+
+// provideCommandQueue connects to the Redis or RabbitMQ command queue picked
+// by the URL scheme; without a queue URL the command queue is disabled.
+//
+// A Redis queue gets a client of its own; waiting for commands holds one
+// connection of its pool while the dead letters are read on another.
+func provideCommandQueue(r container.Resolver) (any, error) {
+	cfg, err := container.Resolve[*config.Config](r, Config)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Commands.QueueURL == "" {
+		return (*commandQueueRef)(nil), nil
+	}
+
+	ref := &commandQueueRef{}
+	if strings.HasPrefix(cfg.Commands.QueueURL, "amqp") {
+		rabbitMQ := queueInfra.NewRabbitMQQueue(queueInfra.RabbitMQConfig{
+			URL:      cfg.Commands.QueueURL,
+			Exchange: cfg.Commands.Exchange,
+			Queue:    cfg.Commands.Queue,
+			Prefetch: cfg.Commands.Prefetch,
+		})
+		ref.queue, ref.deadLetters = rabbitMQ, rabbitMQ
+	} else {
+		client, err := newRedisClient(cfg.Commands.QueueURL)
+		if err != nil {
+			return nil, err
+		}
+		redisQueue := queueInfra.NewRedisQueue(client, cfg.Commands.Queue)
+		ref.queue, ref.deadLetters = redisQueue, redisQueue
+	}
+	r.Lifecycle().Append(lifecycle.Hook{
+		Name:   "commands.queue",
+		OnStop: func(context.Context) error { return ref.queue.Close() },
+	})
+	return ref, nil
+}
+
+// provideCommandConsumer consumes module commands from the command queue;
+// without a queue no consumer runs.
+func provideCommandConsumer(r container.Resolver) (any, error) {
+	cfg, err := container.Resolve[*config.Config](r, Config)
+	if err != nil {
+		return nil, err
+	}
+	commandQueue, err := container.Resolve[*commandQueueRef](r, CommandQueue)
+	if err != nil {
+		return nil, err
+	}
+	if commandQueue == nil {
+		return (*commands.Consumer)(nil), nil
+	}
+	service, err := container.Resolve[*moduleService.ModuleService](r, ModuleService)
+	if err != nil {
+		return nil, err
+	}
+	purger, err := container.Resolve[cdn.Purger](r, CDNPurger)
+	if err != nil {
+		return nil, err
+	}
+	m, err := container.Resolve[metrics.Metrics](r, Metrics)
+	if err != nil {
+		return nil, err
+	}
+
+	return commands.New(r.Lifecycle(), commandQueue.queue, commandQueue.queue, cfg.Commands.ReplyTopic, cfg.Commands.MaxRetries, service, purger, m), nil
+}
+
+// provideDeadLetterService exposes the dead-letter queue of the command
+// queue; without a queue its calls fail with ErrDisabled.
+func provideDeadLetterService(r container.Resolver) (any, error) {
+	commandQueue, err := container.Resolve[*commandQueueRef](r, CommandQueue)
+	if err != nil {
+		return nil, err
+	}
+	m, err := container.Resolve[metrics.Metrics](r, Metrics)
+	if err != nil {
+		return nil, err
+	}
+	if commandQueue == nil {
+		return deadLetterService.NewDeadLetterService(nil, m)
+	}
+	return deadLetterService.NewDeadLetterService(commandQueue.deadLetters, m)
+}
+
+func provideDeadLetterHandler(r container.Resolver) (any, error) {
+	service, err := container.Resolve[*deadLetterService.DeadLetterService](r, DeadLetterService)
+	if err != nil {
+		return nil, err
+	}
+	return handlers.NewDeadLetterHandler(service), nil
+}
+
+// provideAllocator reserves module resources on the provisioning service;
+// without a provisioning URL a local stand-in allocates nothing.
+func provideAllocator(r container.Resolver) (any, error) {
+	cfg, err := container.Resolve[*config.Config](r, Config)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Provisioning.URL == "" {
+		return provisioning.Local, nil
+	}
+	client := httpClient("provisioning", cfg.HTTPClient)
+	return provisioning.Allocator(provisioningInfra.NewHTTPAllocator(cfg.Provisioning.URL, cfg.Provisioning.Token, client)), nil
+}
+
+func provideWorkflowService(r container.Resolver) (any, error) {
+	repo, err := container.Resolve[workflowService.WorkflowRepository](r, WorkflowRepository)
+	if err != nil {
+		return nil, err
+	}
+	modules, err := container.Resolve[*moduleService.ModuleService](r, ModuleService)
+	if err != nil {
+		return nil, err
+	}
+	allocator, err := container.Resolve[provisioning.Allocator](r, Allocator)
+	if err != nil {
+		return nil, err
+	}
+	bus, err := container.Resolve[*events.Bus](r, EventBus)
+	if err != nil {
+		return nil, err
+	}
+	return workflowService.NewWorkflowService(repo, workflowService.NewProvisioningWorkflow(modules, allocator, bus))
+}
+
+func provideSagaOrchestrator(r container.Resolver) (any, error) {
+	service, err := container.Resolve[*workflowService.WorkflowService](r, WorkflowService)
+	if err != nil {
+		return nil, err
+	}
+	return saga.New(r.Lifecycle(), service), nil
+}
+
+func provideWorkflowHandler(r container.Resolver) (any, error) {
+	orchestrator, err := container.Resolve[*saga.Orchestrator](r, SagaOrchestrator)
+	if err != nil {
+		return nil, err
+	}
+	service, err := container.Resolve[*workflowService.WorkflowService](r, WorkflowService)
+	if err != nil {
+		return nil, err
+	}
+	return handlers.NewWorkflowHandler(orchestrator, service), nil
+}
+
+// swaggerOptions serves the API documentation with the configured protection
+// and deployment address.
+func swaggerOptions(cfg config.SwaggerConfig) router.SwaggerOptions {
+	opts := router.SwaggerOptions{
+		Disabled: !cfg.Enabled,
+		Host:     cfg.Host,
+		BasePath: cfg.BasePath,
+		Schemes:  cfg.Schemes,
+	}
+	switch cfg.Auth {
+	case config.SwaggerAuthBasic:
+		opts.Guard = []gin.HandlerFunc{gin.BasicAuthForRealm(gin.Accounts{cfg.Username: cfg.Password}, "API documentation")}
+	case config.SwaggerAuthAdmin:
+		opts.Guard = []gin.HandlerFunc{router.RequireScope(auth.ScopeAdmin)}
+	}
+	return opts
+}
+
+// httpClient builds the outbound client of an integration.
+func httpClient(name string, cfg config.HTTPClientConfig) *httpclient.Client {
+	return httpclient.New(name, httpclient.Config{
+		Timeout:          cfg.Timeout,
+		MaxRetries:       cfg.Retries,
+		RetryBackoff:     cfg.RetryBackoff,
+		BreakerThreshold: cfg.BreakerThreshold,
+		BreakerCooldown:  cfg.BreakerCooldown,
+		Proxy:            cfg.Proxy,
+		NoProxy:          cfg.NoProxy,
+		RootCAs:          cfg.RootCAs,
+		TLSMinVersion:    cfg.TLSMinVersion,
+	})
+}
+
+// moduleScheduleJob applies due module activation schedules.
+func moduleScheduleJob(service *moduleService.ModuleService) scheduler.Job {
+	return scheduler.Job{
+		Name: "module.schedules",
+		Run: func(ctx context.Context) error {
+			_, err := service.ApplySchedules(ctx, time.Now())
+			return err
+		},
+	}
+}
+
+func provideModuleHandler(r container.Resolver) (any, error) {
+	service, err := container.Resolve[*moduleService.ModuleService](r, ModuleService)
+	if err != nil {
+		return nil, err
+	}
+	templates, err := container.Resolve[*templating.Engine](r, Templates)
+	if err != nil {
+		return nil, err
+	}
+	return handlers.NewModuleHandler(service, templates), nil
+}
+
+func provideTagService(r container.Resolver) (any, error) {
+	repo, err := container.Resolve[tagService.TagRepository](r, TagRepository)
+	if err != nil {
+		return nil, err
+	}
+	modules, err := container.Resolve[*moduleService.ModuleService](r, ModuleService)
+	if err != nil {
+		return nil, err
+	}
+	return tagService.NewTagService(repo, modules)
+}
+
+func provideTagHandler(r container.Resolver) (any, error) {
+	service, err := container.Resolve[*tagService.TagService](r, TagService)
+	if err != nil {
+		return nil, err
+	}
+	return handlers.NewTagHandler(service), nil
+}
+
+func provideDependencyService(r container.Resolver) (any, error) {
+	repo, err := container.Resolve[dependencyService.DependencyRepository](r, DependencyRepository)
+	if err != nil {
+		return nil, err
+	}
+	modules, err := container.Resolve[*moduleService.ModuleService](r, ModuleService)
+	if err != nil {
+		return nil, err
+	}
+	return dependencyService.NewDependencyService(repo, modules)
+}
+
+func provideDependencyHandler(r container.Resolver) (any, error) {
+	service, err := container.Resolve[*dependencyService.DependencyService](r, DependencyService)
+	if err != nil {
+		return nil, err
+	}
+	return handlers.NewDependencyHandler(service), nil
+}
+
+func provideSettingService(r container.Resolver) (any, error) {
+	repo, err := container.Resolve[settingService.SettingRepository](r, SettingRepository)
+	if err != nil {
+		return nil, err
+	}
+	modules, err := container.Resolve[*moduleService.ModuleService](r, ModuleService)
+	if err != nil {
+		return nil, err
+	}
+	schemas, err := container.Resolve[*settingService.SchemaRegistry](r, SettingSchemas)
+	if err != nil {
+		return nil, err
+	}
+	return settingService.NewSettingService(repo, modules, schemas)
+}
+
+func provideSettingHandler(r container.Resolver) (any, error) {
+	service, err := container.Resolve[*settingService.SettingService](r, SettingService)
+	if err != nil {
+		return nil, err
+	}
+	return handlers.NewSettingHandler(service), nil
+}
+
+func provideNoteService(r container.Resolver) (any, error) {
+	repo, err := container.Resolve[noteService.NoteRepository](r, NoteRepository)
+	if err != nil {
+		return nil, err
+	}
+	modules, err := container.Resolve[*moduleService.ModuleService](r, ModuleService)
+	if err != nil {
+		return nil, err
+	}
+	return noteService.NewNoteService(repo, modules)
+}
+
+func provideNoteHandler(r container.Resolver) (any, error) {
+	service, err := container.Resolve[*noteService.NoteService](r, NoteService)
+	if err != nil {
+		return nil, err
+	}
+	return handlers.NewNoteHandler(service), nil
+}
+
+func provideTemplateService(r container.Resolver) (any, error) {
+	repo, err := container.Resolve[templateService.TemplateRepository](r, TemplateRepository)
+	if err != nil {
+		return nil, err
+	}
+	modules, err := container.Resolve[*moduleService.ModuleService](r, ModuleService)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := container.Resolve[*settingService.SettingService](r, SettingService)
+	if err != nil {
+		return nil, err
+	}
+	return templateService.NewTemplateService(repo, modules, settings)
+}
+
+func provideTemplateHandler(r container.Resolver) (any, error) {
+	service, err := container.Resolve[*templateService.TemplateService](r, TemplateService)
+	if err != nil {
+		return nil, err
+	}
+	return handlers.NewTemplateHandler(service), nil
+}
+
+// provideObjectStorage builds the object storage selected by the configuration.
+func provideObjectStorage(r container.Resolver) (any, error) {
+	cfg, err := container.Resolve[*config.Config](r, Config)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Export.Storage == config.ExportStorageS3 {
+		// Transfers of whole export files are bounded by their operation
+		clientCfg := cfg.HTTPClient
+		clientCfg.Timeout = 0
+		store, err := objectStorage.NewS3Storage(objectStorage.S3Config{
+			Endpoint:        cfg.Export.S3Endpoint,
+			Region:          cfg.Export.S3Region,
+			Bucket:          cfg.Export.S3Bucket,
+			AccessKeyID:     cfg.Export.S3AccessKeyID,
+			SecretAccessKey: cfg.Export.S3SecretAccessKey,
+			ForcePathStyle:  cfg.Export.S3ForcePathStyle,
+		}, httpClient("s3", clientCfg))
+		if err != nil {
+			return nil, err
+		}
+		return storage.Storage(store), nil
+	}
+	return objectStorage.NewLocalStorage(cfg.Export.Dir, cfg.Export.SigningKey, router.DownloadPrefix), nil
+}
+
+func provideExportService(r container.Resolver) (any, error) {
+	modules, err := container.Resolve[*moduleService.ModuleService](r, ModuleService)
+	if err != nil {
+		return nil, err
+	}
+	store, err := container.Resolve[storage.Storage](r, ObjectStorage)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := container.Resolve[*config.Config](r, Config)
+	if err != nil {
+		return nil, err
+	}
+	return exportService.NewExportService(modules, store, cfg.Export.URLTTL)
+}
+
+func provideExportHandler(r container.Resolver) (any, error) {
+	service, err := container.Resolve[*exportService.ExportService](r, ExportService)
+	if err != nil {
+		return nil, err
+	}
+	runner, err := container.Resolve[*jobs.Runner](r, JobRunner)
+	if err != nil {
+		return nil, err
+	}
+	store, err := r.Resolve(ObjectStorage)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the local storage serves downloads through the API
+	downloads, _ := store.(handlers.DownloadSource)
+	return handlers.NewExportHandler(service, runner, downloads), nil
+}
+
+func provideBackupService(r container.Resolver) (any, error) {
+	modules, err := container.Resolve[moduleService.ModuleRepository](r, ModuleRepository)
+	if err != nil {
+		return nil, err
+	}
+	importer, err := container.Resolve[*moduleService.ModuleService](r, ModuleService)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := container.Resolve[tagService.TagRepository](r, TagRepository)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := container.Resolve[settingService.SettingRepository](r, SettingRepository)
+	if err != nil {
+		return nil, err
+	}
+	store, err := container.Resolve[storage.Storage](r, ObjectStorage)
+	if err != nil {
+		return nil, err
+	}
+	locks, err := container.Resolve[lock.Lock](r, Locks)
+	if err != nil {
+		return nil, err
+	}
+	m, err := container.Resolve[metrics.Metrics](r, Metrics)
+	if err != nil {
+		return nil, err
+	}
+	return backupService.NewBackupService(modules, importer, tags, settings, store, locks, m)
+}
+
+func provideBackupHandler(r container.Resolver) (any, error) {
+	service, err := container.Resolve[*backupService.BackupService](r, BackupService)
+	if err != nil {
+		return nil, err
+	}
+	runner, err := container.Resolve[*jobs.Runner](r, JobRunner)
+	if err != nil {
+		return nil, err
+	}
+	return handlers.NewBackupHandler(service, runner), nil
+}
+
+func provideRetentionService(r container.Resolver) (any, error) {
+	repo, err := container.Resolve[retentionService.RetentionRepository](r, RetentionRepository)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := container.Resolve[*config.Config](r, Config)
+	if err != nil {
+		return nil, err
+	}
+	return retentionService.NewRetentionService(repo, cfg.Retention.Rules, cfg.Retention.DryRun, cfg.Retention.Interval)
+}
+
+func provideProjectionService(r container.Resolver) (any, error) {
+	repo, err := container.Resolve[projectionService.SummaryRepository](r, SummaryRepository)
+	if err != nil {
+		return nil, err
+	}
+	return projectionService.NewProjectionService(repo, projectionService.DefaultBatchSize)
+}
+
+// provideRetentionScheduler runs the retention rules on their own interval; without rules nothing is scheduled.
+func provideRetentionScheduler(r container.Resolver) (any, error) {
+	cfg, err := container.Resolve[*config.Config](r, Config)
+	if err != nil {
+		return nil, err
+	}
+	if len(cfg.Retention.Rules) == 0 {
+		return (*scheduler.Scheduler)(nil), nil
+	}
+	service, err := container.Resolve[*retentionService.RetentionService](r, RetentionService)
+	if err != nil {
+		return nil, err
+	}
+	locks, err := container.Resolve[lock.Lock](r, Locks)
+	if err != nil {
+		return nil, err
+	}
+	elector, err := container.Resolve[*leader.Elector](r, LeaderElector)
+	if err != nil {
+		return nil, err
+	}
+	return scheduler.New(r.Lifecycle(), cfg.Retention.Interval, scheduler.LeaderOnly(elector, scheduler.Exclusive(locks, cfg.Retention.Interval, retentionJob(service)))), nil
+}
+
+// retentionJob applies the data retention rules.
+func retentionJob(service *retentionService.RetentionService) scheduler.Job {
+	return scheduler.Job{
+		Name: "retention",
+		Run: func(ctx context.Context) error {
+			_, err := service.Apply(ctx, time.Now())
+			return err
+		},
+	}
+}
+
+func provideRetentionHandler(r container.Resolver) (any, error) {
+	service, err := container.Resolve[*retentionService.RetentionService](r, RetentionService)
+	if err != nil {
+		return nil, err
+	}
+	runner, err := container.Resolve[*jobs.Runner](r, JobRunner)
+	if err != nil {
+		return nil, err
+	}
+	return handlers.NewRetentionHandler(service, runner), nil
+}
+
+func providePrivacyService(r container.Resolver) (any, error) {
+	repo, err := container.Resolve[privacyService.UserDataRepository](r, UserDataRepository)
+	if err != nil {
+		return nil, err
+	}
+	return privacyService.NewPrivacyService(repo)
+}
+
+func providePrivacyHandler(r container.Resolver) (any, error) {
+	service, err := container.Resolve[*privacyService.PrivacyService](r, PrivacyService)
+	if err != nil {
+		return nil, err
+	}
+	return handlers.NewPrivacyHandler(service), nil
+}
+
+// provideUsageService builds the usage service; its stop hook flushes the counts recorded since the last flush.
+func provideUsageService(r container.Resolver) (any, error) {
+	repo, err := container.Resolve[usageService.UsageRepository](r, UsageRepository)
+	if err != nil {
+		return nil, err
+	}
+	modules, err := container.Resolve[*moduleService.ModuleService](r, ModuleService)
+	if err != nil {
+		return nil, err
+	}
+	service, err := usageService.NewUsageService(repo, modules)
+	if err != nil {
+		return nil, err
+	}
+	r.Lifecycle().Append(lifecycle.Hook{
+		Name:   UsageService,
+		OnStop: service.Flush,
+	})
+	return service, nil
+}
+
+// provideUsageScheduler writes the usage counts on their own interval.
+func provideUsageScheduler(r container.Resolver) (any, error) {
+	cfg, err := container.Resolve[*config.Config](r, Config)
+	if err != nil {
+		return nil, err
+	}
+	service, err := container.Resolve[*usageService.UsageService](r, UsageService)
+	if err != nil {
+		return nil, err
+	}
+	return scheduler.New(r.Lifecycle(), cfg.Usage.FlushInterval, scheduler.Job{Name: "usage.flush", Run: service.Flush}), nil
+}
+
+func provideUsageHandler(r container.Resolver) (any, error) {
+	service, err := container.Resolve[*usageService.UsageService](r, UsageService)
+	if err != nil {
+		return nil, err
+	}
+	return handlers.NewUsageHandler(service), nil
+}
+
+// provideQuotaService builds the quota service; its stop hook flushes the requests counted since the last flush.
+func provideQuotaService(r container.Resolver) (any, error) {
+	repo, err := container.Resolve[quotaService.QuotaRepository](r, QuotaRepository)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := container.Resolve[*config.Config](r, Config)
+	if err != nil {
+		return nil, err
+	}
+	service, err := quotaService.NewQuotaService(repo, cfg.Auth.Quotas)
+	if err != nil {
+		return nil, err
+	}
+	r.Lifecycle().Append(lifecycle.Hook{
+		Name:   QuotaService,
+		OnStop: service.Flush,
+	})
+	return service, nil
+}
+
+// provideQuotaScheduler writes the API key request counts on the usage flush interval.
+func provideQuotaScheduler(r container.Resolver) (any, error) {
+	cfg, err := container.Resolve[*config.Config](r, Config)
+	if err != nil {
+		return nil, err
+	}
+	service, err := container.Resolve[*quotaService.QuotaService](r, QuotaService)
+	if err != nil {
+		return nil, err
+	}
+	return scheduler.New(r.Lifecycle(), cfg.Usage.FlushInterval, scheduler.Job{Name: "quota.flush", Run: service.Flush}), nil
+}
+
+func provideAccountHandler(r container.Resolver) (any, error) {
+	service, err := container.Resolve[*quotaService.QuotaService](r, QuotaService)
+	if err != nil {
+		return nil, err
+	}
+	return handlers.NewAccountHandler(service), nil
+}
+
+func provideJobHandler(r container.Resolver) (any, error) {
+	runner, err := container.Resolve[*jobs.Runner](r, JobRunner)
+	if err != nil {
+		return nil, err
+	}
+	return handlers.NewJobHandler(runner), nil
+}
+
+func provideActivityRecorder(r container.Resolver) (any, error) {
+	cfg, err := container.Resolve[*config.Config](r, Config)
+	if err != nil {
+		return nil, err
+	}
+	return activity.NewRecorder(activity.DefaultCapacity, cfg.Dashboard.SlowRequestThreshold), nil
+}
+
+func provideDashboardHandler(r container.Resolver) (any, error) {
+	recorder, err := container.Resolve[*activity.Recorder](r, ActivityRecorder)
+	if err != nil {
+		return nil, err
+	}
+	runner, err := container.Resolve[*jobs.Runner](r, JobRunner)
+	if err != nil {
+		return nil, err
+	}
+	dispatcher, err := container.Resolve[*notifier.Dispatcher](r, Notifier)
+	if err != nil {
+		return nil, err
+	}
+	return handlers.NewDashboardHandler(recorder, runner, dispatcher), nil
+}
+
+// provideRouter builds the Gin engine with the routes of every handler tagged
+// RoutableTag; the container is needed for the request-scope middleware.
+func provideRouter(r container.Resolver, c *container.Container) (any, error) {
+	cfg, err := container.Resolve[*config.Config](r, Config)
+	if err != nil {
+		return nil, err
+	}
+	routables, err := container.ResolveTagged[handlers.Routable](r, RoutableTag)
+	if err != nil {
+		return nil, err
+	}
+	recorder, err := container.Resolve[*activity.Recorder](r, ActivityRecorder)
+	if err != nil {
+		return nil, err
+	}
+	usage, err := container.Resolve[*usageService.UsageService](r, UsageService)
+	if err != nil {
+		return nil, err
+	}
+	quotas, err := container.Resolve[*quotaService.QuotaService](r, QuotaService)
+	if err != nil {
+		return nil, err
+	}
+	messages, err := container.Resolve[*i18n.Bundle](r, Messages)
+	if err != nil {
+		return nil, err
+	}
+	purger, err := container.Resolve[cdn.Purger](r, CDNPurger)
+	if err != nil {
+		return nil, err
+	}
+	watchdog, err := container.Resolve[*db.Watchdog](r, DatabaseWatchdog)
+	if err != nil {
+		return nil, err
+	}
+	counter, err := container.Resolve[*db.QueryCounter](r, QueryCounter)
+	if err != nil {
+		return nil, err
+	}
+	tracer, err := container.Resolve[*db.StatementTracer](r, StatementTracer)
+	if err != nil {
+		return nil, err
+	}
+
+	r.Lifecycle().Append(lifecycle.Hook{Name: "validators", OnWarmUp: handlers.WarmUpValidators})
+
+	// The router writes the access log itself, sampled and with runtime levels
+	engine := gin.New()
+	engine.Use(gin.Recovery())
+	if err := engine.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("invalid trusted proxies: %w", err)
+	}
+	opts := router.Options{
+		RequestIDStrategy: cfg.RequestID.Strategy,
+		UsageRecorder:     usage,
+		Chaos:             cfg.Chaos.Rules,
+		Readiness:         r.Lifecycle().Ready,
+		LogSampler:        logging.NewSampler(cfg.Logging.SampleInitial, cfg.Logging.SampleThereafter),
+		Messages:          messages,
+		Activity:          recorder,
+		PublicCacheMaxAge: cfg.Public.CacheMaxAge,
+		CDNPurger:         purger,
+		CDNMaxAge:         cfg.CDN.MaxAge,
+		Swagger:           swaggerOptions(cfg.Swagger),
+	}
+	if watchdog != nil {
+		opts.Dependencies = map[string]func() error{"database": watchdog.Err}
+	}
+	opts.UnitOfWork = cfg.Database.Driver != config.DriverMemory
+	if counter != nil {
+		opts.QueryTracker, opts.QueryBudget = counter, cfg.Database.QueryBudget
+	}
+	opts.SlowRequestThreshold = cfg.Logging.SlowRequestThreshold
+	if tracer != nil {
+		opts.StatementTracer = tracer
+	}
+	if cfg.Public.RateLimit > 0 {
+		opts.PublicRateLimiter = middleware.NewRateLimiter(cfg.Public.RateLimit, time.Minute)
+	}
+	if len(opts.Chaos) > 0 {
+		logger.Warnf("Chaos middleware injecting faults on %d rule(s) in %s", len(opts.Chaos), cfg.Environment)
+	}
+	if cfg.Auth.Enabled() {
+		opts.Authenticator = auth.NewTokenAuthenticator([]byte(cfg.Auth.JWTSecret), cfg.Auth.APIKeys)
+		opts.QuotaLimiter = quotas
+	} else {
+		opts.Anonymous = auth.NewAnonymous(cfg.Auth.AnonymousScopes)
+	}
+	router.SetupRouter(engine, c, opts, routables)
+	return engine, nil
+}
+
+func provideServer(r container.Resolver) (any, error) {
+	engine, err := container.Resolve[*gin.Engine](r, HTTPRouter)
+	if err != nil {
+		return nil, err
+	}
+	return server.NewHTTPServer(r.Lifecycle(), engine), nil
+}
+
+func provideResponseMapper(r container.Resolver) (any, error) {
+	cfg, err := container.Resolve[*config.Config](r, Config)
+	if err != nil {
+		return nil, err
+	}
+	clk, err := container.Resolve[clock.Clock](r, Clock)
+	if err != nil {
+		return nil, err
+	}
+	requestID, err := container.Resolve[string](r, RequestID)
+	if err != nil {
+		return nil, err
+	}
+	return response.NewResponseMapper(requestID).
+		UseNaming(cfg.Response.Naming).
+		UseTimeFormat(cfg.Response.TimeFormat, cfg.Response.UTC).
+		UseClock(clk), nil
+}
+
+// provideClock freezes the clock at CLOCK_FIXED_AT; otherwise it follows the system clock.
+func provideClock(r container.Resolver) (any, error) {
+	cfg, err := container.Resolve[*config.Config](r, Config)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Clock.FixedAt.IsZero() {
+		return clock.System, nil
+	}
+	return clock.Fixed(cfg.Clock.FixedAt), nil
+}
+
+// provideSeeder creates the sample modules on start; without SEED_DATA nothing is seeded.
+func provideSeeder(r container.Resolver) (any, error) {
+	cfg, err := container.Resolve[*config.Config](r, Config)
+	if err != nil {
+		return nil, err
+	}
+	if !cfg.Seed.Enabled {
+		return (*seed.Seeder)(nil), nil
+	}
+	modules, err := container.Resolve[*moduleService.ModuleService](r, ModuleService)
+	if err != nil {
+		return nil, err
+	}
+	return seed.New(r.Lifecycle(), modules), nil
+}
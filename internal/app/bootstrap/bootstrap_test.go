@@ -0,0 +1,38 @@
+package bootstrap_test
+
+import (
+	"testing"
+
+	"go_di_architecture/internal/app/bootstrap"
+	"go_di_architecture/internal/app/container"
+	"go_di_architecture/internal/config"
+	"go_di_architecture/internal/domain/models/module"
+	moduleService "go_di_architecture/internal/domain/service/module"
+)
+
+// TestMemoryDriverUsesConfiguredIDGenerator checks the in-memory backend
+// assigns module IDs with the configured generator, like the SQL backends.
+func TestMemoryDriverUsesConfiguredIDGenerator(t *testing.T) {
+	t.Setenv("APP_ENV", config.EnvTest)
+	t.Setenv("DB_ID_GENERATOR", config.IDGeneratorSnowflake)
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("config.Load() error = %v", err)
+	}
+	c, err := bootstrap.NewContainer(cfg)
+	if err != nil {
+		t.Fatalf("bootstrap.NewContainer() error = %v", err)
+	}
+	repo, err := container.Resolve[moduleService.ModuleRepository](c, bootstrap.ModuleRepository)
+	if err != nil {
+		t.Fatalf("resolve module repository: %v", err)
+	}
+
+	created, err := repo.CreateModule(&module.Module{Name: "payments", Status: module.StatusDraft})
+	if err != nil {
+		t.Fatalf("CreateModule() error = %v", err)
+	}
+	if created.ID < 1<<22 {
+		t.Errorf("CreateModule() ID = %d, want a snowflake ID", created.ID)
+	}
+}
@@ -0,0 +1,348 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+
+	"go_di_architecture/internal/app/lifecycle"
+	"go_di_architecture/internal/domain/cdn"
+	"go_di_architecture/internal/domain/metrics"
+	"go_di_architecture/internal/domain/models/command"
+	"go_di_architecture/internal/domain/models/module"
+	"go_di_architecture/internal/domain/models/response"
+	"go_di_architecture/internal/domain/queue"
+	moduleService "go_di_architecture/internal/domain/service/module"
+	"go_di_architecture/internal/i18n"
+	"go_di_architecture/internal/logging"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// logger writes the log lines of the command consumer.
+var logger = logging.New("commands")
+
+// Timeouts of the command consumer
+const (
+	// replyTimeout bounds publishing a reply and acknowledging its command
+	replyTimeout = 5 * time.Second
+
+	// retryDelay is the pause after the queue could not be read
+	retryDelay = 5 * time.Second
+)
+
+// malformedCommand is the error code of messages that are not JSON commands.
+const malformedCommand = "MALFORMED_COMMAND"
+
+// Consumer executes module commands received from a message queue.
+//
+// Producers that cannot call the HTTP API push commands (see
+// command.Command) onto the queue; each is validated with the binding rules
+// of the HTTP request bodies, executed by the module service with the actor
+// and teams of the command, and answered with a command.Reply on the reply
+// topic. Commands are processed one at a time, in queue order, so an update
+// never overtakes the create before it.
+//
+// Every received command is acknowledged once executed, including commands
+// that were refused (4xx): their failure is reported in the reply, and
+// retrying them would fail again. Commands failing with a server error (5xx),
+// e.g. while the database is unreachable, are requeued behind the waiting
+// commands without a reply, up to maxRetries times; then they are moved to
+// the dead-letter queue with the failure as reply. Messages that are not
+// commands at all, and commands whose execution panicked, are quarantined in
+// the dead-letter queue right away, so one bad payload never blocks the
+// stream. Operators inspect and replay dead letters through the admin API. A
+// reply that cannot be published is logged and lost.
+//
+// Attempts are counted in memory per payload, so a restart grants requeued
+// commands a fresh set of retries.
+//
+// Metrics:
+//   - commands_retried_total: Commands requeued after a server error
+//   - commands_dead_lettered_total: Commands moved to the dead-letter queue,
+//     by reason (malformed, panic, retries_exhausted)
+//
+// Lifecycle:
+//   - OnStart launches the receive loop
+//   - OnStop ends the loop after the command in progress, bounded by the
+//     stop context
+type Consumer struct {
+	queue      queue.Consumer
+	replies    queue.Publisher
+	replyTopic string
+	maxRetries int
+	modules    *moduleService.ModuleService
+	purger     cdn.Purger
+
+	// attempts counts the failed attempts per message ID; only the receive
+	// loop uses it
+	attempts     map[string]int
+	retried      metrics.Counter
+	deadLettered metrics.Counter
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// New creates a consumer and registers its lifecycle hooks.
+//
+// Parameters:
+//   - lc: Application lifecycle to register start/stop hooks with
+//   - commands: Queue the commands arrive on
+//   - replies: Publisher of the replies
+//   - replyTopic: Topic of replies to commands without replyTo
+//   - maxRetries: Requeues of a command failing with a server error before it
+//     is dead-lettered
+//   - modules: Service executing the commands
+//   - purger: CDN purged after successful changes
+//   - m: Business metrics recording retries and dead letters
+//
+// Returns:
+//   - *Consumer: The consumer (started by the lifecycle)
+func New(lc *lifecycle.Lifecycle, commands queue.Consumer, replies queue.Publisher, replyTopic string, maxRetries int, modules *moduleService.ModuleService, purger cdn.Purger, m metrics.Metrics) *Consumer {
+	c := &Consumer{
+		queue:        commands,
+		replies:      replies,
+		replyTopic:   replyTopic,
+		maxRetries:   maxRetries,
+		modules:      modules,
+		purger:       purger,
+		attempts:     make(map[string]int),
+		retried:      m.Counter("commands_retried_total"),
+		deadLettered: m.Counter("commands_dead_lettered_total"),
+		done:         make(chan struct{}),
+	}
+
+	lc.Append(lifecycle.Hook{
+		Name:    "commands",
+		OnStart: c.start,
+		OnStop:  c.stop,
+	})
+
+	return c
+}
+
+// start launches the receive loop; it outlives the start context.
+func (c *Consumer) start(context.Context) error {
+	var ctx context.Context
+	ctx, c.cancel = context.WithCancel(context.Background())
+	go c.run(ctx)
+	logger.Infof("Command consumer started, replying on %s", c.replyTopic)
+	return nil
+}
+
+// stop ends the receive loop and waits for it, bounded by the stop context.
+func (c *Consumer) stop(ctx context.Context) error {
+	c.cancel()
+	select {
+	case <-c.done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("commands: %w", ctx.Err())
+	}
+}
+
+// run receives and processes commands until the context is cancelled.
+func (c *Consumer) run(ctx context.Context) {
+	defer close(c.done)
+
+	for ctx.Err() == nil {
+		message, err := c.queue.Receive(ctx)
+		if err != nil {
+			logger.Errorf("Receiving commands failed, retrying in %s: %v", retryDelay, err)
+			select {
+			case <-ctx.Done():
+			case <-time.After(retryDelay):
+			}
+			continue
+		}
+		if message != nil {
+			c.process(ctx, message)
+		}
+	}
+}
+
+// process executes a command, publishes its reply and acknowledges, requeues
+// or dead-letters it.
+func (c *Consumer) process(loopCtx context.Context, message *queue.Message) {
+	reply, topic, panicked := c.executeSafely(message.Body)
+	id := queue.MessageID(message.Body)
+
+	ctx, cancel := context.WithTimeout(context.Background(), replyTimeout)
+	defer cancel()
+
+	// Step 1: Decide the fate of the message
+	var reason string
+	switch {
+	case reply.Error != nil && reply.Error.Code == malformedCommand:
+		reason = "malformed"
+	case panicked:
+		reason = "panic"
+	case reply.Status >= http.StatusInternalServerError && c.attempts[id] < c.maxRetries:
+		c.attempts[id]++
+		c.retried.Add("", 1)
+		logger.Warnf("Command %q failed (attempt %d of %d), requeueing it", reply.CommandID, c.attempts[id], c.maxRetries+1)
+		if err := c.queue.Requeue(ctx, message); err != nil {
+			logger.Errorf("Requeueing command %q failed: %v", reply.CommandID, err)
+		}
+		// Give the failing dependency time before the next command
+		select {
+		case <-loopCtx.Done():
+		case <-time.After(retryDelay):
+		}
+		return
+	case reply.Status >= http.StatusInternalServerError:
+		reason = "retries_exhausted"
+	}
+	delete(c.attempts, id)
+
+	// Step 2: Report the outcome
+	body, err := json.Marshal(reply)
+	if err == nil {
+		err = c.replies.Publish(ctx, topic, body)
+	}
+	if err != nil {
+		logger.Errorf("Reply to command %q on %s failed: %v", reply.CommandID, topic, err)
+	}
+
+	// Step 3: Remove the message from the queue
+	if reason != "" {
+		logger.Warnf("Moving message %s to the dead-letter queue (%s): %.100s", id, reason, message.Body)
+		c.deadLettered.Add(reason, 1)
+		err = c.queue.Reject(ctx, message)
+	} else {
+		err = c.queue.Ack(ctx, message)
+	}
+	if err != nil {
+		logger.Errorf("Acknowledging command %q failed: %v", reply.CommandID, err)
+	}
+}
+
+// executeSafely executes a command, turning a panic into an internal error.
+func (c *Consumer) executeSafely(body []byte) (reply command.Reply, topic string, panicked bool) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			logger.Errorf("Command panicked: %v\n%s", recovered, debug.Stack())
+			reply = failed(command.Reply{ProcessedAt: time.Now()}, fmt.Errorf("command panicked: %v", recovered))
+			topic, panicked = c.replyTopic, true
+		}
+	}()
+	reply, topic = c.Execute(body)
+	return reply, topic, false
+}
+
+// Execute decodes, validates and executes one command.
+//
+// Parameters:
+//   - body: The message as received
+//
+// Returns:
+//   - command.Reply: The outcome; failures carry the error of the HTTP API
+//   - string: Topic of the reply (replyTo, else the configured topic)
+//
+// Error Codes:
+//   - MALFORMED_COMMAND: When the message is not a JSON command
+//   - VALIDATION_ERROR: When the command or its payload breaks a binding rule,
+//     with field-specific details
+//   - Any error code of the module service, e.g. MODULE_NOT_FOUND
+func (c *Consumer) Execute(body []byte) (command.Reply, string) {
+	// Step 1: Decode the command
+	reply := command.Reply{ProcessedAt: time.Now()}
+	var cmd command.Command
+	if err := json.Unmarshal(body, &cmd); err != nil {
+		return failed(reply, response.NewHTTPError(http.StatusBadRequest, malformedCommand, nil)), c.replyTopic
+	}
+	reply.CommandID, reply.Type, reply.DryRun = cmd.ID, cmd.Type, cmd.DryRun
+	topic := c.replyTopic
+	if cmd.ReplyTo != "" {
+		topic = cmd.ReplyTo
+	}
+
+	// Step 2: Validate it like a request body
+	if err := binding.Validator.ValidateStruct(&cmd); err != nil {
+		return failed(reply, response.NewHTTPError(http.StatusBadRequest, "VALIDATION_ERROR", validationDetails(err))), topic
+	}
+
+	// Step 3: Execute it
+	data, status, err := c.dispatch(cmd)
+	if err != nil {
+		return failed(reply, err), topic
+	}
+	reply.Success, reply.Status, reply.Data = true, status, data
+
+	// Step 4: Drop the edge cache entries of the module
+	if !cmd.DryRun {
+		id := cmd.ModuleID
+		if data != nil {
+			id = data.ID
+		}
+		keys := []string{module.SurrogateKey(id), module.SurrogateCollectionKey}
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), replyTimeout)
+			defer cancel()
+			if err := c.purger.Purge(ctx, keys); err != nil {
+				logger.Warnf("CDN purge of %v after command %q failed: %v", keys, cmd.ID, err)
+			}
+		}()
+	}
+	return reply, topic
+}
+
+// dispatch calls the module service for a validated command.
+func (c *Consumer) dispatch(cmd command.Command) (*module.ModuleResponse, int, error) {
+	subject := module.Subject{User: strings.TrimSpace(cmd.Actor)}
+	for _, team := range cmd.Teams {
+		if team = strings.TrimSpace(team); team != "" {
+			subject.Teams = append(subject.Teams, team)
+		}
+	}
+	id := strconv.Itoa(cmd.ModuleID)
+
+	switch cmd.Type {
+	case command.TypeCreateModule:
+		created, err := c.modules.CreateModule(*cmd.Payload, subject.User, cmd.DryRun)
+		return created, http.StatusCreated, err
+	case command.TypeUpdateModule:
+		updated, err := c.modules.UpdateModule(id, *cmd.Payload, subject, cmd.DryRun)
+		return updated, http.StatusOK, err
+	default:
+		return nil, http.StatusNoContent, c.modules.DeleteModule(id, subject, cmd.DryRun)
+	}
+}
+
+// failed renders an error into a reply the way the HTTP API renders it.
+func failed(reply command.Reply, err error) command.Reply {
+	var httpErr *response.HTTPError
+	if !errors.As(err, &httpErr) {
+		httpErr = response.Errors.Map(err)
+	}
+	if httpErr.Status >= http.StatusInternalServerError {
+		if httpErr.Reference == "" {
+			httpErr.WithReference()
+		}
+		logger.Errorf("[%s] Command %q failed: %v", httpErr.Reference, reply.CommandID, httpErr)
+	}
+
+	apiResponse, status := response.NewResponseMapper(reply.CommandID).Fail(httpErr)
+	reply.Success, reply.Status, reply.Error = false, status, apiResponse.Error
+	return reply
+}
+
+// validationDetails lists the failed binding rules per field, in English.
+func validationDetails(err error) map[string][]string {
+	details := make(map[string][]string)
+	var verr validator.ValidationErrors
+	if errors.As(err, &verr) {
+		for _, fieldErr := range verr {
+			details[fieldErr.Field()] = append(details[fieldErr.Field()], i18n.FieldMessage(nil, fieldErr))
+		}
+	}
+	return details
+}
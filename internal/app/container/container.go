@@ -0,0 +1,313 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go_di_architecture/internal/app/lifecycle"
+)
+
+// Common container errors
+var (
+	ErrDuplicateProvider = errors.New("provider already registered")
+	ErrUnknownComponent  = errors.New("no provider registered for component")
+	ErrCircularReference = errors.New("circular dependency detected")
+	ErrTypeMismatch      = errors.New("component has unexpected type")
+	ErrScopeRequired     = errors.New("scoped component resolved outside of a scope")
+	ErrNotSupplied       = errors.New("component must be supplied by the scope")
+	ErrScopeDisposed     = errors.New("scope already disposed")
+)
+
+// Resolver resolves components by name.
+//
+// Both the Container (root resolver) and a Scope implement this interface, and
+// factories receive a Resolver so they can build their dependencies regardless
+// of the lifetime they are resolved in.
+type Resolver interface {
+	// Resolve returns the named component, building it if needed
+	Resolve(name string) (any, error)
+
+	// Lifecycle returns the application lifecycle for registering hooks
+	Lifecycle() *lifecycle.Lifecycle
+}
+
+// Factory builds a component, resolving its dependencies from the resolver.
+type Factory func(r Resolver) (any, error)
+
+// Provider describes how to build a named component.
+type Provider struct {
+	// Unique name of the component (e.g. "module.service")
+	Name string
+
+	// Lifetime of the component instances (defaults to Singleton)
+	Lifetime Lifetime
+
+	// Names of the components this one depends on
+	Dependencies []string
+
+	// Function constructing the component
+	Factory Factory
+
+	// Decorators wrapping the built instance, innermost first (see Decorate)
+	Decorators []Decorator
+
+	// Tags grouping the component with others for ResolveTagged (e.g. the
+	// handlers registering HTTP routes)
+	Tags []string
+}
+
+// Container is a small runtime dependency injection container.
+//
+// The container:
+//   - Keeps a registry of named providers
+//   - Builds singletons once and shares them for the whole application
+//   - Builds scoped components once per Scope (typically one HTTP request)
+//   - Builds transient components on every resolution
+//   - Resolves dependencies before the component that needs them
+//   - Detects circular dependencies during resolution
+//   - Verifies the declared wiring before building anything (Verify)
+//   - Wraps components in decorator chains for cross-cutting concerns
+//   - Discovers the components carrying a tag (ResolveTagged)
+//   - Owns the application lifecycle so components can register start/stop hooks
+//
+// Registration and singleton construction happen during bootstrap on a single
+// goroutine. Once Start has built every singleton the container is read-only,
+// so scopes may resolve from it concurrently.
+//
+// Usage Example:
+//
+//	c := container.New()
+//	c.Provide(container.Provider{
+//	    Name:         "module.service",
+//	    Dependencies: []string{"module.repository"},
+//	    Factory: func(r container.Resolver) (any, error) {
+//	        repo, err := container.Resolve[*repository.ModuleRepository](r, "module.repository")
+//	        if err != nil {
+//	            return nil, err
+//	        }
+//	        return service.NewModuleService(repo)
+//	    },
+//	})
+type Container struct {
+	providers map[string]Provider
+	order     []string
+	instances map[string]any
+	lifecycle *lifecycle.Lifecycle
+}
+
+// New creates an empty container with its own lifecycle manager.
+//
+// Returns:
+//   - *Container: A container without registered providers
+func New() *Container {
+	return &Container{
+		providers: make(map[string]Provider),
+		instances: make(map[string]any),
+		lifecycle: lifecycle.New(),
+	}
+}
+
+// Provide registers a component provider.
+//
+// Parameters:
+//   - provider: Component name, lifetime, dependencies and factory
+//
+// Returns:
+//   - error: ErrDuplicateProvider if the name is already registered
+func (c *Container) Provide(provider Provider) error {
+	if _, exists := c.providers[provider.Name]; exists {
+		return fmt.Errorf("%w: %s", ErrDuplicateProvider, provider.Name)
+	}
+	if provider.Lifetime == "" {
+		provider.Lifetime = Singleton
+	}
+
+	c.providers[provider.Name] = provider
+	c.order = append(c.order, provider.Name)
+	return nil
+}
+
+// Resolve returns the component registered under the given name, building it if needed.
+//
+// Scoped components cannot be resolved from the container directly; use a Scope.
+//
+// Parameters:
+//   - name: Name of the component
+//
+// Returns:
+//   - any: The component instance
+//   - error: Error if the component is unknown, circular or fails to build
+func (c *Container) Resolve(name string) (any, error) {
+	return c.resolve(name, nil, nil)
+}
+
+// Resolve returns the named component converted to the requested type.
+//
+// Parameters:
+//   - r: The container or scope to resolve from
+//   - name: Name of the component
+//
+// Returns:
+//   - T: The typed component instance
+//   - error: Error if resolution fails or the component has a different type
+func Resolve[T any](r Resolver, name string) (T, error) {
+	var zero T
+
+	instance, err := r.Resolve(name)
+	if err != nil {
+		return zero, err
+	}
+
+	typed, ok := instance.(T)
+	if !ok {
+		return zero, fmt.Errorf("%w: %s is %T, want %T", ErrTypeMismatch, name, instance, zero)
+	}
+	return typed, nil
+}
+
+// Lifecycle returns the lifecycle manager components register their hooks with.
+//
+// Returns:
+//   - *lifecycle.Lifecycle: The container's lifecycle manager
+func (c *Container) Lifecycle() *lifecycle.Lifecycle {
+	return c.lifecycle
+}
+
+// Build eagerly constructs every singleton in registration order.
+//
+// Scoped and transient components are skipped since they are built on demand.
+//
+// Returns:
+//   - error: The first construction error
+func (c *Container) Build() error {
+	for _, name := range c.order {
+		if c.providers[name].Lifetime != Singleton {
+			continue
+		}
+		if _, err := c.Resolve(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Start verifies the wiring, builds all singletons and runs their start hooks
+// in dependency order.
+//
+// Parameters:
+//   - ctx: Context bounding the startup time
+//
+// Returns:
+//   - error: A *VerificationError for incomplete wiring, or the error of a
+//     component failing to build or start
+func (c *Container) Start(ctx context.Context) error {
+	if err := c.Verify(); err != nil {
+		return err
+	}
+	if err := c.Build(); err != nil {
+		return err
+	}
+	return c.lifecycle.Start(ctx)
+}
+
+// Stop runs the stop hooks of all started components in reverse order.
+//
+// Parameters:
+//   - ctx: Context bounding the shutdown time
+//
+// Returns:
+//   - error: All shutdown errors joined together
+func (c *Container) Stop(ctx context.Context) error {
+	return c.lifecycle.Stop(ctx)
+}
+
+// resolve builds or looks up a component following its lifetime rules.
+//
+// The path holds the components currently being built on this resolution
+// chain and is used to detect cycles.
+func (c *Container) resolve(name string, scope *Scope, path []string) (any, error) {
+	provider, ok := c.providers[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownComponent, name)
+	}
+
+	// Step 1: Return cached instances
+	switch provider.Lifetime {
+	case Singleton:
+		if instance, ok := c.instances[name]; ok {
+			return instance, nil
+		}
+		// Singletons never see the scope, which prevents captive dependencies
+		scope = nil
+
+	case Scoped:
+		if scope == nil {
+			return nil, fmt.Errorf("%w: %s", ErrScopeRequired, name)
+		}
+		if instance, ok := scope.instances[name]; ok {
+			return instance, nil
+		}
+	}
+
+	// Step 2: Detect cycles using the current resolution path
+	for i, pending := range path {
+		if pending == name {
+			cycle := append(append([]string{}, path[i:]...), name)
+			return nil, fmt.Errorf("%w: %s", ErrCircularReference, strings.Join(cycle, " -> "))
+		}
+	}
+	path = append(path[:len(path):len(path)], name)
+
+	// Step 3: Build dependencies first so their lifecycle hooks are registered earlier
+	for _, dependency := range provider.Dependencies {
+		if _, err := c.resolve(dependency, scope, path); err != nil {
+			return nil, err
+		}
+	}
+
+	// Step 4: Build the component and wrap it in its decorators
+	resolver := &resolution{container: c, scope: scope, path: path}
+	instance, err := provider.Factory(resolver)
+	if err != nil {
+		return nil, fmt.Errorf("build %s: %w", name, err)
+	}
+	if instance == nil {
+		return nil, fmt.Errorf("build %s: %w", name, ErrNilComponent)
+	}
+	for _, decorate := range provider.Decorators {
+		if instance, err = decorate(resolver, instance); err != nil {
+			return nil, fmt.Errorf("decorate %s: %w", name, err)
+		}
+	}
+
+	// Step 5: Cache according to lifetime
+	switch provider.Lifetime {
+	case Singleton:
+		c.instances[name] = instance
+	case Scoped:
+		scope.track(name, instance)
+	case Transient:
+		if scope != nil {
+			scope.track("", instance)
+		}
+	}
+
+	return instance, nil
+}
+
+// resolution is the Resolver handed to factories, carrying the resolution path.
+type resolution struct {
+	container *Container
+	scope     *Scope
+	path      []string
+}
+
+func (r *resolution) Resolve(name string) (any, error) {
+	return r.container.resolve(name, r.scope, r.path)
+}
+
+func (r *resolution) Lifecycle() *lifecycle.Lifecycle {
+	return r.container.lifecycle
+}
@@ -0,0 +1,67 @@
+package container
+
+import "fmt"
+
+// Decorator wraps a component instance with a cross-cutting concern
+// (logging, metrics, caching, authorization) and returns the wrapper.
+//
+// Decorators receive the resolver so they can depend on other components,
+// e.g. the metrics registry. The wrapper must implement the same interface
+// as the instance, since dependents resolve the component by that type.
+type Decorator func(r Resolver, instance any) (any, error)
+
+// DecoratorFor adapts a typed wrapper to a Decorator, e.g. for the
+// Decorators of a Provider.
+//
+// Go has no dynamic proxies, so every concern is implemented once per
+// interface it decorates; DecoratorFor takes care of the type assertions.
+//
+// Parameters:
+//   - wrap: Function wrapping an instance of the interface
+//
+// Returns:
+//   - Decorator: A decorator failing with ErrTypeMismatch for instances not
+//     implementing T
+func DecoratorFor[T any](wrap func(r Resolver, inner T) (T, error)) Decorator {
+	return func(r Resolver, instance any) (any, error) {
+		inner, ok := instance.(T)
+		if !ok {
+			var zero T
+			return nil, fmt.Errorf("%w: cannot decorate %T as %T", ErrTypeMismatch, instance, zero)
+		}
+		return wrap(r, inner)
+	}
+}
+
+// Decorate appends typed decorators to a registered component.
+//
+// Decorators run in order once the factory built the instance: the first one
+// wraps the instance, each later one wraps the result, so the last decorator
+// is the outermost and sees calls first. Decorators must be added before the
+// component is first resolved. Resolving the component fails with
+// ErrTypeMismatch when its instance does not implement T.
+//
+// Usage Example:
+//
+//	err := container.Decorate(c, "provisioning.allocator", func(_ container.Resolver, inner provisioning.Allocator) (provisioning.Allocator, error) {
+//	    return decorators.LogAllocator(inner), nil
+//	})
+//
+// Parameters:
+//   - c: Container the component is registered in
+//   - name: Name of the component
+//   - wraps: Wrappers to append to its chain, innermost first
+//
+// Returns:
+//   - error: ErrUnknownComponent if no provider is registered under the name
+func Decorate[T any](c *Container, name string, wraps ...func(r Resolver, inner T) (T, error)) error {
+	provider, ok := c.providers[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownComponent, name)
+	}
+	for _, wrap := range wraps {
+		provider.Decorators = append(provider.Decorators, DecoratorFor(wrap))
+	}
+	c.providers[name] = provider
+	return nil
+}
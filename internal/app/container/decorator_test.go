@@ -0,0 +1,108 @@
+package container_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"go_di_architecture/internal/app/container"
+)
+
+// greeter and counter are the two component interfaces the decorator tests wrap.
+type greeter interface{ Greet(name string) string }
+
+type counter interface{ Count() int }
+
+type plainGreeter struct{}
+
+func (plainGreeter) Greet(name string) string { return "hello " + name }
+
+// prefixedGreeter prefixes the greetings of inner.
+type prefixedGreeter struct {
+	inner  greeter
+	prefix string
+}
+
+func (g prefixedGreeter) Greet(name string) string { return g.prefix + g.inner.Greet(name) }
+
+type fixedCounter int
+
+func (c fixedCounter) Count() int { return int(c) }
+
+// doubledCounter doubles the count of inner.
+type doubledCounter struct{ inner counter }
+
+func (c doubledCounter) Count() int { return 2 * c.inner.Count() }
+
+// TestDecorateWrapsComponentsOfAnyType checks decorators of components of
+// different types wrap their instances in order, innermost first, and can
+// resolve their own dependencies.
+func TestDecorateWrapsComponentsOfAnyType(t *testing.T) {
+	c := container.New()
+	for _, provider := range []container.Provider{
+		{Name: "prefix", Factory: func(container.Resolver) (any, error) { return "[b]", nil }},
+		{Name: "greeter", Factory: func(container.Resolver) (any, error) { return plainGreeter{}, nil }},
+		{Name: "counter", Factory: func(container.Resolver) (any, error) { return fixedCounter(3), nil }},
+	} {
+		if err := c.Provide(provider); err != nil {
+			t.Fatalf("Provide(%s) error = %v", provider.Name, err)
+		}
+	}
+
+	err := container.Decorate(c, "greeter",
+		func(_ container.Resolver, inner greeter) (greeter, error) {
+			return prefixedGreeter{inner: inner, prefix: "[a]"}, nil
+		},
+		func(r container.Resolver, inner greeter) (greeter, error) {
+			prefix, err := container.Resolve[string](r, "prefix")
+			return prefixedGreeter{inner: inner, prefix: prefix}, err
+		},
+	)
+	if err != nil {
+		t.Fatalf("Decorate(greeter) error = %v", err)
+	}
+	err = container.Decorate(c, "counter", func(_ container.Resolver, inner counter) (counter, error) {
+		return doubledCounter{inner: inner}, nil
+	})
+	if err != nil {
+		t.Fatalf("Decorate(counter) error = %v", err)
+	}
+
+	g, err := container.Resolve[greeter](c, "greeter")
+	if err != nil {
+		t.Fatalf("Resolve(greeter) error = %v", err)
+	}
+	if got := g.Greet("jane"); got != "[b][a]hello jane" {
+		t.Errorf("Greet() = %q, want the last decorator outermost", got)
+	}
+	n, err := container.Resolve[counter](c, "counter")
+	if err != nil {
+		t.Fatalf("Resolve(counter) error = %v", err)
+	}
+	if got := n.Count(); got != 6 {
+		t.Errorf("Count() = %d, want 6", got)
+	}
+}
+
+// TestDecorateRejectsUnknownComponentsAndOtherTypes checks decorating an
+// unregistered component fails, and so does resolving a component whose
+// instance is not of the decorated type.
+func TestDecorateRejectsUnknownComponentsAndOtherTypes(t *testing.T) {
+	c := container.New()
+	if err := c.Provide(container.Provider{Name: "counter", Factory: func(container.Resolver) (any, error) { return fixedCounter(3), nil }}); err != nil {
+		t.Fatalf("Provide() error = %v", err)
+	}
+
+	wrap := func(_ container.Resolver, inner greeter) (greeter, error) { return inner, nil }
+	if err := container.Decorate(c, "greeter", wrap); !errors.Is(err, container.ErrUnknownComponent) {
+		t.Errorf("Decorate(unregistered) error = %v, want ErrUnknownComponent", err)
+	}
+	if err := container.Decorate(c, "counter", wrap); err != nil {
+		t.Fatalf("Decorate(counter) error = %v", err)
+	}
+	_, err := c.Resolve("counter")
+	if !errors.Is(err, container.ErrTypeMismatch) || !strings.Contains(err.Error(), fmt.Sprintf("%T", fixedCounter(0))) {
+		t.Errorf("Resolve() error = %v, want ErrTypeMismatch naming the instance type", err)
+	}
+}
@@ -0,0 +1,114 @@
+package container
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Node describes a single component in the dependency graph.
+type Node struct {
+	// Name of the component
+	Name string `json:"name"`
+
+	// Lifetime of the component instances
+	Lifetime Lifetime `json:"lifetime"`
+
+	// Go type of the built instance (empty if not built yet)
+	Type string `json:"type,omitempty"`
+
+	// Names of the components this one depends on
+	Dependencies []string `json:"dependencies"`
+
+	// Dependencies that have no registered provider
+	Missing []string `json:"missing,omitempty"`
+
+	// Tags of the component (see ResolveTagged)
+	Tags []string `json:"tags,omitempty"`
+}
+
+// Graph is a snapshot of the wired components and their dependencies.
+//
+// Example:
+//
+//	{
+//	  "nodes": [
+//	    {"name": "module.repository", "lifetime": "singleton", "type": "*module.ModuleRepository", "dependencies": []},
+//	    {"name": "module.service", "lifetime": "singleton", "type": "*module.ModuleService", "dependencies": ["module.repository"]}
+//	  ]
+//	}
+type Graph struct {
+	Nodes []Node `json:"nodes"`
+}
+
+// Graph returns a snapshot of the registered components in registration order.
+//
+// Dependencies without a provider are reported in Node.Missing so wiring
+// mistakes are visible without starting the application.
+//
+// Returns:
+//   - Graph: The dependency graph
+func (c *Container) Graph() Graph {
+	graph := Graph{Nodes: make([]Node, 0, len(c.order))}
+
+	for _, name := range c.order {
+		provider := c.providers[name]
+
+		node := Node{
+			Name:         name,
+			Lifetime:     provider.Lifetime,
+			Dependencies: append([]string{}, provider.Dependencies...),
+			Tags:         provider.Tags,
+		}
+		if instance, ok := c.instances[name]; ok {
+			node.Type = fmt.Sprintf("%T", instance)
+		}
+		for _, dependency := range provider.Dependencies {
+			if _, ok := c.providers[dependency]; !ok {
+				node.Missing = append(node.Missing, dependency)
+			}
+		}
+
+		graph.Nodes = append(graph.Nodes, node)
+	}
+
+	return graph
+}
+
+// DOT renders the graph in Graphviz format.
+//
+// Edges point from a component to its dependencies. Missing dependencies are
+// drawn as red dashed nodes.
+//
+// Usage:
+//
+//	go run ./cmd/api -dump-graph=dot | dot -Tsvg > graph.svg
+//
+// Returns:
+//   - string: The Graphviz document
+func (g Graph) DOT() string {
+	var b strings.Builder
+
+	b.WriteString("digraph container {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, fontname=\"Helvetica\"];\n")
+
+	for _, node := range g.Nodes {
+		label := fmt.Sprintf("%s\\n(%s)", node.Name, node.Lifetime)
+		if node.Type != "" {
+			label += fmt.Sprintf("\\n%s", node.Type)
+		}
+		fmt.Fprintf(&b, "  \"%s\" [label=\"%s\"];\n", node.Name, label)
+	}
+
+	for _, node := range g.Nodes {
+		for _, missing := range node.Missing {
+			fmt.Fprintf(&b, "  \"%s\" [color=red, style=dashed, label=\"%s\\n(missing)\"];\n", missing, missing)
+		}
+		for _, dependency := range node.Dependencies {
+			fmt.Fprintf(&b, "  \"%s\" -> \"%s\";\n", node.Name, dependency)
+		}
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
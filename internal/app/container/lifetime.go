@@ -0,0 +1,23 @@
+package container
+
+// Lifetime describes how long a component instance lives.
+type Lifetime string
+
+const (
+	// Singleton components are built once and shared for the whole application
+	// (database connections, loggers, repositories, services).
+	Singleton Lifetime = "singleton"
+
+	// Scoped components are built once per Scope and disposed with it
+	// (request-bound response mappers, units of work).
+	Scoped Lifetime = "scoped"
+
+	// Transient components are built on every resolution
+	// (stateless helpers, per-call factories).
+	Transient Lifetime = "transient"
+)
+
+// Disposable is implemented by components that release resources when their scope ends.
+type Disposable interface {
+	Dispose() error
+}
@@ -0,0 +1,212 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go_di_architecture/internal/app/lifecycle"
+)
+
+// scopeContextKey is the context key under which the request scope is stored.
+type scopeContextKey struct{}
+
+// Scope holds the scoped component instances of a single unit of work.
+//
+// A scope is usually created per HTTP request by the request-scope middleware:
+//   - Scoped components are built once per scope on first resolution
+//   - Transient components built within the scope are tracked for disposal
+//   - Singletons are delegated to the parent container
+//   - Dispose releases every Disposable instance and runs the cleanups
+//     registered with OnDispose, in reverse order
+//
+// Handlers reach the scope of their request through the request context with
+// Get and MustGet; components that are never asked for are never built.
+//
+// A scope is bound to the goroutine serving its request and is not safe for
+// concurrent use.
+type Scope struct {
+	container   *Container
+	instances   map[string]any
+	disposables []Disposable
+	disposed    bool
+}
+
+// NewScope creates a new scope resolving from this container.
+//
+// Returns:
+//   - *Scope: An empty scope
+func (c *Container) NewScope() *Scope {
+	return &Scope{
+		container: c,
+		instances: make(map[string]any),
+	}
+}
+
+// Resolve returns the named component, building scoped instances on first use.
+//
+// Parameters:
+//   - name: Name of the component
+//
+// Returns:
+//   - any: The component instance
+//   - error: Error if the scope is disposed or resolution fails
+func (s *Scope) Resolve(name string) (any, error) {
+	if s.disposed {
+		return nil, fmt.Errorf("%w: resolving %s", ErrScopeDisposed, name)
+	}
+	return s.container.resolve(name, s, nil)
+}
+
+// Lifecycle returns the application lifecycle of the parent container.
+//
+// Returns:
+//   - *lifecycle.Lifecycle: The container's lifecycle manager
+func (s *Scope) Lifecycle() *lifecycle.Lifecycle {
+	return s.container.lifecycle
+}
+
+// Supply stores a pre-built instance for a scoped component.
+//
+// This is how per-request values that cannot be constructed by a factory
+// (request ID, authenticated principal) enter the scope.
+//
+// Parameters:
+//   - name: Name of the scoped component
+//   - instance: The value to use for this scope
+func (s *Scope) Supply(name string, instance any) {
+	s.instances[name] = instance
+}
+
+// OnDispose registers a cleanup to run when the scope is disposed.
+//
+// Cleanups run in reverse order together with the disposable instances, so a
+// cleanup registered by a factory runs before those of its dependencies. This
+// is how instances that do not implement Disposable (e.g. a transaction to
+// roll back) are released with the scope.
+//
+// Parameters:
+//   - cleanup: Function releasing the resource
+func (s *Scope) OnDispose(cleanup func() error) {
+	s.disposables = append(s.disposables, disposeFunc(cleanup))
+}
+
+// Dispose releases all disposable instances in reverse creation order.
+//
+// Disposing an already disposed scope is a no-op.
+//
+// Returns:
+//   - error: All disposal errors joined together
+func (s *Scope) Dispose() error {
+	if s.disposed {
+		return nil
+	}
+	s.disposed = true
+
+	var errs []error
+	for i := len(s.disposables) - 1; i >= 0; i-- {
+		if err := s.disposables[i].Dispose(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	s.disposables = nil
+	s.instances = nil
+	return errors.Join(errs...)
+}
+
+// track records a built instance for caching (when named) and disposal.
+func (s *Scope) track(name string, instance any) {
+	if name != "" {
+		s.instances[name] = instance
+	}
+	if disposable, ok := instance.(Disposable); ok {
+		s.disposables = append(s.disposables, disposable)
+	}
+}
+
+// disposeFunc adapts a cleanup function to Disposable.
+type disposeFunc func() error
+
+func (f disposeFunc) Dispose() error { return f() }
+
+// Supplied returns a factory for scoped components whose value is provided via Scope.Supply.
+//
+// Parameters:
+//   - name: Name of the component, used in the error message
+//
+// Returns:
+//   - Factory: A factory that always fails with ErrNotSupplied
+func Supplied(name string) Factory {
+	return func(r Resolver) (any, error) {
+		return nil, fmt.Errorf("%w: %s", ErrNotSupplied, name)
+	}
+}
+
+// WithScope returns a copy of the context carrying the scope.
+//
+// Parameters:
+//   - ctx: Parent context
+//   - scope: The scope to attach
+//
+// Returns:
+//   - context.Context: A context carrying the scope
+func WithScope(ctx context.Context, scope *Scope) context.Context {
+	return context.WithValue(ctx, scopeContextKey{}, scope)
+}
+
+// ScopeFrom returns the scope attached to the context, if any.
+//
+// Parameters:
+//   - ctx: Context possibly carrying a scope
+//
+// Returns:
+//   - *Scope: The attached scope
+//   - bool: False if the context carries no scope
+func ScopeFrom(ctx context.Context) (*Scope, bool) {
+	scope, ok := ctx.Value(scopeContextKey{}).(*Scope)
+	return scope, ok
+}
+
+// Get resolves a component from the scope attached to the context.
+//
+// Scoped components are built on the first Get of a request and shared by
+// the later ones, so per-request dependencies cost nothing on requests that
+// do not use them.
+//
+// Parameters:
+//   - ctx: Context carrying the request scope (e.g. ctx.Request.Context())
+//   - name: Name of the component
+//
+// Returns:
+//   - T: The typed component instance
+//   - error: ErrScopeRequired if the context carries no scope, or the
+//     resolution error
+func Get[T any](ctx context.Context, name string) (T, error) {
+	scope, ok := ScopeFrom(ctx)
+	if !ok {
+		var zero T
+		return zero, fmt.Errorf("%w: %s", ErrScopeRequired, name)
+	}
+	return Resolve[T](scope, name)
+}
+
+// MustGet resolves a component like Get and panics if it cannot be resolved.
+//
+// It is meant for components every route of the application can rely on
+// (request ID, principal); the panic is turned into a 500 response by the
+// exception middleware.
+//
+// Parameters:
+//   - ctx: Context carrying the request scope
+//   - name: Name of the component
+//
+// Returns:
+//   - T: The typed component instance
+func MustGet[T any](ctx context.Context, name string) T {
+	instance, err := Get[T](ctx, name)
+	if err != nil {
+		panic(err)
+	}
+	return instance
+}
@@ -0,0 +1,61 @@
+package container
+
+import (
+	"fmt"
+	"slices"
+)
+
+// tagLister is implemented by the resolvers of this package.
+type tagLister interface {
+	tagged(tag string) []string
+}
+
+// ResolveTagged returns every component carrying the tag, in registration order.
+//
+// Consumers discover their collaborators this way instead of listing them:
+// the HTTP router, for example, registers the routes of every component
+// tagged as routable. Components are resolved through r, so cycles through a
+// tagged component are still detected.
+//
+// Parameters:
+//   - r: The container, scope or factory resolver to resolve from
+//   - tag: Tag of the components
+//
+// Returns:
+//   - []T: The typed component instances
+//   - error: Error if a component fails to build or has a different type
+func ResolveTagged[T any](r Resolver, tag string) ([]T, error) {
+	lister, ok := r.(tagLister)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T cannot list tagged components", ErrTypeMismatch, r)
+	}
+
+	var components []T
+	for _, name := range lister.tagged(tag) {
+		component, err := Resolve[T](r, name)
+		if err != nil {
+			return nil, err
+		}
+		components = append(components, component)
+	}
+	return components, nil
+}
+
+// tagged returns the names of the components carrying the tag, in registration order.
+func (c *Container) tagged(tag string) []string {
+	var names []string
+	for _, name := range c.order {
+		if slices.Contains(c.providers[name].Tags, tag) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func (s *Scope) tagged(tag string) []string {
+	return s.container.tagged(tag)
+}
+
+func (r *resolution) tagged(tag string) []string {
+	return r.container.tagged(tag)
+}
@@ -0,0 +1,131 @@
+package container
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Errors reported by Verify
+var (
+	ErrMissingFactory     = errors.New("provider has no factory")
+	ErrCaptiveDependency  = errors.New("singleton depends on a scoped component")
+	ErrNilComponent       = errors.New("factory returned no instance")
+	ErrVerificationFailed = errors.New("container wiring is incomplete")
+)
+
+// VerificationError lists every wiring problem Verify found.
+//
+// errors.Is matches ErrVerificationFailed as well as the error of each
+// problem (e.g. ErrUnknownComponent, ErrCircularReference).
+type VerificationError struct {
+	Problems []error
+}
+
+// Error renders the problems as a report, one per line.
+func (e *VerificationError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%v (%d problems):", ErrVerificationFailed, len(e.Problems))
+	for _, problem := range e.Problems {
+		b.WriteString("\n  - ")
+		b.WriteString(problem.Error())
+	}
+	return b.String()
+}
+
+// Unwrap returns the problems together with ErrVerificationFailed.
+func (e *VerificationError) Unwrap() []error {
+	return append([]error{ErrVerificationFailed}, e.Problems...)
+}
+
+// Verify checks the declared wiring without building any component.
+//
+// Start runs the check before building the singletons, so a wiring mistake
+// stops the application at boot with a report of every problem instead of
+// surfacing as a failed resolution (or a nil component) in the middle of a
+// request.
+//
+// Checks:
+//   - Every provider has a factory
+//   - Every declared dependency has a provider
+//   - Declared dependencies contain no cycles
+//   - Singletons do not depend on scoped components, which only exist
+//     inside a scope
+//
+// Only dependencies listed in Provider.Dependencies are checked; components a
+// factory resolves without declaring them are still checked when built.
+//
+// Returns:
+//   - error: A *VerificationError listing the problems, nil if there are none
+func (c *Container) Verify() error {
+	var problems []error
+
+	// Step 1: Check each provider and its direct dependencies
+	for _, name := range c.order {
+		provider := c.providers[name]
+		if provider.Factory == nil {
+			problems = append(problems, fmt.Errorf("%w: %s", ErrMissingFactory, name))
+		}
+		for _, dependency := range provider.Dependencies {
+			target, ok := c.providers[dependency]
+			switch {
+			case !ok:
+				problems = append(problems, fmt.Errorf("%w: %s (needed by %s)", ErrUnknownComponent, dependency, name))
+			case provider.Lifetime == Singleton && target.Lifetime == Scoped:
+				problems = append(problems, fmt.Errorf("%w: %s -> %s", ErrCaptiveDependency, name, dependency))
+			}
+		}
+	}
+
+	// Step 2: Find the cycles of the declared graph
+	for _, cycle := range c.cycles() {
+		problems = append(problems, fmt.Errorf("%w: %s", ErrCircularReference, strings.Join(cycle, " -> ")))
+	}
+
+	if len(problems) > 0 {
+		return &VerificationError{Problems: problems}
+	}
+	return nil
+}
+
+// cycles returns each cycle of the declared dependencies once, as the path
+// from its first registered component back to that component.
+func (c *Container) cycles() [][]string {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(c.order))
+	var cycles [][]string
+
+	var visit func(name string, path []string)
+	visit = func(name string, path []string) {
+		state[name] = visiting
+		path = append(path, name)
+		for _, dependency := range c.providers[name].Dependencies {
+			if _, ok := c.providers[dependency]; !ok {
+				continue
+			}
+			switch state[dependency] {
+			case unvisited:
+				visit(dependency, path)
+			case visiting:
+				for i, pending := range path {
+					if pending == dependency {
+						cycles = append(cycles, append(append([]string{}, path[i:]...), dependency))
+						break
+					}
+				}
+			}
+		}
+		state[name] = done
+	}
+
+	for _, name := range c.order {
+		if state[name] == unvisited {
+			visit(name, nil)
+		}
+	}
+	return cycles
+}
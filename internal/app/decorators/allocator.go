@@ -0,0 +1,224 @@
+package decorators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"go_di_architecture/internal/app/container"
+	"go_di_architecture/internal/domain/metrics"
+	"go_di_architecture/internal/domain/provisioning"
+	"go_di_architecture/internal/logging"
+)
+
+// ErrPlanNotAllowed is returned by AuthorizeAllocator for plans outside the policy.
+var ErrPlanNotAllowed = errors.New("resource plan not allowed")
+
+// allocatorLogger writes the log lines of LogAllocator.
+var allocatorLogger = logging.New("provisioning")
+
+// AllocatorSet returns the decorators available for the resource allocator.
+//
+// Parameters:
+//   - metricsComponent: Name of the metrics registry the metrics decorator resolves
+//   - plans: Plans the authorize decorator allows
+//
+// Returns:
+//   - Set[provisioning.Allocator]: The logging, metrics, cache and authorize decorators
+func AllocatorSet(metricsComponent string, plans []string) Set[provisioning.Allocator] {
+	return Set[provisioning.Allocator]{
+		Logging: func(_ container.Resolver, inner provisioning.Allocator) (provisioning.Allocator, error) {
+			return LogAllocator(inner), nil
+		},
+		Metrics: func(r container.Resolver, inner provisioning.Allocator) (provisioning.Allocator, error) {
+			m, err := container.Resolve[metrics.Metrics](r, metricsComponent)
+			if err != nil {
+				return nil, err
+			}
+			return MeasureAllocator(inner, m), nil
+		},
+		Cache: func(_ container.Resolver, inner provisioning.Allocator) (provisioning.Allocator, error) {
+			return CacheAllocator(inner), nil
+		},
+		Authorize: func(_ container.Resolver, inner provisioning.Allocator) (provisioning.Allocator, error) {
+			return AuthorizeAllocator(inner, plans), nil
+		},
+	}
+}
+
+// loggedAllocator logs every call with its duration and outcome.
+type loggedAllocator struct {
+	inner provisioning.Allocator
+}
+
+// LogAllocator logs the allocations and releases of an allocator.
+//
+// Parameters:
+//   - inner: The decorated allocator
+//
+// Returns:
+//   - provisioning.Allocator: The logging allocator
+func LogAllocator(inner provisioning.Allocator) provisioning.Allocator {
+	return &loggedAllocator{inner: inner}
+}
+
+func (a *loggedAllocator) Allocate(ctx context.Context, moduleID int, plan string) (string, error) {
+	start := time.Now()
+	allocationID, err := a.inner.Allocate(ctx, moduleID, plan)
+	if err != nil {
+		allocatorLogger.Warnf("Allocating %s plan for module %d failed after %s: %v", plan, moduleID, time.Since(start), err)
+		return "", err
+	}
+	allocatorLogger.Infof("Allocated %s plan for module %d as %s in %s", plan, moduleID, allocationID, time.Since(start))
+	return allocationID, nil
+}
+
+func (a *loggedAllocator) Release(ctx context.Context, allocationID string) error {
+	start := time.Now()
+	if err := a.inner.Release(ctx, allocationID); err != nil {
+		allocatorLogger.Warnf("Releasing allocation %s failed after %s: %v", allocationID, time.Since(start), err)
+		return err
+	}
+	allocatorLogger.Infof("Released allocation %s in %s", allocationID, time.Since(start))
+	return nil
+}
+
+// measuredAllocator records the duration and failures of every call.
+type measuredAllocator struct {
+	inner    provisioning.Allocator
+	duration metrics.Histogram
+	failures metrics.Counter
+}
+
+// MeasureAllocator records the calls of an allocator.
+//
+// Parameters:
+//   - inner: The decorated allocator
+//   - m: Registers the provisioning_call_duration histogram and the
+//     provisioning_call_failures_total counter, labelled with the operation
+//     (allocate, release)
+//
+// Returns:
+//   - provisioning.Allocator: The measuring allocator
+func MeasureAllocator(inner provisioning.Allocator, m metrics.Metrics) provisioning.Allocator {
+	return &measuredAllocator{
+		inner:    inner,
+		duration: m.Histogram("provisioning_call_duration"),
+		failures: m.Counter("provisioning_call_failures_total"),
+	}
+}
+
+func (a *measuredAllocator) Allocate(ctx context.Context, moduleID int, plan string) (string, error) {
+	start := time.Now()
+	allocationID, err := a.inner.Allocate(ctx, moduleID, plan)
+	a.record("allocate", start, err)
+	return allocationID, err
+}
+
+func (a *measuredAllocator) Release(ctx context.Context, allocationID string) error {
+	start := time.Now()
+	err := a.inner.Release(ctx, allocationID)
+	a.record("release", start, err)
+	return err
+}
+
+// record observes the duration of a call and counts its failure.
+func (a *measuredAllocator) record(operation string, start time.Time, err error) {
+	a.duration.Observe(operation, time.Since(start))
+	if err != nil {
+		a.failures.Add(operation, 1)
+	}
+}
+
+// cachedAllocator remembers the allocations it made.
+type cachedAllocator struct {
+	inner provisioning.Allocator
+
+	mu          sync.Mutex
+	allocations map[string]string
+}
+
+// CacheAllocator makes repeated allocations of a module and plan return the
+// first allocation instead of reserving the resources again.
+//
+// A workflow step retried after a lost response (timeout, restart) then does
+// not leak an allocation. Releasing an allocation forgets it. The cache lives
+// in memory and is not shared between instances.
+//
+// Parameters:
+//   - inner: The decorated allocator
+//
+// Returns:
+//   - provisioning.Allocator: The caching allocator
+func CacheAllocator(inner provisioning.Allocator) provisioning.Allocator {
+	return &cachedAllocator{inner: inner, allocations: make(map[string]string)}
+}
+
+func (a *cachedAllocator) Allocate(ctx context.Context, moduleID int, plan string) (string, error) {
+	key := strconv.Itoa(moduleID) + "/" + plan
+
+	a.mu.Lock()
+	allocationID, ok := a.allocations[key]
+	a.mu.Unlock()
+	if ok {
+		return allocationID, nil
+	}
+
+	allocationID, err := a.inner.Allocate(ctx, moduleID, plan)
+	if err != nil {
+		return "", err
+	}
+	a.mu.Lock()
+	a.allocations[key] = allocationID
+	a.mu.Unlock()
+	return allocationID, nil
+}
+
+func (a *cachedAllocator) Release(ctx context.Context, allocationID string) error {
+	if err := a.inner.Release(ctx, allocationID); err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for key, cached := range a.allocations {
+		if cached == allocationID {
+			delete(a.allocations, key)
+		}
+	}
+	return nil
+}
+
+// authorizedAllocator refuses plans outside its policy.
+type authorizedAllocator struct {
+	inner provisioning.Allocator
+	plans []string
+}
+
+// AuthorizeAllocator restricts an allocator to a set of plans.
+//
+// Parameters:
+//   - inner: The decorated allocator
+//   - plans: Plans that may be allocated
+//
+// Returns:
+//   - provisioning.Allocator: The authorizing allocator; other plans fail
+//     with ErrPlanNotAllowed without reaching inner
+func AuthorizeAllocator(inner provisioning.Allocator, plans []string) provisioning.Allocator {
+	return &authorizedAllocator{inner: inner, plans: plans}
+}
+
+func (a *authorizedAllocator) Allocate(ctx context.Context, moduleID int, plan string) (string, error) {
+	if !slices.Contains(a.plans, plan) {
+		return "", fmt.Errorf("%w: %s", ErrPlanNotAllowed, plan)
+	}
+	return a.inner.Allocate(ctx, moduleID, plan)
+}
+
+func (a *authorizedAllocator) Release(ctx context.Context, allocationID string) error {
+	return a.inner.Release(ctx, allocationID)
+}
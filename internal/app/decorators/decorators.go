@@ -0,0 +1,70 @@
+// Package decorators wraps service interfaces with cross-cutting concerns.
+//
+// Each concern (logging, metrics, caching, authorization) is a decorator
+// implementing the interface it wraps, so services stay free of them and a
+// concern is written once per interface instead of once per method call
+// site. The container applies the decorators configured for a component in
+// order (see container.Decorate and the DECORATORS setting), e.g.
+// "provisioning.allocator=authorize,cache,metrics,logging".
+package decorators
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"go_di_architecture/internal/app/container"
+)
+
+// Names of the decorators in a chain
+const (
+	Logging   = "logging"
+	Metrics   = "metrics"
+	Cache     = "cache"
+	Authorize = "authorize"
+)
+
+// Chain wraps a component in decorators chosen by name.
+//
+// Implemented by Set for every interface, so the components with decorators
+// can be listed together whatever their types.
+type Chain interface {
+	Decorate(c *container.Container, component string, names []string) error
+}
+
+// Set maps decorator names to the decorators available for components of
+// type T.
+type Set[T any] map[string]func(r container.Resolver, inner T) (T, error)
+
+// Decorate wraps a component in the decorators named in the chain (see
+// container.Decorate).
+//
+// Parameters:
+//   - c: Container the component is registered in
+//   - component: Name of the component
+//   - names: Decorator names, innermost first
+//
+// Returns:
+//   - error: Error naming the first unknown decorator, or the error of
+//     container.Decorate
+func (s Set[T]) Decorate(c *container.Container, component string, names []string) error {
+	chain := make([]func(container.Resolver, T) (T, error), 0, len(names))
+	for _, name := range names {
+		decorator, ok := s[name]
+		if !ok {
+			return fmt.Errorf("unknown decorator %q (available: %s)", name, strings.Join(s.names(), ", "))
+		}
+		chain = append(chain, decorator)
+	}
+	return container.Decorate(c, component, chain...)
+}
+
+// names returns the available decorator names, sorted.
+func (s Set[T]) names() []string {
+	names := make([]string, 0, len(s))
+	for name := range s {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"go_di_architecture/internal/app/auth"
+	"go_di_architecture/internal/domain/models/quota"
+	"go_di_architecture/internal/domain/models/response"
+	quotaService "go_di_architecture/internal/domain/service/quota"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AccountHandler handles HTTP requests of API consumers about their own account.
+type AccountHandler struct {
+	quotas *quotaService.QuotaService
+}
+
+// NewAccountHandler creates a new instance of AccountHandler.
+//
+// Parameters:
+//   - quotas: Business service counting requests per API key
+//
+// Returns:
+//   - *AccountHandler: A new handler instance
+func NewAccountHandler(quotas *quotaService.QuotaService) *AccountHandler {
+	return &AccountHandler{quotas: quotas}
+}
+
+// Routes returns the routes API consumers use to inspect their own account.
+//
+// The routes are mounted outside the versioned API group so they do not
+// count against request quotas; they only require authentication.
+func (h *AccountHandler) Routes() []RouteSpec {
+	return []RouteSpec{
+		{Group: GroupRoot, Method: http.MethodGet, Path: "/api/v1/account/usage", Policy: authenticated, Handler: h.GetAccountUsage, Response: quota.AccountUsage{}}, // GET /api/v1/account/usage
+	}
+}
+
+// GetAccountUsage godoc
+// @Summary Get the request quota consumption of the calling API key
+// @Description Returns the requests made with the calling API key in the current UTC month and, for keys with a quota, the limit, the remaining requests and when the quota resets. This endpoint does not count against the quota, so it stays available once the quota is used up.
+// @Tags account
+// @Produce json
+// @Success 200 {object} response.APIResponse{data=quota.AccountUsage} "Quota consumption"
+// @Failure 400 {object} response.APIResponse "The request was not authenticated with an API key"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /account/usage [get]
+//
+// Sample Request:
+//
+//	GET /api/v1/account/usage
+//	X-API-Key: s3cr3t
+func (h *AccountHandler) GetAccountUsage(ctx *gin.Context) {
+	// Step 1: Identify the API key
+	principal := auth.PrincipalFrom(ctx)
+	if principal == nil || principal.APIKey == "" {
+		Respond(ctx, Result{}, response.NewHTTPError(http.StatusBadRequest, "VALIDATION_ERROR", map[string][]string{auth.APIKeyHeader: {"Quotas are tracked per API key; authenticate with the X-API-Key header"}}))
+		return
+	}
+
+	// Step 2: Report the consumption
+	usage, err := h.quotas.GetAccountUsage(principal.APIKey, time.Now())
+	if err != nil {
+		Respond(ctx, Result{}, response.NewHTTPError(http.StatusInternalServerError, "INTERNAL_ERROR", nil).WithCause(err))
+		return
+	}
+
+	Respond(ctx, Result{Data: usage}, nil)
+}
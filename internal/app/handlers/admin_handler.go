@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"expvar"
+	"net/http"
+
+	"go_di_architecture/internal/app/container"
+	"go_di_architecture/internal/domain/models/admin"
+	"go_di_architecture/internal/domain/models/response"
+	"go_di_architecture/internal/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GraphSource provides a snapshot of the wired dependency graph.
+type GraphSource interface {
+	Graph() container.Graph
+}
+
+// AdminHandler exposes operational endpoints for inspecting the running application.
+//
+// These endpoints are intended for operators debugging wiring problems and are
+// registered outside of the versioned public API.
+type AdminHandler struct {
+	graph GraphSource
+}
+
+// NewAdminHandler creates a new instance of AdminHandler.
+//
+// Parameters:
+//   - graph: Source of the dependency graph (usually the container itself);
+//     nil when the application is wired at compile time
+//
+// Returns:
+//   - *AdminHandler: A new handler instance
+func NewAdminHandler(graph GraphSource) *AdminHandler {
+	return &AdminHandler{graph: graph}
+}
+
+// Routes returns the container, metrics and log level routes.
+func (h *AdminHandler) Routes() []RouteSpec {
+	return []RouteSpec{
+		// Container introspection
+		{Group: GroupAdmin, Method: http.MethodGet, Path: "/container/graph", Handler: h.GetContainerGraph, Response: container.Graph{}}, // GET /admin/container/graph
+
+		// Runtime metrics (expvar), e.g. module cache hits and misses
+		{Group: GroupAdmin, Method: http.MethodGet, Path: "/metrics", Handler: gin.WrapH(expvar.Handler())}, // GET /admin/metrics
+
+		// Runtime log levels per logger
+		{Group: GroupAdmin, Method: http.MethodGet, Path: "/log-level", Handler: h.GetLogLevels, Response: logging.Levels{}},                               // GET /admin/log-level
+		{Group: GroupAdmin, Method: http.MethodPut, Path: "/log-level", Handler: h.SetLogLevel, Body: admin.LogLevelRequest{}, Response: logging.Levels{}}, // PUT /admin/log-level
+	}
+}
+
+// GetContainerGraph godoc
+// @Summary Dump the dependency graph
+// @Description Returns every wired component with its lifetime and dependencies, as JSON or Graphviz
+// @Tags admin
+// @Produce json
+// @Produce text/vnd.graphviz
+// @Param format query string false "Output format" Enums(json, dot) default(json)
+// @Success 200 {object} response.APIResponse{data=container.Graph} "Dependency graph"
+// @Failure 400 {object} response.APIResponse "Unsupported format"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 404 {object} response.APIResponse "Runtime container not in use"
+// @Security ApiKeyAuth[admin] || BearerAuth[admin]
+// @Router /admin/container/graph [get]
+//
+// Sample Request:
+//
+//	GET /admin/container/graph?format=dot
+func (h *AdminHandler) GetContainerGraph(ctx *gin.Context) {
+	// Compile-time wiring has no runtime graph to report
+	if h.graph == nil {
+		Respond(ctx, Result{}, response.NewHTTPError(http.StatusNotFound, "NOT_FOUND", nil))
+		return
+	}
+
+	graph := h.graph.Graph()
+
+	switch ctx.DefaultQuery("format", "json") {
+	case "json":
+		Respond(ctx, Result{Data: graph}, nil)
+
+	case "dot":
+		ctx.Data(http.StatusOK, "text/vnd.graphviz; charset=utf-8", []byte(graph.DOT()))
+
+	default:
+		Respond(ctx, Result{}, response.NewHTTPError(http.StatusBadRequest, "VALIDATION_ERROR", map[string][]string{"format": {"Supported formats are json and dot"}}))
+	}
+}
+
+// GetLogLevels godoc
+// @Summary List log levels
+// @Description Returns the default log level and the effective level of every logger
+// @Tags admin
+// @Produce json
+// @Success 200 {object} response.APIResponse{data=logging.Levels} "Log levels"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Security ApiKeyAuth[admin] || BearerAuth[admin]
+// @Router /admin/log-level [get]
+func (h *AdminHandler) GetLogLevels(ctx *gin.Context) {
+	Respond(ctx, Result{Data: logging.CurrentLevels()}, nil)
+}
+
+// SetLogLevel godoc
+// @Summary Change a log level at runtime
+// @Description Changes the level of one logger (e.g. http for the access log) or the default level of all loggers without an override. The change lasts until the next restart.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body admin.LogLevelRequest true "Logger and level"
+// @Success 200 {object} response.APIResponse{data=logging.Levels} "Log levels after the change"
+// @Failure 400 {object} response.APIResponse "Invalid level"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Security ApiKeyAuth[admin] || BearerAuth[admin]
+// @Router /admin/log-level [put]
+//
+// Sample Request:
+//
+//	PUT /admin/log-level
+//	{"logger": "http", "level": "warn"}
+func (h *AdminHandler) SetLogLevel(ctx *gin.Context) {
+	var request admin.LogLevelRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		Respond(ctx, Result{}, response.NewHTTPError(http.StatusBadRequest, "VALIDATION_ERROR", extractValidationErrors(ctx, err)))
+		return
+	}
+
+	switch {
+	case request.Level == admin.LogLevelDefault && request.Logger == "":
+		Respond(ctx, Result{}, response.NewHTTPError(http.StatusBadRequest, "VALIDATION_ERROR", map[string][]string{"level": {"The default level needs a concrete level"}}))
+		return
+
+	case request.Level == admin.LogLevelDefault:
+		logging.ResetLevel(request.Logger)
+
+	default:
+		// The binding already restricted the level to known names
+		level, _ := logging.ParseLevel(request.Level)
+		if request.Logger == "" {
+			logging.SetDefaultLevel(level)
+		} else {
+			logging.SetLevel(request.Logger, level)
+		}
+	}
+
+	Respond(ctx, Result{Data: logging.CurrentLevels()}, nil)
+}
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"go_di_architecture/internal/app/jobs"
+	"go_di_architecture/internal/domain/models/backup"
+	"go_di_architecture/internal/domain/models/response"
+	backupService "go_di_architecture/internal/domain/service/backup"
+	"go_di_architecture/internal/domain/storage"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BackupHandler exposes backup and restore of module data to operators.
+//
+// Backups and restores run as background jobs; their results are reported
+// through the jobs API. The routes are registered outside of the versioned
+// public API next to the other admin endpoints.
+type BackupHandler struct {
+	service *backupService.BackupService
+	jobs    *jobs.Runner
+}
+
+// NewBackupHandler creates a new instance of BackupHandler.
+//
+// Parameters:
+//   - service: Business service writing and reading archives
+//   - runner: Background job runner executing backups and restores
+//
+// Returns:
+//   - *BackupHandler: A new handler instance
+func NewBackupHandler(service *backupService.BackupService, runner *jobs.Runner) *BackupHandler {
+	return &BackupHandler{service: service, jobs: runner}
+}
+
+// Routes returns the backup and restore routes.
+func (h *BackupHandler) Routes() []RouteSpec {
+	return []RouteSpec{
+		{Group: GroupAdmin, Method: http.MethodGet, Path: "/backups", Handler: h.ListBackups, Response: []storage.ObjectInfo{}},                                                           // GET /admin/backups
+		{Group: GroupAdmin, Method: http.MethodPost, Path: "/backups", Handler: h.StartBackup, Response: jobs.Job{}, Status: http.StatusAccepted},                                         // POST /admin/backups
+		{Group: GroupAdmin, Method: http.MethodPost, Path: "/backups/restore", Handler: h.StartRestore, Body: backup.RestoreRequest{}, Response: jobs.Job{}, Status: http.StatusAccepted}, // POST /admin/backups/restore
+	}
+}
+
+// ListBackups godoc
+// @Summary List backup archives
+// @Description Returns the backup archives in object storage, newest first
+// @Tags admin
+// @Produce json
+// @Success 200 {object} response.APIResponse{data=[]storage.ObjectInfo} "Archives"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 500 {object} response.APIResponse "Storage error"
+// @Security ApiKeyAuth[admin] || BearerAuth[admin]
+// @Router /admin/backups [get]
+//
+// Sample Request:
+//
+//	GET /admin/backups
+func (h *BackupHandler) ListBackups(ctx *gin.Context) {
+	archives, err := h.service.ListBackups(ctx.Request.Context())
+	if err != nil {
+		Respond(ctx, Result{}, response.NewHTTPError(http.StatusInternalServerError, "INTERNAL_ERROR", nil).WithCause(err))
+		return
+	}
+
+	Respond(ctx, Result{Data: archives}, nil)
+}
+
+// StartBackup godoc
+// @Summary Back up module data
+// @Description Queues a job writing every module with its tags and settings to a versioned JSON archive in object storage. The job result names the archive key.
+// @Tags admin
+// @Produce json
+// @Success 202 {object} response.APIResponse{data=jobs.Job} "Queued backup job"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Security ApiKeyAuth[admin] || BearerAuth[admin]
+// @Router /admin/backups [post]
+//
+// Sample Request:
+//
+//	POST /admin/backups
+func (h *BackupHandler) StartBackup(ctx *gin.Context) {
+	job := h.jobs.Submit(backupService.BackupJobKind, func(jobCtx context.Context) (any, error) {
+		return h.service.Backup(jobCtx)
+	})
+
+	Respond(ctx, Result{Data: job, Status: http.StatusAccepted, Location: "/api/v1/jobs/" + job.ID}, nil)
+}
+
+// StartRestore godoc
+// @Summary Restore module data from a backup archive
+// @Description Queues a job writing the modules of an archive back, matched by name. The conflict policy decides what happens to modules whose name is taken: skip keeps them, overwrite replaces them with the archived state, fail aborts before writing anything. The job result reports what was created, overwritten and skipped.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body backup.RestoreRequest true "Archive and conflict policy"
+// @Param X-Actor header string false "Who restores; recorded in the change history of restored modules"
+// @Success 202 {object} response.APIResponse{data=jobs.Job} "Queued restore job"
+// @Failure 400 {object} response.APIResponse "Missing key or unsupported conflict policy"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Security ApiKeyAuth[admin] || BearerAuth[admin]
+// @Router /admin/backups/restore [post]
+//
+// Sample Request:
+//
+//	POST /admin/backups/restore
+//	X-Actor: ops
+//	{
+//	  "key": "backups/modules-20230815T143000.000Z.json",
+//	  "conflict": "skip"
+//	}
+func (h *BackupHandler) StartRestore(ctx *gin.Context) {
+	// Step 1: Validate request payload
+	var request backup.RestoreRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		Respond(ctx, Result{}, response.NewHTTPError(http.StatusBadRequest, "VALIDATION_ERROR", extractValidationErrors(ctx, err)))
+		return
+	}
+	if request.Conflict == "" {
+		request.Conflict = backup.ConflictFail
+	}
+
+	// Step 2: Queue the restore
+	actor := requestActor(ctx)
+	job := h.jobs.Submit(backupService.RestoreJobKind, func(jobCtx context.Context) (any, error) {
+		return h.service.Restore(jobCtx, request.Key, request.Conflict, actor)
+	})
+
+	// Step 3: Point the client at the job
+	Respond(ctx, Result{Data: job, Status: http.StatusAccepted, Location: "/api/v1/jobs/" + job.ID}, nil)
+}
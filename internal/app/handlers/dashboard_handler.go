@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http"
+
+	"go_di_architecture/internal/app/activity"
+	"go_di_architecture/internal/app/jobs"
+	"go_di_architecture/internal/app/scheduler"
+	"go_di_architecture/internal/domain/models/dashboard"
+
+	"github.com/gin-gonic/gin"
+)
+
+// dashboardCaches are the expvar maps reported as caches.
+var dashboardCaches = []string{"module_cache", "module_name_filter"}
+
+// QueueSource reports the fill level of a background queue.
+type QueueSource interface {
+	QueueStats() dashboard.Queue
+}
+
+// DashboardHandler exposes aggregate operational data for the SRE dashboards.
+//
+// The data is held in memory by the instance answering the request; it is a
+// summary for dashboards, while logs and /admin/metrics stay the detailed
+// sources.
+type DashboardHandler struct {
+	recorder *activity.Recorder
+	jobs     *jobs.Runner
+	queues   []QueueSource
+}
+
+// NewDashboardHandler creates a new instance of DashboardHandler.
+//
+// Parameters:
+//   - recorder: Recorder of the server errors, slow requests and objectives
+//   - runner: Background job runner
+//   - queues: Background queues to report, e.g. the notification dispatcher
+//
+// Returns:
+//   - *DashboardHandler: A new handler instance
+func NewDashboardHandler(recorder *activity.Recorder, runner *jobs.Runner, queues ...QueueSource) *DashboardHandler {
+	return &DashboardHandler{recorder: recorder, jobs: runner, queues: append([]QueueSource{runner}, queues...)}
+}
+
+// Routes returns the aggregate operational data of the SRE dashboards.
+func (h *DashboardHandler) Routes() []RouteSpec {
+	return []RouteSpec{
+		{Group: GroupAdmin, Method: http.MethodGet, Path: "/api/overview", Handler: h.GetOverview, Response: dashboard.Overview{}},             // GET /admin/api/overview
+		{Group: GroupAdmin, Method: http.MethodGet, Path: "/api/errors", Handler: h.GetRecentErrors, Response: []dashboard.Request{}},          // GET /admin/api/errors
+		{Group: GroupAdmin, Method: http.MethodGet, Path: "/api/slow-requests", Handler: h.GetSlowRequests, Response: []dashboard.Request{}},   // GET /admin/api/slow-requests
+		{Group: GroupAdmin, Method: http.MethodGet, Path: "/api/objectives", Handler: h.GetObjectives, Response: []dashboard.RouteObjective{}}, // GET /admin/api/objectives
+		{Group: GroupAdmin, Method: http.MethodGet, Path: "/api/caches", Handler: h.GetCaches, Response: []dashboard.Cache{}},                  // GET /admin/api/caches
+		{Group: GroupAdmin, Method: http.MethodGet, Path: "/api/queues", Handler: h.GetQueues, Response: []dashboard.Queue{}},                  // GET /admin/api/queues
+		{Group: GroupAdmin, Method: http.MethodGet, Path: "/api/jobs", Handler: h.GetJobStatuses, Response: dashboard.Jobs{}},                  // GET /admin/api/jobs
+	}
+}
+
+// GetOverview godoc
+// @Summary Get the operational overview
+// @Description Returns recent server errors, slow requests, objective burn rates, cache counters, queue depths and job statuses of the instance in one response
+// @Tags admin
+// @Produce json
+// @Success 200 {object} response.APIResponse{data=dashboard.Overview} "Overview"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Security ApiKeyAuth[admin] || BearerAuth[admin]
+// @Router /admin/api/overview [get]
+func (h *DashboardHandler) GetOverview(ctx *gin.Context) {
+	Respond(ctx, Result{Data: dashboard.Overview{
+		Errors:         h.recorder.Errors(),
+		SlowRequests:   h.recorder.SlowRequests(),
+		Objectives:     h.recorder.Objectives(),
+		Caches:         h.caches(),
+		Queues:         h.queueStats(),
+		ScheduledJobs:  scheduler.Statuses(),
+		BackgroundJobs: h.jobs.Counts(),
+	}}, nil)
+}
+
+// GetRecentErrors godoc
+// @Summary List recent server errors
+// @Description Returns the most recent requests answered with a 5xx status, newest first, with their internal error
+// @Tags admin
+// @Produce json
+// @Success 200 {object} response.APIResponse{data=[]dashboard.Request} "Server errors"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Security ApiKeyAuth[admin] || BearerAuth[admin]
+// @Router /admin/api/errors [get]
+func (h *DashboardHandler) GetRecentErrors(ctx *gin.Context) {
+	Respond(ctx, Result{Data: h.recorder.Errors()}, nil)
+}
+
+// GetSlowRequests godoc
+// @Summary List recent slow requests
+// @Description Returns the most recent requests that took at least the slow request threshold, newest first
+// @Tags admin
+// @Produce json
+// @Success 200 {object} response.APIResponse{data=[]dashboard.Request} "Slow requests"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Security ApiKeyAuth[admin] || BearerAuth[admin]
+// @Router /admin/api/slow-requests [get]
+func (h *DashboardHandler) GetSlowRequests(ctx *gin.Context) {
+	Respond(ctx, Result{Data: h.recorder.SlowRequests()}, nil)
+}
+
+// GetObjectives godoc
+// @Summary Get the burn rates of the service level objectives
+// @Description Returns, per route with requests in the last hour, its latency and availability objective and the rates at which the last 5 minutes and the last hour spent the error budgets, fastest burning first. A burn rate of 1 spends the budget exactly as fast as the target allows.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} response.APIResponse{data=[]dashboard.RouteObjective} "Objectives"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Security ApiKeyAuth[admin] || BearerAuth[admin]
+// @Router /admin/api/objectives [get]
+func (h *DashboardHandler) GetObjectives(ctx *gin.Context) {
+	Respond(ctx, Result{Data: h.recorder.Objectives()}, nil)
+}
+
+// GetCaches godoc
+// @Summary Get cache counters
+// @Description Returns the hit, miss and invalidation counters of the in-memory caches since start
+// @Tags admin
+// @Produce json
+// @Success 200 {object} response.APIResponse{data=[]dashboard.Cache} "Caches"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Security ApiKeyAuth[admin] || BearerAuth[admin]
+// @Router /admin/api/caches [get]
+func (h *DashboardHandler) GetCaches(ctx *gin.Context) {
+	Respond(ctx, Result{Data: h.caches()}, nil)
+}
+
+// GetQueues godoc
+// @Summary Get queue depths
+// @Description Returns the items waiting in and being processed by the background queues
+// @Tags admin
+// @Produce json
+// @Success 200 {object} response.APIResponse{data=[]dashboard.Queue} "Queues"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Security ApiKeyAuth[admin] || BearerAuth[admin]
+// @Router /admin/api/queues [get]
+func (h *DashboardHandler) GetQueues(ctx *gin.Context) {
+	Respond(ctx, Result{Data: h.queueStats()}, nil)
+}
+
+// GetJobStatuses godoc
+// @Summary Get background job statuses
+// @Description Returns the run status of every periodic job and the background jobs by state
+// @Tags admin
+// @Produce json
+// @Success 200 {object} response.APIResponse{data=dashboard.Jobs} "Jobs"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Security ApiKeyAuth[admin] || BearerAuth[admin]
+// @Router /admin/api/jobs [get]
+func (h *DashboardHandler) GetJobStatuses(ctx *gin.Context) {
+	Respond(ctx, Result{Data: dashboard.Jobs{
+		ScheduledJobs:  scheduler.Statuses(),
+		BackgroundJobs: h.jobs.Counts(),
+	}}, nil)
+}
+
+// caches reads the counters of the cache expvars that are published.
+func (h *DashboardHandler) caches() []dashboard.Cache {
+	caches := []dashboard.Cache{}
+	for _, name := range dashboardCaches {
+		stats, ok := expvar.Get(name).(*expvar.Map)
+		if !ok {
+			continue
+		}
+		cache := dashboard.Cache{Name: name, Stats: make(map[string]any)}
+		stats.Do(func(kv expvar.KeyValue) {
+			var value any
+			if err := json.Unmarshal([]byte(kv.Value.String()), &value); err == nil {
+				cache.Stats[kv.Key] = value
+			}
+		})
+		caches = append(caches, cache)
+	}
+	return caches
+}
+
+// queueStats reports every queue.
+func (h *DashboardHandler) queueStats() []dashboard.Queue {
+	queues := make([]dashboard.Queue, 0, len(h.queues))
+	for _, queue := range h.queues {
+		queues = append(queues, queue.QueueStats())
+	}
+	return queues
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"go_di_architecture/internal/domain/models/deadletter"
+	deadLetterService "go_di_architecture/internal/domain/service/deadletter"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DeadLetterHandler exposes the dead-letter queue of the command consumer to
+// operators.
+//
+// Commands land there when they are malformed, panic or keep failing with
+// server errors; operators inspect them and replay them once the cause is
+// fixed.
+type DeadLetterHandler struct {
+	service *deadLetterService.DeadLetterService
+}
+
+// NewDeadLetterHandler creates a new instance of DeadLetterHandler.
+//
+// Parameters:
+//   - service: Business service reading and replaying dead letters
+//
+// Returns:
+//   - *DeadLetterHandler: A new handler instance
+func NewDeadLetterHandler(service *deadLetterService.DeadLetterService) *DeadLetterHandler {
+	return &DeadLetterHandler{service: service}
+}
+
+// Routes returns the dead-letter queue routes of the command consumer.
+func (h *DeadLetterHandler) Routes() []RouteSpec {
+	return []RouteSpec{
+		{Group: GroupAdmin, Method: http.MethodGet, Path: "/dead-letters", Handler: h.ListDeadLetters, Query: deadletter.ListQuery{}, Response: deadletter.DeadLetterList{}}, // GET /admin/dead-letters
+		{Group: GroupAdmin, Method: http.MethodPost, Path: "/dead-letters/:id/replay", Handler: h.ReplayDeadLetter, Status: http.StatusNoContent},                            // POST /admin/dead-letters/{id}/replay
+	}
+}
+
+// ListDeadLetters godoc
+// @Summary List dead-lettered commands
+// @Description Returns the number of messages in the dead-letter queue of the command consumer and the oldest of them, with their bodies as received. Listing leaves the messages in the queue.
+// @Tags admin
+// @Produce json
+// @Param limit query int false "Most dead letters returned (1-100, default 50)"
+// @Success 200 {object} response.APIResponse{data=deadletter.DeadLetterList} "Dead letters"
+// @Failure 400 {object} response.APIResponse "Invalid limit"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 404 {object} response.APIResponse "No command queue is configured"
+// @Failure 500 {object} response.APIResponse "Broker unreachable"
+// @Security ApiKeyAuth[admin] || BearerAuth[admin]
+// @Router /admin/dead-letters [get]
+//
+// Sample Request:
+//
+//	GET /admin/dead-letters?limit=20
+func (h *DeadLetterHandler) ListDeadLetters(ctx *gin.Context) {
+	var query deadletter.ListQuery
+	if !bindQuery(ctx, &query) {
+		return
+	}
+
+	list, err := h.service.List(ctx.Request.Context(), query.Limit)
+	if err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	Respond(ctx, Result{Data: list}, nil)
+}
+
+// ReplayDeadLetter godoc
+// @Summary Replay a dead-lettered command
+// @Description Moves the dead letter back to the end of the command queue, where the consumer executes it again and replies as usual. Identical dead letters share an identifier; one of them is replayed per call.
+// @Tags admin
+// @Produce json
+// @Param id path string true "Dead letter identifier (16 hex digits)"
+// @Success 204 "Dead letter requeued"
+// @Failure 400 {object} response.APIResponse "Invalid identifier"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 404 {object} response.APIResponse "Dead letter not found or no command queue configured"
+// @Failure 500 {object} response.APIResponse "Broker unreachable"
+// @Security ApiKeyAuth[admin] || BearerAuth[admin]
+// @Router /admin/dead-letters/{id}/replay [post]
+//
+// Sample Request:
+//
+//	POST /admin/dead-letters/9f86d081884c7d65/replay
+func (h *DeadLetterHandler) ReplayDeadLetter(ctx *gin.Context) {
+	var params deadletter.IDParams
+	if !bindPath(ctx, &params) {
+		return
+	}
+
+	if err := h.service.Replay(ctx.Request.Context(), params.ID); err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	Respond(ctx, Result{}, nil)
+}
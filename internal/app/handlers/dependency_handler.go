@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"go_di_architecture/internal/domain/models/module"
+	"go_di_architecture/internal/domain/models/response"
+	dependencyService "go_di_architecture/internal/domain/service/dependency"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DependencyHandler handles HTTP requests for dependencies between modules.
+//
+// Dependencies are directed edges "module -> module it depends on". The
+// handler exposes both walk directions:
+//   - dependencies: what a module needs to run
+//   - dependents: what breaks when a module changes (impact analysis)
+type DependencyHandler struct {
+	service *dependencyService.DependencyService
+}
+
+// NewDependencyHandler creates a new instance of DependencyHandler.
+//
+// Parameters:
+//   - service: Business service handling dependency operations
+//
+// Returns:
+//   - *DependencyHandler: A new handler instance
+func NewDependencyHandler(service *dependencyService.DependencyService) *DependencyHandler {
+	return &DependencyHandler{service: service}
+}
+
+// Routes returns the routes of the dependencies between modules.
+func (h *DependencyHandler) Routes() []RouteSpec {
+	return []RouteSpec{
+		// Graph walks
+		{Method: http.MethodGet, Path: "/modules/:id/dependencies", Policy: readScope, Handler: h.ListDependencies, Query: module.DependencyWalkQuery{}, Response: []module.DependencyResponse{}}, // GET /api/v1/modules/{id}/dependencies
+		{Method: http.MethodGet, Path: "/modules/:id/dependents", Policy: readScope, Handler: h.ListDependents, Query: module.DependencyWalkQuery{}, Response: []module.DependencyResponse{}},     // GET /api/v1/modules/{id}/dependents
+
+		// Edge management
+		{Method: http.MethodPut, Path: "/modules/:id/dependencies/:dependencyId", Policy: writeScope, Handler: h.AddDependency, Response: []module.DependencyResponse{}},       // PUT /api/v1/modules/{id}/dependencies/{dependencyId}
+		{Method: http.MethodDelete, Path: "/modules/:id/dependencies/:dependencyId", Policy: writeScope, Handler: h.RemoveDependency, Response: []module.DependencyResponse{}}, // DELETE /api/v1/modules/{id}/dependencies/{dependencyId}
+	}
+}
+
+// ListDependencies godoc
+// @Summary List the dependencies of a module
+// @Description Lists the modules a module depends on. With transitive=true the whole dependency tree is walked and each module is reported once at its shortest depth.
+// @Tags dependencies
+// @Produce json
+// @Param id path int true "Module ID"
+// @Param transitive query bool false "Include indirect dependencies" default(false)
+// @Success 200 {object} response.APIResponse{data=[]module.DependencyResponse} "Dependencies ordered by depth"
+// @Failure 400 {object} response.APIResponse "Invalid module ID or query parameter"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 404 {object} response.APIResponse "Module not found"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[modules:read] || BearerAuth[modules:read]
+// @Router /modules/{id}/dependencies [get]
+//
+// Sample Request:
+//
+//	GET /api/v1/modules/1/dependencies?transitive=true
+//
+// Sample Success Response (200):
+//
+//	{
+//	  "success": true,
+//	  "message": "Operation completed successfully",
+//	  "data": [
+//	    {"id": 2, "name": "Billing", "description": "", "isActive": true, "createdAt": "2023-08-15T14:30:00Z", "updatedAt": "2023-08-15T14:30:00Z", "depth": 1},
+//	    {"id": 5, "name": "Ledger", "description": "", "isActive": true, "createdAt": "2023-08-15T14:30:00Z", "updatedAt": "2023-08-15T14:30:00Z", "depth": 2}
+//	  ],
+//	  "meta": {
+//	    "requestId": "a1b2c3d4",
+//	    "timestamp": "2023-08-15T14:30:00Z"
+//	  }
+//	}
+func (h *DependencyHandler) ListDependencies(ctx *gin.Context) {
+	h.walk(ctx, dependencyService.Dependencies)
+}
+
+// ListDependents godoc
+// @Summary List the dependents of a module
+// @Description Lists the modules depending on a module, for impact analysis. With transitive=true every module indirectly affected by a change is reported.
+// @Tags dependencies
+// @Produce json
+// @Param id path int true "Module ID"
+// @Param transitive query bool false "Include indirect dependents" default(false)
+// @Success 200 {object} response.APIResponse{data=[]module.DependencyResponse} "Dependents ordered by depth"
+// @Failure 400 {object} response.APIResponse "Invalid module ID or query parameter"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 404 {object} response.APIResponse "Module not found"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[modules:read] || BearerAuth[modules:read]
+// @Router /modules/{id}/dependents [get]
+//
+// Sample Request:
+//
+//	GET /api/v1/modules/5/dependents?transitive=true
+func (h *DependencyHandler) ListDependents(ctx *gin.Context) {
+	h.walk(ctx, dependencyService.Dependents)
+}
+
+// AddDependency godoc
+// @Summary Declare a dependency
+// @Description Declares that the module depends on another module. Declaring an existing dependency is a no-op; dependencies that would create a cycle are rejected with the cycle path in error.context.
+// @Tags dependencies
+// @Produce json
+// @Param id path int true "Module ID"
+// @Param dependencyId path int true "ID of the module depended on"
+// @Success 200 {object} response.APIResponse{data=[]module.DependencyResponse} "Direct dependencies after the change"
+// @Failure 400 {object} response.APIResponse "Invalid ID or self-dependency"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 404 {object} response.APIResponse "Module not found"
+// @Failure 409 {object} response.APIResponse "Dependency would create a cycle"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[modules:write] || BearerAuth[modules:write]
+// @Router /modules/{id}/dependencies/{dependencyId} [put]
+//
+// Sample Request:
+//
+//	PUT /api/v1/modules/5/dependencies/1
+//
+// Sample Cycle Response (409):
+//
+//	{
+//	  "success": false,
+//	  "message": "Resource already exists",
+//	  "error": {
+//	    "code": "DEPENDENCY_CYCLE",
+//	    "message": "dependency would create a cycle",
+//	    "context": {
+//	      "cycle": [5, 1, 2, 5]
+//	    }
+//	  },
+//	  "meta": {
+//	    "requestId": "a1b2c3d4",
+//	    "timestamp": "2023-08-15T14:30:00Z"
+//	  }
+//	}
+func (h *DependencyHandler) AddDependency(ctx *gin.Context) {
+	var params module.DependencyParams
+	if !bindPath(ctx, &params) {
+		return
+	}
+
+	dependencies, err := h.service.AddDependency(params.Key(), params.DependencyKey(), requestSubject(ctx))
+	if err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	Respond(ctx, Result{Data: dependencies}, nil)
+}
+
+// RemoveDependency godoc
+// @Summary Remove a dependency
+// @Description Removes a declared dependency; removing a dependency that does not exist is a no-op
+// @Tags dependencies
+// @Produce json
+// @Param id path int true "Module ID"
+// @Param dependencyId path int true "ID of the module depended on"
+// @Success 200 {object} response.APIResponse{data=[]module.DependencyResponse} "Direct dependencies after the change"
+// @Failure 400 {object} response.APIResponse "Invalid module or dependency ID"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 404 {object} response.APIResponse "Module not found"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[modules:write] || BearerAuth[modules:write]
+// @Router /modules/{id}/dependencies/{dependencyId} [delete]
+func (h *DependencyHandler) RemoveDependency(ctx *gin.Context) {
+	var params module.DependencyParams
+	if !bindPath(ctx, &params) {
+		return
+	}
+
+	dependencies, err := h.service.RemoveDependency(params.Key(), params.DependencyKey(), requestSubject(ctx))
+	if err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	Respond(ctx, Result{Data: dependencies}, nil)
+}
+
+// walk serves both graph listing endpoints.
+//
+// Parameters:
+//   - ctx: Gin context for the request
+//   - direction: Which edges to follow
+func (h *DependencyHandler) walk(ctx *gin.Context, direction dependencyService.Direction) {
+	// Step 1: Parse the module ID and transitive flag
+	var params module.IDParams
+	var query module.DependencyWalkQuery
+	if !bindPath(ctx, &params) || !bindQuery(ctx, &query) {
+		return
+	}
+
+	// Step 2: Walk the graph
+	modules, err := h.service.ListDependencies(params.Key(), requestSubject(ctx), direction, query.Transitive)
+	if err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	// Step 3: Return the reached modules
+	Respond(ctx, Result{Data: modules}, nil)
+}
+
+// init registers the response details of the dependency service errors.
+func init() {
+	// Surface the cycle so clients can show which edge to remove
+	response.Errors.Enrich(dependencyService.ErrDependencyCycle, func(err error, httpErr *response.HTTPError) {
+		var cycle *dependencyService.CycleError
+		if errors.As(err, &cycle) {
+			httpErr.Message = cycle.Error()
+			httpErr.WithContext(map[string]interface{}{"cycle": cycle.Path})
+		}
+	})
+}
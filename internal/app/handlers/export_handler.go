@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+
+	"go_di_architecture/internal/app/jobs"
+	"go_di_architecture/internal/domain/models/export"
+	"go_di_architecture/internal/domain/models/response"
+	exportService "go_di_architecture/internal/domain/service/export"
+	"go_di_architecture/internal/domain/storage"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DownloadSource opens objects behind signed download links served by the API.
+//
+// Implemented by the local storage; object stores such as S3 serve their
+// pre-signed links themselves.
+type DownloadSource interface {
+	Open(key, expires, signature string) (*os.File, error)
+}
+
+// ExportHandler handles HTTP requests for asynchronous module exports.
+//
+// An export runs as a background job; clients poll the job through the jobs
+// API and download the file from the pre-signed URL in the job result.
+type ExportHandler struct {
+	service   *exportService.ExportService
+	jobs      *jobs.Runner
+	downloads DownloadSource
+}
+
+// NewExportHandler creates a new instance of ExportHandler.
+//
+// Parameters:
+//   - service: Business service writing the exports
+//   - runner: Background job runner executing the exports
+//   - downloads: Source of API-served downloads; nil when the object store
+//     serves its own links
+//
+// Returns:
+//   - *ExportHandler: A new handler instance
+func NewExportHandler(service *exportService.ExportService, runner *jobs.Runner, downloads DownloadSource) *ExportHandler {
+	return &ExportHandler{service: service, jobs: runner, downloads: downloads}
+}
+
+// Routes returns the export and download routes.
+//
+// Downloads carry no policy: the signed link itself grants access.
+func (h *ExportHandler) Routes() []RouteSpec {
+	return []RouteSpec{
+		{Method: http.MethodPost, Path: "/exports", Policy: readScope, Handler: h.StartExport, Body: export.ExportRequest{}, Response: jobs.Job{}, Status: http.StatusAccepted}, // POST /api/v1/exports
+		{Method: http.MethodGet, Path: "/downloads/*key", Handler: h.Download, Objective: streamObjective},                                                                      // GET /api/v1/downloads/{key}
+	}
+}
+
+// StartExport godoc
+// @Summary Start an asynchronous module export
+// @Description Queues a job writing every module visible to the actor as CSV or XLSX to object storage. Poll GET /jobs/{id}; once the job succeeded its result holds a pre-signed download URL valid for a limited time.
+// @Tags exports
+// @Accept json
+// @Produce json
+// @Param request body export.ExportRequest true "Export format"
+// @Param X-Actor header string false "Who requests the export; modules hidden from the actor by their ACL are left out"
+// @Param X-Actor-Teams header string false "Comma-separated teams of the actor, matched against the module ACL"
+// @Success 202 {object} response.APIResponse{data=jobs.Job} "Queued export job"
+// @Failure 400 {object} response.APIResponse "Missing or unsupported format"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Security ApiKeyAuth[modules:read] || BearerAuth[modules:read]
+// @Router /exports [post]
+//
+// Sample Request:
+//
+//	POST /api/v1/exports
+//	X-Actor: jane
+//	{
+//	  "format": "xlsx"
+//	}
+func (h *ExportHandler) StartExport(ctx *gin.Context) {
+	// Step 1: Validate request payload
+	var request export.ExportRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		Respond(ctx, Result{}, response.NewHTTPError(http.StatusBadRequest, "VALIDATION_ERROR", extractValidationErrors(ctx, err)))
+		return
+	}
+
+	// Step 2: Queue the export; the subject is captured now, the request ends before the job runs
+	subject := requestSubject(ctx)
+	job := h.jobs.Submit(exportService.JobKind, func(jobCtx context.Context) (any, error) {
+		return h.service.Export(jobCtx, request.Format, subject)
+	})
+
+	// Step 3: Point the client at the job
+	Respond(ctx, Result{Data: job, Status: http.StatusAccepted, Location: "/api/v1/jobs/" + job.ID}, nil)
+}
+
+// Download godoc
+// @Summary Download an export file
+// @Description Serves a file behind a signed download link handed out by a finished export job. Only used with local storage; links of object stores point at the store directly.
+// @Tags exports
+// @Produce octet-stream
+// @Param key path string true "Object key"
+// @Param expires query int true "Unix expiry time of the link"
+// @Param signature query string true "Signature of the link"
+// @Success 200 {file} file "Export file"
+// @Failure 403 {object} response.APIResponse "Forged, altered or expired link"
+// @Failure 404 {object} response.APIResponse "File not found or local storage not in use"
+// @Router /downloads/{key} [get]
+//
+// Sample Request:
+//
+//	GET /api/v1/downloads/exports/modules-20230815T143000Z-9f2c1a7b3d4e5f60.csv?expires=1692110700&signature=...
+func (h *ExportHandler) Download(ctx *gin.Context) {
+	// Step 1: Verify the link and open the file
+	if h.downloads == nil {
+		Respond(ctx, Result{}, response.NewHTTPError(http.StatusNotFound, "NOT_FOUND", nil))
+		return
+	}
+	key := strings.TrimPrefix(ctx.Param("key"), "/")
+	file, err := h.downloads.Open(key, ctx.Query("expires"), ctx.Query("signature"))
+	switch {
+	case errors.Is(err, storage.ErrInvalidSignature):
+		Respond(ctx, Result{}, response.NewHTTPError(http.StatusForbidden, "INVALID_SIGNATURE", nil))
+		return
+	case errors.Is(err, fs.ErrNotExist):
+		Respond(ctx, Result{}, response.NewHTTPError(http.StatusNotFound, "NOT_FOUND", nil))
+		return
+	case err != nil:
+		Respond(ctx, Result{}, response.NewHTTPError(http.StatusInternalServerError, "INTERNAL_ERROR", nil).WithCause(err))
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		Respond(ctx, Result{}, response.NewHTTPError(http.StatusInternalServerError, "INTERNAL_ERROR", nil).WithCause(err))
+		return
+	}
+
+	// Step 2: Stream the file as an attachment
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	ctx.DataFromReader(http.StatusOK, info.Size(), contentType, file, map[string]string{
+		"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": path.Base(key)}),
+	})
+}
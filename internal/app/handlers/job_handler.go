@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"net/http"
+
+	"go_di_architecture/internal/app/jobs"
+	"go_di_architecture/internal/domain/models/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JobHandler exposes the status of background jobs.
+type JobHandler struct {
+	jobs *jobs.Runner
+}
+
+// NewJobHandler creates a new instance of JobHandler.
+//
+// Parameters:
+//   - runner: Background job runner holding the job states
+//
+// Returns:
+//   - *JobHandler: A new handler instance
+func NewJobHandler(runner *jobs.Runner) *JobHandler {
+	return &JobHandler{jobs: runner}
+}
+
+// Routes returns the background job routes.
+func (h *JobHandler) Routes() []RouteSpec {
+	return []RouteSpec{
+		{Method: http.MethodGet, Path: "/jobs/:id", Policy: readScope, Handler: h.GetJob, Response: jobs.Job{}}, // GET /api/v1/jobs/{id}
+	}
+}
+
+// GetJob godoc
+// @Summary Get a background job
+// @Description Returns the state of a background job. Succeeded jobs carry their result (e.g. the download URL of an export), failed jobs the reason. Jobs are kept in memory for 24 hours after they finish and are lost on restart.
+// @Tags jobs
+// @Produce json
+// @Param id path string true "Job ID"
+// @Success 200 {object} response.APIResponse{data=jobs.Job} "Job"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 404 {object} response.APIResponse "Job not found"
+// @Security ApiKeyAuth[modules:read] || BearerAuth[modules:read]
+// @Router /jobs/{id} [get]
+//
+// Sample Request:
+//
+//	GET /api/v1/jobs/0190f5c4-3b1e-7c51-9a51-4be6d1c7a8f2
+func (h *JobHandler) GetJob(ctx *gin.Context) {
+	job, ok := h.jobs.Get(ctx.Param("id"))
+	if !ok {
+		Respond(ctx, Result{}, response.NewHTTPError(http.StatusNotFound, "NOT_FOUND", nil))
+		return
+	}
+
+	Respond(ctx, Result{Data: job}, nil)
+}
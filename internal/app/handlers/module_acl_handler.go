@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"net/http"
+
+	"go_di_architecture/internal/domain/models/module"
+	"go_di_architecture/internal/domain/models/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetModuleACL godoc
+// @Summary Get the access control list of a module
+// @Description Lists who may view or edit a module. A module without entries is open to everyone.
+// @Tags modules
+// @Produce json
+// @Param id path int true "Module ID"
+// @Param X-Actor header string false "Who asks; must be allowed to view the module"
+// @Param X-Actor-Teams header string false "Comma-separated teams of the actor"
+// @Success 200 {object} response.APIResponse{data=module.ModuleACLResponse} "Access control list"
+// @Failure 400 {object} response.APIResponse "Invalid module ID"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 404 {object} response.APIResponse "Module not found or hidden from the actor"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[modules:read] || BearerAuth[modules:read]
+// @Router /modules/{id}/acl [get]
+//
+// Sample Request:
+//
+//	GET /api/v1/modules/123/acl
+//	X-Actor: jane
+func (h *ModuleHandler) GetModuleACL(ctx *gin.Context) {
+	var params module.IDParams
+	if !bindPath(ctx, &params) {
+		return
+	}
+
+	acl, err := h.bound(ctx).GetModuleACL(params.Key(), requestSubject(ctx))
+	if err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	Respond(ctx, Result{Data: acl}, nil)
+}
+
+// ReplaceModuleACL godoc
+// @Summary Replace the access control list of a module
+// @Description Replaces all entries of a module ACL. Principals are "user:<actor>" or "team:<name>"; "edit" includes "view". A non-empty list always grants edit to the module owner, and must grant edit to the caller or one of their teams unless the caller is the owner or an admin. An empty list opens the module to everyone again.
+// @Tags modules
+// @Accept json
+// @Produce json
+// @Param id path int true "Module ID"
+// @Param request body module.ModuleACLRequest true "New access control list"
+// @Param X-Actor header string false "Who changes the ACL; must be allowed to edit the module"
+// @Param X-Actor-Teams header string false "Comma-separated teams of the actor"
+// @Success 200 {object} response.APIResponse{data=module.ModuleACLResponse} "Stored access control list"
+// @Failure 400 {object} response.APIResponse "Malformed entries or the caller would lock themselves out"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "The actor may view but not edit the module"
+// @Failure 404 {object} response.APIResponse "Module not found or hidden from the actor"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[modules:write] || BearerAuth[modules:write]
+// @Router /modules/{id}/acl [put]
+//
+// Sample Request:
+//
+//	PUT /api/v1/modules/123/acl
+//	X-Actor: jane
+//	{
+//	  "entries": [
+//	    {"principal": "user:jane", "permission": "edit"},
+//	    {"principal": "team:payments", "permission": "view"}
+//	  ]
+//	}
+func (h *ModuleHandler) ReplaceModuleACL(ctx *gin.Context) {
+	var params module.IDParams
+	if !bindPath(ctx, &params) {
+		return
+	}
+
+	// Step 1: Validate request payload
+	var request module.ModuleACLRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		Respond(ctx, Result{}, response.NewHTTPError(http.StatusBadRequest, "VALIDATION_ERROR", extractValidationErrors(ctx, err)))
+		return
+	}
+
+	// Step 2: Replace the entries
+	acl, err := h.bound(ctx).ReplaceModuleACL(params.Key(), request, requestSubject(ctx))
+	if err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	// Step 3: Return the stored ACL
+	Respond(ctx, Result{Data: acl}, nil)
+}
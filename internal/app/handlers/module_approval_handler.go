@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"net/http"
+
+	"go_di_architecture/internal/domain/models/module"
+	"go_di_architecture/internal/domain/models/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SubmitModule godoc
+// @Summary Submit a draft module for approval
+// @Description Moves a draft to pending. The module needs a description; while pending it cannot be updated or reverted. The change is recorded in the module history as a "submit" revision and announced through a module.submitted event.
+// @Tags modules
+// @Produce json
+// @Param id path int true "Module ID"
+// @Param X-Actor header string false "Who submits the module; must be allowed to edit it"
+// @Param X-Actor-Teams header string false "Comma-separated teams of the actor, matched against the module ACL"
+// @Success 200 {object} response.APIResponse{data=module.ModuleResponse} "Pending module"
+// @Failure 400 {object} response.APIResponse "The module has no description"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "The actor may view but not edit the module"
+// @Failure 404 {object} response.APIResponse "Module not found"
+// @Failure 409 {object} response.APIResponse "The module is not a draft"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[modules:write] || BearerAuth[modules:write]
+// @Router /modules/{id}/submit [post]
+//
+// Sample Request:
+//
+//	POST /api/v1/modules/123/submit
+//	X-Actor: jane
+func (h *ModuleHandler) SubmitModule(ctx *gin.Context) {
+	var params module.IDParams
+	if !bindPath(ctx, &params) {
+		return
+	}
+
+	submitted, err := h.bound(ctx).SubmitModule(params.Key(), requestSubject(ctx))
+	if err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	Respond(ctx, Result{Data: submitted, ETag: moduleETag(submitted)}, nil)
+}
+
+// ApproveModule godoc
+// @Summary Approve a pending module
+// @Description Approves a pending module and activates it; a module with activateAt is activated by the scheduler at that time instead. The change is recorded in the module history as an "approve" revision and the owner is notified through a module.approved event carrying the optional comment.
+// @Tags modules
+// @Accept json
+// @Produce json
+// @Param id path int true "Module ID"
+// @Param request body module.ReviewRequest false "Comment for the owner"
+// @Param X-Actor header string false "The approver"
+// @Param X-Actor-Teams header string false "Comma-separated teams of the actor, matched against the module ACL"
+// @Success 200 {object} response.APIResponse{data=module.ModuleResponse} "Approved module"
+// @Failure 400 {object} response.APIResponse "Comment too long"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 404 {object} response.APIResponse "Module not found"
+// @Failure 409 {object} response.APIResponse "The module is not pending"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[modules:approve] || BearerAuth[modules:approve]
+// @Router /modules/{id}/approve [post]
+//
+// Sample Request:
+//
+//	POST /api/v1/modules/123/approve
+//	X-Actor: lead
+//	{
+//	  "comment": "Looks good"
+//	}
+func (h *ModuleHandler) ApproveModule(ctx *gin.Context) {
+	params, request, ok := bindReview(ctx)
+	if !ok {
+		return
+	}
+
+	approved, err := h.bound(ctx).ApproveModule(params.Key(), request, requestSubject(ctx))
+	if err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	Respond(ctx, Result{Data: approved, ETag: moduleETag(approved)}, nil)
+}
+
+// RejectModule godoc
+// @Summary Return a pending module to draft
+// @Description Rejects a pending module; it becomes a draft the owner can change and submit again. The change is recorded in the module history as a "reject" revision and the owner is notified through a module.rejected event carrying the optional comment.
+// @Tags modules
+// @Accept json
+// @Produce json
+// @Param id path int true "Module ID"
+// @Param request body module.ReviewRequest false "Reason for the owner"
+// @Param X-Actor header string false "The approver"
+// @Param X-Actor-Teams header string false "Comma-separated teams of the actor, matched against the module ACL"
+// @Success 200 {object} response.APIResponse{data=module.ModuleResponse} "Draft module"
+// @Failure 400 {object} response.APIResponse "Comment too long"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 404 {object} response.APIResponse "Module not found"
+// @Failure 409 {object} response.APIResponse "The module is not pending"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[modules:approve] || BearerAuth[modules:approve]
+// @Router /modules/{id}/reject [post]
+//
+// Sample Request:
+//
+//	POST /api/v1/modules/123/reject
+//	X-Actor: lead
+//	{
+//	  "comment": "Please describe which warehouses use the module"
+//	}
+func (h *ModuleHandler) RejectModule(ctx *gin.Context) {
+	params, request, ok := bindReview(ctx)
+	if !ok {
+		return
+	}
+
+	rejected, err := h.bound(ctx).RejectModule(params.Key(), request, requestSubject(ctx))
+	if err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	Respond(ctx, Result{Data: rejected, ETag: moduleETag(rejected)}, nil)
+}
+
+// bindReview binds the module ID and the optional review payload.
+//
+// Parameters:
+//   - ctx: Gin context for the request
+//
+// Returns:
+//   - module.IDParams: The module ID
+//   - module.ReviewRequest: The comment (empty without a body)
+//   - bool: False if a 400 response was already written
+func bindReview(ctx *gin.Context) (module.IDParams, module.ReviewRequest, bool) {
+	var params module.IDParams
+	var request module.ReviewRequest
+	if !bindPath(ctx, &params) {
+		return params, request, false
+	}
+
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			Respond(ctx, Result{}, response.NewHTTPError(http.StatusBadRequest, "VALIDATION_ERROR", extractValidationErrors(ctx, err)))
+			return params, request, false
+		}
+	}
+	return params, request, true
+}
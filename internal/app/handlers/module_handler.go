@@ -1,22 +1,29 @@
 package handlers
 
 import (
-	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"go_di_architecture/internal/domain/models/module"
+	"go_di_architecture/internal/domain/models/pagination"
 	"go_di_architecture/internal/domain/models/response"
 	moduleService "go_di_architecture/internal/domain/service/module"
-	moduleRepo "go_di_architecture/internal/infra/db/module"
+	tagService "go_di_architecture/internal/domain/service/tag"
+	"go_di_architecture/internal/i18n"
+	"go_di_architecture/internal/logging"
+	"go_di_architecture/internal/templating"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 )
 
+// logger writes the errors the handlers cannot report in their responses.
+var logger = logging.New("handlers")
+
 // ModuleHandler handles HTTP requests for module entities.
 //
 // This handler implements the same pattern as the .NET example, using:
@@ -36,31 +43,97 @@ import (
 //   - meta: Additional metadata (request ID, timestamp)
 type ModuleHandler struct {
 	service *moduleService.ModuleService
+
+	// Templates rendering HTML reports
+	templates *templating.Engine
 }
 
 // NewModuleHandler creates a new instance of ModuleHandler.
 //
+// Parameters:
+//   - service: Business service handling module operations
+//   - templates: Templates rendering HTML reports
+//
 // Returns:
 //   - *ModuleHandler: A new handler instance
-func NewModuleHandler() *ModuleHandler {
-	fmt.Println("[DEBUG] NewModuleHandler called") // <-- THIS MUST BE PRINTED
+func NewModuleHandler(service *moduleService.ModuleService, templates *templating.Engine) *ModuleHandler {
+	return &ModuleHandler{service: service, templates: templates}
+}
 
-	repo := moduleRepo.NewModuleRepository()
-	service := moduleService.NewModuleService(repo)
-	return &ModuleHandler{service: service}
+// bound returns the service for an endpoint, running the module statements
+// with the request context: the query budget and the slow request trace
+// attribute them to the request, and the writes join its unit of work.
+func (h *ModuleHandler) bound(ctx *gin.Context) *moduleService.ModuleService {
+	return h.service.WithContext(ctx.Request.Context())
+}
+
+// Routes returns the module, recycle bin, ownership transfer and public module routes.
+func (h *ModuleHandler) Routes() []RouteSpec {
+	return []RouteSpec{
+		// Collection endpoints
+		{Method: http.MethodGet, Path: "/modules", Policy: readScope, Handler: h.ListModules, Query: module.ListQuery{}, Response: []module.ModuleResponse{}},                                 // GET /api/v1/modules
+		{Method: http.MethodPost, Path: "/modules", Policy: writeScope, Handler: h.CreateModule, Body: module.ModuleRequest{}, Response: module.ModuleResponse{}, Status: http.StatusCreated}, // POST /api/v1/modules
+		{Method: http.MethodGet, Path: "/modules/stream", Policy: readScope, Handler: h.StreamModules, Objective: streamObjective},                                                            // GET /api/v1/modules/stream
+		{Method: http.MethodGet, Path: "/modules/count", Policy: readScope, Handler: h.CountModules, Response: module.ModuleCountResponse{}},                                                  // GET /api/v1/modules/count
+		{Method: http.MethodGet, Path: "/modules/stats", Policy: readScope, Handler: h.GetModuleStats, Response: module.ModuleStatsResponse{}},                                                // GET /api/v1/modules/stats
+		{Method: http.MethodGet, Path: "/modules/stats/report", Policy: readScope, Handler: h.GetModuleStatsReport},                                                                           // GET /api/v1/modules/stats/report
+
+		// Recycle bin (administrators)
+		{Method: http.MethodGet, Path: "/modules/trash", Policy: adminScope, Handler: h.ListDeletedModules, Query: module.PageQuery{}, Response: []module.DeletedModuleResponse{}},     // GET /api/v1/modules/trash
+		{Method: http.MethodPost, Path: "/modules/trash/restore", Policy: adminScope, Handler: h.RestoreModules, Body: module.ModuleIdsRequest{}, Response: []module.ModuleResponse{}}, // POST /api/v1/modules/trash/restore
+		{Method: http.MethodPost, Path: "/modules/trash/purge", Policy: adminScope, Handler: h.PurgeModules, Body: module.ModuleIdsRequest{}, Response: []int{}},                       // POST /api/v1/modules/trash/purge
+
+		// Resource endpoints
+		{Method: http.MethodGet, Path: "/modules/:id", Policy: readScope, Handler: h.GetModuleById, Response: module.ModuleResponse{}},                               // GET /api/v1/modules/{id}
+		{Method: http.MethodHead, Path: "/modules/:id", Policy: readScope, Handler: h.HeadModule},                                                                    // HEAD /api/v1/modules/{id}
+		{Method: http.MethodPut, Path: "/modules/:id", Policy: writeScope, Handler: h.UpdateModule, Body: module.ModuleRequest{}, Response: module.ModuleResponse{}}, // PUT /api/v1/modules/{id}
+		{Method: http.MethodDelete, Path: "/modules/:id", Policy: writeScope, Handler: h.DeleteModule, Status: http.StatusNoContent},                                 // DELETE /api/v1/modules/{id}
+
+		// Change history
+		{Method: http.MethodGet, Path: "/modules/:id/history", Policy: readScope, Handler: h.GetModuleHistory, Query: module.HistoryQuery{}, Response: []module.ModuleChangeResponse{}}, // GET /api/v1/modules/{id}/history
+		{Method: http.MethodPost, Path: "/modules/:id/revert", Policy: writeScope, Handler: h.RevertModule, Query: module.RevertQuery{}, Response: module.ModuleResponse{}},             // POST /api/v1/modules/{id}/revert?revision={n}
+
+		// Access control
+		{Method: http.MethodGet, Path: "/modules/:id/acl", Policy: readScope, Handler: h.GetModuleACL, Response: module.ModuleACLResponse{}},                                       // GET /api/v1/modules/{id}/acl
+		{Method: http.MethodPut, Path: "/modules/:id/acl", Policy: writeScope, Handler: h.ReplaceModuleACL, Body: module.ModuleACLRequest{}, Response: module.ModuleACLResponse{}}, // PUT /api/v1/modules/{id}/acl
+
+		// Approval workflow (draft -> pending -> approved)
+		{Method: http.MethodPost, Path: "/modules/:id/submit", Policy: writeScope, Handler: h.SubmitModule, Response: module.ModuleResponse{}},                                 // POST /api/v1/modules/{id}/submit
+		{Method: http.MethodPost, Path: "/modules/:id/approve", Policy: approveScope, Handler: h.ApproveModule, Response: module.ModuleResponse{}},                             // POST /api/v1/modules/{id}/approve
+		{Method: http.MethodPost, Path: "/modules/:id/reject", Policy: approveScope, Handler: h.RejectModule, Body: module.ReviewRequest{}, Response: module.ModuleResponse{}}, // POST /api/v1/modules/{id}/reject
+
+		// Per-user favorites
+		{Method: http.MethodPut, Path: "/modules/:id/star", Policy: readScope, Handler: h.StarModule, Status: http.StatusNoContent},      // PUT /api/v1/modules/{id}/star
+		{Method: http.MethodDelete, Path: "/modules/:id/star", Policy: readScope, Handler: h.UnstarModule, Status: http.StatusNoContent}, // DELETE /api/v1/modules/{id}/star
+
+		// Ownership, with transfers addressed by their own ID
+		{Method: http.MethodPost, Path: "/modules/:id/transfer-ownership", Policy: writeScope, Handler: h.RequestOwnershipTransfer, Body: module.TransferOwnershipRequest{}, Response: module.TransferResponse{}, Status: http.StatusCreated}, // POST /api/v1/modules/{id}/transfer-ownership
+		{Method: http.MethodGet, Path: "/transfers/:id", Policy: readScope, Handler: h.GetOwnershipTransfer, Response: module.TransferResponse{}},                                                                                             // GET /api/v1/transfers/{id}
+		{Method: http.MethodPost, Path: "/transfers/:id/accept", Policy: writeScope, Handler: h.AcceptOwnershipTransfer, Response: module.TransferResponse{}},                                                                                 // POST /api/v1/transfers/{id}/accept
+
+		// Published modules (active, without an ACL) for unauthenticated clients
+		{Group: GroupPublic, Method: http.MethodGet, Path: "/modules", Handler: h.ListPublicModules, Query: module.PageQuery{}, Response: []module.PublicModuleResponse{}}, // GET /public/v1/modules
+		{Group: GroupPublic, Method: http.MethodGet, Path: "/modules/:id", Handler: h.GetPublicModule, Response: module.PublicModuleResponse{}},                            // GET /public/v1/modules/{id}
+	}
 }
 
 // CreateModule godoc
 // @Summary Create a new module
-// @Description Creates a new module entity with the provided details
+// @Description Creates a new module as a draft. Drafts cannot be active: submit the module with POST /modules/{id}/submit and it is activated once an approver approves it. Optional activateAt/deactivateAt times schedule a later change of the active flag; activateAt takes effect once the module is approved.
 // @Tags modules
 // @Accept json
 // @Produce json
 // @Param request body module.ModuleRequest true "Module creation payload"
+// @Param X-Actor header string false "Who creates the module, recorded in the change history"
+// @Param dryRun query bool false "Run all checks and return the would-be module (with id 0) without storing it"
+// @Param X-Response-Format header string false "Set to raw to receive the bare resource without the envelope; errors keep the envelope" Enums(envelope, raw)
 // @Success 201 {object} response.APIResponse{data=module.ModuleResponse} "Module created successfully"
-// @Failure 400 {object} response.APIResponse "Validation error"
+// @Failure 400 {object} response.APIResponse "Validation error, or isActive set on a new module"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
 // @Failure 409 {object} response.APIResponse "Module name already exists"
 // @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[modules:write] || BearerAuth[modules:write]
 // @Router /modules [post]
 //
 // Sample Request:
@@ -68,8 +141,7 @@ func NewModuleHandler() *ModuleHandler {
 //	POST /api/v1/modules
 //	{
 //	  "name": "Inventory",
-//	  "description": "Handles product stock management",
-//	  "isActive": true
+//	  "description": "Handles product stock management"
 //	}
 //
 // Sample Success Response (201):
@@ -81,7 +153,8 @@ func NewModuleHandler() *ModuleHandler {
 //	    "id": 123,
 //	    "name": "Inventory",
 //	    "description": "Handles product stock management",
-//	    "isActive": true,
+//	    "isActive": false,
+//	    "status": "draft",
 //	    "createdAt": "2023-08-15T14:30:00Z"
 //	  },
 //	  "meta": {
@@ -90,6 +163,10 @@ func NewModuleHandler() *ModuleHandler {
 //	  }
 //	}
 //
+// Dry Run (POST /api/v1/modules?dryRun=true):
+//   - Same status and body as a real create, with "id": 0 and "meta.dryRun": true
+//   - No Location header; nothing is stored and no revision is recorded
+//
 // Sample Error Response (400):
 //
 //	{
@@ -107,164 +184,689 @@ func NewModuleHandler() *ModuleHandler {
 //	    "timestamp": "2023-08-15T14:30:00Z"
 //	  }
 //	}
+//
+// Sample Conflict Response (409):
+//
+//	{
+//	  "success": false,
+//	  "message": "Resource already exists",
+//	  "error": {
+//	    "code": "RESOURCE_CONFLICT",
+//	    "message": "Resource already exists",
+//	    "context": {
+//	      "conflictingId": 123,
+//	      "suggestions": ["Inventory-2", "Inventory-3", "Inventory-4"]
+//	    }
+//	  },
+//	  "meta": {
+//	    "requestId": "a1b2c3d4",
+//	    "timestamp": "2023-08-15T14:30:00Z"
+//	  }
+//	}
 func (h *ModuleHandler) CreateModule(ctx *gin.Context) {
-	// Step 1: Get request ID from context
-	requestID := ctx.GetString("request_id")
+	// Step 1: Get the request-scoped response mapper
+	mapper := responseMapper(ctx)
 
-	// Step 2: Create response mapper
-	mapper := response.NewResponseMapper(requestID)
-
-	bodyBytes, _ := io.ReadAll(ctx.Request.Body)
-	fmt.Println("RAW BODY:", string(bodyBytes))
-	ctx.Request.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+	dryRun, ok := requestDryRun(ctx, mapper)
+	if !ok {
+		return
+	}
 
-	// Step 3: Validate request payload
+	// Step 2: Validate request payload
 	var request module.ModuleRequest
 	if err := ctx.ShouldBindJSON(&request); err != nil {
 		// Map validation errors to our format
-		details := extractValidationErrors(err)
-
-		// Use mapper to create error response
-		response, statusCode := mapper.Error(
-			"VALIDATION_ERROR",
-			response.StatusToMessage(http.StatusBadRequest),
-			details,
-			http.StatusBadRequest,
-		)
-		ctx.JSON(statusCode, response)
+		details := extractValidationErrors(ctx, err)
+		h.service.RecordValidationFailure(validationFailureCode(err))
+
+		Respond(ctx, Result{}, response.NewHTTPError(http.StatusBadRequest, "VALIDATION_ERROR", details))
 		return
 	}
 
-	// Step 4: Execute business logic
-	responseData, err := h.service.CreateModule(request)
+	// Step 3: Execute business logic
+	responseData, err := h.bound(ctx).CreateModule(request, requestActor(ctx), dryRun)
 	if err != nil {
-		fmt.Println("[DEBUG] Service error:", err)
 		// Map service errors to appropriate responses
-		handleServiceError(ctx, err, mapper)
+		Respond(ctx, Result{}, err)
 		return
 	}
 
-	// Step 5: Use mapper to create success response
-	response, statusCode := mapper.Success(
-		responseData,
-		response.StatusToMessage(http.StatusCreated),
-		http.StatusCreated,
-	)
+	// Step 4: Return standardized response
+	result := Result{Data: responseData, Status: http.StatusCreated}
+	if !dryRun {
+		result.Location = "/api/v1/modules/" + strconv.Itoa(responseData.ID)
+		result.ETag = moduleETag(responseData)
+	}
+	Respond(ctx, result, nil)
+}
 
-	// Step 6: Return standardized response
-	ctx.Header("Location", "/api/v1/modules/"+strconv.Itoa(responseData.ID))
-	ctx.JSON(statusCode, response)
+// UpdateModule godoc
+// @Summary Update a module
+// @Description Replaces the name, description, active flag and activation schedule of a module and records the change in its history. Fields left out are reset (isActive defaults to false, schedule times are cleared); a request that changes nothing is accepted without writing a revision. Modules pending approval cannot be changed, and only approved modules can be active.
+// @Tags modules
+// @Accept json
+// @Produce json
+// @Param id path int true "Module ID"
+// @Param request body module.ModuleRequest true "New module state"
+// @Param X-Actor header string false "Who changes the module, recorded in the change history"
+// @Param X-Actor-Teams header string false "Comma-separated teams of the actor, matched against the module ACL"
+// @Param dryRun query bool false "Run all checks and return the would-be module without storing it"
+// @Success 200 {object} response.APIResponse{data=module.ModuleResponse} "Module updated successfully"
+// @Failure 400 {object} response.APIResponse "Validation error, or isActive set on a module that is not approved"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "The actor may view but not edit the module"
+// @Failure 404 {object} response.APIResponse "Module not found"
+// @Failure 409 {object} response.APIResponse "Module name already exists, or the module is pending approval"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[modules:write] || BearerAuth[modules:write]
+// @Router /modules/{id} [put]
+//
+// Sample Request:
+//
+//	PUT /api/v1/modules/123
+//	X-Actor: jane
+//	{
+//	  "name": "Inventory",
+//	  "description": "Handles product stock management",
+//	  "isActive": false,
+//	  "activateAt": "2023-09-01T08:00:00Z"
+//	}
+func (h *ModuleHandler) UpdateModule(ctx *gin.Context) {
+	mapper := responseMapper(ctx)
+
+	var params module.IDParams
+	if !bindPath(ctx, &params) {
+		return
+	}
+
+	dryRun, ok := requestDryRun(ctx, mapper)
+	if !ok {
+		return
+	}
+
+	// Step 1: Validate request payload
+	var request module.ModuleRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		h.service.RecordValidationFailure(validationFailureCode(err))
+		Respond(ctx, Result{}, response.NewHTTPError(http.StatusBadRequest, "VALIDATION_ERROR", extractValidationErrors(ctx, err)))
+		return
+	}
+
+	// Step 2: Execute business logic
+	updated, err := h.bound(ctx).UpdateModule(params.Key(), request, requestSubject(ctx), dryRun)
+	if err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	// Step 3: Return the updated module
+	result := Result{Data: updated}
+	if !dryRun {
+		result.ETag = moduleETag(updated)
+	}
+	Respond(ctx, result, nil)
 }
 
 // GetModuleById godoc
 // @Summary Get a module by ID
-// @Description Retrieves a specific module by its unique identifier
+// @Description Retrieves a specific module by its unique identifier. The response carries an ETag and a Last-Modified date; sending either back in If-None-Match or If-Modified-Since returns 304 while the module is unchanged. If-None-Match takes precedence when both are sent.
 // @Tags modules
 // @Produce json
 // @Param id path int true "Module ID"
+// @Param X-Response-Format header string false "Set to raw to receive the bare resource without the envelope; errors keep the envelope" Enums(envelope, raw)
+// @Param fields query string false "Comma-separated fields to return (e.g. id,name,isActive); all fields when omitted"
+// @Param If-None-Match header string false "ETag of a cached copy of the module"
+// @Param If-Modified-Since header string false "Last-Modified date of a cached copy of the module; ignored when If-None-Match is sent"
 // @Success 200 {object} response.APIResponse{data=module.ModuleResponse} "Module retrieved successfully"
+// @Success 304 "Module unchanged since the given ETag or date"
+// @Failure 400 {object} response.APIResponse "Invalid module ID"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
 // @Failure 404 {object} response.APIResponse "Module not found"
 // @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[modules:read] || BearerAuth[modules:read]
 // @Router /modules/{id} [get]
+//
+// Sample Request:
+//
+//	GET /api/v1/modules/123?fields=id,name
+//
+// Sample Success Response (200):
+//
+//	{
+//	  "success": true,
+//	  "message": "Operation completed successfully",
+//	  "data": {
+//	    "id": 123,
+//	    "name": "Inventory"
+//	  },
+//	  "meta": {
+//	    "requestId": "a1b2c3d4",
+//	    "timestamp": "2023-08-15T14:30:00Z"
+//	  }
+//	}
 func (h *ModuleHandler) GetModuleById(ctx *gin.Context) {
-	requestID := ctx.GetString("request_id")
-	mapper := response.NewResponseMapper(requestID)
+	var params module.IDParams
+	if !bindPath(ctx, &params) {
+		return
+	}
+
+	module, err := h.bound(ctx).GetModuleById(params.Key(), requestSubject(ctx))
+	if err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	Respond(ctx, Result{Data: module, ETag: moduleETag(module), LastModified: module.UpdatedAt}, nil)
+}
+
+// HeadModule godoc
+// @Summary Check whether a module exists
+// @Description Returns 200 when the module exists and 404 otherwise, without a response body
+// @Tags modules
+// @Param id path int true "Module ID"
+// @Success 200 "Module exists"
+// @Failure 400 "Invalid module ID"
+// @Failure 401 "Authentication required"
+// @Failure 403 "Missing scope"
+// @Failure 404 "Module not found"
+// @Failure 500 "Internal server error"
+// @Security ApiKeyAuth[modules:read] || BearerAuth[modules:read]
+// @Router /modules/{id} [head]
+//
+// Sample Request:
+//
+//	HEAD /api/v1/modules/123
+func (h *ModuleHandler) HeadModule(ctx *gin.Context) {
+	var params module.IDParams
+	if !bindPath(ctx, &params) {
+		return
+	}
+
+	exists, err := h.bound(ctx).ModuleExists(params.Key(), requestSubject(ctx))
+	if err != nil {
+		logger.Errorf("[%s] Module existence check failed: %v", ctx.GetString("request_id"), err)
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+
+	if !exists {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+	ctx.Status(http.StatusOK)
+}
 
-	id := ctx.Param("id")
-	module, err := h.service.GetModuleById(id)
+// CountModules godoc
+// @Summary Count modules
+// @Description Returns the number of modules, optionally filtered by active flag, without transferring module payloads
+// @Tags modules
+// @Produce json
+// @Param isActive query bool false "Only count modules with this active flag"
+// @Success 200 {object} response.APIResponse{data=module.ModuleCountResponse} "Module count"
+// @Failure 400 {object} response.APIResponse "Invalid filter"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[modules:read] || BearerAuth[modules:read]
+// @Router /modules/count [get]
+//
+// Sample Request:
+//
+//	GET /api/v1/modules/count?isActive=true
+//
+// Sample Success Response (200):
+//
+//	{
+//	  "success": true,
+//	  "message": "Operation completed successfully",
+//	  "data": {
+//	    "count": 42
+//	  },
+//	  "meta": {
+//	    "requestId": "a1b2c3d4",
+//	    "timestamp": "2023-08-15T14:30:00Z"
+//	  }
+//	}
+func (h *ModuleHandler) CountModules(ctx *gin.Context) {
+	// Step 1: Bind the optional active filter
+	var query module.CountQuery
+	if !bindQuery(ctx, &query) {
+		return
+	}
+
+	// Step 2: Count matching modules
+	count, err := h.bound(ctx).CountModules(query.IsActive, requestSubject(ctx))
 	if err != nil {
-		handleServiceError(ctx, err, mapper)
+		Respond(ctx, Result{}, err)
 		return
 	}
 
-	// Use mapper to create success response
-	response, statusCode := mapper.Success(
-		module,
-		response.StatusToMessage(http.StatusOK),
-		http.StatusOK,
-	)
-	ctx.JSON(statusCode, response)
+	// Step 3: Return the count
+	Respond(ctx, Result{Data: module.ModuleCountResponse{Count: count}}, nil)
 }
 
-// handleServiceError processes errors from the business layer into standardized responses.
+// GetModuleStats godoc
+// @Summary Get module statistics
+// @Description Returns totals by status, creations per day over the last 30 days and the longest-unchanged modules. Results are cached for up to one minute.
+// @Tags modules
+// @Produce json
+// @Success 200 {object} response.APIResponse{data=module.ModuleStatsResponse} "Module statistics"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[modules:read] || BearerAuth[modules:read]
+// @Router /modules/stats [get]
 //
-// This function maps business layer errors to appropriate HTTP status codes
-// and creates consistent error responses following the APIResponse structure.
+// Sample Request:
+//
+//	GET /api/v1/modules/stats
+func (h *ModuleHandler) GetModuleStats(ctx *gin.Context) {
+	stats, err := h.bound(ctx).GetStats(requestSubject(ctx))
+	if err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	Respond(ctx, Result{Data: stats}, nil)
+}
+
+// StatsReportTemplate names the template rendering the statistics report.
+const StatsReportTemplate = "reports/module_stats.html"
+
+// GetModuleStatsReport godoc
+// @Summary Get module statistics as an HTML report
+// @Description Renders the statistics of GET /modules/stats as a standalone HTML page. The layout comes from the reports/module_stats.html template, which can be replaced through TEMPLATE_DIR.
+// @Tags modules
+// @Produce html
+// @Success 200 {string} string "HTML report"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[modules:read] || BearerAuth[modules:read]
+// @Router /modules/stats/report [get]
+//
+// Sample Request:
+//
+//	GET /api/v1/modules/stats/report
+func (h *ModuleHandler) GetModuleStatsReport(ctx *gin.Context) {
+	// Step 1: Compute the statistics
+	stats, err := h.bound(ctx).GetStats(requestSubject(ctx))
+	if err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	// Step 2: Render the report
+	page, err := h.templates.Render(StatsReportTemplate, stats)
+	if err != nil {
+		logger.Errorf("[%s] Rendering stats report failed: %v", ctx.GetString("request_id"), err)
+		Respond(ctx, Result{}, response.NewHTTPError(http.StatusInternalServerError, "INTERNAL_ERROR", nil))
+		return
+	}
+
+	ctx.Data(http.StatusOK, "text/html; charset=utf-8", []byte(page))
+}
+
+// ListModules godoc
+// @Summary List modules
+// @Description Lists modules ordered by creation time. Offset pagination (page/pageSize) is used by default; passing the cursor parameter (empty for the first page) switches to keyset pagination, which stays fast on large tables. Passing ids fetches those modules in one query instead and reports unknown IDs in meta.missingIds.
+// @Tags modules
+// @Produce json
+// @Param page query int false "Page number (offset mode)" default(1) minimum(1)
+// @Param pageSize query int false "Items per page" default(20) minimum(1) maximum(100)
+// @Param cursor query string false "Keyset cursor from meta.nextCursor; send an empty value to start keyset mode"
+// @Param ids query string false "Comma-separated module IDs to fetch in one request (max 100); disables pagination"
+// @Param tag query string false "Only list modules carrying this tag (case-insensitive)" maxlength(30)
+// @Param status query string false "Only list modules in this approval state" Enums(draft, pending, approved)
+// @Param starred query bool false "Only list modules the caller starred; requires an identified user"
+// @Param include query string false "Comma-separated relations to include with every module, loaded with one query each (tags, permissions)"
+// @Param X-Response-Format header string false "Set to raw to receive the bare resource without the envelope; errors keep the envelope" Enums(envelope, raw)
+// @Param fields query string false "Comma-separated fields to return (e.g. id,name,isActive); all fields when omitted"
+// @Success 200 {object} response.APIResponse{data=[]module.ModuleResponse} "Modules retrieved successfully"
+// @Failure 400 {object} response.APIResponse "Invalid pagination parameters"
+// @Failure 401 {object} response.APIResponse "Authentication required, or no user identified for starred=true"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[modules:read] || BearerAuth[modules:read]
+// @Router /modules [get]
+//
+// Sample Requests:
+//
+//	GET /api/v1/modules?page=2&pageSize=20
+//	GET /api/v1/modules?cursor=&pageSize=50
+//	GET /api/v1/modules?fields=id,name,isActive
+//	GET /api/v1/modules?cursor=eyJjcmVhdGVkQXQiOiIyMDIzLTA4LTE1VDE0OjMwOjAwWiIsImlkIjoxMjN9
+//	GET /api/v1/modules?ids=1,5,9
+//	GET /api/v1/modules?tag=backend&pageSize=50
+//	GET /api/v1/modules?status=pending
+//	GET /api/v1/modules?starred=true
+//	GET /api/v1/modules?include=tags,permissions
+//
+// Sample Keyset Response (200):
+//
+//	{
+//	  "success": true,
+//	  "message": "Operation completed successfully",
+//	  "data": [
+//	    {"id": 123, "name": "Inventory", "description": "", "isActive": true, "createdAt": "2023-08-15T14:30:00Z"}
+//	  ],
+//	  "meta": {
+//	    "requestId": "a1b2c3d4",
+//	    "timestamp": "2023-08-15T14:30:00Z",
+//	    "nextCursor": "eyJjcmVhdGVkQXQiOiIyMDIzLTA4LTE1VDE0OjMwOjAwWiIsImlkIjoxMjN9"
+//	  }
+//	}
+//
+// Sample Batch Response (200):
+//
+//	{
+//	  "success": true,
+//	  "message": "Operation completed successfully",
+//	  "data": [
+//	    {"id": 1, "name": "Inventory", "description": "", "isActive": true, "createdAt": "2023-08-15T14:30:00Z"},
+//	    {"id": 9, "name": "Billing", "description": "", "isActive": false, "createdAt": "2023-08-16T09:12:00Z"}
+//	  ],
+//	  "meta": {
+//	    "requestId": "a1b2c3d4",
+//	    "timestamp": "2023-08-15T14:30:00Z",
+//	    "missingIds": [5]
+//	  }
+//	}
+func (h *ModuleHandler) ListModules(ctx *gin.Context) {
+	// Batch lookups bypass pagination
+	if rawIds, ok := ctx.GetQuery("ids"); ok {
+		h.getModulesByIds(ctx, rawIds)
+		return
+	}
+
+	// Step 1: Parse pagination and filter parameters
+	var query module.ListQuery
+	if !bindQuery(ctx, &query) {
+		return
+	}
+
+	var cursor *pagination.Cursor
+	encodedCursor, keyset := ctx.GetQuery("cursor")
+	if keyset {
+		details := make(map[string][]string)
+		if cursor = queryCursor(ctx, encodedCursor, details); len(details) > 0 {
+			Respond(ctx, Result{}, response.NewHTTPError(http.StatusBadRequest, "VALIDATION_ERROR", details))
+			return
+		}
+	}
+
+	include, err := module.IncludeOptions(ctx.Query("include"))
+	if err != nil {
+		relations := module.IncludeTags + ", " + module.IncludePermissions
+		Respond(ctx, Result{}, response.NewHTTPError(http.StatusBadRequest, "VALIDATION_ERROR", map[string][]string{"include": {i18n.Message(translator(ctx), "validation.relation", relations)}}))
+		return
+	}
+
+	filter := module.ModuleFilter{Tag: tagService.NormalizeTagName(query.Tag), Status: query.Status}
+	if query.Starred {
+		if filter.StarredBy = requestUser(ctx); filter.StarredBy == "" {
+			Respond(ctx, Result{}, moduleService.ErrUserRequired)
+			return
+		}
+	}
+
+	// Step 2: Keyset mode returns the next cursor
+	if keyset {
+		result, err := h.bound(ctx).ListModulesAfter(filter, requestSubject(ctx), cursor, query.PageSize, include...)
+		if err != nil {
+			Respond(ctx, Result{}, err)
+			return
+		}
+
+		nextCursor := ""
+		if result.NextCursor != nil {
+			nextCursor = result.NextCursor.Encode()
+		}
+
+		Respond(ctx, Result{Data: result.Items, NextCursor: nextCursor}, nil)
+		return
+	}
+
+	// Step 3: Offset mode returns page totals
+	result, err := h.bound(ctx).ListModules(filter, requestSubject(ctx), query.Page, query.PageSize, include...)
+	if err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	Respond(ctx, Result{
+		Data: result.Items,
+		Pagination: &response.PaginationMeta{
+			Page:       result.Page,
+			PageSize:   result.PageSize,
+			TotalItems: result.TotalItems,
+			TotalPages: int((result.TotalItems + int64(result.PageSize) - 1) / int64(result.PageSize)),
+		},
+	}, nil)
+}
+
+// getModulesByIds serves the batch form of the list endpoint (?ids=1,5,9).
 //
 // Parameters:
 //   - ctx: Gin context for the request
-//   - err: The error returned from the business layer
-//   - mapper: The response mapper to use for creating responses
-func handleServiceError(ctx *gin.Context, err error, mapper *response.ResponseMapper) {
-	statusCode := http.StatusInternalServerError
-	code := "INTERNAL_ERROR"
-	message := response.StatusToMessage(statusCode)
+//   - rawIds: Comma-separated module IDs from the query string
+func (h *ModuleHandler) getModulesByIds(ctx *gin.Context, rawIds string) {
+	// Step 1: Parse and validate the ID list
+	var ids []int
+	var details map[string][]string
+	for _, rawId := range strings.Split(rawIds, ",") {
+		id, err := strconv.Atoi(strings.TrimSpace(rawId))
+		if err != nil || id < 1 {
+			details = map[string][]string{"ids": {i18n.Message(translator(ctx), "validation.id_list")}}
+			break
+		}
+		ids = append(ids, id)
+	}
+	if details == nil && len(ids) > moduleService.MaxBatchIds {
+		details = map[string][]string{"ids": {i18n.Message(translator(ctx), "validation.id_list_size", strconv.Itoa(moduleService.MaxBatchIds))}}
+	}
 
-	switch {
-	case errors.Is(err, moduleService.ErrNameRequired),
-		errors.Is(err, moduleService.ErrNameLength),
-		errors.Is(err, moduleService.ErrDescriptionLength):
-		statusCode = http.StatusBadRequest
-		code = "VALIDATION_ERROR"
-		message = response.StatusToMessage(statusCode)
+	if details != nil {
+		Respond(ctx, Result{}, response.NewHTTPError(http.StatusBadRequest, "VALIDATION_ERROR", details))
+		return
+	}
+
+	// Step 2: Load all modules with a single query
+	modules, missingIds, err := h.bound(ctx).GetModulesByIds(ids, requestSubject(ctx))
+	if err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	// Step 3: Return found modules and report the missing IDs
+	Respond(ctx, Result{Data: modules, MissingIds: missingIds}, nil)
+}
 
-	case errors.Is(err, moduleService.ErrNameExists):
-		statusCode = http.StatusConflict
-		code = "RESOURCE_CONFLICT"
-		message = response.StatusToMessage(statusCode)
+// streamFlushInterval is the number of NDJSON rows written between flushes.
+const streamFlushInterval = 100
 
-	case errors.Is(err, moduleService.ErrNotFound):
-		statusCode = http.StatusNotFound
-		code = "NOT_FOUND"
-		message = response.StatusToMessage(statusCode)
+// StreamModules godoc
+// @Summary Stream all modules as NDJSON
+// @Description Streams every module as newline-delimited JSON, one module per line, without buffering the full result set. Accepts a keyset cursor from the list endpoint to resume after a known position.
+// @Tags modules
+// @Produce application/x-ndjson
+// @Param cursor query string false "Keyset cursor from meta.nextCursor of the list endpoint"
+// @Success 200 {object} module.ModuleResponse "One module per line"
+// @Failure 400 {object} response.APIResponse "Invalid cursor"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[modules:read] || BearerAuth[modules:read]
+// @Router /modules/stream [get]
+//
+// Sample Requests:
+//
+//	GET /api/v1/modules/stream
+//	GET /api/v1/modules/stream?cursor=eyJjcmVhdGVkQXQiOiIyMDIzLTA4LTE1VDE0OjMwOjAwWiIsImlkIjoxMjN9
+//
+// Sample Success Response (200):
+//
+//	{"id":1,"name":"Inventory","description":"Handles product stock management","isActive":true,"createdAt":"2023-08-15T14:30:00Z"}
+//	{"id":2,"name":"Billing","description":"Invoices and payments","isActive":false,"createdAt":"2023-08-16T09:12:00Z"}
+//
+// Error Handling:
+//   - Errors before the first row return the standard error envelope
+//   - Errors after streaming started cannot change the status code; a final
+//     {"error":{...}} line is written and the stream ends
+//   - The stream stops as soon as the client disconnects
+func (h *ModuleHandler) StreamModules(ctx *gin.Context) {
+	mapper := responseMapper(ctx)
+
+	// Resume after the cursor when provided
+	details := make(map[string][]string)
+	cursor := queryCursor(ctx, ctx.Query("cursor"), details)
+	if len(details) > 0 {
+		Respond(ctx, Result{}, response.NewHTTPError(http.StatusBadRequest, "VALIDATION_ERROR", details))
+		return
 	}
 
-	// For validation errors, extract field details
-	var details map[string][]string
-	if statusCode == http.StatusBadRequest {
-		details = map[string][]string{
-			"name": {err.Error()},
+	encoder := json.NewEncoder(ctx.Writer)
+	rows := 0
+
+	err := h.bound(ctx).StreamModules(cursor, requestSubject(ctx), func(m *module.ModuleResponse) error {
+		// Stop when the client goes away
+		if err := ctx.Request.Context().Err(); err != nil {
+			return err
+		}
+
+		// Commit the streaming response on the first row
+		if rows == 0 {
+			ctx.Header("Content-Type", "application/x-ndjson")
+			ctx.Status(http.StatusOK)
+		}
+
+		if err := encoder.Encode(mapper.Render(m)); err != nil {
+			return err
+		}
+
+		rows++
+		if rows%streamFlushInterval == 0 {
+			ctx.Writer.Flush()
 		}
+		return nil
+	})
+
+	switch {
+	case err == nil && rows == 0:
+		// Empty result: still a valid (empty) NDJSON document
+		ctx.Data(http.StatusOK, "application/x-ndjson", nil)
+
+	case err == nil:
+		ctx.Writer.Flush()
+
+	case rows == 0:
+		Respond(ctx, Result{}, err)
+
+	default:
+		reference := response.NewErrorReference()
+		logger.Errorf("[%s] [%s] Module stream aborted after %d rows: %v", ctx.GetString("request_id"), reference, rows, err)
+		_ = encoder.Encode(gin.H{"error": response.APIError{
+			Code:      "STREAM_ABORTED",
+			Message:   response.StatusToMessage(http.StatusInternalServerError),
+			Reference: reference,
+		}})
+		ctx.Writer.Flush()
 	}
+}
 
-	// Use mapper to create error response
-	response, statusCode := mapper.Error(
-		code,
-		message,
-		details,
-		statusCode,
-	)
-	ctx.JSON(statusCode, response)
+// init registers the response details of the module service errors.
+func init() {
+	// Surface the conflicting module and available alternatives
+	response.Errors.Enrich(moduleService.ErrNameExists, func(err error, httpErr *response.HTTPError) {
+		var conflict *moduleService.NameConflictError
+		if errors.As(err, &conflict) {
+			httpErr.WithContext(map[string]interface{}{
+				"conflictingId": conflict.ConflictingID,
+				"suggestions":   conflict.Suggestions,
+			})
+		}
+	})
 }
 
-// extractValidationErrors converts Gin validation errors to our format.
+// extractValidationErrors converts Gin validation errors to our format, in
+// the caller's language.
 //
 // Parameters:
+//   - ctx: Gin context for the request
 //   - err: The validation error
 //
 // Returns:
 //   - map[string][]string: Field-specific error messages
-func extractValidationErrors(err error) map[string][]string {
+func extractValidationErrors(ctx *gin.Context, err error) map[string][]string {
 	errors := make(map[string][]string)
 
 	if verr, ok := err.(validator.ValidationErrors); ok {
 		for _, fieldErr := range verr {
 			field := fieldErr.Field()
-			tag := fieldErr.Tag()
-
-			message := "Validation failed"
-			switch tag {
-			case "required":
-				message = "This field is required"
-			case "min":
-				message = "Value is too short"
-			case "max":
-				message = "Value exceeds maximum length"
-			}
-
-			errors[field] = append(errors[field], message)
+			errors[field] = append(errors[field], validationMessage(ctx, fieldErr))
 		}
 	}
 
 	return errors
 }
+
+// validationFailureCode names the first binding failure of a module payload
+// with the error code of the matching service rule.
+//
+// Parameters:
+//   - err: The binding error
+//
+// Returns:
+//   - string: NAME_REQUIRED, NAME_LENGTH or DESCRIPTION_LENGTH, or
+//     MALFORMED_BODY for bodies that are not valid module JSON
+func validationFailureCode(err error) string {
+	var verr validator.ValidationErrors
+	if !errors.As(err, &verr) || len(verr) == 0 {
+		return "MALFORMED_BODY"
+	}
+
+	switch field := verr[0]; {
+	case field.Field() == "Name" && field.Tag() == "required":
+		return "NAME_REQUIRED"
+	case field.Field() == "Name":
+		return "NAME_LENGTH"
+	case field.Field() == "Description":
+		return "DESCRIPTION_LENGTH"
+	default:
+		return "MALFORMED_BODY"
+	}
+}
+
+// queryCursor decodes an optional keyset cursor.
+//
+// Parameters:
+//   - ctx: Gin context for the request, selecting the message language
+//   - encoded: The raw cursor value (empty means start from the beginning)
+//   - details: Validation details receiving a message on failure
+//
+// Returns:
+//   - *pagination.Cursor: The decoded cursor, or nil when empty or invalid
+func queryCursor(ctx *gin.Context, encoded string, details map[string][]string) *pagination.Cursor {
+	if encoded == "" {
+		return nil
+	}
+
+	cursor, err := pagination.DecodeCursor(encoded)
+	if err != nil {
+		details["cursor"] = append(details["cursor"], i18n.Message(translator(ctx), "validation.cursor"))
+		return nil
+	}
+	return cursor
+}
+
+// moduleETag derives the weak entity tag of a module from its last change.
+//
+// Parameters:
+//   - m: The module being returned
+//
+// Returns:
+//   - string: The ETag header value
+func moduleETag(m *module.ModuleResponse) string {
+	return fmt.Sprintf(`W/"%d-%d"`, m.ID, m.UpdatedAt.UnixNano())
+}
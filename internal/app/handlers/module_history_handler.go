@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"net/http"
+
+	"go_di_architecture/internal/domain/models/module"
+	"go_di_architecture/internal/domain/models/response"
+	"go_di_architecture/internal/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetModuleHistory godoc
+// @Summary Get the change history of a module
+// @Description Lists the changes of a module in chronological order. Each entry carries the field-level diff (old and new value) of one create or update.
+// @Tags modules
+// @Produce json
+// @Param id path int true "Module ID"
+// @Param page query int false "Page number" default(1) minimum(1)
+// @Param pageSize query int false "Items per page" default(20) minimum(1) maximum(100)
+// @Param actor query string false "Only list changes made by this actor"
+// @Param from query string false "Only list changes made at or after this time (RFC 3339)"
+// @Param to query string false "Only list changes made at or before this time (RFC 3339)"
+// @Success 200 {object} response.APIResponse{data=[]module.ModuleChangeResponse} "Change history"
+// @Failure 400 {object} response.APIResponse "Invalid module ID or query parameter"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 404 {object} response.APIResponse "Module not found"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[modules:read] || BearerAuth[modules:read]
+// @Router /modules/{id}/history [get]
+//
+// Sample Request:
+//
+//	GET /api/v1/modules/123/history?actor=jane&from=2023-08-01T00:00:00Z
+//
+// Sample Success Response (200):
+//
+//	{
+//	  "success": true,
+//	  "message": "Operation completed successfully",
+//	  "data": [
+//	    {
+//	      "revision": 2,
+//	      "action": "update",
+//	      "actor": "jane",
+//	      "changedAt": "2023-08-16T09:12:00Z",
+//	      "changes": [
+//	        {"field": "isActive", "old": true, "new": false}
+//	      ]
+//	    }
+//	  ],
+//	  "meta": {
+//	    "requestId": "a1b2c3d4",
+//	    "timestamp": "2023-08-16T09:15:00Z",
+//	    "pagination": {"page": 1, "pageSize": 20, "totalItems": 1, "totalPages": 1}
+//	  }
+//	}
+func (h *ModuleHandler) GetModuleHistory(ctx *gin.Context) {
+	// Step 1: Parse pagination and filter parameters
+	var params module.IDParams
+	var query module.HistoryQuery
+	if !bindPath(ctx, &params) || !bindQuery(ctx, &query) {
+		return
+	}
+	if query.From != nil && query.To != nil && query.From.After(*query.To) {
+		Respond(ctx, Result{}, response.NewHTTPError(http.StatusBadRequest, "VALIDATION_ERROR", map[string][]string{"to": {i18n.Message(translator(ctx), "validation.not_before", "from")}}))
+		return
+	}
+	filter := module.RevisionFilter{Actor: query.Actor, From: query.From, To: query.To}
+
+	// Step 2: Load the history page
+	result, err := h.bound(ctx).GetModuleHistory(params.Key(), requestSubject(ctx), filter, query.Page, query.PageSize)
+	if err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	// Step 3: Return the changes with page totals
+	Respond(ctx, Result{
+		Data: result.Items,
+		Pagination: &response.PaginationMeta{
+			Page:       result.Page,
+			PageSize:   result.PageSize,
+			TotalItems: result.TotalItems,
+			TotalPages: int((result.TotalItems + int64(result.PageSize) - 1) / int64(result.PageSize)),
+		},
+	}, nil)
+}
+
+// RevertModule godoc
+// @Summary Revert a module to an earlier revision
+// @Description Restores the name, description and active flag recorded in a revision. The restored state is re-validated against the current business rules and written as a new "revert" revision; history is never rewritten.
+// @Tags modules
+// @Produce json
+// @Param id path int true "Module ID"
+// @Param revision query int true "Revision number to restore" minimum(1)
+// @Param X-Actor header string false "Who reverts the module, recorded in the change history"
+// @Param X-Actor-Teams header string false "Comma-separated teams of the actor, matched against the module ACL"
+// @Success 200 {object} response.APIResponse{data=module.ModuleResponse} "Module after the revert"
+// @Failure 400 {object} response.APIResponse "Missing revision or restored state is no longer valid"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "The actor may view but not edit the module"
+// @Failure 404 {object} response.APIResponse "Module or revision not found"
+// @Failure 409 {object} response.APIResponse "The old name is now used by another module, or the module is pending approval"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[modules:write] || BearerAuth[modules:write]
+// @Router /modules/{id}/revert [post]
+//
+// Sample Request:
+//
+//	POST /api/v1/modules/123/revert?revision=2
+//	X-Actor: jane
+func (h *ModuleHandler) RevertModule(ctx *gin.Context) {
+	// Step 1: Parse the module ID and revision number
+	var params module.IDParams
+	var query module.RevertQuery
+	if !bindPath(ctx, &params) || !bindQuery(ctx, &query) {
+		return
+	}
+
+	// Step 2: Restore the revision
+	reverted, err := h.bound(ctx).RevertModule(params.Key(), query.Revision, requestSubject(ctx))
+	if err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	// Step 3: Return the module after the revert
+	Respond(ctx, Result{Data: reverted}, nil)
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"fmt"
+	"hash/fnv"
+
+	"go_di_architecture/internal/domain/models/module"
+	"go_di_architecture/internal/domain/models/pagination"
+	"go_di_architecture/internal/domain/models/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListPublicModules godoc
+// @Summary List published modules
+// @Description Lists active modules without an ACL, with only their public fields, for embedding in documentation. Needs no credentials; requests are rate limited per client address (X-RateLimit-* headers, 429 RATE_LIMITED) and responses may be cached (Cache-Control, ETag with If-None-Match).
+// @Tags public
+// @Produce json
+// @Param page query int false "Page number (1-based)" default(1) minimum(1)
+// @Param pageSize query int false "Modules per page" default(20) minimum(1) maximum(100)
+// @Param If-None-Match header string false "ETag of a cached copy of the page"
+// @Success 200 {object} response.APIResponse{data=[]module.PublicModuleResponse} "Published modules"
+// @Success 304 "Page unchanged since the given ETag"
+// @Failure 400 {object} response.APIResponse "Invalid pagination parameters"
+// @Failure 429 {object} response.APIResponse "Rate limit exceeded"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Router /public/v1/modules [get]
+//
+// Sample Request:
+//
+//	GET /public/v1/modules?page=1&pageSize=20
+//
+// Sample Success Response (200):
+//
+//	{
+//	  "success": true,
+//	  "message": "Operation completed successfully",
+//	  "data": [
+//	    {"id": 123, "name": "Inventory", "description": "Handles product stock management", "updatedAt": "2023-08-15T14:30:00Z"}
+//	  ],
+//	  "meta": {
+//	    "requestId": "a1b2c3d4",
+//	    "timestamp": "2023-08-15T14:35:00Z",
+//	    "pagination": {"page": 1, "pageSize": 20, "totalItems": 1, "totalPages": 1}
+//	  }
+//	}
+func (h *ModuleHandler) ListPublicModules(ctx *gin.Context) {
+	var query module.PageQuery
+	if !bindQuery(ctx, &query) {
+		return
+	}
+
+	result, err := h.bound(ctx).ListPublicModules(query.Page, query.PageSize)
+	if err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	Respond(ctx, Result{
+		Data: result.Items,
+		ETag: publicPageETag(result),
+		Pagination: &response.PaginationMeta{
+			Page:       result.Page,
+			PageSize:   result.PageSize,
+			TotalItems: result.TotalItems,
+			TotalPages: int((result.TotalItems + int64(result.PageSize) - 1) / int64(result.PageSize)),
+		},
+	}, nil)
+}
+
+// GetPublicModule godoc
+// @Summary Get a published module
+// @Description Returns the public fields of an active module without an ACL. Other modules are reported as not found. Needs no credentials; rate limited and cacheable like the list, and also revalidated by date: the response carries Last-Modified and If-Modified-Since returns 304 while the module is unchanged.
+// @Tags public
+// @Produce json
+// @Param id path int true "Module ID"
+// @Param If-None-Match header string false "ETag of a cached copy of the module"
+// @Param If-Modified-Since header string false "Last-Modified date of a cached copy of the module; ignored when If-None-Match is sent"
+// @Success 200 {object} response.APIResponse{data=module.PublicModuleResponse} "Published module"
+// @Success 304 "Module unchanged since the given ETag or date"
+// @Failure 400 {object} response.APIResponse "Invalid module ID"
+// @Failure 404 {object} response.APIResponse "Module not found or not published"
+// @Failure 429 {object} response.APIResponse "Rate limit exceeded"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Router /public/v1/modules/{id} [get]
+//
+// Sample Request:
+//
+//	GET /public/v1/modules/123
+func (h *ModuleHandler) GetPublicModule(ctx *gin.Context) {
+	var params module.IDParams
+	if !bindPath(ctx, &params) {
+		return
+	}
+
+	published, err := h.bound(ctx).GetPublicModule(params.Key())
+	if err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	Respond(ctx, Result{
+		Data:         published,
+		ETag:         fmt.Sprintf(`W/"%d-%d"`, published.ID, published.UpdatedAt.UnixNano()),
+		LastModified: published.UpdatedAt,
+	}, nil)
+}
+
+// publicPageETag builds a weak ETag for a page of published modules.
+//
+// The tag covers the page position, the total and the ID and last change of
+// every module, so it changes whenever the rendered page would.
+//
+// Parameters:
+//   - page: The page being returned
+//
+// Returns:
+//   - string: The ETag header value
+func publicPageETag(page *pagination.Page[*module.PublicModuleResponse]) string {
+	hash := fnv.New64a()
+	fmt.Fprintf(hash, "%d/%d/%d", page.Page, page.PageSize, page.TotalItems)
+	for _, m := range page.Items {
+		fmt.Fprintf(hash, "/%d-%d", m.ID, m.UpdatedAt.UnixNano())
+	}
+	return fmt.Sprintf(`W/"%x"`, hash.Sum64())
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"go_di_architecture/internal/domain/models/module"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StarModule godoc
+// @Summary Star a module
+// @Description Adds the module to the favorites of the caller; starring a starred module is a no-op. Starred modules are listed with GET /modules?starred=true. The caller is the authenticated principal, or the X-Actor header while authentication is disabled; anonymous requests are rejected.
+// @Tags modules
+// @Param id path int true "Module ID"
+// @Param X-Actor header string false "The user starring the module"
+// @Param X-Actor-Teams header string false "Comma-separated teams of the actor, matched against the module ACL"
+// @Success 204 "Module starred"
+// @Failure 400 {object} response.APIResponse "Invalid module ID"
+// @Failure 401 {object} response.APIResponse "Authentication required or no user identified"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 404 {object} response.APIResponse "Module not found"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[modules:read] || BearerAuth[modules:read]
+// @Router /modules/{id}/star [put]
+//
+// Sample Request:
+//
+//	PUT /api/v1/modules/123/star
+//	X-Actor: jane
+func (h *ModuleHandler) StarModule(ctx *gin.Context) {
+	var params module.IDParams
+	if !bindPath(ctx, &params) {
+		return
+	}
+
+	if err := h.bound(ctx).StarModule(params.Key(), starSubject(ctx)); err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	Respond(ctx, Result{}, nil)
+}
+
+// UnstarModule godoc
+// @Summary Unstar a module
+// @Description Removes the module from the favorites of the caller; unstarring a module that is not starred is a no-op
+// @Tags modules
+// @Param id path int true "Module ID"
+// @Param X-Actor header string false "The user unstarring the module"
+// @Param X-Actor-Teams header string false "Comma-separated teams of the actor, matched against the module ACL"
+// @Success 204 "Module unstarred"
+// @Failure 400 {object} response.APIResponse "Invalid module ID"
+// @Failure 401 {object} response.APIResponse "Authentication required or no user identified"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 404 {object} response.APIResponse "Module not found"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[modules:read] || BearerAuth[modules:read]
+// @Router /modules/{id}/star [delete]
+func (h *ModuleHandler) UnstarModule(ctx *gin.Context) {
+	var params module.IDParams
+	if !bindPath(ctx, &params) {
+		return
+	}
+
+	if err := h.bound(ctx).UnstarModule(params.Key(), starSubject(ctx)); err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	Respond(ctx, Result{}, nil)
+}
+
+// starSubject returns the subject of a star request; the user is empty for
+// anonymous requests, which the service rejects.
+func starSubject(ctx *gin.Context) module.Subject {
+	subject := requestSubject(ctx)
+	subject.User = requestUser(ctx)
+	return subject
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"net/http"
+
+	"go_di_architecture/internal/domain/models/module"
+	"go_di_architecture/internal/domain/models/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestOwnershipTransfer godoc
+// @Summary Propose a new owner for a module
+// @Description Records a pending ownership transfer. The module keeps its owner until the proposed owner accepts the transfer with POST /transfers/{id}/accept; the transfer expires after 72 hours. The proposed owner is notified through a module.ownership_transfer_requested event.
+// @Tags modules
+// @Accept json
+// @Produce json
+// @Param id path int true "Module ID"
+// @Param request body module.TransferOwnershipRequest true "Proposed owner"
+// @Param X-Actor header string false "Who requests the transfer; must be allowed to edit the module"
+// @Param X-Actor-Teams header string false "Comma-separated teams of the actor, matched against the module ACL"
+// @Success 201 {object} response.APIResponse{data=module.TransferResponse} "Pending transfer"
+// @Failure 400 {object} response.APIResponse "Missing new owner or the new owner already owns the module"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "The actor may view but not edit the module"
+// @Failure 404 {object} response.APIResponse "Module not found"
+// @Failure 409 {object} response.APIResponse "The module already has a pending transfer"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[modules:write] || BearerAuth[modules:write]
+// @Router /modules/{id}/transfer-ownership [post]
+//
+// Sample Request:
+//
+//	POST /api/v1/modules/123/transfer-ownership
+//	X-Actor: jane
+//	{
+//	  "newOwner": "bob"
+//	}
+func (h *ModuleHandler) RequestOwnershipTransfer(ctx *gin.Context) {
+	var params module.IDParams
+	if !bindPath(ctx, &params) {
+		return
+	}
+
+	// Step 1: Validate request payload
+	var request module.TransferOwnershipRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		Respond(ctx, Result{}, response.NewHTTPError(http.StatusBadRequest, "VALIDATION_ERROR", extractValidationErrors(ctx, err)))
+		return
+	}
+
+	// Step 2: Record the transfer
+	transfer, err := h.bound(ctx).RequestOwnershipTransfer(params.Key(), request, requestSubject(ctx))
+	if err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	// Step 3: Return the pending transfer
+	Respond(ctx, Result{Data: transfer, Status: http.StatusCreated}, nil)
+}
+
+// GetOwnershipTransfer godoc
+// @Summary Get an ownership transfer
+// @Description Returns an ownership transfer. Pending transfers past their expiry are reported as expired.
+// @Tags modules
+// @Produce json
+// @Param id path int true "Transfer ID"
+// @Param X-Actor header string false "Who asks; must be allowed to view the module"
+// @Param X-Actor-Teams header string false "Comma-separated teams of the actor, matched against the module ACL"
+// @Success 200 {object} response.APIResponse{data=module.TransferResponse} "Transfer"
+// @Failure 400 {object} response.APIResponse "Invalid transfer ID"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 404 {object} response.APIResponse "Transfer not found"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[modules:read] || BearerAuth[modules:read]
+// @Router /transfers/{id} [get]
+func (h *ModuleHandler) GetOwnershipTransfer(ctx *gin.Context) {
+	var params module.IDParams
+	if !bindPath(ctx, &params) {
+		return
+	}
+
+	transfer, err := h.bound(ctx).GetOwnershipTransfer(params.Key(), requestSubject(ctx))
+	if err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	Respond(ctx, Result{Data: transfer}, nil)
+}
+
+// AcceptOwnershipTransfer godoc
+// @Summary Accept an ownership transfer
+// @Description Makes the proposed owner the owner of the module. Only the proposed owner may accept. The change is recorded in the module history as a "transfer" revision, a restricted module grants the new owner edit access, and the previous owner is notified through a module.ownership_transferred event.
+// @Tags modules
+// @Produce json
+// @Param id path int true "Transfer ID"
+// @Param X-Actor header string false "Who accepts; must be the proposed owner"
+// @Success 200 {object} response.APIResponse{data=module.TransferResponse} "Accepted transfer"
+// @Failure 400 {object} response.APIResponse "Invalid transfer ID"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "The actor is not the proposed owner"
+// @Failure 404 {object} response.APIResponse "Transfer or module not found"
+// @Failure 409 {object} response.APIResponse "The transfer was already accepted or has expired"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[modules:write] || BearerAuth[modules:write]
+// @Router /transfers/{id}/accept [post]
+//
+// Sample Request:
+//
+//	POST /api/v1/transfers/7/accept
+//	X-Actor: bob
+func (h *ModuleHandler) AcceptOwnershipTransfer(ctx *gin.Context) {
+	var params module.IDParams
+	if !bindPath(ctx, &params) {
+		return
+	}
+
+	transfer, err := h.bound(ctx).AcceptOwnershipTransfer(params.Key(), requestSubject(ctx))
+	if err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	Respond(ctx, Result{Data: transfer}, nil)
+}
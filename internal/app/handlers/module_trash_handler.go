@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"net/http"
+
+	"go_di_architecture/internal/domain/models/module"
+	"go_di_architecture/internal/domain/models/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DeleteModule godoc
+// @Summary Delete a module
+// @Description Moves a module to the recycle bin. The module disappears from all read endpoints but keeps its tags, dependencies, settings and history, and its name stays reserved until it is purged.
+// @Tags modules
+// @Param id path int true "Module ID"
+// @Param X-Actor header string false "Who deletes the module, recorded in the change history"
+// @Param X-Actor-Teams header string false "Comma-separated teams of the actor, matched against the module ACL"
+// @Param dryRun query bool false "Only check that the module can be deleted; the response carries X-Dry-Run: true"
+// @Success 204 "Module moved to the recycle bin (or would be, on a dry run)"
+// @Failure 400 {object} response.APIResponse "Invalid module ID or dryRun flag"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "The actor may view but not edit the module"
+// @Failure 404 {object} response.APIResponse "Module not found"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[modules:write] || BearerAuth[modules:write]
+// @Router /modules/{id} [delete]
+func (h *ModuleHandler) DeleteModule(ctx *gin.Context) {
+	mapper := responseMapper(ctx)
+
+	var params module.IDParams
+	if !bindPath(ctx, &params) {
+		return
+	}
+	dryRun, ok := requestDryRun(ctx, mapper)
+	if !ok {
+		return
+	}
+
+	if err := h.bound(ctx).DeleteModule(params.Key(), requestSubject(ctx), dryRun); err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	Respond(ctx, Result{}, nil)
+}
+
+// ListDeletedModules godoc
+// @Summary List the recycle bin
+// @Description Lists soft-deleted modules with deletion metadata, most recently deleted first. Intended for administrators.
+// @Tags trash
+// @Produce json
+// @Param page query int false "Page number" default(1) minimum(1)
+// @Param pageSize query int false "Items per page" default(20) minimum(1) maximum(100)
+// @Success 200 {object} response.APIResponse{data=[]module.DeletedModuleResponse} "Deleted modules"
+// @Failure 400 {object} response.APIResponse "Invalid pagination parameters"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[admin] || BearerAuth[admin]
+// @Router /modules/trash [get]
+//
+// Sample Success Response (200):
+//
+//	{
+//	  "success": true,
+//	  "message": "Operation completed successfully",
+//	  "data": [
+//	    {"id": 9, "name": "Billing", "description": "", "isActive": false, "createdAt": "2023-08-15T14:30:00Z",
+//	     "updatedAt": "2023-08-20T08:00:00Z", "deletedAt": "2023-08-20T08:00:00Z", "deletedBy": "jane"}
+//	  ],
+//	  "meta": {
+//	    "requestId": "a1b2c3d4",
+//	    "timestamp": "2023-08-20T08:05:00Z",
+//	    "pagination": {"page": 1, "pageSize": 20, "totalItems": 1, "totalPages": 1}
+//	  }
+//	}
+func (h *ModuleHandler) ListDeletedModules(ctx *gin.Context) {
+	// Step 1: Parse pagination parameters
+	var query module.PageQuery
+	if !bindQuery(ctx, &query) {
+		return
+	}
+
+	// Step 2: Load the page
+	result, err := h.bound(ctx).ListDeletedModules(query.Page, query.PageSize)
+	if err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	// Step 3: Return the modules with page totals
+	Respond(ctx, Result{
+		Data: result.Items,
+		Pagination: &response.PaginationMeta{
+			Page:       result.Page,
+			PageSize:   result.PageSize,
+			TotalItems: result.TotalItems,
+			TotalPages: int((result.TotalItems + int64(result.PageSize) - 1) / int64(result.PageSize)),
+		},
+	}, nil)
+}
+
+// RestoreModules godoc
+// @Summary Restore modules from the recycle bin
+// @Description Restores the listed modules. IDs that are not in the recycle bin are reported in meta.missingIds. Intended for administrators.
+// @Tags trash
+// @Accept json
+// @Produce json
+// @Param request body module.ModuleIdsRequest true "Modules to restore"
+// @Param X-Actor header string false "Who restores the modules, recorded in the change history"
+// @Success 200 {object} response.APIResponse{data=[]module.ModuleResponse} "Restored modules"
+// @Failure 400 {object} response.APIResponse "Validation error"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[admin] || BearerAuth[admin]
+// @Router /modules/trash/restore [post]
+//
+// Sample Request:
+//
+//	POST /api/v1/modules/trash/restore
+//	{
+//	  "ids": [9, 12]
+//	}
+func (h *ModuleHandler) RestoreModules(ctx *gin.Context) {
+	// Step 1: Validate request payload
+	var request module.ModuleIdsRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		Respond(ctx, Result{}, response.NewHTTPError(http.StatusBadRequest, "VALIDATION_ERROR", extractValidationErrors(ctx, err)))
+		return
+	}
+
+	// Step 2: Restore the modules
+	restored, missing, err := h.bound(ctx).RestoreModules(request.Ids, requestActor(ctx))
+	if err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	// Step 3: Return the restored modules and the skipped IDs
+	Respond(ctx, Result{Data: restored, MissingIds: missing}, nil)
+}
+
+// PurgeModules godoc
+// @Summary Purge modules from the recycle bin
+// @Description Permanently removes the listed modules together with their tag assignments, dependencies, settings and history; their names become available again. IDs that are not in the recycle bin are reported in meta.missingIds. Intended for administrators.
+// @Tags trash
+// @Accept json
+// @Produce json
+// @Param request body module.ModuleIdsRequest true "Modules to purge"
+// @Success 200 {object} response.APIResponse{data=[]int} "IDs of the purged modules"
+// @Failure 400 {object} response.APIResponse "Validation error"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[admin] || BearerAuth[admin]
+// @Router /modules/trash/purge [post]
+//
+// Sample Request:
+//
+//	POST /api/v1/modules/trash/purge
+//	{
+//	  "ids": [9, 12]
+//	}
+func (h *ModuleHandler) PurgeModules(ctx *gin.Context) {
+	// Step 1: Validate request payload
+	var request module.ModuleIdsRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		Respond(ctx, Result{}, response.NewHTTPError(http.StatusBadRequest, "VALIDATION_ERROR", extractValidationErrors(ctx, err)))
+		return
+	}
+
+	// Step 2: Purge the modules
+	purged, missing, err := h.bound(ctx).PurgeModules(request.Ids)
+	if err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	// Step 3: Return the purged and skipped IDs
+	Respond(ctx, Result{Data: purged, MissingIds: missing}, nil)
+}
@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"net/http"
+
+	"go_di_architecture/internal/domain/models/module"
+	"go_di_architecture/internal/domain/models/response"
+	noteService "go_di_architecture/internal/domain/service/note"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NoteHandler handles HTTP requests for free-text notes on modules.
+//
+// Notes record operational context such as incidents or manual changes. They
+// are append-only: a note is written once and can only be deleted, which
+// hides it from listings.
+type NoteHandler struct {
+	service *noteService.NoteService
+}
+
+// NewNoteHandler creates a new instance of NoteHandler.
+//
+// Parameters:
+//   - service: Business service handling note operations
+//
+// Returns:
+//   - *NoteHandler: A new handler instance
+func NewNoteHandler(service *noteService.NoteService) *NoteHandler {
+	return &NoteHandler{service: service}
+}
+
+// Routes returns the module note routes.
+func (h *NoteHandler) Routes() []RouteSpec {
+	return []RouteSpec{
+		{Method: http.MethodGet, Path: "/modules/:id/notes", Policy: readScope, Handler: h.ListNotes, Query: module.PageQuery{}, Response: []module.NoteResponse{}},                            // GET /api/v1/modules/{id}/notes
+		{Method: http.MethodPost, Path: "/modules/:id/notes", Policy: writeScope, Handler: h.AddNote, Body: module.NoteRequest{}, Response: module.NoteResponse{}, Status: http.StatusCreated}, // POST /api/v1/modules/{id}/notes
+		{Method: http.MethodDelete, Path: "/modules/:id/notes/:noteId", Policy: writeScope, Handler: h.DeleteNote, Status: http.StatusNoContent},                                               // DELETE /api/v1/modules/{id}/notes/{noteId}
+	}
+}
+
+// ListNotes godoc
+// @Summary List module notes
+// @Description Returns one page of the notes attached to a module, newest first. Deleted notes are not listed. bodyHtml holds the body with HTML escaped and line breaks as markup, safe to insert into a page.
+// @Tags notes
+// @Produce json
+// @Param id path int true "Module ID"
+// @Param page query int false "Page number (1-based)" default(1) minimum(1)
+// @Param pageSize query int false "Notes per page" default(20) minimum(1) maximum(100)
+// @Success 200 {object} response.APIResponse{data=[]module.NoteResponse} "Notes of the module"
+// @Failure 400 {object} response.APIResponse "Invalid module ID or pagination parameters"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 404 {object} response.APIResponse "Module not found"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[modules:read] || BearerAuth[modules:read]
+// @Router /modules/{id}/notes [get]
+//
+// Sample Request:
+//
+//	GET /api/v1/modules/123/notes?page=1&pageSize=20
+//
+// Sample Success Response (200):
+//
+//	{
+//	  "success": true,
+//	  "message": "Operation completed successfully",
+//	  "data": [
+//	    {"id": 7, "moduleId": 123, "author": "jane", "body": "Rotated the **billing** credentials",
+//	     "bodyHtml": "<p>Rotated the **billing** credentials</p>", "createdAt": "2023-08-15T14:30:00Z"}
+//	  ],
+//	  "meta": {
+//	    "requestId": "a1b2c3d4",
+//	    "timestamp": "2023-08-15T14:35:00Z",
+//	    "pagination": {"page": 1, "pageSize": 20, "totalItems": 1, "totalPages": 1}
+//	  }
+//	}
+func (h *NoteHandler) ListNotes(ctx *gin.Context) {
+	// Step 1: Parse the module ID and pagination parameters
+	var params module.IDParams
+	var query module.PageQuery
+	if !bindPath(ctx, &params) || !bindQuery(ctx, &query) {
+		return
+	}
+
+	// Step 2: Load the page
+	result, err := h.service.ListNotes(params.Key(), requestSubject(ctx), query.Page, query.PageSize)
+	if err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	// Step 3: Return the notes with page totals
+	Respond(ctx, Result{
+		Data: result.Items,
+		Pagination: &response.PaginationMeta{
+			Page:       result.Page,
+			PageSize:   result.PageSize,
+			TotalItems: result.TotalItems,
+			TotalPages: int((result.TotalItems + int64(result.PageSize) - 1) / int64(result.PageSize)),
+		},
+	}, nil)
+}
+
+// AddNote godoc
+// @Summary Add a note to a module
+// @Description Attaches a free-text note to a module. Markdown is stored verbatim; the author is the authenticated principal or the X-Actor header.
+// @Tags notes
+// @Accept json
+// @Produce json
+// @Param id path int true "Module ID"
+// @Param request body module.NoteRequest true "Note text"
+// @Param X-Actor header string false "Author of the note"
+// @Success 201 {object} response.APIResponse{data=module.NoteResponse} "Created note"
+// @Failure 400 {object} response.APIResponse "Validation error"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 404 {object} response.APIResponse "Module not found"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[modules:write] || BearerAuth[modules:write]
+// @Router /modules/{id}/notes [post]
+//
+// Sample Request:
+//
+//	POST /api/v1/modules/123/notes
+//	X-Actor: jane
+//	{
+//	  "body": "Rotated the **billing** credentials, see INC-42"
+//	}
+func (h *NoteHandler) AddNote(ctx *gin.Context) {
+	// Step 1: Validate the module ID and payload
+	var params module.IDParams
+	if !bindPath(ctx, &params) {
+		return
+	}
+
+	var request module.NoteRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		Respond(ctx, Result{}, response.NewHTTPError(http.StatusBadRequest, "VALIDATION_ERROR", extractValidationErrors(ctx, err)))
+		return
+	}
+
+	// Step 2: Store the note
+	note, err := h.service.AddNote(params.Key(), request, requestSubject(ctx))
+	if err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	Respond(ctx, Result{Data: note, Status: http.StatusCreated}, nil)
+}
+
+// DeleteNote godoc
+// @Summary Delete a module note
+// @Description Soft-deletes a note: it is no longer listed, but is kept with the deleting actor until the module is purged
+// @Tags notes
+// @Param id path int true "Module ID"
+// @Param noteId path int true "Note ID"
+// @Param X-Actor header string false "Who deletes the note"
+// @Success 204 "Note deleted"
+// @Failure 400 {object} response.APIResponse "Invalid module or note ID"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 404 {object} response.APIResponse "Module or note not found"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[modules:write] || BearerAuth[modules:write]
+// @Router /modules/{id}/notes/{noteId} [delete]
+func (h *NoteHandler) DeleteNote(ctx *gin.Context) {
+	var params module.NoteParams
+	if !bindPath(ctx, &params) {
+		return
+	}
+
+	if err := h.service.DeleteNote(params.Key(), params.NoteKey(), requestSubject(ctx)); err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	Respond(ctx, Result{}, nil)
+}
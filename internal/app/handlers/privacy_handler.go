@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"go_di_architecture/internal/domain/models/privacy"
+	privacyService "go_di_architecture/internal/domain/service/privacy"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PrivacyHandler exposes export and erasure of user data to operators.
+//
+// The routes serve data subject requests (access and erasure) and are
+// registered outside of the versioned public API next to the other admin
+// endpoints.
+type PrivacyHandler struct {
+	service *privacyService.PrivacyService
+}
+
+// NewPrivacyHandler creates a new instance of PrivacyHandler.
+//
+// Parameters:
+//   - service: Business service collecting and erasing user data
+//
+// Returns:
+//   - *PrivacyHandler: A new handler instance
+func NewPrivacyHandler(service *privacyService.PrivacyService) *PrivacyHandler {
+	return &PrivacyHandler{service: service}
+}
+
+// Routes returns the export and erasure routes of user data.
+func (h *PrivacyHandler) Routes() []RouteSpec {
+	return []RouteSpec{
+		{Group: GroupAdmin, Method: http.MethodGet, Path: "/users/:user/data", Handler: h.ExportUserData, Response: privacy.UserDataExport{}},  // GET /admin/users/{user}/data
+		{Group: GroupAdmin, Method: http.MethodDelete, Path: "/users/:user/data", Handler: h.EraseUserData, Response: privacy.ErasureReport{}}, // DELETE /admin/users/{user}/data
+	}
+}
+
+// ExportUserData godoc
+// @Summary Export all data linked to a user
+// @Description Returns the modules the user owns or moved to the recycle bin, the change history entries made by the user or naming the user as owner, ownership transfers involving the user, ACL entries granting the user access and archived modules the user owned.
+// @Tags admin
+// @Produce json
+// @Param user path string true "User (actor name)"
+// @Success 200 {object} response.APIResponse{data=privacy.UserDataExport} "User data"
+// @Failure 400 {object} response.APIResponse "Invalid user"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[admin] || BearerAuth[admin]
+// @Router /admin/users/{user}/data [get]
+//
+// Sample Request:
+//
+//	GET /admin/users/jane/data
+func (h *PrivacyHandler) ExportUserData(ctx *gin.Context) {
+	export, err := h.service.ExportUserData(ctx.Param("user"))
+	if err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	Respond(ctx, Result{Data: export}, nil)
+}
+
+// EraseUserData godoc
+// @Summary Erase a user's personal identifiers
+// @Description Replaces the user with a random pseudonym as module owner, recycle bin actor, change history actor and owner, transfer party, ACL principal and archived module owner, in one transaction. Records are kept, so modules stay restricted and their history stays complete. Pending transfers to the user are expired. The completion report counts the changed records and lists what the erasure does not reach.
+// @Tags admin
+// @Produce json
+// @Param user path string true "User (actor name)"
+// @Success 200 {object} response.APIResponse{data=privacy.ErasureReport} "Completion report"
+// @Failure 400 {object} response.APIResponse "Invalid or already erased user"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 500 {object} response.APIResponse "Internal server error; nothing was erased"
+// @Security ApiKeyAuth[admin] || BearerAuth[admin]
+// @Router /admin/users/{user}/data [delete]
+//
+// Sample Request:
+//
+//	DELETE /admin/users/jane/data
+func (h *PrivacyHandler) EraseUserData(ctx *gin.Context) {
+	report, err := h.service.EraseUser(ctx.Param("user"))
+	if err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	Respond(ctx, Result{Data: report}, nil)
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"slices"
+	"strings"
+
+	"go_di_architecture/internal/app/auth"
+	"go_di_architecture/internal/domain/models/module"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ActorHeader names the header identifying who performs a change.
+//
+// Authenticated callers are identified by their credentials and the header is
+// ignored; while authentication is disabled callers identify themselves. The
+// value is recorded in the module change history.
+const ActorHeader = "X-Actor"
+
+// anonymousActor is recorded when a request carries no actor header.
+const anonymousActor = "anonymous"
+
+// requestActor returns the actor of a request, trimmed to the stored length.
+//
+// Parameters:
+//   - ctx: Gin context for the request
+//
+// Returns:
+//   - string: The authenticated principal, else the header, or "anonymous"
+//     when the header is absent or blank
+func requestActor(ctx *gin.Context) string {
+	actor := strings.TrimSpace(ctx.GetHeader(ActorHeader))
+	if principal := auth.PrincipalFrom(ctx); principal != nil && !principal.Anonymous {
+		actor = principal.Name
+	}
+	if actor == "" {
+		return anonymousActor
+	}
+	if len(actor) > module.MaxActorLength {
+		actor = actor[:module.MaxActorLength]
+	}
+	return actor
+}
+
+// requestUser returns the identified user of a request.
+//
+// Parameters:
+//   - ctx: Gin context for the request
+//
+// Returns:
+//   - string: The actor (see requestActor), or "" for anonymous requests
+func requestUser(ctx *gin.Context) string {
+	if actor := requestActor(ctx); actor != anonymousActor {
+		return actor
+	}
+	return ""
+}
+
+// TeamsHeader names the header listing the teams of the actor, comma-separated.
+//
+// Like ActorHeader it is only read while authentication is disabled;
+// authenticated callers belong to the teams of their credentials. The teams
+// are matched against "team:<name>" entries of module access control lists.
+const TeamsHeader = "X-Actor-Teams"
+
+// requestSubject returns the actor and teams of a request for access checks.
+//
+// Parameters:
+//   - ctx: Gin context for the request
+//
+// Returns:
+//   - module.Subject: The actor (see requestActor) and its teams: those of
+//     the principal, or the non-blank teams of the header while
+//     authentication is disabled. Authenticated principals holding the admin
+//     scope administer every module
+func requestSubject(ctx *gin.Context) module.Subject {
+	subject := module.Subject{User: requestActor(ctx)}
+	principal := auth.PrincipalFrom(ctx)
+	if principal == nil || !principal.Anonymous {
+		// Unauthenticated callers of an authenticating API belong to no team
+		if principal != nil {
+			subject.Teams = slices.Clone(principal.Teams)
+			subject.Admin = principal.HasScope(auth.ScopeAdmin)
+		}
+		return subject
+	}
+	for _, team := range strings.Split(ctx.GetHeader(TeamsHeader), ",") {
+		if team = strings.TrimSpace(team); team != "" {
+			subject.Teams = append(subject.Teams, team)
+		}
+	}
+	return subject
+}
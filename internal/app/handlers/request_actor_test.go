@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"testing"
+
+	"go_di_architecture/internal/app/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	os.Exit(m.Run())
+}
+
+// subjectContext builds the context of a request carrying the actor headers.
+func subjectContext(t *testing.T, principal *auth.Principal, actor, teams string) *gin.Context {
+	t.Helper()
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/api/v1/modules/1", nil)
+	ctx.Request.Header.Set(ActorHeader, actor)
+	ctx.Request.Header.Set(TeamsHeader, teams)
+	if principal != nil {
+		auth.SetPrincipal(ctx, principal)
+	}
+	return ctx
+}
+
+func TestRequestSubjectIgnoresTeamsHeaderOfAuthenticatedCallers(t *testing.T) {
+	keys := []auth.APIKey{{Name: "bob", Key: "b0b", Scopes: []string{auth.ScopeModulesWrite}, Teams: []string{"infra"}}}
+	request := httptest.NewRequest(http.MethodPut, "/api/v1/modules/1/acl", nil)
+	request.Header.Set(auth.APIKeyHeader, "b0b")
+	principal, err := auth.NewTokenAuthenticator(nil, keys).Authenticate(request)
+	if err != nil || principal == nil {
+		t.Fatalf("Authenticate() = %v, %v; want bob", principal, err)
+	}
+
+	subject := requestSubject(subjectContext(t, principal, "alice", "core, infra"))
+
+	if subject.User != "bob" {
+		t.Errorf("User = %q, want the principal bob", subject.User)
+	}
+	if !slices.Equal(subject.Teams, []string{"infra"}) {
+		t.Errorf("Teams = %v, want the teams of the API key [infra]", subject.Teams)
+	}
+}
+
+func TestRequestSubject(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal *auth.Principal
+		teams     string
+		wantUser  string
+		wantTeams []string
+	}{
+		{
+			name:      "authentication disabled trusts the header",
+			principal: auth.NewAnonymous(nil),
+			teams:     " core, ,infra ",
+			wantUser:  "alice",
+			wantTeams: []string{"core", "infra"},
+		},
+		{
+			name:      "token principal without teams",
+			principal: &auth.Principal{Name: "carol", Scopes: []string{auth.ScopeModulesRead}},
+			teams:     "core",
+			wantUser:  "carol",
+		},
+		{
+			name:      "unauthenticated caller of an authenticating API",
+			principal: nil,
+			teams:     "core",
+			wantUser:  "alice",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			subject := requestSubject(subjectContext(t, test.principal, "alice", test.teams))
+
+			if subject.User != test.wantUser {
+				t.Errorf("User = %q, want %q", subject.User, test.wantUser)
+			}
+			if !slices.Equal(subject.Teams, test.wantTeams) {
+				t.Errorf("Teams = %v, want %v", subject.Teams, test.wantTeams)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"go_di_architecture/internal/domain/models/response"
+	"go_di_architecture/internal/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DryRunQuery names the query parameter asking a mutating endpoint to run all
+// checks without storing anything.
+const DryRunQuery = "dryRun"
+
+// DryRunHeader is set on responses to dry-run requests, including those
+// without a body (e.g. 204 No Content).
+const DryRunHeader = "X-Dry-Run"
+
+// requestDryRun reads the dry-run flag of a mutating request.
+//
+// An invalid value is answered with a 400 validation error. For dry runs the
+// response mapper and headers are marked so clients can tell the response
+// describes what would have happened.
+//
+// Parameters:
+//   - ctx: Gin context for the request
+//   - mapper: Response mapper of the request
+//
+// Returns:
+//   - dryRun: Whether the request is a dry run
+//   - ok: False when an error has been reported
+func requestDryRun(ctx *gin.Context, mapper *response.ResponseMapper) (dryRun bool, ok bool) {
+	raw, present := ctx.GetQuery(DryRunQuery)
+	if !present {
+		return false, true
+	}
+
+	dryRun, err := strconv.ParseBool(raw)
+	if err != nil {
+		Respond(ctx, Result{}, response.NewHTTPError(http.StatusBadRequest, "VALIDATION_ERROR", map[string][]string{DryRunQuery: {i18n.Message(translator(ctx), "validation.boolean")}}))
+		return false, false
+	}
+
+	if dryRun {
+		mapper.MarkDryRun()
+		ctx.Header(DryRunHeader, "true")
+	}
+	return dryRun, true
+}
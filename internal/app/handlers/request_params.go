@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"go_di_architecture/internal/domain/models/response"
+	"go_di_architecture/internal/i18n"
+
+	"github.com/gin-gonic/gin"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+)
+
+// bindPath binds and validates the path parameters of a request.
+//
+// Parameters:
+//   - ctx: Gin context for the request
+//   - params: Pointer to a struct with uri tags, e.g. *module.IDParams
+//
+// Returns:
+//   - bool: False if a parameter is invalid; a 400 VALIDATION_ERROR naming
+//     the parameter was reported and the handler must return
+func bindPath(ctx *gin.Context, params any) bool {
+	return reportParams(ctx, params, "uri", ctx.ShouldBindUri(params))
+}
+
+// bindQuery binds and validates the query parameters of a request.
+//
+// Parameters:
+//   - ctx: Gin context for the request
+//   - query: Pointer to a struct with form tags, e.g. *module.ListQuery
+//
+// Returns:
+//   - bool: False if a parameter is invalid; a 400 VALIDATION_ERROR naming
+//     the parameter was reported and the handler must return
+func bindQuery(ctx *gin.Context, query any) bool {
+	return reportParams(ctx, query, "form", ctx.ShouldBindQuery(query))
+}
+
+// reportParams reports the binding error of path or query parameters.
+//
+// Validation errors are keyed by parameter name like body validation errors
+// are keyed by field. Values that cannot be converted (e.g. "abc" for an
+// integer) fail before validation without naming their parameter, so the
+// parameters are parsed once more to find them.
+func reportParams(ctx *gin.Context, target any, tag string, err error) bool {
+	if err == nil {
+		return true
+	}
+
+	var details map[string][]string
+	var verr validator.ValidationErrors
+	if errors.As(err, &verr) {
+		details = make(map[string][]string)
+		targetType := reflect.TypeOf(target).Elem()
+		for _, fieldErr := range verr {
+			name := paramName(targetType, fieldErr.StructField(), tag)
+			details[name] = append(details[name], validationMessage(ctx, fieldErr))
+		}
+	} else {
+		details = unparsableParams(ctx, reflect.TypeOf(target).Elem(), tag)
+	}
+
+	Respond(ctx, Result{}, response.NewHTTPError(http.StatusBadRequest, "VALIDATION_ERROR", details))
+	return false
+}
+
+// paramName returns the request name of a struct field, e.g. "pageSize".
+func paramName(structType reflect.Type, fieldName, tag string) string {
+	field, ok := structType.FieldByName(fieldName)
+	if !ok {
+		return fieldName
+	}
+	name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+	if name == "" {
+		return fieldName
+	}
+	return name
+}
+
+// unparsableParams lists the parameters whose value does not convert to the
+// type of their field.
+func unparsableParams(ctx *gin.Context, structType reflect.Type, tag string) map[string][]string {
+	details := make(map[string][]string)
+	collectUnparsable(ctx, structType, tag, details)
+
+	if len(details) == 0 {
+		source := "query"
+		if tag == "uri" {
+			source = "path"
+		}
+		details[source] = []string{i18n.Message(translator(ctx), "validation.malformed")}
+	}
+	return details
+}
+
+// collectUnparsable adds the unparsable parameters of a struct to details,
+// descending into embedded structs.
+func collectUnparsable(ctx *gin.Context, structType reflect.Type, tag string, details map[string][]string) {
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			collectUnparsable(ctx, field.Type, tag, details)
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "" {
+			continue
+		}
+		raw, ok := ctx.GetQuery(name)
+		if tag == "uri" {
+			raw, ok = ctx.Params.Get(name)
+		}
+		if !ok || raw == "" {
+			continue
+		}
+
+		if key := parseFailure(field, raw); key != "" {
+			details[name] = append(details[name], i18n.Message(translator(ctx), key))
+		}
+	}
+}
+
+// parseFailure returns the message key of why a raw value does not convert
+// to a field's type, or "" when it does.
+func parseFailure(field reflect.StructField, raw string) string {
+	fieldType := field.Type
+	if fieldType.Kind() == reflect.Pointer {
+		fieldType = fieldType.Elem()
+	}
+
+	switch {
+	case fieldType == reflect.TypeOf(time.Time{}):
+		if _, err := time.Parse(field.Tag.Get("time_format"), raw); err != nil {
+			return "validation.timestamp"
+		}
+	case fieldType.Kind() == reflect.Bool:
+		if _, err := strconv.ParseBool(raw); err != nil {
+			return "validation.boolean"
+		}
+	case fieldType.Kind() >= reflect.Int && fieldType.Kind() <= reflect.Int64:
+		if _, err := strconv.ParseInt(raw, 10, fieldType.Bits()); err != nil {
+			return "validation.integer"
+		}
+	}
+	return ""
+}
+
+// validationMessage describes a failed binding rule in the caller's language.
+//
+// Parameters:
+//   - ctx: Gin context for the request
+//   - fieldErr: The failed rule of one field
+//
+// Returns:
+//   - string: The message reported for the field
+func validationMessage(ctx *gin.Context, fieldErr validator.FieldError) string {
+	return i18n.FieldMessage(translator(ctx), fieldErr)
+}
+
+// translator returns the translator of the caller's language, or nil for
+// English when the locale middleware is not installed.
+func translator(ctx *gin.Context) ut.Translator {
+	trans, _ := ctx.Value(i18n.TranslatorContextKey).(ut.Translator)
+	return trans
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"context"
+
+	"go_di_architecture/internal/domain/models/admin"
+	"go_di_architecture/internal/domain/models/backup"
+	"go_di_architecture/internal/domain/models/deadletter"
+	"go_di_architecture/internal/domain/models/export"
+	"go_di_architecture/internal/domain/models/module"
+	"go_di_architecture/internal/domain/models/tag"
+	"go_di_architecture/internal/domain/models/template"
+	"go_di_architecture/internal/domain/models/workflow"
+
+	"github.com/gin-gonic/gin/binding"
+)
+
+// boundRequests lists a value of every request body, path and query struct
+// the handlers bind.
+var boundRequests = []any{
+	&module.ModuleRequest{},
+	&module.ModuleIdsRequest{},
+	&module.ModuleACLRequest{},
+	&module.TransferOwnershipRequest{},
+	&module.ReviewRequest{},
+	&module.NoteRequest{},
+	&tag.TagRequest{},
+	&template.TemplateRequest{},
+	&template.InstantiateRequest{},
+	&export.ExportRequest{},
+	&backup.RestoreRequest{},
+	&admin.LogLevelRequest{},
+	&workflow.ProvisionRequest{},
+	&module.IDParams{},
+	&module.DependencyParams{},
+	&module.NoteParams{},
+	&template.TemplateParams{},
+	&deadletter.IDParams{},
+	&workflow.WorkflowParams{},
+	&module.PageQuery{},
+	&module.ListQuery{},
+	&module.HistoryQuery{},
+	&module.RevertQuery{},
+	&module.DependencyWalkQuery{},
+	&module.UsageQuery{},
+	&deadletter.ListQuery{},
+}
+
+// WarmUpValidators compiles the binding rules of every request body.
+//
+// The validator parses the struct tags of a type on its first use; validating
+// an empty value of each request moves that work from the first requests to
+// startup. The validation errors of the empty values are expected and ignored.
+//
+// Parameters:
+//   - ctx: Context cancelling the warm-up
+//
+// Returns:
+//   - error: The context error if the warm-up was cancelled
+func WarmUpValidators(ctx context.Context) error {
+	for _, request := range boundRequests {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		_ = binding.Validator.ValidateStruct(request)
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"go_di_architecture/internal/domain/models/response"
+	"go_di_architecture/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// respondedKey marks a request whose response was produced by Respond.
+const respondedKey = "handlers.responded"
+
+// Result is the successful outcome of a handler, rendered by Respond.
+type Result struct {
+	// Payload of the response
+	Data interface{}
+
+	// HTTP status code; zero picks 201 when Location is set, 204 when Data is
+	// nil and 200 otherwise
+	Status int
+
+	// URL of the created or accepted resource, sent as the Location header
+	Location string
+
+	// Entity tag of the returned representation, sent as the ETag header
+	ETag string
+
+	// Last change of the returned representation, sent as the Last-Modified
+	// header; zero omits it
+	LastModified time.Time
+
+	// Page details (offset pagination only)
+	Pagination *response.PaginationMeta
+
+	// Opaque cursor for the next page (keyset pagination only)
+	NextCursor string
+
+	// Requested IDs that were not found (batch lookups only)
+	MissingIds []int
+}
+
+// Respond writes the one response of a request.
+//
+// Every handler ends by calling Respond:
+//   - A non-nil err is reported with ctx.Error for the exception middleware;
+//     a *response.HTTPError is kept as is, any other error is mapped by the
+//     service error registrations in response.Errors. The request's mapper is
+//     resolved first, so the error envelope has the configured naming and
+//     clock even when the handler failed before building a response
+//   - Otherwise the status is picked, the Location and ETag headers are set,
+//     the Last-Modified header is set (whole seconds, as HTTP dates carry no
+//     fractions), the surrogate keys of the returned entities are recorded
+//     for the edge cache middleware and the payload is wrapped in the
+//     request's envelope
+//   - A GET or HEAD whose If-None-Match matches the ETag is answered with 304;
+//     without If-None-Match, so is one whose If-Modified-Since is not before
+//     the Last-Modified time (If-None-Match takes precedence, RFC 9110)
+//   - A second call for the same request is logged and ignored, so a handler
+//     can never write two bodies
+//
+// Parameters:
+//   - ctx: Gin context for the request
+//   - result: Payload and headers of a successful response
+//   - err: The error to report instead, nil on success
+func Respond(ctx *gin.Context, result Result, err error) {
+	// Step 1: Guard against a second response
+	if ctx.GetBool(respondedKey) || ctx.Writer.Written() {
+		logger.Errorf("[%s] Response already written, ignoring %s", ctx.GetString("request_id"), ctx.HandlerName())
+		return
+	}
+	ctx.Set(respondedKey, true)
+
+	// Step 2: Report errors to the exception middleware
+	if err != nil {
+		var httpErr *response.HTTPError
+		if !errors.As(err, &httpErr) {
+			httpErr = response.Errors.Map(err)
+		}
+		responseMapper(ctx)
+		ctx.Error(httpErr)
+		return
+	}
+
+	// Step 3: Pick the status and set the headers
+	statusCode := result.Status
+	switch {
+	case statusCode != 0:
+	case result.Location != "":
+		statusCode = http.StatusCreated
+	case result.Data == nil:
+		statusCode = http.StatusNoContent
+	default:
+		statusCode = http.StatusOK
+	}
+	if result.Location != "" {
+		ctx.Header("Location", result.Location)
+	}
+	middleware.AddSurrogateKeys(ctx, surrogateKeys(result.Data)...)
+	if result.ETag != "" {
+		ctx.Header("ETag", result.ETag)
+	}
+	if !result.LastModified.IsZero() {
+		ctx.Header("Last-Modified", result.LastModified.UTC().Format(http.TimeFormat))
+	}
+	if notModified(ctx.Request, result) {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+	if statusCode == http.StatusNoContent {
+		ctx.Status(statusCode)
+		return
+	}
+
+	// Step 4: Build the envelope
+	apiResponse, statusCode := responseMapper(ctx).Success(
+		result.Data,
+		response.StatusToMessage(statusCode),
+		statusCode,
+	)
+	apiResponse.Meta.Pagination = result.Pagination
+	apiResponse.Meta.NextCursor = result.NextCursor
+	apiResponse.Meta.MissingIds = result.MissingIds
+	ctx.JSON(statusCode, apiResponse)
+}
+
+// surrogateKeyed is implemented by responses naming the entities they show.
+type surrogateKeyed interface {
+	SurrogateKeys() []string
+}
+
+// surrogateKeys collects the surrogate keys of a payload or of the elements
+// of a slice payload.
+func surrogateKeys(data any) []string {
+	if keyed, ok := data.(surrogateKeyed); ok {
+		return keyed.SurrogateKeys()
+	}
+
+	value := reflect.ValueOf(data)
+	if value.Kind() != reflect.Slice {
+		return nil
+	}
+	var keys []string
+	for i := 0; i < value.Len(); i++ {
+		if keyed, ok := value.Index(i).Interface().(surrogateKeyed); ok {
+			keys = append(keys, keyed.SurrogateKeys()...)
+		}
+	}
+	return keys
+}
+
+// notModified reports whether a conditional GET or HEAD can be answered with
+// 304: If-None-Match is evaluated against the ETag when sent, otherwise
+// If-Modified-Since against the Last-Modified time. An unparsable date is
+// ignored, as RFC 9110 requires.
+func notModified(request *http.Request, result Result) bool {
+	if request.Method != http.MethodGet && request.Method != http.MethodHead {
+		return false
+	}
+	if header := request.Header.Get("If-None-Match"); header != "" {
+		return result.ETag != "" && etagMatches(header, result.ETag)
+	}
+
+	header := request.Header.Get("If-Modified-Since")
+	if header == "" || result.LastModified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(header)
+	if err != nil {
+		return false
+	}
+	return !result.LastModified.Truncate(time.Second).After(since)
+}
+
+// etagMatches reports whether an If-None-Match header lists the entity tag.
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"go_di_architecture/internal/app/container"
+	"go_di_architecture/internal/domain/models/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MapperComponent is the container name of the request-scoped response mapper.
+const MapperComponent = "response.mapper"
+
+// responseMapper returns the response mapper bound to the current request.
+//
+// The mapper is resolved from the request scope created by the request-scope
+// middleware. When no scope is attached (e.g. a handler mounted without the
+// middleware) a mapper is created directly from the request ID.
+//
+// The ?fields= query parameter (e.g. fields=id,name,isActive) is applied to the
+// mapper so every success payload is projected to the requested fields.
+//
+// The response format comes from the X-Response-Format header, falling back
+// to the route default set by the response-format middleware.
+//
+// The field naming strategy comes from the profile parameter of the Accept
+// header (e.g. "application/json; profile=snake_case"), falling back to the
+// configured default of the request-scoped mapper.
+//
+// The mapper is stored on the context, so later calls for the same request and
+// the exception middleware rendering reported errors use the same mapper.
+//
+// Parameters:
+//   - ctx: Gin context for the request
+//
+// Returns:
+//   - *response.ResponseMapper: The request-bound response mapper
+func responseMapper(ctx *gin.Context) *response.ResponseMapper {
+	if value, ok := ctx.Get(response.MapperContextKey); ok {
+		if mapper, ok := value.(*response.ResponseMapper); ok {
+			return mapper
+		}
+	}
+
+	fields := response.ParseFields(ctx.Query("fields"))
+
+	format := ctx.GetHeader(response.FormatHeader)
+	if format == "" {
+		format = ctx.GetString(response.FormatContextKey)
+	}
+
+	var mapper *response.ResponseMapper
+	if scoped, err := container.Get[*response.ResponseMapper](ctx.Request.Context(), MapperComponent); err == nil {
+		mapper = scoped
+	}
+	if mapper == nil {
+		mapper = response.NewResponseMapper(ctx.GetString("request_id"))
+	}
+
+	if naming := response.NamingFromAccept(ctx.GetHeader("Accept")); naming != "" {
+		mapper.UseNaming(naming)
+	}
+	mapper.SelectFields(fields).UseFormat(format)
+
+	// Errors reported with ctx.Error are rendered with the same mapper
+	ctx.Set(response.MapperContextKey, mapper)
+	return mapper
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"go_di_architecture/internal/app/jobs"
+	"go_di_architecture/internal/domain/models/response"
+	"go_di_architecture/internal/domain/models/retention"
+	retentionService "go_di_architecture/internal/domain/service/retention"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RetentionHandler exposes the data retention policy to operators.
+//
+// Scheduled runs are driven by the retention scheduler; this handler reports
+// on them, previews the rules and starts manual runs as background jobs.
+type RetentionHandler struct {
+	service *retentionService.RetentionService
+	jobs    *jobs.Runner
+}
+
+// NewRetentionHandler creates a new instance of RetentionHandler.
+//
+// Parameters:
+//   - service: Business service applying the retention rules
+//   - runner: Background job runner executing manual runs
+//
+// Returns:
+//   - *RetentionHandler: A new handler instance
+func NewRetentionHandler(service *retentionService.RetentionService, runner *jobs.Runner) *RetentionHandler {
+	return &RetentionHandler{service: service, jobs: runner}
+}
+
+// Routes returns the data retention routes.
+func (h *RetentionHandler) Routes() []RouteSpec {
+	return []RouteSpec{
+		{Group: GroupAdmin, Method: http.MethodGet, Path: "/retention", Handler: h.GetRetention, Response: retention.Status{}},                             // GET /admin/retention
+		{Group: GroupAdmin, Method: http.MethodGet, Path: "/retention/preview", Handler: h.PreviewRetention, Response: retention.Report{}},                 // GET /admin/retention/preview
+		{Group: GroupAdmin, Method: http.MethodPost, Path: "/retention/run", Handler: h.StartRetention, Response: jobs.Job{}, Status: http.StatusAccepted}, // POST /admin/retention/run
+	}
+}
+
+// GetRetention godoc
+// @Summary Get the data retention policy
+// @Description Returns the configured retention rules, whether runs are dry runs, the run interval and the report of the last scheduled or manual run.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} response.APIResponse{data=retention.Status} "Retention policy"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Security ApiKeyAuth[admin] || BearerAuth[admin]
+// @Router /admin/retention [get]
+//
+// Sample Request:
+//
+//	GET /admin/retention
+func (h *RetentionHandler) GetRetention(ctx *gin.Context) {
+	Respond(ctx, Result{Data: h.service.Status()}, nil)
+}
+
+// PreviewRetention godoc
+// @Summary Preview the data retention rules
+// @Description Reports per rule how many revisions would be purged and which modules would be archived, without changing anything.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} response.APIResponse{data=retention.Report} "Dry-run report"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 500 {object} response.APIResponse "A rule failed; the details name the failed rules"
+// @Security ApiKeyAuth[admin] || BearerAuth[admin]
+// @Router /admin/retention/preview [get]
+//
+// Sample Request:
+//
+//	GET /admin/retention/preview
+func (h *RetentionHandler) PreviewRetention(ctx *gin.Context) {
+	report, err := h.service.Preview(ctx.Request.Context(), time.Now())
+	if err != nil {
+		details := make(map[string][]string)
+		for _, rule := range report.Rules {
+			if rule.Error != "" {
+				details[rule.Rule] = []string{rule.Error}
+			}
+		}
+		Respond(ctx, Result{}, response.NewHTTPError(http.StatusInternalServerError, "INTERNAL_ERROR", details))
+		return
+	}
+
+	Respond(ctx, Result{Data: report}, nil)
+}
+
+// StartRetention godoc
+// @Summary Run the data retention rules
+// @Description Queues a job applying the retention rules now instead of waiting for the next scheduled run. In dry-run mode the job only reports what would change. The job result is the run report, which also becomes the last run of GET /admin/retention.
+// @Tags admin
+// @Produce json
+// @Success 202 {object} response.APIResponse{data=jobs.Job} "Queued retention job"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Security ApiKeyAuth[admin] || BearerAuth[admin]
+// @Router /admin/retention/run [post]
+//
+// Sample Request:
+//
+//	POST /admin/retention/run
+func (h *RetentionHandler) StartRetention(ctx *gin.Context) {
+	job := h.jobs.Submit(retentionService.JobKind, func(jobCtx context.Context) (any, error) {
+		return h.service.Apply(jobCtx, time.Now())
+	})
+
+	Respond(ctx, Result{Data: job, Status: http.StatusAccepted, Location: "/api/v1/jobs/" + job.ID}, nil)
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"go_di_architecture/internal/app/activity"
+	"go_di_architecture/internal/app/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Route groups the handlers mount their routes in
+const (
+	// GroupAPI is the versioned API under /api/v1; its routes are counted
+	// against API key quotas and module usage and may be cached at the edge
+	GroupAPI = "api"
+
+	// GroupRoot is the root of the engine, for routes outside the versioned
+	// API middleware (e.g. the account routes, not counted against quotas)
+	GroupRoot = "root"
+
+	// GroupAdmin holds the operational routes under /admin; every route of
+	// the group requires the admin scope
+	GroupAdmin = "admin"
+
+	// GroupPublic is the unauthenticated read-only API under /public/v1,
+	// rate limited per client address; it is only mounted when the public
+	// API is enabled
+	GroupPublic = "public"
+)
+
+// Policies of the routes
+var (
+	// authenticated routes only require a principal
+	authenticated = &auth.Policy{}
+
+	readScope    = &auth.Policy{Scopes: []string{auth.ScopeModulesRead}}
+	writeScope   = &auth.Policy{Scopes: []string{auth.ScopeModulesWrite}}
+	approveScope = &auth.Policy{Scopes: []string{auth.ScopeModulesApprove}}
+	adminScope   = &auth.Policy{Scopes: []string{auth.ScopeAdmin}}
+)
+
+// Objectives of routes not served by activity.DefaultObjective
+var (
+	// streamed responses take as long as their content, so only server
+	// errors count against them
+	streamObjective = &activity.Objective{AvailabilityTarget: activity.DefaultObjective.AvailabilityTarget}
+)
+
+// RouteSpec describes one route of a handler.
+//
+// Besides registering the route, the spec feeds the OpenAPI document served
+// at /openapi.json: its path, policy and the optional Summary, Body, Query,
+// Response and Status fields describe the operation, so the document follows
+// the registered routes.
+type RouteSpec struct {
+	// Group the route is mounted in (GroupAPI when empty)
+	Group string
+
+	// HTTP method (e.g. http.MethodGet)
+	Method string
+
+	// Path relative to the group, with gin parameters (e.g. "/modules/:id")
+	Path string
+
+	// Scopes the caller must hold; nil registers no policy (public routes,
+	// signed download links, and the admin group, which has its own)
+	Policy *auth.Policy
+
+	// Middleware running after the policy and before the handler
+	Middleware []gin.HandlerFunc
+
+	// Handler serving the route
+	Handler gin.HandlerFunc
+
+	// Short description in the OpenAPI document (derived from the name of
+	// the handler method when empty, e.g. ListModules -> "List modules")
+	Summary string
+
+	// Value of the request body type, e.g. module.ModuleRequest{} (optional)
+	Body any
+
+	// Value of the struct binding the query parameters, e.g.
+	// module.ListQuery{} (optional)
+	Query any
+
+	// Value of the data payload of the success response, e.g.
+	// module.ModuleResponse{} (optional)
+	Response any
+
+	// Status of the success response in the OpenAPI document (200 when zero)
+	Status int
+
+	// Service level objective the route is tracked against (see
+	// GET /admin/api/objectives); routes of the versioned and public API
+	// without one get activity.DefaultObjective, other routes are not tracked
+	Objective *activity.Objective
+}
+
+// Routable is implemented by handlers that serve HTTP routes.
+//
+// The router registers the routes of every routable handler of the
+// container (see container.ResolveTagged), so adding a handler needs no
+// router change: the handler declares its paths, policies and middleware
+// next to the methods serving them.
+type Routable interface {
+	// Routes returns the routes of the handler
+	Routes() []RouteSpec
+}
@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"go_di_architecture/internal/domain/models/module"
+	"go_di_architecture/internal/domain/models/response"
+	settingService "go_di_architecture/internal/domain/service/setting"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SettingHandler handles HTTP requests for per-module runtime configuration.
+//
+// Settings are a JSON object of typed values; each key is validated against
+// the JSON schema registered for it, which clients can fetch from the schema
+// endpoint.
+type SettingHandler struct {
+	service *settingService.SettingService
+}
+
+// NewSettingHandler creates a new instance of SettingHandler.
+//
+// Parameters:
+//   - service: Business service handling setting operations
+//
+// Returns:
+//   - *SettingHandler: A new handler instance
+func NewSettingHandler(service *settingService.SettingService) *SettingHandler {
+	return &SettingHandler{service: service}
+}
+
+// Routes returns the module setting routes.
+func (h *SettingHandler) Routes() []RouteSpec {
+	return []RouteSpec{
+		{Method: http.MethodGet, Path: "/modules/:id/settings", Policy: readScope, Handler: h.GetSettings},                                     // GET /api/v1/modules/{id}/settings
+		{Method: http.MethodPut, Path: "/modules/:id/settings", Policy: writeScope, Handler: h.ReplaceSettings, Body: module.ModuleSettings{}}, // PUT /api/v1/modules/{id}/settings
+		{Method: http.MethodGet, Path: "/settings/schemas", Policy: readScope, Handler: h.ListSchemas},                                         // GET /api/v1/settings/schemas
+	}
+}
+
+// GetSettings godoc
+// @Summary Get module settings
+// @Description Returns the runtime configuration of a module as a JSON object keyed by setting key
+// @Tags settings
+// @Produce json
+// @Param id path int true "Module ID"
+// @Success 200 {object} response.APIResponse{data=object} "Module settings"
+// @Failure 400 {object} response.APIResponse "Invalid module ID"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 404 {object} response.APIResponse "Module not found"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[modules:read] || BearerAuth[modules:read]
+// @Router /modules/{id}/settings [get]
+//
+// Sample Request:
+//
+//	GET /api/v1/modules/1/settings
+//
+// Sample Success Response (200):
+//
+//	{
+//	  "success": true,
+//	  "message": "Operation completed successfully",
+//	  "data": {
+//	    "logLevel": "debug",
+//	    "maxConnections": 20
+//	  },
+//	  "meta": {
+//	    "requestId": "a1b2c3d4",
+//	    "timestamp": "2023-08-15T14:30:00Z"
+//	  }
+//	}
+func (h *SettingHandler) GetSettings(ctx *gin.Context) {
+	var params module.IDParams
+	if !bindPath(ctx, &params) {
+		return
+	}
+
+	settings, err := h.service.GetSettings(params.Key(), requestSubject(ctx))
+	if err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	Respond(ctx, Result{Data: settings}, nil)
+}
+
+// ReplaceSettings godoc
+// @Summary Replace module settings
+// @Description Replaces the complete settings document of a module. Every key must be known and every value must satisfy the JSON schema of its key; keys left out are removed. All violations are reported at once, keyed by setting.
+// @Tags settings
+// @Accept json
+// @Produce json
+// @Param id path int true "Module ID"
+// @Param request body object true "Settings keyed by setting key"
+// @Success 200 {object} response.APIResponse{data=object} "Stored settings"
+// @Failure 400 {object} response.APIResponse "Validation error"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 404 {object} response.APIResponse "Module not found"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[modules:write] || BearerAuth[modules:write]
+// @Router /modules/{id}/settings [put]
+//
+// Sample Request:
+//
+//	PUT /api/v1/modules/1/settings
+//	{
+//	  "logLevel": "verbose",
+//	  "maxConnections": 0
+//	}
+//
+// Sample Validation Error Response (400):
+//
+//	{
+//	  "success": false,
+//	  "message": "Invalid request parameters",
+//	  "error": {
+//	    "code": "VALIDATION_ERROR",
+//	    "message": "Invalid request parameters",
+//	    "details": {
+//	      "logLevel": ["/: value must be one of 'debug', 'info', 'warn', 'error'"],
+//	      "maxConnections": ["/: minimum: got 0, want 1"]
+//	    }
+//	  },
+//	  "meta": {
+//	    "requestId": "a1b2c3d4",
+//	    "timestamp": "2023-08-15T14:30:00Z"
+//	  }
+//	}
+func (h *SettingHandler) ReplaceSettings(ctx *gin.Context) {
+	var params module.IDParams
+	if !bindPath(ctx, &params) {
+		return
+	}
+
+	// Step 1: Decode the settings object
+	var request module.ModuleSettings
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		Respond(ctx, Result{}, response.NewHTTPError(http.StatusBadRequest, "VALIDATION_ERROR", map[string][]string{"body": {"Request body must be a JSON object of settings"}}))
+		return
+	}
+
+	// Step 2: Validate and store the settings
+	settings, err := h.service.ReplaceSettings(params.Key(), requestSubject(ctx), request)
+	if err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	// Step 3: Return the stored settings
+	Respond(ctx, Result{Data: settings}, nil)
+}
+
+// ListSchemas godoc
+// @Summary List setting schemas
+// @Description Returns the JSON schema of every supported setting key so clients can validate values before sending them
+// @Tags settings
+// @Produce json
+// @Success 200 {object} response.APIResponse{data=object} "JSON schemas keyed by setting key"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Security ApiKeyAuth[modules:read] || BearerAuth[modules:read]
+// @Router /settings/schemas [get]
+//
+// Sample Request:
+//
+//	GET /api/v1/settings/schemas
+func (h *SettingHandler) ListSchemas(ctx *gin.Context) {
+	Respond(ctx, Result{Data: h.service.Schemas()}, nil)
+}
+
+// init registers the response details of the setting service errors.
+func init() {
+	// Report the violations of every invalid key
+	response.Errors.Enrich(settingService.ErrInvalidSettings, func(err error, httpErr *response.HTTPError) {
+		var invalid *settingService.ValidationError
+		if errors.As(err, &invalid) {
+			httpErr.Details = invalid.Fields
+		}
+	})
+}
@@ -0,0 +1,222 @@
+package handlers
+
+import (
+	"net/http"
+
+	"go_di_architecture/internal/domain/models/module"
+	"go_di_architecture/internal/domain/models/response"
+	"go_di_architecture/internal/domain/models/tag"
+	tagService "go_di_architecture/internal/domain/service/tag"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TagHandler handles HTTP requests for tags and their module assignments.
+//
+// Tags are addressed by name in every route; names are case-insensitive and
+// normalized to lower case by the service.
+type TagHandler struct {
+	service *tagService.TagService
+}
+
+// NewTagHandler creates a new instance of TagHandler.
+//
+// Parameters:
+//   - service: Business service handling tag operations
+//
+// Returns:
+//   - *TagHandler: A new handler instance
+func NewTagHandler(service *tagService.TagService) *TagHandler {
+	return &TagHandler{service: service}
+}
+
+// Routes returns the tag and module tag assignment routes.
+func (h *TagHandler) Routes() []RouteSpec {
+	return []RouteSpec{
+		// Tag collection endpoints
+		{Method: http.MethodGet, Path: "/tags", Policy: readScope, Handler: h.ListTags, Response: []tag.TagResponse{}},                                                      // GET /api/v1/tags
+		{Method: http.MethodPost, Path: "/tags", Policy: writeScope, Handler: h.CreateTag, Body: tag.TagRequest{}, Response: tag.TagResponse{}, Status: http.StatusCreated}, // POST /api/v1/tags
+		{Method: http.MethodDelete, Path: "/tags/:name", Policy: writeScope, Handler: h.DeleteTag, Status: http.StatusNoContent},                                            // DELETE /api/v1/tags/{name}
+
+		// Module tag assignment endpoints
+		{Method: http.MethodGet, Path: "/modules/:id/tags", Policy: readScope, Handler: h.ListModuleTags, Response: []tag.TagResponse{}},        // GET /api/v1/modules/{id}/tags
+		{Method: http.MethodPut, Path: "/modules/:id/tags/:name", Policy: writeScope, Handler: h.AssignTag, Response: []tag.TagResponse{}},      // PUT /api/v1/modules/{id}/tags/{name}
+		{Method: http.MethodDelete, Path: "/modules/:id/tags/:name", Policy: writeScope, Handler: h.UnassignTag, Response: []tag.TagResponse{}}, // DELETE /api/v1/modules/{id}/tags/{name}
+	}
+}
+
+// CreateTag godoc
+// @Summary Create a new tag
+// @Description Creates a tag that can be attached to modules. Names are normalized to lower case.
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param request body tag.TagRequest true "Tag creation payload"
+// @Success 201 {object} response.APIResponse{data=tag.TagResponse} "Tag created successfully"
+// @Failure 400 {object} response.APIResponse "Validation error"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 409 {object} response.APIResponse "Tag already exists"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[modules:write] || BearerAuth[modules:write]
+// @Router /tags [post]
+//
+// Sample Request:
+//
+//	POST /api/v1/tags
+//	{
+//	  "name": "Backend"
+//	}
+func (h *TagHandler) CreateTag(ctx *gin.Context) {
+	// Step 1: Validate request payload
+	var request tag.TagRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		Respond(ctx, Result{}, response.NewHTTPError(http.StatusBadRequest, "VALIDATION_ERROR", extractValidationErrors(ctx, err)))
+		return
+	}
+
+	// Step 2: Execute business logic
+	created, err := h.service.CreateTag(request)
+	if err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	// Step 3: Return the created tag
+	Respond(ctx, Result{Data: created, Location: "/api/v1/tags/" + created.Name}, nil)
+}
+
+// ListTags godoc
+// @Summary List tags
+// @Description Lists every tag ordered by name
+// @Tags tags
+// @Produce json
+// @Success 200 {object} response.APIResponse{data=[]tag.TagResponse} "Tags retrieved successfully"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[modules:read] || BearerAuth[modules:read]
+// @Router /tags [get]
+func (h *TagHandler) ListTags(ctx *gin.Context) {
+	tags, err := h.service.ListTags()
+	if err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	Respond(ctx, Result{Data: tags}, nil)
+}
+
+// DeleteTag godoc
+// @Summary Delete a tag
+// @Description Deletes a tag and detaches it from every module
+// @Tags tags
+// @Param name path string true "Tag name"
+// @Success 204 "Tag deleted"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 404 {object} response.APIResponse "Tag not found"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[modules:write] || BearerAuth[modules:write]
+// @Router /tags/{name} [delete]
+func (h *TagHandler) DeleteTag(ctx *gin.Context) {
+	if err := h.service.DeleteTag(ctx.Param("name")); err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	Respond(ctx, Result{}, nil)
+}
+
+// ListModuleTags godoc
+// @Summary List the tags of a module
+// @Description Lists the tags attached to a module ordered by name
+// @Tags tags
+// @Produce json
+// @Param id path int true "Module ID"
+// @Success 200 {object} response.APIResponse{data=[]tag.TagResponse} "Module tags"
+// @Failure 400 {object} response.APIResponse "Invalid module ID"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 404 {object} response.APIResponse "Module not found"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[modules:read] || BearerAuth[modules:read]
+// @Router /modules/{id}/tags [get]
+func (h *TagHandler) ListModuleTags(ctx *gin.Context) {
+	var params module.IDParams
+	if !bindPath(ctx, &params) {
+		return
+	}
+
+	tags, err := h.service.ListModuleTags(params.Key(), requestSubject(ctx))
+	if err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	Respond(ctx, Result{Data: tags}, nil)
+}
+
+// AssignTag godoc
+// @Summary Attach a tag to a module
+// @Description Attaches an existing tag to a module; attaching an already attached tag is a no-op
+// @Tags tags
+// @Produce json
+// @Param id path int true "Module ID"
+// @Param name path string true "Tag name"
+// @Success 200 {object} response.APIResponse{data=[]tag.TagResponse} "Module tags after the change"
+// @Failure 400 {object} response.APIResponse "Invalid module ID"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 404 {object} response.APIResponse "Module or tag not found"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[modules:write] || BearerAuth[modules:write]
+// @Router /modules/{id}/tags/{name} [put]
+//
+// Sample Request:
+//
+//	PUT /api/v1/modules/123/tags/backend
+func (h *TagHandler) AssignTag(ctx *gin.Context) {
+	var params module.IDParams
+	if !bindPath(ctx, &params) {
+		return
+	}
+
+	tags, err := h.service.AssignTag(params.Key(), requestSubject(ctx), ctx.Param("name"))
+	if err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	Respond(ctx, Result{Data: tags}, nil)
+}
+
+// UnassignTag godoc
+// @Summary Detach a tag from a module
+// @Description Detaches a tag from a module; detaching a tag that is not attached is a no-op
+// @Tags tags
+// @Produce json
+// @Param id path int true "Module ID"
+// @Param name path string true "Tag name"
+// @Success 200 {object} response.APIResponse{data=[]tag.TagResponse} "Module tags after the change"
+// @Failure 400 {object} response.APIResponse "Invalid module ID"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 404 {object} response.APIResponse "Module or tag not found"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[modules:write] || BearerAuth[modules:write]
+// @Router /modules/{id}/tags/{name} [delete]
+func (h *TagHandler) UnassignTag(ctx *gin.Context) {
+	var params module.IDParams
+	if !bindPath(ctx, &params) {
+		return
+	}
+
+	tags, err := h.service.UnassignTag(params.Key(), requestSubject(ctx), ctx.Param("name"))
+	if err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	Respond(ctx, Result{Data: tags}, nil)
+}
@@ -0,0 +1,238 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"go_di_architecture/internal/domain/models/module"
+	"go_di_architecture/internal/domain/models/response"
+	"go_di_architecture/internal/domain/models/template"
+	templateService "go_di_architecture/internal/domain/service/template"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TemplateHandler handles HTTP requests for the module template catalogue.
+//
+// Templates pre-fill the name, description and settings of new modules.
+// Administrators maintain the catalogue; anyone allowed to create modules can
+// create one from a template.
+type TemplateHandler struct {
+	service *templateService.TemplateService
+}
+
+// NewTemplateHandler creates a new instance of TemplateHandler.
+//
+// Parameters:
+//   - service: Business service handling template operations
+//
+// Returns:
+//   - *TemplateHandler: A new handler instance
+func NewTemplateHandler(service *templateService.TemplateService) *TemplateHandler {
+	return &TemplateHandler{service: service}
+}
+
+// Routes returns the module template routes.
+func (h *TemplateHandler) Routes() []RouteSpec {
+	return []RouteSpec{
+		{Method: http.MethodGet, Path: "/module-templates", Policy: readScope, Handler: h.ListTemplates, Response: []template.TemplateResponse{}},                                                                // GET /api/v1/module-templates
+		{Method: http.MethodPost, Path: "/module-templates", Policy: adminScope, Handler: h.CreateTemplate, Body: template.TemplateRequest{}, Response: template.TemplateResponse{}, Status: http.StatusCreated}, // POST /api/v1/module-templates
+		{Method: http.MethodGet, Path: "/module-templates/:templateId", Policy: readScope, Handler: h.GetTemplate, Response: template.TemplateResponse{}},                                                        // GET /api/v1/module-templates/{templateId}
+		{Method: http.MethodDelete, Path: "/module-templates/:templateId", Policy: adminScope, Handler: h.DeleteTemplate, Status: http.StatusNoContent},                                                          // DELETE /api/v1/module-templates/{templateId}
+
+		{Method: http.MethodPost, Path: "/modules/from-template/:templateId", Policy: writeScope, Handler: h.InstantiateTemplate, Body: template.InstantiateRequest{}, Response: module.ModuleResponse{}, Status: http.StatusCreated}, // POST /api/v1/modules/from-template/{templateId}
+	}
+}
+
+// ListTemplates godoc
+// @Summary List module templates
+// @Description Returns the template catalogue ordered by name. placeholders lists the variables the name pattern needs besides the built-in {date} and {actor}.
+// @Tags templates
+// @Produce json
+// @Success 200 {object} response.APIResponse{data=[]template.TemplateResponse} "Templates"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[modules:read] || BearerAuth[modules:read]
+// @Router /module-templates [get]
+//
+// Sample Request:
+//
+//	GET /api/v1/module-templates
+//
+// Sample Success Response (200):
+//
+//	{
+//	  "success": true,
+//	  "message": "Operation completed successfully",
+//	  "data": [
+//	    {"id": 3, "name": "warehouse-sync", "namePattern": "sync-{warehouse}-{date}",
+//	     "placeholders": ["warehouse"], "description": "Nightly stock synchronization",
+//	     "settings": {"batchSize": 500}, "createdAt": "2023-08-15T14:30:00Z"}
+//	  ],
+//	  "meta": {"requestId": "a1b2c3d4", "timestamp": "2023-08-15T14:35:00Z"}
+//	}
+func (h *TemplateHandler) ListTemplates(ctx *gin.Context) {
+	templates, err := h.service.ListTemplates()
+	if err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	Respond(ctx, Result{Data: templates}, nil)
+}
+
+// GetTemplate godoc
+// @Summary Get a module template
+// @Tags templates
+// @Produce json
+// @Param templateId path int true "Template ID"
+// @Success 200 {object} response.APIResponse{data=template.TemplateResponse} "Template"
+// @Failure 400 {object} response.APIResponse "Invalid template ID"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 404 {object} response.APIResponse "Template not found"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[modules:read] || BearerAuth[modules:read]
+// @Router /module-templates/{templateId} [get]
+func (h *TemplateHandler) GetTemplate(ctx *gin.Context) {
+	var params template.TemplateParams
+	if !bindPath(ctx, &params) {
+		return
+	}
+
+	found, err := h.service.GetTemplate(params.Key())
+	if err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	Respond(ctx, Result{Data: found}, nil)
+}
+
+// CreateTemplate godoc
+// @Summary Add a module template
+// @Description Adds a template to the catalogue. The name pattern may contain {placeholders}: a letter followed by letters, digits or underscores. {date} (UTC, YYYY-MM-DD) and {actor} are filled in automatically; the others are supplied when a module is created. Settings must satisfy the registered setting schemas.
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Param request body template.TemplateRequest true "Template"
+// @Success 201 {object} response.APIResponse{data=template.TemplateResponse} "Created template"
+// @Failure 400 {object} response.APIResponse "Validation error or invalid settings"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 409 {object} response.APIResponse "Template name already exists"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[admin] || BearerAuth[admin]
+// @Router /module-templates [post]
+//
+// Sample Request:
+//
+//	POST /api/v1/module-templates
+//	{
+//	  "name": "warehouse-sync",
+//	  "namePattern": "sync-{warehouse}-{date}",
+//	  "description": "Nightly stock synchronization",
+//	  "settings": {"batchSize": 500}
+//	}
+func (h *TemplateHandler) CreateTemplate(ctx *gin.Context) {
+	var request template.TemplateRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		Respond(ctx, Result{}, response.NewHTTPError(http.StatusBadRequest, "VALIDATION_ERROR", extractValidationErrors(ctx, err)))
+		return
+	}
+
+	created, err := h.service.CreateTemplate(request)
+	if err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	Respond(ctx, Result{
+		Data:     created,
+		Status:   http.StatusCreated,
+		Location: "/api/v1/module-templates/" + strconv.Itoa(created.ID),
+	}, nil)
+}
+
+// DeleteTemplate godoc
+// @Summary Delete a module template
+// @Description Removes a template from the catalogue. Modules created from it are not affected.
+// @Tags templates
+// @Param templateId path int true "Template ID"
+// @Success 204 "Template deleted"
+// @Failure 400 {object} response.APIResponse "Invalid template ID"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 404 {object} response.APIResponse "Template not found"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[admin] || BearerAuth[admin]
+// @Router /module-templates/{templateId} [delete]
+func (h *TemplateHandler) DeleteTemplate(ctx *gin.Context) {
+	var params template.TemplateParams
+	if !bindPath(ctx, &params) {
+		return
+	}
+
+	if err := h.service.DeleteTemplate(params.Key()); err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	Respond(ctx, Result{}, nil)
+}
+
+// InstantiateTemplate godoc
+// @Summary Create a module from a template
+// @Description Creates a draft module named after the template's name pattern, with the template description and settings. Variables fill in the placeholders of the pattern; {date} and {actor} are built in. The module is checked like any new module, e.g. the resolved name must be unique.
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Param templateId path int true "Template ID"
+// @Param request body template.InstantiateRequest false "Placeholder values"
+// @Param X-Actor header string false "Owner of the new module and value of {actor}"
+// @Success 201 {object} response.APIResponse{data=module.ModuleResponse} "Created module"
+// @Failure 400 {object} response.APIResponse "Missing variables, invalid resolved name or invalid template settings"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 404 {object} response.APIResponse "Template not found"
+// @Failure 409 {object} response.APIResponse "Module name already exists"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[modules:write] || BearerAuth[modules:write]
+// @Router /modules/from-template/{templateId} [post]
+//
+// Sample Request:
+//
+//	POST /api/v1/modules/from-template/3
+//	X-Actor: jane
+//	{
+//	  "variables": {"warehouse": "berlin"}
+//	}
+func (h *TemplateHandler) InstantiateTemplate(ctx *gin.Context) {
+	// Step 1: Bind the template ID and the optional variables
+	var params template.TemplateParams
+	var request template.InstantiateRequest
+	if !bindPath(ctx, &params) {
+		return
+	}
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			Respond(ctx, Result{}, response.NewHTTPError(http.StatusBadRequest, "VALIDATION_ERROR", extractValidationErrors(ctx, err)))
+			return
+		}
+	}
+
+	// Step 2: Create the module
+	created, err := h.service.Instantiate(params.Key(), request, requestActor(ctx))
+	if err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	Respond(ctx, Result{
+		Data:     created,
+		Status:   http.StatusCreated,
+		Location: "/api/v1/modules/" + strconv.Itoa(created.ID),
+		ETag:     moduleETag(created),
+	}, nil)
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"go_di_architecture/internal/domain/models/module"
+	usageService "go_di_architecture/internal/domain/service/usage"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UsageHandler handles HTTP requests for module usage counters.
+//
+// Requests are counted by middleware.UsageHandler; this handler only reports
+// the counts.
+type UsageHandler struct {
+	service *usageService.UsageService
+}
+
+// NewUsageHandler creates a new instance of UsageHandler.
+//
+// Parameters:
+//   - service: Business service counting module usage
+//
+// Returns:
+//   - *UsageHandler: A new handler instance
+func NewUsageHandler(service *usageService.UsageService) *UsageHandler {
+	return &UsageHandler{service: service}
+}
+
+// Routes returns the module usage routes.
+func (h *UsageHandler) Routes() []RouteSpec {
+	return []RouteSpec{
+		{Method: http.MethodGet, Path: "/modules/:id/usage", Policy: readScope, Handler: h.GetModuleUsage, Query: module.UsageQuery{}, Response: module.ModuleUsageResponse{}}, // GET /api/v1/modules/{id}/usage?days={n}
+	}
+}
+
+// GetModuleUsage godoc
+// @Summary Get module usage
+// @Description Returns the number of successful API reads (GET, HEAD) and writes addressing a module per UTC day, ending with the current day. Days without requests are listed with zero counts. Counters are anonymous and include requests not yet written to the database.
+// @Tags modules
+// @Produce json
+// @Param id path int true "Module ID"
+// @Param days query int false "Number of days (1-366)" default(30)
+// @Success 200 {object} response.APIResponse{data=module.ModuleUsageResponse} "Module usage"
+// @Failure 400 {object} response.APIResponse "Invalid parameters"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Permission denied"
+// @Failure 404 {object} response.APIResponse "Module not found"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[modules:read] || BearerAuth[modules:read]
+// @Router /modules/{id}/usage [get]
+//
+// Sample Request:
+//
+//	GET /api/v1/modules/1/usage?days=7
+func (h *UsageHandler) GetModuleUsage(ctx *gin.Context) {
+	// Step 1: Parse the module ID and range
+	var params module.IDParams
+	var query module.UsageQuery
+	if !bindPath(ctx, &params) || !bindQuery(ctx, &query) {
+		return
+	}
+
+	// Step 2: Build the report
+	report, err := h.service.GetModuleUsage(params.Key(), requestSubject(ctx), query.Days, time.Now())
+	if err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	Respond(ctx, Result{Data: report}, nil)
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"net/http"
+
+	"go_di_architecture/internal/app/saga"
+	"go_di_architecture/internal/domain/models/response"
+	"go_di_architecture/internal/domain/models/workflow"
+	workflowService "go_di_architecture/internal/domain/service/workflow"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WorkflowHandler starts multi-step workflows and reports their progress.
+//
+// Workflows run in the background; the start routes answer 202 with the
+// workflow, whose URL clients poll until it is completed or compensated.
+type WorkflowHandler struct {
+	orchestrator *saga.Orchestrator
+	service      *workflowService.WorkflowService
+}
+
+// NewWorkflowHandler creates a new instance of WorkflowHandler.
+//
+// Parameters:
+//   - orchestrator: Orchestrator running the workflows in the background
+//   - service: Business service reading the workflow states
+//
+// Returns:
+//   - *WorkflowHandler: A new handler instance
+func NewWorkflowHandler(orchestrator *saga.Orchestrator, service *workflowService.WorkflowService) *WorkflowHandler {
+	return &WorkflowHandler{orchestrator: orchestrator, service: service}
+}
+
+// Routes returns the module provisioning and workflow routes.
+func (h *WorkflowHandler) Routes() []RouteSpec {
+	return []RouteSpec{
+		{Method: http.MethodPost, Path: "/modules/provision", Policy: writeScope, Handler: h.ProvisionModule, Body: workflow.ProvisionRequest{}, Response: workflow.WorkflowResponse{}, Status: http.StatusAccepted}, // POST /api/v1/modules/provision
+		{Method: http.MethodGet, Path: "/workflows/:id", Policy: readScope, Handler: h.GetWorkflow, Response: workflow.WorkflowResponse{}},                                                                           // GET /api/v1/workflows/{id}
+	}
+}
+
+// ProvisionModule godoc
+// @Summary Provision a module with its platform resources
+// @Description Starts a workflow that creates the module as a draft owned by the caller, allocates the resources of the plan on the provisioning service and notifies the caller. When a step fails, the completed steps are undone in reverse order (the allocation is released, the module deleted and purged) and the workflow ends compensated. Poll the workflow URL of the Location header for the outcome.
+// @Tags modules
+// @Accept json
+// @Produce json
+// @Param request body workflow.ProvisionRequest true "Module and resource plan"
+// @Success 202 {object} response.APIResponse{data=workflow.WorkflowResponse} "Started workflow"
+// @Failure 400 {object} response.APIResponse "Validation error"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 500 {object} response.APIResponse "Internal server error"
+// @Security ApiKeyAuth[modules:write] || BearerAuth[modules:write]
+// @Router /modules/provision [post]
+//
+// Sample Request:
+//
+//	POST /api/v1/modules/provision
+//	{"name": "Inventory", "description": "Manages stock levels", "plan": "small"}
+func (h *WorkflowHandler) ProvisionModule(ctx *gin.Context) {
+	var request workflow.ProvisionRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		Respond(ctx, Result{}, response.NewHTTPError(http.StatusBadRequest, "VALIDATION_ERROR", extractValidationErrors(ctx, err)))
+		return
+	}
+
+	data := workflowService.Data{
+		workflowService.KeyName:        request.Name,
+		workflowService.KeyDescription: request.Description,
+		workflowService.KeyPlan:        request.Plan,
+	}
+	started, err := h.orchestrator.Start(workflow.KindProvisionModule, requestActor(ctx), data)
+	if err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	Respond(ctx, Result{Data: started, Status: http.StatusAccepted, Location: "/api/v1/workflows/" + started.ID}, nil)
+}
+
+// GetWorkflow godoc
+// @Summary Get the state of a workflow
+// @Description Returns the status of the workflow, the state of each step, the values the steps recorded (e.g. the created module ID) and the failure reason if a step or a compensation failed.
+// @Tags modules
+// @Produce json
+// @Param id path string true "Workflow ID (UUID)"
+// @Success 200 {object} response.APIResponse{data=workflow.WorkflowResponse} "Workflow"
+// @Failure 400 {object} response.APIResponse "Invalid workflow ID"
+// @Failure 401 {object} response.APIResponse "Authentication required"
+// @Failure 403 {object} response.APIResponse "Missing scope"
+// @Failure 404 {object} response.APIResponse "Workflow not found"
+// @Security ApiKeyAuth[modules:read] || BearerAuth[modules:read]
+// @Router /workflows/{id} [get]
+//
+// Sample Request:
+//
+//	GET /api/v1/workflows/0190f5c4-3b1e-7c51-9a51-4be6d1c7a8f2
+func (h *WorkflowHandler) GetWorkflow(ctx *gin.Context) {
+	var params workflow.WorkflowParams
+	if !bindPath(ctx, &params) {
+		return
+	}
+
+	found, err := h.service.GetWorkflow(params.ID)
+	if err != nil {
+		Respond(ctx, Result{}, err)
+		return
+	}
+
+	Respond(ctx, Result{Data: found}, nil)
+}
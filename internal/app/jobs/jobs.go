@@ -0,0 +1,301 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go_di_architecture/internal/app/lifecycle"
+	"go_di_architecture/internal/domain/models/dashboard"
+	"go_di_architecture/internal/logging"
+
+	"github.com/google/uuid"
+)
+
+// logger writes the log lines of the job runner.
+var logger = logging.New("jobs")
+
+// Job states
+const (
+	// StatusQueued marks a job waiting for a free worker
+	StatusQueued = "queued"
+
+	// StatusRunning marks a job being executed
+	StatusRunning = "running"
+
+	// StatusSucceeded marks a job that finished with a result
+	StatusSucceeded = "succeeded"
+
+	// StatusFailed marks a job that returned an error or was cancelled by shutdown
+	StatusFailed = "failed"
+)
+
+// Runner limits and retention defaults
+const (
+	// DefaultConcurrency is the number of jobs executed at the same time
+	DefaultConcurrency = 2
+
+	// Retention is how long finished jobs stay queryable
+	Retention = 24 * time.Hour
+)
+
+// Job is the status of a background job as reported by the jobs API.
+//
+// Example:
+//
+//	{
+//	  "id": "0190f5c4-3b1e-7c51-9a51-4be6d1c7a8f2",
+//	  "kind": "module.export",
+//	  "status": "succeeded",
+//	  "createdAt": "2023-08-15T14:30:00Z",
+//	  "startedAt": "2023-08-15T14:30:00Z",
+//	  "finishedAt": "2023-08-15T14:30:04Z",
+//	  "result": {"downloadUrl": "https://..."}
+//	}
+type Job struct {
+	// Unique identifier of the job
+	ID string `json:"id"`
+
+	// What the job does (e.g. module.export)
+	Kind string `json:"kind"`
+
+	// Current state (queued, running, succeeded or failed)
+	Status string `json:"status"`
+
+	// Timestamp when the job was submitted
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Timestamp when a worker picked the job up
+	StartedAt *time.Time `json:"startedAt"`
+
+	// Timestamp when the job succeeded or failed
+	FinishedAt *time.Time `json:"finishedAt"`
+
+	// Outcome of a succeeded job
+	Result any `json:"result,omitempty"`
+
+	// Reason of a failed job
+	Error string `json:"error,omitempty"`
+}
+
+// Func is the work of a job; the context is cancelled on shutdown.
+type Func func(ctx context.Context) (any, error)
+
+// Runner executes submitted jobs in the background and keeps their status.
+//
+// Jobs are held in memory: they do not survive a restart and are only
+// visible on the instance that runs them. At most DefaultConcurrency jobs run
+// at once; the others wait in submission order. Finished jobs are forgotten
+// after Retention.
+//
+// Lifecycle:
+//   - Jobs submitted before start wait until the application has started
+//   - OnStop cancels running jobs and waits for them to return
+type Runner struct {
+	mu   sync.Mutex
+	jobs map[string]*Job
+
+	slots   chan struct{}
+	started chan struct{}
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// New creates a runner and registers its lifecycle hooks.
+//
+// Parameters:
+//   - lc: Application lifecycle to register start/stop hooks with
+//
+// Returns:
+//   - *Runner: The runner (started by the lifecycle)
+func New(lc *lifecycle.Lifecycle) *Runner {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &Runner{
+		jobs:    make(map[string]*Job),
+		slots:   make(chan struct{}, DefaultConcurrency),
+		started: make(chan struct{}),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+
+	lc.Append(lifecycle.Hook{
+		Name:    "jobs",
+		OnStart: r.start,
+		OnStop:  r.stop,
+	})
+
+	return r
+}
+
+// Submit queues a job for background execution.
+//
+// Parameters:
+//   - kind: What the job does, reported in its status
+//   - run: The work to execute
+//
+// Returns:
+//   - Job: A snapshot of the queued job
+func (r *Runner) Submit(kind string, run Func) Job {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.prune(time.Now())
+	job := &Job{
+		ID:        uuid.NewString(),
+		Kind:      kind,
+		Status:    StatusQueued,
+		CreatedAt: time.Now(),
+	}
+	r.jobs[job.ID] = job
+
+	r.wg.Add(1)
+	go r.execute(job, run)
+
+	return *job
+}
+
+// Get returns the status of a job.
+//
+// Parameters:
+//   - id: Identifier returned by Submit
+//
+// Returns:
+//   - Job: A snapshot of the job
+//   - bool: False when the job is unknown or was forgotten
+func (r *Runner) Get(id string) (Job, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	job, ok := r.jobs[id]
+	if !ok {
+		return Job{}, false
+	}
+	return *job, true
+}
+
+// Counts returns the known jobs by state.
+//
+// Returns:
+//   - dashboard.JobCounts: Queued and running jobs, and the finished jobs of
+//     the retention period
+func (r *Runner) Counts() dashboard.JobCounts {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var counts dashboard.JobCounts
+	for _, job := range r.jobs {
+		switch job.Status {
+		case StatusQueued:
+			counts.Queued++
+		case StatusRunning:
+			counts.Running++
+		case StatusSucceeded:
+			counts.Succeeded++
+		case StatusFailed:
+			counts.Failed++
+		}
+	}
+	return counts
+}
+
+// QueueStats reports the jobs waiting for and holding a worker.
+//
+// Returns:
+//   - dashboard.Queue: The "jobs" queue, which is unbounded
+func (r *Runner) QueueStats() dashboard.Queue {
+	counts := r.Counts()
+	return dashboard.Queue{Name: "jobs", Depth: counts.Queued, Running: counts.Running}
+}
+
+// execute waits for start and a free slot, then runs the job.
+func (r *Runner) execute(job *Job, run Func) {
+	defer r.wg.Done()
+
+	select {
+	case <-r.started:
+	case <-r.ctx.Done():
+	}
+	select {
+	case r.slots <- struct{}{}:
+		defer func() { <-r.slots }()
+	case <-r.ctx.Done():
+		r.finish(job, nil, r.ctx.Err())
+		return
+	}
+	if err := r.ctx.Err(); err != nil {
+		r.finish(job, nil, err)
+		return
+	}
+
+	r.mu.Lock()
+	now := time.Now()
+	job.Status = StatusRunning
+	job.StartedAt = &now
+	r.mu.Unlock()
+
+	result, err := r.protect(job, run)
+	r.finish(job, result, err)
+}
+
+// protect runs the job, turning a panic into an error.
+func (r *Runner) protect(job *Job, run Func) (result any, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			logger.Errorf("Job %s (%s) panicked: %v", job.ID, job.Kind, recovered)
+			err = fmt.Errorf("job panicked: %v", recovered)
+		}
+	}()
+	return run(r.ctx)
+}
+
+// finish records the outcome of a job.
+func (r *Runner) finish(job *Job, result any, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	job.FinishedAt = &now
+	if err != nil {
+		job.Status = StatusFailed
+		job.Error = err.Error()
+		logger.Errorf("Job %s (%s) failed: %v", job.ID, job.Kind, err)
+		return
+	}
+	job.Status = StatusSucceeded
+	job.Result = result
+}
+
+// prune forgets jobs finished longer than Retention ago; the caller must hold the lock.
+func (r *Runner) prune(now time.Time) {
+	for id, job := range r.jobs {
+		if job.FinishedAt != nil && now.Sub(*job.FinishedAt) > Retention {
+			delete(r.jobs, id)
+		}
+	}
+}
+
+// start releases the queued jobs.
+func (r *Runner) start(context.Context) error {
+	close(r.started)
+	return nil
+}
+
+// stop cancels the jobs and waits for them, bounded by the stop context.
+func (r *Runner) stop(ctx context.Context) error {
+	r.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("jobs: %w", ctx.Err())
+	}
+}
@@ -0,0 +1,197 @@
+package leader
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"expvar"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go_di_architecture/internal/app/lifecycle"
+	"go_di_architecture/internal/logging"
+)
+
+// logger writes the log lines of the leader elections.
+var logger = logging.New("leader")
+
+// electionStats are the counters of the elections, exported as the
+// "leader_election" expvar:
+//   - <name>.leader: Instance currently holding the lease ("" when unknown)
+//   - <name>.is_leader: 1 while this instance leads, else 0
+//   - <name>.failovers: Times the lease passed from one instance to another
+var electionStats = expvar.NewMap("leader_election")
+
+// Lease is the shared record the instances compete for.
+//
+// Implementations live in the infrastructure layer (Redis keys, a database
+// table, or in-process for single-instance deployments).
+type Lease interface {
+	// Acquire takes the lease for holder when it is free or expired, renews
+	// it when holder has it, and returns the holder after the call
+	Acquire(ctx context.Context, name, holder string, ttl time.Duration) (string, error)
+
+	// Release gives the lease up if holder has it
+	Release(ctx context.Context, name, holder string) error
+}
+
+// Elector keeps one instance in charge of an election.
+//
+// Every instance tries to acquire the lease on start and then renews or
+// retries three times per TTL. The holder leads until it stops renewing: on
+// shutdown it releases the lease so another instance takes over at its next
+// attempt, and if it dies the lease expires after the TTL. An instance that
+// cannot reach the lease steps down at once, so there is at most one leader
+// as long as clocks agree within a fraction of the TTL.
+//
+// Usage Example:
+//
+//	elector := leader.New(lc, lease, "scheduler", leader.NewInstanceID(), 15*time.Second)
+//	if elector.IsLeader() { ... }
+type Elector struct {
+	lease    Lease
+	name     string
+	instance string
+	ttl      time.Duration
+
+	leading atomic.Bool
+
+	// Last observed lease holder, to count failovers
+	mu     sync.Mutex
+	holder string
+
+	stats  *expvar.Map
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewInstanceID returns an identifier of this process: the host name with a
+// random suffix, so restarted and co-located instances differ.
+//
+// Returns:
+//   - string: The instance identifier, e.g. "api-7d9f-3fa1c2d8"
+func NewInstanceID() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "instance"
+	}
+	suffix := make([]byte, 4)
+	rand.Read(suffix)
+	return host + "-" + hex.EncodeToString(suffix)
+}
+
+// New creates an elector and registers its lifecycle hooks.
+//
+// Parameters:
+//   - lc: Application lifecycle; the first attempt runs on start, the lease
+//     is released on stop
+//   - lease: The shared lease
+//   - name: Name of the election
+//   - instance: Identifier of this instance
+//   - ttl: How long a lease survives without renewal
+//
+// Returns:
+//   - *Elector: The elector (started by the lifecycle)
+func New(lc *lifecycle.Lifecycle, lease Lease, name, instance string, ttl time.Duration) *Elector {
+	e := &Elector{lease: lease, name: name, instance: instance, ttl: ttl, stats: new(expvar.Map).Init()}
+	e.stats.Set("leader", new(expvar.String))
+	e.stats.Set("is_leader", new(expvar.Int))
+	e.stats.Set("failovers", new(expvar.Int))
+	electionStats.Set(name, e.stats)
+
+	lc.Append(lifecycle.Hook{
+		Name:    "leader." + name,
+		OnStart: e.start,
+		OnStop:  e.stop,
+	})
+
+	return e
+}
+
+// IsLeader reports whether this instance currently leads the election.
+func (e *Elector) IsLeader() bool {
+	return e.leading.Load()
+}
+
+// start makes the first attempt and launches the renewal loop.
+func (e *Elector) start(ctx context.Context) error {
+	e.attempt(ctx)
+
+	loopCtx, cancel := context.WithCancel(context.Background())
+	e.cancel = cancel
+	e.done = make(chan struct{})
+	go e.loop(loopCtx)
+
+	return nil
+}
+
+// stop ends the renewal loop and hands the lease over.
+func (e *Elector) stop(ctx context.Context) error {
+	if e.cancel == nil {
+		return nil
+	}
+	e.cancel()
+	<-e.done
+
+	if !e.leading.Swap(false) {
+		return nil
+	}
+	e.stats.Get("is_leader").(*expvar.Int).Set(0)
+	logger.Infof("Instance %s stepped down as %s leader", e.instance, e.name)
+	return e.lease.Release(ctx, e.name, e.instance)
+}
+
+// loop renews or retries the lease three times per TTL.
+func (e *Elector) loop(ctx context.Context) {
+	defer close(e.done)
+
+	ticker := time.NewTicker(e.ttl / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			e.attempt(ctx)
+		}
+	}
+}
+
+// attempt acquires or renews the lease and records the outcome.
+func (e *Elector) attempt(ctx context.Context) {
+	attemptCtx, cancel := context.WithTimeout(ctx, e.ttl/3)
+	defer cancel()
+
+	holder, err := e.lease.Acquire(attemptCtx, e.name, e.instance, e.ttl)
+	if err != nil {
+		if ctx.Err() == nil {
+			logger.Warnf("Leader election %s failed: %v", e.name, err)
+		}
+		holder = ""
+	}
+
+	e.mu.Lock()
+	if holder != "" && e.holder != "" && holder != e.holder {
+		e.stats.Add("failovers", 1)
+	}
+	if holder != "" {
+		e.holder = holder
+	}
+	e.mu.Unlock()
+	e.stats.Get("leader").(*expvar.String).Set(holder)
+
+	leading := holder == e.instance
+	if e.leading.Swap(leading) == leading {
+		return
+	}
+	if leading {
+		e.stats.Get("is_leader").(*expvar.Int).Set(1)
+		logger.Infof("Instance %s became %s leader", e.instance, e.name)
+	} else {
+		e.stats.Get("is_leader").(*expvar.Int).Set(0)
+		logger.Warnf("Instance %s lost %s leadership", e.instance, e.name)
+	}
+}
@@ -0,0 +1,164 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"go_di_architecture/internal/logging"
+)
+
+// logger writes the log lines of the lifecycle.
+var logger = logging.New("lifecycle")
+
+// Hook is a set of callbacks bound to the start, warm-up and stop phases of the application.
+//
+// Components that own resources (HTTP server, schedulers, consumers, database
+// connections) register a Hook while they are being constructed. Any callback
+// may be nil when the component only cares about some of the phases.
+type Hook struct {
+	// Name identifies the component in logs and error messages
+	Name string
+
+	// OnStart is invoked when the application starts
+	OnStart func(ctx context.Context) error
+
+	// OnWarmUp is invoked once every hook has started, before the application
+	// reports ready; it preloads caches and primes connections
+	OnWarmUp func(ctx context.Context) error
+
+	// OnStop is invoked when the application shuts down
+	OnStop func(ctx context.Context) error
+}
+
+// Lifecycle coordinates the ordered start and stop of application components.
+//
+// The manager follows the same model as uber/fx hooks:
+//   - Hooks are started in the order they were appended
+//   - Hooks are stopped in reverse order
+//   - A failed start rolls back every hook that already started
+//   - Stop keeps going after a failure and reports all errors together
+//
+// Once every hook has started, the warm-up callbacks run in the same order
+// and only then does Ready report true. Warm-up is best effort: a failure is
+// logged and leaves that component to warm up on its first requests, but
+// does not fail the startup. Ready turns false again as soon as Stop begins,
+// so readiness probes take the instance out of rotation while it drains.
+//
+// Because the container appends a component's hook only after all of its
+// dependencies have been constructed, append order is dependency order. This
+// guarantees that, for example, the database is connected before the HTTP
+// server accepts traffic and is closed only after the server has drained.
+type Lifecycle struct {
+	mu      sync.Mutex
+	hooks   []Hook
+	started int
+	ready   atomic.Bool
+}
+
+// New creates an empty lifecycle manager.
+//
+// Returns:
+//   - *Lifecycle: A lifecycle with no registered hooks
+func New() *Lifecycle {
+	return &Lifecycle{}
+}
+
+// Append registers a hook to be run during start and stop.
+//
+// Parameters:
+//   - hook: The start/stop callbacks of a component
+func (l *Lifecycle) Append(hook Hook) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.hooks = append(l.hooks, hook)
+}
+
+// Start runs every OnStart callback in registration order, then every OnWarmUp
+// callback, and marks the application ready.
+//
+// If a start callback fails, the hooks that already started are stopped in
+// reverse order before the error is returned, leaving the application in a
+// clean state. Warm-up failures are logged only.
+//
+// Parameters:
+//   - ctx: Context bounding the total startup time
+//
+// Returns:
+//   - error: The first start failure, joined with any rollback failures
+func (l *Lifecycle) Start(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for l.started < len(l.hooks) {
+		hook := l.hooks[l.started]
+		if hook.OnStart != nil {
+			logger.Infof("Starting %s", hook.Name)
+			if err := hook.OnStart(ctx); err != nil {
+				startErr := fmt.Errorf("start %s: %w", hook.Name, err)
+				return errors.Join(startErr, l.stop(ctx))
+			}
+		}
+		l.started++
+	}
+
+	for _, hook := range l.hooks {
+		if hook.OnWarmUp == nil {
+			continue
+		}
+		logger.Infof("Warming up %s", hook.Name)
+		if err := hook.OnWarmUp(ctx); err != nil {
+			logger.Warnf("Warm-up of %s failed: %v", hook.Name, err)
+		}
+	}
+
+	l.ready.Store(true)
+	logger.Infof("Application ready")
+	return nil
+}
+
+// Ready reports whether the application has started and warmed up and is not
+// stopping, which is what readiness probes check.
+//
+// Returns:
+//   - bool: True while the application should receive traffic
+func (l *Lifecycle) Ready() bool {
+	return l.ready.Load()
+}
+
+// Stop runs the OnStop callbacks of all started hooks in reverse order.
+//
+// Parameters:
+//   - ctx: Context bounding the total shutdown time
+//
+// Returns:
+//   - error: All stop failures joined together, or nil
+func (l *Lifecycle) Stop(ctx context.Context) error {
+	l.ready.Store(false)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.stop(ctx)
+}
+
+// stop unwinds started hooks; the caller must hold the lock.
+func (l *Lifecycle) stop(ctx context.Context) error {
+	var errs []error
+	for ; l.started > 0; l.started-- {
+		hook := l.hooks[l.started-1]
+		if hook.OnStop == nil {
+			continue
+		}
+
+		logger.Infof("Stopping %s", hook.Name)
+		if err := hook.OnStop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("stop %s: %w", hook.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
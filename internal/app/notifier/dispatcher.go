@@ -0,0 +1,160 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go_di_architecture/internal/app/lifecycle"
+	"go_di_architecture/internal/domain/events"
+	"go_di_architecture/internal/domain/models/dashboard"
+	"go_di_architecture/internal/domain/notification"
+	"go_di_architecture/internal/logging"
+)
+
+// logger writes the log lines of the notification dispatcher.
+var logger = logging.New("notifier")
+
+// QueueSize is the number of events buffered for delivery.
+const QueueSize = 100
+
+// deliveryTimeout bounds the delivery of one notification over one channel.
+const deliveryTimeout = 10 * time.Second
+
+// Dispatcher turns domain events into notifications.
+//
+// The dispatcher subscribes to the event bus and routes each event to the
+// channels configured for its type. Bus subscribers run on the publishing
+// goroutine, so events are only queued there; a background worker renders
+// and delivers them. When the queue is full, events are dropped with a
+// warning rather than slowing down requests.
+//
+// Lifecycle:
+//   - OnStart launches the delivery worker
+//   - OnStop stops accepting events and waits for queued ones to be delivered
+type Dispatcher struct {
+	routes    notification.Routes
+	notifiers map[string]notification.Notifier
+	templates notification.Templates
+
+	mu      sync.RWMutex
+	queue   chan events.Event
+	stopped bool
+	done    chan struct{}
+}
+
+// New creates a dispatcher, subscribes it to the bus and registers its lifecycle hooks.
+//
+// Parameters:
+//   - lc: Application lifecycle to register start/stop hooks with
+//   - bus: Event bus to subscribe to
+//   - routes: Channels to notify per event type
+//   - notifiers: Notifier per channel name; routes to missing channels are skipped
+//   - templates: Templates rendering the notification messages
+//
+// Returns:
+//   - *Dispatcher: The dispatcher (started by the lifecycle)
+func New(lc *lifecycle.Lifecycle, bus *events.Bus, routes notification.Routes, notifiers map[string]notification.Notifier, templates notification.Templates) *Dispatcher {
+	d := &Dispatcher{
+		routes:    routes,
+		notifiers: notifiers,
+		templates: templates,
+		queue:     make(chan events.Event, QueueSize),
+		done:      make(chan struct{}),
+	}
+
+	bus.Subscribe(d.enqueue)
+	lc.Append(lifecycle.Hook{
+		Name:    "notifier",
+		OnStart: d.start,
+		OnStop:  d.stop,
+	})
+
+	return d
+}
+
+// enqueue queues a routed event without blocking the publisher.
+func (d *Dispatcher) enqueue(event events.Event) {
+	if len(d.routes.Channels(event.Type)) == 0 {
+		return
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if d.stopped {
+		return
+	}
+
+	select {
+	case d.queue <- event:
+	default:
+		logger.Warnf("Notification queue full, dropping %s for module %d", event.Type, event.ModuleID)
+	}
+}
+
+// QueueStats reports the events waiting for delivery.
+//
+// Returns:
+//   - dashboard.Queue: The "notifier" queue; events beyond its capacity are dropped
+func (d *Dispatcher) QueueStats() dashboard.Queue {
+	return dashboard.Queue{Name: "notifier", Depth: len(d.queue), Capacity: cap(d.queue)}
+}
+
+// start launches the delivery worker; it outlives the start context.
+func (d *Dispatcher) start(context.Context) error {
+	go d.work()
+	logger.Infof("Notifier routing %d rule(s) to %d channel(s)", len(d.routes), len(d.notifiers))
+	return nil
+}
+
+// stop closes the queue and waits for the worker, bounded by the stop context.
+func (d *Dispatcher) stop(ctx context.Context) error {
+	d.mu.Lock()
+	if !d.stopped {
+		d.stopped = true
+		close(d.queue)
+	}
+	d.mu.Unlock()
+
+	select {
+	case <-d.done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("notifier: %w", ctx.Err())
+	}
+}
+
+// work delivers queued events until the queue is closed.
+func (d *Dispatcher) work() {
+	defer close(d.done)
+
+	for event := range d.queue {
+		d.deliver(event)
+	}
+}
+
+// deliver renders an event and sends it over every routed channel.
+//
+// Failures are logged per channel; one failing channel does not prevent
+// delivery over the others.
+func (d *Dispatcher) deliver(event events.Event) {
+	message, err := notification.Render(d.templates, event)
+	if err != nil {
+		logger.Errorf("Notification for %s failed: %v", event.Type, err)
+		return
+	}
+
+	for _, channel := range d.routes.Channels(event.Type) {
+		notifier, ok := d.notifiers[channel]
+		if !ok {
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), deliveryTimeout)
+		if err := notifier.Notify(ctx, message); err != nil {
+			logger.Errorf("Notification %s via %s failed: %v", event.Type, channel, err)
+		}
+		cancel()
+	}
+}
@@ -0,0 +1,287 @@
+package openapi
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"go_di_architecture/internal/domain/models/response"
+)
+
+// Security schemes of the document
+const (
+	// SchemeAPIKey authenticates with the X-API-Key header
+	SchemeAPIKey = "ApiKeyAuth"
+
+	// SchemeBearer authenticates with a Bearer token
+	SchemeBearer = "BearerAuth"
+)
+
+var (
+	// pathParam matches the ":name" and "*name" segments of gin route patterns
+	pathParam = regexp.MustCompile(`[:*]([A-Za-z0-9_]+)`)
+
+	// versionSegment matches the version segment of a path (e.g. "v1")
+	versionSegment = regexp.MustCompile(`^v[0-9]+$`)
+
+	// nonAlphanumeric matches the separators replaced in derived operation IDs
+	nonAlphanumeric = regexp.MustCompile(`[^A-Za-z0-9]+`)
+)
+
+// Route is a registered route as described in the document.
+type Route struct {
+	// HTTP method (e.g. http.MethodGet)
+	Method string
+
+	// Full path with gin parameters (e.g. "/api/v1/modules/:id")
+	Path string
+
+	// Name of the handler, used as operation ID (e.g. "GetModuleById")
+	Name string
+
+	// Short description (derived from Name when empty)
+	Summary string
+
+	// Whether the route requires a principal
+	Secured bool
+
+	// Scopes the principal must hold
+	Scopes []string
+
+	// Values of the request body, query struct and response data types (optional)
+	Body, Query, Response any
+
+	// Status of the success response (200 when zero)
+	Status int
+}
+
+// Build generates the document of the routes.
+//
+// Every operation answers with the APIResponse envelope: successes carry the
+// route's response type as data, failures reference the shared error
+// response of their status, whose error code is restricted to the codes
+// registered for that status. The error responses of an operation follow
+// from what it declares: 400 for input, 401 and 403 for a policy, 404 for
+// path parameters, 409 for writes and 500 always.
+//
+// Parameters:
+//   - info: Title, description and version of the API
+//   - routes: The registered routes
+//   - codes: Every error code of the API (see response.ErrorRegistry.Codes)
+//
+// Returns:
+//   - *Document: The document; the caller adds the servers
+func Build(info Info, routes []Route, codes []response.ErrorCode) *Document {
+	s := &schemas{components: make(map[string]*Schema)}
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   make(map[string]*PathItem),
+		Components: Components{
+			Schemas:   s.components,
+			Responses: make(map[string]*Response),
+			SecuritySchemes: map[string]SecurityScheme{
+				SchemeAPIKey: {Type: "apiKey", In: "header", Name: "X-API-Key", Description: "API key configured in AUTH_API_KEYS"},
+				SchemeBearer: {Type: "http", Scheme: "bearer", Description: "HS256 JWT signed with AUTH_JWT_SECRET"},
+			},
+		},
+	}
+
+	// Step 1: Shared envelope and error responses; the envelope has its own
+	// MarshalJSON but renders its fields as tagged
+	envelope := s.structRef(reflect.TypeFor[response.APIResponse]())
+	statuses := errorResponses(doc, envelope, codes)
+
+	// Step 2: One operation per route
+	operationIDs := make(map[string]bool)
+	tags := make(map[string]bool)
+	for _, route := range routes {
+		path := pathParam.ReplaceAllString(route.Path, "{$1}")
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = &PathItem{}
+			doc.Paths[path] = item
+		}
+
+		operation := s.operation(route, envelope, statuses)
+		operation.OperationID = uniqueID(operationIDs, route)
+		(*item)[strings.ToLower(route.Method)] = operation
+		for _, tag := range operation.Tags {
+			tags[tag] = true
+		}
+	}
+
+	for _, tag := range slices.Sorted(maps.Keys(tags)) {
+		doc.Tags = append(doc.Tags, Tag{Name: tag})
+	}
+	return doc
+}
+
+// errorResponses adds the ErrorCode schema and one error response per status
+// to the components.
+//
+// Returns:
+//   - map[int]bool: The statuses that have a shared error response
+func errorResponses(doc *Document, envelope *Schema, codes []response.ErrorCode) map[int]bool {
+	all := &Schema{Type: "string", Description: "Machine-readable error code"}
+	byStatus := make(map[int][]any)
+	for _, code := range codes {
+		if !slices.Contains(all.Enum, any(code.Code)) {
+			all.Enum = append(all.Enum, code.Code)
+		}
+		byStatus[code.Status] = append(byStatus[code.Status], code.Code)
+	}
+	doc.Components.Schemas["ErrorCode"] = all
+
+	statuses := make(map[int]bool, len(byStatus))
+	for status, statusCodes := range byStatus {
+		statuses[status] = true
+		doc.Components.Responses[errorResponseName(status)] = &Response{
+			Description: http.StatusText(status),
+			Content: jsonContent(&Schema{AllOf: []*Schema{envelope, {
+				Type: "object",
+				Properties: map[string]*Schema{
+					"error": {Type: "object", Properties: map[string]*Schema{
+						"code": {AllOf: []*Schema{{Ref: schemaRefPrefix + "ErrorCode"}}, Enum: statusCodes},
+					}},
+				},
+			}}}),
+		}
+	}
+	return statuses
+}
+
+// operation describes a route, without its operation ID.
+func (s *schemas) operation(route Route, envelope *Schema, statuses map[int]bool) *Operation {
+	operation := &Operation{
+		Summary:   route.Summary,
+		Tags:      []string{tagOf(route.Path)},
+		Responses: make(map[string]*Response),
+	}
+	if operation.Summary == "" {
+		operation.Summary = summaryOf(route.Name)
+	}
+
+	// Step 1: Path and query parameters
+	for _, match := range pathParam.FindAllStringSubmatch(route.Path, -1) {
+		operation.Parameters = append(operation.Parameters, Parameter{
+			Name: match[1], In: "path", Required: true, Schema: &Schema{Type: "string"},
+		})
+	}
+	if route.Query != nil {
+		for _, f := range fields(reflect.Indirect(reflect.ValueOf(route.Query)).Type(), "form") {
+			operation.Parameters = append(operation.Parameters, Parameter{
+				Name: f.name, In: "query", Required: f.required, Schema: s.fieldSchema(f),
+			})
+		}
+	}
+
+	// Step 2: Request body
+	if route.Body != nil {
+		operation.RequestBody = &RequestBody{Required: true, Content: jsonContent(s.schemaOf(route.Body))}
+	}
+
+	// Step 3: Success response
+	status := route.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	success := &Response{Description: http.StatusText(status)}
+	if status != http.StatusNoContent && route.Method != http.MethodHead {
+		data := &Schema{}
+		if route.Response != nil {
+			data = s.schemaOf(route.Response)
+		}
+		success.Content = jsonContent(&Schema{AllOf: []*Schema{envelope, {
+			Type:       "object",
+			Properties: map[string]*Schema{"data": data},
+		}}})
+	}
+	operation.Responses[strconv.Itoa(status)] = success
+
+	// Step 4: Error responses and security; any operation may fail or time
+	// out in the data layer
+	errorStatuses := []int{http.StatusInternalServerError, http.StatusGatewayTimeout}
+	if route.Body != nil || route.Query != nil || len(operation.Parameters) > 0 {
+		errorStatuses = append(errorStatuses, http.StatusBadRequest)
+	}
+	if strings.Contains(route.Path, ":") {
+		errorStatuses = append(errorStatuses, http.StatusNotFound)
+	}
+	if route.Method != http.MethodGet && route.Method != http.MethodHead {
+		errorStatuses = append(errorStatuses, http.StatusConflict)
+	}
+	if route.Secured {
+		errorStatuses = append(errorStatuses, http.StatusUnauthorized, http.StatusForbidden)
+		scopes := append([]string{}, route.Scopes...)
+		operation.Security = []map[string][]string{{SchemeAPIKey: scopes}, {SchemeBearer: scopes}}
+	}
+	for _, errorStatus := range errorStatuses {
+		if statuses[errorStatus] {
+			operation.Responses[strconv.Itoa(errorStatus)] = &Response{Ref: "#/components/responses/" + errorResponseName(errorStatus)}
+		}
+	}
+	return operation
+}
+
+// uniqueID returns the handler name as operation ID, suffixed with the method
+// or a counter when another route already uses it (e.g. a handler serving
+// both GET and HEAD).
+func uniqueID(used map[string]bool, route Route) string {
+	id := route.Name
+	if id == "" {
+		// Closures have no name; derive one from the route (e.g. get_api_v1_modules_id)
+		id = strings.ToLower(route.Method) + nonAlphanumeric.ReplaceAllString(pathParam.ReplaceAllString(route.Path, "$1"), "_")
+	}
+	if used[id] {
+		id += strings.ToUpper(route.Method[:1]) + strings.ToLower(route.Method[1:])
+	}
+	for base, n := id, 2; used[id]; n++ {
+		id = base + strconv.Itoa(n)
+	}
+	used[id] = true
+	return id
+}
+
+// tagOf returns the resource a path belongs to: its first segment after the
+// version prefix (e.g. "/api/v1/modules/:id" -> "modules").
+func tagOf(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, segment := range segments {
+		if (i == 0 && (segment == "api" || segment == "public")) || versionSegment.MatchString(segment) {
+			continue
+		}
+		return segment
+	}
+	return segments[0]
+}
+
+// summaryOf turns a handler name into a sentence (e.g. "GetModuleById" ->
+// "Get module by id").
+func summaryOf(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if i > 0 && unicode.IsUpper(r) {
+			b.WriteByte(' ')
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// errorResponseName returns the name of the shared error response of a status.
+func errorResponseName(status int) string {
+	return fmt.Sprintf("Error%d", status)
+}
+
+// jsonContent returns a JSON body of the schema.
+func jsonContent(schema *Schema) map[string]MediaType {
+	return map[string]MediaType{"application/json": {Schema: schema}}
+}
@@ -0,0 +1,272 @@
+package openapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// schemaRefPrefix is the prefix of references to shared schemas.
+const schemaRefPrefix = "#/components/schemas/"
+
+var (
+	timeType          = reflect.TypeFor[time.Time]()
+	rawMessageType    = reflect.TypeFor[json.RawMessage]()
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// invalidNameChars matches the characters not allowed in component names
+// (e.g. the brackets of generic type names).
+var invalidNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// schemas reflects Go types into JSON Schemas.
+//
+// Named struct types are added to the shared schemas once and referenced
+// wherever they are used, which also terminates recursive types.
+type schemas struct {
+	components map[string]*Schema
+}
+
+// schemaOf returns the schema of a value's type, nil for a nil value.
+func (s *schemas) schemaOf(value any) *Schema {
+	if value == nil {
+		return nil
+	}
+	return s.schema(reflect.TypeOf(value))
+}
+
+// schema returns the schema of a type.
+func (s *schemas) schema(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	// Step 1: Types with their own JSON encoding
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == rawMessageType:
+		return &Schema{}
+	case reflect.PointerTo(t).Implements(jsonMarshalerType):
+		return &Schema{}
+	case reflect.PointerTo(t).Implements(textMarshalerType):
+		return &Schema{Type: "string"}
+	}
+
+	// Step 2: Types by kind
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: s.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: s.schema(t.Elem())}
+	case reflect.Struct:
+		return s.structRef(t)
+	}
+	// Interfaces and anything else accept any value
+	return &Schema{}
+}
+
+// structRef returns a reference to the shared schema of a struct, adding it
+// on first use; anonymous structs are inlined.
+func (s *schemas) structRef(t reflect.Type) *Schema {
+	if t.Name() == "" {
+		return s.object(t, "json")
+	}
+
+	name := invalidNameChars.ReplaceAllString(t.String(), "_")
+	if _, ok := s.components[name]; !ok {
+		// Reserve the name before reflecting the fields, for recursive types
+		s.components[name] = &Schema{}
+		*s.components[name] = *s.object(t, "json")
+	}
+	return &Schema{Ref: schemaRefPrefix + name}
+}
+
+// object returns the schema of a struct whose fields are named by the given
+// tag (json for bodies, form for query parameters).
+func (s *schemas) object(t reflect.Type, tag string) *Schema {
+	object := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	for _, field := range fields(t, tag) {
+		object.Properties[field.name] = s.fieldSchema(field)
+		if field.required {
+			object.Required = append(object.Required, field.name)
+		}
+	}
+	return object
+}
+
+// fieldSchema returns the schema of a field with its binding rules applied.
+func (s *schemas) fieldSchema(f field) *Schema {
+	schema := s.schema(f.Type)
+	if schema.Ref != "" && (f.rules != "" || f.defaultValue != "") {
+		// Siblings of $ref are allowed in 3.1, but keep the reference intact
+		schema = &Schema{AllOf: []*Schema{schema}}
+	}
+	applyRules(schema, f.rules)
+	if f.defaultValue != "" {
+		schema.Default = parseDefault(schema, f.defaultValue)
+	}
+	return schema
+}
+
+// field is a serialized struct field.
+type field struct {
+	reflect.StructField
+
+	// Name in the JSON body or query string
+	name string
+
+	// Whether the binding rules require the field
+	required bool
+
+	// Binding rules (e.g. "required,min=3,max=50")
+	rules string
+
+	// Default of a query parameter (form tag option default=)
+	defaultValue string
+}
+
+// fields returns the serialized fields of a struct, including the promoted
+// fields of embedded structs.
+func fields(t reflect.Type, tag string) []field {
+	var result []field
+	for i := 0; i < t.NumField(); i++ {
+		structField := t.Field(i)
+		name, options, _ := strings.Cut(structField.Tag.Get(tag), ",")
+		if name == "-" {
+			continue
+		}
+
+		embedded := structField.Type
+		if embedded.Kind() == reflect.Pointer {
+			embedded = embedded.Elem()
+		}
+		if structField.Anonymous && name == "" && embedded.Kind() == reflect.Struct {
+			result = append(result, fields(embedded, tag)...)
+			continue
+		}
+		if !structField.IsExported() {
+			continue
+		}
+		if name == "" {
+			if tag != "json" {
+				continue
+			}
+			name = structField.Name
+		}
+
+		rules := structField.Tag.Get("binding")
+		f := field{StructField: structField, name: name, rules: rules, required: hasRule(rules, "required")}
+		for _, option := range strings.Split(options, ",") {
+			if value, ok := strings.CutPrefix(option, "default="); ok {
+				f.defaultValue = value
+			}
+		}
+		result = append(result, f)
+	}
+	return result
+}
+
+// hasRule reports whether the binding rules contain a rule.
+func hasRule(rules, rule string) bool {
+	for _, candidate := range strings.Split(rules, ",") {
+		if candidate == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// applyRules translates the binding rules of a field into schema keywords.
+//
+// Rules after dive apply to the elements and are not described.
+func applyRules(schema *Schema, rules string) {
+	target := schema
+	if len(schema.AllOf) > 0 {
+		target = schema.AllOf[0]
+	}
+
+	for _, rule := range strings.Split(rules, ",") {
+		name, value, _ := strings.Cut(rule, "=")
+		switch name {
+		case "dive":
+			return
+		case "min", "max", "len":
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			if name != "max" {
+				setBound(schema, target, n, true)
+			}
+			if name != "min" {
+				setBound(schema, target, n, false)
+			}
+		case "oneof":
+			for _, option := range strings.Fields(value) {
+				schema.Enum = append(schema.Enum, option)
+			}
+		case "uuid":
+			schema.Format = "uuid"
+		}
+	}
+}
+
+// setBound sets the lower or upper bound matching the type of the target:
+// a length for strings, a count for arrays, a value for numbers.
+func setBound(schema, target *Schema, n float64, lower bool) {
+	count := int(n)
+	switch target.Type {
+	case "string":
+		if lower {
+			schema.MinLength = &count
+		} else {
+			schema.MaxLength = &count
+		}
+	case "array":
+		if lower {
+			schema.MinItems = &count
+		} else {
+			schema.MaxItems = &count
+		}
+	case "integer", "number":
+		if lower {
+			schema.Minimum = &n
+		} else {
+			schema.Maximum = &n
+		}
+	}
+}
+
+// parseDefault converts the default of a query parameter to the type of its
+// schema.
+func parseDefault(schema *Schema, value string) any {
+	switch schema.Type {
+	case "integer":
+		if n, err := strconv.Atoi(value); err == nil {
+			return n
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return value
+}
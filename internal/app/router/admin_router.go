@@ -0,0 +1,26 @@
+package router
+
+import (
+	"net/http"
+
+	"go_di_architecture/internal/adminui"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupAdminUIRoutes serves the embedded admin dashboard.
+//
+// The page and its assets are public because they hold no data: the
+// dashboard asks for an admin API key or token and sends it with its calls to
+// the admin API, which keeps requiring the admin scope.
+func SetupAdminUIRoutes(r *gin.Engine) {
+	assets := http.FS(adminui.Assets())
+
+	// Dashboard page
+	r.GET("/admin", func(c *gin.Context) {
+		c.FileFromFS("/", assets)
+	}) // GET /admin
+
+	// Script and style sheet of the page
+	r.StaticFS("/admin/ui", assets) // GET /admin/ui/{file}
+}
@@ -0,0 +1,60 @@
+package router
+
+import (
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"go_di_architecture/internal/app/handlers"
+	"go_di_architecture/internal/domain/models/discovery"
+
+	"github.com/gin-gonic/gin"
+)
+
+// apiPrefix is the path of the versioned API.
+const apiPrefix = "/api/v1"
+
+// pathParam matches the ":name" and "*name" segments of route patterns.
+var pathParam = regexp.MustCompile(`[:*]([A-Za-z0-9_]+)`)
+
+// SetupDiscoveryRoutes configures the discovery document of the versioned API.
+//
+// Route Structure:
+//
+//	GET /api/v1 - Resources and the methods they accept
+//
+// The document is built from the registered routes on every request, so it
+// never drifts from the router. It needs no scope and is not counted against
+// quotas.
+//
+// Parameters:
+//   - r: The engine the versioned routes are registered on
+func SetupDiscoveryRoutes(r *gin.Engine) {
+	r.GET(apiPrefix, func(ctx *gin.Context) {
+		handlers.Respond(ctx, handlers.Result{Data: discoveryDocument(r.Routes()), Status: http.StatusOK}, nil)
+	})
+}
+
+// discoveryDocument groups the routes of the versioned API by path.
+func discoveryDocument(routes gin.RoutesInfo) discovery.Document {
+	methods := make(map[string][]string)
+	for _, route := range routes {
+		if !strings.HasPrefix(route.Path, apiPrefix+"/") {
+			continue
+		}
+		methods[route.Path] = append(methods[route.Path], route.Method)
+	}
+
+	document := discovery.Document{Version: strings.TrimPrefix(apiPrefix, "/api/"), Resources: []discovery.Resource{}}
+	for path, pathMethods := range methods {
+		document.Resources = append(document.Resources, discovery.Resource{
+			Path:    pathParam.ReplaceAllString(path, "{$1}"),
+			Methods: withAutoMethods(pathMethods),
+		})
+	}
+	sort.Slice(document.Resources, func(i, j int) bool {
+		return document.Resources[i].Path < document.Resources[j].Path
+	})
+	return document
+}
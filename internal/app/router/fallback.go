@@ -0,0 +1,129 @@
+package router
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"go_di_architecture/internal/app/handlers"
+	"go_di_architecture/internal/domain/models/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupFallbackRoutes answers requests no route matches with the standard
+// error envelope instead of Gin's plain-text bodies.
+//
+// Fallback Behavior:
+//   - Unknown paths return 404 ROUTE_NOT_FOUND
+//   - OPTIONS on a known path returns 204 with an Allow header listing the
+//     methods of the path
+//   - Known paths with another method return 405 METHOD_NOT_ALLOWED and the
+//     same Allow header
+//   - Paths differing from a route only by a trailing slash keep redirecting
+//     to the route (Gin's default), so they never reach the fallbacks
+//
+// The global middleware runs for fallbacks too, so the error is rendered by
+// the exception middleware with the request ID and naming of the request.
+// HEAD requests are answered by AutoHead before they reach the engine.
+//
+// Parameters:
+//   - r: The engine, after all routes are registered
+func SetupFallbackRoutes(r *gin.Engine) {
+	r.RedirectTrailingSlash = true
+	r.HandleMethodNotAllowed = true
+
+	r.NoRoute(func(ctx *gin.Context) {
+		handlers.Respond(ctx, handlers.Result{}, response.NewHTTPError(http.StatusNotFound, "ROUTE_NOT_FOUND", nil))
+	})
+
+	r.NoMethod(func(ctx *gin.Context) {
+		allowed := allowedMethods(r.Routes(), ctx.Request.URL.Path)
+		ctx.Header("Allow", strings.Join(allowed, ", "))
+
+		if ctx.Request.Method == http.MethodOptions {
+			handlers.Respond(ctx, handlers.Result{}, nil)
+			return
+		}
+		handlers.Respond(ctx, handlers.Result{}, response.NewHTTPError(http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", nil))
+	})
+}
+
+// AutoHead serves HEAD requests of routes registering only GET.
+//
+// Gin picks the route by method before any middleware runs, so a HEAD request
+// is replayed as GET on a copy of the request. The server drops the body
+// because the original request is HEAD; the access log shows the GET.
+//
+// Parameters:
+//   - engine: The engine serving the routes
+//
+// Returns:
+//   - http.Handler: The engine with HEAD support
+func AutoHead(engine *gin.Engine) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method == http.MethodHead {
+			methods := routeMethods(engine.Routes(), req.URL.Path)
+			if slices.Contains(methods, http.MethodGet) && !slices.Contains(methods, http.MethodHead) {
+				get := req.Clone(req.Context())
+				get.Method = http.MethodGet
+				engine.ServeHTTP(w, get)
+				return
+			}
+		}
+		engine.ServeHTTP(w, req)
+	})
+}
+
+// allowedMethods returns the methods accepted for a path, including the
+// automatic HEAD and OPTIONS.
+func allowedMethods(routes gin.RoutesInfo, path string) []string {
+	return withAutoMethods(routeMethods(routes, path))
+}
+
+// routeMethods returns the methods of the registered routes matching a path.
+//
+// Gin keeps one route tree per method, so a method is accepted as soon as one
+// of its routes matches, even when another method has a more specific route.
+func routeMethods(routes gin.RoutesInfo, path string) []string {
+	var methods []string
+	for _, route := range routes {
+		if routeMatches(route.Path, path) && !slices.Contains(methods, route.Method) {
+			methods = append(methods, route.Method)
+		}
+	}
+	return methods
+}
+
+// withAutoMethods adds HEAD to methods with GET and OPTIONS to any, sorted.
+func withAutoMethods(methods []string) []string {
+	methods = slices.Clone(methods)
+	if slices.Contains(methods, http.MethodGet) && !slices.Contains(methods, http.MethodHead) {
+		methods = append(methods, http.MethodHead)
+	}
+	if len(methods) > 0 && !slices.Contains(methods, http.MethodOptions) {
+		methods = append(methods, http.MethodOptions)
+	}
+	slices.Sort(methods)
+	return methods
+}
+
+// routeMatches reports whether a route pattern (":param" and "*wildcard"
+// segments) matches a request path.
+func routeMatches(pattern, path string) bool {
+	patternParts := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+
+	for i, part := range patternParts {
+		if strings.HasPrefix(part, "*") {
+			return true
+		}
+		if i >= len(pathParts) {
+			return false
+		}
+		if !strings.HasPrefix(part, ":") && part != pathParts[i] {
+			return false
+		}
+	}
+	return len(patternParts) == len(pathParts)
+}
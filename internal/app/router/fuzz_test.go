@@ -0,0 +1,116 @@
+package router_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// checkEnvelope fails unless the response is a client error or success
+// rendered in the standard envelope; a panic in the handler layer would be
+// rendered as a 500 by the exception middleware. Redirects of the router
+// (e.g. of a trailing slash) carry no envelope.
+func checkEnvelope(t *testing.T, recorder *httptest.ResponseRecorder) {
+	t.Helper()
+	if recorder.Code >= http.StatusInternalServerError {
+		t.Fatalf("status %d: %s", recorder.Code, recorder.Body)
+	}
+	if recorder.Code == http.StatusNoContent || recorder.Code >= http.StatusMultipleChoices && recorder.Code < http.StatusBadRequest {
+		return
+	}
+
+	var envelope struct {
+		Success *bool           `json:"success"`
+		Error   json.RawMessage `json:"error"`
+		Meta    struct {
+			RequestID string `json:"requestId"`
+		} `json:"meta"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &envelope); err != nil {
+		t.Fatalf("status %d: body is not JSON: %v: %s", recorder.Code, err, recorder.Body)
+	}
+	if envelope.Success == nil || *envelope.Success != (recorder.Code < http.StatusBadRequest) {
+		t.Fatalf("status %d: success does not match the status: %s", recorder.Code, recorder.Body)
+	}
+	if !*envelope.Success && len(envelope.Error) == 0 {
+		t.Fatalf("status %d: error response without error: %s", recorder.Code, recorder.Body)
+	}
+	if envelope.Meta.RequestID != testRequestID {
+		t.Fatalf("status %d: meta.requestId = %q: %s", recorder.Code, envelope.Meta.RequestID, recorder.Body)
+	}
+}
+
+// serveQuery sends a GET with the raw query string, which may be malformed.
+func serveQuery(engine *gin.Engine, path, rawQuery string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodGet, path, nil)
+	request.URL.RawQuery = rawQuery
+	request.Header.Set("X-Request-Id", testRequestID)
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func FuzzCreateModuleBody(f *testing.F) {
+	for _, body := range []string{
+		`{"name":"Payments","description":"Fees"}`,
+		`{"name":"Payments","isActive":true}`,
+		`{"name":"x"}`,
+		`{"name":"Payments","activateAt":"2030-01-02T00:00:00Z","deactivateAt":"2030-01-01T00:00:00Z"}`,
+		`{"name":"Payments","activateAt":"yesterday"}`,
+		`{"name":12,"description":null}`,
+		`{"name":"` + strings.Repeat("é", 51) + `"}`,
+		`[]`,
+		`{`,
+		``,
+	} {
+		f.Add(body)
+	}
+	engine := newTestRouter(f)
+
+	f.Fuzz(func(t *testing.T, body string) {
+		checkEnvelope(t, serve(t, engine, apiRequest{method: http.MethodPost, path: "/api/v1/modules", body: body}))
+	})
+}
+
+func FuzzListModulesQuery(f *testing.F) {
+	for _, query := range []string{
+		"page=2&pageSize=10",
+		"page=0&pageSize=1000",
+		"page=-1&pageSize=abc",
+		"tag=payments&status=approved&starred=true",
+		"status=unknown",
+		"cursor=eyJjcmVhdGVkQXQiOiIyMDI0LTAxLTAxVDAwOjAwOjAwWiIsImlkIjoxfQ",
+		"cursor=%%%",
+		"fields=id,name,,unknown&include=tags,permissions",
+		"page=99999999999999999999",
+		"a=%zz&&=;",
+	} {
+		f.Add(query)
+	}
+	engine := newTestRouter(f)
+	mustServe(f, engine, createPayments)
+
+	f.Fuzz(func(t *testing.T, query string) {
+		checkEnvelope(t, serveQuery(engine, "/api/v1/modules", query))
+	})
+}
+
+func FuzzModuleID(f *testing.F) {
+	for _, id := range []string{"1", "0", "-1", "abc", "1.5", "99999999999999999999", " 1", "0x10", "1e3", ""} {
+		f.Add(id)
+	}
+	engine := newTestRouter(f)
+	mustServe(f, engine, createPayments)
+
+	f.Fuzz(func(t *testing.T, id string) {
+		path := "/api/v1/modules/" + url.PathEscape(id)
+		checkEnvelope(t, serve(t, engine, apiRequest{method: http.MethodGet, path: path}))
+		checkEnvelope(t, serve(t, engine, apiRequest{method: http.MethodPut, path: path, body: `{"name":"Payments"}`}))
+		checkEnvelope(t, serveQuery(engine, path+"/history", "page=1"))
+	})
+}
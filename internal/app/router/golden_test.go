@@ -0,0 +1,115 @@
+package router_test
+
+import (
+	"flag"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+// updateGolden rewrites the golden files from the current responses:
+//
+//	go test ./internal/app/router -run TestGoldenResponses -update
+var updateGolden = flag.Bool("update", false, "rewrite the golden files of TestGoldenResponses")
+
+// goldenDir holds one file per scenario with the status and canonical body.
+const goldenDir = "testdata/golden"
+
+// Requests preparing the golden scenarios
+var (
+	createTag   = apiRequest{method: http.MethodPost, path: "/api/v1/tags", body: `{"name":"billing"}`}
+	tagPayments = apiRequest{method: http.MethodPut, path: "/api/v1/modules/1/tags/billing"}
+	addNote     = apiRequest{method: http.MethodPost, path: "/api/v1/modules/1/notes", body: `{"body":"Rotated the credentials"}`}
+	addDepends  = apiRequest{method: http.MethodPut, path: "/api/v1/modules/1/dependencies/2"}
+)
+
+// TestGoldenResponses compares the wire format of each endpoint and scenario
+// with its golden file in testdata/golden.
+//
+// Bodies are compared in canonical form (sorted keys, wall-clock times and
+// cursors masked), so any added, removed, renamed or retyped field fails the
+// test while the order of the fields does not; run with -update after an
+// intended change and review the diff of the golden files.
+func TestGoldenResponses(t *testing.T) {
+	scenarios := []struct {
+		name    string
+		setup   []apiRequest
+		request apiRequest
+	}{
+		// Modules
+		{name: "modules_list", setup: []apiRequest{createPayments, createBilling}, request: apiRequest{method: http.MethodGet, path: "/api/v1/modules?pageSize=1"}},
+		{name: "modules_list_cursor", setup: []apiRequest{createPayments, createBilling}, request: apiRequest{method: http.MethodGet, path: "/api/v1/modules?cursor=&pageSize=1&include=tags"}},
+		{name: "modules_list_invalid_query", request: apiRequest{method: http.MethodGet, path: "/api/v1/modules?page=0&status=unknown"}},
+		{name: "modules_create", request: createPayments},
+		{name: "modules_create_invalid", request: apiRequest{method: http.MethodPost, path: "/api/v1/modules", body: `{"name":"x"}`}},
+		{name: "modules_create_malformed", request: apiRequest{method: http.MethodPost, path: "/api/v1/modules", body: `{`}},
+		{name: "modules_create_conflict", setup: []apiRequest{createPayments}, request: createPayments},
+		{name: "modules_get", setup: []apiRequest{createPayments}, request: apiRequest{method: http.MethodGet, path: "/api/v1/modules/1"}},
+		{name: "modules_get_not_found", request: apiRequest{method: http.MethodGet, path: "/api/v1/modules/99"}},
+		{name: "modules_get_invalid_id", request: apiRequest{method: http.MethodGet, path: "/api/v1/modules/abc"}},
+		{name: "modules_update", setup: []apiRequest{createPayments}, request: apiRequest{method: http.MethodPut, path: "/api/v1/modules/1", body: `{"name":"Checkout","description":"Carts"}`}},
+		{name: "modules_delete", setup: []apiRequest{createPayments}, request: deletePayments},
+		{name: "modules_count", setup: []apiRequest{createPayments}, request: apiRequest{method: http.MethodGet, path: "/api/v1/modules/count"}},
+		{name: "modules_history", setup: []apiRequest{createPayments, submitPayments}, request: apiRequest{method: http.MethodGet, path: "/api/v1/modules/1/history"}},
+		{name: "modules_trash", setup: []apiRequest{createPayments, deletePayments}, request: apiRequest{method: http.MethodGet, path: "/api/v1/modules/trash"}},
+		{name: "modules_trash_purge", setup: []apiRequest{createPayments, deletePayments}, request: apiRequest{method: http.MethodPost, path: "/api/v1/modules/trash/purge", body: `{"ids":[1,2]}`}},
+
+		// Response styles
+		{name: "modules_get_snake_case", setup: []apiRequest{createPayments}, request: apiRequest{method: http.MethodGet, path: "/api/v1/modules/1", header: map[string]string{"Accept": "application/json; profile=snake_case"}}},
+		{name: "modules_get_raw", setup: []apiRequest{createPayments}, request: apiRequest{method: http.MethodGet, path: "/api/v1/modules/1", header: map[string]string{"X-Response-Format": "raw"}}},
+		{name: "modules_get_fields", setup: []apiRequest{createPayments}, request: apiRequest{method: http.MethodGet, path: "/api/v1/modules/1?fields=id,name"}},
+
+		// Related resources
+		{name: "tags_list", setup: []apiRequest{createPayments, createTag, tagPayments}, request: apiRequest{method: http.MethodGet, path: "/api/v1/tags"}},
+		{name: "tags_create", request: createTag},
+		{name: "tags_create_invalid", request: apiRequest{method: http.MethodPost, path: "/api/v1/tags", body: `{"name":""}`}},
+		{name: "module_tags_add", setup: []apiRequest{createPayments, createTag}, request: tagPayments},
+		{name: "module_tags_add_unknown", setup: []apiRequest{createPayments}, request: tagPayments},
+		{name: "module_notes_add", setup: []apiRequest{createPayments}, request: addNote},
+		{name: "module_notes_list", setup: []apiRequest{createPayments, addNote}, request: apiRequest{method: http.MethodGet, path: "/api/v1/modules/1/notes"}},
+		{name: "module_dependencies_add", setup: []apiRequest{createPayments, createBilling}, request: addDepends},
+		{name: "module_dependencies_cycle", setup: []apiRequest{createPayments, createBilling, addDepends}, request: apiRequest{method: http.MethodPut, path: "/api/v1/modules/2/dependencies/1"}},
+		{name: "module_settings_get", setup: []apiRequest{createPayments}, request: apiRequest{method: http.MethodGet, path: "/api/v1/modules/1/settings"}},
+
+		// Routing
+		{name: "route_not_found", request: apiRequest{method: http.MethodGet, path: "/api/v1/unknown"}},
+		{name: "method_not_allowed", request: apiRequest{method: http.MethodPatch, path: "/api/v1/modules"}},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			engine := newTestRouter(t)
+			for _, call := range scenario.setup {
+				mustServe(t, engine, call)
+			}
+			recorder := serve(t, engine, scenario.request)
+
+			body := "null"
+			if recorder.Body.Len() > 0 {
+				body = recorder.Body.String()
+			}
+			got := canonicalJSON(t, []byte(`{"status":`+strconv.Itoa(recorder.Code)+`,"body":`+body+`}`))
+
+			path := filepath.Join(goldenDir, scenario.name+".json")
+			if *updateGolden {
+				if err := os.MkdirAll(goldenDir, 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("%v (run with -update to create it)", err)
+			}
+			if got != string(want) {
+				t.Errorf("response differs from %s:\n%s\nwant\n%s", path, got, want)
+			}
+		})
+	}
+}
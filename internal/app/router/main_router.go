@@ -1,33 +1,230 @@
 package router
 
 import (
+	"go_di_architecture/internal/app/activity"
+	"go_di_architecture/internal/app/auth"
+	"go_di_architecture/internal/app/container"
+	"go_di_architecture/internal/app/handlers"
+	"go_di_architecture/internal/domain/cdn"
+	"go_di_architecture/internal/i18n"
+	"go_di_architecture/internal/logging"
 	"go_di_architecture/internal/middleware"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	swaggerFiles "github.com/swaggo/files"
-	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
+// Options configures the global middleware of the router.
+type Options struct {
+	// Request ID strategy (middleware.RequestIDUUID when empty)
+	RequestIDStrategy string
+
+	// Receiver of module usage counts (nil disables counting)
+	UsageRecorder middleware.UsageRecorder
+
+	// Source of request principals (nil grants every request the scopes of
+	// Anonymous)
+	Authenticator auth.Authenticator
+
+	// Principal of every request while Authenticator is nil (nil reads but
+	// changes nothing, see auth.AnonymousScopes)
+	Anonymous *auth.Principal
+
+	// Request quota enforcement of API keys (nil disables quotas)
+	QuotaLimiter middleware.QuotaLimiter
+
+	// Fault injection rules (none disables the chaos middleware)
+	Chaos []middleware.ChaosRule
+
+	// Reports whether the instance has started and warmed up (nil is always ready)
+	Readiness func() bool
+
+	// Health of the dependencies by name, e.g. "database"; /ready reports the
+	// instance degraded while one returns an error (nil checks none)
+	Dependencies map[string]func() error
+
+	// Whether write requests run in the unit of work of their scope
+	// (requires the container to register middleware.UnitOfWorkComponent)
+	UnitOfWork bool
+
+	// Counter of the database statements of a request (nil disables the
+	// query budget)
+	QueryTracker middleware.QueryTracker
+
+	// Statements a request may run before a warning about N+1 queries
+	QueryBudget int
+
+	// Duration from which a request is logged with a breakdown of its
+	// middleware, handler and SQL timings (zero disables the tracing)
+	SlowRequestThreshold time.Duration
+
+	// Tracer of the database statements of a request for the breakdown of
+	// slow requests (nil leaves SQL out of it)
+	StatementTracer middleware.StatementTracer
+
+	// Sampler of the access log (nil logs every request)
+	LogSampler *logging.Sampler
+
+	// Message bundles localizing validation errors (nil reports them in English)
+	Messages *i18n.Bundle
+
+	// Recorder of server errors, slow requests and the compliance of the
+	// routes with their objectives for the dashboards (nil records nothing)
+	Activity *activity.Recorder
+
+	// Rate limiter of the public API (nil disables the public API)
+	PublicRateLimiter *middleware.RateLimiter
+
+	// How long public API responses may be cached
+	PublicCacheMaxAge time.Duration
+
+	// CDN receiving the surrogate keys of written resources (nil purges nothing)
+	CDNPurger cdn.Purger
+
+	// How long a CDN may serve API reads (zero keeps shared caches from storing them)
+	CDNMaxAge time.Duration
+
+	// Whether and how the API documentation is served
+	Swagger SwaggerOptions
+}
+
 // SetupRouter configures the complete routing structure for the application.
-func SetupRouter(r *gin.Engine) {
+//
+// The container may be nil when components are wired at compile time
+// (google/wire); request scopes are then disabled and handlers build their
+// request-bound helpers directly.
+//
+// The routes come from the routable handlers (see handlers.Routable), which
+// declare the group, path, required scopes and middleware of each route; the
+// router mounts the groups with their middleware and registers the routes in
+// them. The principal is identified once per request by the authenticator of
+// the options.
+//
+// With a slow request threshold, every middleware and route handler after
+// the request ID is timed for the breakdown of slow requests.
+func SetupRouter(r *gin.Engine, c *container.Container, opts Options, routables []handlers.Routable) {
 	// Global middleware handlers
-	r.Use(middleware.RequestIDHandler())
-	r.Use(middleware.ExceptionHandler())
-	// r.Use(middleware.LoggingHandler())
+	if c != nil {
+		// Terminal handler: disposes the request scope after all others
+		r.Use(middleware.ScopeDisposalHandler())
+	}
+	r.Use(middleware.RequestIDHandler(opts.RequestIDStrategy))
+	step := func(handler gin.HandlerFunc) gin.HandlerFunc { return handler }
+	if opts.SlowRequestThreshold > 0 {
+		r.Use(middleware.SlowRequestTraceHandler(opts.StatementTracer, opts.SlowRequestThreshold))
+		step = middleware.TraceStep
+	}
+	r.Use(step(middleware.LoggingHandler(opts.LogSampler)))
+	if opts.Activity != nil {
+		r.Use(step(middleware.ActivityHandler(opts.Activity)))
+	}
+	if opts.QueryTracker != nil {
+		r.Use(step(middleware.QueryBudgetHandler(opts.QueryTracker, opts.QueryBudget)))
+	}
+	r.Use(step(middleware.ExceptionHandler()))
+	if opts.Messages != nil {
+		r.Use(step(middleware.LocaleHandler(opts.Messages)))
+	}
+	if len(opts.Chaos) > 0 {
+		r.Use(step(middleware.ChaosHandler(opts.Chaos)))
+	}
+	r.Use(step(middleware.AuthenticationHandler(opts.Authenticator, opts.Anonymous)))
+	if c != nil {
+		r.Use(step(middleware.RequestScopeHandler(c)))
+		if opts.UnitOfWork {
+			r.Use(step(middleware.UnitOfWorkHandler()))
+		}
+	}
 
 	// Versioned API routes
-	v1 := r.Group("/api/v1")
-	{
-		// Module routes
-		SetupModuleRoutes(v1)
+	v1 := r.Group(apiPrefix)
+	if opts.QuotaLimiter != nil {
+		v1.Use(step(middleware.QuotaHandler(opts.QuotaLimiter)))
+	}
+	if opts.UsageRecorder != nil {
+		v1.Use(step(middleware.UsageHandler(opts.UsageRecorder)))
+	}
+	purger := opts.CDNPurger
+	if purger == nil {
+		purger = cdn.Discard
+	}
+	v1.Use(step(middleware.EdgeCacheHandler(purger, opts.CDNMaxAge)))
+
+	// Route groups of the handlers with their path prefixes
+	groups := map[string]gin.IRoutes{
+		handlers.GroupAPI:  v1,
+		handlers.GroupRoot: r,
+		// Every operational route requires the admin scope
+		handlers.GroupAdmin: r.Group("/admin", step(RequireScope(auth.ScopeAdmin))),
+	}
+	prefixes := map[string]string{
+		handlers.GroupAPI:   apiPrefix,
+		handlers.GroupRoot:  "",
+		handlers.GroupAdmin: "/admin",
+	}
+
+	// Unauthenticated read-only routes (not counted against quotas): every
+	// client address is rate limited, and responses carry Cache-Control and
+	// the surrogate keys the writes of the versioned API purge
+	if opts.PublicRateLimiter != nil {
+		groups[handlers.GroupPublic] = r.Group("/public/v1",
+			step(middleware.RateLimitHandler(opts.PublicRateLimiter)),
+			step(middleware.CacheControlHandler(opts.PublicCacheMaxAge)),
+			step(middleware.EdgeCacheHandler(cdn.Discard, 0)),
+		)
+		prefixes[handlers.GroupPublic] = "/public/v1"
 	}
 
+	// Routes of the handlers
+	registerRoutes(groups, routables, step)
+	if opts.Activity != nil {
+		declareObjectives(opts.Activity, prefixes, routables)
+	}
+
+	// Admin dashboard page
+	SetupAdminUIRoutes(r)
+
 	// Health check endpoint
 	r.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"status": "ok"})
 	})
 
+	// Readiness check endpoint: 503 until startup and warm-up are done, while
+	// a dependency is unhealthy and again while stopping
+	r.GET("/ready", func(c *gin.Context) {
+		if opts.Readiness != nil && !opts.Readiness() {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"status": "not ready"})
+			return
+		}
+		if failures := dependencyFailures(opts.Dependencies); len(failures) > 0 {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"status": "degraded", "dependencies": failures})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"status": "ready"})
+	})
+
 	// Swagger documentation
-	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	SetupSwaggerRoutes(r, opts.Swagger)
+
+	// OpenAPI document generated from the routes
+	SetupOpenAPIRoutes(r, opts.Swagger, prefixes, routables)
+
+	// Discovery document of the versioned API
+	SetupDiscoveryRoutes(r)
+
+	// Enveloped 404 and 405 responses, OPTIONS for every known path
+	SetupFallbackRoutes(r)
+}
+
+// dependencyFailures runs the dependency checks and returns the errors of
+// the failing ones by name.
+func dependencyFailures(checks map[string]func() error) map[string]string {
+	failures := make(map[string]string)
+	for name, check := range checks {
+		if err := check(); err != nil {
+			failures[name] = err.Error()
+		}
+	}
+	return failures
 }
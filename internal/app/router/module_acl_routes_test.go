@@ -0,0 +1,78 @@
+package router_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// Actors of the ACL cases
+var (
+	asJane = map[string]string{"X-Actor": "jane"}
+	asBob  = map[string]string{"X-Actor": "bob"}
+)
+
+// TestModuleACLHidesSubresources checks a module whose ACL leaves a caller
+// out is reported missing by every sub-resource endpoint, and left out of
+// the count and stats aggregates of that caller.
+func TestModuleACLHidesSubresources(t *testing.T) {
+	engine := newTestRouter(t)
+	for _, call := range []apiRequest{
+		{method: http.MethodPost, path: "/api/v1/modules", body: `{"name":"Payments","description":"Fees"}`, header: asJane},
+		{method: http.MethodPut, path: "/api/v1/modules/1/acl", body: `{"entries":[{"principal":"user:jane","permission":"edit"}]}`, header: asJane},
+		{method: http.MethodPost, path: "/api/v1/modules", body: `{"name":"Billing","description":"Invoices"}`, header: asBob},
+		{method: http.MethodPost, path: "/api/v1/tags", body: `{"name":"core"}`, header: asBob},
+	} {
+		mustServe(t, engine, call)
+	}
+
+	hidden := []apiRequest{
+		{method: http.MethodGet, path: "/api/v1/modules/1/notes"},
+		{method: http.MethodPost, path: "/api/v1/modules/1/notes", body: `{"body":"Rotated the credentials"}`},
+		{method: http.MethodDelete, path: "/api/v1/modules/1/notes/1"},
+		{method: http.MethodGet, path: "/api/v1/modules/1/settings"},
+		{method: http.MethodPut, path: "/api/v1/modules/1/settings", body: `{}`},
+		{method: http.MethodGet, path: "/api/v1/modules/1/tags"},
+		{method: http.MethodPut, path: "/api/v1/modules/1/tags/core"},
+		{method: http.MethodDelete, path: "/api/v1/modules/1/tags/core"},
+		{method: http.MethodGet, path: "/api/v1/modules/1/dependencies"},
+		{method: http.MethodGet, path: "/api/v1/modules/1/dependents"},
+		{method: http.MethodPut, path: "/api/v1/modules/2/dependencies/1"},
+		{method: http.MethodPut, path: "/api/v1/modules/1/dependencies/2"},
+		{method: http.MethodDelete, path: "/api/v1/modules/2/dependencies/1"},
+	}
+	for _, call := range hidden {
+		t.Run(call.method+" "+call.path, func(t *testing.T) {
+			call.header = asBob
+			recorder := serve(t, engine, call)
+			if recorder.Code != http.StatusNotFound || recorder.Body.String() != moduleNotFound {
+				t.Errorf("status %d, body %s, want 404 %s", recorder.Code, recorder.Body, moduleNotFound)
+			}
+		})
+	}
+
+	// The owner still reaches the sub-resources
+	if recorder := serve(t, engine, apiRequest{method: http.MethodGet, path: "/api/v1/modules/1/notes", header: asJane}); recorder.Code != http.StatusOK {
+		t.Errorf("notes of the owner: status %d, body %s, want 200", recorder.Code, recorder.Body)
+	}
+
+	aggregates := []struct {
+		name   string
+		header map[string]string
+		path   string
+		want   string
+	}{
+		{name: "count for bob", header: asBob, path: "/api/v1/modules/count", want: `"data":{"count":1}`},
+		{name: "count for jane", header: asJane, path: "/api/v1/modules/count", want: `"data":{"count":2}`},
+		{name: "stats for bob", header: asBob, path: "/api/v1/modules/stats", want: `"totalModules":1`},
+		{name: "stats for jane", header: asJane, path: "/api/v1/modules/stats", want: `"totalModules":2`},
+	}
+	for _, tt := range aggregates {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := serve(t, engine, apiRequest{method: http.MethodGet, path: tt.path, header: tt.header})
+			if recorder.Code != http.StatusOK || !strings.Contains(recorder.Body.String(), tt.want) {
+				t.Errorf("status %d, body %s, want 200 with %s", recorder.Code, recorder.Body, tt.want)
+			}
+		})
+	}
+}
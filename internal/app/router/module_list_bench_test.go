@@ -0,0 +1,33 @@
+package router_test
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+// BenchmarkListModulesEndpoint serves full pages of the module list through
+// the router of the test profile from concurrent clients. Compare the
+// encoders by running it with and without the go_json build tag:
+//
+//	go test -run '^$' -bench ListModulesEndpoint -benchmem ./internal/app/router
+//	go test -run '^$' -bench ListModulesEndpoint -benchmem -tags go_json ./internal/app/router
+func BenchmarkListModulesEndpoint(b *testing.B) {
+	engine := newTestRouter(b)
+	for i := 1; i <= 100; i++ {
+		body := `{"name":"Module ` + strconv.Itoa(i) + `","description":"Handles product stock management"}`
+		mustServe(b, engine, apiRequest{method: http.MethodPost, path: "/api/v1/modules", body: body})
+	}
+	list := apiRequest{method: http.MethodGet, path: "/api/v1/modules?pageSize=100"}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if recorder := serve(b, engine, list); recorder.Code != http.StatusOK {
+				b.Errorf("status %d: %s", recorder.Code, recorder.Body)
+				return
+			}
+		}
+	})
+}
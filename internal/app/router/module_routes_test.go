@@ -0,0 +1,262 @@
+package router_test
+
+import (
+	"net/http"
+	"testing"
+)
+
+// Requests creating the modules the cases start from
+var (
+	createPayments = apiRequest{method: http.MethodPost, path: "/api/v1/modules", body: `{"name":"Payments","description":"Fees"}`}
+	createBilling  = apiRequest{method: http.MethodPost, path: "/api/v1/modules", body: `{"name":"Billing","description":"Invoices"}`}
+	deletePayments = apiRequest{method: http.MethodDelete, path: "/api/v1/modules/1"}
+	submitPayments = apiRequest{method: http.MethodPost, path: "/api/v1/modules/1/submit"}
+)
+
+// Envelope parts shared by the expected responses
+const (
+	testMeta       = `"meta":{"requestId":"test-request","timestamp":"2024-01-01T00:00:00Z"}`
+	paymentsDraft  = `{"id":1,"name":"Payments","description":"Fees","isActive":false,"status":"draft","activateAt":null,"deactivateAt":null,"owner":"anonymous","createdAt":"<time>","updatedAt":"<time>"}`
+	billingDraft   = `{"id":2,"name":"Billing","description":"Invoices","isActive":false,"status":"draft","activateAt":null,"deactivateAt":null,"owner":"anonymous","createdAt":"<time>","updatedAt":"<time>"}`
+	moduleNotFound = `{"success":false,"message":"Module not found","error":{"code":"NOT_FOUND","message":"Module not found","messageKey":"module.not_found"},` + testMeta + `}`
+)
+
+// TestModuleRoutes checks the exact response of every module endpoint for
+// its success, validation, conflict and not-found cases.
+func TestModuleRoutes(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      []apiRequest
+		request    apiRequest
+		wantStatus int
+		wantBody   string
+	}{
+		// Collection
+		{
+			name:       "list empty",
+			request:    apiRequest{method: http.MethodGet, path: "/api/v1/modules"},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"success":true,"message":"Operation completed successfully","data":[],"meta":{"requestId":"test-request","timestamp":"2024-01-01T00:00:00Z","pagination":{"page":1,"pageSize":20,"totalItems":0,"totalPages":0}}}`,
+		},
+		{
+			name:       "list page",
+			setup:      []apiRequest{createPayments, createBilling},
+			request:    apiRequest{method: http.MethodGet, path: "/api/v1/modules?page=2&pageSize=1"},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"success":true,"message":"Operation completed successfully","data":[` + billingDraft + `],"meta":{"requestId":"test-request","timestamp":"2024-01-01T00:00:00Z","pagination":{"page":2,"pageSize":1,"totalItems":2,"totalPages":2}}}`,
+		},
+		{
+			name:       "count",
+			setup:      []apiRequest{createPayments, createBilling},
+			request:    apiRequest{method: http.MethodGet, path: "/api/v1/modules/count"},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"success":true,"message":"Operation completed successfully","data":{"count":2},` + testMeta + `}`,
+		},
+		{
+			name:       "count with a malformed filter",
+			request:    apiRequest{method: http.MethodGet, path: "/api/v1/modules/count?isActive=maybe"},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"success":false,"message":"Invalid request parameters","error":{"code":"VALIDATION_ERROR","message":"Invalid request parameters","details":{"isActive":["Value must be true or false"]}},` + testMeta + `}`,
+		},
+		{
+			name:       "batch with a malformed ID",
+			request:    apiRequest{method: http.MethodGet, path: "/api/v1/modules?ids=1,x", header: map[string]string{"Accept-Language": "de"}},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"success":false,"message":"Invalid request parameters","error":{"code":"VALIDATION_ERROR","message":"Invalid request parameters","details":{"ids":["Die IDs müssen positive ganze Zahlen sein, durch Kommas getrennt"]}},` + testMeta + `}`,
+		},
+		{
+			name:       "list with a malformed cursor",
+			request:    apiRequest{method: http.MethodGet, path: "/api/v1/modules?cursor=bogus", header: map[string]string{"Accept-Language": "es"}},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"success":false,"message":"Invalid request parameters","error":{"code":"VALIDATION_ERROR","message":"Invalid request parameters","details":{"cursor":["El cursor está mal formado o ha caducado"]}},` + testMeta + `}`,
+		},
+		{
+			name:       "list with an unknown relation",
+			request:    apiRequest{method: http.MethodGet, path: "/api/v1/modules?include=owners", header: map[string]string{"Accept-Language": "fr"}},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"success":false,"message":"Invalid request parameters","error":{"code":"VALIDATION_ERROR","message":"Invalid request parameters","details":{"include":["Relation inconnue, attendu : tags, permissions"]}},` + testMeta + `}`,
+		},
+		{
+			name:       "create",
+			request:    createPayments,
+			wantStatus: http.StatusCreated,
+			wantBody:   `{"success":true,"message":"Resource created successfully","data":` + paymentsDraft + `,` + testMeta + `}`,
+		},
+		{
+			name:       "create with a short name",
+			request:    apiRequest{method: http.MethodPost, path: "/api/v1/modules", body: `{"name":"x"}`},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"success":false,"message":"Invalid request parameters","error":{"code":"VALIDATION_ERROR","message":"Invalid request parameters","details":{"Name":["Value is too short"]}},` + testMeta + `}`,
+		},
+		{
+			name:       "create with a malformed body",
+			request:    apiRequest{method: http.MethodPost, path: "/api/v1/modules", body: `{`},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"success":false,"message":"Invalid request parameters","error":{"code":"VALIDATION_ERROR","message":"Invalid request parameters"},` + testMeta + `}`,
+		},
+		{
+			name:       "create active without approval",
+			request:    apiRequest{method: http.MethodPost, path: "/api/v1/modules", body: `{"name":"Payments","isActive":true}`},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"success":false,"message":"Module must be approved before it can be active","error":{"code":"VALIDATION_ERROR","message":"Module must be approved before it can be active","messageKey":"module.not_approved","details":{"isActive":["Module must be approved before it can be active"]}},` + testMeta + `}`,
+		},
+		{
+			name:       "create with a taken name",
+			setup:      []apiRequest{createPayments},
+			request:    apiRequest{method: http.MethodPost, path: "/api/v1/modules", body: `{"name":"PAYMENTS"}`},
+			wantStatus: http.StatusConflict,
+			wantBody:   `{"success":false,"message":"Module name already exists","error":{"code":"RESOURCE_CONFLICT","message":"Module name already exists","messageKey":"module.name_exists","context":{"conflictingId":1,"suggestions":["PAYMENTS-2","PAYMENTS-3","PAYMENTS-4"]}},` + testMeta + `}`,
+		},
+
+		// Resource
+		{
+			name:       "get",
+			setup:      []apiRequest{createPayments},
+			request:    apiRequest{method: http.MethodGet, path: "/api/v1/modules/1"},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"success":true,"message":"Operation completed successfully","data":` + paymentsDraft + `,` + testMeta + `}`,
+		},
+		{
+			name:       "get unknown",
+			request:    apiRequest{method: http.MethodGet, path: "/api/v1/modules/99"},
+			wantStatus: http.StatusNotFound,
+			wantBody:   moduleNotFound,
+		},
+		{
+			name:       "get with a malformed ID",
+			request:    apiRequest{method: http.MethodGet, path: "/api/v1/modules/abc"},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"success":false,"message":"Invalid request parameters","error":{"code":"VALIDATION_ERROR","message":"Invalid request parameters","details":{"id":["Value must be an integer"]}},` + testMeta + `}`,
+		},
+		{
+			name:       "head with a malformed ID",
+			request:    apiRequest{method: http.MethodHead, path: "/api/v1/modules/0"},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"success":false,"message":"Invalid request parameters","error":{"code":"VALIDATION_ERROR","message":"Invalid request parameters","details":{"id":["Value must be at least 1"]}},` + testMeta + `}`,
+		},
+		{
+			name:       "update",
+			setup:      []apiRequest{createPayments},
+			request:    apiRequest{method: http.MethodPut, path: "/api/v1/modules/1", body: `{"name":"Checkout","description":"Carts"}`},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"success":true,"message":"Operation completed successfully","data":{"id":1,"name":"Checkout","description":"Carts","isActive":false,"status":"draft","activateAt":null,"deactivateAt":null,"owner":"anonymous","createdAt":"<time>","updatedAt":"<time>"},` + testMeta + `}`,
+		},
+		{
+			name:       "update unknown",
+			request:    apiRequest{method: http.MethodPut, path: "/api/v1/modules/99", body: `{"name":"Checkout"}`},
+			wantStatus: http.StatusNotFound,
+			wantBody:   moduleNotFound,
+		},
+		{
+			name:       "update to a taken name",
+			setup:      []apiRequest{createPayments, createBilling},
+			request:    apiRequest{method: http.MethodPut, path: "/api/v1/modules/2", body: `{"name":"payments"}`},
+			wantStatus: http.StatusConflict,
+			wantBody:   `{"success":false,"message":"Module name already exists","error":{"code":"RESOURCE_CONFLICT","message":"Module name already exists","messageKey":"module.name_exists","context":{"conflictingId":1,"suggestions":["payments-2","payments-3","payments-4"]}},` + testMeta + `}`,
+		},
+		{
+			name:       "delete",
+			setup:      []apiRequest{createPayments},
+			request:    deletePayments,
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "get deleted",
+			setup:      []apiRequest{createPayments, deletePayments},
+			request:    apiRequest{method: http.MethodGet, path: "/api/v1/modules/1"},
+			wantStatus: http.StatusNotFound,
+			wantBody:   moduleNotFound,
+		},
+		{
+			name:       "delete unknown",
+			request:    apiRequest{method: http.MethodDelete, path: "/api/v1/modules/99"},
+			wantStatus: http.StatusNotFound,
+			wantBody:   moduleNotFound,
+		},
+
+		// Approval workflow
+		{
+			name:       "submit",
+			setup:      []apiRequest{createPayments},
+			request:    submitPayments,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"success":true,"message":"Operation completed successfully","data":{"id":1,"name":"Payments","description":"Fees","isActive":false,"status":"pending","activateAt":null,"deactivateAt":null,"owner":"anonymous","createdAt":"<time>","updatedAt":"<time>"},` + testMeta + `}`,
+		},
+		{
+			name:       "approve",
+			setup:      []apiRequest{createPayments, submitPayments},
+			request:    apiRequest{method: http.MethodPost, path: "/api/v1/modules/1/approve"},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"success":true,"message":"Operation completed successfully","data":{"id":1,"name":"Payments","description":"Fees","isActive":true,"status":"approved","activateAt":null,"deactivateAt":null,"owner":"anonymous","createdAt":"<time>","updatedAt":"<time>"},` + testMeta + `}`,
+		},
+		{
+			name:       "approve unknown",
+			request:    apiRequest{method: http.MethodPost, path: "/api/v1/modules/99/approve"},
+			wantStatus: http.StatusNotFound,
+			wantBody:   moduleNotFound,
+		},
+
+		// Change history
+		{
+			name:       "history",
+			setup:      []apiRequest{createPayments, submitPayments},
+			request:    apiRequest{method: http.MethodGet, path: "/api/v1/modules/1/history"},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"success":true,"message":"Operation completed successfully","data":[{"revision":1,"action":"create","actor":"anonymous","changedAt":"<time>","changes":[{"field":"name","old":null,"new":"Payments"},{"field":"description","old":null,"new":"Fees"},{"field":"isActive","old":null,"new":false},{"field":"status","old":null,"new":"draft"},{"field":"owner","old":null,"new":"anonymous"}]},{"revision":2,"action":"submit","actor":"anonymous","changedAt":"<time>","changes":[{"field":"status","old":"draft","new":"pending"}]}],"meta":{"requestId":"test-request","timestamp":"2024-01-01T00:00:00Z","pagination":{"page":1,"pageSize":20,"totalItems":2,"totalPages":1}}}`,
+		},
+
+		// Recycle bin
+		{
+			name:       "list trash",
+			setup:      []apiRequest{createPayments, deletePayments},
+			request:    apiRequest{method: http.MethodGet, path: "/api/v1/modules/trash"},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"success":true,"message":"Operation completed successfully","data":[{"id":1,"name":"Payments","description":"Fees","isActive":false,"status":"draft","activateAt":null,"deactivateAt":null,"owner":"anonymous","createdAt":"<time>","updatedAt":"<time>","deletedAt":"<time>","deletedBy":"anonymous"}],"meta":{"requestId":"test-request","timestamp":"2024-01-01T00:00:00Z","pagination":{"page":1,"pageSize":20,"totalItems":1,"totalPages":1}}}`,
+		},
+		{
+			name:       "restore",
+			setup:      []apiRequest{createPayments, deletePayments},
+			request:    apiRequest{method: http.MethodPost, path: "/api/v1/modules/trash/restore", body: `{"ids":[1]}`},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"success":true,"message":"Operation completed successfully","data":[` + paymentsDraft + `],` + testMeta + `}`,
+		},
+		{
+			name:       "purge",
+			setup:      []apiRequest{createPayments, deletePayments},
+			request:    apiRequest{method: http.MethodPost, path: "/api/v1/modules/trash/purge", body: `{"ids":[1,2]}`},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"success":true,"message":"Operation completed successfully","data":[1],"meta":{"requestId":"test-request","timestamp":"2024-01-01T00:00:00Z","missingIds":[2]}}`,
+		},
+
+		// Unknown routes keep the envelope
+		{
+			name:       "unknown route",
+			request:    apiRequest{method: http.MethodGet, path: "/api/v1/unknown"},
+			wantStatus: http.StatusNotFound,
+			wantBody:   `{"success":false,"message":"Resource not found","error":{"code":"ROUTE_NOT_FOUND","message":"Resource not found"},` + testMeta + `}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			engine := newTestRouter(t)
+			for _, call := range test.setup {
+				mustServe(t, engine, call)
+			}
+
+			recorder := serve(t, engine, test.request)
+			if recorder.Code != test.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", recorder.Code, test.wantStatus, recorder.Body)
+			}
+			if test.wantBody == "" {
+				if recorder.Body.Len() != 0 {
+					t.Errorf("body = %s, want none", recorder.Body)
+				}
+				return
+			}
+			if got, want := canonicalJSON(t, recorder.Body.Bytes()), canonicalJSON(t, []byte(test.wantBody)); got != want {
+				t.Errorf("body =\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
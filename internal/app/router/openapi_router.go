@@ -0,0 +1,124 @@
+package router
+
+import (
+	"net/http"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"go_di_architecture/internal/app/auth"
+	"go_di_architecture/internal/app/handlers"
+	"go_di_architecture/internal/app/openapi"
+	"go_di_architecture/internal/domain/models/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// openAPIInfo describes the API in the generated document.
+var openAPIInfo = openapi.Info{
+	Title:       "Module API",
+	Description: "API for managing module entities in the system",
+	Version:     "1.0",
+}
+
+// closureName matches the names the compiler gives to function literals.
+var closureName = regexp.MustCompile(`^func[0-9]+$`)
+
+// SetupOpenAPIRoutes configures the OpenAPI document generated from the routes.
+//
+// Route Structure:
+//
+//	GET /openapi.json - OpenAPI 3.1 document of the handler routes
+//
+// Unlike the Swagger document, which is generated from annotations by swag
+// init, this document is built at startup from the route specs of the
+// handlers, the DTOs they name and the error code registry (see package
+// openapi), so it always matches the registered routes. It is served and
+// protected like the Swagger documentation; its server URL is the
+// deployment's host and scheme.
+//
+// Parameters:
+//   - r: The engine to register the route on
+//   - opts: Whether and how the documentation is served
+//   - prefixes: Path prefix of each mounted route group
+//   - routables: Handlers whose routes are documented
+func SetupOpenAPIRoutes(r *gin.Engine, opts SwaggerOptions, prefixes map[string]string, routables []handlers.Routable) {
+	if opts.Disabled {
+		return
+	}
+
+	document := openapi.Build(openAPIInfo, documentedRoutes(prefixes, routables), response.Errors.Codes())
+	chain := append(append([]gin.HandlerFunc{}, opts.Guard...), func(c *gin.Context) {
+		// Render a copy, since requests with different hosts run concurrently
+		doc := *document
+		doc.Servers = []openapi.Server{{URL: serverURL(c, opts)}}
+		c.JSON(http.StatusOK, &doc)
+	})
+	r.GET("/openapi.json", chain...)
+}
+
+// documentedRoutes describes the routes of the handlers in mounted groups.
+func documentedRoutes(prefixes map[string]string, routables []handlers.Routable) []openapi.Route {
+	var routes []openapi.Route
+	for _, routable := range routables {
+		for _, spec := range routable.Routes() {
+			group := spec.Group
+			if group == "" {
+				group = handlers.GroupAPI
+			}
+			prefix, ok := prefixes[group]
+			if !ok {
+				continue
+			}
+
+			route := openapi.Route{
+				Method:   spec.Method,
+				Path:     prefix + spec.Path,
+				Name:     handlerName(spec.Handler),
+				Summary:  spec.Summary,
+				Body:     spec.Body,
+				Query:    spec.Query,
+				Response: spec.Response,
+				Status:   spec.Status,
+			}
+			switch {
+			case spec.Policy != nil:
+				route.Secured, route.Scopes = true, spec.Policy.Scopes
+			case group == handlers.GroupAdmin:
+				route.Secured, route.Scopes = true, []string{auth.ScopeAdmin}
+			}
+			routes = append(routes, route)
+		}
+	}
+	return routes
+}
+
+// handlerName returns the method name of a handler (e.g. "ListModules"),
+// empty for function literals.
+func handlerName(handler gin.HandlerFunc) string {
+	fn := runtime.FuncForPC(reflect.ValueOf(handler).Pointer())
+	if fn == nil {
+		return ""
+	}
+	name := strings.TrimSuffix(fn.Name(), "-fm")
+	name = name[strings.LastIndex(name, ".")+1:]
+	if closureName.MatchString(name) {
+		return ""
+	}
+	return name
+}
+
+// serverURL returns the URL the API is reached at, from the options or the
+// request.
+func serverURL(c *gin.Context, opts SwaggerOptions) string {
+	host := opts.Host
+	if host == "" {
+		host = c.Request.Host
+	}
+	scheme := requestScheme(c)
+	if len(opts.Schemes) > 0 {
+		scheme = opts.Schemes[0]
+	}
+	return scheme + "://" + host
+}
@@ -0,0 +1,24 @@
+package router
+
+import (
+	"go_di_architecture/internal/app/auth"
+	"go_di_architecture/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireScope declares the scopes a route requires.
+//
+// The returned handler goes before the route handler, so policies are visible
+// in the route table instead of being checked inside handlers:
+//
+//	modules.POST("", RequireScope(auth.ScopeModulesWrite), handler.CreateModule)
+//
+// Parameters:
+//   - scopes: Scopes the caller must all hold (none only requires authentication)
+//
+// Returns:
+//   - gin.HandlerFunc: A handler rejecting callers with 401 or 403 envelopes
+func RequireScope(scopes ...string) gin.HandlerFunc {
+	return middleware.AuthorizationHandler(auth.Policy{Scopes: scopes})
+}
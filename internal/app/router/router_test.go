@@ -0,0 +1,141 @@
+package router_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"go_di_architecture/internal/app/bootstrap"
+	"go_di_architecture/internal/app/container"
+	"go_di_architecture/internal/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// testRequestID is sent as X-Request-Id, so meta.requestId is predictable.
+const testRequestID = "test-request"
+
+// volatileFields are the payload fields set from the wall clock, in both
+// naming styles, with the placeholder replacing their values in canonical
+// JSON; meta.timestamp is not among them, as the test profile freezes the
+// application clock.
+var volatileFields = map[string]string{
+	"createdAt":    "<time>",
+	"updatedAt":    "<time>",
+	"deletedAt":    "<time>",
+	"changedAt":    "<time>",
+	"generatedAt":  "<time>",
+	"created_at":   "<time>",
+	"updated_at":   "<time>",
+	"deleted_at":   "<time>",
+	"changed_at":   "<time>",
+	"generated_at": "<time>",
+
+	// Keyset cursors encode the creation time of the last module
+	"nextCursor":  "<cursor>",
+	"next_cursor": "<cursor>",
+}
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	os.Exit(m.Run())
+}
+
+// newTestRouter boots the full router of the test profile: in-memory
+// storage, a frozen clock and no authentication.
+func newTestRouter(t testing.TB) *gin.Engine {
+	t.Helper()
+	t.Setenv("APP_ENV", config.EnvTest)
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("config.Load() error = %v", err)
+	}
+	c, err := bootstrap.NewContainer(cfg)
+	if err != nil {
+		t.Fatalf("bootstrap.NewContainer() error = %v", err)
+	}
+	engine, err := container.Resolve[*gin.Engine](c, bootstrap.HTTPRouter)
+	if err != nil {
+		t.Fatalf("resolve router: %v", err)
+	}
+	return engine
+}
+
+// apiRequest is one call of the API.
+type apiRequest struct {
+	method string
+	path   string
+	body   string
+
+	// Additional request headers, e.g. Accept or X-Response-Format
+	header map[string]string
+}
+
+// serve sends the request through the router.
+func serve(t testing.TB, engine *gin.Engine, call apiRequest) *httptest.ResponseRecorder {
+	t.Helper()
+	request := httptest.NewRequest(call.method, call.path, strings.NewReader(call.body))
+	if call.body != "" {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	for name, value := range call.header {
+		request.Header.Set(name, value)
+	}
+	request.Header.Set("X-Request-Id", testRequestID)
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, request)
+	return recorder
+}
+
+// mustServe sends a setup request and fails the test unless it succeeds.
+func mustServe(t testing.TB, engine *gin.Engine, call apiRequest) {
+	t.Helper()
+	if recorder := serve(t, engine, call); recorder.Code >= http.StatusBadRequest {
+		t.Fatalf("%s %s: status %d: %s", call.method, call.path, recorder.Code, recorder.Body)
+	}
+}
+
+// canonicalJSON re-encodes a JSON document with sorted object keys, two-space
+// indentation and the volatile fields masked, so documents compare equal
+// exactly when they carry the same fields and values.
+func canonicalJSON(t *testing.T, document []byte) string {
+	t.Helper()
+	decoder := json.NewDecoder(bytes.NewReader(document))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		t.Fatalf("invalid JSON %s: %v", document, err)
+	}
+
+	var out bytes.Buffer
+	encoder := json.NewEncoder(&out)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(maskVolatile(value)); err != nil {
+		t.Fatalf("encode %v: %v", value, err)
+	}
+	return out.String()
+}
+
+// maskVolatile replaces the non-null values of the volatile fields.
+func maskVolatile(value any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, field := range value {
+			if placeholder, ok := volatileFields[key]; ok && field != nil {
+				value[key] = placeholder
+				continue
+			}
+			value[key] = maskVolatile(field)
+		}
+	case []any:
+		for i, item := range value {
+			value[i] = maskVolatile(item)
+		}
+	}
+	return value
+}
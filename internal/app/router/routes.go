@@ -0,0 +1,93 @@
+package router
+
+import (
+	"fmt"
+
+	"go_di_architecture/internal/app/activity"
+	"go_di_architecture/internal/app/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DownloadPrefix is the path under which signed download links of the local storage are served.
+const DownloadPrefix = "/api/v1/downloads/"
+
+// registerRoutes mounts the routes of the handlers in their groups.
+//
+// Each route gets the handler chain policy, middleware, handler: the policy
+// (see RequireScope) runs first, so callers lacking a scope never reach the
+// route's own middleware. Routes of a group that is not mounted (e.g. the
+// public API when it is disabled) are skipped.
+//
+// Parameters:
+//   - groups: Mounted groups by name (handlers.GroupAPI, ...)
+//   - routables: Handlers whose routes to register
+//   - step: Wraps each handler of a chain, e.g. middleware.TraceStep to
+//     time it
+//
+// Panics on routes naming an unknown group, like gin does on conflicting
+// routes, so wiring mistakes stop the startup.
+func registerRoutes(groups map[string]gin.IRoutes, routables []handlers.Routable, step func(gin.HandlerFunc) gin.HandlerFunc) {
+	for _, routable := range routables {
+		for _, spec := range routable.Routes() {
+			name := spec.Group
+			if name == "" {
+				name = handlers.GroupAPI
+			}
+			group, ok := groups[name]
+			if !ok {
+				if name == handlers.GroupPublic {
+					continue
+				}
+				panic(fmt.Sprintf("router: %s %s of %T names unknown group %q", spec.Method, spec.Path, routable, name))
+			}
+
+			chain := make([]gin.HandlerFunc, 0, len(spec.Middleware)+2)
+			if spec.Policy != nil {
+				chain = append(chain, step(RequireScope(spec.Policy.Scopes...)))
+			}
+			for _, handler := range spec.Middleware {
+				chain = append(chain, step(handler))
+			}
+			chain = append(chain, step(spec.Handler))
+			group.Handle(spec.Method, spec.Path, chain...)
+		}
+	}
+}
+
+// declareObjectives tracks the routes of the handlers against their service
+// level objectives; routes of the versioned and public API without one get
+// activity.DefaultObjective.
+//
+// Parameters:
+//   - recorder: Recorder tracking the objectives
+//   - prefixes: Path prefixes of the mounted groups by name
+//   - routables: Handlers whose routes to track
+//
+// Panics on an invalid objective, like registerRoutes on an unknown group.
+func declareObjectives(recorder *activity.Recorder, prefixes map[string]string, routables []handlers.Routable) {
+	for _, routable := range routables {
+		for _, spec := range routable.Routes() {
+			name := spec.Group
+			if name == "" {
+				name = handlers.GroupAPI
+			}
+			prefix, ok := prefixes[name]
+			if !ok {
+				continue
+			}
+
+			objective := spec.Objective
+			if objective == nil && (name == handlers.GroupAPI || name == handlers.GroupPublic) {
+				objective = &activity.DefaultObjective
+			}
+			if objective == nil {
+				continue
+			}
+			if err := objective.Validate(); err != nil {
+				panic(fmt.Sprintf("router: objective of %s %s of %T: %v", spec.Method, spec.Path, routable, err))
+			}
+			recorder.DeclareObjective(spec.Method, prefix+spec.Path, *objective)
+		}
+	}
+}
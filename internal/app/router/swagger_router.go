@@ -0,0 +1,100 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+	"github.com/swaggo/swag"
+)
+
+// swaggerDocPath is the path of the Swagger document below /swagger.
+const swaggerDocPath = "/doc.json"
+
+// SwaggerOptions configures the API documentation.
+type SwaggerOptions struct {
+	// Hide the documentation (production)
+	Disabled bool
+
+	// Middleware protecting the documentation, e.g. gin.BasicAuth or
+	// RequireScope(auth.ScopeAdmin) (none serves it to everyone)
+	Guard []gin.HandlerFunc
+
+	// Host of the API in the document (empty uses the Host of the request)
+	Host string
+
+	// Base path of the API in the document (empty keeps the @BasePath annotation)
+	BasePath string
+
+	// Schemes of the API in the document (none uses the scheme of the request)
+	Schemes []string
+}
+
+// SetupSwaggerRoutes configures the Swagger UI and document.
+//
+// Route Structure:
+//
+//	GET /swagger/index.html - Swagger UI
+//	GET /swagger/doc.json   - Swagger document
+//
+// The document is the one registered by the swag-generated docs package; its
+// host, base path and schemes are replaced by the options on every request,
+// so the UI calls the API where it is actually deployed rather than where
+// the annotations say. Without a registered document doc.json answers 404.
+//
+// Parameters:
+//   - r: The engine to register the routes on
+//   - opts: Whether and how the documentation is served
+func SetupSwaggerRoutes(r *gin.Engine, opts SwaggerOptions) {
+	if opts.Disabled {
+		return
+	}
+
+	ui := ginSwagger.WrapHandler(swaggerFiles.Handler)
+	handlers := append(append([]gin.HandlerFunc{}, opts.Guard...), func(c *gin.Context) {
+		if c.Param("any") != swaggerDocPath {
+			ui(c)
+			return
+		}
+		swaggerDocument(c, opts)
+	})
+	r.GET("/swagger/*any", handlers...)
+}
+
+// swaggerDocument writes the registered document with the deployment's host,
+// base path and schemes.
+func swaggerDocument(c *gin.Context, opts SwaggerOptions) {
+	spec, ok := swag.GetSwagger(swag.Name).(*swag.Spec)
+	if !ok {
+		c.String(http.StatusNotFound, "API documentation not generated; run swag init")
+		return
+	}
+
+	// Render a copy, since requests with different hosts run concurrently
+	doc := *spec
+	doc.Host = opts.Host
+	if doc.Host == "" {
+		doc.Host = c.Request.Host
+	}
+	if opts.BasePath != "" {
+		doc.BasePath = opts.BasePath
+	}
+	doc.Schemes = opts.Schemes
+	if len(doc.Schemes) == 0 {
+		doc.Schemes = []string{requestScheme(c)}
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", []byte(doc.ReadDoc()))
+}
+
+// requestScheme returns the scheme the client used, honouring the
+// X-Forwarded-Proto header of TLS-terminating proxies.
+func requestScheme(c *gin.Context) string {
+	if proto := c.GetHeader("X-Forwarded-Proto"); proto == "http" || proto == "https" {
+		return proto
+	}
+	if c.Request.TLS != nil {
+		return "https"
+	}
+	return "http"
+}
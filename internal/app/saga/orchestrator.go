@@ -0,0 +1,131 @@
+package saga
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go_di_architecture/internal/app/lifecycle"
+	"go_di_architecture/internal/domain/models/workflow"
+	workflowService "go_di_architecture/internal/domain/service/workflow"
+	"go_di_architecture/internal/logging"
+)
+
+// logger writes the log lines of the saga orchestrator.
+var logger = logging.New("saga")
+
+// Orchestrator runs workflows (sagas) in the background.
+//
+// Each started workflow executes on a goroutine of its own; the workflow
+// service saves its state after every step. On shutdown the running steps
+// are cancelled and their workflows stay unfinished; on the next start the
+// orchestrator resumes every unfinished workflow from its saved state, so a
+// workflow is never left half done by a restart. With several instances
+// sharing a database every instance resumes the unfinished workflows, so
+// instances should be restarted one at a time.
+//
+// Lifecycle:
+//   - OnStart resumes the unfinished workflows
+//   - OnStop cancels the running workflows and waits for them to save
+type Orchestrator struct {
+	service *workflowService.WorkflowService
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New creates an orchestrator and registers its lifecycle hooks.
+//
+// Parameters:
+//   - lc: Application lifecycle to register start/stop hooks with
+//   - service: Workflow service executing the steps
+//
+// Returns:
+//   - *Orchestrator: The orchestrator (started by the lifecycle)
+func New(lc *lifecycle.Lifecycle, service *workflowService.WorkflowService) *Orchestrator {
+	ctx, cancel := context.WithCancel(context.Background())
+	o := &Orchestrator{service: service, ctx: ctx, cancel: cancel}
+
+	lc.Append(lifecycle.Hook{
+		Name:    "saga",
+		OnStart: o.resume,
+		OnStop:  o.stop,
+	})
+
+	return o
+}
+
+// Start stores a new workflow and executes it in the background.
+//
+// Parameters:
+//   - kind: Kind of the workflow (e.g. module.provision)
+//   - actor: Who starts the workflow
+//   - data: Input values of the steps
+//
+// Returns:
+//   - *workflow.WorkflowResponse: The workflow as started, all steps pending
+//   - error: Error if the workflow cannot be stored
+func (o *Orchestrator) Start(kind, actor string, data workflowService.Data) (*workflow.WorkflowResponse, error) {
+	w, err := o.service.Create(kind, actor, data)
+	if err != nil {
+		return nil, err
+	}
+	response := o.service.Response(w)
+	o.run(w)
+	return response, nil
+}
+
+// resume executes the workflows a previous run left unfinished.
+func (o *Orchestrator) resume(context.Context) error {
+	unfinished, err := o.service.Unfinished()
+	if err != nil {
+		return fmt.Errorf("load unfinished workflows: %w", err)
+	}
+	for _, w := range unfinished {
+		logger.Infof("Resuming %s workflow %s (%s after %d steps)", w.Kind, w.ID, w.Status, w.Step)
+		o.run(w)
+	}
+	return nil
+}
+
+// run executes a workflow on a goroutine of its own.
+func (o *Orchestrator) run(w *workflow.Workflow) {
+	o.wg.Add(1)
+	go func() {
+		defer o.wg.Done()
+		if err := o.service.Execute(o.ctx, w); err != nil {
+			if o.ctx.Err() != nil {
+				logger.Infof("Workflow %s interrupted by shutdown; it resumes on the next start", w.ID)
+				return
+			}
+			logger.Errorf("Workflow %s stopped: %v", w.ID, err)
+			return
+		}
+		switch w.Status {
+		case workflow.StatusCompleted:
+			logger.Infof("%s workflow %s completed", w.Kind, w.ID)
+		case workflow.StatusCompensated:
+			logger.Warnf("%s workflow %s compensated: %s", w.Kind, w.ID, w.Error)
+		default:
+			logger.Errorf("%s workflow %s failed and needs manual cleanup: %s", w.Kind, w.ID, w.Error)
+		}
+	}()
+}
+
+// stop cancels the running workflows and waits for them, bounded by the stop context.
+func (o *Orchestrator) stop(ctx context.Context) error {
+	o.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		o.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("saga: %w", ctx.Err())
+	}
+}
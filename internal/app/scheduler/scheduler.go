@@ -0,0 +1,244 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go_di_architecture/internal/app/lifecycle"
+	"go_di_architecture/internal/domain/lock"
+	"go_di_architecture/internal/domain/models/dashboard"
+	"go_di_architecture/internal/logging"
+)
+
+// logger writes the log lines of the background jobs.
+var logger = logging.New("scheduler")
+
+// DefaultInterval is how often jobs run when no interval is configured.
+const DefaultInterval = 30 * time.Second
+
+// statuses holds the run status of the jobs of every scheduler by job name,
+// reported by Statuses.
+var statuses = struct {
+	mu   sync.Mutex
+	jobs map[string]*dashboard.ScheduledJob
+}{jobs: make(map[string]*dashboard.ScheduledJob)}
+
+// Job is a unit of background work run periodically.
+type Job struct {
+	// Name identifies the job in logs
+	Name string
+
+	// Run performs one execution; the context is cancelled on shutdown
+	Run func(ctx context.Context) error
+}
+
+// Scheduler runs background jobs at a fixed interval.
+//
+// Jobs run once right after start (catching up on work that became due while
+// the application was down) and then on every tick. Executions of one job
+// never overlap; a failed execution is logged and retried on the next tick.
+//
+// Lifecycle:
+//   - OnStart launches one goroutine per job
+//   - OnStop cancels running executions and waits for the goroutines to exit
+type Scheduler struct {
+	interval time.Duration
+	jobs     []Job
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New creates a scheduler and registers its lifecycle hooks.
+//
+// Parameters:
+//   - lc: Application lifecycle to register start/stop hooks with
+//   - interval: Time between executions of each job
+//   - jobs: Jobs to run
+//
+// Returns:
+//   - *Scheduler: The scheduler (started by the lifecycle)
+func New(lc *lifecycle.Lifecycle, interval time.Duration, jobs ...Job) *Scheduler {
+	s := &Scheduler{interval: interval, jobs: jobs}
+
+	statuses.mu.Lock()
+	for _, job := range jobs {
+		statuses.jobs[job.Name] = &dashboard.ScheduledJob{Name: job.Name, Interval: interval.String()}
+	}
+	statuses.mu.Unlock()
+
+	lc.Append(lifecycle.Hook{
+		Name:    "scheduler",
+		OnStart: s.start,
+		OnStop:  s.stop,
+	})
+
+	return s
+}
+
+// start launches the job loops; they outlive the start context.
+func (s *Scheduler) start(context.Context) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(ctx, job)
+	}
+
+	logger.Infof("Scheduler running %d job(s) every %s", len(s.jobs), s.interval)
+	return nil
+}
+
+// stop cancels the job loops and waits for them, bounded by the stop context.
+func (s *Scheduler) stop(ctx context.Context) error {
+	if s.cancel == nil {
+		return nil
+	}
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("scheduler: %w", ctx.Err())
+	}
+}
+
+// loop runs one job immediately and then on every tick until cancelled.
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.run(ctx, job)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// run executes a job once and records its status.
+func (s *Scheduler) run(ctx context.Context, job Job) {
+	start := time.Now()
+	setStatus(job.Name, func(status *dashboard.ScheduledJob) { status.Running = true })
+
+	err := job.Run(ctx)
+	if err != nil && ctx.Err() == nil {
+		logger.Errorf("Scheduled job %s failed: %v", job.Name, err)
+	}
+
+	setStatus(job.Name, func(status *dashboard.ScheduledJob) {
+		status.Running = false
+		if ctx.Err() != nil {
+			// Interrupted by shutdown, not a finished run
+			return
+		}
+		status.Runs++
+		status.LastRunAt = &start
+		status.LastDurationMs = float64(time.Since(start).Microseconds()) / 1000
+		status.LastError = ""
+		if err != nil {
+			status.Failures++
+			status.LastError = err.Error()
+			return
+		}
+		status.LastSuccessAt = &start
+	})
+}
+
+// setStatus changes the recorded status of a job.
+func setStatus(name string, change func(status *dashboard.ScheduledJob)) {
+	statuses.mu.Lock()
+	defer statuses.mu.Unlock()
+	if status, ok := statuses.jobs[name]; ok {
+		change(status)
+	}
+}
+
+// Statuses returns the run status of the jobs of every scheduler.
+//
+// Returns:
+//   - []dashboard.ScheduledJob: The statuses, sorted by job name
+func Statuses() []dashboard.ScheduledJob {
+	statuses.mu.Lock()
+	defer statuses.mu.Unlock()
+
+	list := make([]dashboard.ScheduledJob, 0, len(statuses.jobs))
+	for _, status := range statuses.jobs {
+		list = append(list, *status)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// Exclusive wraps a job so only one instance runs it at a time.
+//
+// Each execution tries the lock "job:<name>" first. When another instance
+// holds it the execution is skipped quietly and the job runs again on the
+// next tick; jobs that must run on every instance (e.g. flushing local
+// counters) must not be wrapped.
+//
+// Parameters:
+//   - locks: Lock shared by the instances
+//   - ttl: Expiry of the lock if the holder dies; longer than one execution
+//   - job: The job to guard
+//
+// Returns:
+//   - Job: The guarded job with the same name
+func Exclusive(locks lock.Lock, ttl time.Duration, job Job) Job {
+	return Job{
+		Name: job.Name,
+		Run: func(ctx context.Context) error {
+			err := lock.Run(ctx, locks, "job:"+job.Name, ttl, job.Run)
+			if errors.Is(err, lock.ErrHeld) {
+				return nil
+			}
+			return err
+		},
+	}
+}
+
+// Leadership reports whether this instance leads an election.
+type Leadership interface {
+	IsLeader() bool
+}
+
+// LeaderOnly wraps a job so it only runs on the elected leader.
+//
+// Followers skip every execution quietly and pick the job up on the first
+// tick after they become leader. Combine it with Exclusive to also cover the
+// handover, when the old leader may still be running an execution.
+//
+// Parameters:
+//   - leadership: The election deciding which instance runs the job
+//   - job: The job to guard
+//
+// Returns:
+//   - Job: The guarded job with the same name
+func LeaderOnly(leadership Leadership, job Job) Job {
+	return Job{
+		Name: job.Name,
+		Run: func(ctx context.Context) error {
+			if !leadership.IsLeader() {
+				return nil
+			}
+			return job.Run(ctx)
+		},
+	}
+}
@@ -0,0 +1,284 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strconv"
+	"time"
+
+	"go_di_architecture/internal/domain/models/module"
+	"go_di_architecture/internal/domain/models/tag"
+	moduleService "go_di_architecture/internal/domain/service/module"
+	tagService "go_di_architecture/internal/domain/service/tag"
+)
+
+// GeneratorActor is recorded in the change history of generated modules.
+const GeneratorActor = "datagen"
+
+// maxNameAttempts bounds the suffixes tried for a name taken by an earlier run.
+const maxNameAttempts = 1000
+
+// Vocabulary of the generated modules
+var (
+	nameDomains = []string{
+		"Billing", "Inventory", "Shipping", "Catalog", "Checkout", "Payments",
+		"Identity", "Search", "Pricing", "Loyalty", "Reporting", "Analytics",
+		"Fulfillment", "Returns", "Support", "Onboarding", "Compliance", "Ledger",
+		"Payroll", "Procurement", "Marketing", "Messaging", "Scheduling", "Fleet",
+	}
+	nameComponents = []string{
+		"Gateway", "Service", "Worker", "Sync", "Exporter", "Importer", "Engine",
+		"Portal", "Dashboard", "Scheduler", "Connector", "Cache", "Notifier",
+		"Reconciler", "Validator", "Archive", "Bridge", "Monitor", "Planner",
+	}
+	descriptionVerbs = []string{
+		"Tracks", "Syncs", "Exports", "Validates", "Aggregates", "Publishes",
+		"Reconciles", "Schedules", "Archives", "Enriches", "Routes", "Audits",
+	}
+	descriptionObjects = []string{
+		"stock levels", "invoices", "customer accounts", "shipments", "price lists",
+		"payment events", "support tickets", "product images", "tax reports",
+		"loyalty points", "purchase orders", "delivery slots", "user sessions",
+	}
+	descriptionScopes = []string{
+		"across warehouses", "for the finance team", "between regions",
+		"every night", "in near real time", "for partner portals",
+		"for the mobile apps", "from legacy systems", "per sales channel",
+	}
+	tagNames = []string{
+		"backend", "frontend", "billing", "payments", "internal", "legacy",
+		"beta", "critical", "eu", "us", "apac", "mobile", "analytics", "batch",
+		"realtime", "pii", "partner", "deprecated", "experimental", "core",
+	}
+	owners = []string{"jane", "john", "alex", "sam", "maria", "li", "noah", "fatima", "ops", "platform"}
+)
+
+// GenerateOptions configures a generated dataset.
+type GenerateOptions struct {
+	// Number of modules to create
+	Count int
+
+	// Number of distinct tags attached to the modules (zero attaches none)
+	Tags int
+
+	// Creation times are spread over this period before now, denser towards
+	// now like a growing catalog
+	Span time.Duration
+
+	// Seed of the random choices; the same seed creates the same names,
+	// descriptions and tags
+	Seed uint64
+}
+
+// GenerateResult summarizes a generated dataset.
+type GenerateResult struct {
+	// Modules created
+	Modules int `json:"modules"`
+
+	// Tag assignments created
+	TagAssignments int `json:"tagAssignments"`
+
+	// Creation time of the oldest and newest module
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+
+	// Time the generation took
+	Duration string `json:"duration"`
+}
+
+// Generator fills a store with large synthetic datasets for performance
+// testing of pagination, search and export.
+//
+// Modules are written through the import path of the module service, so
+// they keep their generated creation times and get a change history like
+// restored modules. Tags follow a Zipf distribution: a few tags are on many
+// modules and most on few, so tag filters of every selectivity can be tried.
+//
+// Usage Example:
+//
+//	generator := seed.NewGenerator(modules, tags)
+//	result, err := generator.Generate(ctx, seed.GenerateOptions{Count: 100000, Tags: 20, Span: 2 * 365 * 24 * time.Hour, Seed: 1})
+type Generator struct {
+	modules *moduleService.ModuleService
+	tags    tagService.TagRepository
+}
+
+// NewGenerator creates a generator.
+//
+// Parameters:
+//   - modules: Module service importing the generated modules
+//   - tags: Tag repository creating and assigning the tags
+//
+// Returns:
+//   - *Generator: A new generator
+func NewGenerator(modules *moduleService.ModuleService, tags tagService.TagRepository) *Generator {
+	return &Generator{modules: modules, tags: tags}
+}
+
+// Generate creates a synthetic dataset.
+//
+// Names already taken, e.g. by an earlier run, get a further numeric
+// suffix, so runs add to a store instead of failing on it.
+//
+// Parameters:
+//   - ctx: Context cancelling the generation between modules
+//   - opts: Size and shape of the dataset
+//
+// Returns:
+//   - *GenerateResult: What was created, also when the generation stopped
+//     early
+//   - error: Error if the options are invalid, the context ended or the data
+//     layer fails
+func (g *Generator) Generate(ctx context.Context, opts GenerateOptions) (*GenerateResult, error) {
+	if opts.Count <= 0 {
+		return nil, fmt.Errorf("generate: count must be positive, got %d", opts.Count)
+	}
+	if opts.Tags < 0 || opts.Span < 0 {
+		return nil, errors.New("generate: tags and span must not be negative")
+	}
+
+	started := time.Now()
+	random := rand.New(rand.NewPCG(opts.Seed, opts.Seed))
+	result := &GenerateResult{}
+	defer func() { result.Duration = time.Since(started).Round(time.Millisecond).String() }()
+
+	// Step 1: Create the tag vocabulary
+	tagIDs, err := g.ensureTags(opts.Tags)
+	if err != nil {
+		return result, err
+	}
+	var popularity *rand.Zipf
+	if len(tagIDs) > 1 {
+		popularity = rand.NewZipf(random, 1.2, 1, uint64(len(tagIDs)-1))
+	}
+
+	// Step 2: Create the modules in creation order, so IDs follow time
+	start := started.Add(-opts.Span)
+	used := make(map[string]int)
+	for i := range opts.Count {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		state := g.randomModule(random)
+		state.CreatedAt = start.Add(time.Duration(float64(opts.Span) * math.Sqrt((float64(i)+random.Float64())/float64(opts.Count))))
+
+		saved, err := g.importModule(state, used)
+		if err != nil {
+			return result, err
+		}
+		if result.Modules == 0 {
+			result.From = saved.CreatedAt
+		}
+		result.To = saved.CreatedAt
+		result.Modules++
+
+		// Step 3: Attach up to three tags, favoring the popular ones
+		assigned, err := g.assignTags(random, popularity, tagIDs, saved.ID)
+		if err != nil {
+			return result, err
+		}
+		result.TagAssignments += assigned
+
+		if result.Modules%1000 == 0 {
+			logger.Infof("Generated %d of %d modules", result.Modules, opts.Count)
+		}
+	}
+
+	logger.Infof("Generated %d modules with %d tag assignments", result.Modules, result.TagAssignments)
+	return result, nil
+}
+
+// ensureTags creates the first count tags of the vocabulary, numbering them
+// once it is exhausted, and returns their IDs.
+func (g *Generator) ensureTags(count int) ([]int, error) {
+	ids := make([]int, 0, count)
+	for i := range count {
+		name := tagNames[i%len(tagNames)]
+		if i >= len(tagNames) {
+			name += "-" + strconv.Itoa(i/len(tagNames)+1)
+		}
+
+		existing, err := g.tags.FindTagByName(name)
+		if err != nil {
+			return nil, fmt.Errorf("generate tag %q: %w", name, err)
+		}
+		if existing == nil {
+			if existing, err = g.tags.CreateTag(&tag.Tag{Name: name, CreatedAt: time.Now()}); err != nil {
+				return nil, fmt.Errorf("generate tag %q: %w", name, err)
+			}
+		}
+		ids = append(ids, existing.ID)
+	}
+	return ids, nil
+}
+
+// randomModule picks the name, description and state of a module.
+func (g *Generator) randomModule(random *rand.Rand) *module.Module {
+	state := &module.Module{
+		Name: nameDomains[random.IntN(len(nameDomains))] + " " + nameComponents[random.IntN(len(nameComponents))],
+		Description: descriptionVerbs[random.IntN(len(descriptionVerbs))] + " " +
+			descriptionObjects[random.IntN(len(descriptionObjects))] + " " +
+			descriptionScopes[random.IntN(len(descriptionScopes))],
+		IsActive: random.IntN(10) < 7,
+		Owner:    owners[random.IntN(len(owners))],
+	}
+
+	// Most modules went through approval; some are still in review
+	switch n := random.IntN(20); {
+	case n < 2:
+		state.Status = module.StatusDraft
+	case n < 4:
+		state.Status = module.StatusPending
+	default:
+		state.Status = module.StatusApproved
+	}
+	return state
+}
+
+// importModule writes a module, numbering its name until it is free.
+func (g *Generator) importModule(state *module.Module, used map[string]int) (*module.Module, error) {
+	base := state.Name
+	for range maxNameAttempts {
+		if n := used[base]; n > 0 {
+			state.Name = base + " " + strconv.Itoa(n+1)
+		}
+		used[base]++
+
+		saved, err := g.modules.ImportModule(state, 0, GeneratorActor)
+		if errors.Is(err, moduleService.ErrNameExists) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("generate module %q: %w", state.Name, err)
+		}
+		return saved, nil
+	}
+	return nil, fmt.Errorf("generate module %q: no free name after %d attempts", base, maxNameAttempts)
+}
+
+// assignTags attaches zero to three distinct tags to a module.
+func (g *Generator) assignTags(random *rand.Rand, popularity *rand.Zipf, tagIDs []int, moduleID int) (int, error) {
+	if len(tagIDs) == 0 {
+		return 0, nil
+	}
+
+	assigned := make(map[int]bool)
+	for range random.IntN(4) {
+		index := 0
+		if popularity != nil {
+			index = int(popularity.Uint64())
+		}
+		if assigned[tagIDs[index]] {
+			continue
+		}
+		assigned[tagIDs[index]] = true
+		if err := g.tags.AssignTag(moduleID, tagIDs[index]); err != nil {
+			return len(assigned) - 1, fmt.Errorf("generate tags of module %d: %w", moduleID, err)
+		}
+	}
+	return len(assigned), nil
+}
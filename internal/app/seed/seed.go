@@ -0,0 +1,71 @@
+package seed
+
+import (
+	"context"
+	"fmt"
+
+	"go_di_architecture/internal/app/lifecycle"
+	"go_di_architecture/internal/domain/models/module"
+	moduleService "go_di_architecture/internal/domain/service/module"
+	"go_di_architecture/internal/logging"
+)
+
+// logger writes the log lines of the seeder.
+var logger = logging.New("seed")
+
+// Actor is recorded in the change history of the sample modules.
+const Actor = "seed"
+
+// Modules are the sample modules created on an empty store.
+var Modules = []module.ModuleRequest{
+	{Name: "Inventory", Description: "Tracks stock levels across warehouses"},
+	{Name: "Billing", Description: "Issues invoices and records payments"},
+	{Name: "Reporting", Description: "Builds the monthly business reports"},
+}
+
+// Seeder fills an empty store with sample data for development.
+//
+// Lifecycle:
+//   - OnStart creates the sample modules unless modules exist already, so
+//     restarts against a persistent database add nothing
+type Seeder struct {
+	modules *moduleService.ModuleService
+}
+
+// New creates a seeder and registers its lifecycle hook.
+//
+// Parameters:
+//   - lc: Application lifecycle to register the start hook with; it runs
+//     after the database migrations, which are registered earlier
+//   - modules: Module service creating the sample modules
+//
+// Returns:
+//   - *Seeder: The seeder (run by the lifecycle)
+func New(lc *lifecycle.Lifecycle, modules *moduleService.ModuleService) *Seeder {
+	s := &Seeder{modules: modules}
+	lc.Append(lifecycle.Hook{
+		Name:    "seed",
+		OnStart: s.seed,
+	})
+	return s
+}
+
+// seed creates the sample modules on an empty store.
+func (s *Seeder) seed(context.Context) error {
+	count, err := s.modules.CountModules(nil, module.Subject{Admin: true})
+	if err != nil {
+		return fmt.Errorf("seed: %w", err)
+	}
+	if count > 0 {
+		logger.Debugf("Store holds %d modules, skipping sample data", count)
+		return nil
+	}
+
+	for _, request := range Modules {
+		if _, err := s.modules.CreateModule(request, Actor, false); err != nil {
+			return fmt.Errorf("seed module %q: %w", request.Name, err)
+		}
+	}
+	logger.Infof("Created %d sample modules", len(Modules))
+	return nil
+}
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+
+	"go_di_architecture/internal/app/lifecycle"
+	"go_di_architecture/internal/app/router"
+	"go_di_architecture/internal/domain/models/response"
+	"go_di_architecture/internal/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// logger writes the start and unexpected end of the HTTP server.
+var logger = logging.New("server")
+
+// Addr is the address the HTTP server listens on.
+const Addr = ":8080"
+
+// NewHTTPServer builds the HTTP server and registers its lifecycle hooks.
+//
+// The listener is opened during start so address conflicts fail the startup
+// instead of surfacing later from a background goroutine. On stop the server
+// drains in-flight requests until the shutdown context expires.
+//
+// Parameters:
+//   - lc: Application lifecycle to register start/stop hooks with
+//   - engine: Gin engine serving the routes; HEAD requests of GET-only routes
+//     are answered through router.AutoHead
+//
+// Returns:
+//   - *http.Server: The configured (not yet listening) server
+func NewHTTPServer(lc *lifecycle.Lifecycle, engine *gin.Engine) *http.Server {
+	server := &http.Server{Addr: Addr, Handler: router.AutoHead(engine)}
+
+	lc.Append(lifecycle.Hook{
+		Name: "http.server",
+		OnStart: func(ctx context.Context) error {
+			listener, err := net.Listen("tcp", server.Addr)
+			if err != nil {
+				return err
+			}
+
+			go func() {
+				if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					logger.Errorf("HTTP server stopped unexpectedly: %v", err)
+				}
+			}()
+
+			logger.Infof("HTTP server listening on %s (JSON encoder: %s)", server.Addr, response.JSONEncoder)
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			return server.Shutdown(ctx)
+		},
+	})
+
+	return server
+}
@@ -0,0 +1,313 @@
+package wiring
+
+import (
+	"context"
+	"crypto/rand"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go_di_architecture/internal/app/activity"
+	"go_di_architecture/internal/app/handlers"
+	"go_di_architecture/internal/app/jobs"
+	"go_di_architecture/internal/app/lifecycle"
+	"go_di_architecture/internal/app/notifier"
+	"go_di_architecture/internal/app/router"
+	"go_di_architecture/internal/app/saga"
+	"go_di_architecture/internal/app/scheduler"
+	"go_di_architecture/internal/app/server"
+	"go_di_architecture/internal/domain/events"
+	"go_di_architecture/internal/domain/lock"
+	"go_di_architecture/internal/domain/metrics"
+	"go_di_architecture/internal/domain/notification"
+	"go_di_architecture/internal/domain/provisioning"
+	backupService "go_di_architecture/internal/domain/service/backup"
+	deadLetterService "go_di_architecture/internal/domain/service/deadletter"
+	dependencyService "go_di_architecture/internal/domain/service/dependency"
+	exportService "go_di_architecture/internal/domain/service/export"
+	moduleService "go_di_architecture/internal/domain/service/module"
+	noteService "go_di_architecture/internal/domain/service/note"
+	privacyService "go_di_architecture/internal/domain/service/privacy"
+	quotaService "go_di_architecture/internal/domain/service/quota"
+	retentionService "go_di_architecture/internal/domain/service/retention"
+	settingService "go_di_architecture/internal/domain/service/setting"
+	tagService "go_di_architecture/internal/domain/service/tag"
+	templateService "go_di_architecture/internal/domain/service/template"
+	usageService "go_di_architecture/internal/domain/service/usage"
+	workflowService "go_di_architecture/internal/domain/service/workflow"
+	"go_di_architecture/internal/infra/db"
+	moduleRepo "go_di_architecture/internal/infra/db/module"
+	lockInfra "go_di_architecture/internal/infra/lock"
+	metricsInfra "go_di_architecture/internal/infra/metrics"
+	objectStorage "go_di_architecture/internal/infra/storage"
+	"go_di_architecture/internal/middleware"
+	"go_di_architecture/internal/templating"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/wire"
+)
+
+// InfraSet provides the infrastructure layer (repositories, locks, metrics).
+//
+// Compile-time wiring uses the in-memory backend and in-process locks; SQL
+// backends and distributed locks are selected at runtime through the container.
+var InfraSet = wire.NewSet(
+	wire.InterfaceValue(new(db.IDGenerator), db.AutoIncrement{}),
+	moduleRepo.NewInMemoryModuleRepository,
+	wire.Bind(new(moduleService.ModuleRepository), new(*moduleRepo.InMemoryModuleRepository)),
+	wire.Bind(new(moduleService.RevisionRepository), new(*moduleRepo.InMemoryModuleRepository)),
+	wire.Bind(new(moduleService.ACLRepository), new(*moduleRepo.InMemoryModuleRepository)),
+	wire.Bind(new(moduleService.TransferRepository), new(*moduleRepo.InMemoryModuleRepository)),
+	wire.Bind(new(moduleService.StarRepository), new(*moduleRepo.InMemoryModuleRepository)),
+	wire.Bind(new(tagService.TagRepository), new(*moduleRepo.InMemoryModuleRepository)),
+	wire.Bind(new(dependencyService.DependencyRepository), new(*moduleRepo.InMemoryModuleRepository)),
+	wire.Bind(new(settingService.SettingRepository), new(*moduleRepo.InMemoryModuleRepository)),
+	wire.Bind(new(noteService.NoteRepository), new(*moduleRepo.InMemoryModuleRepository)),
+	wire.Bind(new(templateService.TemplateRepository), new(*moduleRepo.InMemoryModuleRepository)),
+	wire.Bind(new(retentionService.RetentionRepository), new(*moduleRepo.InMemoryModuleRepository)),
+	wire.Bind(new(privacyService.UserDataRepository), new(*moduleRepo.InMemoryModuleRepository)),
+	wire.Bind(new(usageService.UsageRepository), new(*moduleRepo.InMemoryModuleRepository)),
+	wire.Bind(new(quotaService.QuotaRepository), new(*moduleRepo.InMemoryModuleRepository)),
+	wire.Bind(new(workflowService.WorkflowRepository), new(*moduleRepo.InMemoryModuleRepository)),
+	lockInfra.NewLocalLock,
+	wire.Bind(new(lock.Lock), new(*lockInfra.LocalLock)),
+	provideMetrics,
+	wire.Bind(new(metrics.Metrics), new(*metricsInfra.ExpvarMetrics)),
+)
+
+// provideMetrics publishes the business metrics under the "business" expvar.
+func provideMetrics() *metricsInfra.ExpvarMetrics {
+	return metricsInfra.NewExpvarMetrics("business")
+}
+
+// DomainSet provides the domain layer (event bus and business services).
+var DomainSet = wire.NewSet(
+	provideEventBus,
+	wire.Bind(new(events.Publisher), new(*events.Bus)),
+	moduleService.NewModuleService,
+	wire.Bind(new(tagService.ModuleFinder), new(*moduleService.ModuleService)),
+	wire.Bind(new(dependencyService.ModuleFinder), new(*moduleService.ModuleService)),
+	wire.Bind(new(settingService.ModuleFinder), new(*moduleService.ModuleService)),
+	wire.Bind(new(noteService.ModuleFinder), new(*moduleService.ModuleService)),
+	tagService.NewTagService,
+	dependencyService.NewDependencyService,
+	settingService.NewDefaultSchemaRegistry,
+	settingService.NewSettingService,
+	noteService.NewNoteService,
+	templateService.NewTemplateService,
+)
+
+// AppSet provides the application layer (scheduler, notifier, job runner, export storage, retention, privacy, dead letters, workflows, usage counters, quotas, handlers, router, HTTP server, lifecycle).
+var AppSet = wire.NewSet(
+	lifecycle.New,
+	provideTemplates,
+	provideScheduler,
+	provideNotifier,
+	jobs.New,
+	provideObjectStorage,
+	provideExportService,
+	provideExportHandler,
+	handlers.NewJobHandler,
+	provideBackupService,
+	handlers.NewBackupHandler,
+	provideRetentionService,
+	handlers.NewRetentionHandler,
+	privacyService.NewPrivacyService,
+	handlers.NewPrivacyHandler,
+	provideDeadLetterService,
+	handlers.NewDeadLetterHandler,
+	provideWorkflowService,
+	saga.New,
+	handlers.NewWorkflowHandler,
+	provideUsageService,
+	handlers.NewUsageHandler,
+	provideQuotaService,
+	handlers.NewAccountHandler,
+	handlers.NewModuleHandler,
+	handlers.NewTagHandler,
+	handlers.NewDependencyHandler,
+	handlers.NewSettingHandler,
+	handlers.NewNoteHandler,
+	handlers.NewTemplateHandler,
+	provideAdminHandler,
+	provideActivityRecorder,
+	provideDashboardHandler,
+	provideRoutables,
+	provideEngine,
+	server.NewHTTPServer,
+	wire.Struct(new(Application), "*"),
+)
+
+// Application is the root object produced by compile-time wiring.
+//
+// It plays the same role as the runtime container: it owns the lifecycle and
+// keeps the top-level components alive.
+type Application struct {
+	Lifecycle *lifecycle.Lifecycle
+	Server    *http.Server
+	Scheduler *scheduler.Scheduler
+	Notifier  *notifier.Dispatcher
+}
+
+// Start runs all start hooks in dependency order.
+//
+// Parameters:
+//   - ctx: Context bounding the startup time
+//
+// Returns:
+//   - error: Error if a component fails to start
+func (a *Application) Start(ctx context.Context) error {
+	return a.Lifecycle.Start(ctx)
+}
+
+// Stop runs all stop hooks in reverse order.
+//
+// Parameters:
+//   - ctx: Context bounding the shutdown time
+//
+// Returns:
+//   - error: All shutdown errors joined together
+func (a *Application) Stop(ctx context.Context) error {
+	return a.Lifecycle.Stop(ctx)
+}
+
+// provideEventBus builds the event bus with event logging.
+func provideEventBus() *events.Bus {
+	return events.NewBus(events.Log)
+}
+
+// provideScheduler builds the background scheduler running the module schedule job.
+func provideScheduler(lc *lifecycle.Lifecycle, service *moduleService.ModuleService) *scheduler.Scheduler {
+	return scheduler.New(lc, scheduler.DefaultInterval, scheduler.Job{
+		Name: "module.schedules",
+		Run: func(ctx context.Context) error {
+			_, err := service.ApplySchedules(ctx, time.Now())
+			return err
+		},
+	})
+}
+
+// provideTemplates loads the embedded templates without overrides.
+func provideTemplates() (*templating.Engine, error) {
+	return templating.New("")
+}
+
+// provideNotifier builds the notification dispatcher without routing rules.
+//
+// Compile-time wiring has no configuration, so no notifications are sent.
+func provideNotifier(lc *lifecycle.Lifecycle, bus *events.Bus, templates *templating.Engine) *notifier.Dispatcher {
+	return notifier.New(lc, bus, notification.Routes{}, nil, templates)
+}
+
+// provideObjectStorage builds a local export storage below the temp directory.
+//
+// Compile-time wiring has no configuration, so download links are signed with
+// a random per-process key.
+func provideObjectStorage() (*objectStorage.LocalStorage, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return objectStorage.NewLocalStorage(filepath.Join(os.TempDir(), "module-exports"), key, router.DownloadPrefix), nil
+}
+
+// provideExportService builds the export service with the default link validity.
+func provideExportService(modules *moduleService.ModuleService, store *objectStorage.LocalStorage) (*exportService.ExportService, error) {
+	return exportService.NewExportService(modules, store, exportService.DefaultURLTTL)
+}
+
+// provideExportHandler builds the export handler serving downloads from the local storage.
+func provideExportHandler(service *exportService.ExportService, runner *jobs.Runner, store *objectStorage.LocalStorage) *handlers.ExportHandler {
+	return handlers.NewExportHandler(service, runner, store)
+}
+
+// provideBackupService builds the backup service over the local storage.
+func provideBackupService(modules moduleService.ModuleRepository, importer *moduleService.ModuleService, tags tagService.TagRepository, settings settingService.SettingRepository, store *objectStorage.LocalStorage, locks lock.Lock, m metrics.Metrics) (*backupService.BackupService, error) {
+	return backupService.NewBackupService(modules, importer, tags, settings, store, locks, m)
+}
+
+// provideRetentionService builds the retention service without rules.
+//
+// Compile-time wiring has no configuration, so no data is purged or archived.
+func provideRetentionService(repo retentionService.RetentionRepository) (*retentionService.RetentionService, error) {
+	return retentionService.NewRetentionService(repo, nil, false, 0)
+}
+
+// provideDeadLetterService builds the dead-letter service without a queue.
+//
+// Compile-time wiring has no configuration and so no command queue; the
+// dead-letter routes report it as disabled.
+func provideDeadLetterService(m metrics.Metrics) (*deadLetterService.DeadLetterService, error) {
+	return deadLetterService.NewDeadLetterService(nil, m)
+}
+
+// provideWorkflowService builds the workflow service with the local allocator.
+//
+// Compile-time wiring has no configuration and so no provisioning service;
+// provisioned modules get a local allocation.
+func provideWorkflowService(repo workflowService.WorkflowRepository, modules *moduleService.ModuleService, bus *events.Bus) (*workflowService.WorkflowService, error) {
+	return workflowService.NewWorkflowService(repo, workflowService.NewProvisioningWorkflow(modules, provisioning.Local, bus))
+}
+
+// provideUsageService builds the usage service, flushing counts periodically and on shutdown.
+func provideUsageService(lc *lifecycle.Lifecycle, repo usageService.UsageRepository, modules *moduleService.ModuleService) (*usageService.UsageService, error) {
+	service, err := usageService.NewUsageService(repo, modules)
+	if err != nil {
+		return nil, err
+	}
+	scheduler.New(lc, usageService.DefaultFlushInterval, scheduler.Job{Name: "usage.flush", Run: service.Flush})
+	lc.Append(lifecycle.Hook{Name: "usage", OnStop: service.Flush})
+	return service, nil
+}
+
+// provideQuotaService builds the quota service without quotas.
+//
+// Compile-time wiring has no configuration and so no API keys; no requests are counted.
+func provideQuotaService(repo quotaService.QuotaRepository) (*quotaService.QuotaService, error) {
+	return quotaService.NewQuotaService(repo, nil)
+}
+
+// provideAdminHandler builds the admin handler without a runtime dependency graph.
+func provideAdminHandler() *handlers.AdminHandler {
+	return handlers.NewAdminHandler(nil)
+}
+
+// provideActivityRecorder keeps server errors and requests slower than the default threshold.
+func provideActivityRecorder() *activity.Recorder {
+	return activity.NewRecorder(activity.DefaultCapacity, activity.DefaultSlowThreshold)
+}
+
+// provideDashboardHandler builds the dashboard handler reporting the job runner and notifier queues.
+func provideDashboardHandler(recorder *activity.Recorder, runner *jobs.Runner, dispatcher *notifier.Dispatcher) *handlers.DashboardHandler {
+	return handlers.NewDashboardHandler(recorder, runner, dispatcher)
+}
+
+// provideRoutables lists the handlers whose routes the router registers.
+//
+// Compile-time wiring has no container to discover them in, so the list
+// names every routable handler.
+func provideRoutables(moduleHandler *handlers.ModuleHandler, tagHandler *handlers.TagHandler, dependencyHandler *handlers.DependencyHandler, settingHandler *handlers.SettingHandler, noteHandler *handlers.NoteHandler, templateHandler *handlers.TemplateHandler, exportHandler *handlers.ExportHandler, jobHandler *handlers.JobHandler, adminHandler *handlers.AdminHandler, dashboardHandler *handlers.DashboardHandler, backupHandler *handlers.BackupHandler, retentionHandler *handlers.RetentionHandler, privacyHandler *handlers.PrivacyHandler, deadLetterHandler *handlers.DeadLetterHandler, workflowHandler *handlers.WorkflowHandler, usageHandler *handlers.UsageHandler, accountHandler *handlers.AccountHandler) []handlers.Routable {
+	return []handlers.Routable{moduleHandler, tagHandler, dependencyHandler, settingHandler, noteHandler, templateHandler, exportHandler, jobHandler, adminHandler, dashboardHandler, backupHandler, retentionHandler, privacyHandler, deadLetterHandler, workflowHandler, usageHandler, accountHandler}
+}
+
+// provideEngine builds the Gin engine with all routes registered and warms up the request validators.
+func provideEngine(lc *lifecycle.Lifecycle, routables []handlers.Routable, usage *usageService.UsageService, recorder *activity.Recorder) *gin.Engine {
+	lc.Append(lifecycle.Hook{Name: "validators", OnWarmUp: handlers.WarmUpValidators})
+
+	// The router writes the access log itself
+	engine := gin.New()
+	engine.Use(gin.Recovery())
+	// No proxy is trusted to report the client address the public API is
+	// rate limited by; trusting none cannot fail
+	_ = engine.SetTrustedProxies(nil)
+	opts := router.Options{
+		UsageRecorder:     usage,
+		Readiness:         lc.Ready,
+		Activity:          recorder,
+		PublicRateLimiter: middleware.NewRateLimiter(middleware.DefaultPublicRateLimit, time.Minute),
+		PublicCacheMaxAge: middleware.DefaultPublicCacheMaxAge,
+	}
+	router.SetupRouter(engine, nil, opts, routables)
+	return engine
+}
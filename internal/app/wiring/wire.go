@@ -0,0 +1,15 @@
+//go:build wireinject
+
+package wiring
+
+import "github.com/google/wire"
+
+// InitializeApplication wires every layer at compile time.
+//
+// Regenerate wire_gen.go after changing a provider set:
+//
+//	go generate ./internal/app/wiring
+func InitializeApplication() (*Application, error) {
+	wire.Build(InfraSet, DomainSet, AppSet)
+	return nil, nil
+}
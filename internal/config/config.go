@@ -0,0 +1,1448 @@
+package config
+
+import (
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"math"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go_di_architecture/internal/app/auth"
+	"go_di_architecture/internal/domain/models/quota"
+	"go_di_architecture/internal/domain/models/response"
+	"go_di_architecture/internal/domain/models/retention"
+	"go_di_architecture/internal/domain/notification"
+	"go_di_architecture/internal/logging"
+	"go_di_architecture/internal/middleware"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/redis/go-redis/v9"
+)
+
+// Deployment environments
+const (
+	EnvDevelopment = "development"
+	EnvTest        = "test"
+	EnvStaging     = "staging"
+	EnvProduction  = "production"
+)
+
+// Lock backends
+const (
+	LockLocal    = "local"
+	LockRedis    = "redis"
+	LockPostgres = "postgres"
+)
+
+// Protections of the Swagger documentation
+const (
+	SwaggerAuthNone  = "none"
+	SwaggerAuthBasic = "basic"
+	SwaggerAuthAdmin = "admin"
+)
+
+// Leader election backends
+const (
+	LeaderLocal    = "local"
+	LeaderRedis    = "redis"
+	LeaderDatabase = "database"
+)
+
+// Supported database drivers
+const (
+	DriverMemory   = "memory"
+	DriverSQLite   = "sqlite"
+	DriverPostgres = "postgres"
+	DriverMySQL    = "mysql"
+)
+
+// Supported generators of module IDs
+const (
+	IDGeneratorSequence  = "sequence"
+	IDGeneratorSnowflake = "snowflake"
+)
+
+// Config holds the application configuration loaded from the environment.
+//
+// Environment Variables:
+//   - APP_ENV: Deployment environment (development, test, staging,
+//     production); default development, so a bare "go run ./cmd/api"
+//     starts on the in-memory store; deployments set it. The environment's
+//     profile gives defaults to DB_DRIVER, LOG_LEVEL, LOG_FORMAT,
+//     SWAGGER_ENABLED, SEED_DATA, CLOCK_FIXED_AT and NOTIFY_FAKE; setting
+//     one of them overrides the profile (see Profiles below)
+//   - LOG_LEVEL: Level of loggers without an override (debug, info, warn,
+//     error); default info (debug in development), changeable at runtime via
+//     PUT /admin/log-level
+//   - LOG_FORMAT: Format of log lines (text, json); default json in staging
+//     and production, otherwise text
+//   - LOG_LEVELS: Per-logger levels as comma-separated logger=level pairs,
+//     e.g. "http=warn,scheduler=debug"; default none
+//   - LOG_SAMPLE_INITIAL: Access log lines written per second before sampling
+//     starts; default 0, which logs every request. Server errors are never sampled
+//   - LOG_SAMPLE_THEREAFTER: Every how many requests one is logged once
+//     sampling started; default 100, 0 drops the rest
+//   - LOG_SLOW_REQUEST_THRESHOLD: Duration from which a request is logged
+//     under its request ID with a breakdown of its middleware and handler
+//     timings and SQL statements (Go duration); default 0, which disables
+//     it. Statements are logged with placeholders, not their values
+//   - DB_DRIVER: Storage backend (memory, sqlite, postgres, mysql); default
+//     memory, which production does not allow
+//   - DB_DSN: Driver-specific connection string (required unless DB_DRIVER=memory)
+//   - DB_PREPARE_STMT: Cache prepared statements per connection (true/false);
+//     default false, leave off behind transaction-pooling proxies like PgBouncer
+//   - DB_SKIP_DEFAULT_TRANSACTION: Run single writes without wrapping them in a
+//     transaction (true/false); default true, multi-statement writes keep
+//     their explicit transactions
+//   - DB_BATCH_SIZE: Rows per INSERT when creating many records at once;
+//     default 100
+//   - DB_CACHE_TTL: How long module lookups by ID are cached in memory (Go
+//     duration); default 0, which only lets concurrent lookups of a module
+//     share one query. Writes through other instances show up once entries
+//     expire
+//   - DB_CACHE_NOT_FOUND_TTL: How long lookups of missing module IDs are
+//     cached (Go duration); default 0, which disables negative caching
+//   - DB_NAME_FILTER_REFRESH: How often the in-memory filter of module names,
+//     which lets new names skip the uniqueness query, is rebuilt from the
+//     database (Go duration); default 0, which disables the filter. Meant for
+//     very large module tables
+//   - DB_CACHE_INVALIDATION_URL: Redis server (redis:// or rediss:// URL with
+//     optional credentials) broadcasting module changes so every instance
+//     evicts its cached copies; default none
+//   - DB_CACHE_INVALIDATION_CHANNEL: Pub/sub channel of the broadcasts, shared
+//     by all instances; default module-cache
+//   - DB_HEALTH_INTERVAL: How often the database is pinged while running (Go
+//     duration); default 10s, 0 disables the pings. While pings fail /ready
+//     answers 503 "degraded", so load balancers stop routing to the instance
+//   - DB_QUERY_TIMEOUT: Deadline of a read statement (Go duration); default
+//     5s, 0 disables it. A statement running out of time fails the request
+//     with 504 UPSTREAM_TIMEOUT instead of holding it while the database hangs
+//   - DB_WRITE_TIMEOUT: Deadline of a write statement, including the
+//     transaction around a single write (Go duration); default 10s, 0
+//     disables it. Migrations and maintenance queries set their own
+//   - DB_QUERY_BUDGET: Statements a request may run before a warning names
+//     its most repeated statement, the usual sign of an N+1 query; default
+//     20 in development, otherwise 0, which disables counting
+//   - DB_ID_GENERATOR: How the IDs of new modules are assigned (sequence,
+//     snowflake); default sequence, the auto-increment column (a counter with
+//     the memory driver). snowflake generates time-ordered 63-bit IDs without
+//     coordination, for instances writing to different databases that are
+//     merged later. These IDs exceed the integers JavaScript numbers hold
+//     exactly; keep the choice fixed for a database. UUIDs are rejected, as
+//     module IDs are integers
+//   - DB_NODE_ID: Node number of the instance within snowflake IDs (0-1023);
+//     default 0. Every instance needs its own
+//   - LOCK_BACKEND: Where locks keeping instances from running the same
+//     scheduled job or restore at once live (local, redis, postgres); default
+//     postgres with DB_DRIVER=postgres, otherwise local (one instance only)
+//   - LOCK_REDIS_URL: Redis server of the redis lock backend (redis:// or
+//     rediss:// URL)
+//   - LEADER_BACKEND: Where the lease electing the instance that runs the
+//     scheduled jobs lives (local, redis, database); default database with a
+//     SQL driver, otherwise local (one instance only)
+//   - LEADER_REDIS_URL: Redis server of the redis leader backend; default
+//     LOCK_REDIS_URL
+//   - LEADER_LEASE_TTL: How long the lease survives a leader that stopped
+//     renewing it (Go duration); default 15s
+//   - SCHEDULER_INTERVAL: How often background jobs run (Go duration); default 30s
+//   - RESPONSE_NAMING: JSON field naming of responses (camelCase, snake_case);
+//     default camelCase, overridable per request with an Accept profile
+//   - TIMESTAMP_FORMAT: Format of response timestamps (rfc3339nano, rfc3339,
+//     epoch_millis); default rfc3339nano
+//   - TIMESTAMP_UTC: Render response timestamps in UTC instead of their own
+//     zone (true/false); default false
+//   - REQUEST_ID_STRATEGY: How request IDs are generated (uuid, monotonic,
+//     trace); default uuid
+//   - NOTIFY_ROUTES: Channels notified per event type, e.g.
+//     "module.deactivated=email,slack;*=webhook"; default none
+//   - SMTP_ADDR, SMTP_FROM, SMTP_TO (comma-separated), SMTP_USERNAME,
+//     SMTP_PASSWORD: Mail server for the email channel
+//   - NOTIFY_WEBHOOK_URL: Endpoint of the webhook channel
+//   - NOTIFY_SLACK_WEBHOOK_URL: Slack incoming webhook of the slack channel
+//   - NOTIFY_FAKE: Log and record notifications instead of delivering them
+//     (true/false); default true in test, otherwise false. The channel
+//     settings above are then not needed
+//   - HTTP_CLIENT_TIMEOUT: Bound of a single outbound HTTP request of the
+//     webhook and slack channels (Go duration); default 10s. Object storage
+//     transfers are bounded by their operation instead
+//   - HTTP_CLIENT_RETRIES: Times an outbound request is repeated after a
+//     network error, 5xx or 429 response; default 2
+//   - HTTP_CLIENT_RETRY_BACKOFF: Wait before the first retry, doubled for
+//     every further retry (Go duration); default 200ms
+//   - HTTP_CLIENT_BREAKER_THRESHOLD: Consecutive failed calls after which an
+//     integration is not called until the cooldown has passed; default 5, 0
+//     disables the breaker
+//   - HTTP_CLIENT_BREAKER_COOLDOWN: How long an open breaker rejects calls
+//     (Go duration); default 30s
+//   - HTTP_CLIENT_PROXY: Egress proxy of all outbound HTTP requests (http://,
+//     https:// or socks5:// URL with optional credentials); default none,
+//     which uses HTTPS_PROXY, HTTP_PROXY and NO_PROXY
+//   - HTTP_CLIENT_NO_PROXY: Hosts reached without HTTP_CLIENT_PROXY, e.g.
+//     "localhost,.internal,10.0.0.0/8"; default NO_PROXY
+//   - HTTP_CLIENT_CA_FILE: PEM bundle of certificate authorities trusted by
+//     outbound requests besides the system roots, e.g. of a TLS-inspecting
+//     proxy or an internal MinIO; default none
+//   - HTTP_CLIENT_TLS_MIN_VERSION: Lowest TLS version of outbound requests
+//     (1.2, 1.3); default 1.2
+//   - TEMPLATE_DIR: Directory with templates overriding the embedded
+//     notification and report templates; default none
+//   - I18N_DIR: Directory with <locale>.json message bundles overriding or
+//     adding to the embedded ones (en, es, fr, de); default none. Validation
+//     errors are reported in the language picked by the Accept-Language header
+//   - EXPORT_STORAGE: Object storage receiving export files (local, s3);
+//     default local
+//   - EXPORT_DIR: Directory of the local storage; default <tmp>/module-exports
+//   - EXPORT_SIGNING_KEY: Secret signing local download links; default random
+//     per process, which invalidates links on restart
+//   - EXPORT_URL_TTL: How long download links stay valid (Go duration);
+//     default 15m
+//   - S3_ENDPOINT, S3_REGION, S3_BUCKET, S3_ACCESS_KEY_ID,
+//     S3_SECRET_ACCESS_KEY, S3_FORCE_PATH_STYLE: S3-compatible bucket of the
+//     s3 storage (AWS S3, Google Cloud Storage with HMAC keys, MinIO)
+//   - RETENTION_RULES: Data retention rules, e.g.
+//     "revisions.purge=365d;modules.archive=730d"; default none
+//   - RETENTION_DRY_RUN: Only report what the retention rules would change
+//     (true/false); default false
+//   - RETENTION_INTERVAL: How often the retention rules run (Go duration);
+//     default 24h
+//   - USAGE_FLUSH_INTERVAL: How often module usage and API key request
+//     counters are written to the database (Go duration); default 1m
+//   - AUTH_JWT_SECRET: HMAC secret of HS256 bearer tokens; tokens carry their
+//     scopes space-separated in the "scope" claim and their teams as an array
+//     in the "teams" claim
+//   - AUTH_API_KEYS: API keys with their scopes, e.g.
+//     "ci=modules:read,modules:write@s3cr3t;ops=admin@t0ps3cr3t"
+//     (scopes: modules:read, modules:write, modules:approve, admin)
+//   - AUTH_API_KEY_QUOTAS: Monthly request quota per API key, e.g.
+//     "ci=100000;partner=5000"; keys not listed are unlimited
+//   - AUTH_API_KEY_TEAMS: Teams of the API key holders for module access
+//     control lists, e.g. "ci=platform;bob=core,infra"; keys not listed
+//     belong to no team
+//   - AUTH_ANONYMOUS_SCOPES: Comma-separated scopes of every request while
+//     neither AUTH_JWT_SECRET nor AUTH_API_KEYS is set; default admin in
+//     development and test, otherwise modules:read
+//   - TRUSTED_PROXIES: Comma-separated addresses or CIDR ranges of the
+//     reverse proxies whose X-Forwarded-For header names the client address,
+//     e.g. "10.0.0.0/8,192.168.1.10"; default none, so the client address is
+//     the peer of the connection and cannot be spoofed to pass rate limits
+//   - PUBLIC_RATE_LIMIT: Requests per minute each client address may send to
+//     the unauthenticated /public/v1 routes; default 30, 0 disables the
+//     public API
+//   - PUBLIC_CACHE_MAX_AGE: How long browsers and CDNs may cache public API
+//     responses (Go duration); default 5m
+//   - CDN_MAX_AGE: How long a CDN may serve /api/v1 reads without asking the
+//     API (Go duration, sent as s-maxage); default 0, which keeps shared
+//     caches from storing them. Responses carry Surrogate-Key headers either way
+//   - CDN_PURGE_URL: Endpoint receiving the surrogate keys of written
+//     resources (http:// or https:// URL, e.g. Fastly's
+//     https://api.fastly.com/service/<id>/purge); default none
+//   - CDN_PURGE_TOKEN: API token sent to CDN_PURGE_URL; default none
+//   - COMMAND_QUEUE_URL: Broker whose command queue delivers module commands
+//     to execute, for producers that cannot call the HTTP API: a Redis
+//     server (redis:// or rediss:// URL) or a RabbitMQ broker (amqp:// or
+//     amqps:// URL, the path naming the virtual host), with optional
+//     credentials; default none, which disables the command consumer
+//   - COMMAND_QUEUE: Queue (Redis list, RabbitMQ queue and routing key) the
+//     commands arrive on; default module-commands
+//   - COMMAND_REPLY_TOPIC: Redis list, or RabbitMQ routing key, receiving the
+//     replies of commands that name no replyTo; default module-command-replies
+//   - COMMAND_QUEUE_EXCHANGE: RabbitMQ direct exchange commands and replies
+//     are published to; default modules
+//   - COMMAND_QUEUE_PREFETCH: Most unacknowledged commands RabbitMQ delivers
+//     ahead; default 10, 0 for unlimited
+//   - COMMAND_MAX_RETRIES: Requeues of a command failing with a server error
+//     before it is moved to the dead-letter queue; default 3
+//   - PROVISIONING_URL: Base URL of the provisioning API allocating the
+//     resources of modules created by POST /api/v1/modules/provision (http://
+//     or https://); default none, which records local allocations only
+//   - PROVISIONING_TOKEN: API token sent to PROVISIONING_URL; default none
+//   - PROVISIONING_PLANS: Comma-separated resource plans the authorize
+//     decorator of the allocator lets through; default small,medium,large
+//   - DECORATORS: Decorator chains wrapping container components, e.g.
+//     "provisioning.allocator=authorize,cache,metrics,logging"; chains are
+//     separated by semicolons and list the innermost decorator first; the
+//     allocator supports logging, metrics, cache and authorize; default none
+//   - DASHBOARD_SLOW_REQUEST_THRESHOLD: Duration from which a request is kept
+//     as slow for GET /admin/api/slow-requests (Go duration); default 1s
+//   - CHAOS_RULES: Faults injected per route to test client retries, e.g.
+//     "GET /api/v1/modules/:id=latency:100ms-2s,error:0.1;*=drop:0.01";
+//     default none, not allowed when APP_ENV is production
+//   - SWAGGER_ENABLED: Serve the API documentation under /swagger and
+//     /openapi.json (true/false); default false in production, otherwise true
+//   - SWAGGER_AUTH: Protection of the documentation (none, basic, admin);
+//     default none. admin requires the admin scope and so AUTH_JWT_SECRET or
+//     AUTH_API_KEYS
+//   - SWAGGER_USERNAME, SWAGGER_PASSWORD: Credentials of the basic protection
+//   - SWAGGER_HOST: Host (with optional port) of the API in the document,
+//     e.g. api.example.com; default the Host the document was requested with
+//   - SWAGGER_BASE_PATH: Base path of the API in the document; default /api/v1
+//   - SWAGGER_SCHEMES: Comma-separated schemes of the API in the document
+//     (http, https); default the scheme the document was requested with
+//   - SEED_DATA: Create sample modules on start when there are none
+//     (true/false); default true in development, otherwise false
+//   - CLOCK_FIXED_AT: RFC 3339 time the application clock is frozen at, so
+//     meta.timestamp of responses is deterministic; default
+//     2024-01-01T00:00:00Z in test, otherwise none (the system clock)
+//
+// Profiles:
+//
+//	development  DB_DRIVER=memory LOG_LEVEL=debug LOG_FORMAT=text SWAGGER_ENABLED=true SEED_DATA=true AUTH_ANONYMOUS_SCOPES=admin
+//	test         DB_DRIVER=memory LOG_FORMAT=text SWAGGER_ENABLED=true CLOCK_FIXED_AT=2024-01-01T00:00:00Z NOTIFY_FAKE=true AUTH_ANONYMOUS_SCOPES=admin
+//	staging      LOG_FORMAT=json SWAGGER_ENABLED=true
+//	production   LOG_FORMAT=json SWAGGER_ENABLED=false (and a SQL DB_DRIVER and AUTH_JWT_SECRET or AUTH_API_KEYS)
+//
+// Without AUTH_JWT_SECRET and AUTH_API_KEYS requests are not authenticated:
+// every request is granted AUTH_ANONYMOUS_SCOPES. Production refuses to start
+// without credentials.
+//
+// Example:
+//
+//	go run ./cmd/api
+//	DB_DRIVER=sqlite DB_DSN="file:modules.db" go run ./cmd/api
+//	APP_ENV=production DB_DRIVER=postgres DB_DSN="host=localhost user=app dbname=modules sslmode=disable" go run ./cmd/api
+type Config struct {
+	Environment   string
+	Database      DatabaseConfig
+	Scheduler     SchedulerConfig
+	Response      ResponseConfig
+	RequestID     RequestIDConfig
+	Notifications NotificationConfig
+	HTTPClient    HTTPClientConfig
+	Templates     TemplatesConfig
+	I18n          I18nConfig
+	Export        ExportConfig
+	Retention     RetentionConfig
+	Usage         UsageConfig
+	Auth          AuthConfig
+	Chaos         ChaosConfig
+	Public        PublicConfig
+	CDN           CDNConfig
+	Commands      CommandConfig
+	Provisioning  ProvisioningConfig
+	Decorators    map[string][]string
+	Dashboard     DashboardConfig
+	Logging       LoggingConfig
+	Locks         LockConfig
+	Leader        LeaderConfig
+	Swagger       SwaggerConfig
+	Seed          SeedConfig
+	Clock         ClockConfig
+
+	// Addresses and CIDR ranges of the reverse proxies trusted to report the
+	// client address (none trusts no proxy)
+	TrustedProxies []string
+}
+
+// DatabaseConfig holds the storage backend settings.
+type DatabaseConfig struct {
+	// Storage backend driver name
+	Driver string
+
+	// Driver-specific connection string
+	DSN string
+
+	// Cache prepared statements per connection
+	PrepareStmt bool
+
+	// Run single writes without a wrapping transaction
+	SkipDefaultTransaction bool
+
+	// Rows per INSERT when creating many records at once
+	BatchSize int
+
+	// How long module lookups by ID are cached (zero only coalesces them)
+	CacheTTL time.Duration
+
+	// How long lookups of missing module IDs are cached (zero disables it)
+	CacheNotFoundTTL time.Duration
+
+	// Time between rebuilds of the module name filter (zero disables it)
+	NameFilterRefresh time.Duration
+
+	// Redis server broadcasting module changes between instances (empty disables it)
+	CacheInvalidationURL string
+
+	// Pub/sub channel of the broadcasts
+	CacheInvalidationChannel string
+
+	// Time between health pings of the database (zero disables the watchdog)
+	HealthInterval time.Duration
+
+	// Deadline of a read statement (zero leaves reads unbounded)
+	QueryTimeout time.Duration
+
+	// Deadline of a write statement with its default transaction (zero
+	// leaves writes unbounded)
+	WriteTimeout time.Duration
+
+	// Statements a request may run before a warning names its most repeated
+	// one, hinting at an N+1 query (zero disables counting)
+	QueryBudget int
+
+	// Generator of the IDs of new modules (sequence, snowflake)
+	IDGenerator string
+
+	// Node number of the instance within snowflake IDs
+	NodeID int
+}
+
+// LoggingConfig holds the log level and access log sampling settings.
+type LoggingConfig struct {
+	// Level of loggers without an override
+	Level logging.Level
+
+	// Levels of individual loggers by name
+	Levels map[string]logging.Level
+
+	// Line format (text, json)
+	Format string
+
+	// Access log lines per second before sampling starts (zero disables sampling)
+	SampleInitial int
+
+	// Every how many lines one is written once sampling started
+	SampleThereafter int
+
+	// Duration from which a request is logged with a breakdown of its
+	// timings (zero disables it)
+	SlowRequestThreshold time.Duration
+}
+
+// SwaggerConfig holds the settings of the API documentation.
+type SwaggerConfig struct {
+	// Serve the Swagger UI and document under /swagger and the generated
+	// OpenAPI document under /openapi.json
+	Enabled bool
+
+	// Protection of the documentation (none, basic, admin)
+	Auth string
+
+	// Credentials of the basic protection
+	Username string
+	Password string
+
+	// Host of the API in the document (empty uses the Host of the request)
+	Host string
+
+	// Base path of the API in the document
+	BasePath string
+
+	// Schemes of the API in the document (none uses the scheme of the request)
+	Schemes []string
+}
+
+// SeedConfig holds the settings of the sample data.
+type SeedConfig struct {
+	// Create sample modules on start when there are none
+	Enabled bool
+}
+
+// ClockConfig holds the settings of the application clock.
+type ClockConfig struct {
+	// Time the clock is frozen at (zero follows the system clock)
+	FixedAt time.Time
+}
+
+// PublicConfig holds the settings of the unauthenticated public API.
+type PublicConfig struct {
+	// Requests per minute and client address (zero disables the public API)
+	RateLimit int
+
+	// How long responses may be cached
+	CacheMaxAge time.Duration
+}
+
+// CDNConfig holds the edge caching settings.
+type CDNConfig struct {
+	// How long the CDN may serve read responses (zero disables shared caching)
+	MaxAge time.Duration
+
+	// Purge endpoint of the CDN (empty disables purging)
+	PurgeURL string
+
+	// API token of the purge endpoint
+	PurgeToken string
+}
+
+// ProvisioningConfig holds the settings of the resource provisioning service.
+type ProvisioningConfig struct {
+	// Base URL of the provisioning API (empty allocates locally)
+	URL string
+
+	// API token of the provisioning API
+	Token string
+
+	// Plans the authorize decorator allows
+	Plans []string
+}
+
+// CommandConfig holds the settings of the command queue consumer.
+type CommandConfig struct {
+	// Broker of the queue, redis:// or amqp:// (empty disables the consumer)
+	QueueURL string
+
+	// Queue the commands arrive on
+	Queue string
+
+	// Default topic of the replies
+	ReplyTopic string
+
+	// Exchange of the RabbitMQ broker
+	Exchange string
+
+	// Most unacknowledged deliveries of the RabbitMQ broker (0: unlimited)
+	Prefetch int
+
+	// Requeues of a transiently failing command before it is dead-lettered
+	MaxRetries int
+}
+
+// DashboardConfig holds the settings of the operational dashboard endpoints.
+type DashboardConfig struct {
+	// Duration from which a request counts as slow
+	SlowRequestThreshold time.Duration
+}
+
+// LockConfig holds the cross-instance lock settings.
+type LockConfig struct {
+	// Lock backend name
+	Backend string
+
+	// Redis server of the redis backend
+	RedisURL string
+}
+
+// LeaderConfig holds the leader election settings.
+type LeaderConfig struct {
+	// Leader election backend name
+	Backend string
+
+	// Redis server of the redis backend
+	RedisURL string
+
+	// Validity of the lease without renewal
+	LeaseTTL time.Duration
+}
+
+// SchedulerConfig holds the background job settings.
+type SchedulerConfig struct {
+	// Time between runs of each background job
+	Interval time.Duration
+}
+
+// ResponseConfig holds the response rendering settings.
+type ResponseConfig struct {
+	// Default JSON field naming strategy
+	Naming string
+
+	// Format of timestamps in responses
+	TimeFormat string
+
+	// Render timestamps in UTC
+	UTC bool
+}
+
+// RequestIDConfig holds the request ID settings.
+type RequestIDConfig struct {
+	// Request ID strategy (uuid, monotonic or trace)
+	Strategy string
+}
+
+// NotificationConfig holds the notification routing and channel settings.
+type NotificationConfig struct {
+	// Channels notified per event type
+	Routes notification.Routes
+
+	// Mail server of the email channel
+	SMTPAddr     string
+	SMTPFrom     string
+	SMTPTo       []string
+	SMTPUsername string
+	SMTPPassword string
+
+	// Endpoint of the webhook channel
+	WebhookURL string
+
+	// Slack incoming webhook of the slack channel
+	SlackWebhookURL string
+
+	// Record notifications instead of delivering them, on every channel
+	Fake bool
+}
+
+// HTTPClientConfig holds the settings shared by the outbound HTTP clients.
+type HTTPClientConfig struct {
+	// Bound of a single request
+	Timeout time.Duration
+
+	// Times a failed request is repeated
+	Retries int
+
+	// Wait before the first retry, doubled for every further retry
+	RetryBackoff time.Duration
+
+	// Consecutive failed calls opening the breaker (zero disables it)
+	BreakerThreshold int
+
+	// How long an open breaker rejects calls
+	BreakerCooldown time.Duration
+
+	// Egress proxy (nil uses the standard proxy variables)
+	Proxy *url.URL
+
+	// Hosts reached without Proxy
+	NoProxy string
+
+	// Trusted certificate authorities (nil for the system roots)
+	RootCAs *x509.CertPool
+
+	// Lowest TLS version (a crypto/tls version constant)
+	TLSMinVersion uint16
+}
+
+// I18nConfig holds the message bundle settings.
+type I18nConfig struct {
+	// Directory with message bundles overriding the embedded ones (empty for none)
+	Dir string
+}
+
+// TemplatesConfig holds the template settings.
+type TemplatesConfig struct {
+	// Directory with templates overriding the embedded defaults (empty for none)
+	Dir string
+}
+
+// Supported export storage backends
+const (
+	ExportStorageLocal = "local"
+	ExportStorageS3    = "s3"
+)
+
+// ExportConfig holds the object storage settings of asynchronous exports.
+type ExportConfig struct {
+	// Storage backend (local or s3)
+	Storage string
+
+	// Root directory of the local storage
+	Dir string
+
+	// Secret signing local download links
+	SigningKey []byte
+
+	// Validity of download links
+	URLTTL time.Duration
+
+	// S3-compatible bucket of the s3 storage
+	S3Endpoint        string
+	S3Region          string
+	S3Bucket          string
+	S3AccessKeyID     string
+	S3SecretAccessKey string
+	S3ForcePathStyle  bool
+}
+
+// RetentionConfig holds the data retention policy.
+type RetentionConfig struct {
+	// Rules to apply (none disables the policy)
+	Rules []retention.Rule
+
+	// Only report what the rules would change
+	DryRun bool
+
+	// Time between scheduled runs of the rules
+	Interval time.Duration
+}
+
+// UsageConfig holds the module usage counter settings.
+type UsageConfig struct {
+	// Time between writes of the counters aggregated in memory
+	FlushInterval time.Duration
+}
+
+// AuthConfig holds the accepted credentials.
+type AuthConfig struct {
+	// HMAC secret of bearer tokens (empty rejects tokens)
+	JWTSecret string
+
+	// Accepted API keys
+	APIKeys []auth.APIKey
+
+	// Monthly request limit per API key name
+	Quotas map[string]int64
+
+	// Scopes of every request while no credentials are configured
+	AnonymousScopes []string
+}
+
+// Enabled reports whether any credentials are configured.
+//
+// Returns:
+//   - bool: True if requests must authenticate to pass route policies
+func (a AuthConfig) Enabled() bool {
+	return a.JWTSecret != "" || len(a.APIKeys) > 0
+}
+
+// ChaosConfig holds the fault injection settings.
+type ChaosConfig struct {
+	// Faults injected per route (none disables the chaos middleware)
+	Rules []middleware.ChaosRule
+}
+
+// Load reads the configuration from environment variables.
+//
+// Returns:
+//   - *Config: The loaded configuration
+//   - error: Error if a value is missing or invalid
+func Load() (*Config, error) {
+	environment := getEnv("APP_ENV", EnvDevelopment)
+	p, ok := profiles[environment]
+	if !ok {
+		return nil, fmt.Errorf("unsupported APP_ENV %q", environment)
+	}
+
+	cfg := &Config{
+		Environment: environment,
+		Database: DatabaseConfig{
+			Driver: p.getEnv("DB_DRIVER", DriverMemory),
+			DSN:    os.Getenv("DB_DSN"),
+		},
+		Templates: TemplatesConfig{
+			Dir: os.Getenv("TEMPLATE_DIR"),
+		},
+		I18n: I18nConfig{
+			Dir: os.Getenv("I18N_DIR"),
+		},
+		Response: ResponseConfig{
+			Naming:     getEnv("RESPONSE_NAMING", response.NamingCamelCase),
+			TimeFormat: getEnv("TIMESTAMP_FORMAT", response.TimeFormatRFC3339Nano),
+		},
+	}
+
+	if !response.IsNamingStrategy(cfg.Response.Naming) {
+		return nil, fmt.Errorf("unsupported RESPONSE_NAMING %q", cfg.Response.Naming)
+	}
+	if !response.IsTimeFormat(cfg.Response.TimeFormat) {
+		return nil, fmt.Errorf("unsupported TIMESTAMP_FORMAT %q", cfg.Response.TimeFormat)
+	}
+
+	utc, err := strconv.ParseBool(getEnv("TIMESTAMP_UTC", "false"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid TIMESTAMP_UTC %q", os.Getenv("TIMESTAMP_UTC"))
+	}
+	cfg.Response.UTC = utc
+
+	if err := loadDatabase(&cfg.Database, p); err != nil {
+		return nil, err
+	}
+
+	if err := loadLogging(&cfg.Logging, p); err != nil {
+		return nil, err
+	}
+
+	cfg.RequestID.Strategy = getEnv("REQUEST_ID_STRATEGY", middleware.RequestIDUUID)
+	if !middleware.IsRequestIDStrategy(cfg.RequestID.Strategy) {
+		return nil, fmt.Errorf("unsupported REQUEST_ID_STRATEGY %q", cfg.RequestID.Strategy)
+	}
+
+	interval, err := time.ParseDuration(getEnv("SCHEDULER_INTERVAL", "30s"))
+	if err != nil || interval <= 0 {
+		return nil, fmt.Errorf("invalid SCHEDULER_INTERVAL %q", os.Getenv("SCHEDULER_INTERVAL"))
+	}
+	cfg.Scheduler.Interval = interval
+
+	if err := loadNotifications(&cfg.Notifications, p); err != nil {
+		return nil, err
+	}
+
+	if err := loadHTTPClient(&cfg.HTTPClient); err != nil {
+		return nil, err
+	}
+
+	if err := loadExport(&cfg.Export); err != nil {
+		return nil, err
+	}
+
+	if err := loadRetention(&cfg.Retention); err != nil {
+		return nil, err
+	}
+
+	flushInterval, err := time.ParseDuration(getEnv("USAGE_FLUSH_INTERVAL", "1m"))
+	if err != nil || flushInterval <= 0 {
+		return nil, fmt.Errorf("invalid USAGE_FLUSH_INTERVAL %q", os.Getenv("USAGE_FLUSH_INTERVAL"))
+	}
+	cfg.Usage.FlushInterval = flushInterval
+
+	keys, err := auth.ParseAPIKeys(os.Getenv("AUTH_API_KEYS"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid AUTH_API_KEYS: %w", err)
+	}
+	cfg.Auth = AuthConfig{JWTSecret: os.Getenv("AUTH_JWT_SECRET"), APIKeys: keys}
+
+	quotas, err := quota.ParseLimits(os.Getenv("AUTH_API_KEY_QUOTAS"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid AUTH_API_KEY_QUOTAS: %w", err)
+	}
+	for name := range quotas {
+		if !slices.ContainsFunc(keys, func(key auth.APIKey) bool { return key.Name == name }) {
+			return nil, fmt.Errorf("invalid AUTH_API_KEY_QUOTAS: no API key named %q", name)
+		}
+	}
+	cfg.Auth.Quotas = quotas
+
+	teams, err := auth.ParseTeams(os.Getenv("AUTH_API_KEY_TEAMS"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid AUTH_API_KEY_TEAMS: %w", err)
+	}
+	for name, members := range teams {
+		i := slices.IndexFunc(keys, func(key auth.APIKey) bool { return key.Name == name })
+		if i < 0 {
+			return nil, fmt.Errorf("invalid AUTH_API_KEY_TEAMS: no API key named %q", name)
+		}
+		keys[i].Teams = members
+	}
+
+	if !cfg.Auth.Enabled() && cfg.Environment == EnvProduction {
+		return nil, fmt.Errorf("AUTH_JWT_SECRET or AUTH_API_KEYS is required when APP_ENV is %q", EnvProduction)
+	}
+	for _, scope := range strings.Split(p.getEnv("AUTH_ANONYMOUS_SCOPES", auth.ScopeModulesRead), ",") {
+		if scope = strings.TrimSpace(scope); scope == "" {
+			continue
+		}
+		if !auth.IsScope(scope) {
+			return nil, fmt.Errorf("invalid AUTH_ANONYMOUS_SCOPES: unsupported scope %q", scope)
+		}
+		cfg.Auth.AnonymousScopes = append(cfg.Auth.AnonymousScopes, scope)
+	}
+
+	chaos, err := middleware.ParseChaosRules(os.Getenv("CHAOS_RULES"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid CHAOS_RULES: %w", err)
+	}
+	if len(chaos) > 0 && cfg.Environment == EnvProduction {
+		return nil, fmt.Errorf("CHAOS_RULES is not allowed when APP_ENV is %q", EnvProduction)
+	}
+	cfg.Chaos.Rules = chaos
+
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy == "" {
+			continue
+		}
+		if _, _, err := net.ParseCIDR(proxy); err != nil && net.ParseIP(proxy) == nil {
+			return nil, fmt.Errorf("invalid TRUSTED_PROXIES entry %q", proxy)
+		}
+		cfg.TrustedProxies = append(cfg.TrustedProxies, proxy)
+	}
+
+	publicLimit, err := strconv.Atoi(getEnv("PUBLIC_RATE_LIMIT", strconv.Itoa(middleware.DefaultPublicRateLimit)))
+	if err != nil || publicLimit < 0 {
+		return nil, fmt.Errorf("invalid PUBLIC_RATE_LIMIT %q", os.Getenv("PUBLIC_RATE_LIMIT"))
+	}
+	cfg.Public.RateLimit = publicLimit
+
+	publicMaxAge, err := time.ParseDuration(getEnv("PUBLIC_CACHE_MAX_AGE", middleware.DefaultPublicCacheMaxAge.String()))
+	if err != nil || publicMaxAge < 0 {
+		return nil, fmt.Errorf("invalid PUBLIC_CACHE_MAX_AGE %q", os.Getenv("PUBLIC_CACHE_MAX_AGE"))
+	}
+	cfg.Public.CacheMaxAge = publicMaxAge
+
+	if err := loadCDN(&cfg.CDN); err != nil {
+		return nil, err
+	}
+
+	if err := loadCommands(&cfg.Commands); err != nil {
+		return nil, err
+	}
+
+	cfg.Provisioning.URL = os.Getenv("PROVISIONING_URL")
+	if cfg.Provisioning.URL != "" {
+		parsed, err := url.Parse(cfg.Provisioning.URL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return nil, fmt.Errorf("invalid PROVISIONING_URL %q: must be an http:// or https:// URL", cfg.Provisioning.URL)
+		}
+	}
+	cfg.Provisioning.Token = os.Getenv("PROVISIONING_TOKEN")
+	for _, plan := range strings.Split(getEnv("PROVISIONING_PLANS", "small,medium,large"), ",") {
+		if plan = strings.TrimSpace(plan); plan != "" {
+			cfg.Provisioning.Plans = append(cfg.Provisioning.Plans, plan)
+		}
+	}
+
+	if err := loadProfileSwitches(cfg, p); err != nil {
+		return nil, err
+	}
+
+	decorators, err := parseDecorators(os.Getenv("DECORATORS"))
+	if err != nil {
+		return nil, err
+	}
+	cfg.Decorators = decorators
+
+	slowThreshold, err := time.ParseDuration(getEnv("DASHBOARD_SLOW_REQUEST_THRESHOLD", "1s"))
+	if err != nil || slowThreshold <= 0 {
+		return nil, fmt.Errorf("invalid DASHBOARD_SLOW_REQUEST_THRESHOLD %q", os.Getenv("DASHBOARD_SLOW_REQUEST_THRESHOLD"))
+	}
+	cfg.Dashboard.SlowRequestThreshold = slowThreshold
+
+	switch cfg.Database.Driver {
+	case DriverMemory:
+		if cfg.Environment == EnvProduction {
+			return nil, fmt.Errorf("DB_DRIVER %q keeps no data and is not allowed when APP_ENV is %q; use a SQL driver or APP_ENV=%s", DriverMemory, EnvProduction, EnvDevelopment)
+		}
+	case DriverSQLite, DriverPostgres, DriverMySQL:
+		if cfg.Database.DSN == "" {
+			return nil, fmt.Errorf("DB_DSN is required for driver %q", cfg.Database.Driver)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported DB_DRIVER %q", cfg.Database.Driver)
+	}
+
+	if err := loadLocks(&cfg.Locks, cfg.Database.Driver); err != nil {
+		return nil, err
+	}
+
+	if err := loadLeader(&cfg.Leader, cfg.Database.Driver, cfg.Locks.RedisURL); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// loadCDN reads the edge caching settings.
+func loadCDN(c *CDNConfig) error {
+	maxAge, err := time.ParseDuration(getEnv("CDN_MAX_AGE", "0s"))
+	if err != nil || maxAge < 0 {
+		return fmt.Errorf("invalid CDN_MAX_AGE %q", os.Getenv("CDN_MAX_AGE"))
+	}
+	c.MaxAge = maxAge
+
+	c.PurgeURL = os.Getenv("CDN_PURGE_URL")
+	if c.PurgeURL != "" {
+		parsed, err := url.Parse(c.PurgeURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("invalid CDN_PURGE_URL %q: must be an http:// or https:// URL", c.PurgeURL)
+		}
+	}
+	c.PurgeToken = os.Getenv("CDN_PURGE_TOKEN")
+	return nil
+}
+
+// loadCommands reads the command queue settings.
+func loadCommands(c *CommandConfig) error {
+	c.QueueURL = os.Getenv("COMMAND_QUEUE_URL")
+	c.Queue = getEnv("COMMAND_QUEUE", "module-commands")
+	c.ReplyTopic = getEnv("COMMAND_REPLY_TOPIC", "module-command-replies")
+	c.Exchange = getEnv("COMMAND_QUEUE_EXCHANGE", "modules")
+
+	prefetch, err := strconv.Atoi(getEnv("COMMAND_QUEUE_PREFETCH", "10"))
+	if err != nil || prefetch < 0 || prefetch > math.MaxUint16 {
+		return fmt.Errorf("invalid COMMAND_QUEUE_PREFETCH %q", os.Getenv("COMMAND_QUEUE_PREFETCH"))
+	}
+	c.Prefetch = prefetch
+
+	maxRetries, err := strconv.Atoi(getEnv("COMMAND_MAX_RETRIES", "3"))
+	if err != nil || maxRetries < 0 {
+		return fmt.Errorf("invalid COMMAND_MAX_RETRIES %q", os.Getenv("COMMAND_MAX_RETRIES"))
+	}
+	c.MaxRetries = maxRetries
+
+	switch {
+	case c.QueueURL == "":
+	case strings.HasPrefix(c.QueueURL, "amqp"):
+		if _, err := amqp.ParseURI(c.QueueURL); err != nil {
+			return fmt.Errorf("invalid COMMAND_QUEUE_URL: %w", err)
+		}
+	default:
+		if _, err := redis.ParseURL(c.QueueURL); err != nil {
+			return fmt.Errorf("invalid COMMAND_QUEUE_URL: %w", err)
+		}
+	}
+	return nil
+}
+
+// loadProfileSwitches reads the settings whose defaults mostly come from the
+// environment profile: Swagger, sample data and the clock.
+func loadProfileSwitches(cfg *Config, p profile) error {
+	swagger, err := strconv.ParseBool(p.getEnv("SWAGGER_ENABLED", "false"))
+	if err != nil {
+		return fmt.Errorf("invalid SWAGGER_ENABLED %q", os.Getenv("SWAGGER_ENABLED"))
+	}
+	cfg.Swagger.Enabled = swagger
+	if err := loadSwagger(&cfg.Swagger, cfg.Auth); err != nil {
+		return err
+	}
+
+	seed, err := strconv.ParseBool(p.getEnv("SEED_DATA", "false"))
+	if err != nil {
+		return fmt.Errorf("invalid SEED_DATA %q", os.Getenv("SEED_DATA"))
+	}
+	cfg.Seed.Enabled = seed
+
+	if fixedAt := p.getEnv("CLOCK_FIXED_AT", ""); fixedAt != "" {
+		parsed, err := time.Parse(time.RFC3339, fixedAt)
+		if err != nil {
+			return fmt.Errorf("invalid CLOCK_FIXED_AT %q: must be an RFC 3339 time", fixedAt)
+		}
+		cfg.Clock.FixedAt = parsed
+	}
+	return nil
+}
+
+// loadSwagger reads the protection and the deployment address of the API
+// documentation.
+func loadSwagger(s *SwaggerConfig, authCfg AuthConfig) error {
+	s.Auth = getEnv("SWAGGER_AUTH", SwaggerAuthNone)
+	s.Username = os.Getenv("SWAGGER_USERNAME")
+	s.Password = os.Getenv("SWAGGER_PASSWORD")
+	switch s.Auth {
+	case SwaggerAuthNone:
+	case SwaggerAuthBasic:
+		if s.Username == "" || s.Password == "" {
+			return fmt.Errorf("SWAGGER_USERNAME and SWAGGER_PASSWORD are required when SWAGGER_AUTH is %q", SwaggerAuthBasic)
+		}
+	case SwaggerAuthAdmin:
+		if !authCfg.Enabled() {
+			return fmt.Errorf("SWAGGER_AUTH %q needs AUTH_JWT_SECRET or AUTH_API_KEYS", SwaggerAuthAdmin)
+		}
+	default:
+		return fmt.Errorf("unsupported SWAGGER_AUTH %q", s.Auth)
+	}
+
+	s.Host = os.Getenv("SWAGGER_HOST")
+	if strings.Contains(s.Host, "/") {
+		return fmt.Errorf("invalid SWAGGER_HOST %q: must be a host with optional port", s.Host)
+	}
+	s.BasePath = getEnv("SWAGGER_BASE_PATH", "/api/v1")
+	if !strings.HasPrefix(s.BasePath, "/") {
+		return fmt.Errorf("invalid SWAGGER_BASE_PATH %q: must start with /", s.BasePath)
+	}
+	for _, scheme := range strings.Split(os.Getenv("SWAGGER_SCHEMES"), ",") {
+		switch scheme = strings.TrimSpace(scheme); scheme {
+		case "":
+		case "http", "https":
+			s.Schemes = append(s.Schemes, scheme)
+		default:
+			return fmt.Errorf("invalid SWAGGER_SCHEMES entry %q: must be http or https", scheme)
+		}
+	}
+	return nil
+}
+
+// parseDecorators parses decorator chains such as
+// "provisioning.allocator=cache,logging;module.repository=metrics".
+func parseDecorators(spec string) (map[string][]string, error) {
+	chains := make(map[string][]string)
+	for _, rule := range strings.Split(spec, ";") {
+		if strings.TrimSpace(rule) == "" {
+			continue
+		}
+		component, names, ok := strings.Cut(rule, "=")
+		component = strings.TrimSpace(component)
+		if !ok || component == "" {
+			return nil, fmt.Errorf("invalid DECORATORS entry %q: must look like <component>=<decorator>[,<decorator>]", rule)
+		}
+		for _, name := range strings.Split(names, ",") {
+			if name = strings.TrimSpace(name); name == "" {
+				return nil, fmt.Errorf("invalid DECORATORS entry %q: empty decorator name", rule)
+			}
+			chains[component] = append(chains[component], name)
+		}
+	}
+	return chains, nil
+}
+
+// loadLogging reads the log levels and format and the access log sampling.
+func loadLogging(l *LoggingConfig, p profile) error {
+	level, err := logging.ParseLevel(p.getEnv("LOG_LEVEL", "info"))
+	if err != nil {
+		return fmt.Errorf("invalid LOG_LEVEL %q", os.Getenv("LOG_LEVEL"))
+	}
+	l.Level = level
+
+	l.Format = p.getEnv("LOG_FORMAT", logging.FormatText)
+	if !logging.IsFormat(l.Format) {
+		return fmt.Errorf("unsupported LOG_FORMAT %q", l.Format)
+	}
+
+	l.Levels = make(map[string]logging.Level)
+	for _, pair := range strings.Split(os.Getenv("LOG_LEVELS"), ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		name, levelName, ok := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		level, err := logging.ParseLevel(strings.TrimSpace(levelName))
+		if !ok || name == "" || err != nil {
+			return fmt.Errorf("invalid LOG_LEVELS entry %q", pair)
+		}
+		l.Levels[name] = level
+	}
+
+	initial, err := strconv.Atoi(getEnv("LOG_SAMPLE_INITIAL", "0"))
+	if err != nil || initial < 0 {
+		return fmt.Errorf("invalid LOG_SAMPLE_INITIAL %q", os.Getenv("LOG_SAMPLE_INITIAL"))
+	}
+	l.SampleInitial = initial
+
+	thereafter, err := strconv.Atoi(getEnv("LOG_SAMPLE_THEREAFTER", "100"))
+	if err != nil || thereafter < 0 {
+		return fmt.Errorf("invalid LOG_SAMPLE_THEREAFTER %q", os.Getenv("LOG_SAMPLE_THEREAFTER"))
+	}
+	l.SampleThereafter = thereafter
+
+	slowThreshold, err := time.ParseDuration(getEnv("LOG_SLOW_REQUEST_THRESHOLD", "0s"))
+	if err != nil || slowThreshold < 0 {
+		return fmt.Errorf("invalid LOG_SLOW_REQUEST_THRESHOLD %q", os.Getenv("LOG_SLOW_REQUEST_THRESHOLD"))
+	}
+	l.SlowRequestThreshold = slowThreshold
+	return nil
+}
+
+// loadLocks reads the lock backend and checks that it fits the database.
+func loadLocks(l *LockConfig, driver string) error {
+	defaultBackend := LockLocal
+	if driver == DriverPostgres {
+		defaultBackend = LockPostgres
+	}
+	l.Backend = getEnv("LOCK_BACKEND", defaultBackend)
+	l.RedisURL = os.Getenv("LOCK_REDIS_URL")
+
+	switch l.Backend {
+	case LockLocal:
+	case LockRedis:
+		if l.RedisURL == "" {
+			return fmt.Errorf("LOCK_REDIS_URL is required for LOCK_BACKEND %q", LockRedis)
+		}
+		if _, err := redis.ParseURL(l.RedisURL); err != nil {
+			return fmt.Errorf("invalid LOCK_REDIS_URL: %w", err)
+		}
+	case LockPostgres:
+		if driver != DriverPostgres {
+			return fmt.Errorf("LOCK_BACKEND %q needs DB_DRIVER %q", LockPostgres, DriverPostgres)
+		}
+	default:
+		return fmt.Errorf("unsupported LOCK_BACKEND %q", l.Backend)
+	}
+	return nil
+}
+
+// loadLeader reads the leader election backend and checks that it fits the database.
+func loadLeader(l *LeaderConfig, driver, lockRedisURL string) error {
+	defaultBackend := LeaderDatabase
+	if driver == DriverMemory {
+		defaultBackend = LeaderLocal
+	}
+	l.Backend = getEnv("LEADER_BACKEND", defaultBackend)
+	l.RedisURL = getEnv("LEADER_REDIS_URL", lockRedisURL)
+
+	ttl, err := time.ParseDuration(getEnv("LEADER_LEASE_TTL", "15s"))
+	if err != nil || ttl <= 0 {
+		return fmt.Errorf("invalid LEADER_LEASE_TTL %q", os.Getenv("LEADER_LEASE_TTL"))
+	}
+	l.LeaseTTL = ttl
+
+	switch l.Backend {
+	case LeaderLocal:
+	case LeaderRedis:
+		if l.RedisURL == "" {
+			return fmt.Errorf("LEADER_REDIS_URL is required for LEADER_BACKEND %q", LeaderRedis)
+		}
+		if _, err := redis.ParseURL(l.RedisURL); err != nil {
+			return fmt.Errorf("invalid LEADER_REDIS_URL: %w", err)
+		}
+	case LeaderDatabase:
+		if driver == DriverMemory {
+			return fmt.Errorf("LEADER_BACKEND %q needs a SQL DB_DRIVER", LeaderDatabase)
+		}
+	default:
+		return fmt.Errorf("unsupported LEADER_BACKEND %q", l.Backend)
+	}
+	return nil
+}
+
+// loadDatabase reads the GORM performance and caching settings.
+func loadDatabase(d *DatabaseConfig, p profile) error {
+	prepareStmt, err := strconv.ParseBool(getEnv("DB_PREPARE_STMT", "false"))
+	if err != nil {
+		return fmt.Errorf("invalid DB_PREPARE_STMT %q", os.Getenv("DB_PREPARE_STMT"))
+	}
+	d.PrepareStmt = prepareStmt
+
+	skipTransaction, err := strconv.ParseBool(getEnv("DB_SKIP_DEFAULT_TRANSACTION", "true"))
+	if err != nil {
+		return fmt.Errorf("invalid DB_SKIP_DEFAULT_TRANSACTION %q", os.Getenv("DB_SKIP_DEFAULT_TRANSACTION"))
+	}
+	d.SkipDefaultTransaction = skipTransaction
+
+	batchSize, err := strconv.Atoi(getEnv("DB_BATCH_SIZE", "100"))
+	if err != nil || batchSize <= 0 {
+		return fmt.Errorf("invalid DB_BATCH_SIZE %q", os.Getenv("DB_BATCH_SIZE"))
+	}
+	d.BatchSize = batchSize
+
+	cacheTTL, err := time.ParseDuration(getEnv("DB_CACHE_TTL", "0s"))
+	if err != nil || cacheTTL < 0 {
+		return fmt.Errorf("invalid DB_CACHE_TTL %q", os.Getenv("DB_CACHE_TTL"))
+	}
+	d.CacheTTL = cacheTTL
+
+	notFoundTTL, err := time.ParseDuration(getEnv("DB_CACHE_NOT_FOUND_TTL", "0s"))
+	if err != nil || notFoundTTL < 0 {
+		return fmt.Errorf("invalid DB_CACHE_NOT_FOUND_TTL %q", os.Getenv("DB_CACHE_NOT_FOUND_TTL"))
+	}
+	d.CacheNotFoundTTL = notFoundTTL
+
+	nameFilterRefresh, err := time.ParseDuration(getEnv("DB_NAME_FILTER_REFRESH", "0s"))
+	if err != nil || nameFilterRefresh < 0 {
+		return fmt.Errorf("invalid DB_NAME_FILTER_REFRESH %q", os.Getenv("DB_NAME_FILTER_REFRESH"))
+	}
+	d.NameFilterRefresh = nameFilterRefresh
+
+	healthInterval, err := time.ParseDuration(getEnv("DB_HEALTH_INTERVAL", "10s"))
+	if err != nil || healthInterval < 0 {
+		return fmt.Errorf("invalid DB_HEALTH_INTERVAL %q", os.Getenv("DB_HEALTH_INTERVAL"))
+	}
+	d.HealthInterval = healthInterval
+
+	queryTimeout, err := time.ParseDuration(getEnv("DB_QUERY_TIMEOUT", "5s"))
+	if err != nil || queryTimeout < 0 {
+		return fmt.Errorf("invalid DB_QUERY_TIMEOUT %q", os.Getenv("DB_QUERY_TIMEOUT"))
+	}
+	d.QueryTimeout = queryTimeout
+
+	writeTimeout, err := time.ParseDuration(getEnv("DB_WRITE_TIMEOUT", "10s"))
+	if err != nil || writeTimeout < 0 {
+		return fmt.Errorf("invalid DB_WRITE_TIMEOUT %q", os.Getenv("DB_WRITE_TIMEOUT"))
+	}
+	d.WriteTimeout = writeTimeout
+
+	queryBudget, err := strconv.Atoi(p.getEnv("DB_QUERY_BUDGET", "0"))
+	if err != nil || queryBudget < 0 {
+		return fmt.Errorf("invalid DB_QUERY_BUDGET %q", os.Getenv("DB_QUERY_BUDGET"))
+	}
+	d.QueryBudget = queryBudget
+
+	d.IDGenerator = getEnv("DB_ID_GENERATOR", IDGeneratorSequence)
+	switch d.IDGenerator {
+	case IDGeneratorSequence, IDGeneratorSnowflake:
+	case "uuid", "uuidv7":
+		return fmt.Errorf("unsupported DB_ID_GENERATOR %q: module IDs are integers; use %s for time-ordered IDs unique across instances", d.IDGenerator, IDGeneratorSnowflake)
+	default:
+		return fmt.Errorf("unsupported DB_ID_GENERATOR %q", d.IDGenerator)
+	}
+
+	nodeID, err := strconv.Atoi(getEnv("DB_NODE_ID", "0"))
+	if err != nil || nodeID < 0 || nodeID > 1023 {
+		return fmt.Errorf("invalid DB_NODE_ID %q", os.Getenv("DB_NODE_ID"))
+	}
+	d.NodeID = nodeID
+
+	d.CacheInvalidationURL = os.Getenv("DB_CACHE_INVALIDATION_URL")
+	d.CacheInvalidationChannel = getEnv("DB_CACHE_INVALIDATION_CHANNEL", "module-cache")
+	if d.CacheInvalidationURL != "" {
+		if _, err := redis.ParseURL(d.CacheInvalidationURL); err != nil {
+			return fmt.Errorf("invalid DB_CACHE_INVALIDATION_URL: %w", err)
+		}
+	}
+	return nil
+}
+
+// loadNotifications reads the notification settings and checks that every
+// routed channel is configured.
+func loadNotifications(n *NotificationConfig, p profile) error {
+	routes, err := notification.ParseRoutes(os.Getenv("NOTIFY_ROUTES"))
+	if err != nil {
+		return fmt.Errorf("invalid NOTIFY_ROUTES: %w", err)
+	}
+	n.Routes = routes
+
+	n.SMTPAddr = os.Getenv("SMTP_ADDR")
+	n.SMTPFrom = os.Getenv("SMTP_FROM")
+	for _, to := range strings.Split(os.Getenv("SMTP_TO"), ",") {
+		if to = strings.TrimSpace(to); to != "" {
+			n.SMTPTo = append(n.SMTPTo, to)
+		}
+	}
+	n.SMTPUsername = os.Getenv("SMTP_USERNAME")
+	n.SMTPPassword = os.Getenv("SMTP_PASSWORD")
+	n.WebhookURL = os.Getenv("NOTIFY_WEBHOOK_URL")
+	n.SlackWebhookURL = os.Getenv("NOTIFY_SLACK_WEBHOOK_URL")
+
+	fake, err := strconv.ParseBool(p.getEnv("NOTIFY_FAKE", "false"))
+	if err != nil {
+		return fmt.Errorf("invalid NOTIFY_FAKE %q", os.Getenv("NOTIFY_FAKE"))
+	}
+	n.Fake = fake
+
+	used := routes.Used()
+	switch {
+	case n.Fake:
+	case used[notification.ChannelEmail] && (n.SMTPAddr == "" || n.SMTPFrom == "" || len(n.SMTPTo) == 0):
+		return fmt.Errorf("SMTP_ADDR, SMTP_FROM and SMTP_TO are required when NOTIFY_ROUTES uses %q", notification.ChannelEmail)
+	case used[notification.ChannelWebhook] && n.WebhookURL == "":
+		return fmt.Errorf("NOTIFY_WEBHOOK_URL is required when NOTIFY_ROUTES uses %q", notification.ChannelWebhook)
+	case used[notification.ChannelSlack] && n.SlackWebhookURL == "":
+		return fmt.Errorf("NOTIFY_SLACK_WEBHOOK_URL is required when NOTIFY_ROUTES uses %q", notification.ChannelSlack)
+	}
+	return nil
+}
+
+// loadHTTPClient reads the timeout, retry and breaker settings of outbound calls.
+func loadHTTPClient(h *HTTPClientConfig) error {
+	timeout, err := time.ParseDuration(getEnv("HTTP_CLIENT_TIMEOUT", "10s"))
+	if err != nil || timeout <= 0 {
+		return fmt.Errorf("invalid HTTP_CLIENT_TIMEOUT %q", os.Getenv("HTTP_CLIENT_TIMEOUT"))
+	}
+	h.Timeout = timeout
+
+	retries, err := strconv.Atoi(getEnv("HTTP_CLIENT_RETRIES", "2"))
+	if err != nil || retries < 0 {
+		return fmt.Errorf("invalid HTTP_CLIENT_RETRIES %q", os.Getenv("HTTP_CLIENT_RETRIES"))
+	}
+	h.Retries = retries
+
+	backoff, err := time.ParseDuration(getEnv("HTTP_CLIENT_RETRY_BACKOFF", "200ms"))
+	if err != nil || backoff < 0 {
+		return fmt.Errorf("invalid HTTP_CLIENT_RETRY_BACKOFF %q", os.Getenv("HTTP_CLIENT_RETRY_BACKOFF"))
+	}
+	h.RetryBackoff = backoff
+
+	threshold, err := strconv.Atoi(getEnv("HTTP_CLIENT_BREAKER_THRESHOLD", "5"))
+	if err != nil || threshold < 0 {
+		return fmt.Errorf("invalid HTTP_CLIENT_BREAKER_THRESHOLD %q", os.Getenv("HTTP_CLIENT_BREAKER_THRESHOLD"))
+	}
+	h.BreakerThreshold = threshold
+
+	cooldown, err := time.ParseDuration(getEnv("HTTP_CLIENT_BREAKER_COOLDOWN", "30s"))
+	if err != nil || cooldown <= 0 {
+		return fmt.Errorf("invalid HTTP_CLIENT_BREAKER_COOLDOWN %q", os.Getenv("HTTP_CLIENT_BREAKER_COOLDOWN"))
+	}
+	h.BreakerCooldown = cooldown
+
+	return loadEgress(h)
+}
+
+// loadEgress reads the proxy and TLS settings of outbound calls and loads the
+// CA bundle.
+func loadEgress(h *HTTPClientConfig) error {
+	if raw := os.Getenv("HTTP_CLIENT_PROXY"); raw != "" {
+		proxy, err := url.Parse(raw)
+		if err != nil || proxy.Host == "" {
+			return fmt.Errorf("invalid HTTP_CLIENT_PROXY: expected a URL like http://proxy:3128")
+		}
+		switch proxy.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return fmt.Errorf("unsupported HTTP_CLIENT_PROXY scheme %q (expected http, https or socks5)", proxy.Scheme)
+		}
+		h.Proxy = proxy
+	}
+	h.NoProxy = getEnv("HTTP_CLIENT_NO_PROXY", os.Getenv("NO_PROXY"))
+
+	if path := os.Getenv("HTTP_CLIENT_CA_FILE"); path != "" {
+		bundle, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("invalid HTTP_CLIENT_CA_FILE: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(bundle) {
+			return fmt.Errorf("invalid HTTP_CLIENT_CA_FILE: no PEM certificates in %s", path)
+		}
+		h.RootCAs = pool
+	}
+
+	switch version := getEnv("HTTP_CLIENT_TLS_MIN_VERSION", "1.2"); version {
+	case "1.2":
+		h.TLSMinVersion = tls.VersionTLS12
+	case "1.3":
+		h.TLSMinVersion = tls.VersionTLS13
+	default:
+		return fmt.Errorf("unsupported HTTP_CLIENT_TLS_MIN_VERSION %q (expected 1.2 or 1.3)", version)
+	}
+	return nil
+}
+
+// loadExport reads the export storage settings and checks the selected backend is configured.
+func loadExport(e *ExportConfig) error {
+	e.Storage = getEnv("EXPORT_STORAGE", ExportStorageLocal)
+	e.Dir = getEnv("EXPORT_DIR", filepath.Join(os.TempDir(), "module-exports"))
+
+	ttl, err := time.ParseDuration(getEnv("EXPORT_URL_TTL", "15m"))
+	if err != nil || ttl <= 0 {
+		return fmt.Errorf("invalid EXPORT_URL_TTL %q", os.Getenv("EXPORT_URL_TTL"))
+	}
+	e.URLTTL = ttl
+
+	e.SigningKey = []byte(os.Getenv("EXPORT_SIGNING_KEY"))
+	if len(e.SigningKey) == 0 {
+		e.SigningKey = make([]byte, 32)
+		if _, err := rand.Read(e.SigningKey); err != nil {
+			return fmt.Errorf("generate export signing key: %w", err)
+		}
+	}
+
+	e.S3Endpoint = os.Getenv("S3_ENDPOINT")
+	e.S3Region = getEnv("S3_REGION", "us-east-1")
+	e.S3Bucket = os.Getenv("S3_BUCKET")
+	e.S3AccessKeyID = os.Getenv("S3_ACCESS_KEY_ID")
+	e.S3SecretAccessKey = os.Getenv("S3_SECRET_ACCESS_KEY")
+	forcePathStyle, err := strconv.ParseBool(getEnv("S3_FORCE_PATH_STYLE", "false"))
+	if err != nil {
+		return fmt.Errorf("invalid S3_FORCE_PATH_STYLE %q", os.Getenv("S3_FORCE_PATH_STYLE"))
+	}
+	e.S3ForcePathStyle = forcePathStyle
+
+	switch e.Storage {
+	case ExportStorageLocal:
+	case ExportStorageS3:
+		if e.S3Endpoint == "" || e.S3Bucket == "" || e.S3AccessKeyID == "" || e.S3SecretAccessKey == "" {
+			return fmt.Errorf("S3_ENDPOINT, S3_BUCKET, S3_ACCESS_KEY_ID and S3_SECRET_ACCESS_KEY are required when EXPORT_STORAGE is %q", ExportStorageS3)
+		}
+		if e.URLTTL > 7*24*time.Hour {
+			return fmt.Errorf("EXPORT_URL_TTL must not exceed 168h with EXPORT_STORAGE %q", ExportStorageS3)
+		}
+	default:
+		return fmt.Errorf("unsupported EXPORT_STORAGE %q", e.Storage)
+	}
+	return nil
+}
+
+// loadRetention reads the data retention policy.
+func loadRetention(r *RetentionConfig) error {
+	rules, err := retention.ParseRules(os.Getenv("RETENTION_RULES"))
+	if err != nil {
+		return fmt.Errorf("invalid RETENTION_RULES: %w", err)
+	}
+	r.Rules = rules
+
+	dryRun, err := strconv.ParseBool(getEnv("RETENTION_DRY_RUN", "false"))
+	if err != nil {
+		return fmt.Errorf("invalid RETENTION_DRY_RUN %q", os.Getenv("RETENTION_DRY_RUN"))
+	}
+	r.DryRun = dryRun
+
+	interval, err := time.ParseDuration(getEnv("RETENTION_INTERVAL", "24h"))
+	if err != nil || interval <= 0 {
+		return fmt.Errorf("invalid RETENTION_INTERVAL %q", os.Getenv("RETENTION_INTERVAL"))
+	}
+	r.Interval = interval
+	return nil
+}
+
+// getEnv returns the environment variable or a fallback when unset or empty.
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestLoadWithoutEnvironment(t *testing.T) {
+	t.Setenv("APP_ENV", "")
+	t.Setenv("DB_DRIVER", "")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v, want a bare start to work", err)
+	}
+	if cfg.Environment != EnvDevelopment {
+		t.Errorf("Environment = %q, want %q", cfg.Environment, EnvDevelopment)
+	}
+	if cfg.Database.Driver != DriverMemory {
+		t.Errorf("Database.Driver = %q, want %q", cfg.Database.Driver, DriverMemory)
+	}
+}
+
+func TestLoadRefusesMemoryDriverInProduction(t *testing.T) {
+	t.Setenv("APP_ENV", EnvProduction)
+	t.Setenv("DB_DRIVER", "")
+
+	if _, err := Load(); err == nil {
+		t.Error("Load() error = nil, want the in-memory driver refused in production")
+	}
+}
+
+func TestLoadRejectsUUIDGenerator(t *testing.T) {
+	t.Setenv("APP_ENV", "")
+	t.Setenv("DB_ID_GENERATOR", "uuidv7")
+
+	_, err := Load()
+	if err == nil || !strings.Contains(err.Error(), IDGeneratorSnowflake) {
+		t.Errorf("Load() error = %v, want UUIDs rejected in favour of %s", err, IDGeneratorSnowflake)
+	}
+}
+
+func TestLoadRequiresCredentialsInProduction(t *testing.T) {
+	t.Setenv("APP_ENV", EnvProduction)
+	t.Setenv("DB_DRIVER", DriverSQLite)
+	t.Setenv("DB_DSN", "file:modules.db")
+	t.Setenv("AUTH_JWT_SECRET", "")
+	t.Setenv("AUTH_API_KEYS", "")
+
+	_, err := Load()
+	if err == nil || !strings.Contains(err.Error(), "AUTH_JWT_SECRET") {
+		t.Errorf("Load() error = %v, want production refused without credentials", err)
+	}
+
+	t.Setenv("AUTH_API_KEYS", "ops=admin@t0ps3cr3t")
+	if _, err := Load(); err != nil {
+		t.Errorf("Load() with an API key error = %v", err)
+	}
+}
+
+func TestLoadAnonymousScopes(t *testing.T) {
+	tests := []struct {
+		environment string
+		scopes      string
+		want        []string
+	}{
+		{environment: EnvStaging, want: []string{"modules:read"}},
+		{environment: EnvDevelopment, want: []string{"admin"}},
+		{environment: EnvStaging, scopes: "modules:read, modules:write", want: []string{"modules:read", "modules:write"}},
+	}
+	for _, tt := range tests {
+		t.Setenv("APP_ENV", tt.environment)
+		t.Setenv("DB_DRIVER", DriverMemory)
+		t.Setenv("AUTH_ANONYMOUS_SCOPES", tt.scopes)
+
+		cfg, err := Load()
+		if err != nil {
+			t.Fatalf("Load() in %s error = %v", tt.environment, err)
+		}
+		if !slices.Equal(cfg.Auth.AnonymousScopes, tt.want) {
+			t.Errorf("AnonymousScopes in %s with %q = %v, want %v", tt.environment, tt.scopes, cfg.Auth.AnonymousScopes, tt.want)
+		}
+	}
+
+	t.Setenv("AUTH_ANONYMOUS_SCOPES", "modules:delete")
+	if _, err := Load(); err == nil {
+		t.Error("Load() error = nil, want an unsupported scope rejected")
+	}
+}
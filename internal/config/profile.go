@@ -0,0 +1,62 @@
+package config
+
+import "os"
+
+// profile holds the defaults an environment (APP_ENV) gives to settings that
+// are not set explicitly.
+//
+// Every entry is an environment variable with its default; setting the
+// variable overrides the profile, so single components can be switched
+// independently (e.g. SWAGGER_ENABLED=true in production).
+type profile map[string]string
+
+// profiles holds the defaults of each environment.
+//
+//   - development: in-memory storage with sample data, debug logs, Swagger,
+//     warnings about requests running many statements, all scopes for
+//     unauthenticated requests
+//   - test: in-memory storage, a clock frozen at a fixed time and
+//     notifications recorded instead of delivered, Swagger, all scopes for
+//     unauthenticated requests
+//   - staging: JSON logs, Swagger
+//   - production: JSON logs, no Swagger; a SQL driver and credentials are
+//     required
+var profiles = map[string]profile{
+	EnvDevelopment: {
+		"DB_DRIVER":             DriverMemory,
+		"LOG_LEVEL":             "debug",
+		"LOG_FORMAT":            "text",
+		"SWAGGER_ENABLED":       "true",
+		"SEED_DATA":             "true",
+		"DB_QUERY_BUDGET":       "20",
+		"AUTH_ANONYMOUS_SCOPES": "admin",
+	},
+	EnvTest: {
+		"DB_DRIVER":             DriverMemory,
+		"LOG_FORMAT":            "text",
+		"SWAGGER_ENABLED":       "true",
+		"CLOCK_FIXED_AT":        "2024-01-01T00:00:00Z",
+		"NOTIFY_FAKE":           "true",
+		"AUTH_ANONYMOUS_SCOPES": "admin",
+	},
+	EnvStaging: {
+		"LOG_FORMAT":      "json",
+		"SWAGGER_ENABLED": "true",
+	},
+	EnvProduction: {
+		"LOG_FORMAT":      "json",
+		"SWAGGER_ENABLED": "false",
+	},
+}
+
+// getEnv reads an environment variable, falling back to the profile default
+// and then to the given fallback.
+func (p profile) getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	if value, ok := p[key]; ok {
+		return value
+	}
+	return fallback
+}
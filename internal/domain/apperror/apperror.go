@@ -0,0 +1,196 @@
+package apperror
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// maxStackDepth caps the frames recorded by Wrap and Detailf.
+const maxStackDepth = 32
+
+// declared holds every error declared with New, in declaration order.
+var (
+	declaredMu sync.Mutex
+	declared   []*AppError
+)
+
+// AppError is an error of the business layer that knows how it is reported.
+//
+// Services declare their failures as AppError sentinels and return them,
+// wrapped around their cause or with a client-facing detail when there is one.
+// The transport layer renders the status, code, message key and field details
+// without looking at the cause, so clients get a sanitized response while logs
+// show the full chain down to the root cause.
+//
+// Copies made by Wrap and Detailf match their sentinel with errors.Is and
+// record the stack of the call that created them.
+//
+// Usage Example:
+//
+//	var ErrNotFound = apperror.New(http.StatusNotFound, "NOT_FOUND", "module.not_found", "module not found")
+//
+//	if errors.Is(err, gorm.ErrRecordNotFound) {
+//	    return nil, ErrNotFound.Wrap(err)
+//	}
+type AppError struct {
+	// HTTP status code of the response
+	Status int
+
+	// Machine-readable error code, shared by related errors (e.g. "NOT_FOUND")
+	Code string
+
+	// Key of the user message, unique per sentinel (e.g. "module.not_found");
+	// clients use it to pick a localized text
+	MessageKey string
+
+	// Description of the error, logged with the cause and used as text of the
+	// field details
+	Message string
+
+	// Field-specific validation errors (optional)
+	Fields map[string][]string
+
+	// Underlying error, logged but never rendered
+	cause error
+
+	// Program counters of the call that created the copy (sentinels have none)
+	stack []uintptr
+}
+
+// New declares an error.
+//
+// Declare sentinels as package variables; every declared error is listed by
+// Declared.
+//
+// Parameters:
+//   - status: HTTP status code of the response
+//   - code: Machine-readable error code
+//   - messageKey: Key of the user message
+//   - message: Description of the error
+//
+// Returns:
+//   - *AppError: The sentinel error
+func New(status int, code, messageKey, message string) *AppError {
+	err := &AppError{Status: status, Code: code, MessageKey: messageKey, Message: message}
+
+	declaredMu.Lock()
+	declared = append(declared, err)
+	declaredMu.Unlock()
+	return err
+}
+
+// Declared returns every error declared with New.
+//
+// Sentinels are declared as package variables, so by the time main runs the
+// list holds the errors of every linked service; the API documentation lists
+// their codes.
+//
+// Returns:
+//   - []*AppError: The sentinels, in declaration order
+func Declared() []*AppError {
+	declaredMu.Lock()
+	defer declaredMu.Unlock()
+
+	return append([]*AppError(nil), declared...)
+}
+
+// Validation declares a 400 VALIDATION_ERROR reported on one field.
+//
+// Parameters:
+//   - field: Request field the error is reported on
+//   - messageKey: Key of the user message
+//   - message: Description of the error, also the text of the field detail
+//
+// Returns:
+//   - *AppError: The sentinel error
+func Validation(field, messageKey, message string) *AppError {
+	err := New(http.StatusBadRequest, "VALIDATION_ERROR", messageKey, message)
+	err.Fields = map[string][]string{field: {message}}
+	return err
+}
+
+// Wrap returns a copy of the error caused by another error.
+//
+// Parameters:
+//   - cause: The underlying error, e.g. from the data layer
+//
+// Returns:
+//   - *AppError: The copy, matching the error with errors.Is and unwrapping
+//     to cause
+func (e *AppError) Wrap(cause error) *AppError {
+	wrapped := e.clone()
+	wrapped.cause = cause
+	return wrapped
+}
+
+// Detailf returns a copy of the error with a detail appended to its message
+// and field details.
+//
+// The detail is shown to clients, so it must not contain internals.
+//
+// Parameters:
+//   - format: Format of the detail, e.g. "principal %q is listed twice"
+//   - args: Format arguments
+//
+// Returns:
+//   - *AppError: The copy, matching the error with errors.Is
+func (e *AppError) Detailf(format string, args ...any) *AppError {
+	detailed := e.clone()
+	detailed.Message = e.Message + ": " + fmt.Sprintf(format, args...)
+	for field := range detailed.Fields {
+		detailed.Fields[field] = []string{detailed.Message}
+	}
+	return detailed
+}
+
+// clone copies the error and records the stack of the caller's caller.
+func (e *AppError) clone() *AppError {
+	copied := *e
+	copied.Fields = maps.Clone(e.Fields)
+	copied.stack = make([]uintptr, maxStackDepth)
+	copied.stack = copied.stack[:runtime.Callers(3, copied.stack)]
+	return &copied
+}
+
+// Error describes the error and its cause for logs.
+func (e *AppError) Error() string {
+	if e.cause != nil {
+		return e.Message + ": " + e.cause.Error()
+	}
+	return e.Message
+}
+
+// Unwrap returns the cause of the error.
+func (e *AppError) Unwrap() error {
+	return e.cause
+}
+
+// Is reports whether target is the same sentinel, so copies match it.
+func (e *AppError) Is(target error) bool {
+	sentinel, ok := target.(*AppError)
+	return ok && sentinel.MessageKey == e.MessageKey
+}
+
+// Stack returns the frames of the call that created the error.
+//
+// Returns:
+//   - string: One "function (file:line)" per line; empty for sentinels
+func (e *AppError) Stack() string {
+	if len(e.stack) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	frames := runtime.CallersFrames(e.stack)
+	for {
+		frame, more := frames.Next()
+		fmt.Fprintf(&b, "%s (%s:%d)\n", frame.Function, frame.File, frame.Line)
+		if !more {
+			return b.String()
+		}
+	}
+}
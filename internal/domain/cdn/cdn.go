@@ -0,0 +1,58 @@
+package cdn
+
+import (
+	"context"
+
+	"go_di_architecture/internal/domain/events"
+	"go_di_architecture/internal/domain/models/module"
+	"go_di_architecture/internal/logging"
+)
+
+// logger writes the failed purges of event-triggered invalidations.
+var logger = logging.New("cdn")
+
+// Purger drops cached responses from a content delivery network.
+//
+// The interface is owned by the domain layer so writes can invalidate edge
+// caches without depending on a CDN vendor. Responses are tagged with
+// surrogate keys (Surrogate-Key header); purging a key drops every response
+// tagged with it. Implementations live in the infrastructure layer:
+//   - HTTPPurger: posts the keys to a purge endpoint (Fastly, a purge proxy, ...)
+//   - Discard: drops the purges when no CDN is configured
+type Purger interface {
+	// Purge drops the responses tagged with any of the keys
+	Purge(ctx context.Context, keys []string) error
+}
+
+// Discard is a Purger doing nothing.
+var Discard Purger = discard{}
+
+type discard struct{}
+
+func (discard) Purge(context.Context, []string) error { return nil }
+
+// EventSubscriber purges the modules changed outside of HTTP requests.
+//
+// Scheduled activations and deactivations change modules without a write
+// request passing the purge middleware; their events trigger the purge
+// instead. The purge runs in the background so the scheduler is not slowed
+// down by the CDN.
+//
+// Parameters:
+//   - purger: CDN receiving the purges
+//
+// Returns:
+//   - events.Subscriber: Subscriber to register on the event bus
+func EventSubscriber(purger Purger) events.Subscriber {
+	return func(event events.Event) {
+		if event.Type != events.ModuleActivated && event.Type != events.ModuleDeactivated {
+			return
+		}
+		keys := []string{module.SurrogateKey(event.ModuleID), module.SurrogateCollectionKey}
+		go func() {
+			if err := purger.Purge(context.Background(), keys); err != nil {
+				logger.Warnf("CDN purge of %v failed: %v", keys, err)
+			}
+		}()
+	}
+}
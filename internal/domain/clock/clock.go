@@ -0,0 +1,33 @@
+// Package clock provides the current time to components that need a
+// replaceable one, e.g. a frozen clock for deterministic tests.
+package clock
+
+import "time"
+
+// Clock tells the current time.
+type Clock interface {
+	// Now returns the current time
+	Now() time.Time
+}
+
+// System is the clock of the operating system.
+var System Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// Fixed returns a clock frozen at the given time.
+//
+// Parameters:
+//   - at: The time every call of Now returns
+//
+// Returns:
+//   - Clock: The frozen clock
+func Fixed(at time.Time) Clock {
+	return fixedClock(at)
+}
+
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time { return time.Time(c) }
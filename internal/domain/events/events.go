@@ -0,0 +1,135 @@
+package events
+
+import (
+	"sync"
+	"time"
+
+	"go_di_architecture/internal/logging"
+)
+
+// logger writes the events of the Log subscriber.
+var logger = logging.New("events")
+
+// Module event types
+const (
+	// ModuleActivated is published when a module changes from inactive to active
+	ModuleActivated = "module.activated"
+
+	// ModuleDeactivated is published when a module changes from active to inactive
+	ModuleDeactivated = "module.deactivated"
+
+	// OwnershipTransferRequested is published when a module owner proposes a
+	// new owner; the recipient is the proposed owner
+	OwnershipTransferRequested = "module.ownership_transfer_requested"
+
+	// OwnershipTransferred is published when the proposed owner accepts a
+	// transfer; the recipient is the previous owner
+	OwnershipTransferred = "module.ownership_transferred"
+
+	// ModuleSubmitted is published when a draft is submitted for approval
+	ModuleSubmitted = "module.submitted"
+
+	// ModuleApproved is published when an approver approves a submitted
+	// module; the recipient is the module owner
+	ModuleApproved = "module.approved"
+
+	// ModuleRejected is published when an approver returns a submitted module
+	// to draft; the recipient is the module owner
+	ModuleRejected = "module.rejected"
+
+	// ModuleProvisioned is published when a provisioning workflow created a
+	// module and allocated its resources; the recipient is the actor
+	ModuleProvisioned = "module.provisioned"
+)
+
+// Event describes something that happened to a module.
+type Event struct {
+	// Event type (e.g. module.activated)
+	Type string `json:"type"`
+
+	// Module the event is about
+	ModuleID int `json:"moduleId"`
+
+	// Who caused the event ("scheduler" for scheduled transitions)
+	Actor string `json:"actor"`
+
+	// User the event is addressed to, if any (e.g. the proposed owner of a transfer)
+	Recipient string `json:"recipient,omitempty"`
+
+	// Note of the actor, if any (e.g. the reason of a rejection)
+	Comment string `json:"comment,omitempty"`
+
+	// Time the event happened
+	OccurredAt time.Time `json:"occurredAt"`
+}
+
+// Publisher publishes domain events.
+//
+// The interface is owned by the domain layer so services can emit events
+// without knowing who consumes them.
+type Publisher interface {
+	Publish(event Event)
+}
+
+// Subscriber receives published events.
+type Subscriber func(event Event)
+
+// Bus is an in-process Publisher delivering events to subscribers synchronously.
+//
+// Subscribers run on the publishing goroutine in subscription order, so they
+// must be fast and must not publish events themselves while holding locks the
+// publisher needs.
+//
+// Usage Example:
+//
+//	bus := events.NewBus(events.Log)
+//	bus.Subscribe(func(e events.Event) {
+//	    if e.Type == events.ModuleDeactivated {
+//	        notify(e.ModuleID)
+//	    }
+//	})
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers []Subscriber
+}
+
+// NewBus creates a bus with optional initial subscribers.
+//
+// Parameters:
+//   - subscribers: Subscribers receiving every event
+//
+// Returns:
+//   - *Bus: A new bus
+func NewBus(subscribers ...Subscriber) *Bus {
+	return &Bus{subscribers: subscribers}
+}
+
+// Subscribe registers a subscriber for all future events.
+//
+// Parameters:
+//   - subscriber: Callback receiving each event
+func (b *Bus) Subscribe(subscriber Subscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.subscribers = append(b.subscribers, subscriber)
+}
+
+// Publish delivers an event to every subscriber.
+//
+// Parameters:
+//   - event: The event to deliver
+func (b *Bus) Publish(event Event) {
+	b.mu.RLock()
+	subscribers := b.subscribers
+	b.mu.RUnlock()
+
+	for _, subscriber := range subscribers {
+		subscriber(event)
+	}
+}
+
+// Log is a subscriber writing every event to the "events" logger.
+func Log(event Event) {
+	logger.Infof("Event %s module=%d actor=%s", event.Type, event.ModuleID, event.Actor)
+}
@@ -0,0 +1,77 @@
+// Package guard checks the dependencies handed to constructors.
+//
+// Constructors of the domain services call Require before storing their
+// dependencies, so a missing component is reported when the service is
+// built, with the names of the missing arguments, instead of as a nil
+// pointer dereference on the first request that reaches it.
+package guard
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrNilDependency is returned when a required dependency is nil.
+var ErrNilDependency = errors.New("required dependency is nil")
+
+// Dependency is a named constructor argument checked by Require.
+type Dependency struct {
+	name  string
+	value any
+}
+
+// Dep names a constructor argument for Require.
+//
+// Parameters:
+//   - name: Name of the argument, reported when it is nil
+//   - value: The argument
+//
+// Returns:
+//   - Dependency: The argument to check
+func Dep(name string, value any) Dependency {
+	return Dependency{name: name, value: value}
+}
+
+// Require checks that none of the dependencies is nil.
+//
+// Interfaces holding a nil pointer, map, slice, channel or function count as
+// nil as well, since calling through them fails just the same.
+//
+// Usage Example:
+//
+//	if err := guard.Require("NewNoteService", guard.Dep("repo", repo), guard.Dep("modules", modules)); err != nil {
+//	    return nil, err
+//	}
+//
+// Parameters:
+//   - constructor: Name of the constructor, prefixed to the error
+//   - dependencies: The required arguments
+//
+// Returns:
+//   - error: ErrNilDependency naming every nil argument, nil if there is none
+func Require(constructor string, dependencies ...Dependency) error {
+	var missing []string
+	for _, dependency := range dependencies {
+		if isNil(dependency.value) {
+			missing = append(missing, dependency.name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%s: %w: %s", constructor, ErrNilDependency, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// isNil reports whether the value is nil or an interface holding a nil value.
+func isNil(value any) bool {
+	if value == nil {
+		return true
+	}
+	switch v := reflect.ValueOf(value); v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func, reflect.Interface:
+		return v.IsNil()
+	}
+	return false
+}
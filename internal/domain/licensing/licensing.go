@@ -0,0 +1,65 @@
+package licensing
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Errors of license lookups
+var (
+	// ErrUnknownLicense is returned for a key the licensing service does not know
+	ErrUnknownLicense = errors.New("unknown license")
+
+	// ErrUnavailable is returned when the licensing service cannot be reached
+	// or answers with an error; the lookup may succeed later
+	ErrUnavailable = errors.New("licensing service unavailable")
+)
+
+// Edition is the product edition a license unlocks.
+type Edition string
+
+// Editions of the product
+const (
+	EditionCommunity  Edition = "community"
+	EditionTeam       Edition = "team"
+	EditionEnterprise Edition = "enterprise"
+)
+
+// License is a license as the application sees it, independent of how the
+// licensing service represents it.
+type License struct {
+	// License key the license was looked up with
+	Key string
+
+	// Edition the license unlocks
+	Edition Edition
+
+	// Number of users the license covers (zero for unlimited)
+	Seats int
+
+	// When the license ends (zero for perpetual licenses)
+	ExpiresAt time.Time
+
+	// Whether the vendor suspended or revoked the license
+	Suspended bool
+}
+
+// Valid reports whether the license can be used at the given time.
+func (l License) Valid(now time.Time) bool {
+	return !l.Suspended && (l.ExpiresAt.IsZero() || now.Before(l.ExpiresAt))
+}
+
+// Licenses looks up licenses on an external licensing service.
+//
+// The interface is owned by the domain layer so services can check licenses
+// without depending on the vendor's API; the implementations translate the
+// vendor's representation into License and its errors into ErrUnknownLicense
+// and ErrUnavailable. Implementations live in the infrastructure layer:
+//   - external.LicensingClient: calls the REST API of the licensing service
+//   - external.StubLicensing: serves licenses from memory, for development
+//     and tests
+type Licenses interface {
+	// Lookup returns the license with the key
+	Lookup(ctx context.Context, key string) (*License, error)
+}
@@ -0,0 +1,55 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrHeld is returned when another holder already has the lock.
+var ErrHeld = errors.New("lock is held by another instance")
+
+// Release gives a lock back; it is safe to call after the lock expired.
+type Release func(ctx context.Context) error
+
+// Lock grants named locks shared by every instance of the application.
+//
+// The interface is owned by the domain layer so services can guard critical
+// sections without depending on a locking technology. Implementations live in
+// the infrastructure layer:
+//   - LocalLock: in-process locks for single-instance deployments
+//   - RedisLock: SET NX PX keys with token-checked release on a Redis server
+//   - PostgresLock: session-level advisory locks held on a dedicated connection
+//
+// Locks are not reentrant: trying a lock the same instance holds fails with
+// ErrHeld, too.
+type Lock interface {
+	// TryLock acquires the named lock without waiting.
+	//
+	// The TTL bounds how long the lock survives a holder that dies without
+	// releasing it; implementations whose locks end with the holder's
+	// connection may ignore it. Pick a TTL longer than the critical section.
+	TryLock(ctx context.Context, name string, ttl time.Duration) (Release, error)
+}
+
+// Run runs fn while holding the named lock.
+//
+// Parameters:
+//   - ctx: Context passed to the lock and fn
+//   - l: Lock implementation
+//   - name: Name of the lock
+//   - ttl: Expiry of the lock if the holder dies
+//   - fn: The critical section
+//
+// Returns:
+//   - error: ErrHeld when another instance holds the lock (fn did not run),
+//     otherwise the error of acquiring the lock or of fn
+func Run(ctx context.Context, l Lock, name string, ttl time.Duration, fn func(ctx context.Context) error) error {
+	release, err := l.TryLock(ctx, name, ttl)
+	if err != nil {
+		return err
+	}
+	defer release(context.WithoutCancel(ctx))
+
+	return fn(ctx)
+}
@@ -0,0 +1,52 @@
+package metrics
+
+import "time"
+
+// Metrics registers the business metrics of the services.
+//
+// The interface is owned by the domain layer so services can report their
+// KPIs without depending on a metrics library. Each metric has one label
+// dimension (e.g. the error code of a validation failure); services pass ""
+// for metrics without labels. Implementations live in the infrastructure
+// layer:
+//   - ExpvarMetrics: JSON under the "business" expvar at /admin/metrics
+//
+// Usage Example:
+//
+//	created := m.Counter("modules_created_total")
+//	created.Add("", 1)
+type Metrics interface {
+	// Counter registers a monotonically increasing count; scrapers derive
+	// rates from it
+	Counter(name string) Counter
+
+	// Gauge registers a value computed from collect on every read; collect
+	// returns the value per label, and failed reads are reported without values
+	Gauge(name string, collect func() (map[string]int64, error))
+
+	// Histogram registers a distribution of durations
+	Histogram(name string) Histogram
+}
+
+// Counter counts events per label.
+type Counter interface {
+	Add(label string, delta int64)
+}
+
+// Histogram records durations per label.
+type Histogram interface {
+	Observe(label string, d time.Duration)
+}
+
+// Discard is a Metrics implementation recording nothing, for services built
+// without metrics.
+var Discard Metrics = discard{}
+
+// discard implements Discard.
+type discard struct{}
+
+func (discard) Counter(string) Counter                         { return discard{} }
+func (discard) Gauge(string, func() (map[string]int64, error)) {}
+func (discard) Histogram(string) Histogram                     { return discard{} }
+func (discard) Add(string, int64)                              {}
+func (discard) Observe(string, time.Duration)                  {}
@@ -0,0 +1,23 @@
+package admin
+
+// LogLevelDefault as the level of a named logger drops its override.
+const LogLevelDefault = "default"
+
+// LogLevelRequest represents a runtime change of a log level.
+//
+// Example:
+//
+//	{
+//	  "logger": "http",
+//	  "level": "warn"
+//	}
+type LogLevelRequest struct {
+	// Component whose level changes (e.g. http, scheduler, jobs); empty
+	// changes the default level of all loggers without an override
+	Logger string `json:"logger" binding:"max=100" example:"http"`
+
+	// New level (debug, info, warn, error), or default to make a named logger
+	// follow the default level again
+	// required: true
+	Level string `json:"level" binding:"required,oneof=debug info warn error default" example:"warn"`
+}
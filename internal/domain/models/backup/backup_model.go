@@ -0,0 +1,163 @@
+package backup
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ArchiveVersion is the version of the archive format written by backups.
+//
+// Restores accept archives up to this version; bump it whenever a field is
+// renamed or its meaning changes, and keep reading older versions.
+const ArchiveVersion = 1
+
+// Conflict policies deciding what a restore does with modules whose name is already taken
+const (
+	// ConflictSkip keeps the existing module and ignores the archived one
+	ConflictSkip = "skip"
+
+	// ConflictOverwrite replaces the existing module, its tags and settings
+	// with the archived state
+	ConflictOverwrite = "overwrite"
+
+	// ConflictFail aborts the restore before writing anything
+	ConflictFail = "fail"
+)
+
+// Archive is the JSON document written by a backup.
+//
+// Example:
+//
+//	{
+//	  "version": 1,
+//	  "createdAt": "2023-08-15T14:30:00Z",
+//	  "tags": ["billing", "core"],
+//	  "modules": [
+//	    {
+//	      "id": 123,
+//	      "name": "Inventory",
+//	      "description": "Handles product stock management",
+//	      "isActive": true,
+//	      "owner": "jane",
+//	      "activateAt": null,
+//	      "deactivateAt": null,
+//	      "createdAt": "2023-08-01T09:00:00Z",
+//	      "updatedAt": "2023-08-15T14:00:00Z",
+//	      "tags": ["core"],
+//	      "settings": {"logLevel": "debug"}
+//	    }
+//	  ]
+//	}
+type Archive struct {
+	// Archive format version (see ArchiveVersion)
+	Version int `json:"version"`
+
+	// Timestamp when the backup was taken
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Every tag, including tags not assigned to any module
+	Tags []string `json:"tags"`
+
+	// Every module outside the recycle bin
+	Modules []ArchivedModule `json:"modules"`
+}
+
+// ArchivedModule is the state of one module in an archive.
+//
+// Modules are matched by name on restore; the ID only documents the source
+// database since restored modules may get different IDs.
+type ArchivedModule struct {
+	ID           int                        `json:"id"`
+	Name         string                     `json:"name"`
+	Description  string                     `json:"description"`
+	IsActive     bool                       `json:"isActive"`
+	Status       string                     `json:"status,omitempty"`
+	Owner        string                     `json:"owner"`
+	ActivateAt   *time.Time                 `json:"activateAt"`
+	DeactivateAt *time.Time                 `json:"deactivateAt"`
+	CreatedAt    time.Time                  `json:"createdAt"`
+	UpdatedAt    time.Time                  `json:"updatedAt"`
+	Tags         []string                   `json:"tags"`
+	Settings     map[string]json.RawMessage `json:"settings"`
+}
+
+// BackupResult describes a written archive; it is the result of a module.backup job.
+//
+// Example:
+//
+//	{
+//	  "key": "backups/modules-20230815T143000Z.json",
+//	  "version": 1,
+//	  "modules": 42,
+//	  "tags": 7,
+//	  "sizeBytes": 18342,
+//	  "createdAt": "2023-08-15T14:30:00Z"
+//	}
+type BackupResult struct {
+	Key       string    `json:"key"`
+	Version   int       `json:"version"`
+	Modules   int       `json:"modules"`
+	Tags      int       `json:"tags"`
+	SizeBytes int64     `json:"sizeBytes"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// RestoreRequest represents the payload of a restore.
+//
+// Example:
+//
+//	{
+//	  "key": "backups/modules-20230815T143000Z.json",
+//	  "conflict": "skip"
+//	}
+type RestoreRequest struct {
+	// Key of the archive to restore, as listed by GET /admin/backups
+	// required: true
+	Key string `json:"key" binding:"required" example:"backups/modules-20230815T143000Z.json"`
+
+	// What to do with modules whose name is already taken (skip, overwrite or
+	// fail); default fail
+	Conflict string `json:"conflict" binding:"omitempty,oneof=skip overwrite fail" example:"skip"`
+}
+
+// RestoreConflict reports an archived module whose name was already taken.
+type RestoreConflict struct {
+	// Name of the archived module
+	Name string `json:"name"`
+
+	// Module currently holding the name
+	ExistingID int `json:"existingId"`
+
+	// The existing module is in the recycle bin and cannot be overwritten
+	InRecycleBin bool `json:"inRecycleBin"`
+
+	// What the restore did (skipped or overwritten)
+	Resolution string `json:"resolution" example:"skipped"`
+}
+
+// RestoreReport summarizes a restore; it is the result of a module.restore job.
+//
+// Example:
+//
+//	{
+//	  "key": "backups/modules-20230815T143000Z.json",
+//	  "version": 1,
+//	  "conflict": "skip",
+//	  "created": 40,
+//	  "overwritten": 0,
+//	  "skipped": 2,
+//	  "tagsCreated": 3,
+//	  "conflicts": [
+//	    {"name": "Inventory", "existingId": 7, "inRecycleBin": false, "resolution": "skipped"}
+//	  ]
+//	}
+type RestoreReport struct {
+	Key         string            `json:"key"`
+	Version     int               `json:"version"`
+	Conflict    string            `json:"conflict"`
+	Created     int               `json:"created"`
+	Overwritten int               `json:"overwritten"`
+	Skipped     int               `json:"skipped"`
+	TagsCreated int               `json:"tagsCreated"`
+	Conflicts   []RestoreConflict `json:"conflicts"`
+}
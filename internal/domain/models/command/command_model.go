@@ -0,0 +1,99 @@
+package command
+
+import (
+	"time"
+
+	"go_di_architecture/internal/domain/models/module"
+	"go_di_architecture/internal/domain/models/response"
+)
+
+// Command types
+const (
+	// TypeCreateModule creates a module from the payload
+	TypeCreateModule = "module.create"
+
+	// TypeUpdateModule replaces the module moduleId with the payload
+	TypeUpdateModule = "module.update"
+
+	// TypeDeleteModule moves the module moduleId to the trash
+	TypeDeleteModule = "module.delete"
+)
+
+// Command is a module command received from the command queue.
+//
+// Commands go through the same service layer as the HTTP API: the payload is
+// validated with the rules of the request bodies, and access is checked for
+// the actor and teams given in the command, as for the X-Actor and
+// X-Actor-Teams headers.
+//
+// Example:
+//
+//	{
+//	  "id": "6f1c2e2a",
+//	  "type": "module.update",
+//	  "moduleId": 123,
+//	  "actor": "billing-sync",
+//	  "teams": ["billing"],
+//	  "replyTo": "billing-sync-replies",
+//	  "payload": {
+//	    "name": "Inventory",
+//	    "description": "Tracks stock levels",
+//	    "isActive": true
+//	  }
+//	}
+type Command struct {
+	// Correlation ID chosen by the producer, echoed in the reply (required)
+	ID string `json:"id" binding:"required,max=100"`
+
+	// Command type: module.create, module.update or module.delete (required)
+	Type string `json:"type" binding:"required,oneof=module.create module.update module.delete"`
+
+	// Module changed by update and delete commands (required for them)
+	ModuleID int `json:"moduleId" binding:"required_unless=Type module.create,omitempty,min=1"`
+
+	// Who issues the command, recorded in the change history (required)
+	Actor string `json:"actor" binding:"required,max=100"`
+
+	// Teams of the actor, matched against the module ACL
+	Teams []string `json:"teams"`
+
+	// Validate and report the outcome without changing anything
+	DryRun bool `json:"dryRun"`
+
+	// Topic receiving the reply; the configured reply topic when empty
+	ReplyTo string `json:"replyTo" binding:"max=200"`
+
+	// Module state of create and update commands (required for them)
+	Payload *module.ModuleRequest `json:"payload" binding:"required_unless=Type module.delete"`
+}
+
+// Reply is the outcome of a command, published to the reply topic.
+//
+// Status is the HTTP status the same request would have received (201 for a
+// create, 200 for an update, 204 for a delete, 4xx/5xx for failures) and
+// Error carries the same error codes as the HTTP API.
+//
+// Example:
+//
+//	{
+//	  "commandId": "6f1c2e2a",
+//	  "type": "module.update",
+//	  "success": false,
+//	  "status": 409,
+//	  "error": {
+//	    "code": "MODULE_UNDER_REVIEW",
+//	    "message": "Resource already exists",
+//	    "messageKey": "module.under_review"
+//	  },
+//	  "processedAt": "2023-08-15T14:30:00Z"
+//	}
+type Reply struct {
+	CommandID   string                 `json:"commandId"`
+	Type        string                 `json:"type"`
+	Success     bool                   `json:"success"`
+	Status      int                    `json:"status"`
+	DryRun      bool                   `json:"dryRun,omitempty"`
+	Data        *module.ModuleResponse `json:"data,omitempty"`
+	Error       *response.APIError     `json:"error,omitempty"`
+	ProcessedAt time.Time              `json:"processedAt"`
+}
@@ -0,0 +1,202 @@
+package dashboard
+
+import "time"
+
+// Overview is the operational state of an instance, as shown on the SRE
+// dashboard.
+//
+// Every section is also served on its own. The data is kept in memory per
+// instance, so a dashboard of several instances queries each of them.
+//
+// Example:
+//
+//	{
+//	  "errors": [{"requestId": "...", "method": "GET", "route": "/api/v1/modules/:id", "status": 500, ...}],
+//	  "slowRequests": [],
+//	  "objectives": [{"method": "GET", "route": "/api/v1/modules", "latencyMs": 500, ...}],
+//	  "caches": [{"name": "module_cache", "stats": {"hits": 120, "misses": 14}}],
+//	  "queues": [{"name": "notifier", "depth": 0, "capacity": 100, "running": 0}],
+//	  "scheduledJobs": [{"name": "module.schedules", "runs": 42, "failures": 0, ...}],
+//	  "backgroundJobs": {"queued": 0, "running": 1, "succeeded": 7, "failed": 0}
+//	}
+type Overview struct {
+	// Most recent server errors, newest first
+	Errors []Request `json:"errors"`
+
+	// Most recent slow requests, newest first
+	SlowRequests []Request `json:"slowRequests"`
+
+	// Compliance of the routes with their service level objectives, fastest
+	// burning first
+	Objectives []RouteObjective `json:"objectives"`
+
+	// Counters of the in-memory caches
+	Caches []Cache `json:"caches"`
+
+	// Fill level of the background queues
+	Queues []Queue `json:"queues"`
+
+	// Status of the periodic jobs
+	ScheduledJobs []ScheduledJob `json:"scheduledJobs"`
+
+	// Background jobs by state
+	BackgroundJobs JobCounts `json:"backgroundJobs"`
+}
+
+// Request is a finished request kept for the dashboard.
+type Request struct {
+	// ID of the request, to find its log lines
+	RequestID string `json:"requestId"`
+
+	// HTTP method
+	Method string `json:"method" example:"GET"`
+
+	// Route pattern, empty for unknown paths
+	Route string `json:"route" example:"/api/v1/modules/:id"`
+
+	// Requested path
+	Path string `json:"path" example:"/api/v1/modules/123"`
+
+	// Response status code
+	Status int `json:"status" example:"500"`
+
+	// Time taken to answer, in milliseconds
+	DurationMs float64 `json:"durationMs" example:"1520.4"`
+
+	// Internal error of a server error (never shown to clients)
+	Error string `json:"error,omitempty"`
+
+	// Time the request finished
+	FinishedAt time.Time `json:"finishedAt"`
+}
+
+// RouteObjective is the compliance of a route with its service level
+// objective.
+//
+// A burn rate divides the share of bad requests in a window by the error
+// budget of the target: 1 spends the budget exactly as fast as the target
+// allows, 10 spends it ten times as fast.
+//
+// Example:
+//
+//	{
+//	  "method": "GET", "route": "/api/v1/modules",
+//	  "latencyMs": 500, "latencyTarget": 0.99, "availabilityTarget": 0.999,
+//	  "windows": [
+//	    {"window": "5m", "requests": 1200, "serverErrors": 3, "slowRequests": 6, "availabilityBurnRate": 2.5, "latencyBurnRate": 0.5},
+//	    {"window": "1h", "requests": 14000, "serverErrors": 3, "slowRequests": 40, "availabilityBurnRate": 0.214, "latencyBurnRate": 0.286}
+//	  ]
+//	}
+type RouteObjective struct {
+	// HTTP method
+	Method string `json:"method" example:"GET"`
+
+	// Route pattern
+	Route string `json:"route" example:"/api/v1/modules"`
+
+	// Duration within which requests count as answered in time, in
+	// milliseconds (zero when the route tracks no latency)
+	LatencyMs float64 `json:"latencyMs" example:"500"`
+
+	// Share of requests to answer within the latency
+	LatencyTarget float64 `json:"latencyTarget" example:"0.99"`
+
+	// Share of requests to answer without a server error
+	AvailabilityTarget float64 `json:"availabilityTarget" example:"0.999"`
+
+	// Requests and burn rates per window, shortest first
+	Windows []ObjectiveWindow `json:"windows"`
+}
+
+// ObjectiveWindow counts the requests of a route in a recent window.
+type ObjectiveWindow struct {
+	// Length of the window
+	Window string `json:"window" example:"5m"`
+
+	// Requests finished in the window
+	Requests int64 `json:"requests" example:"1200"`
+
+	// Requests answered with a 5xx status
+	ServerErrors int64 `json:"serverErrors" example:"3"`
+
+	// Requests slower than the latency of the objective
+	SlowRequests int64 `json:"slowRequests" example:"6"`
+
+	// Speed at which server errors spend the availability budget
+	AvailabilityBurnRate float64 `json:"availabilityBurnRate" example:"2.5"`
+
+	// Speed at which slow requests spend the latency budget
+	LatencyBurnRate float64 `json:"latencyBurnRate" example:"0.5"`
+}
+
+// Cache holds the counters of one cache.
+type Cache struct {
+	// Name of the cache (its expvar name)
+	Name string `json:"name" example:"module_cache"`
+
+	// Counters by name, e.g. hits and misses
+	Stats map[string]any `json:"stats"`
+}
+
+// Queue is the fill level of a background queue.
+type Queue struct {
+	// Name of the queue
+	Name string `json:"name" example:"notifier"`
+
+	// Items waiting to be processed
+	Depth int `json:"depth" example:"3"`
+
+	// Items the queue holds before it drops or blocks (0 for unbounded)
+	Capacity int `json:"capacity" example:"100"`
+
+	// Items being processed
+	Running int `json:"running" example:"1"`
+}
+
+// ScheduledJob is the run status of a periodic job.
+type ScheduledJob struct {
+	// Name of the job
+	Name string `json:"name" example:"module.schedules"`
+
+	// Time between runs
+	Interval string `json:"interval" example:"30s"`
+
+	// Whether a run is in progress
+	Running bool `json:"running"`
+
+	// Finished runs, including runs skipped on followers or while another
+	// instance held the lock
+	Runs int64 `json:"runs" example:"42"`
+
+	// Runs that returned an error
+	Failures int64 `json:"failures" example:"0"`
+
+	// Start of the last finished run
+	LastRunAt *time.Time `json:"lastRunAt"`
+
+	// Time the last finished run took, in milliseconds
+	LastDurationMs float64 `json:"lastDurationMs" example:"12.5"`
+
+	// Start of the last successful run
+	LastSuccessAt *time.Time `json:"lastSuccessAt"`
+
+	// Error of the last run, empty when it succeeded
+	LastError string `json:"lastError,omitempty"`
+}
+
+// Jobs is the status of the periodic and background jobs.
+type Jobs struct {
+	// Status of the periodic jobs
+	ScheduledJobs []ScheduledJob `json:"scheduledJobs"`
+
+	// Background jobs by state
+	BackgroundJobs JobCounts `json:"backgroundJobs"`
+}
+
+// JobCounts counts the background jobs (exports, backups, ...) by state.
+type JobCounts struct {
+	Queued    int `json:"queued"`
+	Running   int `json:"running"`
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+}
@@ -0,0 +1,57 @@
+package deadletter
+
+// DeadLetter represents a message of the command dead-letter queue.
+//
+// The body is returned as received; messages are dead-lettered because they
+// are not valid commands, because executing them panicked or because they
+// kept failing with server errors.
+//
+// Example:
+//
+//	{
+//	  "id": "9f86d081884c7d65",
+//	  "body": "{\"id\":\"cmd-1\",\"type\":\"module.create\",...}"
+//	}
+type DeadLetter struct {
+	// Identifier derived from the body, used to replay the message
+	ID string `json:"id"`
+
+	// Message payload as received
+	Body string `json:"body"`
+}
+
+// DeadLetterList is the content of the command dead-letter queue.
+//
+// Example:
+//
+//	{
+//	  "depth": 42,
+//	  "items": [{"id": "9f86d081884c7d65", "body": "not json"}]
+//	}
+type DeadLetterList struct {
+	// Number of dead letters in the queue
+	Depth int `json:"depth"`
+
+	// The oldest dead letters, up to the requested limit
+	Items []DeadLetter `json:"items"`
+}
+
+// ListQuery binds the paging of the dead-letter listing.
+//
+// Example:
+//
+//	GET /admin/dead-letters?limit=20
+type ListQuery struct {
+	// Most dead letters returned (optional, 1-100, default 50)
+	Limit int `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// IDParams binds the identifier in the path of dead-letter routes.
+//
+// Example:
+//
+//	POST /admin/dead-letters/9f86d081884c7d65/replay
+type IDParams struct {
+	// Identifier of the dead letter (16 hex digits)
+	ID string `uri:"id" binding:"required,len=16,hexadecimal"`
+}
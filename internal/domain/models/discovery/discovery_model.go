@@ -0,0 +1,29 @@
+package discovery
+
+// Document lists the resources of an API version and the methods they accept.
+//
+// Example:
+//
+//	{
+//	  "version": "v1",
+//	  "resources": [
+//	    {"path": "/api/v1/modules", "methods": ["GET", "HEAD", "OPTIONS", "POST"]},
+//	    {"path": "/api/v1/modules/{id}", "methods": ["DELETE", "GET", "HEAD", "OPTIONS", "PUT"]}
+//	  ]
+//	}
+type Document struct {
+	// API version (e.g. "v1")
+	Version string `json:"version" example:"v1"`
+
+	// Resources sorted by path
+	Resources []Resource `json:"resources"`
+}
+
+// Resource is one route path of the API.
+type Resource struct {
+	// Path template with {name} placeholders for path parameters
+	Path string `json:"path" example:"/api/v1/modules/{id}"`
+
+	// Accepted methods, sorted; HEAD and OPTIONS are answered automatically
+	Methods []string `json:"methods"`
+}
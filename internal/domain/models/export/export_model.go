@@ -0,0 +1,36 @@
+package export
+
+import "time"
+
+// ExportRequest represents the payload starting a module export.
+//
+// Example:
+//
+//	{
+//	  "format": "xlsx"
+//	}
+type ExportRequest struct {
+	// File format of the export (csv or xlsx)
+	// required: true
+	// example: csv
+	Format string `json:"format" binding:"required,oneof=csv xlsx" example:"csv"`
+}
+
+// ExportResult describes a finished export; it is the result of a module.export job.
+//
+// Example:
+//
+//	{
+//	  "format": "csv",
+//	  "rows": 12500,
+//	  "sizeBytes": 1843200,
+//	  "downloadUrl": "/api/v1/downloads/exports/modules-20230815T143000Z-9f2c1a7b3d4e5f60.csv?expires=1692110700&signature=...",
+//	  "expiresAt": "2023-08-15T14:45:00Z"
+//	}
+type ExportResult struct {
+	Format      string    `json:"format" example:"csv"`
+	Rows        int       `json:"rows"`
+	SizeBytes   int64     `json:"sizeBytes"`
+	DownloadURL string    `json:"downloadUrl"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+}
@@ -0,0 +1,20 @@
+package leader
+
+import "time"
+
+// Lease records which instance leads an election until when.
+type Lease struct {
+	// Name of the election, e.g. "scheduler"
+	Name string `gorm:"primaryKey;size:100"`
+
+	// Instance holding the lease
+	Holder string `gorm:"size:200;not null"`
+
+	// Time after which other instances may take the lease over
+	ExpiresAt time.Time `gorm:"not null"`
+}
+
+// TableName overrides the default GORM table name.
+func (Lease) TableName() string {
+	return "leader_leases"
+}
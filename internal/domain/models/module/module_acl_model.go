@@ -0,0 +1,141 @@
+package module
+
+import (
+	"strings"
+	"time"
+)
+
+// Module permissions, from weakest to strongest
+const (
+	// PermissionView allows reading the module
+	PermissionView = "view"
+
+	// PermissionEdit allows reading and changing the module and its ACL
+	PermissionEdit = "edit"
+)
+
+// Principal prefixes naming who an ACL entry applies to
+const (
+	PrincipalUserPrefix = "user:"
+	PrincipalTeamPrefix = "team:"
+)
+
+// MaxACLEntries is the maximum number of ACL entries per module.
+const MaxACLEntries = 100
+
+// ModuleACLEntry grants one principal a permission on a module.
+//
+// A module without entries is open to everyone; as soon as it has one entry
+// only the listed principals may view or edit it.
+type ModuleACLEntry struct {
+	// Module the entry applies to
+	ModuleID int `gorm:"primaryKey;autoIncrement:false"`
+
+	// Who is granted access ("user:<actor>" or "team:<name>")
+	Principal string `gorm:"primaryKey;size:120"`
+
+	// Granted permission (view or edit)
+	Permission string `gorm:"size:10;not null"`
+
+	// Timestamp when the entry was written
+	CreatedAt time.Time
+}
+
+// TableName overrides the default GORM table name.
+func (ModuleACLEntry) TableName() string {
+	return "module_acl"
+}
+
+// Subject identifies who performs a request for access checks.
+type Subject struct {
+	// Actor of the request
+	User string
+
+	// Teams the actor belongs to
+	Teams []string
+
+	// Whether the actor administers the service: it may view and edit every
+	// module whatever its ACL, e.g. to restore access its owner lost
+	Admin bool
+}
+
+// Principals returns the ACL principals matching the subject.
+//
+// Returns:
+//   - []string: "user:<user>" followed by "team:<team>" for each team
+func (s Subject) Principals() []string {
+	principals := make([]string, 0, len(s.Teams)+1)
+	principals = append(principals, PrincipalUserPrefix+s.User)
+	for _, team := range s.Teams {
+		principals = append(principals, PrincipalTeamPrefix+team)
+	}
+	return principals
+}
+
+// IsValidPrincipal reports whether the value is "user:<name>" or "team:<name>".
+//
+// Parameters:
+//   - principal: The value to check
+//
+// Returns:
+//   - bool: True when the prefix is known and the name is not blank
+func IsValidPrincipal(principal string) bool {
+	for _, prefix := range []string{PrincipalUserPrefix, PrincipalTeamPrefix} {
+		if name, ok := strings.CutPrefix(principal, prefix); ok {
+			return strings.TrimSpace(name) != ""
+		}
+	}
+	return false
+}
+
+// ACLEntryRequest represents one entry of an ACL update.
+type ACLEntryRequest struct {
+	// Who is granted access ("user:<actor>" or "team:<name>")
+	// required: true
+	// example: user:jane
+	Principal string `json:"principal" binding:"required,max=120" example:"user:jane"`
+
+	// Granted permission
+	// required: true
+	// example: edit
+	Permission string `json:"permission" binding:"required,oneof=view edit" example:"edit"`
+}
+
+// ModuleACLRequest replaces the ACL of a module.
+//
+// An empty list removes all entries and opens the module to everyone.
+//
+// Example:
+//
+//	{
+//	  "entries": [
+//	    {"principal": "user:jane", "permission": "edit"},
+//	    {"principal": "team:payments", "permission": "view"}
+//	  ]
+//	}
+type ModuleACLRequest struct {
+	Entries []ACLEntryRequest `json:"entries" binding:"max=100,dive"`
+}
+
+// ModuleACLResponse represents the ACL of a module.
+//
+// Example:
+//
+//	{
+//	  "moduleId": 123,
+//	  "restricted": true,
+//	  "entries": [
+//	    {"principal": "team:payments", "permission": "view"},
+//	    {"principal": "user:jane", "permission": "edit"}
+//	  ]
+//	}
+type ModuleACLResponse struct {
+	// Module the ACL belongs to
+	ModuleID int `json:"moduleId" example:"123"`
+
+	// Whether access is limited to the listed principals
+	Restricted bool `json:"restricted" example:"true"`
+
+	// Entries ordered by principal
+	Entries []ACLEntryRequest `json:"entries"`
+}
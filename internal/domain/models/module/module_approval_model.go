@@ -0,0 +1,38 @@
+package module
+
+// Module approval states
+//
+// Modules are created as drafts and submitted for approval; only approved
+// modules can be activated:
+//
+//	draft --submit--> pending --approve--> approved
+//	  ^                  |
+//	  +-----reject-------+
+const (
+	// StatusDraft marks a module being prepared by its owner; it cannot be active
+	StatusDraft = "draft"
+
+	// StatusPending marks a module submitted for approval; its fields are
+	// locked until an approver approves or rejects it
+	StatusPending = "pending"
+
+	// StatusApproved marks a module an approver accepted; it can be activated
+	StatusApproved = "approved"
+)
+
+// MaxReviewCommentLength is the longest comment accepted with a review.
+const MaxReviewCommentLength = 500
+
+// ReviewRequest represents the optional payload of approving or rejecting a
+// module.
+//
+// Example:
+//
+//	{
+//	  "comment": "Please describe which warehouses use the module"
+//	}
+type ReviewRequest struct {
+	// Note for the module owner, e.g. the reason of a rejection (optional)
+	// example: Please describe which warehouses use the module
+	Comment string `json:"comment" binding:"max=500" example:"Please describe which warehouses use the module"`
+}
@@ -0,0 +1,40 @@
+package module
+
+import "time"
+
+// ModuleArchiveRecord keeps the last state of a module removed by the
+// retention policy for inactivity.
+//
+// Archived modules no longer exist in the modules table, so their names are
+// free again; the record documents what was removed and can be used to
+// recreate a module manually. The change history of the module is not kept.
+type ModuleArchiveRecord struct {
+	// Unique identifier of the archive record
+	ID int `gorm:"primaryKey"`
+
+	// Identifier the module had before it was archived
+	ModuleID int `gorm:"not null;index"`
+
+	// Module state at the time it was archived
+	Name        string `gorm:"size:50;not null;index"`
+	Description string `gorm:"size:200"`
+	Owner       string `gorm:"size:100;not null;default:''"`
+
+	// Timestamps of the module; set explicitly, not by GORM
+	CreatedAt time.Time `gorm:"autoCreateTime:false"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime:false"`
+
+	// Timestamp when the module was archived
+	ArchivedAt time.Time `gorm:"not null;index"`
+
+	// JSON-encoded []string of the tag names assigned to the module
+	Tags string `gorm:"type:text;not null"`
+
+	// JSON-encoded ModuleSettings of the module
+	Settings string `gorm:"type:text;not null"`
+}
+
+// TableName overrides the default GORM table name.
+func (ModuleArchiveRecord) TableName() string {
+	return "module_archive"
+}
@@ -0,0 +1,43 @@
+package module
+
+import "time"
+
+// ModuleDependency records that a module depends on another module.
+//
+// Dependencies form a directed acyclic graph; the service rejects any edge
+// that would close a cycle.
+type ModuleDependency struct {
+	// Module declaring the dependency
+	ModuleID int `gorm:"primaryKey;autoIncrement:false"`
+
+	// Module being depended on
+	DependsOnID int `gorm:"primaryKey;autoIncrement:false;index:idx_module_dependencies_depends_on_id"`
+
+	// Timestamp when the dependency was declared
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+// TableName overrides the default GORM table name.
+func (ModuleDependency) TableName() string {
+	return "module_dependencies"
+}
+
+// DependencyResponse represents a module reached while walking the dependency graph.
+//
+// Example:
+//
+//	{
+//	  "id": 7,
+//	  "name": "Billing",
+//	  "description": "Invoices and payments",
+//	  "isActive": true,
+//	  "createdAt": "2023-08-15T14:30:00Z",
+//	  "updatedAt": "2023-08-15T14:30:00Z",
+//	  "depth": 2
+//	}
+type DependencyResponse struct {
+	*ModuleResponse
+
+	// Number of edges between the starting module and this module (1 = direct)
+	Depth int `json:"depth"`
+}
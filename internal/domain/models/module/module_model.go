@@ -1,6 +1,12 @@
 package module
 
-import "time"
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
 
 // Module represents a module entity in the system.
 //
@@ -14,24 +20,61 @@ import "time"
 //	  "name": "Inventory",
 //	  "description": "Handles product stock management",
 //	  "isActive": true,
-//	  "createdAt": "2023-08-15T14:30:00Z"
+//	  "status": "approved",
+//	  "owner": "jane",
+//	  "createdAt": "2023-08-15T14:30:00Z",
+//	  "updatedAt": "2023-08-15T14:30:00Z"
 //	}
 type Module struct {
 	// Unique identifier for the module
 	ID int `json:"id" gorm:"primaryKey"`
 
 	// Name of the module (3-50 characters, required)
-	// Business Rule: Must be unique across active modules
-	Name string `json:"name" gorm:"size:50;not null;uniqueIndex:idx_name_active"`
+	// Business Rule: Must be unique (case-insensitive), enforced by the
+	// idx_modules_name_lower functional index created in migrations
+	Name string `json:"name" gorm:"size:50;not null"`
 
 	// Description of what the module does (max 200 characters)
 	Description string `json:"description" gorm:"size:200"`
 
 	// Indicates if the module is currently active
-	IsActive bool `json:"isActive" gorm:"default:true"`
+	// No column default: GORM would otherwise replace an explicit false with it
+	IsActive bool `json:"isActive" gorm:"not null"`
+
+	// Approval state (draft, pending or approved); only approved modules can
+	// be active. The column default approves modules created before the
+	// approval workflow
+	Status string `json:"status" gorm:"size:20;not null;default:'approved';index"`
+
+	// Time at which the scheduler activates the module (cleared once applied)
+	ActivateAt *time.Time `json:"activateAt" gorm:"index"`
+
+	// Time at which the scheduler deactivates the module (cleared once applied)
+	DeactivateAt *time.Time `json:"deactivateAt" gorm:"index"`
+
+	// Who owns the module: its creator until an ownership transfer is accepted
+	Owner string `json:"owner" gorm:"size:100;not null;default:''"`
 
 	// Timestamp when the module was created
 	CreatedAt time.Time `json:"createdAt" gorm:"autoCreateTime"`
+
+	// Timestamp when the module was last changed
+	UpdatedAt time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
+
+	// Timestamp when the module was moved to the recycle bin (soft delete);
+	// GORM excludes soft-deleted rows from queries unless Unscoped is used
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// Who moved the module to the recycle bin
+	DeletedBy string `json:"-" gorm:"size:100"`
+
+	// Names of the module's tags in name order, loaded by list queries with
+	// WithTags (nil when not loaded)
+	Tags []string `json:"-" gorm:"-"`
+
+	// ACL entries of the module, loaded by list queries with WithPermissions
+	// (nil when not loaded, empty for an unrestricted module)
+	Permissions []ModuleACLEntry `json:"-" gorm:"-"`
 }
 
 // ModuleRequest represents the payload for creating a new module.
@@ -43,18 +86,28 @@ type Module struct {
 //	{
 //	  "name": "Inventory",
 //	  "description": "Handles product stock management",
-//	  "isActive": true
+//	  "isActive": false,
+//	  "activateAt": "2023-09-01T06:00:00Z",
+//	  "deactivateAt": "2023-12-31T23:00:00Z"
 //	}
 type ModuleRequest struct {
 	// Name of the module (3-50 characters, required)
-	// Validation: Must be 3-50 characters, alphanumeric with spaces
+	// Validation: Must be 3-50 characters (any characters, not only whitespace)
 	Name string `json:"name" binding:"required,min=3,max=50"`
 
 	// Description of what the module does (max 200 characters)
 	Description string `json:"description" binding:"max=200"`
 
-	// Indicates if the module should be active upon creation
+	// Indicates if the module should be active; only approved modules can be
+	// activated, so new modules must leave it false
 	IsActive bool `json:"isActive"`
+
+	// Optional time at which the module is activated automatically
+	ActivateAt *time.Time `json:"activateAt"`
+
+	// Optional time at which the module is deactivated automatically
+	// Validation: Must be after activateAt when both are set
+	DeactivateAt *time.Time `json:"deactivateAt"`
 }
 
 // ModuleResponse represents the response structure for module operations.
@@ -68,12 +121,218 @@ type ModuleRequest struct {
 //	  "name": "Inventory",
 //	  "description": "Handles product stock management",
 //	  "isActive": true,
-//	  "createdAt": "2023-08-15T14:30:00Z"
+//	  "status": "approved",
+//	  "activateAt": null,
+//	  "deactivateAt": "2023-12-31T23:00:00Z",
+//	  "owner": "jane",
+//	  "createdAt": "2023-08-15T14:30:00Z",
+//	  "updatedAt": "2023-08-15T14:30:00Z",
+//	  "tags": ["backend"],
+//	  "permission": "edit"
 //	}
+//
+// Tags and permission are only set when the list request includes them
+// (include=tags,permissions).
 type ModuleResponse struct {
-	ID          int       `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	IsActive    bool      `json:"isActive"`
-	CreatedAt   time.Time `json:"createdAt"`
+	ID           int        `json:"id"`
+	Name         string     `json:"name"`
+	Description  string     `json:"description"`
+	IsActive     bool       `json:"isActive"`
+	Status       string     `json:"status" example:"approved"`
+	ActivateAt   *time.Time `json:"activateAt"`
+	DeactivateAt *time.Time `json:"deactivateAt"`
+	Owner        string     `json:"owner"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	UpdatedAt    time.Time  `json:"updatedAt"`
+	Tags         []string   `json:"tags,omitempty"`
+	Permission   string     `json:"permission,omitempty" example:"edit"`
+}
+
+// DeletedModuleResponse represents a module in the recycle bin.
+//
+// Example:
+//
+//	{
+//	  "id": 123,
+//	  "name": "Inventory",
+//	  "description": "Handles product stock management",
+//	  "isActive": true,
+//	  "createdAt": "2023-08-15T14:30:00Z",
+//	  "updatedAt": "2023-08-20T08:00:00Z",
+//	  "deletedAt": "2023-08-20T08:00:00Z",
+//	  "deletedBy": "jane"
+//	}
+type DeletedModuleResponse struct {
+	*ModuleResponse
+	DeletedAt time.Time `json:"deletedAt"`
+	DeletedBy string    `json:"deletedBy"`
+}
+
+// ModuleIdsRequest represents the payload of bulk actions on modules.
+//
+// Example:
+//
+//	{
+//	  "ids": [1, 5, 9]
+//	}
+type ModuleIdsRequest struct {
+	// IDs of the modules to act on (1-100 positive IDs)
+	Ids []int `json:"ids" binding:"required,min=1,max=100,dive,min=1"`
+}
+
+// ModuleFilter narrows module list queries.
+//
+// The zero value matches every module.
+type ModuleFilter struct {
+	// Only include modules carrying the tag with this normalized name
+	Tag string
+
+	// Only include modules in this approval state (empty for all)
+	Status string
+
+	// Only include modules this user starred (empty for all)
+	StarredBy string
+
+	// Only include active modules
+	ActiveOnly bool
+
+	// Only include modules without an ACL or with an entry for one of these
+	// principals (nil disables the access check)
+	VisibleTo []string
+}
+
+// Relations included in module lists on request (the include parameter)
+const (
+	IncludeTags        = "tags"
+	IncludePermissions = "permissions"
+)
+
+// Relations selects the relations list queries load along with the modules.
+//
+// Each relation is loaded with one query for the whole page (WHERE module_id
+// IN ...), never with one query per module.
+type Relations struct {
+	// Load the tag names into Module.Tags
+	Tags bool
+
+	// Load the ACL entries into Module.Permissions
+	Permissions bool
+}
+
+// ListOption selects a relation to load with listed modules.
+//
+// Usage Example:
+//
+//	modules, err := repo.ListModulesAfter(filter, after, 500, module.WithTags())
+type ListOption func(*Relations)
+
+// WithTags loads the tag names of listed modules.
+func WithTags() ListOption {
+	return func(r *Relations) { r.Tags = true }
+}
+
+// WithPermissions loads the ACL entries of listed modules.
+func WithPermissions() ListOption {
+	return func(r *Relations) { r.Permissions = true }
+}
+
+// LoadRelations collects the relations selected by list options.
+//
+// Parameters:
+//   - opts: Options passed to a list method
+//
+// Returns:
+//   - Relations: The relations to load
+func LoadRelations(opts []ListOption) Relations {
+	var relations Relations
+	for _, opt := range opts {
+		opt(&relations)
+	}
+	return relations
+}
+
+// IncludeOptions converts the include parameter of a list request into list
+// options.
+//
+// Parameters:
+//   - include: Comma-separated relation names (IncludeTags,
+//     IncludePermissions); empty includes none
+//
+// Returns:
+//   - []ListOption: Options loading the named relations
+//   - error: Error naming the first unknown relation
+func IncludeOptions(include string) ([]ListOption, error) {
+	var opts []ListOption
+	if include == "" {
+		return opts, nil
+	}
+	for _, name := range strings.Split(include, ",") {
+		switch strings.TrimSpace(name) {
+		case IncludeTags:
+			opts = append(opts, WithTags())
+		case IncludePermissions:
+			opts = append(opts, WithPermissions())
+		default:
+			return nil, fmt.Errorf("unknown relation %q, expected %s or %s", strings.TrimSpace(name), IncludeTags, IncludePermissions)
+		}
+	}
+	return opts, nil
+}
+
+// ModuleCountResponse represents the response structure for module counts.
+//
+// Example:
+//
+//	{
+//	  "count": 42
+//	}
+type ModuleCountResponse struct {
+	Count int64 `json:"count"`
+}
+
+// ModuleStatsResponse represents aggregated statistics about all modules.
+//
+// Example:
+//
+//	{
+//	  "totalModules": 42,
+//	  "activeModules": 30,
+//	  "inactiveModules": 12,
+//	  "createdPerDay": [
+//	    {"date": "2023-08-15", "count": 3}
+//	  ],
+//	  "longestUnchanged": [
+//	    {"id": 1, "name": "Inventory", "description": "", "isActive": true,
+//	     "createdAt": "2023-01-02T10:00:00Z", "updatedAt": "2023-01-02T10:00:00Z"}
+//	  ],
+//	  "generatedAt": "2023-08-15T14:30:00Z"
+//	}
+type ModuleStatsResponse struct {
+	// Number of modules regardless of status
+	TotalModules int64 `json:"totalModules"`
+
+	// Number of active modules
+	ActiveModules int64 `json:"activeModules"`
+
+	// Number of inactive modules
+	InactiveModules int64 `json:"inactiveModules"`
+
+	// Modules created per UTC day over the reporting window, oldest first,
+	// including days without creations
+	CreatedPerDay []DailyCount `json:"createdPerDay"`
+
+	// Modules with the oldest last change, oldest first
+	LongestUnchanged []*ModuleResponse `json:"longestUnchanged"`
+
+	// Time the statistics were computed (they may be cached briefly)
+	GeneratedAt time.Time `json:"generatedAt"`
+}
+
+// DailyCount is the number of events on a single UTC day.
+type DailyCount struct {
+	// Day in YYYY-MM-DD format
+	Date string `json:"date"`
+
+	// Number of events on that day
+	Count int64 `json:"count"`
 }
@@ -0,0 +1,81 @@
+package module
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MaxNoteLength is the longest note body accepted, in bytes.
+const MaxNoteLength = 5000
+
+// ModuleNote is a free-text note an operator attached to a module.
+//
+// Notes are soft-deleted: a deleted note disappears from listings but the row
+// is kept with the deleting actor until the module is purged.
+type ModuleNote struct {
+	// Unique identifier of the note
+	ID int `gorm:"primaryKey"`
+
+	// Module the note belongs to
+	ModuleID int `gorm:"not null;index"`
+
+	// Who wrote the note
+	Author string `gorm:"size:100;not null"`
+
+	// Note text as written (Markdown is kept verbatim)
+	Body string `gorm:"type:text;not null"`
+
+	// Timestamp when the note was written
+	CreatedAt time.Time `gorm:"index"`
+
+	// Soft-delete marker; set when the note is deleted
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	// Who deleted the note
+	DeletedBy string `gorm:"size:100"`
+}
+
+// TableName overrides the default GORM table name.
+func (ModuleNote) TableName() string {
+	return "module_notes"
+}
+
+// NoteRequest represents the payload for adding a note to a module.
+//
+// Example:
+//
+//	{
+//	  "body": "Rotated the **billing** credentials, see INC-42"
+//	}
+type NoteRequest struct {
+	// Note text, Markdown allowed (required, 1-5000 bytes)
+	// example: Rotated the **billing** credentials, see INC-42
+	Body string `json:"body" binding:"required,max=5000" example:"Rotated the **billing** credentials, see INC-42"`
+}
+
+// NoteResponse represents a module note in API responses.
+//
+// Body is the text as written; BodyHTML is the same text with every HTML
+// special character escaped and paragraphs and line breaks turned into
+// markup, so clients can insert it into a page without sanitizing it and
+// render the Markdown on top if they wish.
+//
+// Example:
+//
+//	{
+//	  "id": 7,
+//	  "moduleId": 123,
+//	  "author": "jane",
+//	  "body": "Rotated the **billing** credentials, see INC-42",
+//	  "bodyHtml": "<p>Rotated the **billing** credentials, see INC-42</p>",
+//	  "createdAt": "2023-08-15T14:30:00Z"
+//	}
+type NoteResponse struct {
+	ID        int       `json:"id"`
+	ModuleID  int       `json:"moduleId"`
+	Author    string    `json:"author"`
+	Body      string    `json:"body"`
+	BodyHTML  string    `json:"bodyHtml"`
+	CreatedAt time.Time `json:"createdAt"`
+}
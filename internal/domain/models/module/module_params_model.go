@@ -0,0 +1,166 @@
+package module
+
+import (
+	"strconv"
+	"time"
+)
+
+// IDParams binds the ID in the path of module and transfer routes.
+//
+// Example:
+//
+//	GET /api/v1/modules/123
+type IDParams struct {
+	// Unique identifier of the resource (positive)
+	ID int `uri:"id" binding:"min=1"`
+}
+
+// Key returns the ID in the string form the services accept.
+func (p IDParams) Key() string {
+	return strconv.Itoa(p.ID)
+}
+
+// DependencyParams binds the path of a single dependency edge.
+//
+// Example:
+//
+//	PUT /api/v1/modules/123/dependencies/7
+type DependencyParams struct {
+	// Module declaring the dependency (positive)
+	ID int `uri:"id" binding:"min=1"`
+
+	// Module being depended on (positive)
+	DependencyID int `uri:"dependencyId" binding:"min=1"`
+}
+
+// Key returns the declaring module ID in the string form the services accept.
+func (p DependencyParams) Key() string {
+	return strconv.Itoa(p.ID)
+}
+
+// DependencyKey returns the depended-on module ID in the string form the
+// services accept.
+func (p DependencyParams) DependencyKey() string {
+	return strconv.Itoa(p.DependencyID)
+}
+
+// NoteParams binds the module and note IDs of a note path.
+//
+// Example:
+//
+//	DELETE /api/v1/modules/123/notes/7
+type NoteParams struct {
+	// Module the note belongs to (positive)
+	ID int `uri:"id" binding:"min=1"`
+
+	// The note (positive)
+	NoteID int `uri:"noteId" binding:"min=1"`
+}
+
+// Key returns the module ID in the string form the services accept.
+func (p NoteParams) Key() string {
+	return strconv.Itoa(p.ID)
+}
+
+// NoteKey returns the note ID in the string form the services accept.
+func (p NoteParams) NoteKey() string {
+	return strconv.Itoa(p.NoteID)
+}
+
+// PageQuery binds offset pagination parameters.
+//
+// The defaults mirror pagination.DefaultPageSize and pagination.MaxPageSize.
+//
+// Example:
+//
+//	GET /api/v1/modules?page=2&pageSize=50
+type PageQuery struct {
+	// 1-based page number (default 1)
+	Page int `form:"page,default=1" binding:"min=1,max=2147483647"`
+
+	// Items per page (1-100, default 20)
+	PageSize int `form:"pageSize,default=20" binding:"min=1,max=100"`
+}
+
+// ListQuery binds the filters of the module list.
+//
+// The cursor and ids parameters switch the list to other modes and are read
+// separately, since an empty cursor differs from a missing one; so is the
+// comma-separated include parameter (see IncludeOptions).
+//
+// Example:
+//
+//	GET /api/v1/modules?tag=backend&page=1&pageSize=20
+//	GET /api/v1/modules?status=pending
+//	GET /api/v1/modules?starred=true
+//	GET /api/v1/modules?include=tags,permissions
+type ListQuery struct {
+	PageQuery
+
+	// Only list modules carrying this tag (optional, case-insensitive)
+	Tag string `form:"tag" binding:"max=30"`
+
+	// Only list modules in this approval state (optional)
+	Status string `form:"status" binding:"omitempty,oneof=draft pending approved"`
+
+	// Only list modules the caller starred (optional)
+	Starred bool `form:"starred"`
+}
+
+// CountQuery binds the filter of the module count.
+//
+// Example:
+//
+//	GET /api/v1/modules/count?isActive=true
+type CountQuery struct {
+	// Only count modules with this active flag (optional)
+	IsActive *bool `form:"isActive"`
+}
+
+// HistoryQuery binds the filters of a module's change history.
+//
+// Example:
+//
+//	GET /api/v1/modules/123/history?actor=jane&from=2023-08-01T00:00:00Z
+type HistoryQuery struct {
+	PageQuery
+
+	// Only changes made by this actor (optional)
+	Actor string `form:"actor" binding:"max=100"`
+
+	// Only changes at or after this RFC 3339 time (optional)
+	From *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+
+	// Only changes at or before this RFC 3339 time (optional)
+	To *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+// RevertQuery binds the revision a module is reverted to.
+//
+// Example:
+//
+//	POST /api/v1/modules/123/revert?revision=2
+type RevertQuery struct {
+	// Revision number to restore (positive)
+	Revision int `form:"revision" binding:"required,min=1"`
+}
+
+// DependencyWalkQuery binds the options of a dependency graph walk.
+//
+// Example:
+//
+//	GET /api/v1/modules/123/dependencies?transitive=true
+type DependencyWalkQuery struct {
+	// Follow edges beyond the direct neighbors (default false)
+	Transitive bool `form:"transitive,default=false"`
+}
+
+// UsageQuery binds the range of a usage report.
+//
+// Example:
+//
+//	GET /api/v1/modules/123/usage?days=7
+type UsageQuery struct {
+	// Number of days reported, ending today (1-366, default 30)
+	Days int `form:"days,default=30" binding:"min=1,max=366"`
+}
@@ -0,0 +1,23 @@
+package module
+
+import "time"
+
+// PublicModuleResponse represents a module on the unauthenticated public API.
+//
+// Only fields safe to publish are included: owners, approval state, schedules
+// and access lists stay internal.
+//
+// Example:
+//
+//	{
+//	  "id": 123,
+//	  "name": "Inventory",
+//	  "description": "Handles product stock management",
+//	  "updatedAt": "2023-08-15T14:30:00Z"
+//	}
+type PublicModuleResponse struct {
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
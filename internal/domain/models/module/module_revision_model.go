@@ -0,0 +1,136 @@
+package module
+
+import "time"
+
+// Revision actions recorded in the module history
+const (
+	// RevisionCreate marks the revision written when a module is created
+	RevisionCreate = "create"
+
+	// RevisionUpdate marks a revision written by a module update
+	RevisionUpdate = "update"
+
+	// RevisionRevert marks a revision restoring an earlier state
+	RevisionRevert = "revert"
+
+	// RevisionDelete marks the move of a module to the recycle bin
+	RevisionDelete = "delete"
+
+	// RevisionRestore marks the restore of a module from the recycle bin
+	RevisionRestore = "restore"
+
+	// RevisionSchedule marks a change applied by the activation scheduler
+	RevisionSchedule = "schedule"
+
+	// RevisionTransfer marks an accepted ownership transfer
+	RevisionTransfer = "transfer"
+
+	// RevisionSubmit marks the submission of a draft for approval
+	RevisionSubmit = "submit"
+
+	// RevisionApprove marks the approval of a submitted module
+	RevisionApprove = "approve"
+
+	// RevisionReject marks the rejection of a submitted module, which returns
+	// it to draft
+	RevisionReject = "reject"
+
+	// RevisionImport marks a state written by restoring a backup archive
+	RevisionImport = "import"
+
+	// RevisionBaseline marks the state of modules that existed before history
+	// tracking was introduced
+	RevisionBaseline = "baseline"
+)
+
+// MaxActorLength is the longest actor name stored with a revision.
+const MaxActorLength = 100
+
+// ModuleRevision records one change of a module.
+//
+// Each revision keeps the complete module state after the change plus the
+// field-level diff against the previous revision, so history can be listed
+// without reconstructing earlier states.
+type ModuleRevision struct {
+	// Unique identifier of the revision record
+	ID int `gorm:"primaryKey"`
+
+	// Module the revision belongs to
+	ModuleID int `gorm:"not null;uniqueIndex:idx_module_revisions_module_revision"`
+
+	// Revision number, starting at 1 and increasing by 1 per module
+	Revision int `gorm:"not null;uniqueIndex:idx_module_revisions_module_revision"`
+
+	// Kind of change (create, update, revert, delete, restore, schedule,
+	// transfer, submit, approve, reject, import or baseline)
+	Action string `gorm:"size:20;not null"`
+
+	// Who made the change
+	Actor string `gorm:"size:100;not null;index"`
+
+	// Module state after the change (the activation schedule is not part of
+	// the snapshot, so reverts keep the current schedule)
+	Name        string `gorm:"size:50;not null"`
+	Description string `gorm:"size:200"`
+	IsActive    bool   `gorm:"not null"`
+
+	// JSON-encoded []FieldChange against the previous revision
+	Changes string `gorm:"type:text;not null"`
+
+	// Timestamp of the change
+	CreatedAt time.Time `gorm:"index"`
+}
+
+// TableName overrides the default GORM table name.
+func (ModuleRevision) TableName() string {
+	return "module_revisions"
+}
+
+// RevisionFilter narrows module history queries.
+//
+// The zero value matches every revision.
+type RevisionFilter struct {
+	// Only include changes made by this actor
+	Actor string
+
+	// Only include changes made at or after this time
+	From *time.Time
+
+	// Only include changes made at or before this time
+	To *time.Time
+}
+
+// FieldChange is the old and new value of one changed field.
+//
+// Old is null for fields set when the module was created.
+type FieldChange struct {
+	// JSON name of the changed field
+	Field string `json:"field"`
+
+	// Value before the change
+	Old interface{} `json:"old"`
+
+	// Value after the change
+	New interface{} `json:"new"`
+}
+
+// ModuleChangeResponse represents one entry of a module's change history.
+//
+// Example:
+//
+//	{
+//	  "revision": 2,
+//	  "action": "update",
+//	  "actor": "jane",
+//	  "changedAt": "2023-08-16T09:12:00Z",
+//	  "changes": [
+//	    {"field": "isActive", "old": true, "new": false}
+//	  ]
+//	}
+type ModuleChangeResponse struct {
+	Revision  int           `json:"revision"`
+	Action    string        `json:"action"`
+	Actor     string        `json:"actor"`
+	ChangedAt time.Time     `json:"changedAt"`
+	Changes   []FieldChange `json:"changes"`
+}
@@ -0,0 +1,55 @@
+package module
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ModuleSetting stores one configuration value of a module.
+//
+// Values are kept as JSON text; the shape of each key is defined by its JSON
+// schema in the settings schema registry.
+type ModuleSetting struct {
+	// Module owning the setting
+	ModuleID int `gorm:"primaryKey;autoIncrement:false"`
+
+	// Setting key (must be registered in the schema registry); the column is
+	// not named "key" because that is a reserved word in MySQL
+	Key string `gorm:"column:setting_key;primaryKey;size:100"`
+
+	// JSON-encoded value
+	Value string `gorm:"type:text;not null"`
+
+	// Timestamp when the value was last written
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+// TableName overrides the default GORM table name.
+func (ModuleSetting) TableName() string {
+	return "module_settings"
+}
+
+// ModuleSettings maps setting keys to their JSON values.
+//
+// It is used both as the PUT payload and as the GET response.
+//
+// Example:
+//
+//	{
+//	  "logLevel": "debug",
+//	  "maxConnections": 20,
+//	  "featureFlags": {"newCheckout": true}
+//	}
+type ModuleSettings map[string]json.RawMessage
+
+// MarshalJSON encodes the settings as a plain object.
+//
+// Implementing json.Marshaler keeps setting keys verbatim under every response
+// naming strategy: they are user data, not field names.
+//
+// Returns:
+//   - []byte: The JSON object
+//   - error: Error if a value is not valid JSON
+func (s ModuleSettings) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]json.RawMessage(s))
+}
@@ -0,0 +1,24 @@
+package module
+
+import "time"
+
+// ModuleStar records that a user marked a module as a favorite.
+//
+// Stars are private to their user: they only narrow that user's module list
+// and are never shown to others.
+type ModuleStar struct {
+	// The starred module
+	ModuleID int `gorm:"primaryKey;autoIncrement:false"`
+
+	// The user who starred the module; the column is not named "user" because
+	// that is a reserved word in PostgreSQL
+	User string `gorm:"column:user_name;primaryKey;size:100;index"`
+
+	// Timestamp when the module was starred
+	CreatedAt time.Time
+}
+
+// TableName overrides the default GORM table name.
+func (ModuleStar) TableName() string {
+	return "module_stars"
+}
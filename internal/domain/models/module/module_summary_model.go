@@ -0,0 +1,53 @@
+package module
+
+import "time"
+
+// ModuleSummary is the read model of a module's latest recorded change.
+//
+// Summaries are a projection of the module_revisions history: the revision
+// repository updates a module's summary with every revision it appends, and
+// the replay command rebuilds all summaries from the history after data loss.
+// The actor of the change is left out, so erasing a user's data only has to
+// rewrite the history.
+type ModuleSummary struct {
+	// Module the summary belongs to
+	ModuleID int `gorm:"primaryKey;autoIncrement:false"`
+
+	// Module name after the latest change
+	Name string `gorm:"size:50;not null"`
+
+	// Whether the module was active after the latest change
+	IsActive bool `gorm:"not null"`
+
+	// Number of the latest revision
+	Revision int `gorm:"not null"`
+
+	// Kind of the latest change (see the Revision constants)
+	Action string `gorm:"size:20;not null;index"`
+
+	// Timestamp of the latest change
+	ChangedAt time.Time `gorm:"not null;index"`
+}
+
+// TableName overrides the default GORM table name.
+func (ModuleSummary) TableName() string {
+	return "module_summaries"
+}
+
+// SummaryOf returns the summary of a module after the given revision.
+//
+// Parameters:
+//   - revision: The latest revision of the module
+//
+// Returns:
+//   - *ModuleSummary: The module's summary
+func SummaryOf(revision *ModuleRevision) *ModuleSummary {
+	return &ModuleSummary{
+		ModuleID:  revision.ModuleID,
+		Name:      revision.Name,
+		IsActive:  revision.IsActive,
+		Revision:  revision.Revision,
+		Action:    revision.Action,
+		ChangedAt: revision.CreatedAt,
+	}
+}
@@ -0,0 +1,57 @@
+package module
+
+import (
+	"strconv"
+	"strings"
+)
+
+// SurrogateCollectionKey tags responses listing or aggregating modules, e.g.
+// GET /modules and GET /modules/stats. Every module write purges it.
+const SurrogateCollectionKey = "modules"
+
+// surrogateKeyPrefix starts the surrogate key of a single module.
+const surrogateKeyPrefix = "module-"
+
+// SurrogateKey returns the CDN surrogate key of a module, e.g. "module-123".
+//
+// Every cached response showing the module is tagged with the key, so one
+// purge drops them all when the module changes.
+//
+// Parameters:
+//   - id: Identifier of the module
+//
+// Returns:
+//   - string: The surrogate key
+func SurrogateKey(id int) string {
+	return surrogateKeyPrefix + strconv.Itoa(id)
+}
+
+// IsSurrogateKey reports whether a surrogate key addresses a single module.
+func IsSurrogateKey(key string) bool {
+	id, found := strings.CutPrefix(key, surrogateKeyPrefix)
+	if !found {
+		return false
+	}
+	_, err := strconv.Atoi(id)
+	return err == nil
+}
+
+// SurrogateKeys returns the surrogate keys of the module.
+func (m *ModuleResponse) SurrogateKeys() []string {
+	return []string{SurrogateKey(m.ID)}
+}
+
+// SurrogateKeys returns the surrogate keys of the module.
+func (m *PublicModuleResponse) SurrogateKeys() []string {
+	return []string{SurrogateKey(m.ID)}
+}
+
+// SurrogateKeys returns the surrogate keys of the transferred module.
+func (t *TransferResponse) SurrogateKeys() []string {
+	return []string{SurrogateKey(t.ModuleID)}
+}
+
+// SurrogateKeys returns the surrogate keys of the module the note belongs to.
+func (n *NoteResponse) SurrogateKeys() []string {
+	return []string{SurrogateKey(n.ModuleID)}
+}
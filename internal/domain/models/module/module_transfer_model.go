@@ -0,0 +1,98 @@
+package module
+
+import "time"
+
+// Ownership transfer states
+const (
+	// TransferPending marks a transfer waiting for the new owner to accept it
+	TransferPending = "pending"
+
+	// TransferAccepted marks a transfer the new owner accepted
+	TransferAccepted = "accepted"
+
+	// TransferExpired marks a transfer that was not accepted in time
+	TransferExpired = "expired"
+)
+
+// TransferTTL is how long the new owner has to accept an ownership transfer.
+const TransferTTL = 72 * time.Hour
+
+// ModuleTransfer records a request to hand a module over to a new owner.
+//
+// The transfer only takes effect once the proposed owner accepts it; until
+// then the module keeps its current owner. A module has at most one pending
+// transfer at a time.
+type ModuleTransfer struct {
+	// Unique identifier of the transfer
+	ID int `gorm:"primaryKey"`
+
+	// Module being transferred
+	ModuleID int `gorm:"not null;index"`
+
+	// Owner at the time the transfer was requested
+	FromOwner string `gorm:"size:100;not null"`
+
+	// Proposed new owner who has to accept the transfer
+	ToOwner string `gorm:"size:100;not null;index"`
+
+	// Who requested the transfer
+	RequestedBy string `gorm:"size:100;not null"`
+
+	// State of the transfer (pending, accepted or expired)
+	Status string `gorm:"size:20;not null"`
+
+	// Timestamp when the transfer was requested
+	CreatedAt time.Time
+
+	// Time after which the transfer can no longer be accepted
+	ExpiresAt time.Time `gorm:"not null"`
+
+	// Timestamp when the transfer was accepted or marked as expired
+	ResolvedAt *time.Time
+}
+
+// TableName overrides the default GORM table name.
+func (ModuleTransfer) TableName() string {
+	return "module_transfers"
+}
+
+// TransferOwnershipRequest represents the payload of an ownership transfer.
+//
+// Example:
+//
+//	{
+//	  "newOwner": "bob"
+//	}
+type TransferOwnershipRequest struct {
+	// Actor who becomes the owner once they accept
+	// required: true
+	// example: bob
+	NewOwner string `json:"newOwner" binding:"required,max=100" example:"bob"`
+}
+
+// TransferResponse represents an ownership transfer.
+//
+// Example:
+//
+//	{
+//	  "id": 7,
+//	  "moduleId": 123,
+//	  "fromOwner": "jane",
+//	  "toOwner": "bob",
+//	  "requestedBy": "jane",
+//	  "status": "pending",
+//	  "createdAt": "2023-08-15T14:30:00Z",
+//	  "expiresAt": "2023-08-18T14:30:00Z",
+//	  "resolvedAt": null
+//	}
+type TransferResponse struct {
+	ID          int        `json:"id"`
+	ModuleID    int        `json:"moduleId"`
+	FromOwner   string     `json:"fromOwner"`
+	ToOwner     string     `json:"toOwner"`
+	RequestedBy string     `json:"requestedBy"`
+	Status      string     `json:"status" example:"pending"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	ExpiresAt   time.Time  `json:"expiresAt"`
+	ResolvedAt  *time.Time `json:"resolvedAt"`
+}
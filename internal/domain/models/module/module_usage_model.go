@@ -0,0 +1,57 @@
+package module
+
+// ModuleUsage counts the API requests served for a module on one day.
+//
+// Counters hold no information about who made the requests. Days are UTC
+// dates, so counts from instances in different time zones add up.
+type ModuleUsage struct {
+	// Module the requests addressed
+	ModuleID int `gorm:"primaryKey;autoIncrement:false"`
+
+	// UTC date of the requests (YYYY-MM-DD)
+	Day string `gorm:"primaryKey;size:10"`
+
+	// Number of successful read requests (GET and HEAD); READS is reserved in MySQL
+	Reads int64 `gorm:"column:read_count;not null"`
+
+	// Number of successful write requests (all other methods)
+	Writes int64 `gorm:"column:write_count;not null"`
+}
+
+// TableName overrides the default GORM table name.
+func (ModuleUsage) TableName() string {
+	return "module_usage"
+}
+
+// ModuleUsageResponse represents the daily usage of a module.
+//
+// Every day of the range is listed, including days without requests.
+//
+// Example:
+//
+//	{
+//	  "moduleId": 123,
+//	  "from": "2023-08-14",
+//	  "to": "2023-08-15",
+//	  "reads": 42,
+//	  "writes": 3,
+//	  "days": [
+//	    {"date": "2023-08-14", "reads": 40, "writes": 3},
+//	    {"date": "2023-08-15", "reads": 2, "writes": 0}
+//	  ]
+//	}
+type ModuleUsageResponse struct {
+	ModuleID int              `json:"moduleId"`
+	From     string           `json:"from"`
+	To       string           `json:"to"`
+	Reads    int64            `json:"reads"`
+	Writes   int64            `json:"writes"`
+	Days     []ModuleUsageDay `json:"days"`
+}
+
+// ModuleUsageDay is the usage of a module on one day.
+type ModuleUsageDay struct {
+	Date   string `json:"date"`
+	Reads  int64  `json:"reads"`
+	Writes int64  `json:"writes"`
+}
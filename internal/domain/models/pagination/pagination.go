@@ -0,0 +1,81 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Page size limits shared by list endpoints
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// ErrInvalidCursor is returned when a cursor cannot be decoded.
+var ErrInvalidCursor = errors.New("invalid pagination cursor")
+
+// Cursor identifies a position in the (createdAt, id) ordering used for keyset pagination.
+//
+// Keyset pagination resumes strictly after the cursor position instead of
+// skipping OFFSET rows, so its cost does not grow with the page number:
+//
+//	WHERE created_at > :createdAt OR (created_at = :createdAt AND id > :id)
+//	ORDER BY created_at, id
+//	LIMIT :pageSize
+type Cursor struct {
+	// Creation timestamp of the last item on the previous page
+	CreatedAt time.Time `json:"createdAt"`
+
+	// ID of the last item on the previous page (tie-breaker)
+	ID int `json:"id"`
+}
+
+// Encode returns the opaque, URL-safe representation of the cursor.
+//
+// Returns:
+//   - string: Base64url-encoded cursor
+func (c Cursor) Encode() string {
+	payload, _ := json.Marshal(Cursor{CreatedAt: c.CreatedAt.UTC(), ID: c.ID})
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+// DecodeCursor parses a cursor produced by Cursor.Encode.
+//
+// Parameters:
+//   - encoded: The opaque cursor string
+//
+// Returns:
+//   - *Cursor: The decoded position
+//   - error: ErrInvalidCursor if the value is malformed
+func DecodeCursor(encoded string) (*Cursor, error) {
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(payload, &cursor); err != nil || cursor.ID <= 0 {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+// Page is one page of results in either offset or keyset mode.
+type Page[T any] struct {
+	// Items on this page
+	Items []T
+
+	// Requested page size
+	PageSize int
+
+	// 1-based page number (offset mode only)
+	Page int
+
+	// Total number of items across all pages (offset mode only)
+	TotalItems int64
+
+	// Position after the last item, nil when there are no more items (keyset mode only)
+	NextCursor *Cursor
+}
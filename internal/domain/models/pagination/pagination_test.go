@@ -0,0 +1,33 @@
+package pagination
+
+import (
+	"testing"
+	"time"
+)
+
+func FuzzDecodeCursor(f *testing.F) {
+	f.Add(Cursor{CreatedAt: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC), ID: 1}.Encode())
+	f.Add(Cursor{CreatedAt: time.Date(2024, time.January, 1, 0, 0, 0, 123456789, time.FixedZone("CET", 3600)), ID: 42}.Encode())
+	for _, encoded := range []string{"", "%%%", "e30", "eyJpZCI6MH0", "eyJpZCI6LTF9", "bnVsbA", "eyJjcmVhdGVkQXQiOjEsImlkIjoxfQ"} {
+		f.Add(encoded)
+	}
+
+	f.Fuzz(func(t *testing.T, encoded string) {
+		cursor, err := DecodeCursor(encoded)
+		if err != nil {
+			if cursor != nil || err != ErrInvalidCursor {
+				t.Fatalf("DecodeCursor(%q) = %v, %v, want nil, ErrInvalidCursor", encoded, cursor, err)
+			}
+			return
+		}
+		if cursor.ID <= 0 {
+			t.Fatalf("DecodeCursor(%q) accepted ID %d", encoded, cursor.ID)
+		}
+
+		// A decoded cursor survives another round trip
+		again, err := DecodeCursor(cursor.Encode())
+		if err != nil || again.ID != cursor.ID || !again.CreatedAt.Equal(cursor.CreatedAt) {
+			t.Fatalf("DecodeCursor(%q).Encode() does not decode to %+v: %+v, %v", encoded, cursor, again, err)
+		}
+	})
+}
@@ -0,0 +1,260 @@
+package privacy
+
+import (
+	"encoding/json"
+	"time"
+
+	"go_di_architecture/internal/domain/models/module"
+)
+
+// PseudonymPrefix starts the identifiers replacing erased users.
+const PseudonymPrefix = "erased-"
+
+// UserRecords holds every stored record linked to a user.
+//
+// It is returned by the data layer; the service turns it into a UserDataExport.
+type UserRecords struct {
+	// Modules owned by the user, including modules in the recycle bin
+	OwnedModules []module.Module
+
+	// Modules the user moved to the recycle bin
+	DeletedModules []module.Module
+
+	// Revisions made by the user or naming the user as old or new owner
+	Revisions []module.ModuleRevision
+
+	// Ownership transfers from, to or requested by the user
+	Transfers []module.ModuleTransfer
+
+	// ACL entries granting the user access
+	AccessGrants []module.ModuleACLEntry
+
+	// Notes written by the user, including deleted ones
+	Notes []module.ModuleNote
+
+	// Modules the user starred
+	Stars []module.ModuleStar
+
+	// Archived modules the user owned
+	ArchivedModules []module.ModuleArchiveRecord
+}
+
+// UserDataExport is the document listing all data linked to a user.
+//
+// Example:
+//
+//	{
+//	  "user": "jane",
+//	  "generatedAt": "2023-08-15T14:30:00Z",
+//	  "ownedModules": [{"id": 123, "name": "Inventory", "description": "", "isActive": true, "inRecycleBin": false, "createdAt": "...", "updatedAt": "..."}],
+//	  "deletedModules": [],
+//	  "revisions": [{"moduleId": 123, "revision": 1, "action": "create", "byUser": true, "changedAt": "...", "changes": [...]}],
+//	  "transfers": [],
+//	  "accessGrants": [{"moduleId": 7, "permission": "view", "grantedAt": "..."}],
+//	  "notes": [{"id": 4, "moduleId": 123, "body": "Rotated credentials", "deleted": false, "createdAt": "..."}],
+//	  "stars": [{"moduleId": 123, "starredAt": "..."}],
+//	  "archivedModules": []
+//	}
+type UserDataExport struct {
+	// The user the data is linked to
+	User string `json:"user"`
+
+	// Timestamp when the export was generated
+	GeneratedAt time.Time `json:"generatedAt"`
+
+	// Modules owned by the user
+	OwnedModules []UserModule `json:"ownedModules"`
+
+	// Modules the user moved to the recycle bin
+	DeletedModules []UserModule `json:"deletedModules"`
+
+	// Changes made by the user or naming the user as owner
+	Revisions []UserRevision `json:"revisions"`
+
+	// Ownership transfers involving the user
+	Transfers []UserTransfer `json:"transfers"`
+
+	// Modules the user was granted access to
+	AccessGrants []UserAccessGrant `json:"accessGrants"`
+
+	// Notes the user wrote
+	Notes []UserNote `json:"notes"`
+
+	// Modules the user starred
+	Stars []UserStar `json:"stars"`
+
+	// Archived modules the user owned
+	ArchivedModules []UserArchivedModule `json:"archivedModules"`
+}
+
+// UserModule is a module in a user data export.
+type UserModule struct {
+	ID           int       `json:"id"`
+	Name         string    `json:"name"`
+	Description  string    `json:"description"`
+	IsActive     bool      `json:"isActive"`
+	InRecycleBin bool      `json:"inRecycleBin"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+// UserRevision is a change history entry in a user data export.
+//
+// Other actors are not named; ByUser tells whether the user made the change.
+type UserRevision struct {
+	ModuleID  int                  `json:"moduleId"`
+	Revision  int                  `json:"revision"`
+	Action    string               `json:"action"`
+	ByUser    bool                 `json:"byUser"`
+	ChangedAt time.Time            `json:"changedAt"`
+	Changes   []module.FieldChange `json:"changes"`
+}
+
+// UserTransfer is an ownership transfer in a user data export.
+type UserTransfer struct {
+	ID          int        `json:"id"`
+	ModuleID    int        `json:"moduleId"`
+	FromOwner   string     `json:"fromOwner"`
+	ToOwner     string     `json:"toOwner"`
+	RequestedBy string     `json:"requestedBy"`
+	Status      string     `json:"status"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	ExpiresAt   time.Time  `json:"expiresAt"`
+	ResolvedAt  *time.Time `json:"resolvedAt"`
+}
+
+// UserAccessGrant is an ACL entry in a user data export.
+type UserAccessGrant struct {
+	ModuleID   int       `json:"moduleId"`
+	Permission string    `json:"permission"`
+	GrantedAt  time.Time `json:"grantedAt"`
+}
+
+// UserNote is a module note in a user data export.
+type UserNote struct {
+	ID        int       `json:"id"`
+	ModuleID  int       `json:"moduleId"`
+	Body      string    `json:"body"`
+	Deleted   bool      `json:"deleted"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// UserStar is a starred module in a user data export.
+type UserStar struct {
+	ModuleID  int       `json:"moduleId"`
+	StarredAt time.Time `json:"starredAt"`
+}
+
+// UserArchivedModule is an archived module in a user data export.
+type UserArchivedModule struct {
+	ModuleID    int       `json:"moduleId"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+	ArchivedAt  time.Time `json:"archivedAt"`
+}
+
+// ErasureCounts is the number of records changed per kind by an erasure.
+type ErasureCounts struct {
+	// Modules whose owner was replaced
+	OwnedModules int `json:"ownedModules"`
+
+	// Modules whose "deleted by" was replaced
+	DeletedModules int `json:"deletedModules"`
+
+	// Revisions whose actor was replaced
+	RevisionActors int `json:"revisionActors"`
+
+	// Revisions whose owner changes named the user
+	RevisionChanges int `json:"revisionChanges"`
+
+	// Transfers in which the user was replaced
+	Transfers int `json:"transfers"`
+
+	// Pending transfers to the user, expired because nobody can accept them anymore
+	ExpiredTransfers int `json:"expiredTransfers"`
+
+	// ACL entries whose principal was replaced
+	AccessGrants int `json:"accessGrants"`
+
+	// Notes whose author or "deleted by" was replaced
+	Notes int `json:"notes"`
+
+	// Stars whose user was replaced
+	Stars int `json:"stars"`
+
+	// Archived modules whose owner was replaced
+	ArchivedModules int `json:"archivedModules"`
+}
+
+// ErasureReport describes a completed erasure.
+//
+// The pseudonym replacing the user is not reported, so the report cannot be
+// used to link the erased records back to the user.
+//
+// Example:
+//
+//	{
+//	  "user": "jane",
+//	  "startedAt": "2023-08-15T14:30:00Z",
+//	  "finishedAt": "2023-08-15T14:30:00Z",
+//	  "erased": {"ownedModules": 2, "deletedModules": 0, "revisionActors": 14, "revisionChanges": 3, "transfers": 1, "expiredTransfers": 0, "accessGrants": 1, "notes": 0, "stars": 2, "archivedModules": 0},
+//	  "retained": ["Backup archives and export files already in object storage keep the user until they are deleted"]
+//	}
+type ErasureReport struct {
+	// The erased user
+	User string `json:"user"`
+
+	// Timestamps of the erasure
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt"`
+
+	// Records changed per kind
+	Erased ErasureCounts `json:"erased"`
+
+	// Places the erasure does not reach
+	Retained []string `json:"retained"`
+}
+
+// ReplaceOwner rewrites owner changes naming a user in JSON-encoded revision changes.
+//
+// Parameters:
+//   - changes: JSON-encoded []module.FieldChange
+//   - user: The user to look for in old and new owner values
+//   - replacement: The value replacing the user
+//
+// Returns:
+//   - string: The rewritten changes (unchanged when the user is not named)
+//   - bool: True if an owner change named the user
+//   - error: Error if the changes are not valid JSON
+func ReplaceOwner(changes, user, replacement string) (string, bool, error) {
+	var fields []module.FieldChange
+	if err := json.Unmarshal([]byte(changes), &fields); err != nil {
+		return changes, false, err
+	}
+
+	found := false
+	for i := range fields {
+		if fields[i].Field != "owner" {
+			continue
+		}
+		if fields[i].Old == user {
+			fields[i].Old = replacement
+			found = true
+		}
+		if fields[i].New == user {
+			fields[i].New = replacement
+			found = true
+		}
+	}
+	if !found {
+		return changes, false, nil
+	}
+
+	encoded, err := json.Marshal(fields)
+	if err != nil {
+		return changes, false, err
+	}
+	return string(encoded), true, nil
+}
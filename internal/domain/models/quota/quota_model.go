@@ -0,0 +1,93 @@
+package quota
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MonthFormat is the layout of the months quotas are counted for.
+const MonthFormat = "2006-01"
+
+// ErrExceeded is returned when an API key used up its monthly quota.
+var ErrExceeded = errors.New("monthly request quota exceeded")
+
+// APIKeyUsage counts the requests made with an API key in one month.
+type APIKeyUsage struct {
+	// Name of the API key
+	APIKey string `gorm:"primaryKey;size:100"`
+
+	// UTC month of the requests (YYYY-MM)
+	Month string `gorm:"primaryKey;size:7"`
+
+	// Number of accepted requests
+	Requests int64 `gorm:"column:request_count;not null"`
+}
+
+// TableName overrides the default GORM table name.
+func (APIKeyUsage) TableName() string {
+	return "api_key_usage"
+}
+
+// AccountUsage represents the quota consumption of an API key in the current month.
+//
+// Limit and Remaining are omitted for keys without a quota.
+//
+// Example:
+//
+//	{
+//	  "apiKey": "ci",
+//	  "month": "2023-08",
+//	  "requests": 1250,
+//	  "limit": 10000,
+//	  "remaining": 8750,
+//	  "resetsAt": "2023-09-01T00:00:00Z"
+//	}
+type AccountUsage struct {
+	APIKey    string    `json:"apiKey"`
+	Month     string    `json:"month"`
+	Requests  int64     `json:"requests"`
+	Limit     *int64    `json:"limit,omitempty"`
+	Remaining *int64    `json:"remaining,omitempty"`
+	ResetsAt  time.Time `json:"resetsAt"`
+}
+
+// ParseLimits parses monthly quotas of the form "ci=100000;partner=5000".
+//
+// Parameters:
+//   - spec: The quota specification (empty for none)
+//
+// Returns:
+//   - map[string]int64: Monthly request limit per API key name
+//   - error: Error naming the first malformed entry
+func ParseLimits(spec string) (map[string]int64, error) {
+	limits := make(map[string]int64)
+	for _, entry := range strings.Split(spec, ";") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+
+		name, raw, ok := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("quota %q must look like <api key>=<requests per month>", entry)
+		}
+		if _, exists := limits[name]; exists {
+			return nil, fmt.Errorf("quota %q: %q is configured twice", entry, name)
+		}
+		limit, err := strconv.ParseInt(strings.TrimSpace(raw), 10, 64)
+		if err != nil || limit <= 0 {
+			return nil, fmt.Errorf("quota %q: limit must be a positive integer", entry)
+		}
+		limits[name] = limit
+	}
+	return limits, nil
+}
+
+// MonthStart returns the first instant of the UTC month containing t.
+func MonthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
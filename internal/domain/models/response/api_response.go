@@ -2,7 +2,25 @@ package response
 
 import (
 	"net/http"
+	"sync"
 	"time"
+
+	"go_di_architecture/internal/domain/clock"
+)
+
+// Response formats selectable per request (X-Response-Format header) or per route.
+const (
+	// FormatEnvelope wraps payloads in the standard APIResponse envelope (default)
+	FormatEnvelope = "envelope"
+
+	// FormatRaw returns bare payloads on success; errors keep the envelope
+	FormatRaw = "raw"
+
+	// FormatHeader is the request header selecting the response format
+	FormatHeader = "X-Response-Format"
+
+	// FormatContextKey is the Gin context key holding a route's default format
+	FormatContextKey = "response_format"
 )
 
 // APIResponse represents the standardized response structure for all API endpoints.
@@ -63,6 +81,30 @@ type APIResponse struct {
 
 	// Additional metadata about the response
 	Meta ResponseMeta `json:"meta"`
+
+	// Render only Data when set (raw response format, success only)
+	raw bool
+
+	// Field naming and timestamp style applied when rendering
+	style Style
+}
+
+// MarshalJSON renders the envelope, or only the payload in raw format.
+//
+// Error responses always keep the envelope so clients can rely on a single
+// error shape regardless of the requested format. Field names of the envelope
+// and payload and the format of timestamps follow the response's style.
+//
+// Returns:
+//   - []byte: The JSON encoding of the response
+//   - error: Error if the payload cannot be encoded
+func (r APIResponse) MarshalJSON() ([]byte, error) {
+	if r.raw && r.Success {
+		return marshalJSON(Render(r.Data, r.style))
+	}
+
+	type envelope APIResponse
+	return marshalJSON(Render(envelope(r), r.style))
 }
 
 // APIError represents standardized error information.
@@ -73,8 +115,20 @@ type APIError struct {
 	// Human-readable error message
 	Message string `json:"message"`
 
+	// Key of the user message, for clients showing localized texts
+	// (e.g. "module.name_length")
+	MessageKey string `json:"messageKey,omitempty"`
+
 	// Field-specific validation errors
 	Details map[string][]string `json:"details,omitempty"`
+
+	// Machine-readable context helping clients recover from the error
+	// (e.g. the conflicting resource ID and suggested alternatives)
+	Context map[string]interface{} `json:"context,omitempty"`
+
+	// Reference of a server error, under which its details were logged;
+	// quote it when reporting the problem (e.g. "err-3f9c2a7d41b0e865")
+	Reference string `json:"reference,omitempty"`
 }
 
 // ResponseMeta contains additional metadata about the response.
@@ -83,7 +137,34 @@ type ResponseMeta struct {
 	RequestId string `json:"requestId"`
 
 	// Timestamp when the request was processed
-	Timestamp string `json:"timestamp"`
+	Timestamp time.Time `json:"timestamp"`
+
+	// Opaque cursor for the next page (keyset pagination only)
+	NextCursor string `json:"nextCursor,omitempty"`
+
+	// Page details (offset pagination only)
+	Pagination *PaginationMeta `json:"pagination,omitempty"`
+
+	// Requested IDs that were not found (batch lookups only)
+	MissingIds []int `json:"missingIds,omitempty"`
+
+	// Set when the request was a dry run and nothing was stored
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// PaginationMeta describes the position of an offset-paginated response.
+type PaginationMeta struct {
+	// 1-based page number
+	Page int `json:"page"`
+
+	// Number of items per page
+	PageSize int `json:"pageSize"`
+
+	// Total number of items across all pages
+	TotalItems int64 `json:"totalItems"`
+
+	// Total number of pages
+	TotalPages int `json:"totalPages"`
 }
 
 // ResponseMapper provides methods to create standardized API responses.
@@ -94,21 +175,158 @@ type ResponseMeta struct {
 //   - Error responses follow the same structure as success responses
 type ResponseMapper struct {
 	requestID string
+
+	// JSON fields kept in success payloads (nil keeps all fields)
+	fields []string
+
+	// Return bare payloads on success instead of the envelope
+	raw bool
+
+	// Flag every response as a dry run
+	dryRun bool
+
+	// Field naming and timestamp style
+	style Style
+
+	// Clock of meta.timestamp (nil uses the system clock)
+	clock clock.Clock
+}
+
+// mapperPool recycles response mappers, one of which is created per request.
+var mapperPool = sync.Pool{
+	New: func() any { return new(ResponseMapper) },
 }
 
 // NewResponseMapper creates a new response mapper with the request ID.
 //
+// Mappers come from a pool; Release hands a mapper back once the request no
+// longer renders with it. Mappers that are never released are simply
+// garbage collected.
+//
 // Parameters:
 //   - requestID: The unique identifier for the current request
 //
 // Returns:
 //   - *ResponseMapper: A configured response mapper
 func NewResponseMapper(requestID string) *ResponseMapper {
-	return &ResponseMapper{requestID: requestID}
+	m := mapperPool.Get().(*ResponseMapper)
+	*m = ResponseMapper{requestID: requestID}
+	return m
+}
+
+// Release returns the mapper to the pool.
+//
+// Responses already created keep their own copy of the settings, but the
+// mapper itself must not be used after it is released.
+func (m *ResponseMapper) Release() {
+	*m = ResponseMapper{}
+	mapperPool.Put(m)
+}
+
+// SelectFields restricts success payloads to the given JSON fields.
+//
+// The selection applies to single resources and to every item of a list; the
+// envelope, metadata and error responses are never projected.
+//
+// Parameters:
+//   - fields: JSON field names to keep (nil or empty keeps all fields)
+//
+// Returns:
+//   - *ResponseMapper: The same mapper, for chaining
+func (m *ResponseMapper) SelectFields(fields []string) *ResponseMapper {
+	m.fields = fields
+	return m
+}
+
+// UseFormat selects how success responses are rendered.
+//
+// In raw format success responses serialize as the bare payload (a single DTO
+// or a list) without success, message or meta. Error responses are unaffected.
+//
+// Parameters:
+//   - format: FormatEnvelope or FormatRaw (anything else selects the envelope)
+//
+// Returns:
+//   - *ResponseMapper: The same mapper, for chaining
+func (m *ResponseMapper) UseFormat(format string) *ResponseMapper {
+	m.raw = format == FormatRaw
+	return m
+}
+
+// UseNaming selects the field naming strategy of every response.
+//
+// Parameters:
+//   - naming: NamingCamelCase or NamingSnakeCase (anything else keeps camelCase)
+//
+// Returns:
+//   - *ResponseMapper: The same mapper, for chaining
+func (m *ResponseMapper) UseNaming(naming string) *ResponseMapper {
+	m.style.Naming = naming
+	return m
+}
+
+// UseTimeFormat selects how timestamps are rendered in every response,
+// including meta.timestamp.
+//
+// Parameters:
+//   - format: One of the TimeFormat constants (anything else keeps RFC 3339 with fractional seconds)
+//   - utc: Convert timestamps to UTC instead of keeping their zone
+//
+// Returns:
+//   - *ResponseMapper: The same mapper, for chaining
+func (m *ResponseMapper) UseTimeFormat(format string, utc bool) *ResponseMapper {
+	m.style.TimeFormat = format
+	m.style.UTC = utc
+	return m
+}
+
+// UseClock selects the clock stamping meta.timestamp, e.g. a frozen clock
+// for deterministic responses in tests.
+//
+// Parameters:
+//   - c: The clock (nil uses the system clock)
+//
+// Returns:
+//   - *ResponseMapper: The same mapper, for chaining
+func (m *ResponseMapper) UseClock(c clock.Clock) *ResponseMapper {
+	m.clock = c
+	return m
+}
+
+// now returns the time of meta.timestamp.
+func (m *ResponseMapper) now() time.Time {
+	if m.clock == nil {
+		return time.Now()
+	}
+	return m.clock.Now()
+}
+
+// Render applies the mapper's style to a payload written outside the
+// envelope (e.g. NDJSON stream rows).
+//
+// Parameters:
+//   - data: The payload to render
+//
+// Returns:
+//   - interface{}: The payload with the field names and timestamps of the style
+func (m *ResponseMapper) Render(data interface{}) interface{} {
+	return Render(data, m.style)
+}
+
+// MarkDryRun flags every response created by the mapper as a dry run.
+//
+// Returns:
+//   - *ResponseMapper: The same mapper, for chaining
+func (m *ResponseMapper) MarkDryRun() *ResponseMapper {
+	m.dryRun = true
+	return m
 }
 
 // Success creates a standardized success response.
 //
+// When fields were selected, the payload is projected to those fields; field
+// names are matched after applying the naming strategy (e.g. is_active).
+//
 // Parameters:
 //   - data: The actual data payload to return
 //   - message: Brief success message
@@ -118,14 +336,22 @@ func NewResponseMapper(requestID string) *ResponseMapper {
 //   - *APIResponse: A properly formatted success response
 //   - int: The HTTP status code
 func (m *ResponseMapper) Success(data interface{}, message string, statusCode int) (*APIResponse, int) {
+	data = Render(data, m.style)
+	if projected, err := Project(data, m.fields); err == nil {
+		data = projected
+	}
+
 	return &APIResponse{
 		Success: true,
 		Message: message,
 		Data:    data,
 		Meta: ResponseMeta{
 			RequestId: m.requestID,
-			Timestamp: time.Now().Format(time.RFC3339),
+			Timestamp: m.now(),
+			DryRun:    m.dryRun,
 		},
+		raw:   m.raw,
+		style: m.style,
 	}, statusCode
 }
 
@@ -151,11 +377,97 @@ func (m *ResponseMapper) Error(code, message string, details map[string][]string
 		},
 		Meta: ResponseMeta{
 			RequestId: m.requestID,
-			Timestamp: time.Now().Format(time.RFC3339),
+			Timestamp: m.now(),
+			DryRun:    m.dryRun,
 		},
+		style: m.style,
 	}, statusCode
 }
 
+// Fail creates the standardized error response of a reported error.
+//
+// Parameters:
+//   - err: The error reported by the handler
+//
+// Returns:
+//   - *APIResponse: A properly formatted error response
+//   - int: The HTTP status code
+func (m *ResponseMapper) Fail(err *HTTPError) (*APIResponse, int) {
+	response, statusCode := m.ErrorWithContext(err.Code, err.Message, err.Details, err.Context, err.Status)
+	response.Error.MessageKey = err.MessageKey
+	response.Error.Reference = err.Reference
+	return response, statusCode
+}
+
+// SuccessWithPagination creates a success response for an offset-paginated list.
+//
+// Parameters:
+//   - data: The items on the current page
+//   - message: Brief success message
+//   - pagination: Page number, size and totals
+//   - statusCode: HTTP status code for the response
+//
+// Returns:
+//   - *APIResponse: A success response with pagination metadata
+//   - int: The HTTP status code
+func (m *ResponseMapper) SuccessWithPagination(data interface{}, message string, pagination *PaginationMeta, statusCode int) (*APIResponse, int) {
+	response, statusCode := m.Success(data, message, statusCode)
+	response.Meta.Pagination = pagination
+	return response, statusCode
+}
+
+// SuccessWithCursor creates a success response for a keyset-paginated list.
+//
+// Parameters:
+//   - data: The items on the current page
+//   - message: Brief success message
+//   - nextCursor: Opaque cursor of the next page (empty on the last page)
+//   - statusCode: HTTP status code for the response
+//
+// Returns:
+//   - *APIResponse: A success response with the next cursor
+//   - int: The HTTP status code
+func (m *ResponseMapper) SuccessWithCursor(data interface{}, message string, nextCursor string, statusCode int) (*APIResponse, int) {
+	response, statusCode := m.Success(data, message, statusCode)
+	response.Meta.NextCursor = nextCursor
+	return response, statusCode
+}
+
+// SuccessWithMissing creates a success response for a batch lookup.
+//
+// Parameters:
+//   - data: The items that were found
+//   - message: Brief success message
+//   - missingIds: Requested IDs that were not found (omitted when empty)
+//   - statusCode: HTTP status code for the response
+//
+// Returns:
+//   - *APIResponse: A success response listing the missing IDs
+//   - int: The HTTP status code
+func (m *ResponseMapper) SuccessWithMissing(data interface{}, message string, missingIds []int, statusCode int) (*APIResponse, int) {
+	response, statusCode := m.Success(data, message, statusCode)
+	response.Meta.MissingIds = missingIds
+	return response, statusCode
+}
+
+// ErrorWithContext creates a standardized error response carrying recovery context.
+//
+// Parameters:
+//   - code: Machine-readable error code
+//   - message: Human-readable error message
+//   - details: Field-specific validation errors
+//   - context: Additional machine-readable error context
+//   - statusCode: HTTP status code for the response
+//
+// Returns:
+//   - *APIResponse: A properly formatted error response
+//   - int: The HTTP status code
+func (m *ResponseMapper) ErrorWithContext(code, message string, details map[string][]string, context map[string]interface{}, statusCode int) (*APIResponse, int) {
+	response, statusCode := m.Error(code, message, details, statusCode)
+	response.Error.Context = context
+	return response, statusCode
+}
+
 // NewSuccessResponse creates a standardized success response.
 //
 // Parameters:
@@ -172,7 +484,7 @@ func NewSuccessResponse(data interface{}, message string, requestId string) *API
 		Data:    data,
 		Meta: ResponseMeta{
 			RequestId: requestId,
-			Timestamp: time.Now().Format(time.RFC3339),
+			Timestamp: time.Now(),
 		},
 	}
 }
@@ -198,7 +510,7 @@ func NewErrorResponse(code, message string, details map[string][]string, request
 		},
 		Meta: ResponseMeta{
 			RequestId: requestId,
-			Timestamp: time.Now().Format(time.RFC3339),
+			Timestamp: time.Now(),
 		},
 	}
 }
@@ -216,12 +528,22 @@ func StatusToMessage(statusCode int) string {
 		return "Operation completed successfully"
 	case http.StatusCreated:
 		return "Resource created successfully"
+	case http.StatusAccepted:
+		return "Request accepted for processing"
 	case http.StatusBadRequest:
 		return "Invalid request parameters"
+	case http.StatusUnauthorized:
+		return "Authentication required"
+	case http.StatusForbidden:
+		return "Permission denied"
 	case http.StatusNotFound:
 		return "Resource not found"
+	case http.StatusMethodNotAllowed:
+		return "Method not allowed"
 	case http.StatusConflict:
 		return "Resource already exists"
+	case http.StatusTooManyRequests:
+		return "Request quota exceeded"
 	default:
 		return "An unexpected error occurred"
 	}
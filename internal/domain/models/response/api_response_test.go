@@ -0,0 +1,93 @@
+package response_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"go_di_architecture/internal/domain/models/module"
+	"go_di_architecture/internal/domain/models/response"
+)
+
+// benchModule is the payload of a single-resource response.
+var benchModule = module.ModuleResponse{
+	ID:          123,
+	Name:        "Inventory",
+	Description: "Handles product stock management",
+	IsActive:    true,
+	Status:      module.StatusApproved,
+	Owner:       "alice",
+	CreatedAt:   time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+	UpdatedAt:   time.Date(2024, time.January, 2, 0, 0, 0, 0, time.UTC),
+	Tags:        []string{"billing", "core"},
+}
+
+// benchmarkMapper runs the per-request path of a handler: create the mapper,
+// configure it, build the response, encode it and release the mapper:
+//
+//	go test -run '^$' -bench ResponseMapper -benchmem ./internal/domain/models/response
+func benchmarkMapper(b *testing.B, configure func(m *response.ResponseMapper)) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		mapper := response.NewResponseMapper("request-" + strconv.Itoa(i&0xff))
+		configure(mapper)
+		resp, _ := mapper.Success(benchModule, "Module retrieved successfully", http.StatusOK)
+		if _, err := json.Marshal(resp); err != nil {
+			b.Fatal(err)
+		}
+		mapper.Release()
+	}
+}
+
+func BenchmarkResponseMapperDefault(b *testing.B) {
+	benchmarkMapper(b, func(*response.ResponseMapper) {})
+}
+
+func BenchmarkResponseMapperSnakeCase(b *testing.B) {
+	benchmarkMapper(b, func(m *response.ResponseMapper) {
+		m.UseNaming(response.NamingSnakeCase)
+	})
+}
+
+func BenchmarkResponseMapperRFC3339UTC(b *testing.B) {
+	benchmarkMapper(b, func(m *response.ResponseMapper) {
+		m.UseTimeFormat(response.TimeFormatRFC3339, true)
+	})
+}
+
+func BenchmarkResponseMapperFields(b *testing.B) {
+	fields := response.ParseFields("id,name,isActive")
+	benchmarkMapper(b, func(m *response.ResponseMapper) {
+		m.SelectFields(fields)
+	})
+}
+
+func BenchmarkResponseMapperError(b *testing.B) {
+	details := map[string][]string{"name": {"Name must be 3-50 characters"}}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		mapper := response.NewResponseMapper("request")
+		resp, _ := mapper.Error("VALIDATION_ERROR", "Validation error", details, http.StatusBadRequest)
+		if _, err := json.Marshal(resp); err != nil {
+			b.Fatal(err)
+		}
+		mapper.Release()
+	}
+}
+
+// BenchmarkFormatTime compares the timestamp formats; whole seconds are
+// served from the cache while the second does not change.
+func BenchmarkFormatTime(b *testing.B) {
+	now := time.Date(2024, time.January, 1, 12, 30, 45, 123456789, time.UTC)
+	for _, format := range []string{response.TimeFormatRFC3339Nano, response.TimeFormatRFC3339, response.TimeFormatEpochMillis} {
+		style := response.Style{TimeFormat: format}
+		b.Run(format, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				style.FormatTime(now)
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package response
+
+import (
+	"cmp"
+	"errors"
+	"net/http"
+	"slices"
+	"sync"
+
+	"go_di_architecture/internal/domain/apperror"
+)
+
+// ErrorMapping describes the response of an error returned by a service.
+type ErrorMapping struct {
+	// HTTP status code of the response
+	Status int
+
+	// Machine-readable error code
+	Code string
+
+	// Field receiving the error text as validation detail (none when empty)
+	Field string
+
+	// Render the error text as message instead of the standard message of
+	// the status; only for errors whose text is written for clients
+	ExposeMessage bool
+
+	// Adds details or context found in the error chain, e.g. with errors.As
+	// (optional)
+	Enrich func(err error, httpErr *HTTPError)
+}
+
+// ErrorCode is a status and error code pair the API responds with.
+type ErrorCode struct {
+	// HTTP status code of the response
+	Status int
+
+	// Machine-readable error code
+	Code string
+}
+
+// transportCodes are the codes the transport layer responds with directly,
+// without an error of the business layer.
+var transportCodes = []ErrorCode{
+	{Status: http.StatusBadRequest, Code: "VALIDATION_ERROR"},
+	{Status: http.StatusUnauthorized, Code: "UNAUTHORIZED"},
+	{Status: http.StatusForbidden, Code: "FORBIDDEN"},
+	{Status: http.StatusForbidden, Code: "INVALID_SIGNATURE"},
+	{Status: http.StatusNotFound, Code: "NOT_FOUND"},
+	{Status: http.StatusNotFound, Code: "ROUTE_NOT_FOUND"},
+	{Status: http.StatusMethodNotAllowed, Code: "METHOD_NOT_ALLOWED"},
+	{Status: http.StatusTooManyRequests, Code: "RATE_LIMITED"},
+	{Status: http.StatusTooManyRequests, Code: "QUOTA_EXCEEDED"},
+	{Status: http.StatusInternalServerError, Code: "INTERNAL_ERROR"},
+}
+
+// ErrorRegistry maps service errors to HTTP errors.
+//
+// Errors carrying an apperror.AppError are rendered from it: its status, code,
+// message key and field details, never its cause. Plain sentinel errors need a
+// mapping registered by the transport layer, usually from an init function
+// next to the service's handler. Entries are matched with errors.Is in
+// registration order, so wrapped errors match their sentinel; they take
+// precedence over the AppError. Errors matching neither become a 500
+// INTERNAL_ERROR whose text is only logged.
+//
+// Enrichers add what the error type alone cannot express, e.g. context found
+// in a typed error wrapping an AppError sentinel.
+//
+// Usage Example:
+//
+//	func init() {
+//	    response.Errors.Register(storage.ErrObjectNotFound, response.ErrorMapping{
+//	        Status: http.StatusNotFound,
+//	        Code:   "NOT_FOUND",
+//	    })
+//	    response.Errors.Enrich(moduleService.ErrNameExists, addConflictContext)
+//	}
+//
+//	httpErr := response.Errors.Map(err)
+type ErrorRegistry struct {
+	mu        sync.RWMutex
+	entries   []errorEntry
+	enrichers []errorEnricher
+}
+
+// errorEntry is a registered error with its mapping.
+type errorEntry struct {
+	target  error
+	mapping ErrorMapping
+}
+
+// errorEnricher is a registered error with its enricher.
+type errorEnricher struct {
+	target error
+	enrich func(err error, httpErr *HTTPError)
+}
+
+// Errors is the registry of the application, used by handlers and the
+// exception middleware.
+var Errors = NewErrorRegistry()
+
+// NewErrorRegistry creates an empty registry.
+//
+// Returns:
+//   - *ErrorRegistry: A registry mapping every error to a 500
+func NewErrorRegistry() *ErrorRegistry {
+	return &ErrorRegistry{}
+}
+
+// Register adds the mapping of an error.
+//
+// Parameters:
+//   - target: Sentinel error matched with errors.Is
+//   - mapping: How matching errors are rendered
+//
+// Registering the same error twice panics, since the second mapping would
+// never apply.
+func (r *ErrorRegistry) Register(target error, mapping ErrorMapping) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, entry := range r.entries {
+		if entry.target == target {
+			panic("response: error mapping registered twice: " + target.Error())
+		}
+	}
+	r.entries = append(r.entries, errorEntry{target: target, mapping: mapping})
+}
+
+// Enrich adds an enricher of an error.
+//
+// Parameters:
+//   - target: Sentinel error matched with errors.Is
+//   - enrich: Adds details or context to the mapped HTTP error; every matching
+//     enricher runs, in registration order
+func (r *ErrorRegistry) Enrich(target error, enrich func(err error, httpErr *HTTPError)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.enrichers = append(r.enrichers, errorEnricher{target: target, enrich: enrich})
+}
+
+// Codes lists every error code the API can respond with.
+//
+// The list joins the codes of the registered mappings, of the declared
+// AppError sentinels (see apperror.Declared) and of the errors the transport
+// layer renders itself, so documentation generated from it follows the
+// services without being maintained by hand.
+//
+// Returns:
+//   - []ErrorCode: The distinct codes, ordered by status and code
+func (r *ErrorRegistry) Codes() []ErrorCode {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	codes := append([]ErrorCode(nil), transportCodes...)
+	for _, entry := range r.entries {
+		codes = append(codes, ErrorCode{Status: entry.mapping.Status, Code: entry.mapping.Code})
+	}
+	for _, appErr := range apperror.Declared() {
+		codes = append(codes, ErrorCode{Status: appErr.Status, Code: appErr.Code})
+	}
+
+	slices.SortFunc(codes, func(a, b ErrorCode) int {
+		return cmp.Or(cmp.Compare(a.Status, b.Status), cmp.Compare(a.Code, b.Code))
+	})
+	return slices.Compact(codes)
+}
+
+// Map converts an error to the HTTP error rendered for it.
+//
+// Parameters:
+//   - err: The error returned from the business layer
+//
+// Returns:
+//   - *HTTPError: The mapped error with err as cause; a 500 INTERNAL_ERROR
+//     when neither an entry nor an AppError matches
+func (r *ErrorRegistry) Map(err error) *HTTPError {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// Step 1: Render from a registered mapping or the AppError in the chain
+	httpErr := r.mapEntry(err)
+	if httpErr == nil {
+		var appErr *apperror.AppError
+		if !errors.As(err, &appErr) {
+			return NewHTTPError(http.StatusInternalServerError, "INTERNAL_ERROR", nil).WithCause(err)
+		}
+		httpErr = NewHTTPError(appErr.Status, appErr.Code, appErr.Fields).WithCause(err)
+		httpErr.MessageKey = appErr.MessageKey
+	}
+
+	// Step 2: Add what typed errors in the chain know
+	for _, enricher := range r.enrichers {
+		if errors.Is(err, enricher.target) {
+			enricher.enrich(err, httpErr)
+		}
+	}
+	return httpErr
+}
+
+// mapEntry renders an error with the first matching registered mapping.
+func (r *ErrorRegistry) mapEntry(err error) *HTTPError {
+	for _, entry := range r.entries {
+		if !errors.Is(err, entry.target) {
+			continue
+		}
+
+		mapping := entry.mapping
+		var details map[string][]string
+		if mapping.Field != "" {
+			details = map[string][]string{mapping.Field: {err.Error()}}
+		}
+		httpErr := NewHTTPError(mapping.Status, mapping.Code, details).WithCause(err)
+		if mapping.ExposeMessage {
+			httpErr.Message = err.Error()
+		}
+		if mapping.Enrich != nil {
+			mapping.Enrich(err, httpErr)
+		}
+		return httpErr
+	}
+	return nil
+}
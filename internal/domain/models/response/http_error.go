@@ -0,0 +1,120 @@
+package response
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+)
+
+// MapperContextKey is the Gin context key holding the response mapper of the
+// request, so the error middleware renders errors in the request's style.
+const MapperContextKey = "response_mapper"
+
+// HTTPError is an error reported by a handler through ctx.Error.
+//
+// Handlers never write error responses themselves; the error middleware turns
+// the last reported HTTPError into the standard error response. Any other
+// error reaching the middleware is rendered as a 500 without its message.
+type HTTPError struct {
+	// HTTP status code of the response
+	Status int
+
+	// Machine-readable error code
+	Code string
+
+	// Human-readable error message
+	Message string
+
+	// Key of a localizable user message (optional)
+	MessageKey string
+
+	// Field-specific validation errors
+	Details map[string][]string
+
+	// Machine-readable context helping clients recover from the error
+	Context map[string]interface{}
+
+	// Underlying error, logged but never rendered
+	Err error
+
+	// Reference of a server error, rendered instead of its details
+	Reference string
+}
+
+// NewHTTPError creates an error rendered with the standard message of its status.
+//
+// Parameters:
+//   - status: HTTP status code of the response
+//   - code: Machine-readable error code
+//   - details: Field-specific validation errors
+//
+// Returns:
+//   - *HTTPError: The error to report with ctx.Error
+func NewHTTPError(status int, code string, details map[string][]string) *HTTPError {
+	return &HTTPError{
+		Status:  status,
+		Code:    code,
+		Message: StatusToMessage(status),
+		Details: details,
+	}
+}
+
+// WithContext attaches recovery context to the error.
+//
+// Parameters:
+//   - context: Additional machine-readable error context
+//
+// Returns:
+//   - *HTTPError: The same error, for chaining
+func (e *HTTPError) WithContext(context map[string]interface{}) *HTTPError {
+	e.Context = context
+	return e
+}
+
+// WithReference assigns a new error reference to the error.
+//
+// The reference is rendered to the client and logged with the full error, so
+// support can find the log entry of a reported failure without the response
+// revealing internals.
+//
+// Returns:
+//   - *HTTPError: The same error, for chaining
+func (e *HTTPError) WithReference() *HTTPError {
+	e.Reference = NewErrorReference()
+	return e
+}
+
+// NewErrorReference returns a random reference for a server error.
+//
+// Returns:
+//   - string: The reference, e.g. "err-3f9c2a7d41b0e865"
+func NewErrorReference() string {
+	reference := make([]byte, 8)
+	rand.Read(reference)
+	return "err-" + hex.EncodeToString(reference)
+}
+
+// WithCause records the underlying error for logging.
+//
+// Parameters:
+//   - err: The error that caused the response
+//
+// Returns:
+//   - *HTTPError: The same error, for chaining
+func (e *HTTPError) WithCause(err error) *HTTPError {
+	e.Err = err
+	return e
+}
+
+// Error describes the error for logs.
+func (e *HTTPError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%d %s: %v", e.Status, e.Code, e.Err)
+	}
+	return fmt.Sprintf("%d %s", e.Status, e.Code)
+}
+
+// Unwrap returns the underlying error.
+func (e *HTTPError) Unwrap() error {
+	return e.Err
+}
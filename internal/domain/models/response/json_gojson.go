@@ -0,0 +1,13 @@
+//go:build go_json
+
+package response
+
+import json "github.com/goccy/go-json"
+
+// JSONEncoder names the JSON implementation compiled into the response layer.
+const JSONEncoder = "go-json"
+
+var (
+	marshalJSON   = json.Marshal
+	unmarshalJSON = json.Unmarshal
+)
@@ -0,0 +1,19 @@
+//go:build !go_json
+
+package response
+
+import "encoding/json"
+
+// JSONEncoder names the JSON implementation compiled into the response layer.
+//
+// The implementation follows Gin's build tags, so one flag switches both the
+// envelope encoding and Gin's renderer:
+//
+//	go build ./cmd/api                  # encoding/json (default)
+//	go build -tags go_json ./cmd/api    # github.com/goccy/go-json
+const JSONEncoder = "encoding/json"
+
+var (
+	marshalJSON   = json.Marshal
+	unmarshalJSON = json.Unmarshal
+)
@@ -0,0 +1,66 @@
+package response_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"go_di_architecture/internal/domain/models/module"
+	"go_di_architecture/internal/domain/models/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// benchModuleList is a full page of the module list endpoint.
+func benchModuleList() []module.ModuleResponse {
+	modules := make([]module.ModuleResponse, 100)
+	for i := range modules {
+		modules[i] = benchModule
+		modules[i].ID = i + 1
+		modules[i].Name = "Module " + strconv.Itoa(i+1)
+	}
+	return modules
+}
+
+// BenchmarkEncodeModuleList renders a page of modules through Gin as the
+// list handler does. Compare the encoders by running it with and without the
+// go_json build tag:
+//
+//	go test -run '^$' -bench EncodeModuleList -benchmem ./internal/domain/models/response
+//	go test -run '^$' -bench EncodeModuleList -benchmem -tags go_json ./internal/domain/models/response
+func BenchmarkEncodeModuleList(b *testing.B) {
+	gin.SetMode(gin.TestMode)
+	b.Logf("JSON encoder: %s", response.JSONEncoder)
+	modules := benchModuleList()
+	pagination := &response.PaginationMeta{Page: 1, PageSize: len(modules), TotalItems: 1000, TotalPages: 10}
+
+	encode := func(b *testing.B) {
+		recorder := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(recorder)
+		mapper := response.NewResponseMapper("request")
+		resp, status := mapper.SuccessWithPagination(modules, "Modules retrieved successfully", pagination, http.StatusOK)
+		ctx.JSON(status, resp)
+		mapper.Release()
+		if recorder.Code != http.StatusOK {
+			b.Errorf("status %d: %s", recorder.Code, recorder.Body)
+		}
+	}
+
+	b.Run("serial", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			encode(b)
+		}
+	})
+
+	// Concurrent requests, as under load
+	b.Run("parallel", func(b *testing.B) {
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				encode(b)
+			}
+		})
+	})
+}
@@ -0,0 +1,79 @@
+package response
+
+import (
+	"mime"
+	"strings"
+	"unicode"
+)
+
+// JSON naming strategies for response field names.
+const (
+	// NamingCamelCase keeps the field names of the JSON tags (default)
+	NamingCamelCase = "camelCase"
+
+	// NamingSnakeCase renders field names in snake_case (e.g. isActive -> is_active)
+	NamingSnakeCase = "snake_case"
+
+	// NamingProfileParam is the Accept header parameter selecting a strategy per
+	// request, e.g. "Accept: application/json; profile=snake_case"
+	NamingProfileParam = "profile"
+)
+
+// IsNamingStrategy reports whether the value names a supported strategy.
+//
+// Parameters:
+//   - naming: Strategy name to check
+//
+// Returns:
+//   - bool: True for NamingCamelCase and NamingSnakeCase
+func IsNamingStrategy(naming string) bool {
+	return naming == NamingCamelCase || naming == NamingSnakeCase
+}
+
+// NamingFromAccept extracts the naming strategy requested through the Accept header.
+//
+// Media ranges are checked in order; the first profile parameter naming a
+// supported strategy wins.
+//
+// Parameters:
+//   - accept: The raw Accept header value
+//
+// Returns:
+//   - string: The requested strategy, or "" when none is requested
+func NamingFromAccept(accept string) string {
+	for _, mediaRange := range strings.Split(accept, ",") {
+		_, params, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+		if err != nil {
+			continue
+		}
+		if profile := params[NamingProfileParam]; IsNamingStrategy(profile) {
+			return profile
+		}
+	}
+	return ""
+}
+
+// toSnakeCase converts a camelCase name to snake_case.
+//
+// Acronyms stay together ("requestID" -> "request_id", "HTTPServer" ->
+// "http_server"); names without upper-case letters are returned unchanged.
+func toSnakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	b.Grow(len(name) + 4)
+
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				prev := runes[i-1]
+				nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+				if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+					b.WriteByte('_')
+				}
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
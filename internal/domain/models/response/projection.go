@@ -0,0 +1,84 @@
+package response
+
+import "strings"
+
+// ParseFields splits a comma-separated field list such as "id,name,isActive".
+//
+// Blank entries and surrounding whitespace are ignored.
+//
+// Parameters:
+//   - raw: The raw value of the fields query parameter
+//
+// Returns:
+//   - []string: The requested field names, or nil when none were requested
+func ParseFields(raw string) []string {
+	var fields []string
+	for _, field := range strings.Split(raw, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// Project reduces a payload to the requested JSON fields.
+//
+// The payload is rendered with its JSON tags first, so field names match what
+// clients see in the full response. Objects keep only the requested keys; lists
+// are projected element by element. Unknown fields are ignored and scalar
+// payloads are returned unchanged.
+//
+// Parameters:
+//   - data: The payload to project (a struct, map or slice of them)
+//   - fields: JSON field names to keep (nil or empty keeps everything)
+//
+// Returns:
+//   - interface{}: The projected payload
+//   - error: Error if the payload cannot be encoded as JSON
+func Project(data interface{}, fields []string) (interface{}, error) {
+	if len(fields) == 0 || data == nil {
+		return data, nil
+	}
+
+	// Step 1: Render the payload using its JSON representation
+	encoded, err := marshalJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var generic interface{}
+	if err := unmarshalJSON(encoded, &generic); err != nil {
+		return nil, err
+	}
+
+	// Step 2: Keep only the requested keys
+	keep := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		keep[field] = true
+	}
+
+	return projectValue(generic, keep), nil
+}
+
+// projectValue filters an object, or every object in a list, by key.
+func projectValue(value interface{}, keep map[string]bool) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		projected := make(map[string]interface{}, len(keep))
+		for key, item := range typed {
+			if keep[key] {
+				projected[key] = item
+			}
+		}
+		return projected
+
+	case []interface{}:
+		for i, item := range typed {
+			typed[i] = projectValue(item, keep)
+		}
+		return typed
+
+	default:
+		return value
+	}
+}
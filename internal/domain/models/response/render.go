@@ -0,0 +1,268 @@
+package response
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Timestamp formats for response times.
+const (
+	// TimeFormatRFC3339Nano renders RFC 3339 with fractional seconds (default)
+	TimeFormatRFC3339Nano = "rfc3339nano"
+
+	// TimeFormatRFC3339 renders RFC 3339 with whole seconds
+	TimeFormatRFC3339 = "rfc3339"
+
+	// TimeFormatEpochMillis renders milliseconds since the Unix epoch as a number
+	TimeFormatEpochMillis = "epoch_millis"
+)
+
+var (
+	// marshalerType identifies values that control their own JSON encoding
+	marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+	// timeType identifies timestamps, which are formatted by the style
+	timeType = reflect.TypeOf(time.Time{})
+)
+
+// Style controls how response payloads are rendered.
+//
+// The zero value renders payloads exactly as encoding/json does: camelCase
+// field names from the JSON tags and RFC 3339 timestamps with fractional
+// seconds in the time's own zone.
+type Style struct {
+	// Field naming strategy (NamingCamelCase when empty)
+	Naming string
+
+	// Timestamp format (TimeFormatRFC3339Nano when empty)
+	TimeFormat string
+
+	// Convert timestamps to UTC before formatting
+	UTC bool
+}
+
+// IsTimeFormat reports whether the value names a supported timestamp format.
+//
+// Parameters:
+//   - format: Format name to check
+//
+// Returns:
+//   - bool: True for the TimeFormat constants
+func IsTimeFormat(format string) bool {
+	switch format {
+	case TimeFormatRFC3339Nano, TimeFormatRFC3339, TimeFormatEpochMillis:
+		return true
+	}
+	return false
+}
+
+// FormatTime renders a timestamp in the style's zone and format.
+//
+// Parameters:
+//   - t: The time to render
+//
+// Returns:
+//   - interface{}: A string, or an int64 for TimeFormatEpochMillis
+func (s Style) FormatTime(t time.Time) interface{} {
+	if s.UTC {
+		t = t.UTC()
+	}
+	switch s.TimeFormat {
+	case TimeFormatRFC3339:
+		return secondCache.format(t)
+	case TimeFormatEpochMillis:
+		return t.UnixMilli()
+	default:
+		return t.Format(time.RFC3339Nano)
+	}
+}
+
+// secondCache remembers the last timestamp rendered with whole seconds, so the
+// meta timestamps of responses served within the same second share one string.
+var secondCache timeCache
+
+// timeCache holds the RFC 3339 rendering of one second in one zone.
+type timeCache struct {
+	mu   sync.Mutex
+	unix int64
+	loc  *time.Location
+	text string
+}
+
+// format renders t as RFC 3339, reusing the cached text for the same second.
+func (c *timeCache) format(t time.Time) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.text != "" && c.unix == t.Unix() && c.loc == t.Location() {
+		return c.text
+	}
+	c.unix, c.loc, c.text = t.Unix(), t.Location(), t.Format(time.RFC3339)
+	return c.text
+}
+
+// isDefault reports whether the style renders like plain encoding/json.
+func (s Style) isDefault() bool {
+	return s.Naming != NamingSnakeCase && !s.UTC &&
+		(s.TimeFormat == "" || s.TimeFormat == TimeFormatRFC3339Nano)
+}
+
+// Render applies a style to a payload.
+//
+// Struct fields are named from their JSON tags (honouring "-", omitempty and
+// embedded structs) and converted by the naming strategy; map keys are
+// converted the same way. Timestamps are formatted by the style. Other values
+// implementing json.Marshaler (raw JSON, setting maps) control their own
+// encoding and are kept verbatim. Rendering is idempotent, so an already
+// rendered payload can be passed again.
+//
+// Parameters:
+//   - data: The payload to render
+//   - style: Naming and timestamp settings
+//
+// Returns:
+//   - interface{}: The payload unchanged for the default style, otherwise a
+//     tree of maps, slices and scalars
+func Render(data interface{}, style Style) interface{} {
+	if style.isDefault() || data == nil {
+		return data
+	}
+
+	convert := func(name string) string { return name }
+	if style.Naming == NamingSnakeCase {
+		convert = toSnakeCase
+	}
+	return renderValue(reflect.ValueOf(data), style, convert)
+}
+
+// renderValue renders one value of a payload.
+func renderValue(v reflect.Value, style Style, convert func(string) string) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+
+	// Timestamps (and optional timestamps) are formatted by the style
+	if v.Type() == timeType {
+		return style.FormatTime(v.Interface().(time.Time))
+	}
+	if v.Kind() == reflect.Pointer && v.Type().Elem() == timeType {
+		if v.IsNil() {
+			return nil
+		}
+		return style.FormatTime(v.Elem().Interface().(time.Time))
+	}
+
+	if v.Type().Implements(marshalerType) {
+		if isNilable(v) && v.IsNil() {
+			return nil
+		}
+		return v.Interface()
+	}
+
+	switch v.Kind() {
+	case reflect.Interface, reflect.Pointer:
+		if v.IsNil() {
+			return nil
+		}
+		return renderValue(v.Elem(), style, convert)
+
+	case reflect.Struct:
+		fields := make(map[string]interface{}, v.NumField())
+		renderFields(v, style, convert, fields)
+		return fields
+
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		entries := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			entries[convert(fmt.Sprint(iter.Key().Interface()))] = renderValue(iter.Value(), style, convert)
+		}
+		return entries
+
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			// Byte slices encode as base64 strings
+			return v.Interface()
+		}
+		items := make([]interface{}, v.Len())
+		for i := range items {
+			items[i] = renderValue(v.Index(i), style, convert)
+		}
+		return items
+
+	default:
+		return v.Interface()
+	}
+}
+
+// renderFields adds the JSON fields of a struct to fields, flattening embedded structs.
+func renderFields(v reflect.Value, style Style, convert func(string) string, fields map[string]interface{}) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		value := v.Field(i)
+
+		// Embedded structs without a tag name contribute their own fields
+		if field.Anonymous && name == "" {
+			if value.Kind() == reflect.Pointer {
+				if value.IsNil() {
+					continue
+				}
+				value = value.Elem()
+			}
+			if value.Kind() == reflect.Struct {
+				renderFields(value, style, convert, fields)
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if strings.Contains(options, "omitempty") && isEmptyValue(value) {
+			continue
+		}
+		fields[convert(name)] = renderValue(value, style, convert)
+	}
+}
+
+// isNilable reports whether IsNil may be called on the value.
+func isNilable(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice:
+		return true
+	}
+	return false
+}
+
+// isEmptyValue mirrors the omitempty rules of encoding/json.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Interface, reflect.Pointer:
+		return v.IsZero()
+	}
+	return false
+}
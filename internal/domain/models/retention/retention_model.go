@@ -0,0 +1,230 @@
+package retention
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Retention rules
+const (
+	// RulePurgeRevisions deletes change history revisions older than the
+	// maximum age; the latest revision of every module is always kept
+	RulePurgeRevisions = "revisions.purge"
+
+	// RuleArchiveModules moves inactive modules not changed within the
+	// maximum age to the module_archive table
+	RuleArchiveModules = "modules.archive"
+)
+
+// MaxReportedModules caps the module IDs listed per rule in a report.
+const MaxReportedModules = 100
+
+// IsRule reports whether name is a supported retention rule.
+func IsRule(name string) bool {
+	return name == RulePurgeRevisions || name == RuleArchiveModules
+}
+
+// MaxAge is the age after which a retention rule applies.
+//
+// It is written as whole days ("365d") when possible and as a Go duration
+// otherwise.
+type MaxAge time.Duration
+
+// String formats the age as configured.
+func (a MaxAge) String() string {
+	const day = 24 * time.Hour
+	if d := time.Duration(a); d%day == 0 {
+		return strconv.FormatInt(int64(d/day), 10) + "d"
+	}
+	return time.Duration(a).String()
+}
+
+// MarshalJSON encodes the age as a string such as "365d".
+func (a MaxAge) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.String())
+}
+
+// Rule is one configured retention rule.
+//
+// Example:
+//
+//	{
+//	  "name": "revisions.purge",
+//	  "maxAge": "365d"
+//	}
+type Rule struct {
+	// Rule name (revisions.purge or modules.archive)
+	Name string `json:"name"`
+
+	// Age after which the rule applies
+	MaxAge MaxAge `json:"maxAge"`
+}
+
+// ParseRules parses retention rules such as
+// "revisions.purge=365d;modules.archive=730d".
+//
+// Rules are separated by semicolons; each maps a rule name to a maximum age
+// given in days ("365d") or as a Go duration ("8760h"). Blank rules and
+// whitespace are ignored.
+//
+// Parameters:
+//   - spec: The retention rules
+//
+// Returns:
+//   - []Rule: The parsed rules in spec order (empty when spec is blank)
+//   - error: Error if a rule is malformed, unknown, repeated or has no positive age
+func ParseRules(spec string) ([]Rule, error) {
+	var rules []Rule
+	seen := make(map[string]bool)
+	for _, rule := range strings.Split(spec, ";") {
+		if strings.TrimSpace(rule) == "" {
+			continue
+		}
+
+		name, age, ok := strings.Cut(rule, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("rule %q must look like <rule>=<max age>", rule)
+		}
+		if !IsRule(name) {
+			return nil, fmt.Errorf("rule %q: unknown rule %q", rule, name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("rule %q: %q is configured twice", rule, name)
+		}
+		seen[name] = true
+
+		maxAge, err := parseMaxAge(strings.TrimSpace(age))
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: %w", rule, err)
+		}
+		rules = append(rules, Rule{Name: name, MaxAge: maxAge})
+	}
+	return rules, nil
+}
+
+// parseMaxAge parses a positive age in days ("365d") or as a Go duration.
+func parseMaxAge(value string) (MaxAge, error) {
+	var age time.Duration
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid max age %q", value)
+		}
+		age = time.Duration(n) * 24 * time.Hour
+	} else {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, fmt.Errorf("invalid max age %q", value)
+		}
+		age = d
+	}
+	if age <= 0 {
+		return 0, fmt.Errorf("max age %q must be positive", value)
+	}
+	return MaxAge(age), nil
+}
+
+// ArchiveCandidate is an inactive module old enough to be archived.
+type ArchiveCandidate struct {
+	// Identifier of the module
+	ID int
+
+	// Timestamp when the module was last changed
+	UpdatedAt time.Time
+
+	// Number of modules depending on it; modules with dependents are kept
+	Dependents int
+}
+
+// RuleReport describes what one rule did, or would do in a dry run.
+//
+// Example:
+//
+//	{
+//	  "rule": "modules.archive",
+//	  "maxAge": "730d",
+//	  "cutoff": "2021-08-15T14:30:00Z",
+//	  "matched": 3,
+//	  "affected": 2,
+//	  "skipped": 1,
+//	  "moduleIds": [17, 42]
+//	}
+type RuleReport struct {
+	// Rule name
+	Rule string `json:"rule"`
+
+	// Age after which the rule applies
+	MaxAge MaxAge `json:"maxAge"`
+
+	// Records older than the cutoff are affected
+	Cutoff time.Time `json:"cutoff"`
+
+	// Number of revisions or modules matching the rule
+	Matched int `json:"matched"`
+
+	// Number of revisions purged or modules archived (0 in a dry run)
+	Affected int `json:"affected"`
+
+	// Number of matching modules kept because other modules depend on them
+	// or because they changed while the rule ran
+	Skipped int `json:"skipped"`
+
+	// Modules archived, or that would be archived in a dry run (at most
+	// MaxReportedModules)
+	ModuleIDs []int `json:"moduleIds,omitempty"`
+
+	// Reason the rule failed; records handled before the failure stay affected
+	Error string `json:"error,omitempty"`
+}
+
+// Report describes one execution of the retention rules.
+//
+// Example:
+//
+//	{
+//	  "dryRun": true,
+//	  "startedAt": "2023-08-15T14:30:00Z",
+//	  "finishedAt": "2023-08-15T14:30:01Z",
+//	  "rules": [
+//	    {"rule": "revisions.purge", "maxAge": "365d", "cutoff": "2022-08-15T14:30:00Z", "matched": 120, "affected": 0, "skipped": 0}
+//	  ]
+//	}
+type Report struct {
+	// Whether records were only counted, not changed
+	DryRun bool `json:"dryRun"`
+
+	// Timestamps of the execution
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt"`
+
+	// Outcome per rule in configuration order
+	Rules []RuleReport `json:"rules"`
+}
+
+// Status describes the retention policy of the running instance.
+//
+// Example:
+//
+//	{
+//	  "dryRun": false,
+//	  "interval": "24h0m0s",
+//	  "rules": [{"name": "revisions.purge", "maxAge": "365d"}],
+//	  "lastRun": null
+//	}
+type Status struct {
+	// Whether scheduled executions only report what they would do
+	DryRun bool `json:"dryRun"`
+
+	// Time between scheduled executions
+	Interval string `json:"interval"`
+
+	// Configured rules
+	Rules []Rule `json:"rules"`
+
+	// Outcome of the last scheduled or manual execution (null before the first)
+	LastRun *Report `json:"lastRun"`
+}
@@ -0,0 +1,71 @@
+package tag
+
+import "time"
+
+// Tag represents a label that can be attached to any number of modules.
+//
+// Tag names are normalized to lower case, so "Backend" and "backend" refer to
+// the same tag.
+//
+// Example:
+//
+//	{
+//	  "id": 7,
+//	  "name": "backend",
+//	  "createdAt": "2023-08-15T14:30:00Z"
+//	}
+type Tag struct {
+	// Unique identifier for the tag
+	ID int `json:"id" gorm:"primaryKey"`
+
+	// Normalized tag name (1-30 characters: lower-case letters, digits and dashes)
+	Name string `json:"name" gorm:"size:30;not null;uniqueIndex:idx_tags_name"`
+
+	// Timestamp when the tag was created
+	CreatedAt time.Time `json:"createdAt" gorm:"autoCreateTime"`
+}
+
+// ModuleTag links a module to a tag (many-to-many join table).
+type ModuleTag struct {
+	// Tagged module
+	ModuleID int `gorm:"primaryKey;autoIncrement:false"`
+
+	// Attached tag
+	TagID int `gorm:"primaryKey;autoIncrement:false;index:idx_module_tags_tag_id"`
+
+	// Timestamp when the tag was attached
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+// TableName overrides the default GORM table name.
+func (ModuleTag) TableName() string {
+	return "module_tags"
+}
+
+// TagRequest represents the payload for creating a new tag.
+//
+// Example:
+//
+//	{
+//	  "name": "backend"
+//	}
+type TagRequest struct {
+	// Name of the tag (1-30 characters, required)
+	// Validation: Lower-case letters, digits and dashes after normalization
+	Name string `json:"name" binding:"required,max=30"`
+}
+
+// TagResponse represents the response structure for tag operations.
+//
+// Example:
+//
+//	{
+//	  "id": 7,
+//	  "name": "backend",
+//	  "createdAt": "2023-08-15T14:30:00Z"
+//	}
+type TagResponse struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+}
@@ -0,0 +1,122 @@
+package template
+
+import (
+	"strconv"
+	"time"
+
+	"go_di_architecture/internal/domain/models/module"
+)
+
+// ModuleTemplate is a catalogue entry for creating modules with pre-filled
+// values.
+//
+// The name pattern may contain {placeholders} that are resolved when a module
+// is created from the template, e.g. "{team}-inventory".
+type ModuleTemplate struct {
+	// Unique identifier of the template
+	ID int `gorm:"primaryKey"`
+
+	// Catalogue name of the template (unique)
+	Name string `gorm:"size:50;not null;uniqueIndex:idx_module_templates_name"`
+
+	// Pattern of the module name, with {placeholders}
+	NamePattern string `gorm:"size:50;not null"`
+
+	// Description of the created modules
+	Description string `gorm:"size:200"`
+
+	// JSON-encoded settings document applied to the created modules
+	Settings string `gorm:"type:text;not null"`
+
+	// Timestamp when the template was created
+	CreatedAt time.Time
+}
+
+// TableName overrides the default GORM table name.
+func (ModuleTemplate) TableName() string {
+	return "module_templates"
+}
+
+// TemplateRequest represents the payload for adding a template to the catalogue.
+//
+// Example:
+//
+//	{
+//	  "name": "warehouse-inventory",
+//	  "namePattern": "{warehouse}-inventory",
+//	  "description": "Stock management for one warehouse",
+//	  "settings": {"logLevel": "info", "maxConnections": 20}
+//	}
+type TemplateRequest struct {
+	// Catalogue name of the template (1-50 characters, required)
+	// example: warehouse-inventory
+	Name string `json:"name" binding:"required,max=50" example:"warehouse-inventory"`
+
+	// Pattern of the module name (required, at most 50 characters); {date}
+	// and {actor} are filled in automatically, other placeholders from the
+	// variables of the create request
+	// example: {warehouse}-inventory
+	NamePattern string `json:"namePattern" binding:"required,max=50" example:"{warehouse}-inventory"`
+
+	// Description of the created modules (optional, at most 200 characters)
+	// example: Stock management for one warehouse
+	Description string `json:"description" binding:"max=200" example:"Stock management for one warehouse"`
+
+	// Settings applied to the created modules (optional); each value must
+	// satisfy the schema of its key
+	Settings module.ModuleSettings `json:"settings"`
+}
+
+// InstantiateRequest represents the optional payload for creating a module
+// from a template.
+//
+// Example:
+//
+//	{
+//	  "variables": {"warehouse": "berlin"}
+//	}
+type InstantiateRequest struct {
+	// Values of the placeholders in the name pattern
+	Variables map[string]string `json:"variables" binding:"max=20,dive,max=50"`
+}
+
+// TemplateParams binds the ID in the path of template routes.
+//
+// Example:
+//
+//	POST /api/v1/modules/from-template/7
+type TemplateParams struct {
+	// Unique identifier of the template (positive)
+	TemplateID int `uri:"templateId" binding:"min=1"`
+}
+
+// Key returns the template ID in the string form the services accept.
+func (p TemplateParams) Key() string {
+	return strconv.Itoa(p.TemplateID)
+}
+
+// TemplateResponse represents a catalogue template in API responses.
+//
+// Placeholders lists the variables a create request must supply; the
+// built-in {date} and {actor} are not included.
+//
+// Example:
+//
+//	{
+//	  "id": 7,
+//	  "name": "warehouse-inventory",
+//	  "namePattern": "{warehouse}-inventory",
+//	  "placeholders": ["warehouse"],
+//	  "description": "Stock management for one warehouse",
+//	  "settings": {"logLevel": "info", "maxConnections": 20},
+//	  "createdAt": "2023-08-15T14:30:00Z"
+//	}
+type TemplateResponse struct {
+	ID           int                   `json:"id"`
+	Name         string                `json:"name"`
+	NamePattern  string                `json:"namePattern"`
+	Placeholders []string              `json:"placeholders"`
+	Description  string                `json:"description"`
+	Settings     module.ModuleSettings `json:"settings"`
+	CreatedAt    time.Time             `json:"createdAt"`
+}
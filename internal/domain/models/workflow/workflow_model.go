@@ -0,0 +1,166 @@
+package workflow
+
+import "time"
+
+// KindProvisionModule is the kind of module provisioning workflows.
+const KindProvisionModule = "module.provision"
+
+// Workflow states
+const (
+	// StatusRunning marks a workflow executing its steps
+	StatusRunning = "running"
+
+	// StatusCompleted marks a workflow whose steps all succeeded
+	StatusCompleted = "completed"
+
+	// StatusCompensating marks a workflow undoing its completed steps after
+	// a step failed
+	StatusCompensating = "compensating"
+
+	// StatusCompensated marks a workflow whose completed steps were all undone
+	StatusCompensated = "compensated"
+
+	// StatusFailed marks a workflow whose compensation failed; its remaining
+	// steps have to be undone by an operator
+	StatusFailed = "failed"
+)
+
+// Step states reported in WorkflowResponse
+const (
+	StepPending     = "pending"
+	StepCompleted   = "completed"
+	StepFailed      = "failed"
+	StepCompensated = "compensated"
+)
+
+// Workflow is the persisted state of a multi-step workflow (saga).
+//
+// The state is saved after every step and every compensation, so a workflow
+// interrupted by a shutdown continues where it stopped on the next start.
+type Workflow struct {
+	// Unique identifier of the workflow (UUID)
+	ID string `gorm:"primaryKey;size:36"`
+
+	// What the workflow does (e.g. module.provision)
+	Kind string `gorm:"size:50;not null"`
+
+	// Current state (running, completed, compensating, compensated or failed)
+	Status string `gorm:"size:20;not null;index"`
+
+	// Number of leading steps in effect: completed and not compensated
+	Step int `gorm:"not null"`
+
+	// Name of the step that failed, if any
+	FailedStep string `gorm:"size:50"`
+
+	// Who started the workflow
+	Actor string `gorm:"size:100;not null"`
+
+	// JSON-encoded map of the values the steps read and record
+	Data string `gorm:"type:text;not null"`
+
+	// Reason of the failure, if any
+	Error string `gorm:"size:500"`
+
+	// Timestamp when the workflow was started
+	CreatedAt time.Time
+
+	// Timestamp of the last saved step
+	UpdatedAt time.Time
+}
+
+// TableName overrides the default GORM table name.
+func (Workflow) TableName() string {
+	return "workflows"
+}
+
+// Finished reports whether the workflow reached a final state.
+func (w *Workflow) Finished() bool {
+	return w.Status != StatusRunning && w.Status != StatusCompensating
+}
+
+// ProvisionRequest represents the payload for provisioning a module.
+//
+// Example:
+//
+//	{
+//	  "name": "Inventory",
+//	  "description": "Manages stock levels",
+//	  "plan": "small"
+//	}
+type ProvisionRequest struct {
+	// Name of the module (3-50 characters, required)
+	// example: Inventory
+	Name string `json:"name" binding:"required,min=3,max=50" example:"Inventory"`
+
+	// Description of what the module does (max 200 characters)
+	// example: Manages stock levels
+	Description string `json:"description" binding:"max=200" example:"Manages stock levels"`
+
+	// Resource plan allocated to the module (small, medium or large)
+	// example: small
+	Plan string `json:"plan" binding:"required,oneof=small medium large" example:"small"`
+}
+
+// WorkflowParams binds the ID in the path of workflow routes.
+//
+// Example:
+//
+//	GET /api/v1/workflows/0190f5c4-3b1e-7c51-9a51-4be6d1c7a8f2
+type WorkflowParams struct {
+	// Unique identifier of the workflow (UUID)
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+// StepResponse is the state of one workflow step.
+type StepResponse struct {
+	// Name of the step (e.g. allocate_resources)
+	Name string `json:"name"`
+
+	// State (pending, completed, failed or compensated)
+	Status string `json:"status"`
+}
+
+// WorkflowResponse represents a workflow in API responses.
+//
+// Example:
+//
+//	{
+//	  "id": "0190f5c4-3b1e-7c51-9a51-4be6d1c7a8f2",
+//	  "kind": "module.provision",
+//	  "status": "compensated",
+//	  "steps": [
+//	    {"name": "create_module", "status": "compensated"},
+//	    {"name": "allocate_resources", "status": "failed"},
+//	    {"name": "notify", "status": "pending"}
+//	  ],
+//	  "data": {"name": "Inventory", "plan": "small", "moduleId": "123"},
+//	  "error": "allocate resources: POST https://...: unexpected status 503",
+//	  "createdAt": "2023-08-15T14:30:00Z",
+//	  "updatedAt": "2023-08-15T14:30:02Z"
+//	}
+type WorkflowResponse struct {
+	// Unique identifier of the workflow
+	ID string `json:"id"`
+
+	// What the workflow does
+	Kind string `json:"kind"`
+
+	// Current state (running, completed, compensating, compensated or failed)
+	Status string `json:"status"`
+
+	// The steps in execution order
+	Steps []StepResponse `json:"steps"`
+
+	// Values the steps read and recorded (e.g. the created module ID)
+	Data map[string]string `json:"data"`
+
+	// Reason of the failure, if any
+	Error string `json:"error,omitempty"`
+
+	// Timestamp when the workflow was started
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Timestamp of the last saved step
+	UpdatedAt time.Time `json:"updatedAt"`
+}
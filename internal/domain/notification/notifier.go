@@ -0,0 +1,56 @@
+package notification
+
+import (
+	"context"
+
+	"go_di_architecture/internal/domain/events"
+)
+
+// Notification channels that routing rules can name
+const (
+	// ChannelEmail delivers notifications by SMTP
+	ChannelEmail = "email"
+
+	// ChannelWebhook posts notifications as JSON to a URL
+	ChannelWebhook = "webhook"
+
+	// ChannelSlack posts notifications to a Slack incoming webhook
+	ChannelSlack = "slack"
+)
+
+// IsChannel reports whether the value names a supported notification channel.
+//
+// Parameters:
+//   - channel: The channel name to check
+//
+// Returns:
+//   - bool: True for email, webhook and slack
+func IsChannel(channel string) bool {
+	switch channel {
+	case ChannelEmail, ChannelWebhook, ChannelSlack:
+		return true
+	default:
+		return false
+	}
+}
+
+// Message is a rendered notification about a domain event.
+type Message struct {
+	// Short summary, used as the email subject
+	Subject string
+
+	// Full text of the notification
+	Body string
+
+	// Event the notification is about
+	Event events.Event
+}
+
+// Notifier delivers notifications over one channel.
+//
+// The interface is owned by the domain layer; implementations for SMTP,
+// generic webhooks and Slack live in the infrastructure layer.
+type Notifier interface {
+	// Notify delivers the message; the context bounds the delivery time
+	Notify(ctx context.Context, message Message) error
+}
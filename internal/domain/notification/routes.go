@@ -0,0 +1,84 @@
+package notification
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AnyEvent is the routing key matching every event type.
+const AnyEvent = "*"
+
+// Routes maps event types to the channels notified about them.
+//
+// Rules for a specific event type and the AnyEvent rule both apply; a channel
+// listed by both is notified once.
+type Routes map[string][]string
+
+// ParseRoutes parses routing rules such as
+// "module.deactivated=email,slack;*=webhook".
+//
+// Rules are separated by semicolons; each maps an event type (or "*") to a
+// comma-separated list of channels. Blank rules and whitespace are ignored.
+//
+// Parameters:
+//   - spec: The routing rules
+//
+// Returns:
+//   - Routes: The parsed rules (empty when spec is blank)
+//   - error: Error if a rule is malformed or names an unknown channel
+func ParseRoutes(spec string) (Routes, error) {
+	routes := make(Routes)
+	for _, rule := range strings.Split(spec, ";") {
+		if strings.TrimSpace(rule) == "" {
+			continue
+		}
+
+		eventType, channels, ok := strings.Cut(rule, "=")
+		eventType = strings.TrimSpace(eventType)
+		if !ok || eventType == "" {
+			return nil, fmt.Errorf("rule %q must look like <event type>=<channel>[,<channel>]", rule)
+		}
+
+		for _, channel := range strings.Split(channels, ",") {
+			channel = strings.TrimSpace(channel)
+			if !IsChannel(channel) {
+				return nil, fmt.Errorf("rule %q: unknown channel %q", rule, channel)
+			}
+			routes[eventType] = append(routes[eventType], channel)
+		}
+	}
+	return routes, nil
+}
+
+// Channels returns the channels to notify about an event type.
+//
+// Parameters:
+//   - eventType: The type of the published event
+//
+// Returns:
+//   - []string: Distinct channels in rule order, specific rules first
+func (r Routes) Channels(eventType string) []string {
+	var channels []string
+	seen := make(map[string]bool)
+	for _, channel := range append(r[eventType], r[AnyEvent]...) {
+		if !seen[channel] {
+			seen[channel] = true
+			channels = append(channels, channel)
+		}
+	}
+	return channels
+}
+
+// Used returns every channel named by at least one rule.
+//
+// Returns:
+//   - map[string]bool: Set of channel names
+func (r Routes) Used() map[string]bool {
+	used := make(map[string]bool)
+	for _, channels := range r {
+		for _, channel := range channels {
+			used[channel] = true
+		}
+	}
+	return used
+}
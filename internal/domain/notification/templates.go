@@ -0,0 +1,60 @@
+package notification
+
+import (
+	"fmt"
+
+	"go_di_architecture/internal/domain/events"
+)
+
+// Template names used to render notifications
+const (
+	// templatePrefix is followed by the event type, e.g.
+	// "notifications/module.deactivated.txt"
+	templatePrefix = "notifications/"
+
+	// templateSuffix marks plain-text templates
+	templateSuffix = ".txt"
+
+	// DefaultTemplate renders event types without a dedicated template
+	DefaultTemplate = templatePrefix + "default" + templateSuffix
+)
+
+// Templates executes named templates.
+//
+// The interface is owned by the domain layer; the templating package
+// implements it. Notification templates define a "subject" and a "body" block
+// and are executed with the events.Event as data.
+type Templates interface {
+	// Has reports whether a template with the name exists
+	Has(name string) bool
+
+	// RenderBlock executes a block defined inside a template
+	RenderBlock(name, block string, data any) (string, error)
+}
+
+// Render builds the notification message for an event.
+//
+// Parameters:
+//   - templates: Template set holding the notification templates
+//   - event: The published event
+//
+// Returns:
+//   - Message: The rendered subject and body
+//   - error: Error if a template fails to execute
+func Render(templates Templates, event events.Event) (Message, error) {
+	name := templatePrefix + event.Type + templateSuffix
+	if !templates.Has(name) {
+		name = DefaultTemplate
+	}
+
+	subject, err := templates.RenderBlock(name, "subject", event)
+	if err != nil {
+		return Message{}, fmt.Errorf("render %s subject: %w", event.Type, err)
+	}
+	body, err := templates.RenderBlock(name, "body", event)
+	if err != nil {
+		return Message{}, fmt.Errorf("render %s body: %w", event.Type, err)
+	}
+
+	return Message{Subject: subject, Body: body, Event: event}, nil
+}
@@ -0,0 +1,35 @@
+package provisioning
+
+import (
+	"context"
+	"strconv"
+)
+
+// Allocator reserves the platform resources of a module (storage, compute,
+// credentials) on an external provisioning system.
+//
+// The interface is owned by the domain layer so workflows can allocate
+// resources without depending on a platform. Implementations:
+//   - HTTPAllocator (infrastructure layer): calls the allocation API of a
+//     provisioning service
+//   - Local: records nothing, when no provisioning service is configured
+type Allocator interface {
+	// Allocate reserves the resources of the plan for the module and returns
+	// the identifier of the allocation
+	Allocate(ctx context.Context, moduleID int, plan string) (string, error)
+
+	// Release frees an allocation; releasing an unknown allocation succeeds
+	Release(ctx context.Context, allocationID string) error
+}
+
+// Local is an Allocator that allocates nothing and names its allocations
+// after the module ("local-<id>").
+var Local Allocator = local{}
+
+type local struct{}
+
+func (local) Allocate(_ context.Context, moduleID int, _ string) (string, error) {
+	return "local-" + strconv.Itoa(moduleID), nil
+}
+
+func (local) Release(context.Context, string) error { return nil }
@@ -0,0 +1,94 @@
+package queue
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Message is a message taken from a queue.
+type Message struct {
+	// Broker handle of the delivery, passed back to Ack (e.g. an SQS receipt
+	// handle or an AMQP delivery tag); opaque to consumers
+	Handle string
+
+	// Payload as sent by the producer
+	Body []byte
+}
+
+// Consumer takes the messages of one queue.
+//
+// The interface is owned by the domain layer so commands can arrive over a
+// message broker without the application depending on one. Delivery is at
+// least once: a message that is received but never acknowledged is not lost,
+// so consumers must tolerate seeing a message again. Implementations live in
+// the infrastructure layer:
+//   - RedisQueue: Redis lists, with in-flight messages kept on a processing
+//     list until acknowledged
+//   - RabbitMQQueue: a RabbitMQ queue bound to an exchange, with a
+//     dead-letter queue and a prefetch limit
+//
+// An SQS adapter (ReceiveMessage/DeleteMessage, a redrive policy for the
+// dead letters) maps onto the same calls.
+type Consumer interface {
+	// Receive waits for the next message.
+	//
+	// It returns a nil message when none arrived within the broker's wait
+	// time, so callers can check for shutdown between waits.
+	Receive(ctx context.Context) (*Message, error)
+
+	// Ack removes a processed message from the queue
+	Ack(ctx context.Context, message *Message) error
+
+	// Reject removes a message that can never be processed, e.g. one that is
+	// not valid JSON, moving it to the dead-letter queue for inspection
+	Reject(ctx context.Context, message *Message) error
+
+	// Requeue puts a message that failed transiently back at the end of the
+	// queue for a later attempt, so it does not block the messages behind it
+	Requeue(ctx context.Context, message *Message) error
+}
+
+// DeadLetter is a message in the dead-letter queue.
+type DeadLetter struct {
+	// Identifier derived from the payload (see MessageID)
+	ID string
+
+	// Payload as sent by the producer
+	Body []byte
+}
+
+// DeadLetters gives operators access to the dead-letter queue of a Consumer.
+//
+// Dead letters are identified by their payload, so replaying removes one copy
+// of identical messages.
+type DeadLetters interface {
+	// List returns up to limit dead letters, oldest first
+	List(ctx context.Context, limit int) ([]DeadLetter, error)
+
+	// Depth counts the dead letters
+	Depth(ctx context.Context) (int, error)
+
+	// Replay moves the dead letter with the identifier back to the end of
+	// the queue; it reports false when no dead letter has the identifier
+	Replay(ctx context.Context, id string) (bool, error)
+}
+
+// MessageID identifies a message by its payload: the first 16 hex digits of
+// its SHA-256 digest.
+//
+// Parameters:
+//   - body: The payload
+//
+// Returns:
+//   - string: The identifier
+func MessageID(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:8])
+}
+
+// Publisher sends messages to named topics (queues, lists, exchanges).
+type Publisher interface {
+	// Publish sends the body to the topic
+	Publish(ctx context.Context, topic string, body []byte) error
+}
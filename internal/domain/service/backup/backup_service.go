@@ -0,0 +1,519 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go_di_architecture/internal/domain/guard"
+	"go_di_architecture/internal/domain/lock"
+	"go_di_architecture/internal/domain/metrics"
+	"go_di_architecture/internal/domain/models/backup"
+	"go_di_architecture/internal/domain/models/module"
+	"go_di_architecture/internal/domain/models/pagination"
+	"go_di_architecture/internal/domain/models/tag"
+	moduleService "go_di_architecture/internal/domain/service/module"
+	settingService "go_di_architecture/internal/domain/service/setting"
+	tagService "go_di_architecture/internal/domain/service/tag"
+	"go_di_architecture/internal/domain/storage"
+)
+
+// KeyPrefix is the storage prefix under which archives are written.
+const KeyPrefix = "backups/"
+
+// Job kinds of backups and restores in the jobs API
+const (
+	BackupJobKind  = "module.backup"
+	RestoreJobKind = "module.restore"
+)
+
+// backupBatchSize is the number of modules loaded per repository batch.
+const backupBatchSize = 500
+
+// restoreLockName is the lock held by a running restore.
+const restoreLockName = "backup.restore"
+
+// restoreLockTTL bounds how long a restore holds its lock if its instance dies.
+const restoreLockTTL = time.Hour
+
+// Backup errors
+var (
+	// ErrInvalidArchive is returned for archives that cannot be decoded, have
+	// an unsupported version or contain duplicate module names
+	ErrInvalidArchive = errors.New("invalid backup archive")
+
+	// ErrRestoreConflict is returned by ConflictFail restores when archived
+	// module names are already taken
+	ErrRestoreConflict = errors.New("archived modules conflict with existing modules")
+
+	// ErrInvalidConflictPolicy is returned for policies other than skip, overwrite and fail
+	ErrInvalidConflictPolicy = errors.New("unsupported conflict policy")
+
+	// ErrRestoreRunning is returned while another restore, possibly on another
+	// instance, is writing modules
+	ErrRestoreRunning = errors.New("another restore is running")
+)
+
+// ConflictError lists the conflicting modules of an aborted restore.
+//
+// The error wraps ErrRestoreConflict.
+type ConflictError struct {
+	Conflicts []backup.RestoreConflict
+}
+
+// Error returns the message of the wrapped ErrRestoreConflict with the conflicting names.
+func (e *ConflictError) Error() string {
+	names := make([]string, len(e.Conflicts))
+	for i, conflict := range e.Conflicts {
+		names[i] = conflict.Name
+	}
+	return fmt.Sprintf("%s: %s", ErrRestoreConflict, strings.Join(names, ", "))
+}
+
+// Unwrap exposes ErrRestoreConflict to errors.Is.
+func (e *ConflictError) Unwrap() error {
+	return ErrRestoreConflict
+}
+
+// ModuleImporter writes archived module states with change history.
+//
+// Implemented by the module service.
+type ModuleImporter interface {
+	ImportModule(state *module.Module, existingID int, actor string) (*module.Module, error)
+}
+
+// BackupService dumps module data to versioned JSON archives and restores them.
+//
+// An archive holds every module outside the recycle bin with its tags and
+// settings, plus the full tag list. Dependencies, access control lists,
+// change history and pending ownership transfers are not part of it.
+//
+// Usage Example:
+//
+//	service, err := backup.NewBackupService(moduleRepo, moduleService, tagRepo, settingRepo, store, locks, m)
+//	result, err := service.Backup(ctx)
+//	report, err := service.Restore(ctx, result.Key, backup.ConflictSkip, "ops")
+type BackupService struct {
+	modules  moduleService.ModuleRepository
+	importer ModuleImporter
+	tags     tagService.TagRepository
+	settings settingService.SettingRepository
+	storage  storage.Storage
+	locks    lock.Lock
+
+	// Durations of restores (the import jobs) by outcome
+	restoreDuration metrics.Histogram
+}
+
+// NewBackupService creates a new instance of BackupService.
+//
+// Parameters:
+//   - modules: Module repository read by backups and used to find conflicts
+//   - importer: Writes restored modules (usually the module service)
+//   - tags: Tag repository
+//   - settings: Setting repository
+//   - store: Object storage holding the archives
+//   - locks: Lock keeping restores of different instances apart
+//   - m: Registers the import_job_duration histogram, labelled with the
+//     outcome of each restore (succeeded, conflict, failed)
+//
+// Returns:
+//   - *BackupService: A new service instance
+//   - error: guard.ErrNilDependency if a required dependency is nil
+func NewBackupService(modules moduleService.ModuleRepository, importer ModuleImporter, tags tagService.TagRepository, settings settingService.SettingRepository, store storage.Storage, locks lock.Lock, m metrics.Metrics) (*BackupService, error) {
+	if err := guard.Require("NewBackupService",
+		guard.Dep("modules", modules),
+		guard.Dep("importer", importer),
+		guard.Dep("tags", tags),
+		guard.Dep("settings", settings),
+		guard.Dep("store", store),
+		guard.Dep("locks", locks),
+		guard.Dep("m", m),
+	); err != nil {
+		return nil, err
+	}
+	return &BackupService{
+		modules:         modules,
+		importer:        importer,
+		tags:            tags,
+		settings:        settings,
+		storage:         store,
+		locks:           locks,
+		restoreDuration: m.Histogram("import_job_duration"),
+	}, nil
+}
+
+// ListBackups returns the archives in storage, newest first.
+//
+// Parameters:
+//   - ctx: Context bounding the storage request
+//
+// Returns:
+//   - []storage.ObjectInfo: The archives
+//   - error: Error from the storage
+func (s *BackupService) ListBackups(ctx context.Context) ([]storage.ObjectInfo, error) {
+	objects, err := s.storage.List(ctx, KeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keys embed the backup time, so reverse key order is newest first
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key > objects[j].Key })
+	return objects, nil
+}
+
+// Backup writes every module with its tags and settings to a new archive.
+//
+// Parameters:
+//   - ctx: Context cancelling the backup (checked between batches and bounding the upload)
+//
+// Returns:
+//   - *backup.BackupResult: Key and size of the written archive
+//   - error: Error from the data layer or the storage
+//
+// Consistency:
+//   - Modules are read in batches without a transaction, so changes made
+//     while the backup runs may or may not be included
+func (s *BackupService) Backup(ctx context.Context) (*backup.BackupResult, error) {
+	// Step 1: Collect tags and modules
+	now := time.Now().UTC()
+	archive := backup.Archive{Version: backup.ArchiveVersion, CreatedAt: now, Tags: []string{}, Modules: []backup.ArchivedModule{}}
+
+	tags, err := s.tags.ListTags()
+	if err != nil {
+		return nil, fmt.Errorf("database error listing tags: %w", err)
+	}
+	for _, t := range tags {
+		archive.Tags = append(archive.Tags, t.Name)
+	}
+
+	var after *pagination.Cursor
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		batch, err := s.modules.ListModulesAfter(module.ModuleFilter{}, after, backupBatchSize, module.WithTags())
+		if err != nil {
+			return nil, fmt.Errorf("database error listing modules: %w", err)
+		}
+
+		for _, entity := range batch {
+			archived, err := s.archiveModule(entity)
+			if err != nil {
+				return nil, err
+			}
+			archive.Modules = append(archive.Modules, archived)
+		}
+
+		if len(batch) < backupBatchSize {
+			break
+		}
+		last := batch[len(batch)-1]
+		after = &pagination.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+
+	// Step 2: Upload the archive
+	encoded, err := json.Marshal(archive)
+	if err != nil {
+		return nil, err
+	}
+	key := KeyPrefix + "modules-" + now.Format("20060102T150405.000Z") + ".json"
+	if err := s.storage.Put(ctx, key, bytes.NewReader(encoded), int64(len(encoded)), "application/json"); err != nil {
+		return nil, fmt.Errorf("upload backup: %w", err)
+	}
+
+	return &backup.BackupResult{
+		Key:       key,
+		Version:   archive.Version,
+		Modules:   len(archive.Modules),
+		Tags:      len(archive.Tags),
+		SizeBytes: int64(len(encoded)),
+		CreatedAt: now,
+	}, nil
+}
+
+// Restore writes the modules of an archive back.
+//
+// Parameters:
+//   - ctx: Context cancelling the restore (checked between modules)
+//   - key: Key of the archive
+//   - conflict: Policy for archived modules whose name is taken (backup.ConflictSkip,
+//     backup.ConflictOverwrite or backup.ConflictFail)
+//   - actor: Who restores, recorded in the change history of written modules
+//
+// Returns:
+//   - *backup.RestoreReport: What was created, overwritten and skipped
+//   - error: Error if the restore fails
+//
+// Error Types:
+//   - ErrInvalidConflictPolicy: When the policy is unknown
+//   - storage.ErrObjectNotFound: When the archive does not exist
+//   - ErrInvalidArchive: When the archive cannot be read
+//   - ErrRestoreConflict: When the policy is fail and names are taken,
+//     returned as *ConflictError before anything is written
+//   - ErrRestoreRunning: When another restore holds the restore lock
+//
+// Restore Behavior:
+//   - Modules are matched by name, case-insensitively
+//   - Names held by modules in the recycle bin are always skipped
+//   - Created and overwritten modules get the archived tags (missing tags are
+//     created) and settings, replacing their current ones
+//   - Modules are written one at a time; when a write fails the modules
+//     before it stay restored, and running the restore again with skip
+//     completes it
+func (s *BackupService) Restore(ctx context.Context, key, conflict, actor string) (*backup.RestoreReport, error) {
+	if conflict != backup.ConflictSkip && conflict != backup.ConflictOverwrite && conflict != backup.ConflictFail {
+		return nil, fmt.Errorf("%w %q", ErrInvalidConflictPolicy, conflict)
+	}
+
+	// Concurrent restores would race on the same names
+	release, err := s.locks.TryLock(ctx, restoreLockName, restoreLockTTL)
+	if errors.Is(err, lock.ErrHeld) {
+		return nil, ErrRestoreRunning
+	}
+	if err != nil {
+		return nil, fmt.Errorf("acquire restore lock: %w", err)
+	}
+	defer release(context.WithoutCancel(ctx))
+
+	start := time.Now()
+	report, err := s.restoreArchive(ctx, key, conflict, actor)
+	outcome := "succeeded"
+	switch {
+	case errors.Is(err, ErrRestoreConflict):
+		outcome = "conflict"
+	case err != nil:
+		outcome = "failed"
+	}
+	s.restoreDuration.Observe(outcome, time.Since(start))
+
+	return report, err
+}
+
+// restoreArchive runs the steps of Restore while the restore lock is held.
+func (s *BackupService) restoreArchive(ctx context.Context, key, conflict, actor string) (*backup.RestoreReport, error) {
+	// Step 1: Load and check the archive
+	archive, err := s.readArchive(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 2: Find modules whose name is taken
+	existing := make([]*module.Module, len(archive.Modules))
+	conflicts := []backup.RestoreConflict{}
+	for i, archived := range archive.Modules {
+		found, err := s.modules.FindModuleByName(archived.Name)
+		if err != nil {
+			return nil, fmt.Errorf("database error checking name: %w", err)
+		}
+		if found == nil {
+			continue
+		}
+		existing[i] = found
+
+		resolution := "skipped"
+		if conflict == backup.ConflictOverwrite && !found.DeletedAt.Valid {
+			resolution = "overwritten"
+		}
+		conflicts = append(conflicts, backup.RestoreConflict{
+			Name:         archived.Name,
+			ExistingID:   found.ID,
+			InRecycleBin: found.DeletedAt.Valid,
+			Resolution:   resolution,
+		})
+	}
+	if conflict == backup.ConflictFail && len(conflicts) > 0 {
+		return nil, &ConflictError{Conflicts: conflicts}
+	}
+
+	// Step 3: Recreate missing tags
+	report := &backup.RestoreReport{Key: key, Version: archive.Version, Conflict: conflict, Conflicts: conflicts}
+	tagIDs, created, err := s.ensureTags(archive)
+	if err != nil {
+		return nil, err
+	}
+	report.TagsCreated = created
+
+	// Step 4: Write the modules with their tags and settings
+	for i, archived := range archive.Modules {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		existingID := 0
+		if found := existing[i]; found != nil {
+			if conflict != backup.ConflictOverwrite || found.DeletedAt.Valid {
+				report.Skipped++
+				continue
+			}
+			existingID = found.ID
+		}
+
+		saved, err := s.importer.ImportModule(&module.Module{
+			Name:         archived.Name,
+			Description:  archived.Description,
+			IsActive:     archived.IsActive,
+			Status:       archived.Status,
+			Owner:        archived.Owner,
+			ActivateAt:   archived.ActivateAt,
+			DeactivateAt: archived.DeactivateAt,
+			CreatedAt:    archived.CreatedAt,
+		}, existingID, actor)
+		if err != nil {
+			return nil, fmt.Errorf("restore module %q: %w", archived.Name, err)
+		}
+		if err := s.restoreAssociations(saved.ID, archived, tagIDs, existingID != 0); err != nil {
+			return nil, fmt.Errorf("restore module %q: %w", archived.Name, err)
+		}
+
+		if existingID != 0 {
+			report.Overwritten++
+		} else {
+			report.Created++
+		}
+	}
+
+	return report, nil
+}
+
+// archiveModule reads the settings of a module, listed with its tags, into its
+// archived state.
+func (s *BackupService) archiveModule(entity *module.Module) (backup.ArchivedModule, error) {
+	archived := backup.ArchivedModule{
+		ID:           entity.ID,
+		Name:         entity.Name,
+		Description:  entity.Description,
+		IsActive:     entity.IsActive,
+		Status:       entity.Status,
+		Owner:        entity.Owner,
+		ActivateAt:   entity.ActivateAt,
+		DeactivateAt: entity.DeactivateAt,
+		CreatedAt:    entity.CreatedAt,
+		UpdatedAt:    entity.UpdatedAt,
+		Tags:         []string{},
+		Settings:     map[string]json.RawMessage{},
+	}
+
+	archived.Tags = append(archived.Tags, entity.Tags...)
+
+	settings, err := s.settings.ListSettings(entity.ID)
+	if err != nil {
+		return archived, fmt.Errorf("database error listing settings: %w", err)
+	}
+	for _, setting := range settings {
+		archived.Settings[setting.Key] = json.RawMessage(setting.Value)
+	}
+	return archived, nil
+}
+
+// readArchive downloads, decodes and checks an archive.
+func (s *BackupService) readArchive(ctx context.Context, key string) (*backup.Archive, error) {
+	body, err := s.storage.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var archive backup.Archive
+	if err := json.NewDecoder(body).Decode(&archive); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+	if archive.Version < 1 || archive.Version > backup.ArchiveVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidArchive, archive.Version)
+	}
+
+	names := make(map[string]bool, len(archive.Modules))
+	for _, archived := range archive.Modules {
+		name := strings.ToLower(archived.Name)
+		if names[name] {
+			return nil, fmt.Errorf("%w: duplicate module name %q", ErrInvalidArchive, archived.Name)
+		}
+		names[name] = true
+	}
+	return &archive, nil
+}
+
+// ensureTags creates the archived tags that do not exist yet.
+//
+// Returns the ID of every archived tag by name and the number of created tags.
+func (s *BackupService) ensureTags(archive *backup.Archive) (map[string]int, int, error) {
+	wanted := append([]string{}, archive.Tags...)
+	for _, archived := range archive.Modules {
+		wanted = append(wanted, archived.Tags...)
+	}
+
+	ids := make(map[string]int, len(wanted))
+	created := 0
+	for _, name := range wanted {
+		if _, done := ids[name]; done {
+			continue
+		}
+
+		found, err := s.tags.FindTagByName(name)
+		if err != nil {
+			return nil, 0, fmt.Errorf("database error loading tag: %w", err)
+		}
+		if found == nil {
+			found, err = s.tags.CreateTag(&tag.Tag{Name: name})
+			if err != nil {
+				return nil, 0, fmt.Errorf("database error creating tag %q: %w", name, err)
+			}
+			created++
+		}
+		ids[name] = found.ID
+	}
+	return ids, created, nil
+}
+
+// restoreAssociations gives a restored module its archived tags and settings.
+//
+// Overwritten modules lose tags that are not in the archive; settings are
+// always replaced as a whole.
+func (s *BackupService) restoreAssociations(moduleID int, archived backup.ArchivedModule, tagIDs map[string]int, overwrite bool) error {
+	// Step 1: Tags
+	keep := make(map[int]bool, len(archived.Tags))
+	for _, name := range archived.Tags {
+		keep[tagIDs[name]] = true
+	}
+	if overwrite {
+		current, err := s.tags.ListModuleTags(moduleID)
+		if err != nil {
+			return fmt.Errorf("database error listing tags: %w", err)
+		}
+		for _, t := range current {
+			if keep[t.ID] {
+				delete(keep, t.ID)
+				continue
+			}
+			if err := s.tags.UnassignTag(moduleID, t.ID); err != nil {
+				return fmt.Errorf("database error unassigning tag: %w", err)
+			}
+		}
+	}
+	for tagID := range keep {
+		if err := s.tags.AssignTag(moduleID, tagID); err != nil {
+			return fmt.Errorf("database error assigning tag: %w", err)
+		}
+	}
+
+	// Step 2: Settings
+	keys := make([]string, 0, len(archived.Settings))
+	for key := range archived.Settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	settings := make([]module.ModuleSetting, len(keys))
+	for i, key := range keys {
+		settings[i] = module.ModuleSetting{ModuleID: moduleID, Key: key, Value: string(archived.Settings[key])}
+	}
+	if err := s.settings.ReplaceSettings(moduleID, settings); err != nil {
+		return fmt.Errorf("database error replacing settings: %w", err)
+	}
+	return nil
+}
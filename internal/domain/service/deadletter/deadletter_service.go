@@ -0,0 +1,137 @@
+package deadletter
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"go_di_architecture/internal/domain/apperror"
+	"go_di_architecture/internal/domain/guard"
+	"go_di_architecture/internal/domain/metrics"
+	"go_di_architecture/internal/domain/models/deadletter"
+	"go_di_architecture/internal/domain/queue"
+)
+
+// defaultLimit is the number of dead letters listed when no limit is given.
+const defaultLimit = 50
+
+// depthTimeout bounds reading the queue depth for the metrics.
+const depthTimeout = 2 * time.Second
+
+// Errors of the dead-letter service
+var (
+	ErrDisabled = apperror.New(http.StatusNotFound, "COMMAND_QUEUE_DISABLED", "deadletter.disabled", "the command queue is not configured")
+	ErrNotFound = apperror.New(http.StatusNotFound, "NOT_FOUND", "deadletter.not_found", "dead letter not found")
+)
+
+// DeadLetterService lets operators inspect and replay the messages the
+// command consumer moved to the dead-letter queue.
+//
+// Replaying puts a message back at the end of the command queue, typically
+// after the cause of its failure was fixed; a command that fails again is
+// dead-lettered again.
+//
+// Metrics:
+//   - command_dead_letters: Depth of the dead-letter queue, read on scrape
+//
+// Usage Example:
+//
+//	service, err := deadletter.NewDeadLetterService(commandQueue, m)
+//	list, err := service.List(ctx, 20)
+//	err = service.Replay(ctx, list.Items[0].ID)
+type DeadLetterService struct {
+	deadLetters queue.DeadLetters
+}
+
+// NewDeadLetterService creates a new instance of DeadLetterService.
+//
+// Parameters:
+//   - deadLetters: Dead-letter queue of the command queue (nil when the
+//     command queue is disabled)
+//   - m: Business metrics exporting the queue depth
+//
+// Returns:
+//   - *DeadLetterService: A new service instance
+//   - error: guard.ErrNilDependency if a required dependency is nil
+func NewDeadLetterService(deadLetters queue.DeadLetters, m metrics.Metrics) (*DeadLetterService, error) {
+	if err := guard.Require("NewDeadLetterService", guard.Dep("m", m)); err != nil {
+		return nil, err
+	}
+
+	s := &DeadLetterService{deadLetters: deadLetters}
+	if deadLetters != nil {
+		m.Gauge("command_dead_letters", func() (map[string]int64, error) {
+			ctx, cancel := context.WithTimeout(context.Background(), depthTimeout)
+			defer cancel()
+			depth, err := deadLetters.Depth(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]int64{"": int64(depth)}, nil
+		})
+	}
+	return s, nil
+}
+
+// List returns the number of dead letters and the oldest of them.
+//
+// Parameters:
+//   - ctx: Context bounding the broker calls
+//   - limit: Most dead letters returned (0: the default of 50)
+//
+// Returns:
+//   - *deadletter.DeadLetterList: Depth and dead letters, oldest first
+//   - error: Error if the queue is disabled or the broker is unreachable
+//
+// Error Types:
+//   - ErrDisabled: When no command queue is configured
+func (s *DeadLetterService) List(ctx context.Context, limit int) (*deadletter.DeadLetterList, error) {
+	if s.deadLetters == nil {
+		return nil, ErrDisabled
+	}
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+
+	depth, err := s.deadLetters.Depth(ctx)
+	if err != nil {
+		return nil, err
+	}
+	letters, err := s.deadLetters.List(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &deadletter.DeadLetterList{Depth: depth, Items: make([]deadletter.DeadLetter, 0, len(letters))}
+	for _, letter := range letters {
+		list.Items = append(list.Items, deadletter.DeadLetter{ID: letter.ID, Body: string(letter.Body)})
+	}
+	return list, nil
+}
+
+// Replay moves a dead letter back to the end of the command queue.
+//
+// Parameters:
+//   - ctx: Context bounding the broker calls
+//   - id: Identifier of the dead letter
+//
+// Returns:
+//   - error: Error if the dead letter does not exist or the broker is
+//     unreachable
+//
+// Error Types:
+//   - ErrDisabled: When no command queue is configured
+//   - ErrNotFound: When no dead letter has the identifier
+func (s *DeadLetterService) Replay(ctx context.Context, id string) error {
+	if s.deadLetters == nil {
+		return ErrDisabled
+	}
+	found, err := s.deadLetters.Replay(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrNotFound
+	}
+	return nil
+}
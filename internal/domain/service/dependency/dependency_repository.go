@@ -0,0 +1,28 @@
+package dependency
+
+import "go_di_architecture/internal/domain/models/module"
+
+// DependencyRepository defines the data operations the dependency service depends on.
+//
+// Implementations live in the infrastructure layer next to the module
+// repositories:
+//   - InMemoryModuleRepository: stores dependency edges alongside modules
+//   - DependencyRepository (GORM): module_dependencies table
+//
+// Implementations must:
+//   - Treat adding an existing edge as a no-op
+//   - Load the edges of many modules with a single query, so graph walks cost
+//     one query per depth level
+type DependencyRepository interface {
+	// AddDependency records that moduleID depends on dependsOnID
+	AddDependency(moduleID, dependsOnID int) error
+
+	// RemoveDependency deletes the edge from moduleID to dependsOnID
+	RemoveDependency(moduleID, dependsOnID int) error
+
+	// FindDependencies returns the outgoing edges of the given modules
+	FindDependencies(moduleIDs []int) ([]module.ModuleDependency, error)
+
+	// FindDependents returns the incoming edges of the given modules
+	FindDependents(moduleIDs []int) ([]module.ModuleDependency, error)
+}
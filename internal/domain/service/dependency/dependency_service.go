@@ -0,0 +1,340 @@
+package dependency
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+
+	"go_di_architecture/internal/domain/apperror"
+	"go_di_architecture/internal/domain/guard"
+	"go_di_architecture/internal/domain/models/module"
+	moduleService "go_di_architecture/internal/domain/service/module"
+)
+
+// Custom error types for dependency business rule violations
+var (
+	ErrSelfDependency  = apperror.Validation("dependencyId", "dependency.self", "a module cannot depend on itself")
+	ErrDependencyCycle = apperror.New(http.StatusConflict, "DEPENDENCY_CYCLE", "dependency.cycle", "dependency would create a cycle")
+)
+
+// CycleError reports the cycle a new dependency would close.
+//
+// The error wraps ErrDependencyCycle, so callers can keep using
+// errors.Is(err, ErrDependencyCycle) and reach for errors.As when they need
+// the offending path.
+type CycleError struct {
+	// Module IDs along the cycle, starting and ending with the declaring module
+	Path []int
+}
+
+// Error returns the message of the wrapped ErrDependencyCycle.
+func (e *CycleError) Error() string {
+	return ErrDependencyCycle.Error()
+}
+
+// Unwrap exposes ErrDependencyCycle to errors.Is.
+func (e *CycleError) Unwrap() error {
+	return ErrDependencyCycle
+}
+
+// Direction selects which edges a graph walk follows.
+type Direction int
+
+const (
+	// Dependencies follows edges to the modules a module depends on
+	Dependencies Direction = iota
+
+	// Dependents follows edges back to the modules depending on a module (impact analysis)
+	Dependents
+)
+
+// ModuleFinder checks and loads modules for a subject.
+//
+// Implemented by the module service; declared here so dependencies follow the
+// same visibility rules as the modules themselves.
+type ModuleFinder interface {
+	ModuleExists(id string, subject module.Subject) (bool, error)
+	GetModulesByIds(ids []int, subject module.Subject) ([]*module.ModuleResponse, []int, error)
+}
+
+// DependencyService implements business operations for dependencies between modules.
+//
+// Business Rule Enforcement:
+//  1. Both modules must exist and be visible to the caller; listings leave
+//     out modules hidden from it
+//  2. A module cannot depend on itself
+//  3. The graph stays acyclic: an edge A -> B is rejected when A is already
+//     reachable from B
+//
+// Concurrency:
+//   - Cycle checks and inserts are serialized within the process, so two
+//     concurrent requests cannot each add half of a cycle
+//   - Multiple API instances sharing a database are not coordinated
+//
+// Usage Example:
+//
+//	service, err := dependency.NewDependencyService(depRepo, moduleService)
+//	_, err := service.AddDependency("1", "2", subject) // module 1 depends on module 2
+//	_, err = service.AddDependency("2", "1", subject)  // rejected: *CycleError{Path: [2 1 2]}
+type DependencyService struct {
+	repo    DependencyRepository
+	modules ModuleFinder
+	mu      sync.Mutex
+}
+
+// NewDependencyService creates a new instance of DependencyService.
+//
+// Parameters:
+//   - repo: Data access repository for dependency edges
+//   - modules: Module lookup verifying and loading visible modules
+//
+// Returns:
+//   - *DependencyService: A new service instance
+//   - error: guard.ErrNilDependency if a required dependency is nil
+func NewDependencyService(repo DependencyRepository, modules ModuleFinder) (*DependencyService, error) {
+	if err := guard.Require("NewDependencyService", guard.Dep("repo", repo), guard.Dep("modules", modules)); err != nil {
+		return nil, err
+	}
+	return &DependencyService{repo: repo, modules: modules}, nil
+}
+
+// AddDependency declares that a module depends on another module.
+//
+// Declaring an existing dependency again is a no-op.
+//
+// Parameters:
+//   - moduleID: Identifier of the dependent module
+//   - dependsOnID: Identifier of the module depended on
+//   - subject: Who declares the dependency; both modules must be visible to it
+//
+// Returns:
+//   - []*module.DependencyResponse: The module's direct dependencies after the change
+//   - error: Error if business rules are violated
+//
+// Error Types:
+//   - moduleService.ErrNotFound: When either module does not exist or is hidden from the subject
+//   - ErrSelfDependency: When both IDs are the same
+//   - ErrDependencyCycle: When the edge would close a cycle, returned as
+//     *CycleError with the cycle path
+func (s *DependencyService) AddDependency(moduleID, dependsOnID string, subject module.Subject) ([]*module.DependencyResponse, error) {
+	// Step 1: Verify both modules exist
+	from, to, err := s.findPair(moduleID, dependsOnID, subject)
+	if err != nil {
+		return nil, err
+	}
+	if from == to {
+		return nil, ErrSelfDependency
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Step 2: Reject edges closing a cycle (from is reachable from to)
+	path, err := s.pathBetween(to, from)
+	if err != nil {
+		return nil, err
+	}
+	if path != nil {
+		return nil, &CycleError{Path: append([]int{from}, path...)}
+	}
+
+	// Step 3: Persist the edge
+	if err := s.repo.AddDependency(from, to); err != nil {
+		return nil, fmt.Errorf("database error adding dependency: %w", err)
+	}
+
+	return s.ListDependencies(moduleID, subject, Dependencies, false)
+}
+
+// RemoveDependency deletes a dependency between two modules.
+//
+// Removing a dependency that does not exist is a no-op.
+//
+// Parameters:
+//   - moduleID: Identifier of the dependent module
+//   - dependsOnID: Identifier of the module depended on
+//   - subject: Who removes the dependency; both modules must be visible to it
+//
+// Returns:
+//   - []*module.DependencyResponse: The module's direct dependencies after the change
+//   - error: moduleService.ErrNotFound if either module does not exist or is hidden from the subject, or a data layer error
+func (s *DependencyService) RemoveDependency(moduleID, dependsOnID string, subject module.Subject) ([]*module.DependencyResponse, error) {
+	from, to, err := s.findPair(moduleID, dependsOnID, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.RemoveDependency(from, to); err != nil {
+		return nil, fmt.Errorf("database error removing dependency: %w", err)
+	}
+
+	return s.ListDependencies(moduleID, subject, Dependencies, false)
+}
+
+// ListDependencies walks the dependency graph from a module.
+//
+// Parameters:
+//   - moduleID: Identifier of the starting module
+//   - subject: Who asks; the starting module must be visible to it
+//   - direction: Dependencies (what the module needs) or Dependents (what it impacts)
+//   - transitive: Follow edges beyond the direct neighbours
+//
+// Returns:
+//   - []*module.DependencyResponse: Reached modules visible to the subject,
+//     ordered by depth, then ID
+//   - error: moduleService.ErrNotFound if the module does not exist or is hidden from the subject, or a data layer error
+//
+// Walk Behavior:
+//   - Breadth-first: each module is reported once, at its shortest depth
+//   - One edge query per depth level plus one query loading all reached modules
+//   - Hidden modules are walked through but not reported
+func (s *DependencyService) ListDependencies(moduleID string, subject module.Subject, direction Direction, transitive bool) ([]*module.DependencyResponse, error) {
+	// Step 1: Verify the starting module exists
+	start, err := s.findModule(moduleID, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 2: Walk the graph level by level
+	depths := map[int]int{start: 0}
+	var order []int
+	frontier := []int{start}
+	for depth := 1; len(frontier) > 0; depth++ {
+		edges, err := s.edges(frontier, direction)
+		if err != nil {
+			return nil, err
+		}
+
+		var next []int
+		for _, edge := range edges {
+			neighbour := edge.DependsOnID
+			if direction == Dependents {
+				neighbour = edge.ModuleID
+			}
+			if _, seen := depths[neighbour]; seen {
+				continue
+			}
+			depths[neighbour] = depth
+			order = append(order, neighbour)
+			next = append(next, neighbour)
+		}
+
+		if !transitive {
+			break
+		}
+		frontier = next
+	}
+
+	// Step 3: Load every reached module visible to the subject at once
+	found, _, err := s.modules.GetModulesByIds(order, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*module.DependencyResponse, len(found))
+	for i, reached := range found {
+		responses[i] = &module.DependencyResponse{ModuleResponse: reached, Depth: depths[reached.ID]}
+	}
+
+	// Breadth-first order is already by depth; make ties deterministic
+	sortByDepthAndID(responses)
+	return responses, nil
+}
+
+// pathBetween finds a dependency path from one module to another.
+//
+// Returns:
+//   - []int: Module IDs from "from" to "to" inclusive, or nil when unreachable
+//   - error: Error if the data layer fails
+func (s *DependencyService) pathBetween(from, to int) ([]int, error) {
+	parents := map[int]int{from: from}
+	frontier := []int{from}
+
+	for len(frontier) > 0 {
+		edges, err := s.edges(frontier, Dependencies)
+		if err != nil {
+			return nil, err
+		}
+
+		var next []int
+		for _, edge := range edges {
+			if _, seen := parents[edge.DependsOnID]; seen {
+				continue
+			}
+			parents[edge.DependsOnID] = edge.ModuleID
+
+			if edge.DependsOnID == to {
+				// Rebuild the path by following parents back to the start
+				path := []int{to}
+				for current := to; current != from; {
+					current = parents[current]
+					path = append([]int{current}, path...)
+				}
+				return path, nil
+			}
+			next = append(next, edge.DependsOnID)
+		}
+		frontier = next
+	}
+
+	return nil, nil
+}
+
+// edges loads the edges of a set of modules in the given direction.
+func (s *DependencyService) edges(moduleIDs []int, direction Direction) ([]module.ModuleDependency, error) {
+	var edges []module.ModuleDependency
+	var err error
+	if direction == Dependents {
+		edges, err = s.repo.FindDependents(moduleIDs)
+	} else {
+		edges, err = s.repo.FindDependencies(moduleIDs)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("database error loading dependencies: %w", err)
+	}
+	return edges, nil
+}
+
+// findModule parses a module ID and verifies the module exists and is
+// visible to the subject.
+func (s *DependencyService) findModule(moduleID string, subject module.Subject) (int, error) {
+	id, err := strconv.Atoi(moduleID)
+	if err != nil {
+		return 0, moduleService.ErrNotFound.Wrap(err)
+	}
+
+	exists, err := s.modules.ModuleExists(moduleID, subject)
+	if err != nil {
+		return 0, err
+	}
+	if !exists {
+		return 0, moduleService.ErrNotFound
+	}
+	return id, nil
+}
+
+// findPair parses two module IDs and verifies both modules exist and are
+// visible to the subject.
+func (s *DependencyService) findPair(moduleID, dependsOnID string, subject module.Subject) (int, int, error) {
+	from, err := s.findModule(moduleID, subject)
+	if err != nil {
+		return 0, 0, err
+	}
+	to, err := s.findModule(dependsOnID, subject)
+	if err != nil {
+		return 0, 0, err
+	}
+	return from, to, nil
+}
+
+// sortByDepthAndID orders walk results by depth, then module ID.
+func sortByDepthAndID(responses []*module.DependencyResponse) {
+	sort.Slice(responses, func(i, j int) bool {
+		if responses[i].Depth != responses[j].Depth {
+			return responses[i].Depth < responses[j].Depth
+		}
+		return responses[i].ID < responses[j].ID
+	})
+}
@@ -0,0 +1,250 @@
+package export
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"go_di_architecture/internal/domain/guard"
+	"go_di_architecture/internal/domain/models/export"
+	"go_di_architecture/internal/domain/models/module"
+	"go_di_architecture/internal/domain/models/pagination"
+	"go_di_architecture/internal/domain/storage"
+)
+
+// Supported export formats
+const (
+	// FormatCSV writes RFC 4180 comma-separated values with a header row
+	FormatCSV = "csv"
+
+	// FormatXLSX writes an Office Open XML workbook with a single sheet
+	FormatXLSX = "xlsx"
+)
+
+// DefaultURLTTL is how long download links stay valid when no TTL is configured.
+const DefaultURLTTL = 15 * time.Minute
+
+// JobKind identifies module export jobs in the jobs API.
+const JobKind = "module.export"
+
+// ErrInvalidFormat is returned for export formats other than csv and xlsx.
+var ErrInvalidFormat = errors.New("unsupported export format")
+
+// columns lists the exported module fields in column order.
+var columns = []string{"id", "name", "description", "isActive", "owner", "activateAt", "deactivateAt", "createdAt", "updatedAt"}
+
+// contentTypes maps each format to the MIME type stored with the object.
+var contentTypes = map[string]string{
+	FormatCSV:  "text/csv; charset=utf-8",
+	FormatXLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// ModuleSource streams the modules visible to a subject.
+//
+// Implemented by the module service; declared here so the export service
+// does not depend on the whole module service API.
+type ModuleSource interface {
+	StreamModules(after *pagination.Cursor, subject module.Subject, visit func(m *module.ModuleResponse) error) error
+}
+
+// ExportService writes module exports to object storage.
+//
+// Exports are meant to run as background jobs: the rows are spooled to a
+// temporary file so the upload knows its size, the file is uploaded through
+// the Storage interface and a pre-signed download URL is returned. Clients
+// never hold a request open while the export is produced.
+//
+// Usage Example:
+//
+//	service, err := export.NewExportService(moduleService, store, 15*time.Minute)
+//	result, err := service.Export(ctx, export.FormatCSV, subject)
+type ExportService struct {
+	modules ModuleSource
+	storage storage.Storage
+	urlTTL  time.Duration
+}
+
+// NewExportService creates a new instance of ExportService.
+//
+// Parameters:
+//   - modules: Source of the exported modules
+//   - store: Object storage receiving the export files
+//   - urlTTL: How long download links stay valid (DefaultURLTTL when zero)
+//
+// Returns:
+//   - *ExportService: A new service instance
+//   - error: guard.ErrNilDependency if a required dependency is nil
+func NewExportService(modules ModuleSource, store storage.Storage, urlTTL time.Duration) (*ExportService, error) {
+	if err := guard.Require("NewExportService", guard.Dep("modules", modules), guard.Dep("store", store)); err != nil {
+		return nil, err
+	}
+
+	if urlTTL <= 0 {
+		urlTTL = DefaultURLTTL
+	}
+	return &ExportService{modules: modules, storage: store, urlTTL: urlTTL}, nil
+}
+
+// IsFormat reports whether the export format is supported.
+func IsFormat(format string) bool {
+	_, ok := contentTypes[format]
+	return ok
+}
+
+// Export writes every module visible to the subject and uploads the file.
+//
+// Parameters:
+//   - ctx: Context cancelling the export (checked between rows and bounding the upload)
+//   - format: FormatCSV or FormatXLSX
+//   - subject: Who requested the export; modules hidden by their ACL are left out
+//
+// Returns:
+//   - *export.ExportResult: Row count, size and download link of the uploaded file
+//   - error: ErrInvalidFormat, or an error from the data layer, the temporary
+//     file or the storage
+func (s *ExportService) Export(ctx context.Context, format string, subject module.Subject) (*export.ExportResult, error) {
+	if !IsFormat(format) {
+		return nil, fmt.Errorf("%w %q", ErrInvalidFormat, format)
+	}
+
+	// Step 1: Spool the rows to a temporary file
+	spool, err := os.CreateTemp("", "module-export-*."+format)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		spool.Close()
+		os.Remove(spool.Name())
+	}()
+
+	rows, err := s.write(ctx, spool, format, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 2: Upload the file
+	size, err := spool.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	key, err := objectKey(now, format)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.storage.Put(ctx, key, spool, size, contentTypes[format]); err != nil {
+		return nil, fmt.Errorf("upload export: %w", err)
+	}
+
+	// Step 3: Hand out a download link
+	url, err := s.storage.PresignGet(key, s.urlTTL)
+	if err != nil {
+		return nil, fmt.Errorf("presign export: %w", err)
+	}
+
+	return &export.ExportResult{
+		Format:      format,
+		Rows:        rows,
+		SizeBytes:   size,
+		DownloadURL: url,
+		ExpiresAt:   now.Add(s.urlTTL),
+	}, nil
+}
+
+// rowWriter receives the header and data rows of an export.
+type rowWriter interface {
+	WriteRow(values []string) error
+	Close() error
+}
+
+// write streams the modules into w and returns the number of data rows.
+func (s *ExportService) write(ctx context.Context, w io.Writer, format string, subject module.Subject) (int, error) {
+	var out rowWriter
+	if format == FormatXLSX {
+		out = newXLSXWriter(w)
+	} else {
+		out = newCSVWriter(w)
+	}
+
+	if err := out.WriteRow(columns); err != nil {
+		return 0, err
+	}
+
+	rows := 0
+	err := s.modules.StreamModules(nil, subject, func(m *module.ModuleResponse) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		rows++
+		return out.WriteRow(moduleRow(m))
+	})
+	if err != nil {
+		// Closing releases the temporary files of the XLSX stream writer
+		out.Close()
+		return 0, err
+	}
+	return rows, out.Close()
+}
+
+// moduleRow formats a module in column order; timestamps are RFC 3339 in UTC.
+func moduleRow(m *module.ModuleResponse) []string {
+	return []string{
+		strconv.Itoa(m.ID),
+		m.Name,
+		m.Description,
+		strconv.FormatBool(m.IsActive),
+		m.Owner,
+		formatOptionalTime(m.ActivateAt),
+		formatOptionalTime(m.DeactivateAt),
+		m.CreatedAt.UTC().Format(time.RFC3339),
+		m.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// formatOptionalTime formats a timestamp, leaving the cell empty when unset.
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// objectKey builds a unique, unguessable storage key for an export.
+func objectKey(now time.Time, format string) (string, error) {
+	suffix := make([]byte, 8)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("exports/modules-%s-%s.%s", now.Format("20060102T150405Z"), hex.EncodeToString(suffix), format), nil
+}
+
+// csvWriter adapts encoding/csv to rowWriter.
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+// WriteRow writes one record.
+func (c *csvWriter) WriteRow(values []string) error {
+	return c.w.Write(values)
+}
+
+// Close flushes the buffered records.
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
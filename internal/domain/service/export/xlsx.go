@@ -0,0 +1,70 @@
+package export
+
+import (
+	"io"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// xlsxSheet is the name of the only sheet of an export workbook.
+const xlsxSheet = "Modules"
+
+// xlsxWriter streams rows into a single-sheet XLSX workbook.
+//
+// Rows go through an excelize stream writer, which spills them to a
+// temporary file once the sheet grows large instead of holding every cell in
+// memory. The workbook is written to the output when it is closed. Cells
+// are strings; spreadsheet applications still parse numbers and dates on
+// demand.
+type xlsxWriter struct {
+	out    io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+	err    error
+}
+
+// newXLSXWriter creates the workbook and opens its sheet.
+func newXLSXWriter(w io.Writer) *xlsxWriter {
+	x := &xlsxWriter{out: w, file: excelize.NewFile()}
+	if x.err = x.file.SetSheetName(x.file.GetSheetName(0), xlsxSheet); x.err != nil {
+		return x
+	}
+	x.stream, x.err = x.file.NewStreamWriter(xlsxSheet)
+	return x
+}
+
+// WriteRow appends one row to the sheet.
+func (x *xlsxWriter) WriteRow(values []string) error {
+	if x.err != nil {
+		return x.err
+	}
+
+	x.row++
+	cell, err := excelize.CoordinatesToCellName(1, x.row)
+	if err != nil {
+		x.err = err
+		return err
+	}
+	cells := make([]any, len(values))
+	for i, value := range values {
+		cells[i] = value
+	}
+	x.err = x.stream.SetRow(cell, cells)
+	return x.err
+}
+
+// Close finishes the sheet, writes the workbook and removes the temporary
+// files of the stream writer.
+func (x *xlsxWriter) Close() error {
+	defer x.file.Close()
+
+	if x.err != nil {
+		return x.err
+	}
+	if err := x.stream.Flush(); err != nil {
+		return err
+	}
+	_, err := x.file.WriteTo(x.out)
+	return err
+}
@@ -0,0 +1,50 @@
+package export
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestXLSXWriter(t *testing.T) {
+	rows := [][]string{
+		columns,
+		{"1", "payments", "Fees & <refunds>", "true", "alice", "", "", "2026-01-02T03:04:05Z", "2026-01-02T03:04:05Z"},
+		{"2", "  padded  ", "line one\nline two", "false", "bob", "", "", "2026-01-03T00:00:00Z", "2026-01-03T00:00:00Z"},
+	}
+
+	var out bytes.Buffer
+	writer := newXLSXWriter(&out)
+	for _, row := range rows {
+		if err := writer.WriteRow(row); err != nil {
+			t.Fatalf("WriteRow() error = %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	workbook, err := excelize.OpenReader(&out)
+	if err != nil {
+		t.Fatalf("OpenReader() error = %v", err)
+	}
+	defer workbook.Close()
+
+	if sheets := workbook.GetSheetList(); !slices.Equal(sheets, []string{xlsxSheet}) {
+		t.Errorf("sheets = %v, want [%s]", sheets, xlsxSheet)
+	}
+	got, err := workbook.GetRows(xlsxSheet, excelize.Options{RawCellValue: true})
+	if err != nil {
+		t.Fatalf("GetRows() error = %v", err)
+	}
+	if len(got) != len(rows) {
+		t.Fatalf("read %d rows, want %d", len(got), len(rows))
+	}
+	for i, row := range rows {
+		if !slices.Equal(got[i], row) {
+			t.Errorf("row %d = %q, want %q", i, got[i], row)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package module
+
+import "go_di_architecture/internal/domain/models/module"
+
+// ACLRepository defines the data operations for per-module access control lists.
+//
+// Implementations live in the infrastructure layer next to the module
+// repositories. Listing entries of many modules at once lets list endpoints
+// check access with a single query.
+type ACLRepository interface {
+	// ListACLEntries returns the ACL entries of the listed modules ordered by
+	// module ID and principal; modules without an ACL contribute no entries
+	ListACLEntries(moduleIDs []int) ([]module.ModuleACLEntry, error)
+
+	// ReplaceACL replaces all ACL entries of a module atomically
+	ReplaceACL(moduleID int, entries []module.ModuleACLEntry) error
+}
@@ -0,0 +1,252 @@
+package module
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"go_di_architecture/internal/domain/models/module"
+)
+
+// GetModuleACL returns the access control list of a module.
+//
+// Parameters:
+//   - id: Unique identifier of the module
+//   - subject: Who asks; must be allowed to view the module
+//
+// Returns:
+//   - *module.ModuleACLResponse: The entries ordered by principal
+//   - error: ErrNotFound if the module does not exist or is hidden from the
+//     subject, or a data layer error
+func (s *ModuleService) GetModuleACL(id string, subject module.Subject) (*module.ModuleACLResponse, error) {
+	// Step 1: Load the module and check access
+	existing, err := s.loadModule(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorize(existing.ID, subject, module.PermissionView); err != nil {
+		return nil, err
+	}
+
+	// Step 2: Load the entries
+	entries, err := s.acl.ListACLEntries([]int{existing.ID})
+	if err != nil {
+		return nil, fmt.Errorf("database error loading ACL: %w", err)
+	}
+	return toACLResponse(existing.ID, entries), nil
+}
+
+// ReplaceModuleACL replaces the access control list of a module.
+//
+// Parameters:
+//   - id: Unique identifier of the module
+//   - request: The complete new list (empty opens the module to everyone)
+//   - subject: Who changes the ACL; must be allowed to edit the module
+//
+// Returns:
+//   - *module.ModuleACLResponse: The stored ACL
+//   - error: Error if the change is not allowed
+//
+// Error Types:
+//   - ErrNotFound: When the module does not exist or is hidden from the subject
+//   - ErrForbidden: When the subject may view but not edit the module
+//   - ErrInvalidACL: When a principal is malformed or listed twice, or the new
+//     list would take edit access away from the subject
+//
+// Lockout Protection:
+//   - A non-empty list always grants edit to the module owner: an entry for
+//     the owner is added or upgraded, so no editor can lock the owner out
+//   - A non-empty list must grant edit to one of the subject's principals, so
+//     nobody can lock themselves out of a module by accident; the owner and
+//     admins keep their access anyway
+func (s *ModuleService) ReplaceModuleACL(id string, request module.ModuleACLRequest, subject module.Subject) (*module.ModuleACLResponse, error) {
+	// Step 1: Load the module and check access
+	existing, err := s.loadModule(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorize(existing.ID, subject, module.PermissionEdit); err != nil {
+		return nil, err
+	}
+
+	// Step 2: Validate the new entries
+	if err := validateACL(request.Entries, subject, existing.Owner); err != nil {
+		return nil, err
+	}
+
+	// Step 3: Store them with edit for the owner
+	now := time.Now()
+	entries := make([]module.ModuleACLEntry, len(request.Entries))
+	for i, entry := range request.Entries {
+		entries[i] = module.ModuleACLEntry{
+			ModuleID:   existing.ID,
+			Principal:  entry.Principal,
+			Permission: entry.Permission,
+			CreatedAt:  now,
+		}
+	}
+	entries = withOwnerEdit(entries, existing.ID, existing.Owner, now)
+	if err := s.acl.ReplaceACL(existing.ID, entries); err != nil {
+		return nil, fmt.Errorf("database error replacing ACL: %w", err)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Principal < entries[j].Principal
+	})
+	return toACLResponse(existing.ID, entries), nil
+}
+
+// authorize checks that the subject holds a permission on a module.
+//
+// Parameters:
+//   - moduleID: Identifier of the module
+//   - subject: Who performs the request
+//   - required: module.PermissionView or module.PermissionEdit
+//
+// Returns:
+//   - error: ErrNotFound when the subject may not even view the module (its
+//     existence is not revealed), ErrForbidden when it may view but not edit,
+//     or a data layer error
+func (s *ModuleService) authorize(moduleID int, subject module.Subject, required string) error {
+	granted, err := s.permissions([]int{moduleID}, subject)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case granted[moduleID] == "":
+		return ErrNotFound
+	case required == module.PermissionEdit && granted[moduleID] != module.PermissionEdit:
+		return ErrForbidden
+	}
+	return nil
+}
+
+// permissions computes the subject's strongest permission on each module.
+//
+// All modules are checked with one data layer call. Modules without an ACL
+// grant edit to everyone, and admins may edit every module.
+//
+// Parameters:
+//   - moduleIDs: Identifiers of the modules to check
+//   - subject: Who performs the request
+//
+// Returns:
+//   - map[int]string: Permission per module ID ("" when access is denied)
+//   - error: Error if the data layer fails
+func (s *ModuleService) permissions(moduleIDs []int, subject module.Subject) (map[int]string, error) {
+	entries, err := s.acl.ListACLEntries(moduleIDs)
+	if err != nil {
+		return nil, fmt.Errorf("database error loading ACL: %w", err)
+	}
+	return grantedPermissions(moduleIDs, entries, subject), nil
+}
+
+// grantedPermissions computes the subject's strongest permission on each
+// module from the ACL entries of the modules, e.g. those eager-loaded with a
+// module list.
+func grantedPermissions(moduleIDs []int, entries []module.ModuleACLEntry, subject module.Subject) map[int]string {
+	// Step 1: Everything is open unless it has an ACL, or to admins
+	granted := make(map[int]string, len(moduleIDs))
+	for _, id := range moduleIDs {
+		granted[id] = module.PermissionEdit
+	}
+	if subject.Admin {
+		return granted
+	}
+	for _, entry := range entries {
+		granted[entry.ModuleID] = ""
+	}
+
+	// Step 2: Apply the entries matching the subject
+	principals := make(map[string]bool)
+	for _, principal := range subject.Principals() {
+		principals[principal] = true
+	}
+	for _, entry := range entries {
+		if principals[entry.Principal] && granted[entry.ModuleID] != module.PermissionEdit {
+			granted[entry.ModuleID] = entry.Permission
+		}
+	}
+	return granted
+}
+
+// visibleTo returns the principals whose modules the subject may list, or nil
+// for admins, who may list every module.
+func visibleTo(subject module.Subject) []string {
+	if subject.Admin {
+		return nil
+	}
+	return subject.Principals()
+}
+
+// withOwnerEdit adds an edit entry for the owner to non-empty ACL entries, or
+// upgrades an existing entry for the owner to edit.
+func withOwnerEdit(entries []module.ModuleACLEntry, moduleID int, owner string, now time.Time) []module.ModuleACLEntry {
+	if len(entries) == 0 || owner == "" {
+		return entries
+	}
+
+	principal := module.PrincipalUserPrefix + owner
+	for i := range entries {
+		if entries[i].Principal == principal {
+			entries[i].Permission = module.PermissionEdit
+			return entries
+		}
+	}
+	return append(entries, module.ModuleACLEntry{
+		ModuleID:   moduleID,
+		Principal:  principal,
+		Permission: module.PermissionEdit,
+		CreatedAt:  now,
+	})
+}
+
+// validateACL checks a new ACL for malformed and duplicate principals and
+// lockouts of the subject; the owner and admins cannot lock themselves out.
+func validateACL(entries []module.ACLEntryRequest, subject module.Subject, owner string) error {
+	if len(entries) > module.MaxACLEntries {
+		return ErrInvalidACL.Detailf("at most %d entries are allowed", module.MaxACLEntries)
+	}
+
+	seen := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		if !module.IsValidPrincipal(entry.Principal) {
+			return ErrInvalidACL.Detailf("principal %q must look like user:<name> or team:<name>", entry.Principal)
+		}
+		if entry.Permission != module.PermissionView && entry.Permission != module.PermissionEdit {
+			return ErrInvalidACL.Detailf("permission of %q must be view or edit", entry.Principal)
+		}
+		if seen[entry.Principal] {
+			return ErrInvalidACL.Detailf("principal %q is listed twice", entry.Principal)
+		}
+		seen[entry.Principal] = true
+	}
+
+	if len(entries) == 0 || subject.Admin || (owner != "" && subject.User == owner) {
+		return nil
+	}
+	principals := make(map[string]bool)
+	for _, principal := range subject.Principals() {
+		principals[principal] = true
+	}
+	for _, entry := range entries {
+		if entry.Permission == module.PermissionEdit && principals[entry.Principal] {
+			return nil
+		}
+	}
+	return ErrInvalidACL.Detailf("the list must grant edit to you or one of your teams")
+}
+
+// toACLResponse maps stored entries to the ACL response.
+func toACLResponse(moduleID int, entries []module.ModuleACLEntry) *module.ModuleACLResponse {
+	items := make([]module.ACLEntryRequest, len(entries))
+	for i, entry := range entries {
+		items[i] = module.ACLEntryRequest{Principal: entry.Principal, Permission: entry.Permission}
+	}
+	return &module.ModuleACLResponse{
+		ModuleID:   moduleID,
+		Restricted: len(items) > 0,
+		Entries:    items,
+	}
+}
@@ -0,0 +1,99 @@
+package module_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"go_di_architecture/internal/domain/events"
+	"go_di_architecture/internal/domain/metrics"
+	"go_di_architecture/internal/domain/models/module"
+	moduleService "go_di_architecture/internal/domain/service/module"
+	"go_di_architecture/internal/infra/db"
+	repository "go_di_architecture/internal/infra/db/module"
+)
+
+// newACLTestService builds a module service on the in-memory store with a
+// module owned by alice.
+func newACLTestService(t *testing.T) (*moduleService.ModuleService, string) {
+	t.Helper()
+	store := repository.NewInMemoryModuleRepository(db.AutoIncrement{})
+	service, err := moduleService.NewModuleService(store, store, store, store, store, events.NewBus(), metrics.Discard)
+	if err != nil {
+		t.Fatalf("NewModuleService() error = %v", err)
+	}
+	created, err := service.CreateModule(module.ModuleRequest{Name: "payments", Description: "Payment flows"}, "alice", false)
+	if err != nil {
+		t.Fatalf("CreateModule() error = %v", err)
+	}
+	return service, strconv.Itoa(created.ID)
+}
+
+func TestReplaceModuleACLKeepsOwnerEdit(t *testing.T) {
+	service, id := newACLTestService(t)
+	alice := module.Subject{User: "alice"}
+	bob := module.Subject{User: "bob", Teams: []string{"core"}}
+
+	_, err := service.ReplaceModuleACL(id, module.ModuleACLRequest{Entries: []module.ACLEntryRequest{
+		{Principal: "team:core", Permission: module.PermissionEdit},
+	}}, alice)
+	if err != nil {
+		t.Fatalf("ReplaceModuleACL(alice) error = %v", err)
+	}
+
+	// An editor leaving the owner out of the list cannot lock the owner out
+	acl, err := service.ReplaceModuleACL(id, module.ModuleACLRequest{Entries: []module.ACLEntryRequest{
+		{Principal: "user:bob", Permission: module.PermissionEdit},
+		{Principal: "user:alice", Permission: module.PermissionView},
+	}}, bob)
+	if err != nil {
+		t.Fatalf("ReplaceModuleACL(bob) error = %v", err)
+	}
+	want := []module.ACLEntryRequest{
+		{Principal: "user:alice", Permission: module.PermissionEdit},
+		{Principal: "user:bob", Permission: module.PermissionEdit},
+	}
+	if len(acl.Entries) != len(want) || acl.Entries[0] != want[0] || acl.Entries[1] != want[1] {
+		t.Errorf("ACL entries = %v, want %v", acl.Entries, want)
+	}
+
+	// The owner may still edit the module and its ACL
+	if _, err := service.ReplaceModuleACL(id, module.ModuleACLRequest{Entries: []module.ACLEntryRequest{
+		{Principal: "team:core", Permission: module.PermissionView},
+	}}, alice); err != nil {
+		t.Errorf("ReplaceModuleACL(owner) error = %v, want the owner to keep ACL edit", err)
+	}
+	if _, err := service.GetModuleById(id, alice); err != nil {
+		t.Errorf("GetModuleById(owner) error = %v", err)
+	}
+}
+
+func TestAdminOverridesModuleACL(t *testing.T) {
+	service, id := newACLTestService(t)
+
+	_, err := service.ReplaceModuleACL(id, module.ModuleACLRequest{Entries: []module.ACLEntryRequest{
+		{Principal: "user:alice", Permission: module.PermissionEdit},
+	}}, module.Subject{User: "alice"})
+	if err != nil {
+		t.Fatalf("ReplaceModuleACL(alice) error = %v", err)
+	}
+
+	if _, err := service.GetModuleById(id, module.Subject{User: "mallory"}); !errors.Is(err, moduleService.ErrNotFound) {
+		t.Errorf("GetModuleById(stranger) error = %v, want ErrNotFound", err)
+	}
+
+	admin := module.Subject{User: "ops", Admin: true}
+	if _, err := service.GetModuleById(id, admin); err != nil {
+		t.Errorf("GetModuleById(admin) error = %v", err)
+	}
+	page, err := service.ListModules(module.ModuleFilter{}, admin, 1, 20)
+	if err != nil || page.TotalItems != 1 {
+		t.Errorf("ListModules(admin) = %v, %v; want the restricted module", page, err)
+	}
+	// Admins need not grant themselves edit to change the list
+	if _, err := service.ReplaceModuleACL(id, module.ModuleACLRequest{Entries: []module.ACLEntryRequest{
+		{Principal: "team:core", Permission: module.PermissionView},
+	}}, admin); err != nil {
+		t.Errorf("ReplaceModuleACL(admin) error = %v", err)
+	}
+}
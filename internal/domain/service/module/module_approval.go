@@ -0,0 +1,254 @@
+package module
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go_di_architecture/internal/domain/events"
+	"go_di_architecture/internal/domain/models/module"
+)
+
+// SubmitModule submits a draft for approval.
+//
+// Parameters:
+//   - id: Unique identifier of the module
+//   - subject: Who submits the module; must be allowed to edit it
+//
+// Returns:
+//   - *module.ModuleResponse: The module, now pending
+//   - error: Error if the module cannot be submitted
+//
+// Error Types:
+//   - ErrNotFound: When the module does not exist or is hidden from the subject
+//   - ErrForbidden: When the subject may view but not edit the module
+//   - ErrInvalidTransition: When the module is not a draft
+//   - ErrReviewDescription: When the module has no description
+//   - ErrNameRequired, ErrNameLength, ErrDescriptionLength, ErrScheduleWindow:
+//     When the module violates rules introduced after it was written
+//
+// Submit Behavior:
+//   - The module is locked against updates and reverts until it is approved
+//     or rejected
+//   - The change is recorded as a "submit" revision and announced through a
+//     module.submitted event, e.g. to notify the approvers
+func (s *ModuleService) SubmitModule(id string, subject module.Subject) (*module.ModuleResponse, error) {
+	// Step 1: Load the module and check access
+	existing, err := s.loadModule(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorize(existing.ID, subject, module.PermissionEdit); err != nil {
+		return nil, err
+	}
+
+	// Step 2: Check the module is a complete draft
+	if existing.Status != module.StatusDraft {
+		return nil, ErrInvalidTransition.Detailf("module is %s; only drafts can be submitted", existing.Status)
+	}
+	err = validateModuleRequest(module.ModuleRequest{
+		Name:         existing.Name,
+		Description:  existing.Description,
+		ActivateAt:   existing.ActivateAt,
+		DeactivateAt: existing.DeactivateAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(existing.Description) == "" {
+		return nil, ErrReviewDescription
+	}
+
+	// Step 3: Move it to pending and announce it
+	updated := *existing
+	updated.Status = module.StatusPending
+	saved, err := s.transition(existing, &updated, module.RevisionSubmit, subject.User)
+	if err != nil {
+		return nil, err
+	}
+
+	s.events.Publish(events.Event{
+		Type:       events.ModuleSubmitted,
+		ModuleID:   saved.ID,
+		Actor:      subject.User,
+		OccurredAt: saved.UpdatedAt,
+	})
+	return ToModuleResponse(saved), nil
+}
+
+// ApproveModule approves a submitted module and activates it.
+//
+// Parameters:
+//   - id: Unique identifier of the module
+//   - request: Optional comment for the owner
+//   - subject: The approver; must be allowed to view the module (the route
+//     requires the approval scope)
+//
+// Returns:
+//   - *module.ModuleResponse: The approved module
+//   - error: Error if the module cannot be approved
+//
+// Error Types:
+//   - ErrNotFound: When the module does not exist or is hidden from the subject
+//   - ErrInvalidTransition: When the module is not pending
+//
+// Approve Behavior:
+//   - A module without activateAt is activated right away; otherwise the
+//     scheduler activates it at that time
+//   - The change is recorded as an "approve" revision
+//   - The owner is notified through a module.approved event carrying the
+//     comment, followed by module.activated when the module was activated
+func (s *ModuleService) ApproveModule(id string, request module.ReviewRequest, subject module.Subject) (*module.ModuleResponse, error) {
+	// Step 1: Load the pending module
+	existing, err := s.loadReviewed(id, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 2: Approve it, activating it unless a schedule does
+	updated := *existing
+	updated.Status = module.StatusApproved
+	if updated.ActivateAt == nil {
+		updated.IsActive = true
+	}
+	saved, err := s.transition(existing, &updated, module.RevisionApprove, subject.User)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 3: Notify the owner
+	s.events.Publish(events.Event{
+		Type:       events.ModuleApproved,
+		ModuleID:   saved.ID,
+		Actor:      subject.User,
+		Recipient:  saved.Owner,
+		Comment:    strings.TrimSpace(request.Comment),
+		OccurredAt: saved.UpdatedAt,
+	})
+	if saved.IsActive && !existing.IsActive {
+		s.events.Publish(events.Event{
+			Type:       events.ModuleActivated,
+			ModuleID:   saved.ID,
+			Actor:      subject.User,
+			OccurredAt: saved.UpdatedAt,
+		})
+	}
+	return ToModuleResponse(saved), nil
+}
+
+// RejectModule returns a submitted module to draft.
+//
+// Parameters:
+//   - id: Unique identifier of the module
+//   - request: Optional comment for the owner, e.g. what to change
+//   - subject: The approver; must be allowed to view the module (the route
+//     requires the approval scope)
+//
+// Returns:
+//   - *module.ModuleResponse: The module, a draft again
+//   - error: Error if the module cannot be rejected
+//
+// Error Types:
+//   - ErrNotFound: When the module does not exist or is hidden from the subject
+//   - ErrInvalidTransition: When the module is not pending
+//
+// Reject Behavior:
+//   - The owner can change the draft and submit it again
+//   - The change is recorded as a "reject" revision and the owner is notified
+//     through a module.rejected event carrying the comment
+func (s *ModuleService) RejectModule(id string, request module.ReviewRequest, subject module.Subject) (*module.ModuleResponse, error) {
+	// Step 1: Load the pending module
+	existing, err := s.loadReviewed(id, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 2: Return it to draft
+	updated := *existing
+	updated.Status = module.StatusDraft
+	saved, err := s.transition(existing, &updated, module.RevisionReject, subject.User)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 3: Notify the owner
+	s.events.Publish(events.Event{
+		Type:       events.ModuleRejected,
+		ModuleID:   saved.ID,
+		Actor:      subject.User,
+		Recipient:  saved.Owner,
+		Comment:    strings.TrimSpace(request.Comment),
+		OccurredAt: saved.UpdatedAt,
+	})
+	return ToModuleResponse(saved), nil
+}
+
+// loadReviewed loads a pending module for an approver.
+//
+// Parameters:
+//   - id: Unique identifier of the module
+//   - subject: The approver
+//
+// Returns:
+//   - *module.Module: The pending module
+//   - error: ErrNotFound, ErrInvalidTransition or a data layer error
+func (s *ModuleService) loadReviewed(id string, subject module.Subject) (*module.Module, error) {
+	existing, err := s.loadModule(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorize(existing.ID, subject, module.PermissionView); err != nil {
+		return nil, err
+	}
+	if existing.Status != module.StatusPending {
+		return nil, ErrInvalidTransition.Detailf("module is %s; only pending modules can be reviewed", existing.Status)
+	}
+	return existing, nil
+}
+
+// transition writes a new approval state and records the revision.
+//
+// Parameters:
+//   - existing: Current state of the module (not modified)
+//   - updated: State after the transition
+//   - action: Revision action to record
+//   - actor: Who makes the change
+//
+// Returns:
+//   - *module.Module: The saved module
+//   - error: Error if the change cannot be stored
+func (s *ModuleService) transition(existing, updated *module.Module, action, actor string) (*module.Module, error) {
+	updated.UpdatedAt = time.Now()
+	var saved *module.Module
+	err := s.withTx(func(repo ModuleRepository, revisions RevisionRepository) error {
+		var err error
+		if saved, err = repo.UpdateModule(updated); err != nil {
+			return fmt.Errorf("database error updating module: %w", err)
+		}
+		return recordRevision(revisions, saved, action, actor, diffModules(existing, saved))
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.stats.invalidate()
+	return saved, nil
+}
+
+// checkEditable enforces the approval rules of a change requested by a user.
+//
+// Parameters:
+//   - existing: Current state of the module
+//   - moduleDto: Requested field values
+//
+// Returns:
+//   - error: ErrUnderReview while the module is pending, ErrNotApproved when
+//     a module that is not approved would be active; nil when allowed
+func checkEditable(existing *module.Module, moduleDto module.ModuleRequest) error {
+	if existing.Status == module.StatusPending {
+		return ErrUnderReview
+	}
+	if moduleDto.IsActive && existing.Status != module.StatusApproved {
+		return ErrNotApproved
+	}
+	return nil
+}
@@ -0,0 +1,238 @@
+package module
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"go_di_architecture/internal/domain/models/module"
+	"go_di_architecture/internal/domain/models/pagination"
+)
+
+// GetModuleHistory returns one page of a module's change history.
+//
+// Parameters:
+//   - id: Unique identifier of the module
+//   - subject: Who asks; must be allowed to view the module
+//   - filter: Criteria narrowing the listed changes (zero value lists all)
+//   - page: 1-based page number
+//   - pageSize: Number of changes per page (1-100)
+//
+// Returns:
+//   - *pagination.Page[*module.ModuleChangeResponse]: Changes in chronological order with total count
+//   - error: ErrNotFound if the module does not exist or is hidden from the
+//     subject, or a data layer error
+//
+// History Behavior:
+//   - Every create and update writes one revision with its field-level diff
+//   - Updates that change nothing write no revision
+//   - Modules created before history tracking start with a "baseline" revision
+func (s *ModuleService) GetModuleHistory(id string, subject module.Subject, filter module.RevisionFilter, page, pageSize int) (*pagination.Page[*module.ModuleChangeResponse], error) {
+	// Step 1: Verify the module exists
+	moduleID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, ErrNotFound.Wrap(err)
+	}
+
+	exists, err := s.repo.ModuleExists(moduleID)
+	if err != nil {
+		return nil, fmt.Errorf("database error checking module: %w", err)
+	}
+	if !exists {
+		return nil, ErrNotFound
+	}
+	if err := s.authorize(moduleID, subject, module.PermissionView); err != nil {
+		return nil, err
+	}
+
+	// Step 2: Load the requested page of revisions
+	revisions, total, err := s.revisions.ListRevisions(moduleID, filter, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("database error listing revisions: %w", err)
+	}
+
+	// Step 3: Decode the stored diffs
+	changes := make([]*module.ModuleChangeResponse, len(revisions))
+	for i, revision := range revisions {
+		fields := []module.FieldChange{}
+		if err := json.Unmarshal([]byte(revision.Changes), &fields); err != nil {
+			return nil, fmt.Errorf("corrupt changes in revision %d: %w", revision.Revision, err)
+		}
+
+		changes[i] = &module.ModuleChangeResponse{
+			Revision:  revision.Revision,
+			Action:    revision.Action,
+			Actor:     revision.Actor,
+			ChangedAt: revision.CreatedAt,
+			Changes:   fields,
+		}
+	}
+
+	return &pagination.Page[*module.ModuleChangeResponse]{
+		Items:      changes,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalItems: total,
+	}, nil
+}
+
+// RevertModule restores a module to the state of an earlier revision.
+//
+// Parameters:
+//   - id: Unique identifier of the module
+//   - revision: Number of the revision to restore
+//   - subject: Who reverts the module; must be allowed to edit it and is
+//     recorded in the change history
+//
+// Returns:
+//   - *module.ModuleResponse: The module after the revert
+//   - error: Error if the revert is not possible
+//
+// Error Types:
+//   - ErrNotFound: When the module does not exist or is hidden from the subject
+//   - ErrForbidden: When the subject may view but not edit the module
+//   - ErrRevisionNotFound: When the module has no such revision
+//   - ErrNameExists: When another module took the old name in the meantime,
+//     returned as *NameConflictError
+//   - ErrNameRequired, ErrNameLength, ErrDescriptionLength: When the old state
+//     violates rules introduced after it was written
+//   - ErrUnderReview: When the module is pending approval
+//   - ErrNotApproved: When the old state is active and the module is not approved
+//
+// Revert Behavior:
+//   - History is never rewritten; the restored state is appended as a new
+//     "revert" revision whose diff is relative to the current state
+//   - Reverting to a state equal to the current one writes nothing
+//   - The activation schedule and approval state are not part of revisions
+//     and are kept as is
+func (s *ModuleService) RevertModule(id string, revision int, subject module.Subject) (*module.ModuleResponse, error) {
+	// Step 1: Load the current state and check access
+	existing, err := s.loadModule(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorize(existing.ID, subject, module.PermissionEdit); err != nil {
+		return nil, err
+	}
+
+	// Step 2: Load the revision to restore
+	target, err := s.revisions.GetRevision(existing.ID, revision)
+	if err != nil {
+		return nil, fmt.Errorf("database error loading revision: %w", err)
+	}
+	if target == nil {
+		return nil, ErrRevisionNotFound
+	}
+
+	// Step 3: Re-validate the old state against today's business rules
+	restored := module.ModuleRequest{
+		Name:         target.Name,
+		Description:  target.Description,
+		IsActive:     target.IsActive,
+		ActivateAt:   existing.ActivateAt,
+		DeactivateAt: existing.DeactivateAt,
+	}
+	if err := validateModuleRequest(restored); err != nil {
+		return nil, err
+	}
+	if err := checkEditable(existing, restored); err != nil {
+		return nil, err
+	}
+
+	// Step 4: Write it as a new revision
+	return s.applyUpdate(existing, restored, module.RevisionRevert, subject.User, false)
+}
+
+// recordRevision appends a revision with the module state after a change.
+//
+// Parameters:
+//   - revisions: Repository to append with, writing in the change's transaction
+//   - entity: The module after the change
+//   - action: Kind of change (module.RevisionCreate or module.RevisionUpdate)
+//   - actor: Who made the change
+//   - changes: Field-level diff against the previous state
+//
+// Returns:
+//   - error: Error if the revision cannot be stored
+func recordRevision(revisions RevisionRepository, entity *module.Module, action, actor string, changes []module.FieldChange) error {
+	encoded, err := json.Marshal(changes)
+	if err != nil {
+		return fmt.Errorf("encoding changes: %w", err)
+	}
+
+	err = revisions.AppendRevision(&module.ModuleRevision{
+		ModuleID:    entity.ID,
+		Action:      action,
+		Actor:       actor,
+		Name:        entity.Name,
+		Description: entity.Description,
+		IsActive:    entity.IsActive,
+		Changes:     string(encoded),
+		CreatedAt:   entity.UpdatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("database error recording revision: %w", err)
+	}
+	return nil
+}
+
+// diffModules lists the fields that differ between two module states.
+//
+// Parameters:
+//   - before: State before the change (nil for a newly created module)
+//   - after: State after the change
+//
+// Returns:
+//   - []module.FieldChange: Changed fields in declaration order; empty when nothing changed
+func diffModules(before, after *module.Module) []module.FieldChange {
+	changes := []module.FieldChange{}
+
+	if before == nil {
+		changes = append(changes,
+			module.FieldChange{Field: "name", New: after.Name},
+			module.FieldChange{Field: "description", New: after.Description},
+			module.FieldChange{Field: "isActive", New: after.IsActive},
+			module.FieldChange{Field: "status", New: after.Status},
+		)
+		if after.ActivateAt != nil {
+			changes = append(changes, module.FieldChange{Field: "activateAt", New: after.ActivateAt})
+		}
+		if after.DeactivateAt != nil {
+			changes = append(changes, module.FieldChange{Field: "deactivateAt", New: after.DeactivateAt})
+		}
+		changes = append(changes, module.FieldChange{Field: "owner", New: after.Owner})
+		return changes
+	}
+
+	if before.Name != after.Name {
+		changes = append(changes, module.FieldChange{Field: "name", Old: before.Name, New: after.Name})
+	}
+	if before.Description != after.Description {
+		changes = append(changes, module.FieldChange{Field: "description", Old: before.Description, New: after.Description})
+	}
+	if before.IsActive != after.IsActive {
+		changes = append(changes, module.FieldChange{Field: "isActive", Old: before.IsActive, New: after.IsActive})
+	}
+	if before.Status != after.Status {
+		changes = append(changes, module.FieldChange{Field: "status", Old: before.Status, New: after.Status})
+	}
+	if !sameTime(before.ActivateAt, after.ActivateAt) {
+		changes = append(changes, module.FieldChange{Field: "activateAt", Old: before.ActivateAt, New: after.ActivateAt})
+	}
+	if !sameTime(before.DeactivateAt, after.DeactivateAt) {
+		changes = append(changes, module.FieldChange{Field: "deactivateAt", Old: before.DeactivateAt, New: after.DeactivateAt})
+	}
+	if before.Owner != after.Owner {
+		changes = append(changes, module.FieldChange{Field: "owner", Old: before.Owner, New: after.Owner})
+	}
+	return changes
+}
+
+// sameTime reports whether two optional times are both unset or equal.
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
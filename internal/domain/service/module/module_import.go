@@ -0,0 +1,114 @@
+package module
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"go_di_architecture/internal/domain/models/module"
+)
+
+// ImportModule writes a module state read from a backup archive.
+//
+// Unlike CreateModule and UpdateModule the state is taken as it was backed
+// up: owner, active flag, approval state and schedule come from the archive,
+// and a created module keeps its original creation time. Archives written
+// before the approval workflow carry no state; their modules are approved. No access check is made since
+// restores are run by operators.
+//
+// Parameters:
+//   - state: Fields to write; ID, UpdatedAt and the recycle bin fields are ignored
+//   - existingID: Module to overwrite, or 0 to create a new module
+//   - actor: Who restores the backup, recorded in the change history
+//
+// Returns:
+//   - *module.Module: The written module (the existing one unchanged when
+//     the state matches it)
+//   - error: Error if the state is invalid or cannot be written
+//
+// Error Types:
+//   - ErrNameRequired, ErrNameLength, ErrDescriptionLength, ErrScheduleWindow: As for CreateModule
+//   - ErrNameExists: When another module uses the name (case-insensitive)
+//   - ErrNotFound: When the module to overwrite does not exist or is in the recycle bin
+//
+// Import Behavior:
+//   - The change is recorded as an "import" revision
+//   - No events are published, so restoring a backup sends no notifications
+func (s *ModuleService) ImportModule(state *module.Module, existingID int, actor string) (*module.Module, error) {
+	// Step 1: Validate the state like a regular request
+	err := validateModuleRequest(module.ModuleRequest{
+		Name:         state.Name,
+		Description:  state.Description,
+		IsActive:     state.IsActive,
+		ActivateAt:   state.ActivateAt,
+		DeactivateAt: state.DeactivateAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 2: Load the module to overwrite, if any
+	var existing *module.Module
+	if existingID != 0 {
+		if existing, err = s.loadModule(strconv.Itoa(existingID)); err != nil {
+			return nil, err
+		}
+	}
+	exists, err := s.repo.IsModuleNameExists(state.Name, existingID)
+	if err != nil {
+		return nil, fmt.Errorf("database error checking name: %w", err)
+	}
+	if exists {
+		return nil, fmt.Errorf("%w: %q", ErrNameExists, state.Name)
+	}
+
+	// Step 3: Build the new state, skipping overwrites that change nothing
+	now := time.Now()
+	entity := &module.Module{CreatedAt: state.CreatedAt}
+	if existing != nil {
+		copied := *existing
+		entity = &copied
+	} else if entity.CreatedAt.IsZero() {
+		entity.CreatedAt = now
+	}
+	entity.Name = state.Name
+	entity.Description = state.Description
+	entity.IsActive = state.IsActive
+	entity.Status = state.Status
+	if entity.Status == "" {
+		entity.Status = module.StatusApproved
+	}
+	entity.ActivateAt = state.ActivateAt
+	entity.DeactivateAt = state.DeactivateAt
+	entity.Owner = state.Owner
+
+	changes := diffModules(existing, entity)
+	if existing != nil && len(changes) == 0 {
+		return existing, nil
+	}
+	entity.UpdatedAt = now
+
+	// Step 4: Persist with the revision (the unique index catches concurrent duplicates)
+	var saved *module.Module
+	err = s.withTx(func(repo ModuleRepository, revisions RevisionRepository) error {
+		var err error
+		if existing != nil {
+			saved, err = repo.UpdateModule(entity)
+		} else {
+			saved, err = repo.CreateModule(entity)
+		}
+		if errors.Is(err, ErrNameExists) {
+			return fmt.Errorf("%w: %q", ErrNameExists, state.Name)
+		}
+		if err != nil {
+			return fmt.Errorf("database error importing module: %w", err)
+		}
+		return recordRevision(revisions, saved, module.RevisionImport, actor, changes)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.stats.invalidate()
+	return saved, nil
+}
@@ -0,0 +1,194 @@
+package module_test
+
+import (
+	"encoding/json"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/quick"
+	"time"
+	"unicode/utf8"
+
+	"go_di_architecture/internal/domain/events"
+	"go_di_architecture/internal/domain/metrics"
+	"go_di_architecture/internal/domain/models/module"
+	moduleService "go_di_architecture/internal/domain/service/module"
+	"go_di_architecture/internal/infra/db"
+	repository "go_di_architecture/internal/infra/db/module"
+
+	"github.com/gin-gonic/gin/binding"
+)
+
+// propertyRuns is how many inputs each property is checked with.
+const propertyRuns = 500
+
+// nameRunes are the characters generated names and descriptions are made of:
+// ASCII, accented and CJK letters, an emoji taking four bytes, punctuation
+// and whitespace.
+var nameRunes = []rune("abcxyzABCXYZ0129 -_.'éüßçñ日本語한🙂\t")
+
+// randomText returns a string of the given number of characters.
+func randomText(r *rand.Rand, length int) string {
+	var text strings.Builder
+	for range length {
+		text.WriteRune(nameRunes[r.Intn(len(nameRunes))])
+	}
+	return text.String()
+}
+
+// anyRequest is a module payload whose name and description have any length
+// around the limits, including blank names.
+type anyRequest struct {
+	module.ModuleRequest
+}
+
+// Generate implements quick.Generator.
+func (anyRequest) Generate(r *rand.Rand, _ int) reflect.Value {
+	name := randomText(r, r.Intn(60))
+	if r.Intn(10) == 0 {
+		name = strings.Repeat(" ", r.Intn(60))
+	}
+	request := module.ModuleRequest{Name: name, Description: randomText(r, r.Intn(220))}
+	return reflect.ValueOf(anyRequest{request})
+}
+
+// validRequest is a module payload keeping every documented rule, with an
+// optional activation schedule.
+type validRequest struct {
+	module.ModuleRequest
+}
+
+// Generate implements quick.Generator.
+func (validRequest) Generate(r *rand.Rand, _ int) reflect.Value {
+	// A leading letter keeps the name from being blank
+	name := "m" + randomText(r, 2+r.Intn(48))
+	request := module.ModuleRequest{Name: name, Description: randomText(r, r.Intn(201))}
+	if r.Intn(2) == 0 {
+		activateAt := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(r.Int63n(int64(365 * 24 * time.Hour))))
+		deactivateAt := activateAt.Add(time.Duration(1 + r.Int63n(int64(30*24*time.Hour))))
+		request.ActivateAt, request.DeactivateAt = &activateAt, &deactivateAt
+	}
+	return reflect.ValueOf(validRequest{request})
+}
+
+// documentedValid reports whether a payload keeps the documented rules: a
+// name of 3-50 characters that is not only whitespace and a description of
+// at most 200 characters.
+func documentedValid(request module.ModuleRequest) bool {
+	return strings.TrimSpace(request.Name) != "" && bindingValid(request)
+}
+
+// bindingValid reports whether a payload passes the binding rules of the
+// request, which leave blank names to the service.
+func bindingValid(request module.ModuleRequest) bool {
+	nameLength := utf8.RuneCountInString(request.Name)
+	return nameLength >= 3 && nameLength <= 50 && utf8.RuneCountInString(request.Description) <= 200
+}
+
+func newPropertyTestService(t *testing.T) *moduleService.ModuleService {
+	t.Helper()
+	store := repository.NewInMemoryModuleRepository(db.AutoIncrement{})
+	service, err := moduleService.NewModuleService(store, store, store, store, store, events.NewBus(), metrics.Discard)
+	if err != nil {
+		t.Fatalf("NewModuleService() error = %v", err)
+	}
+	return service
+}
+
+// TestModuleValidationMatchesDocumentedRules checks the service accepts
+// exactly the documented payloads, and the request binding never rejects one
+// of them; the binding only lets blank names through to the service.
+func TestModuleValidationMatchesDocumentedRules(t *testing.T) {
+	service := newPropertyTestService(t)
+
+	property := func(input anyRequest) bool {
+		request := input.ModuleRequest
+		want := documentedValid(request)
+
+		_, serviceErr := service.CreateModule(request, "alice", true)
+		if (serviceErr == nil) != want {
+			t.Logf("service: name %q (%d characters), description of %d characters: error = %v, want valid = %v",
+				request.Name, utf8.RuneCountInString(request.Name), utf8.RuneCountInString(request.Description), serviceErr, want)
+			return false
+		}
+
+		bindingErr := binding.Validator.ValidateStruct(&request)
+		if (bindingErr == nil) != bindingValid(request) {
+			t.Logf("binding: name %q (%d characters), description of %d characters: error = %v, want valid = %v",
+				request.Name, utf8.RuneCountInString(request.Name), utf8.RuneCountInString(request.Description), bindingErr, bindingValid(request))
+			return false
+		}
+		return true
+	}
+	if err := quick.Check(property, &quick.Config{MaxCount: propertyRuns}); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestModuleRoundTrip checks a valid payload comes back unchanged from
+// creation, from a lookup and from the JSON encoding of the response.
+func TestModuleRoundTrip(t *testing.T) {
+	alice := module.Subject{User: "alice"}
+
+	property := func(input validRequest) bool {
+		request := input.ModuleRequest
+		service := newPropertyTestService(t)
+
+		created, err := service.CreateModule(request, "alice", false)
+		if err != nil {
+			t.Logf("CreateModule(%q) error = %v", request.Name, err)
+			return false
+		}
+		if !keepsRequest(created, request) || created.Owner != "alice" || created.Status != module.StatusDraft || created.IsActive {
+			t.Logf("CreateModule(%+v) = %+v", request, created)
+			return false
+		}
+
+		found, err := service.GetModuleById(strconv.Itoa(created.ID), alice)
+		if err != nil || !sameResponse(found, created) {
+			t.Logf("GetModuleById(%d) = %+v, %v, want %+v", created.ID, found, err, created)
+			return false
+		}
+
+		encoded, err := json.Marshal(created)
+		if err != nil {
+			t.Logf("json.Marshal() error = %v", err)
+			return false
+		}
+		var decoded module.ModuleResponse
+		if err := json.Unmarshal(encoded, &decoded); err != nil || !sameResponse(&decoded, created) {
+			t.Logf("decoded %s = %+v, %v, want %+v", encoded, decoded, err, created)
+			return false
+		}
+		return true
+	}
+	if err := quick.Check(property, &quick.Config{MaxCount: propertyRuns}); err != nil {
+		t.Error(err)
+	}
+}
+
+// keepsRequest reports whether a response carries the fields of the payload.
+func keepsRequest(response *module.ModuleResponse, request module.ModuleRequest) bool {
+	return response.Name == request.Name &&
+		response.Description == request.Description &&
+		sameTime(response.ActivateAt, request.ActivateAt) &&
+		sameTime(response.DeactivateAt, request.DeactivateAt)
+}
+
+// sameResponse compares two responses, times by instant.
+func sameResponse(a, b *module.ModuleResponse) bool {
+	return a != nil && b != nil &&
+		a.ID == b.ID && a.Name == b.Name && a.Description == b.Description &&
+		a.IsActive == b.IsActive && a.Status == b.Status && a.Owner == b.Owner &&
+		sameTime(a.ActivateAt, b.ActivateAt) && sameTime(a.DeactivateAt, b.DeactivateAt) &&
+		a.CreatedAt.Equal(b.CreatedAt) && a.UpdatedAt.Equal(b.UpdatedAt)
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
@@ -0,0 +1,76 @@
+package module
+
+import (
+	"fmt"
+
+	"go_di_architecture/internal/domain/models/module"
+	"go_di_architecture/internal/domain/models/pagination"
+)
+
+// ListPublicModules returns one page of the modules published on the public API.
+//
+// Parameters:
+//   - page: 1-based page number
+//   - pageSize: Number of modules per page (1-100)
+//
+// Returns:
+//   - *pagination.Page[*module.PublicModuleResponse]: Modules with total count
+//   - error: Error if the data layer fails
+//
+// Publication Rules:
+//   - Only active modules are published
+//   - Modules with an ACL are never published, whatever its entries
+//   - Modules are ordered like ListModules, by creation time, then ID
+func (s *ModuleService) ListPublicModules(page, pageSize int) (*pagination.Page[*module.PublicModuleResponse], error) {
+	// An anonymous subject matches no ACL entry, so restricted modules are left out
+	filter := module.ModuleFilter{ActiveOnly: true, VisibleTo: module.Subject{}.Principals()}
+	entities, total, err := s.repo.ListModules(filter, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("database error listing modules: %w", err)
+	}
+
+	items := make([]*module.PublicModuleResponse, len(entities))
+	for i, entity := range entities {
+		items[i] = toPublicModuleResponse(entity)
+	}
+	return &pagination.Page[*module.PublicModuleResponse]{
+		Items:      items,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalItems: total,
+	}, nil
+}
+
+// GetPublicModule returns a module published on the public API.
+//
+// Parameters:
+//   - id: Unique identifier of the module
+//
+// Returns:
+//   - *module.PublicModuleResponse: The published fields of the module
+//   - error: ErrNotFound when the module does not exist or is not published
+//     (see ListPublicModules), or a data layer error
+func (s *ModuleService) GetPublicModule(id string) (*module.PublicModuleResponse, error) {
+	entity, err := s.loadModule(id)
+	if err != nil {
+		return nil, err
+	}
+	if !entity.IsActive {
+		return nil, ErrNotFound
+	}
+	if err := s.authorize(entity.ID, module.Subject{}, module.PermissionView); err != nil {
+		return nil, err
+	}
+
+	return toPublicModuleResponse(entity), nil
+}
+
+// toPublicModuleResponse maps a module entity to its public DTO.
+func toPublicModuleResponse(entity *module.Module) *module.PublicModuleResponse {
+	return &module.PublicModuleResponse{
+		ID:          entity.ID,
+		Name:        entity.Name,
+		Description: entity.Description,
+		UpdatedAt:   entity.UpdatedAt,
+	}
+}
@@ -0,0 +1,118 @@
+package module
+
+import (
+	"context"
+	"time"
+
+	"go_di_architecture/internal/domain/models/module"
+	"go_di_architecture/internal/domain/models/pagination"
+)
+
+// ModuleRepository defines the data operations the module service depends on.
+//
+// The interface is owned by the domain layer so the service never depends on a
+// concrete storage technology. Implementations live in the infrastructure layer:
+//   - InMemoryModuleRepository: map-backed store for development and demos
+//   - ModuleRepository (GORM): SQL store for PostgreSQL, MySQL and SQLite
+//
+// Implementations must:
+//   - Treat names as case-insensitive for lookups and uniqueness checks
+//   - Return (nil, nil) when a single entity is not found
+//   - Return errors only for infrastructure failures or malformed input
+//   - Hide soft-deleted modules from every method except the name lookups
+//     (IsModuleNameExists, FindModuleByName, FindModuleNamesByPrefix): names of
+//     modules in the recycle bin stay reserved until they are purged
+type ModuleRepository interface {
+	// CreateModule persists a new module and returns it with generated values;
+	// a storage-level name collision is reported as an error wrapping ErrNameExists
+	CreateModule(m *module.Module) (*module.Module, error)
+
+	// UpdateModule persists the name, description, active flag, approval
+	// state, activation schedule and owner of an existing module; a
+	// storage-level name collision is reported as an error wrapping ErrNameExists
+	UpdateModule(m *module.Module) (*module.Module, error)
+
+	// IsModuleNameExists reports whether another module already uses the name
+	IsModuleNameExists(name string, excludeId int) (bool, error)
+
+	// GetModuleById returns the module with the given ID, or nil if not found
+	GetModuleById(id string) (*module.Module, error)
+
+	// ModuleExists reports whether a module with the ID exists without loading it
+	ModuleExists(id int) (bool, error)
+
+	// CountModules counts modules, optionally only those with the given active
+	// flag. The aggregates below and CountModules only include modules visible
+	// to visibleTo like ModuleFilter.VisibleTo (nil includes every module)
+	CountModules(isActive *bool, visibleTo []string) (int64, error)
+
+	// CountModulesByStatus counts active and inactive modules in one aggregate query
+	CountModulesByStatus(visibleTo []string) (active int64, inactive int64, err error)
+
+	// CountModulesCreatedPerDay counts modules created since the given time,
+	// keyed by UTC day (YYYY-MM-DD); days without creations are omitted
+	CountModulesCreatedPerDay(since time.Time, visibleTo []string) (map[string]int64, error)
+
+	// FindLeastRecentlyUpdated returns up to limit modules ordered by (updatedAt, id)
+	FindLeastRecentlyUpdated(limit int, visibleTo []string) ([]*module.Module, error)
+
+	// GetModulesByIds returns the modules whose IDs are in the list ordered by
+	// ID; unknown IDs are skipped
+	GetModulesByIds(ids []int) ([]*module.Module, error)
+
+	// FindModuleByName returns the module using the name, or nil if not found
+	FindModuleByName(name string) (*module.Module, error)
+
+	// FindModuleNamesByPrefix returns the names of all modules starting with the prefix, sorted
+	FindModuleNamesByPrefix(prefix string) ([]string, error)
+
+	// ListModules returns one page of matching modules ordered by (createdAt, id)
+	// plus the total number of matching modules; options load relations of
+	// the page (module.WithTags, module.WithPermissions)
+	ListModules(filter module.ModuleFilter, offset, limit int, opts ...module.ListOption) ([]*module.Module, int64, error)
+
+	// SoftDeleteModule moves a module to the recycle bin; it reports false when
+	// the module does not exist or is already deleted
+	SoftDeleteModule(id int, deletedBy string, at time.Time) (bool, error)
+
+	// ListDeletedModules returns one page of soft-deleted modules, most recently
+	// deleted first, plus the total number of soft-deleted modules
+	ListDeletedModules(offset, limit int) ([]*module.Module, int64, error)
+
+	// RestoreModules takes the listed modules out of the recycle bin and returns
+	// the IDs that were restored; IDs not in the recycle bin are skipped
+	RestoreModules(ids []int, at time.Time) ([]int, error)
+
+	// PurgeModules permanently removes the listed soft-deleted modules with their
+	// tags, dependencies, settings, history, notes, stars, ACLs and ownership
+	// transfers, and returns the purged IDs; IDs not in the recycle bin are skipped
+	PurgeModules(ids []int) ([]int, error)
+
+	// FindDueScheduledModules returns up to limit modules whose deactivateAt,
+	// or activateAt if they are approved, is at or before now, ordered by ID
+	FindDueScheduledModules(now time.Time, limit int) ([]*module.Module, error)
+
+	// ListModulesAfter returns up to limit matching modules strictly after the
+	// cursor in (createdAt, id) order; a nil cursor starts from the beginning.
+	// Options load relations like for ListModules
+	ListModulesAfter(filter module.ModuleFilter, after *pagination.Cursor, limit int, opts ...module.ListOption) ([]*module.Module, error)
+}
+
+// ContextRepository is implemented by module repositories that can run their
+// statements with a request's context, e.g. so per-request query counting and
+// tracing see them. Repositories without statements (the in-memory store)
+// need not implement it.
+type ContextRepository interface {
+	// WithContext returns a repository running its statements with ctx
+	WithContext(ctx context.Context) ModuleRepository
+}
+
+// Transactor is implemented by module repositories that can write a module
+// change and the revision recording it in one transaction, so a failing
+// revision does not leave a change without history. Repositories that do not
+// implement it have both written one after the other.
+type Transactor interface {
+	// WithTx runs fn with module and revision repositories writing in one
+	// transaction, committed when fn returns nil and rolled back otherwise
+	WithTx(fn func(repo ModuleRepository, revisions RevisionRepository) error) error
+}
@@ -0,0 +1,98 @@
+package module
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go_di_architecture/internal/domain/models/module"
+)
+
+// SchedulerActor is the actor recorded for changes applied by the scheduler.
+const SchedulerActor = "scheduler"
+
+// scheduleBatchSize is the number of due modules processed per repository call.
+const scheduleBatchSize = 100
+
+// ApplySchedules activates and deactivates modules whose scheduled time has come.
+//
+// Parameters:
+//   - ctx: Context cancelling the run between modules
+//   - now: Reference time; schedule times at or before it are due
+//
+// Returns:
+//   - int: Number of modules changed
+//   - error: Error if the data layer fails or ctx is cancelled
+//
+// Transition Rules:
+//   - A due activateAt sets isActive to true and is cleared
+//   - A due deactivateAt sets isActive to false and is cleared
+//   - When both are due the later one wins (e.g. a missed window ends inactive)
+//   - activateAt only takes effect on approved modules; on drafts and pending
+//     modules it waits for the approval
+//   - Each change is recorded as a "schedule" revision by the "scheduler" actor
+//     and publishes module.activated or module.deactivated when the flag flips
+func (s *ModuleService) ApplySchedules(ctx context.Context, now time.Time) (int, error) {
+	changed := 0
+	for {
+		// Step 1: Load a batch of due modules; applied times are cleared, so
+		// the next query returns the remaining ones
+		due, err := s.repo.FindDueScheduledModules(now, scheduleBatchSize)
+		if err != nil {
+			return changed, fmt.Errorf("database error loading scheduled modules: %w", err)
+		}
+
+		// Step 2: Apply the transitions
+		for _, entity := range due {
+			if err := ctx.Err(); err != nil {
+				return changed, err
+			}
+			if _, err := s.applyUpdate(entity, scheduledState(entity, now), module.RevisionSchedule, SchedulerActor, false); err != nil {
+				return changed, fmt.Errorf("module %d: %w", entity.ID, err)
+			}
+			changed++
+		}
+
+		if len(due) < scheduleBatchSize {
+			return changed, nil
+		}
+	}
+}
+
+// scheduledState computes the module fields after applying its due schedule times.
+//
+// Parameters:
+//   - entity: Current state of the module
+//   - now: Reference time
+//
+// Returns:
+//   - module.ModuleRequest: The new field values with due times cleared
+func scheduledState(entity *module.Module, now time.Time) module.ModuleRequest {
+	state := module.ModuleRequest{
+		Name:         entity.Name,
+		Description:  entity.Description,
+		IsActive:     entity.IsActive,
+		ActivateAt:   entity.ActivateAt,
+		DeactivateAt: entity.DeactivateAt,
+	}
+
+	activateDue := entity.Status == module.StatusApproved && state.ActivateAt != nil && !state.ActivateAt.After(now)
+	deactivateDue := state.DeactivateAt != nil && !state.DeactivateAt.After(now)
+
+	switch {
+	case activateDue && deactivateDue:
+		state.IsActive = state.ActivateAt.After(*state.DeactivateAt)
+	case activateDue:
+		state.IsActive = true
+	case deactivateDue:
+		state.IsActive = false
+	}
+
+	if activateDue {
+		state.ActivateAt = nil
+	}
+	if deactivateDue {
+		state.DeactivateAt = nil
+	}
+	return state
+}
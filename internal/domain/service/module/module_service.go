@@ -1,34 +1,97 @@
 package module
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
+	"go_di_architecture/internal/domain/apperror"
+	"go_di_architecture/internal/domain/events"
+	"go_di_architecture/internal/domain/guard"
+	"go_di_architecture/internal/domain/metrics"
 	"go_di_architecture/internal/domain/models/module"
-	repository "go_di_architecture/internal/infra/db/module"
+	"go_di_architecture/internal/domain/models/pagination"
 )
 
 // Custom error types for business rule violations
 var (
-	ErrNameRequired      = errors.New("module name is required")
-	ErrNameLength        = errors.New("name must be 3-50 characters")
-	ErrNameExists        = errors.New("module name already exists")
-	ErrDescriptionLength = errors.New("description exceeds 200 characters")
-	ErrNotFound          = errors.New("module not found")
+	ErrNameRequired      = apperror.Validation("name", "module.name_required", "module name is required")
+	ErrNameLength        = apperror.Validation("name", "module.name_length", "name must be 3-50 characters")
+	ErrNameExists        = apperror.New(http.StatusConflict, "RESOURCE_CONFLICT", "module.name_exists", "module name already exists")
+	ErrDescriptionLength = apperror.Validation("description", "module.description_length", "description exceeds 200 characters")
+	ErrNotFound          = apperror.New(http.StatusNotFound, "NOT_FOUND", "module.not_found", "module not found")
+	ErrRevisionNotFound  = apperror.New(http.StatusNotFound, "NOT_FOUND", "module.revision_not_found", "module revision not found")
+	ErrScheduleWindow    = apperror.Validation("deactivateAt", "module.schedule_window", "deactivateAt must be after activateAt")
+	ErrForbidden         = apperror.New(http.StatusForbidden, "FORBIDDEN", "module.forbidden", "permission denied")
+	ErrInvalidACL        = apperror.Validation("entries", "module.invalid_acl", "invalid access control list")
+	ErrInvalidTransfer   = apperror.Validation("newOwner", "module.invalid_transfer", "invalid ownership transfer")
+	ErrTransferNotFound  = apperror.New(http.StatusNotFound, "NOT_FOUND", "module.transfer_not_found", "ownership transfer not found")
+	ErrTransferPending   = apperror.New(http.StatusConflict, "TRANSFER_PENDING", "module.transfer_pending", "module already has a pending ownership transfer")
+	ErrTransferClosed    = apperror.New(http.StatusConflict, "TRANSFER_CLOSED", "module.transfer_closed", "ownership transfer is no longer pending")
+	ErrNotApproved       = apperror.Validation("isActive", "module.not_approved", "module must be approved before it can be active")
+	ErrUnderReview       = apperror.New(http.StatusConflict, "MODULE_UNDER_REVIEW", "module.under_review", "module is pending approval and cannot be changed")
+	ErrInvalidTransition = apperror.New(http.StatusConflict, "INVALID_STATUS_TRANSITION", "module.invalid_transition", "module is not in a state allowing this transition")
+	ErrReviewDescription = apperror.Validation("description", "module.review_description", "module needs a description before it is submitted for approval")
+	ErrUserRequired      = apperror.New(http.StatusUnauthorized, "UNAUTHORIZED", "module.user_required", "an identified user is required")
 )
 
+// MaxBatchIds is the largest number of IDs accepted by a single batch lookup.
+const MaxBatchIds = 100
+
+// Name suggestion limits used when a module name is already taken
+const (
+	maxNameSuggestions   = 3
+	maxSuggestionSuffix  = 99
+	maxModuleNameLength  = 50
+	suggestionSuffixSize = len("-99")
+)
+
+// NameConflictError reports a module name collision with recovery hints.
+//
+// The error wraps ErrNameExists, so callers can keep using
+// errors.Is(err, ErrNameExists) and only reach for errors.As when they need
+// the extra context.
+//
+// Example:
+//
+//	var conflict *NameConflictError
+//	if errors.As(err, &conflict) {
+//	    log.Printf("name taken by module %d, try %v", conflict.ConflictingID, conflict.Suggestions)
+//	}
+type NameConflictError struct {
+	// ID of the existing module using the requested name
+	ConflictingID int
+
+	// Available alternative names (e.g. "Inventory-2")
+	Suggestions []string
+}
+
+// Error returns the message of the wrapped ErrNameExists.
+func (e *NameConflictError) Error() string {
+	return ErrNameExists.Error()
+}
+
+// Unwrap exposes ErrNameExists to errors.Is.
+func (e *NameConflictError) Unwrap() error {
+	return ErrNameExists
+}
+
 // ModuleService implements business operations for module management.
 //
 // This service layer implements and documents all business rules and validation logic.
 // All documentation is centralized here rather than in interfaces per requirements.
 //
 // Business Rule Enforcement:
-//  1. Name Validation: 3-50 character limit, alphanumeric with spaces
+//  1. Name Validation: 3-50 characters, not only whitespace
 //  2. Uniqueness Check: Case-insensitive name uniqueness across active modules
 //  3. Description: Max 200 characters, optional field
 //  4. Status Management: Automatic timestamp generation for creation
+//  5. Approval: Modules start as drafts and can only be active once approved
 //
 // Transaction Behavior:
 //   - Full transaction support via database transaction
@@ -38,19 +101,18 @@ var (
 // Usage Example:
 //
 //	// Create new module with valid data
-//	service := module.NewModuleService(repo)
+//	service, err := module.NewModuleService(repo, revisions, acl, transfers, stars, bus, metrics.Discard)
 //	newModule, err := service.CreateModule(module.ModuleRequest{
 //	    Name:        "Inventory",
 //	    Description: "Stock management module",
-//	    IsActive:    true,
-//	})
+//	}, "jane", false)
 //	if err != nil {
 //	    // Handle business rule violation
 //	    log.Printf("Error creating module: %v", err)
 //	}
 //
 //	// Attempt to create duplicate
-//	_, err = service.CreateModule(module.ModuleRequest{Name: "Inventory"})
+//	_, err = service.CreateModule(module.ModuleRequest{Name: "Inventory"}, "jane", false)
 //	if err != nil {
 //	    // Handle business rule violation
 //	    if errors.Is(err, ErrNameExists) {
@@ -58,24 +120,135 @@ var (
 //	    }
 //	}
 type ModuleService struct {
-	repo *repository.ModuleRepository
+	repo ModuleRepository
+
+	// Change history written on every create and update
+	revisions RevisionRepository
+
+	// Per-module access control lists checked on reads and changes
+	acl ACLRepository
+
+	// Pending and resolved ownership transfers
+	transfers TransferRepository
+
+	// Per-user favorites
+	stars StarRepository
+
+	// Receives activation, ownership and approval events
+	events events.Publisher
+
+	// Short-lived cache for GetStats, shared with the services WithContext returns
+	stats *statsCache
+
+	// Business metrics: creations, soft deletions, and rejected payloads by code
+	created            metrics.Counter
+	deleted            metrics.Counter
+	validationFailures metrics.Counter
 }
 
 // NewModuleService creates a new instance of ModuleService.
 //
 // Parameters:
 //   - repo: Data access repository for module operations
+//   - revisions: Data access repository for the module change history
+//   - acl: Data access repository for per-module access control lists
+//   - transfers: Data access repository for ownership transfers
+//   - stars: Data access repository for per-user favorites
+//   - publisher: Receives module activation, ownership transfer and approval events
+//   - m: Registers the module KPIs (see Business Metrics)
 //
 // Returns:
 //   - *ModuleService: A new service instance
-func NewModuleService(repo *repository.ModuleRepository) *ModuleService {
-	return &ModuleService{repo: repo}
+//   - error: guard.ErrNilDependency if a required dependency is nil
+//
+// Business Metrics:
+//   - modules_total: Gauge of the modules outside the recycle bin by isActive
+//     ("true", "false"), counted with one aggregate query per read
+//   - modules_created_total: Counter of created modules (dry runs excluded)
+//   - modules_deleted_total: Counter of modules moved to the recycle bin
+//   - module_validation_failures_total: Counter of rejected create and update
+//     payloads by error code (e.g. NAME_LENGTH)
+func NewModuleService(repo ModuleRepository, revisions RevisionRepository, acl ACLRepository, transfers TransferRepository, stars StarRepository, publisher events.Publisher, m metrics.Metrics) (*ModuleService, error) {
+	if err := guard.Require("NewModuleService",
+		guard.Dep("repo", repo),
+		guard.Dep("revisions", revisions),
+		guard.Dep("acl", acl),
+		guard.Dep("transfers", transfers),
+		guard.Dep("stars", stars),
+		guard.Dep("publisher", publisher),
+		guard.Dep("m", m),
+	); err != nil {
+		return nil, err
+	}
+
+	m.Gauge("modules_total", func() (map[string]int64, error) {
+		active, inactive, err := repo.CountModulesByStatus(nil)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]int64{"true": active, "false": inactive}, nil
+	})
+
+	return &ModuleService{
+		repo:               repo,
+		revisions:          revisions,
+		acl:                acl,
+		transfers:          transfers,
+		stars:              stars,
+		events:             publisher,
+		stats:              &statsCache{},
+		created:            m.Counter("modules_created_total"),
+		deleted:            m.Counter("modules_deleted_total"),
+		validationFailures: m.Counter("module_validation_failures_total"),
+	}, nil
+}
+
+// WithContext returns a service whose module repository runs its statements
+// with a request's context, so they are counted against the request's query
+// budget and appear in its slow request trace. The other repositories keep
+// running without it.
+//
+// Parameters:
+//   - ctx: Context of the request
+//
+// Returns:
+//   - *ModuleService: The service bound to ctx, or s when the repository
+//     does not run statements with a context
+//
+// Usage Example:
+//
+//	module, err := service.WithContext(ctx.Request.Context()).GetModuleById(id, subject)
+func (s *ModuleService) WithContext(ctx context.Context) *ModuleService {
+	repo, ok := s.repo.(ContextRepository)
+	if !ok {
+		return s
+	}
+	bound := *s
+	bound.repo = repo.WithContext(ctx)
+	return &bound
+}
+
+// withTx runs a module change and the revisions recording it in one
+// transaction when the repository is a Transactor.
+//
+// Parameters:
+//   - fn: The writes, given the repositories to run them with
+//
+// Returns:
+//   - error: The error of fn or of committing the transaction
+func (s *ModuleService) withTx(fn func(repo ModuleRepository, revisions RevisionRepository) error) error {
+	if tx, ok := s.repo.(Transactor); ok {
+		return tx.WithTx(fn)
+	}
+	return fn(s.repo, s.revisions)
 }
 
 // CreateModule creates a new module with comprehensive business validation.
 //
 // Parameters:
 //   - moduleDto: Module creation data with business constraints
+//   - actor: Who creates the module, recorded in the change history
+//   - dryRun: Run every check but store nothing; the returned module has ID 0
 //
 // Returns:
 //   - *module.ModuleResponse: Created module with system-generated properties
@@ -84,69 +257,285 @@ func NewModuleService(repo *repository.ModuleRepository) *ModuleService {
 // Error Types:
 //   - ErrNameRequired: When name is null/empty
 //   - ErrNameLength: When name length is not between 3-50 characters
-//   - ErrNameExists: When name already exists (case-insensitive)
+//   - ErrNameExists: When name already exists (case-insensitive), returned as
+//     *NameConflictError with the conflicting ID and suggested names
 //   - ErrDescriptionLength: When description exceeds 200 characters
+//   - ErrScheduleWindow: When deactivateAt is not after activateAt
+//   - ErrNotApproved: When isActive is set; new modules are drafts that only
+//     become active once approved (see SubmitModule)
 //
 // Detailed Validation Flow:
 //  1. Verify name presence (non-null, non-empty)
 //  2. Check name length (3-50 characters)
-//  3. Validate name format (alphanumeric + spaces)
+//  3. Validate description length (max 200 chars)
 //  4. Query database for name uniqueness
-//  5. Validate description length (max 200 chars)
-//  6. Ensure isActive flag is provided
-//  7. Transform to entity and persist
+//  5. Reject an active flag, since new modules are drafts
+//  6. Transform to a draft owned by the actor and persist (stop here on a dry run)
+//  7. Record the first revision in the change history
 //
 // Performance Notes:
 //   - Name uniqueness check uses indexed database query
 //   - Validation fails fast on first error
 //   - No caching for creation operations
-func (s *ModuleService) CreateModule(moduleDto module.ModuleRequest) (*module.ModuleResponse, error) {
-	// Step 1: Validate required fields
-	if strings.TrimSpace(moduleDto.Name) == "" {
-		return nil, ErrNameRequired
+func (s *ModuleService) CreateModule(moduleDto module.ModuleRequest, actor string, dryRun bool) (*module.ModuleResponse, error) {
+	// Step 1: Validate required fields and field constraints
+	if err := s.validateRequest(moduleDto); err != nil {
+		return nil, err
 	}
-
-	// Step 2: Enforce business constraints
-	if len(moduleDto.Name) < 3 || len(moduleDto.Name) > 50 {
-		return nil, ErrNameLength
+	if moduleDto.IsActive {
+		return nil, ErrNotApproved
 	}
 
-	// Step 3: Check business rule (name uniqueness)
+	// Step 2: Check business rule (name uniqueness)
 	exists, err := s.repo.IsModuleNameExists(moduleDto.Name, 0)
 	if err != nil {
 		return nil, fmt.Errorf("database error checking name: %w", err)
 	}
 	if exists {
-		return nil, ErrNameExists
+		return nil, s.nameConflict(moduleDto.Name)
 	}
 
-	// Step 4: Validate description length
-	if len(moduleDto.Description) > 200 {
-		return nil, ErrDescriptionLength
+	// Step 3: Transform DTO to entity
+	now := time.Now()
+	entity := &module.Module{
+		Name:         moduleDto.Name,
+		Description:  moduleDto.Description,
+		IsActive:     false,
+		Status:       module.StatusDraft,
+		ActivateAt:   moduleDto.ActivateAt,
+		DeactivateAt: moduleDto.DeactivateAt,
+		Owner:        actor,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if dryRun {
+		return ToModuleResponse(entity), nil
 	}
 
-	// Step 5: Transform DTO to entity
-	entity := &module.Module{
-		Name:        moduleDto.Name,
-		Description: moduleDto.Description,
-		IsActive:    moduleDto.IsActive,
-		CreatedAt:   time.Now(),
+	// Step 4: Persist with the first revision (the unique index catches concurrent duplicates)
+	var savedEntity *module.Module
+	err = s.withTx(func(repo ModuleRepository, revisions RevisionRepository) error {
+		saved, err := repo.CreateModule(entity)
+		if err != nil {
+			return fmt.Errorf("database error creating module: %w", err)
+		}
+		savedEntity = saved
+		return recordRevision(revisions, saved, module.RevisionCreate, actor, diffModules(nil, saved))
+	})
+	if errors.Is(err, ErrNameExists) {
+		return nil, s.nameConflict(moduleDto.Name)
+	}
+	if err != nil {
+		return nil, err
 	}
+	s.stats.invalidate()
+	s.created.Add("", 1)
+
+	// Step 5: Map to response DTO
+	return ToModuleResponse(savedEntity), nil
+}
 
-	// Step 6: Persist through data layer
-	savedEntity, err := s.repo.CreateModule(entity)
+// UpdateModule replaces the editable fields of a module.
+//
+// Parameters:
+//   - id: Unique identifier of the module
+//   - moduleDto: New name, description and active flag
+//   - subject: Who changes the module; must be allowed to edit it and is
+//     recorded in the change history
+//   - dryRun: Run every check but store nothing, record no revision and
+//     publish no event
+//
+// Returns:
+//   - *module.ModuleResponse: The module after the update
+//   - error: Error if business rules are violated
+//
+// Error Types:
+//   - ErrNotFound: When the module does not exist or is hidden from the subject
+//   - ErrForbidden: When the subject may view but not edit the module
+//   - ErrNameRequired, ErrNameLength, ErrDescriptionLength, ErrScheduleWindow: As for CreateModule
+//   - ErrNameExists: When another module uses the name (case-insensitive),
+//     returned as *NameConflictError
+//   - ErrUnderReview: When the module is pending approval
+//   - ErrNotApproved: When isActive is set on a module that is not approved
+//
+// Update Behavior:
+//   - All editable fields are replaced (an omitted isActive means false and
+//     omitted schedule times are cleared)
+//   - Renaming a module to a different casing of its own name is allowed
+//   - A request that changes nothing writes nothing and keeps updatedAt
+//   - Every effective update records a revision with its field-level diff
+//   - A change of the active flag publishes module.activated or module.deactivated
+func (s *ModuleService) UpdateModule(id string, moduleDto module.ModuleRequest, subject module.Subject, dryRun bool) (*module.ModuleResponse, error) {
+	// Step 1: Validate the payload
+	if err := s.validateRequest(moduleDto); err != nil {
+		return nil, err
+	}
+
+	// Step 2: Load the current state and check access
+	existing, err := s.loadModule(id)
 	if err != nil {
-		return nil, fmt.Errorf("database error creating module: %w", err)
+		return nil, err
+	}
+	if err := s.authorize(existing.ID, subject, module.PermissionEdit); err != nil {
+		return nil, err
+	}
+	if err := checkEditable(existing, moduleDto); err != nil {
+		return nil, err
 	}
 
-	// Step 7: Map to response DTO
-	return &module.ModuleResponse{
-		ID:          savedEntity.ID,
-		Name:        savedEntity.Name,
-		Description: savedEntity.Description,
-		IsActive:    savedEntity.IsActive,
-		CreatedAt:   savedEntity.CreatedAt,
-	}, nil
+	// Step 3: Apply and record the change
+	return s.applyUpdate(existing, moduleDto, module.RevisionUpdate, subject.User, dryRun)
+}
+
+// loadModule loads a module by ID for a change.
+//
+// Parameters:
+//   - id: Unique identifier of the module
+//
+// Returns:
+//   - *module.Module: The current state of the module
+//   - error: ErrNotFound for malformed or unknown IDs, or a data layer error
+func (s *ModuleService) loadModule(id string) (*module.Module, error) {
+	if _, err := strconv.Atoi(id); err != nil {
+		return nil, ErrNotFound.Wrap(err)
+	}
+
+	existing, err := s.repo.GetModuleById(id)
+	if err != nil {
+		return nil, fmt.Errorf("database error loading module: %w", err)
+	}
+	if existing == nil {
+		return nil, ErrNotFound
+	}
+	return existing, nil
+}
+
+// applyUpdate writes new field values to a module and records the revision.
+//
+// Parameters:
+//   - existing: Current state of the module (not modified)
+//   - moduleDto: New field values, already validated
+//   - action: Revision action to record
+//   - actor: Who makes the change
+//   - dryRun: Return the would-be state without writing anything
+//
+// Returns:
+//   - *module.ModuleResponse: The module after the change
+//   - error: ErrNameExists as *NameConflictError, or a data layer error
+func (s *ModuleService) applyUpdate(existing *module.Module, moduleDto module.ModuleRequest, action, actor string, dryRun bool) (*module.ModuleResponse, error) {
+	// Step 1: Check name uniqueness against the other modules
+	exists, err := s.repo.IsModuleNameExists(moduleDto.Name, existing.ID)
+	if err != nil {
+		return nil, fmt.Errorf("database error checking name: %w", err)
+	}
+	if exists {
+		return nil, s.nameConflict(moduleDto.Name)
+	}
+
+	// Step 2: Apply the changes to a copy and skip no-op updates
+	updated := *existing
+	updated.Name = moduleDto.Name
+	updated.Description = moduleDto.Description
+	updated.IsActive = moduleDto.IsActive
+	updated.ActivateAt = moduleDto.ActivateAt
+	updated.DeactivateAt = moduleDto.DeactivateAt
+
+	changes := diffModules(existing, &updated)
+	if len(changes) == 0 {
+		return ToModuleResponse(existing), nil
+	}
+	updated.UpdatedAt = time.Now()
+	if dryRun {
+		return ToModuleResponse(&updated), nil
+	}
+
+	// Step 3: Persist with the revision (the unique index catches concurrent duplicates)
+	var savedEntity *module.Module
+	err = s.withTx(func(repo ModuleRepository, revisions RevisionRepository) error {
+		saved, err := repo.UpdateModule(&updated)
+		if err != nil {
+			return fmt.Errorf("database error updating module: %w", err)
+		}
+		savedEntity = saved
+		return recordRevision(revisions, saved, action, actor, changes)
+	})
+	if errors.Is(err, ErrNameExists) {
+		return nil, s.nameConflict(moduleDto.Name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.stats.invalidate()
+
+	// Step 4: Announce activation changes
+	if savedEntity.IsActive != existing.IsActive {
+		eventType := events.ModuleDeactivated
+		if savedEntity.IsActive {
+			eventType = events.ModuleActivated
+		}
+		s.events.Publish(events.Event{
+			Type:       eventType,
+			ModuleID:   savedEntity.ID,
+			Actor:      actor,
+			OccurredAt: savedEntity.UpdatedAt,
+		})
+	}
+
+	return ToModuleResponse(savedEntity), nil
+}
+
+// validateRequest validates a client payload and counts rejections by error code.
+func (s *ModuleService) validateRequest(moduleDto module.ModuleRequest) error {
+	err := validateModuleRequest(moduleDto)
+	switch {
+	case errors.Is(err, ErrNameRequired):
+		s.RecordValidationFailure("NAME_REQUIRED")
+	case errors.Is(err, ErrNameLength):
+		s.RecordValidationFailure("NAME_LENGTH")
+	case errors.Is(err, ErrDescriptionLength):
+		s.RecordValidationFailure("DESCRIPTION_LENGTH")
+	case errors.Is(err, ErrScheduleWindow):
+		s.RecordValidationFailure("SCHEDULE_WINDOW")
+	}
+	return err
+}
+
+// RecordValidationFailure counts a create or update payload rejected before
+// it reached the service, e.g. by request binding.
+//
+// Parameters:
+//   - code: Error code of the rejection (NAME_REQUIRED, NAME_LENGTH,
+//     DESCRIPTION_LENGTH, SCHEDULE_WINDOW or MALFORMED_BODY)
+func (s *ModuleService) RecordValidationFailure(code string) {
+	s.validationFailures.Add(code, 1)
+}
+
+// validateModuleRequest checks the field constraints shared by create and update.
+//
+// Lengths are counted in characters, like the binding rules of the request,
+// so a name of 50 accented letters is accepted by both layers.
+//
+// Parameters:
+//   - moduleDto: The payload to check
+//
+// Returns:
+//   - error: ErrNameRequired, ErrNameLength, ErrDescriptionLength or
+//     ErrScheduleWindow; nil when valid
+func validateModuleRequest(moduleDto module.ModuleRequest) error {
+	if strings.TrimSpace(moduleDto.Name) == "" {
+		return ErrNameRequired
+	}
+	if length := utf8.RuneCountInString(moduleDto.Name); length < 3 || length > 50 {
+		return ErrNameLength
+	}
+	if utf8.RuneCountInString(moduleDto.Description) > 200 {
+		return ErrDescriptionLength
+	}
+	if moduleDto.ActivateAt != nil && moduleDto.DeactivateAt != nil &&
+		!moduleDto.DeactivateAt.After(*moduleDto.ActivateAt) {
+		return ErrScheduleWindow
+	}
+	return nil
 }
 
 // GetModuleById retrieves module by ID with business context awareness.
@@ -167,7 +556,7 @@ func (s *ModuleService) CreateModule(moduleDto module.ModuleRequest) (*module.Mo
 //   - Single database roundtrip
 //   - Uses primary key index
 //   - Typical execution time: < 10ms
-func (s *ModuleService) GetModuleById(id string) (*module.ModuleResponse, error) {
+func (s *ModuleService) GetModuleById(id string, subject module.Subject) (*module.ModuleResponse, error) {
 	entity, err := s.repo.GetModuleById(id)
 	if err != nil {
 		return nil, err
@@ -175,12 +564,334 @@ func (s *ModuleService) GetModuleById(id string) (*module.ModuleResponse, error)
 	if entity == nil {
 		return nil, ErrNotFound
 	}
+	if err := s.authorize(entity.ID, subject, module.PermissionView); err != nil {
+		return nil, err
+	}
 
-	return &module.ModuleResponse{
-		ID:          entity.ID,
-		Name:        entity.Name,
-		Description: entity.Description,
-		IsActive:    entity.IsActive,
-		CreatedAt:   entity.CreatedAt,
+	return ToModuleResponse(entity), nil
+}
+
+// ModuleExists checks whether a module exists without loading its details.
+//
+// Parameters:
+//   - id: Unique identifier of the module
+//   - subject: Who asks; modules hidden by their ACL do not exist for them
+//
+// Returns:
+//   - bool: True if the module exists; malformed IDs never exist
+//   - error: Error if the data layer fails
+func (s *ModuleService) ModuleExists(id string, subject module.Subject) (bool, error) {
+	moduleID, err := strconv.Atoi(id)
+	if err != nil {
+		return false, nil
+	}
+
+	exists, err := s.repo.ModuleExists(moduleID)
+	if err != nil {
+		return false, fmt.Errorf("database error checking module: %w", err)
+	}
+	if !exists {
+		return false, nil
+	}
+
+	granted, err := s.permissions([]int{moduleID}, subject)
+	if err != nil {
+		return false, err
+	}
+	return granted[moduleID] != "", nil
+}
+
+// CountModules counts modules, optionally filtered by their active flag.
+//
+// Parameters:
+//   - isActive: Active flag to filter by (nil counts all modules)
+//   - subject: Who asks; modules hidden by their ACL are not counted
+//
+// Returns:
+//   - int64: Number of matching modules
+//   - error: Error if the data layer fails
+func (s *ModuleService) CountModules(isActive *bool, subject module.Subject) (int64, error) {
+	count, err := s.repo.CountModules(isActive, visibleTo(subject))
+	if err != nil {
+		return 0, fmt.Errorf("database error counting modules: %w", err)
+	}
+	return count, nil
+}
+
+// GetModulesByIds retrieves many modules by ID with a single data layer call.
+//
+// Parameters:
+//   - ids: Identifiers of the modules to load (at most MaxBatchIds)
+//   - subject: Who asks; modules hidden by their ACL are reported as missing
+//
+// Returns:
+//   - []*module.ModuleResponse: Found modules, in the order their IDs were requested
+//   - []int: Requested IDs that do not exist, in request order
+//   - error: Error if the data layer fails
+//
+// Batch Behavior:
+//   - Duplicate IDs are collapsed; each module is returned once
+//   - Missing IDs are reported instead of failing the whole lookup
+//   - One database roundtrip (WHERE id IN (...)) instead of one per ID, plus
+//     one for the ACL entries of all found modules
+func (s *ModuleService) GetModulesByIds(ids []int, subject module.Subject) ([]*module.ModuleResponse, []int, error) {
+	// Step 1: Collapse duplicate IDs, keeping the request order
+	unique := uniqueIDs(ids)
+
+	// Step 2: Load all modules at once
+	entities, err := s.repo.GetModulesByIds(unique)
+	if err != nil {
+		return nil, nil, fmt.Errorf("database error loading modules: %w", err)
+	}
+
+	foundIDs := make([]int, len(entities))
+	for i, entity := range entities {
+		foundIDs[i] = entity.ID
+	}
+	granted, err := s.permissions(foundIDs, subject)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	byID := make(map[int]*module.Module, len(entities))
+	for _, entity := range entities {
+		if granted[entity.ID] != "" {
+			byID[entity.ID] = entity
+		}
+	}
+
+	// Step 3: Split into found modules and missing IDs
+	found := make([]*module.ModuleResponse, 0, len(entities))
+	missing := make([]int, 0)
+	for _, id := range unique {
+		if entity, ok := byID[id]; ok {
+			found = append(found, ToModuleResponse(entity))
+		} else {
+			missing = append(missing, id)
+		}
+	}
+
+	return found, missing, nil
+}
+
+// nameConflict builds the conflict error for a taken module name.
+//
+// Parameters:
+//   - name: The requested module name
+//
+// Returns:
+//   - error: *NameConflictError, or a wrapped database error if the lookup fails
+//
+// Suggestion Rules:
+//   - Candidates are "<name>-2", "<name>-3", ... up to "<name>-99"
+//   - The name is truncated so candidates respect the 50 character limit
+//   - All taken names sharing the prefix are loaded with a single query
+//   - At most 3 suggestions are returned
+func (s *ModuleService) nameConflict(name string) error {
+	// Step 1: Find the module holding the name
+	existing, err := s.repo.FindModuleByName(name)
+	if err != nil {
+		return fmt.Errorf("database error finding conflicting module: %w", err)
+	}
+
+	conflict := &NameConflictError{}
+	if existing != nil {
+		conflict.ConflictingID = existing.ID
+	}
+
+	// Step 2: Load every taken name sharing the candidate prefix
+	base := strings.TrimSpace(name)
+	if len(base) > maxModuleNameLength-suggestionSuffixSize {
+		base = base[:maxModuleNameLength-suggestionSuffixSize]
+	}
+
+	takenNames, err := s.repo.FindModuleNamesByPrefix(base)
+	if err != nil {
+		return fmt.Errorf("database error loading similar names: %w", err)
+	}
+
+	taken := make(map[string]bool, len(takenNames))
+	for _, takenName := range takenNames {
+		taken[strings.ToLower(takenName)] = true
+	}
+
+	// Step 3: Pick the first free candidates
+	for suffix := 2; suffix <= maxSuggestionSuffix && len(conflict.Suggestions) < maxNameSuggestions; suffix++ {
+		candidate := fmt.Sprintf("%s-%d", base, suffix)
+		if !taken[strings.ToLower(candidate)] {
+			conflict.Suggestions = append(conflict.Suggestions, candidate)
+		}
+	}
+
+	return conflict
+}
+
+// streamBatchSize is the number of modules loaded per repository batch when streaming.
+const streamBatchSize = 500
+
+// ListModules returns one page of modules using offset pagination.
+//
+// Parameters:
+//   - filter: Criteria narrowing the listed modules (zero value lists all)
+//   - subject: Who asks; modules hidden by their ACL are left out
+//   - page: 1-based page number
+//   - pageSize: Number of modules per page (1-100)
+//   - opts: Relations to include with every module (module.WithTags,
+//     module.WithPermissions), loaded with one query each
+//
+// Returns:
+//   - *pagination.Page[*module.ModuleResponse]: Modules with total count
+//   - error: Error if the data layer fails
+//
+// Ordering:
+//   - Modules are ordered by creation time, then ID, so pages are stable
+//
+// Performance Notes:
+//   - Cost grows with the page number (OFFSET); use ListModulesAfter for deep pages
+func (s *ModuleService) ListModules(filter module.ModuleFilter, subject module.Subject, page, pageSize int, opts ...module.ListOption) (*pagination.Page[*module.ModuleResponse], error) {
+	filter.VisibleTo = visibleTo(subject)
+	entities, total, err := s.repo.ListModules(filter, (page-1)*pageSize, pageSize, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("database error listing modules: %w", err)
+	}
+
+	return &pagination.Page[*module.ModuleResponse]{
+		Items:      toListedModuleResponses(entities, subject, module.LoadRelations(opts)),
+		Page:       page,
+		PageSize:   pageSize,
+		TotalItems: total,
 	}, nil
 }
+
+// ListModulesAfter returns one page of modules using keyset pagination.
+//
+// Parameters:
+//   - filter: Criteria narrowing the listed modules (zero value lists all)
+//   - subject: Who asks; modules hidden by their ACL are left out
+//   - after: Cursor from the previous page (nil for the first page)
+//   - pageSize: Number of modules per page (1-100)
+//   - opts: Relations to include with every module, as for ListModules
+//
+// Returns:
+//   - *pagination.Page[*module.ModuleResponse]: Modules with the next cursor
+//   - error: Error if the data layer fails
+//
+// Cursor Behavior:
+//   - NextCursor points at the last returned module
+//   - NextCursor is nil when no further modules exist
+//   - One extra row is fetched to detect the last page without a COUNT query
+func (s *ModuleService) ListModulesAfter(filter module.ModuleFilter, subject module.Subject, after *pagination.Cursor, pageSize int, opts ...module.ListOption) (*pagination.Page[*module.ModuleResponse], error) {
+	filter.VisibleTo = visibleTo(subject)
+	entities, err := s.repo.ListModulesAfter(filter, after, pageSize+1, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("database error listing modules: %w", err)
+	}
+
+	result := &pagination.Page[*module.ModuleResponse]{PageSize: pageSize}
+	if len(entities) > pageSize {
+		entities = entities[:pageSize]
+		last := entities[len(entities)-1]
+		result.NextCursor = &pagination.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+	result.Items = toListedModuleResponses(entities, subject, module.LoadRelations(opts))
+
+	return result, nil
+}
+
+// StreamModules visits modules in (createdAt, id) order without buffering the full result set.
+//
+// Parameters:
+//   - after: Cursor to resume from (nil to stream everything)
+//   - subject: Who asks; modules hidden by their ACL are left out
+//   - visit: Callback receiving each module; returning an error stops the stream
+//
+// Returns:
+//   - error: Error from the data layer or from visit
+//
+// Streaming Behavior:
+//   - Modules are loaded in batches of 500 using the same keyset query as ListModulesAfter
+//   - Only one batch is held in memory at a time
+//   - Modules created during the stream may or may not be included
+//
+// Usage Example:
+//
+//	err := service.StreamModules(nil, subject, func(m *module.ModuleResponse) error {
+//	    return encoder.Encode(m)
+//	})
+func (s *ModuleService) StreamModules(after *pagination.Cursor, subject module.Subject, visit func(m *module.ModuleResponse) error) error {
+	filter := module.ModuleFilter{VisibleTo: visibleTo(subject)}
+	for {
+		batch, err := s.repo.ListModulesAfter(filter, after, streamBatchSize)
+		if err != nil {
+			return fmt.Errorf("database error streaming modules: %w", err)
+		}
+
+		for _, entity := range batch {
+			if err := visit(ToModuleResponse(entity)); err != nil {
+				return err
+			}
+		}
+
+		if len(batch) < streamBatchSize {
+			return nil
+		}
+		last := batch[len(batch)-1]
+		after = &pagination.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+}
+
+// toModuleResponses maps module entities to response DTOs.
+func toModuleResponses(entities []*module.Module) []*module.ModuleResponse {
+	responses := make([]*module.ModuleResponse, len(entities))
+	for i, entity := range entities {
+		responses[i] = ToModuleResponse(entity)
+	}
+	return responses
+}
+
+// toListedModuleResponses maps a page of module entities to response DTOs with
+// the relations loaded for them: their tags, and the subject's permission
+// computed from the loaded ACL entries.
+func toListedModuleResponses(entities []*module.Module, subject module.Subject, relations module.Relations) []*module.ModuleResponse {
+	responses := toModuleResponses(entities)
+	if !relations.Tags && !relations.Permissions {
+		return responses
+	}
+
+	var granted map[int]string
+	if relations.Permissions {
+		ids := make([]int, len(entities))
+		var entries []module.ModuleACLEntry
+		for i, entity := range entities {
+			ids[i] = entity.ID
+			entries = append(entries, entity.Permissions...)
+		}
+		granted = grantedPermissions(ids, entries, subject)
+	}
+
+	for i, entity := range entities {
+		if relations.Tags {
+			responses[i].Tags = entity.Tags
+		}
+		if relations.Permissions {
+			responses[i].Permission = granted[entity.ID]
+		}
+	}
+	return responses
+}
+
+// ToModuleResponse maps a module entity to its response DTO.
+func ToModuleResponse(entity *module.Module) *module.ModuleResponse {
+	return &module.ModuleResponse{
+		ID:           entity.ID,
+		Name:         entity.Name,
+		Description:  entity.Description,
+		IsActive:     entity.IsActive,
+		Status:       entity.Status,
+		ActivateAt:   entity.ActivateAt,
+		DeactivateAt: entity.DeactivateAt,
+		Owner:        entity.Owner,
+		CreatedAt:    entity.CreatedAt,
+		UpdatedAt:    entity.UpdatedAt,
+	}
+}
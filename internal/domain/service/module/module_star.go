@@ -0,0 +1,88 @@
+package module
+
+import (
+	"fmt"
+
+	"go_di_architecture/internal/domain/models/module"
+)
+
+// StarModule adds a module to the favorites of the subject.
+//
+// Parameters:
+//   - id: Unique identifier of the module
+//   - subject: Who stars the module; must be an identified user allowed to
+//     view it
+//
+// Returns:
+//   - error: Error if the module cannot be starred
+//
+// Error Types:
+//   - ErrUserRequired: When the request carries no user
+//   - ErrNotFound: When the module does not exist or is hidden from the subject
+//
+// Star Behavior:
+//   - Starring is idempotent; starring a starred module changes nothing
+//   - Starred modules are listed with ?starred=true
+func (s *ModuleService) StarModule(id string, subject module.Subject) error {
+	moduleID, err := s.loadStarrable(id, subject)
+	if err != nil {
+		return err
+	}
+
+	if err := s.stars.StarModule(moduleID, subject.User); err != nil {
+		return fmt.Errorf("database error starring module: %w", err)
+	}
+	return nil
+}
+
+// UnstarModule removes a module from the favorites of the subject.
+//
+// Parameters:
+//   - id: Unique identifier of the module
+//   - subject: Who unstars the module; must be an identified user allowed to
+//     view it
+//
+// Returns:
+//   - error: Error if the module cannot be unstarred
+//
+// Error Types:
+//   - ErrUserRequired: When the request carries no user
+//   - ErrNotFound: When the module does not exist or is hidden from the subject
+//
+// Unstar Behavior:
+//   - Unstarring a module that is not starred changes nothing
+func (s *ModuleService) UnstarModule(id string, subject module.Subject) error {
+	moduleID, err := s.loadStarrable(id, subject)
+	if err != nil {
+		return err
+	}
+
+	if err := s.stars.UnstarModule(moduleID, subject.User); err != nil {
+		return fmt.Errorf("database error unstarring module: %w", err)
+	}
+	return nil
+}
+
+// loadStarrable checks the subject may star or unstar a module.
+//
+// Parameters:
+//   - id: Unique identifier of the module
+//   - subject: Who stars the module
+//
+// Returns:
+//   - int: The module ID
+//   - error: ErrUserRequired, ErrNotFound or a data layer error
+func (s *ModuleService) loadStarrable(id string, subject module.Subject) (int, error) {
+	if subject.User == "" {
+		return 0, ErrUserRequired
+	}
+
+	existing, err := s.loadModule(id)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.authorize(existing.ID, subject, module.PermissionView); err != nil {
+		return 0, err
+	}
+	return existing.ID, nil
+}
@@ -0,0 +1,145 @@
+package module
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go_di_architecture/internal/domain/models/module"
+)
+
+// Statistics settings
+const (
+	// statsWindowDays is the number of days covered by the creations-per-day series
+	statsWindowDays = 30
+
+	// statsUnchangedLimit is the number of longest-unchanged modules reported
+	statsUnchangedLimit = 5
+
+	// statsCacheTTL is how long computed statistics are served from memory
+	statsCacheTTL = time.Minute
+
+	// statsCacheEntries bounds the statistics cached at once, one per set of
+	// principals asking; the cache starts over when it is full
+	statsCacheEntries = 1000
+)
+
+// statsCache holds the last computed statistics per set of principals.
+//
+// Statistics run several aggregate queries, so they are cached for a short
+// time and invalidated whenever modules change. Subjects see the statistics
+// of the modules visible to them, so each set of principals has its own entry.
+type statsCache struct {
+	mu      sync.Mutex
+	entries map[string]cachedStats
+}
+
+// cachedStats is computed statistics with their expiry.
+type cachedStats struct {
+	stats     *module.ModuleStatsResponse
+	expiresAt time.Time
+}
+
+// statsKey returns the cache key of the statistics visible to principals
+// (nil for admins, who see every module).
+func statsKey(visibleTo []string) string {
+	if visibleTo == nil {
+		return ""
+	}
+	return "\x00" + strings.Join(visibleTo, "\x00")
+}
+
+// get returns the cached statistics if they have not expired.
+func (c *statsCache) get(key string, now time.Time) *module.ModuleStatsResponse {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || now.After(entry.expiresAt) {
+		return nil
+	}
+	return entry.stats
+}
+
+// set stores freshly computed statistics.
+func (c *statsCache) set(key string, stats *module.ModuleStatsResponse, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entries == nil || len(c.entries) >= statsCacheEntries {
+		c.entries = make(map[string]cachedStats)
+	}
+	c.entries[key] = cachedStats{stats: stats, expiresAt: now.Add(statsCacheTTL)}
+}
+
+// invalidate drops all cached statistics.
+func (c *statsCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	clear(c.entries)
+}
+
+// GetStats aggregates module statistics.
+//
+// Parameters:
+//   - subject: Who asks; only modules visible to it are counted and listed
+//
+// Returns:
+//   - *module.ModuleStatsResponse: Totals, creations per day and longest-unchanged modules
+//   - error: Error if the data layer fails
+//
+// Computed Statistics:
+//   - Totals by active/inactive status (single GROUP BY query)
+//   - Creations per UTC day over the last 30 days, including empty days
+//   - The 5 modules with the oldest last change
+//
+// Caching Behavior:
+//   - Results are cached for one minute per set of principals (see GeneratedAt)
+//   - The cache is invalidated when a module is created
+func (s *ModuleService) GetStats(subject module.Subject) (*module.ModuleStatsResponse, error) {
+	now := time.Now().UTC()
+	visible := visibleTo(subject)
+	key := statsKey(visible)
+	if cached := s.stats.get(key, now); cached != nil {
+		return cached, nil
+	}
+
+	// Step 1: Totals by status
+	active, inactive, err := s.repo.CountModulesByStatus(visible)
+	if err != nil {
+		return nil, fmt.Errorf("database error counting modules: %w", err)
+	}
+
+	// Step 2: Creations per day, filling days without creations
+	firstDay := now.Truncate(24*time.Hour).AddDate(0, 0, -(statsWindowDays - 1))
+	perDay, err := s.repo.CountModulesCreatedPerDay(firstDay, visible)
+	if err != nil {
+		return nil, fmt.Errorf("database error counting creations: %w", err)
+	}
+
+	createdPerDay := make([]module.DailyCount, statsWindowDays)
+	for i := range createdPerDay {
+		day := firstDay.AddDate(0, 0, i).Format("2006-01-02")
+		createdPerDay[i] = module.DailyCount{Date: day, Count: perDay[day]}
+	}
+
+	// Step 3: Modules that have gone longest without changes
+	unchanged, err := s.repo.FindLeastRecentlyUpdated(statsUnchangedLimit, visible)
+	if err != nil {
+		return nil, fmt.Errorf("database error loading unchanged modules: %w", err)
+	}
+
+	stats := &module.ModuleStatsResponse{
+		TotalModules:     active + inactive,
+		ActiveModules:    active,
+		InactiveModules:  inactive,
+		CreatedPerDay:    createdPerDay,
+		LongestUnchanged: toModuleResponses(unchanged),
+		GeneratedAt:      now,
+	}
+	s.stats.set(key, stats, now)
+
+	return stats, nil
+}
@@ -0,0 +1,269 @@
+package module
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go_di_architecture/internal/domain/events"
+	"go_di_architecture/internal/domain/models/module"
+)
+
+// RequestOwnershipTransfer proposes a new owner for a module.
+//
+// The module keeps its owner until the proposed owner accepts the transfer
+// with AcceptOwnershipTransfer. The proposed owner is notified through a
+// module.ownership_transfer_requested event.
+//
+// Parameters:
+//   - id: Unique identifier of the module
+//   - request: The proposed new owner
+//   - subject: Who requests the transfer; must be allowed to edit the module
+//
+// Returns:
+//   - *module.TransferResponse: The pending transfer
+//   - error: Error if the transfer cannot be requested
+//
+// Error Types:
+//   - ErrNotFound: When the module does not exist or is hidden from the subject
+//   - ErrForbidden: When the subject may view but not edit the module
+//   - ErrInvalidTransfer: When the new owner is blank, too long or already owns the module
+//   - ErrTransferPending: When the module already has a pending transfer that
+//     has not expired
+func (s *ModuleService) RequestOwnershipTransfer(id string, request module.TransferOwnershipRequest, subject module.Subject) (*module.TransferResponse, error) {
+	// Step 1: Load the module and check access
+	existing, err := s.loadModule(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorize(existing.ID, subject, module.PermissionEdit); err != nil {
+		return nil, err
+	}
+
+	// Step 2: Validate the new owner
+	newOwner := strings.TrimSpace(request.NewOwner)
+	switch {
+	case newOwner == "" || len(newOwner) > module.MaxActorLength:
+		return nil, ErrInvalidTransfer.Detailf("new owner must be 1-%d characters", module.MaxActorLength)
+	case newOwner == existing.Owner:
+		return nil, ErrInvalidTransfer.Detailf("%q already owns the module", newOwner)
+	}
+
+	// Step 3: Allow one pending transfer per module, expiring stale ones
+	now := time.Now()
+	pending, err := s.transfers.FindPendingTransfer(existing.ID)
+	if err != nil {
+		return nil, fmt.Errorf("database error loading transfer: %w", err)
+	}
+	if pending != nil {
+		if now.Before(pending.ExpiresAt) {
+			return nil, ErrTransferPending
+		}
+		if _, err := s.transfers.ResolveTransfer(pending.ID, module.TransferExpired, now); err != nil {
+			return nil, fmt.Errorf("database error expiring transfer: %w", err)
+		}
+	}
+
+	// Step 4: Record the transfer and notify the proposed owner
+	transfer, err := s.transfers.CreateTransfer(&module.ModuleTransfer{
+		ModuleID:    existing.ID,
+		FromOwner:   existing.Owner,
+		ToOwner:     newOwner,
+		RequestedBy: subject.User,
+		Status:      module.TransferPending,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(module.TransferTTL),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("database error creating transfer: %w", err)
+	}
+
+	s.events.Publish(events.Event{
+		Type:       events.OwnershipTransferRequested,
+		ModuleID:   existing.ID,
+		Actor:      subject.User,
+		Recipient:  newOwner,
+		OccurredAt: now,
+	})
+	return toTransferResponse(transfer, now), nil
+}
+
+// GetOwnershipTransfer returns an ownership transfer.
+//
+// Parameters:
+//   - transferID: Unique identifier of the transfer
+//   - subject: Who asks; must be allowed to view the module
+//
+// Returns:
+//   - *module.TransferResponse: The transfer (pending transfers past their
+//     expiry are reported as expired)
+//   - error: ErrTransferNotFound if the transfer does not exist or its module
+//     is hidden from the subject, or a data layer error
+func (s *ModuleService) GetOwnershipTransfer(transferID string, subject module.Subject) (*module.TransferResponse, error) {
+	transfer, err := s.loadTransfer(transferID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authorize(transfer.ModuleID, subject, module.PermissionView); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, ErrTransferNotFound.Wrap(err)
+		}
+		return nil, err
+	}
+	return toTransferResponse(transfer, time.Now()), nil
+}
+
+// AcceptOwnershipTransfer makes the proposed owner the owner of the module.
+//
+// Parameters:
+//   - transferID: Unique identifier of the transfer
+//   - subject: Who accepts; must be the proposed owner
+//
+// Returns:
+//   - *module.TransferResponse: The accepted transfer
+//   - error: Error if the transfer cannot be accepted
+//
+// Error Types:
+//   - ErrTransferNotFound: When the transfer does not exist
+//   - ErrForbidden: When the subject is not the proposed owner
+//   - ErrTransferClosed: When the transfer was already accepted or has expired
+//   - ErrNotFound: When the module was deleted in the meantime
+//
+// Accept Behavior:
+//   - The owner change is recorded as a "transfer" revision by the new owner
+//   - If the module has an ACL, the new owner is granted edit on it
+//   - The previous owner is notified through a module.ownership_transferred event
+func (s *ModuleService) AcceptOwnershipTransfer(transferID string, subject module.Subject) (*module.TransferResponse, error) {
+	// Step 1: Load the transfer and check who accepts it
+	transfer, err := s.loadTransfer(transferID)
+	if err != nil {
+		return nil, err
+	}
+	if subject.User != transfer.ToOwner {
+		return nil, ErrForbidden
+	}
+	if transfer.Status != module.TransferPending {
+		return nil, ErrTransferClosed
+	}
+
+	now := time.Now()
+	if !now.Before(transfer.ExpiresAt) {
+		if _, err := s.transfers.ResolveTransfer(transfer.ID, module.TransferExpired, now); err != nil {
+			return nil, fmt.Errorf("database error expiring transfer: %w", err)
+		}
+		return nil, ErrTransferClosed
+	}
+
+	// Step 2: Load the module and close the transfer (fails when a concurrent accept won)
+	existing, err := s.loadModule(strconv.Itoa(transfer.ModuleID))
+	if err != nil {
+		return nil, err
+	}
+	resolved, err := s.transfers.ResolveTransfer(transfer.ID, module.TransferAccepted, now)
+	if err != nil {
+		return nil, fmt.Errorf("database error accepting transfer: %w", err)
+	}
+	if !resolved {
+		return nil, ErrTransferClosed
+	}
+	transfer.Status = module.TransferAccepted
+	transfer.ResolvedAt = &now
+
+	// Step 3: Change the owner and record the revision
+	updated := *existing
+	updated.Owner = transfer.ToOwner
+	updated.UpdatedAt = now
+	var savedEntity *module.Module
+	err = s.withTx(func(repo ModuleRepository, revisions RevisionRepository) error {
+		saved, err := repo.UpdateModule(&updated)
+		if err != nil {
+			return fmt.Errorf("database error updating module: %w", err)
+		}
+		savedEntity = saved
+		return recordRevision(revisions, saved, module.RevisionTransfer, subject.User, diffModules(existing, saved))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 4: Keep the new owner able to edit a restricted module
+	if err := s.grantOwnerEdit(savedEntity.ID, transfer.ToOwner, now); err != nil {
+		return nil, err
+	}
+
+	// Step 5: Notify the previous owner
+	s.events.Publish(events.Event{
+		Type:       events.OwnershipTransferred,
+		ModuleID:   savedEntity.ID,
+		Actor:      subject.User,
+		Recipient:  transfer.FromOwner,
+		OccurredAt: now,
+	})
+	return toTransferResponse(transfer, now), nil
+}
+
+// loadTransfer loads a transfer by ID.
+//
+// Parameters:
+//   - id: Unique identifier of the transfer
+//
+// Returns:
+//   - *module.ModuleTransfer: The transfer
+//   - error: ErrTransferNotFound for malformed or unknown IDs, or a data layer error
+func (s *ModuleService) loadTransfer(id string) (*module.ModuleTransfer, error) {
+	transferID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, ErrTransferNotFound.Wrap(err)
+	}
+
+	transfer, err := s.transfers.GetTransfer(transferID)
+	if err != nil {
+		return nil, fmt.Errorf("database error loading transfer: %w", err)
+	}
+	if transfer == nil {
+		return nil, ErrTransferNotFound
+	}
+	return transfer, nil
+}
+
+// grantOwnerEdit adds an edit entry for the owner to a module ACL.
+//
+// Modules without an ACL are left open; an existing entry for the owner is
+// upgraded to edit (see withOwnerEdit).
+func (s *ModuleService) grantOwnerEdit(moduleID int, owner string, now time.Time) error {
+	entries, err := s.acl.ListACLEntries([]int{moduleID})
+	if err != nil {
+		return fmt.Errorf("database error loading ACL: %w", err)
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+
+	entries = withOwnerEdit(entries, moduleID, owner, now)
+	if err := s.acl.ReplaceACL(moduleID, entries); err != nil {
+		return fmt.Errorf("database error replacing ACL: %w", err)
+	}
+	return nil
+}
+
+// toTransferResponse maps a transfer to its response DTO as seen at the given time.
+func toTransferResponse(transfer *module.ModuleTransfer, now time.Time) *module.TransferResponse {
+	status := transfer.Status
+	if status == module.TransferPending && !now.Before(transfer.ExpiresAt) {
+		status = module.TransferExpired
+	}
+	return &module.TransferResponse{
+		ID:          transfer.ID,
+		ModuleID:    transfer.ModuleID,
+		FromOwner:   transfer.FromOwner,
+		ToOwner:     transfer.ToOwner,
+		RequestedBy: transfer.RequestedBy,
+		Status:      status,
+		CreatedAt:   transfer.CreatedAt,
+		ExpiresAt:   transfer.ExpiresAt,
+		ResolvedAt:  transfer.ResolvedAt,
+	}
+}
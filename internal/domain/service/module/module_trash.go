@@ -0,0 +1,204 @@
+package module
+
+import (
+	"fmt"
+	"time"
+
+	"go_di_architecture/internal/domain/models/module"
+	"go_di_architecture/internal/domain/models/pagination"
+)
+
+// DeleteModule moves a module to the recycle bin.
+//
+// Parameters:
+//   - id: Unique identifier of the module
+//   - subject: Who deletes the module; must be allowed to edit it and is
+//     recorded in the change history
+//   - dryRun: Only check that the module can be deleted
+//
+// Returns:
+//   - error: ErrNotFound if the module does not exist, is already deleted or
+//     is hidden from the subject, ErrForbidden if the subject may view but not
+//     edit it, or a data layer error
+//
+// Soft Delete Behavior:
+//   - The module disappears from every read endpoint but keeps its tags,
+//     dependencies, settings and history
+//   - Its name stays reserved until the module is purged, so a restore never
+//     conflicts with a newer module
+func (s *ModuleService) DeleteModule(id string, subject module.Subject, dryRun bool) error {
+	// Step 1: Load the current state for the history entry and check access
+	existing, err := s.loadModule(id)
+	if err != nil {
+		return err
+	}
+	if err := s.authorize(existing.ID, subject, module.PermissionEdit); err != nil || dryRun {
+		return err
+	}
+	actor := subject.User
+
+	// Step 2: Move the module to the recycle bin and record the deletion
+	now := time.Now()
+	err = s.withTx(func(repo ModuleRepository, revisions RevisionRepository) error {
+		deleted, err := repo.SoftDeleteModule(existing.ID, actor, now)
+		if err != nil {
+			return fmt.Errorf("database error deleting module: %w", err)
+		}
+		if !deleted {
+			return ErrNotFound
+		}
+		existing.UpdatedAt = now
+		return recordRevision(revisions, existing, module.RevisionDelete, actor, []module.FieldChange{})
+	})
+	if err != nil {
+		return err
+	}
+	s.stats.invalidate()
+	s.deleted.Add("", 1)
+	return nil
+}
+
+// ListDeletedModules returns one page of the recycle bin.
+//
+// Parameters:
+//   - page: 1-based page number
+//   - pageSize: Number of modules per page (1-100)
+//
+// Returns:
+//   - *pagination.Page[*module.DeletedModuleResponse]: Deleted modules, most recently deleted first
+//   - error: Error if the data layer fails
+func (s *ModuleService) ListDeletedModules(page, pageSize int) (*pagination.Page[*module.DeletedModuleResponse], error) {
+	entities, total, err := s.repo.ListDeletedModules((page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("database error listing deleted modules: %w", err)
+	}
+
+	items := make([]*module.DeletedModuleResponse, len(entities))
+	for i, entity := range entities {
+		items[i] = &module.DeletedModuleResponse{
+			ModuleResponse: ToModuleResponse(entity),
+			DeletedAt:      entity.DeletedAt.Time,
+			DeletedBy:      entity.DeletedBy,
+		}
+	}
+
+	return &pagination.Page[*module.DeletedModuleResponse]{
+		Items:      items,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalItems: total,
+	}, nil
+}
+
+// RestoreModules takes modules out of the recycle bin.
+//
+// Parameters:
+//   - ids: Identifiers of the modules to restore (at most MaxBatchIds)
+//   - actor: Who restores the modules, recorded in the change history
+//
+// Returns:
+//   - []*module.ModuleResponse: Restored modules, in request order
+//   - []int: Requested IDs that were not in the recycle bin, in request order
+//   - error: Error if the data layer fails
+func (s *ModuleService) RestoreModules(ids []int, actor string) ([]*module.ModuleResponse, []int, error) {
+	unique := uniqueIDs(ids)
+
+	var restored []*module.ModuleResponse
+	var missing []int
+	err := s.withTx(func(repo ModuleRepository, revisions RevisionRepository) error {
+		// Step 1: Restore everything found in the recycle bin at once
+		restoredIDs, err := repo.RestoreModules(unique, time.Now())
+		if err != nil {
+			return fmt.Errorf("database error restoring modules: %w", err)
+		}
+
+		// Step 2: Load the restored modules
+		entities, err := repo.GetModulesByIds(restoredIDs)
+		if err != nil {
+			return fmt.Errorf("database error loading modules: %w", err)
+		}
+
+		byID := make(map[int]*module.Module, len(entities))
+		for _, entity := range entities {
+			byID[entity.ID] = entity
+		}
+
+		// Step 3: Record a revision per restored module, keeping the request order
+		restored = make([]*module.ModuleResponse, 0, len(entities))
+		missing = make([]int, 0)
+		for _, id := range unique {
+			entity, ok := byID[id]
+			if !ok {
+				missing = append(missing, id)
+				continue
+			}
+
+			if err := recordRevision(revisions, entity, module.RevisionRestore, actor, []module.FieldChange{}); err != nil {
+				return err
+			}
+			restored = append(restored, ToModuleResponse(entity))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(restored) > 0 {
+		s.stats.invalidate()
+	}
+	return restored, missing, nil
+}
+
+// PurgeModules permanently removes modules from the recycle bin.
+//
+// Parameters:
+//   - ids: Identifiers of the modules to purge (at most MaxBatchIds)
+//
+// Returns:
+//   - []int: Purged IDs, in request order
+//   - []int: Requested IDs that were not in the recycle bin, in request order
+//   - error: Error if the data layer fails
+//
+// Purge Behavior:
+//   - Only modules in the recycle bin can be purged; live modules are reported as missing
+//   - Tags assignments, dependencies in both directions, settings, the
+//     change history, the ACL and ownership transfers are removed together
+//     with the module
+//   - The module name becomes available again
+func (s *ModuleService) PurgeModules(ids []int) ([]int, []int, error) {
+	unique := uniqueIDs(ids)
+
+	purgedIDs, err := s.repo.PurgeModules(unique)
+	if err != nil {
+		return nil, nil, fmt.Errorf("database error purging modules: %w", err)
+	}
+
+	purged := make(map[int]bool, len(purgedIDs))
+	for _, id := range purgedIDs {
+		purged[id] = true
+	}
+
+	ordered := make([]int, 0, len(purgedIDs))
+	missing := make([]int, 0)
+	for _, id := range unique {
+		if purged[id] {
+			ordered = append(ordered, id)
+		} else {
+			missing = append(missing, id)
+		}
+	}
+	return ordered, missing, nil
+}
+
+// uniqueIDs removes duplicate IDs, keeping the first occurrence of each.
+func uniqueIDs(ids []int) []int {
+	seen := make(map[int]bool, len(ids))
+	unique := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,19 @@
+package module
+
+import "go_di_architecture/internal/domain/models/module"
+
+// RevisionRepository defines the data operations for the module change history.
+//
+// Implementations live in the infrastructure layer next to the module
+// repositories; the in-memory store implements both interfaces on one type.
+type RevisionRepository interface {
+	// AppendRevision stores a revision, assigning the next revision number of its module
+	AppendRevision(revision *module.ModuleRevision) error
+
+	// ListRevisions returns one page of matching revisions of a module in
+	// revision order plus the total number of matching revisions
+	ListRevisions(moduleID int, filter module.RevisionFilter, offset, limit int) ([]*module.ModuleRevision, int64, error)
+
+	// GetRevision returns one revision of a module, or nil if it does not exist
+	GetRevision(moduleID, revision int) (*module.ModuleRevision, error)
+}
@@ -0,0 +1,16 @@
+package module
+
+// StarRepository defines the data operations for per-user module favorites.
+//
+// Implementations live in the infrastructure layer next to the module
+// repositories; the module listing queries read the same data to filter by
+// ModuleFilter.StarredBy.
+type StarRepository interface {
+	// StarModule marks the module as a favorite of the user; starring it
+	// again is a no-op
+	StarModule(moduleID int, user string) error
+
+	// UnstarModule removes the module from the user's favorites; removing a
+	// star that does not exist is a no-op
+	UnstarModule(moduleID int, user string) error
+}
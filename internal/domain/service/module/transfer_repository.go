@@ -0,0 +1,28 @@
+package module
+
+import (
+	"time"
+
+	"go_di_architecture/internal/domain/models/module"
+)
+
+// TransferRepository defines the data operations for module ownership transfers.
+//
+// Implementations live in the infrastructure layer next to the module
+// repositories and must return (nil, nil) when a transfer is not found.
+type TransferRepository interface {
+	// CreateTransfer persists a new transfer and returns it with its generated ID
+	CreateTransfer(t *module.ModuleTransfer) (*module.ModuleTransfer, error)
+
+	// GetTransfer returns the transfer with the given ID, or nil if not found
+	GetTransfer(id int) (*module.ModuleTransfer, error)
+
+	// FindPendingTransfer returns the pending transfer of a module, expired or
+	// not, or nil if there is none
+	FindPendingTransfer(moduleID int) (*module.ModuleTransfer, error)
+
+	// ResolveTransfer moves a pending transfer to the given status; it reports
+	// false when the transfer is not pending anymore, so concurrent accepts
+	// cannot both succeed
+	ResolveTransfer(id int, status string, at time.Time) (bool, error)
+}
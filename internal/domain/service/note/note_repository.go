@@ -0,0 +1,25 @@
+package note
+
+import "go_di_architecture/internal/domain/models/module"
+
+// NoteRepository defines the data operations the note service depends on.
+//
+// Implementations live in the infrastructure layer next to the module
+// repositories:
+//   - InMemoryModuleRepository: stores notes alongside modules
+//   - NoteRepository (GORM): module_notes table
+type NoteRepository interface {
+	// AddNote stores a new note, filling in its ID
+	AddNote(note *module.ModuleNote) error
+
+	// ListNotes returns one page of the module's notes that are not deleted,
+	// newest first, together with their total count
+	ListNotes(moduleID, offset, limit int) ([]*module.ModuleNote, int64, error)
+
+	// GetNote returns a note of the module that is not deleted, or nil if
+	// there is none
+	GetNote(moduleID, noteID int) (*module.ModuleNote, error)
+
+	// DeleteNote soft-deletes a note, recording who deleted it
+	DeleteNote(moduleID, noteID int, actor string) error
+}
@@ -0,0 +1,238 @@
+package note
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go_di_architecture/internal/domain/apperror"
+	"go_di_architecture/internal/domain/guard"
+	"go_di_architecture/internal/domain/models/module"
+	"go_di_architecture/internal/domain/models/pagination"
+	moduleService "go_di_architecture/internal/domain/service/module"
+)
+
+// Note service errors
+var (
+	ErrNoteNotFound = apperror.New(http.StatusNotFound, "NOT_FOUND", "note.not_found", "note not found")
+	ErrNoteBody     = apperror.Validation("body", "note.body_required", "note body must not be blank")
+)
+
+// ModuleFinder checks a module exists for a subject.
+//
+// Implemented by the module service; declared here so notes follow the same
+// visibility rules as the module itself.
+type ModuleFinder interface {
+	ModuleExists(id string, subject module.Subject) (bool, error)
+}
+
+// NoteService implements business operations for module notes.
+//
+// Business Rule Enforcement:
+//  1. Notes belong to an existing module visible to the caller
+//  2. A note has an author and a body that is not blank
+//  3. Notes are never edited; deleting one hides it from listings but keeps
+//     the row with the deleting actor
+//
+// Usage Example:
+//
+//	service, err := note.NewNoteService(noteRepo, moduleService)
+//	created, err := service.AddNote("123", module.NoteRequest{Body: "Rotated credentials"}, subject)
+type NoteService struct {
+	repo    NoteRepository
+	modules ModuleFinder
+}
+
+// NewNoteService creates a new instance of NoteService.
+//
+// Parameters:
+//   - repo: Data access repository for notes
+//   - modules: Module lookup verifying the owning module is visible
+//
+// Returns:
+//   - *NoteService: A new service instance
+//   - error: guard.ErrNilDependency if a required dependency is nil
+func NewNoteService(repo NoteRepository, modules ModuleFinder) (*NoteService, error) {
+	if err := guard.Require("NewNoteService", guard.Dep("repo", repo), guard.Dep("modules", modules)); err != nil {
+		return nil, err
+	}
+	return &NoteService{repo: repo, modules: modules}, nil
+}
+
+// AddNote attaches a note to a module.
+//
+// Parameters:
+//   - moduleID: Identifier of the module
+//   - request: The note text
+//   - subject: Who writes the note; the module must be visible to it
+//
+// Returns:
+//   - *module.NoteResponse: The stored note
+//   - error: Error if business rules are violated
+//
+// Error Types:
+//   - moduleService.ErrNotFound: When the module does not exist or is hidden from the subject
+//   - ErrNoteBody: When the body only contains whitespace
+func (s *NoteService) AddNote(moduleID string, request module.NoteRequest, subject module.Subject) (*module.NoteResponse, error) {
+	// Step 1: Verify the module exists
+	id, err := s.findModule(moduleID, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 2: Validate the body
+	if strings.TrimSpace(request.Body) == "" {
+		return nil, ErrNoteBody
+	}
+
+	// Step 3: Store the note
+	note := &module.ModuleNote{
+		ModuleID:  id,
+		Author:    subject.User,
+		Body:      request.Body,
+		CreatedAt: time.Now(),
+	}
+	if err := s.repo.AddNote(note); err != nil {
+		return nil, fmt.Errorf("database error storing note: %w", err)
+	}
+	return ToNoteResponse(note), nil
+}
+
+// ListNotes returns one page of a module's notes, newest first.
+//
+// Parameters:
+//   - moduleID: Identifier of the module
+//   - subject: Who asks; the module must be visible to it
+//   - page: 1-based page number
+//   - pageSize: Number of notes per page (1-100)
+//
+// Returns:
+//   - *pagination.Page[*module.NoteResponse]: Notes with total count
+//   - error: moduleService.ErrNotFound if the module does not exist or is hidden from the subject, or a data layer error
+func (s *NoteService) ListNotes(moduleID string, subject module.Subject, page, pageSize int) (*pagination.Page[*module.NoteResponse], error) {
+	id, err := s.findModule(moduleID, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	notes, total, err := s.repo.ListNotes(id, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("database error listing notes: %w", err)
+	}
+
+	items := make([]*module.NoteResponse, len(notes))
+	for i, note := range notes {
+		items[i] = ToNoteResponse(note)
+	}
+	return &pagination.Page[*module.NoteResponse]{
+		Items:      items,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalItems: total,
+	}, nil
+}
+
+// DeleteNote soft-deletes a note of a module.
+//
+// Parameters:
+//   - moduleID: Identifier of the module
+//   - noteID: Identifier of the note
+//   - subject: Who deletes the note, kept with the deleted row; the module
+//     must be visible to it
+//
+// Returns:
+//   - error: Error if the note cannot be deleted
+//
+// Error Types:
+//   - moduleService.ErrNotFound: When the module does not exist or is hidden from the subject
+//   - ErrNoteNotFound: When the module has no such note or it is already deleted
+func (s *NoteService) DeleteNote(moduleID, noteID string, subject module.Subject) error {
+	// Step 1: Verify the module and note exist
+	id, err := s.findModule(moduleID, subject)
+	if err != nil {
+		return err
+	}
+	nid, err := strconv.Atoi(noteID)
+	if err != nil {
+		return ErrNoteNotFound.Wrap(err)
+	}
+
+	existing, err := s.repo.GetNote(id, nid)
+	if err != nil {
+		return fmt.Errorf("database error loading note: %w", err)
+	}
+	if existing == nil {
+		return ErrNoteNotFound
+	}
+
+	// Step 2: Hide the note
+	if err := s.repo.DeleteNote(id, nid, subject.User); err != nil {
+		return fmt.Errorf("database error deleting note: %w", err)
+	}
+	return nil
+}
+
+// ToNoteResponse converts a stored note to its API representation.
+//
+// Parameters:
+//   - note: The stored note
+//
+// Returns:
+//   - *module.NoteResponse: The note with its body rendered as safe HTML
+func ToNoteResponse(note *module.ModuleNote) *module.NoteResponse {
+	return &module.NoteResponse{
+		ID:        note.ID,
+		ModuleID:  note.ModuleID,
+		Author:    note.Author,
+		Body:      note.Body,
+		BodyHTML:  renderBody(note.Body),
+		CreatedAt: note.CreatedAt,
+	}
+}
+
+// renderBody turns a note body into HTML that is safe to embed in a page.
+//
+// Every character with a meaning in HTML is escaped, so Markdown syntax and
+// any markup in the note appear as written; blank lines separate paragraphs
+// and single line breaks become <br>.
+func renderBody(body string) string {
+	body = strings.ReplaceAll(body, "\r\n", "\n")
+
+	var rendered strings.Builder
+	for _, paragraph := range strings.Split(body, "\n\n") {
+		paragraph = strings.Trim(paragraph, "\n")
+		if strings.TrimSpace(paragraph) == "" {
+			continue
+		}
+
+		lines := strings.Split(paragraph, "\n")
+		for i, line := range lines {
+			lines[i] = html.EscapeString(line)
+		}
+		rendered.WriteString("<p>")
+		rendered.WriteString(strings.Join(lines, "<br>\n"))
+		rendered.WriteString("</p>\n")
+	}
+	return strings.TrimSuffix(rendered.String(), "\n")
+}
+
+// findModule parses a module ID and verifies the module exists and is
+// visible to the subject.
+func (s *NoteService) findModule(moduleID string, subject module.Subject) (int, error) {
+	id, err := strconv.Atoi(moduleID)
+	if err != nil {
+		return 0, moduleService.ErrNotFound.Wrap(err)
+	}
+
+	exists, err := s.modules.ModuleExists(moduleID, subject)
+	if err != nil {
+		return 0, err
+	}
+	if !exists {
+		return 0, moduleService.ErrNotFound
+	}
+	return id, nil
+}
@@ -0,0 +1,22 @@
+package privacy
+
+import (
+	"time"
+
+	"go_di_architecture/internal/domain/models/privacy"
+)
+
+// UserDataRepository defines the data operations on records linked to a user.
+//
+// Implementations live in the infrastructure layer next to the module
+// repositories. Users are identified by the actor names stored as owners,
+// revision actors, transfer parties and "user:" ACL principals.
+type UserDataRepository interface {
+	// FindUserData returns every record linked to the user, including
+	// modules in the recycle bin and archived modules
+	FindUserData(user string) (*privacy.UserRecords, error)
+
+	// EraseUser replaces the user with the pseudonym in every record in one
+	// transaction and expires pending transfers to the user
+	EraseUser(user, pseudonym string, at time.Time) (*privacy.ErasureCounts, error)
+}
@@ -0,0 +1,232 @@
+package privacy
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"go_di_architecture/internal/domain/apperror"
+	"go_di_architecture/internal/domain/guard"
+	"go_di_architecture/internal/domain/models/module"
+	"go_di_architecture/internal/domain/models/privacy"
+)
+
+// ErrInvalidUser is returned for blank or over-long user names.
+var ErrInvalidUser = apperror.Validation("user", "privacy.invalid_user", "invalid user")
+
+// retained lists the places an erasure does not reach.
+var retained = []string{
+	"Backup archives and export files already in object storage keep the user until they are deleted",
+	"Notifications already delivered by email, webhook or Slack cannot be recalled",
+	"The API stores no sessions or request logs; nothing else is linked to the user",
+}
+
+// PrivacyService exports and erases the data linked to a user.
+//
+// Users are the actor names sent with requests. They appear as module owners,
+// as the actor of change history revisions and inside owner changes, as
+// parties of ownership transfers, as "user:" ACL principals and as owners of
+// archived modules.
+//
+// Erasure replaces the user everywhere with a random pseudonym instead of
+// deleting records, so modules, their history and their access restrictions
+// stay intact. The pseudonym is neither stored nor reported.
+//
+// Usage Example:
+//
+//	service, err := privacy.NewPrivacyService(repo)
+//	export, err := service.ExportUserData("jane")
+//	report, err := service.EraseUser("jane")
+type PrivacyService struct {
+	repo UserDataRepository
+}
+
+// NewPrivacyService creates a new instance of PrivacyService.
+//
+// Parameters:
+//   - repo: Data access to records linked to users
+//
+// Returns:
+//   - *PrivacyService: A new service instance
+//   - error: guard.ErrNilDependency if a required dependency is nil
+func NewPrivacyService(repo UserDataRepository) (*PrivacyService, error) {
+	if err := guard.Require("NewPrivacyService", guard.Dep("repo", repo)); err != nil {
+		return nil, err
+	}
+	return &PrivacyService{repo: repo}, nil
+}
+
+// ExportUserData collects all data linked to a user.
+//
+// Parameters:
+//   - user: The user whose data is exported
+//
+// Returns:
+//   - *privacy.UserDataExport: The data; empty lists when nothing is linked
+//   - error: Error if the user name is invalid or the data layer fails
+//
+// Error Types:
+//   - ErrInvalidUser: When the user name is blank or too long
+func (s *PrivacyService) ExportUserData(user string) (*privacy.UserDataExport, error) {
+	// Step 1: Validate the user
+	user, err := normalizeUser(user)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 2: Load the linked records
+	records, err := s.repo.FindUserData(user)
+	if err != nil {
+		return nil, fmt.Errorf("database error loading user data: %w", err)
+	}
+
+	// Step 3: Build the export document
+	export := &privacy.UserDataExport{
+		User:            user,
+		GeneratedAt:     time.Now(),
+		OwnedModules:    toUserModules(records.OwnedModules),
+		DeletedModules:  toUserModules(records.DeletedModules),
+		Revisions:       make([]privacy.UserRevision, len(records.Revisions)),
+		Transfers:       make([]privacy.UserTransfer, len(records.Transfers)),
+		AccessGrants:    make([]privacy.UserAccessGrant, len(records.AccessGrants)),
+		Notes:           make([]privacy.UserNote, len(records.Notes)),
+		Stars:           make([]privacy.UserStar, len(records.Stars)),
+		ArchivedModules: make([]privacy.UserArchivedModule, len(records.ArchivedModules)),
+	}
+	for i, revision := range records.Revisions {
+		fields := []module.FieldChange{}
+		if err := json.Unmarshal([]byte(revision.Changes), &fields); err != nil {
+			return nil, fmt.Errorf("corrupt changes in revision %d of module %d: %w", revision.Revision, revision.ModuleID, err)
+		}
+		export.Revisions[i] = privacy.UserRevision{
+			ModuleID:  revision.ModuleID,
+			Revision:  revision.Revision,
+			Action:    revision.Action,
+			ByUser:    revision.Actor == user,
+			ChangedAt: revision.CreatedAt,
+			Changes:   fields,
+		}
+	}
+	for i, transfer := range records.Transfers {
+		export.Transfers[i] = privacy.UserTransfer{
+			ID:          transfer.ID,
+			ModuleID:    transfer.ModuleID,
+			FromOwner:   transfer.FromOwner,
+			ToOwner:     transfer.ToOwner,
+			RequestedBy: transfer.RequestedBy,
+			Status:      transfer.Status,
+			CreatedAt:   transfer.CreatedAt,
+			ExpiresAt:   transfer.ExpiresAt,
+			ResolvedAt:  transfer.ResolvedAt,
+		}
+	}
+	for i, entry := range records.AccessGrants {
+		export.AccessGrants[i] = privacy.UserAccessGrant{
+			ModuleID:   entry.ModuleID,
+			Permission: entry.Permission,
+			GrantedAt:  entry.CreatedAt,
+		}
+	}
+	for i, note := range records.Notes {
+		export.Notes[i] = privacy.UserNote{
+			ID:        note.ID,
+			ModuleID:  note.ModuleID,
+			Body:      note.Body,
+			Deleted:   note.DeletedAt.Valid,
+			CreatedAt: note.CreatedAt,
+		}
+	}
+	for i, star := range records.Stars {
+		export.Stars[i] = privacy.UserStar{
+			ModuleID:  star.ModuleID,
+			StarredAt: star.CreatedAt,
+		}
+	}
+	for i, record := range records.ArchivedModules {
+		export.ArchivedModules[i] = privacy.UserArchivedModule{
+			ModuleID:    record.ModuleID,
+			Name:        record.Name,
+			Description: record.Description,
+			CreatedAt:   record.CreatedAt,
+			UpdatedAt:   record.UpdatedAt,
+			ArchivedAt:  record.ArchivedAt,
+		}
+	}
+
+	return export, nil
+}
+
+// EraseUser replaces a user with a random pseudonym in all stored data.
+//
+// Parameters:
+//   - user: The user to erase
+//
+// Returns:
+//   - *privacy.ErasureReport: What was changed and what the erasure does not reach
+//   - error: Error if the user name is invalid or the data layer fails
+//
+// Error Types:
+//   - ErrInvalidUser: When the user name is blank, too long or already a pseudonym
+//
+// Erasure Behavior:
+//   - All records change in one transaction; a failure changes nothing
+//   - Pending transfers to the user are expired, so they no longer block
+//     new transfers of the module
+//   - Erasing a user without data succeeds with zero counts
+func (s *PrivacyService) EraseUser(user string) (*privacy.ErasureReport, error) {
+	// Step 1: Validate the user
+	user, err := normalizeUser(user)
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(user, privacy.PseudonymPrefix) {
+		return nil, ErrInvalidUser.Detailf("%q is already erased", user)
+	}
+
+	// Step 2: Pick a pseudonym that cannot be traced back to the user
+	suffix := make([]byte, 8)
+	if _, err := rand.Read(suffix); err != nil {
+		return nil, fmt.Errorf("generate pseudonym: %w", err)
+	}
+	pseudonym := privacy.PseudonymPrefix + hex.EncodeToString(suffix)
+
+	// Step 3: Replace the user everywhere
+	report := &privacy.ErasureReport{User: user, StartedAt: time.Now(), Retained: retained}
+	counts, err := s.repo.EraseUser(user, pseudonym, report.StartedAt)
+	if err != nil {
+		return nil, fmt.Errorf("database error erasing user: %w", err)
+	}
+	report.Erased = *counts
+	report.FinishedAt = time.Now()
+
+	return report, nil
+}
+
+// normalizeUser trims a user name and checks its length.
+func normalizeUser(user string) (string, error) {
+	user = strings.TrimSpace(user)
+	if user == "" || len(user) > module.MaxActorLength {
+		return "", ErrInvalidUser.Detailf("user must be 1-%d characters", module.MaxActorLength)
+	}
+	return user, nil
+}
+
+// toUserModules converts modules to their export representation.
+func toUserModules(modules []module.Module) []privacy.UserModule {
+	converted := make([]privacy.UserModule, len(modules))
+	for i, m := range modules {
+		converted[i] = privacy.UserModule{
+			ID:           m.ID,
+			Name:         m.Name,
+			Description:  m.Description,
+			IsActive:     m.IsActive,
+			InRecycleBin: m.DeletedAt.Valid,
+			CreatedAt:    m.CreatedAt,
+			UpdatedAt:    m.UpdatedAt,
+		}
+	}
+	return converted
+}
@@ -0,0 +1,163 @@
+package projection
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"go_di_architecture/internal/domain/guard"
+	"go_di_architecture/internal/domain/models/module"
+)
+
+// DefaultBatchSize is the number of revisions replayed per batch.
+const DefaultBatchSize = 500
+
+// Progress is the state of a running replay, reported after every batch.
+type Progress struct {
+	// Revisions replayed so far
+	Replayed int64 `json:"replayed"`
+
+	// Revisions in the history when the replay started; revisions written
+	// meanwhile are replayed as well, so Replayed may end above Total
+	Total int64 `json:"total"`
+
+	// Distinct modules replayed so far
+	Modules int `json:"modules"`
+}
+
+// Report is the outcome of a replay.
+//
+// Example:
+//
+//	{"revisions": 1250, "modules": 310, "staleDeleted": 2, "duration": "1.52s"}
+type Report struct {
+	// Revisions replayed
+	Revisions int64 `json:"revisions"`
+
+	// Modules whose summary was written
+	Modules int `json:"modules"`
+
+	// Summaries of modules without revisions that were deleted
+	StaleDeleted int64 `json:"staleDeleted"`
+
+	// How long the replay took
+	Duration string `json:"duration"`
+}
+
+// ProjectionService rebuilds the read models projected from the change
+// history.
+//
+// The revision repository keeps the module summaries up to date as changes
+// are recorded; a replay recomputes them from the module_revisions table, e.g.
+// after the read model was lost or restored from an older backup than the
+// history. Summaries are upserted and never replaced with an older revision,
+// so a replay can be interrupted and run again, and the API can keep serving
+// writes meanwhile.
+//
+// Usage Example:
+//
+//	service, err := projection.NewProjectionService(repo, projection.DefaultBatchSize)
+//	report, err := service.RebuildSummaries(ctx, func(p projection.Progress) {
+//	    fmt.Printf("%d/%d revisions\n", p.Replayed, p.Total)
+//	})
+type ProjectionService struct {
+	repo      SummaryRepository
+	batchSize int
+}
+
+// NewProjectionService creates a new instance of ProjectionService.
+//
+// Parameters:
+//   - repo: Data access of the history and the summaries
+//   - batchSize: Revisions read and summaries written per batch (DefaultBatchSize when not positive)
+//
+// Returns:
+//   - *ProjectionService: A new service instance
+//   - error: guard.ErrNilDependency if a required dependency is nil
+func NewProjectionService(repo SummaryRepository, batchSize int) (*ProjectionService, error) {
+	if err := guard.Require("NewProjectionService", guard.Dep("repo", repo)); err != nil {
+		return nil, err
+	}
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	return &ProjectionService{repo: repo, batchSize: batchSize}, nil
+}
+
+// RebuildSummaries replays the change history into the module summaries.
+//
+// Revisions are read in the order they were written. Each batch upserts the
+// summary of the latest revision per module it contains, so a module changed
+// many times is written once per batch. Once the history is replayed, the
+// summaries of modules without revisions are deleted.
+//
+// Parameters:
+//   - ctx: Context cancelling the replay between batches
+//   - progress: Called after every batch (nil reports nothing)
+//
+// Returns:
+//   - *Report: The outcome, also when the replay failed part way
+//   - error: Error if a query fails or the context is cancelled
+func (s *ProjectionService) RebuildSummaries(ctx context.Context, progress func(Progress)) (*Report, error) {
+	started := time.Now()
+	report := &Report{}
+	defer func() { report.Duration = time.Since(started).Round(time.Millisecond).String() }()
+
+	total, err := s.repo.CountRevisions()
+	if err != nil {
+		return report, err
+	}
+
+	// Step 1: Replay the history batch by batch
+	modules := make(map[int]bool)
+	afterID := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+
+		revisions, err := s.repo.ListRevisionsAfter(afterID, s.batchSize)
+		if err != nil {
+			return report, err
+		}
+		if len(revisions) == 0 {
+			break
+		}
+
+		if err := s.repo.UpsertSummaries(latestSummaries(revisions)); err != nil {
+			return report, err
+		}
+
+		afterID = revisions[len(revisions)-1].ID
+		report.Revisions += int64(len(revisions))
+		for _, revision := range revisions {
+			modules[revision.ModuleID] = true
+		}
+		report.Modules = len(modules)
+		if progress != nil {
+			progress(Progress{Replayed: report.Revisions, Total: total, Modules: report.Modules})
+		}
+	}
+
+	// Step 2: Drop summaries the history no longer backs
+	report.StaleDeleted, err = s.repo.DeleteStaleSummaries()
+	return report, err
+}
+
+// latestSummaries returns the summary of the latest revision of each module,
+// ordered by module ID.
+func latestSummaries(revisions []*module.ModuleRevision) []*module.ModuleSummary {
+	latest := make(map[int]*module.ModuleRevision)
+	for _, revision := range revisions {
+		if current, ok := latest[revision.ModuleID]; !ok || revision.Revision > current.Revision {
+			latest[revision.ModuleID] = revision
+		}
+	}
+
+	summaries := make([]*module.ModuleSummary, 0, len(latest))
+	for _, revision := range latest {
+		summaries = append(summaries, module.SummaryOf(revision))
+	}
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].ModuleID < summaries[j].ModuleID })
+	return summaries
+}
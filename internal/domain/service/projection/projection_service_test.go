@@ -0,0 +1,183 @@
+package projection_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"go_di_architecture/internal/config"
+	"go_di_architecture/internal/domain/models/module"
+	"go_di_architecture/internal/domain/service/projection"
+	"go_di_architecture/internal/infra/db"
+	repository "go_di_architecture/internal/infra/db/module"
+
+	"gorm.io/gorm"
+)
+
+// openSQLite opens a migrated SQLite database in a temporary file.
+func openSQLite(t *testing.T) *gorm.DB {
+	t.Helper()
+	database, err := db.Open(config.DatabaseConfig{
+		Driver: config.DriverSQLite,
+		DSN:    "file:" + filepath.Join(t.TempDir(), "modules.db"),
+	})
+	if err != nil {
+		t.Fatalf("db.Open() error = %v", err)
+	}
+	sqlDB, err := database.DB()
+	if err != nil {
+		t.Fatalf("DB() error = %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	if err := db.Migrate(database); err != nil {
+		t.Fatalf("db.Migrate() error = %v", err)
+	}
+	return database
+}
+
+// appendRevisions records changes of modules, one revision per action.
+func appendRevisions(t *testing.T, revisions *repository.RevisionRepository, moduleID int, name string, actions ...string) {
+	t.Helper()
+	for i, action := range actions {
+		err := revisions.AppendRevision(&module.ModuleRevision{
+			ModuleID:  moduleID,
+			Action:    action,
+			Actor:     "alice",
+			Name:      name,
+			IsActive:  action != module.RevisionDelete,
+			Changes:   "[]",
+			CreatedAt: time.Date(2026, time.January, 2, 3, moduleID, i, 0, time.UTC),
+		})
+		if err != nil {
+			t.Fatalf("AppendRevision(%d, %s) error = %v", moduleID, action, err)
+		}
+	}
+}
+
+// listSummaries returns all stored summaries ordered by module ID.
+func listSummaries(t *testing.T, database *gorm.DB) []module.ModuleSummary {
+	t.Helper()
+	var summaries []module.ModuleSummary
+	if err := database.Order("module_id").Find(&summaries).Error; err != nil {
+		t.Fatalf("list summaries: %v", err)
+	}
+	for i := range summaries {
+		summaries[i].ChangedAt = summaries[i].ChangedAt.UTC()
+	}
+	return summaries
+}
+
+// TestRebuildSummaries checks a replay rebuilds the summaries kept by the
+// revision repository from scratch, reports its progress, drops summaries
+// without history and leaves the read model unchanged when run again.
+func TestRebuildSummaries(t *testing.T) {
+	database := openSQLite(t)
+	revisions := repository.NewRevisionRepository(database)
+
+	// Interleaved changes of three modules, so batches hold partial histories
+	appendRevisions(t, revisions, 1, "Payments", module.RevisionCreate, module.RevisionUpdate)
+	appendRevisions(t, revisions, 2, "Billing", module.RevisionCreate)
+	appendRevisions(t, revisions, 1, "Payments v2", module.RevisionUpdate)
+	appendRevisions(t, revisions, 3, "Search", module.RevisionCreate, module.RevisionDelete)
+	appendRevisions(t, revisions, 2, "Billing", module.RevisionSubmit, module.RevisionApprove)
+
+	live := listSummaries(t, database)
+	want := []module.ModuleSummary{
+		{ModuleID: 1, Name: "Payments v2", IsActive: true, Revision: 3, Action: module.RevisionUpdate, ChangedAt: time.Date(2026, time.January, 2, 3, 1, 0, 0, time.UTC)},
+		{ModuleID: 2, Name: "Billing", IsActive: true, Revision: 3, Action: module.RevisionApprove, ChangedAt: time.Date(2026, time.January, 2, 3, 2, 1, 0, time.UTC)},
+		{ModuleID: 3, Name: "Search", IsActive: false, Revision: 2, Action: module.RevisionDelete, ChangedAt: time.Date(2026, time.January, 2, 3, 3, 1, 0, time.UTC)},
+	}
+	if !reflect.DeepEqual(live, want) {
+		t.Fatalf("summaries kept by AppendRevision = %+v, want %+v", live, want)
+	}
+
+	// Lose the read model, leaving a summary of a module without history
+	if err := database.Exec("DELETE FROM module_summaries").Error; err != nil {
+		t.Fatal(err)
+	}
+	orphan := module.ModuleSummary{ModuleID: 99, Name: "Gone", Revision: 1, Action: module.RevisionCreate, ChangedAt: time.Now()}
+	if err := database.Create(&orphan).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	service, err := projection.NewProjectionService(repository.NewSummaryRepository(database), 3)
+	if err != nil {
+		t.Fatalf("NewProjectionService() error = %v", err)
+	}
+	var progress []projection.Progress
+	report, err := service.RebuildSummaries(context.Background(), func(p projection.Progress) {
+		progress = append(progress, p)
+	})
+	if err != nil {
+		t.Fatalf("RebuildSummaries() error = %v", err)
+	}
+
+	if report.Revisions != 8 || report.Modules != 3 || report.StaleDeleted != 1 {
+		t.Errorf("RebuildSummaries() report = %+v, want 8 revisions of 3 modules and 1 stale summary", report)
+	}
+	wantProgress := []projection.Progress{
+		{Replayed: 3, Total: 8, Modules: 2},
+		{Replayed: 6, Total: 8, Modules: 3},
+		{Replayed: 8, Total: 8, Modules: 3},
+	}
+	if !reflect.DeepEqual(progress, wantProgress) {
+		t.Errorf("progress = %+v, want %+v", progress, wantProgress)
+	}
+	if got := listSummaries(t, database); !reflect.DeepEqual(got, want) {
+		t.Errorf("rebuilt summaries = %+v, want %+v", got, want)
+	}
+
+	// Replaying again changes nothing
+	report, err = service.RebuildSummaries(context.Background(), nil)
+	if err != nil || report.Revisions != 8 || report.StaleDeleted != 0 {
+		t.Errorf("second RebuildSummaries() = %+v, %v, want 8 revisions and no stale summary", report, err)
+	}
+	if got := listSummaries(t, database); !reflect.DeepEqual(got, want) {
+		t.Errorf("summaries after second replay = %+v, want %+v", got, want)
+	}
+}
+
+// TestUpsertSummariesKeepsNewerRevision checks a replay running behind live
+// writes does not roll summaries back to an older revision.
+func TestUpsertSummariesKeepsNewerRevision(t *testing.T) {
+	database := openSQLite(t)
+	revisions := repository.NewRevisionRepository(database)
+	appendRevisions(t, revisions, 1, "Payments", module.RevisionCreate, module.RevisionUpdate)
+
+	older := &module.ModuleSummary{ModuleID: 1, Name: "Stale", IsActive: true, Revision: 1, Action: module.RevisionCreate, ChangedAt: time.Now()}
+	if err := repository.NewSummaryRepository(database).UpsertSummaries([]*module.ModuleSummary{older}); err != nil {
+		t.Fatalf("UpsertSummaries() error = %v", err)
+	}
+
+	got := listSummaries(t, database)
+	if len(got) != 1 || got[0].Revision != 2 || got[0].Name != "Payments" {
+		t.Errorf("summaries = %+v, want revision 2 of Payments", got)
+	}
+}
+
+// TestRebuildSummariesCancelled checks a cancelled replay stops before
+// writing anything.
+func TestRebuildSummariesCancelled(t *testing.T) {
+	database := openSQLite(t)
+	appendRevisions(t, repository.NewRevisionRepository(database), 1, "Payments", module.RevisionCreate)
+	if err := database.Exec("DELETE FROM module_summaries").Error; err != nil {
+		t.Fatal(err)
+	}
+
+	service, err := projection.NewProjectionService(repository.NewSummaryRepository(database), 0)
+	if err != nil {
+		t.Fatalf("NewProjectionService() error = %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := service.RebuildSummaries(ctx, nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("RebuildSummaries() error = %v, want context.Canceled", err)
+	}
+	if got := listSummaries(t, database); len(got) != 0 {
+		t.Errorf("summaries = %+v, want none", got)
+	}
+}
@@ -0,0 +1,27 @@
+package projection
+
+import (
+	"go_di_architecture/internal/domain/models/module"
+)
+
+// SummaryRepository defines the data operations of replaying the change
+// history into the module summaries.
+//
+// Implementations live in the infrastructure layer next to the module
+// repositories.
+type SummaryRepository interface {
+	// CountRevisions returns the number of revisions of all modules
+	CountRevisions() (int64, error)
+
+	// ListRevisionsAfter returns up to limit revisions with a record ID above
+	// afterID, ordered by record ID
+	ListRevisionsAfter(afterID, limit int) ([]*module.ModuleRevision, error)
+
+	// UpsertSummaries writes summaries in one transaction, keeping stored
+	// summaries of a newer revision
+	UpsertSummaries(summaries []*module.ModuleSummary) error
+
+	// DeleteStaleSummaries deletes the summaries of modules without revisions
+	// and returns how many were deleted
+	DeleteStaleSummaries() (int64, error)
+}
@@ -0,0 +1,19 @@
+package quota
+
+import (
+	"go_di_architecture/internal/domain/models/quota"
+)
+
+// QuotaRepository defines the data operations of the API key request counters.
+//
+// Implementations live in the infrastructure layer next to the module
+// repositories.
+type QuotaRepository interface {
+	// AddAPIKeyUsage adds the counts to the stored counters of each key and
+	// month, creating missing counters, in one transaction
+	AddAPIKeyUsage(counts []quota.APIKeyUsage) error
+
+	// GetAPIKeyUsage returns the stored number of requests of a key in a month
+	// (YYYY-MM), zero when nothing is stored
+	GetAPIKeyUsage(apiKey, month string) (int64, error)
+}
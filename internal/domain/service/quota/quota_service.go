@@ -0,0 +1,201 @@
+package quota
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go_di_architecture/internal/domain/guard"
+	"go_di_architecture/internal/domain/models/quota"
+)
+
+// counterKey identifies the counter of an API key in one month.
+type counterKey struct {
+	apiKey string
+	month  string
+}
+
+// QuotaService counts requests per API key and month and enforces quotas.
+//
+// Requests are counted in memory and written to the database by Flush, like
+// the module usage counters. Stored counts are loaded once per key and month
+// and reloaded after every flush, so instances sharing a database see each
+// other's requests within one flush interval; until then a key may exceed
+// its quota by the requests other instances accepted meanwhile.
+//
+// Usage Example:
+//
+//	service, err := quota.NewQuotaService(repo, map[string]int64{"ci": 10000})
+//	usage, err := service.Consume("ci", time.Now())
+//	err = service.Flush(ctx)
+type QuotaService struct {
+	repo   QuotaRepository
+	limits map[string]int64
+
+	mu      sync.Mutex
+	stored  map[counterKey]int64
+	pending map[counterKey]int64
+}
+
+// NewQuotaService creates a new instance of QuotaService.
+//
+// Parameters:
+//   - repo: Data access of the request counters
+//   - limits: Monthly request limit per API key name; keys without a limit
+//     are counted but never rejected
+//
+// Returns:
+//   - *QuotaService: A new service instance
+//   - error: guard.ErrNilDependency if a required dependency is nil
+func NewQuotaService(repo QuotaRepository, limits map[string]int64) (*QuotaService, error) {
+	if err := guard.Require("NewQuotaService", guard.Dep("repo", repo)); err != nil {
+		return nil, err
+	}
+	return &QuotaService{
+		repo:    repo,
+		limits:  limits,
+		stored:  make(map[counterKey]int64),
+		pending: make(map[counterKey]int64),
+	}, nil
+}
+
+// Consume counts one request of an API key unless its quota is used up.
+//
+// Parameters:
+//   - apiKey: Name of the API key
+//   - now: Time of the request; its UTC month selects the counter
+//
+// Returns:
+//   - *quota.AccountUsage: Consumption including the request, or excluding
+//     it when rejected
+//   - error: Error if the quota is used up or the stored count cannot be loaded
+//
+// Error Types:
+//   - quota.ErrExceeded: When the key already made as many requests as its limit
+func (s *QuotaService) Consume(apiKey string, now time.Time) (*quota.AccountUsage, error) {
+	// Step 1: Load the stored count of the month outside the lock
+	key := counterKey{apiKey: apiKey, month: now.UTC().Format(quota.MonthFormat)}
+	if err := s.load(key); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Step 2: Reject the request once the quota is used up
+	requests := s.stored[key] + s.pending[key]
+	if limit, limited := s.limits[apiKey]; limited && requests >= limit {
+		return s.usage(key, requests, now), quota.ErrExceeded
+	}
+
+	// Step 3: Count the request
+	s.pending[key]++
+	return s.usage(key, requests+1, now), nil
+}
+
+// GetAccountUsage reports the consumption of an API key in the current month.
+//
+// Parameters:
+//   - apiKey: Name of the API key
+//   - now: Current time; its UTC month is reported
+//
+// Returns:
+//   - *quota.AccountUsage: Requests including those not yet flushed
+//   - error: Error if the stored count cannot be loaded
+func (s *QuotaService) GetAccountUsage(apiKey string, now time.Time) (*quota.AccountUsage, error) {
+	key := counterKey{apiKey: apiKey, month: now.UTC().Format(quota.MonthFormat)}
+	if err := s.load(key); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.usage(key, s.stored[key]+s.pending[key], now), nil
+}
+
+// Flush writes the requests counted since the last flush to the database.
+//
+// Parameters:
+//   - ctx: Context of the flush (unused; the signature matches scheduler jobs)
+//
+// Returns:
+//   - error: Error if the write fails; the counts are kept for the next flush
+func (s *QuotaService) Flush(ctx context.Context) error {
+	// Step 1: Take the pending counts, so requests keep counting meanwhile;
+	// they stay in the cached totals until the reload
+	s.mu.Lock()
+	pending := s.pending
+	s.pending = make(map[counterKey]int64)
+	for key, requests := range pending {
+		if _, cached := s.stored[key]; cached {
+			s.stored[key] += requests
+		}
+	}
+	s.mu.Unlock()
+
+	counts := make([]quota.APIKeyUsage, 0, len(pending))
+	for key, requests := range pending {
+		counts = append(counts, quota.APIKeyUsage{APIKey: key.apiKey, Month: key.month, Requests: requests})
+	}
+
+	// Step 2: Add them to the stored counters
+	if len(counts) > 0 {
+		if err := s.repo.AddAPIKeyUsage(counts); err != nil {
+			s.mu.Lock()
+			for key, requests := range pending {
+				s.pending[key] += requests
+				if _, cached := s.stored[key]; cached {
+					s.stored[key] -= requests
+				}
+			}
+			s.mu.Unlock()
+			return fmt.Errorf("database error flushing API key usage: %w", err)
+		}
+	}
+
+	// Step 3: Forget the stored counts, so the next request loads the counts
+	// of all instances
+	s.mu.Lock()
+	s.stored = make(map[counterKey]int64)
+	s.mu.Unlock()
+	return nil
+}
+
+// load caches the stored count of a key and month unless it is cached.
+func (s *QuotaService) load(key counterKey) error {
+	s.mu.Lock()
+	_, cached := s.stored[key]
+	s.mu.Unlock()
+	if cached {
+		return nil
+	}
+
+	requests, err := s.repo.GetAPIKeyUsage(key.apiKey, key.month)
+	if err != nil {
+		return fmt.Errorf("database error loading API key usage: %w", err)
+	}
+
+	s.mu.Lock()
+	if _, cached := s.stored[key]; !cached {
+		s.stored[key] = requests
+	}
+	s.mu.Unlock()
+	return nil
+}
+
+// usage builds the consumption report of a key; the caller holds s.mu.
+func (s *QuotaService) usage(key counterKey, requests int64, now time.Time) *quota.AccountUsage {
+	report := &quota.AccountUsage{
+		APIKey:   key.apiKey,
+		Month:    key.month,
+		Requests: requests,
+		ResetsAt: quota.MonthStart(now).AddDate(0, 1, 0),
+	}
+	if limit, limited := s.limits[key.apiKey]; limited {
+		remaining := max(limit-requests, 0)
+		report.Limit = &limit
+		report.Remaining = &remaining
+	}
+	return report
+}
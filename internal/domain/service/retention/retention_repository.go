@@ -0,0 +1,33 @@
+package retention
+
+import (
+	"time"
+
+	"go_di_architecture/internal/domain/models/retention"
+)
+
+// RetentionRepository defines the data operations of the retention rules.
+//
+// Implementations live in the infrastructure layer next to the module
+// repositories. Every write re-checks the rule condition, so records that
+// changed since they were listed are left alone.
+type RetentionRepository interface {
+	// CountExpiredRevisions returns the number of revisions created before the
+	// cutoff that are not the latest revision of their module
+	CountExpiredRevisions(cutoff time.Time) (int, error)
+
+	// PurgeExpiredRevisions deletes up to limit expired revisions and returns
+	// how many were deleted
+	PurgeExpiredRevisions(cutoff time.Time, limit int) (int, error)
+
+	// ListArchiveCandidates returns the inactive modules outside the recycle
+	// bin without a pending activation that were last changed before the
+	// cutoff, ordered by ID
+	ListArchiveCandidates(cutoff time.Time) ([]retention.ArchiveCandidate, error)
+
+	// ArchiveModule copies a candidate to the module_archive table and removes
+	// it with its tags, dependencies, settings, history, ACL, transfers and
+	// usage counters; it reports false when the module is no longer a
+	// candidate or gained dependents
+	ArchiveModule(id int, cutoff, at time.Time) (bool, error)
+}
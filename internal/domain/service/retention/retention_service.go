@@ -0,0 +1,221 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go_di_architecture/internal/domain/guard"
+	"go_di_architecture/internal/domain/models/retention"
+)
+
+// JobKind is the kind of manual retention runs in the jobs API.
+const JobKind = "retention.run"
+
+// purgeBatchSize is the number of revisions deleted per statement.
+const purgeBatchSize = 500
+
+// RetentionService applies the configured data retention rules.
+//
+// Rules run in configuration order. A failing rule is recorded in the report
+// and does not stop the remaining rules. In dry-run mode records are only
+// counted, so operators can review the effect of a policy before enabling it.
+//
+// Modules archived by the policy are removed without events; the module
+// statistics catch up once their cache expires.
+//
+// Usage Example:
+//
+//	rules, _ := retention.ParseRules("revisions.purge=365d;modules.archive=730d")
+//	service, err := retention.NewRetentionService(repo, rules, false, 24*time.Hour)
+//	report, err := service.Apply(ctx, time.Now())
+type RetentionService struct {
+	repo     RetentionRepository
+	rules    []retention.Rule
+	dryRun   bool
+	interval time.Duration
+
+	// running serializes executions that change data
+	running sync.Mutex
+
+	mu      sync.Mutex
+	lastRun *retention.Report
+}
+
+// NewRetentionService creates a new instance of RetentionService.
+//
+// Parameters:
+//   - repo: Data access of the retention rules
+//   - rules: Rules to apply (none disables the policy)
+//   - dryRun: Only report what scheduled and manual runs would change
+//   - interval: Time between scheduled runs, reported by Status
+//
+// Returns:
+//   - *RetentionService: A new service instance
+//   - error: guard.ErrNilDependency if a required dependency is nil
+func NewRetentionService(repo RetentionRepository, rules []retention.Rule, dryRun bool, interval time.Duration) (*RetentionService, error) {
+	if err := guard.Require("NewRetentionService", guard.Dep("repo", repo)); err != nil {
+		return nil, err
+	}
+	return &RetentionService{repo: repo, rules: rules, dryRun: dryRun, interval: interval}, nil
+}
+
+// Status returns the configured policy and the outcome of the last run.
+//
+// Returns:
+//   - *retention.Status: The policy
+func (s *RetentionService) Status() *retention.Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rules := s.rules
+	if rules == nil {
+		rules = []retention.Rule{}
+	}
+	return &retention.Status{
+		DryRun:   s.dryRun,
+		Interval: s.interval.String(),
+		Rules:    rules,
+		LastRun:  s.lastRun,
+	}
+}
+
+// Preview reports what the rules would change without changing anything.
+//
+// Previews are not recorded as the last run.
+//
+// Parameters:
+//   - ctx: Context cancelling the preview between rules
+//   - now: Reference time the rule cutoffs are computed from
+//
+// Returns:
+//   - *retention.Report: Matching records per rule
+//   - error: Joined errors of the failed rules
+func (s *RetentionService) Preview(ctx context.Context, now time.Time) (*retention.Report, error) {
+	return s.run(ctx, now, true)
+}
+
+// Apply runs the rules, or only reports on them when the service is in
+// dry-run mode, and records the outcome as the last run.
+//
+// Concurrent calls run one after the other.
+//
+// Parameters:
+//   - ctx: Context cancelling the run between rules and purge batches
+//   - now: Reference time the rule cutoffs are computed from
+//
+// Returns:
+//   - *retention.Report: Outcome per rule
+//   - error: Joined errors of the failed rules
+func (s *RetentionService) Apply(ctx context.Context, now time.Time) (*retention.Report, error) {
+	s.running.Lock()
+	defer s.running.Unlock()
+
+	report, err := s.run(ctx, now, s.dryRun)
+
+	s.mu.Lock()
+	s.lastRun = report
+	s.mu.Unlock()
+
+	return report, err
+}
+
+// run applies every rule and collects the report.
+func (s *RetentionService) run(ctx context.Context, now time.Time, dryRun bool) (*retention.Report, error) {
+	report := &retention.Report{DryRun: dryRun, StartedAt: time.Now(), Rules: []retention.RuleReport{}}
+
+	var errs []error
+	for _, rule := range s.rules {
+		ruleReport := retention.RuleReport{
+			Rule:   rule.Name,
+			MaxAge: rule.MaxAge,
+			Cutoff: now.Add(-time.Duration(rule.MaxAge)),
+		}
+
+		err := ctx.Err()
+		if err == nil {
+			switch rule.Name {
+			case retention.RulePurgeRevisions:
+				err = s.purgeRevisions(ctx, &ruleReport, dryRun)
+			case retention.RuleArchiveModules:
+				err = s.archiveModules(ctx, &ruleReport, now, dryRun)
+			}
+		}
+		if err != nil {
+			ruleReport.Error = err.Error()
+			errs = append(errs, fmt.Errorf("%s: %w", rule.Name, err))
+		}
+		report.Rules = append(report.Rules, ruleReport)
+	}
+
+	report.FinishedAt = time.Now()
+	return report, errors.Join(errs...)
+}
+
+// purgeRevisions deletes expired revisions in batches.
+func (s *RetentionService) purgeRevisions(ctx context.Context, report *retention.RuleReport, dryRun bool) error {
+	// Step 1: Count the expired revisions
+	matched, err := s.repo.CountExpiredRevisions(report.Cutoff)
+	if err != nil {
+		return err
+	}
+	report.Matched = matched
+	if dryRun {
+		return nil
+	}
+
+	// Step 2: Delete them batch by batch, so large purges do not hold locks for long
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		deleted, err := s.repo.PurgeExpiredRevisions(report.Cutoff, purgeBatchSize)
+		if err != nil {
+			return err
+		}
+		report.Affected += deleted
+		if deleted < purgeBatchSize {
+			return nil
+		}
+	}
+}
+
+// archiveModules moves inactive modules to the archive table.
+func (s *RetentionService) archiveModules(ctx context.Context, report *retention.RuleReport, now time.Time, dryRun bool) error {
+	// Step 1: Find the candidates
+	candidates, err := s.repo.ListArchiveCandidates(report.Cutoff)
+	if err != nil {
+		return err
+	}
+	report.Matched = len(candidates)
+
+	// Step 2: Archive every candidate no other module depends on
+	for _, candidate := range candidates {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if candidate.Dependents > 0 {
+			report.Skipped++
+			continue
+		}
+
+		if !dryRun {
+			archived, err := s.repo.ArchiveModule(candidate.ID, report.Cutoff, now)
+			if err != nil {
+				return err
+			}
+			if !archived {
+				report.Skipped++
+				continue
+			}
+			report.Affected++
+		}
+
+		if len(report.ModuleIDs) < retention.MaxReportedModules {
+			report.ModuleIDs = append(report.ModuleIDs, candidate.ID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package setting
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// DefaultSchemas defines the settings every module can carry.
+//
+// Each key maps to a JSON schema (draft 2020-12) describing its value. Add a
+// key here to make it available to all modules.
+var DefaultSchemas = map[string]string{
+	"logLevel": `{
+		"type": "string",
+		"enum": ["debug", "info", "warn", "error"]
+	}`,
+	"maxConnections": `{
+		"type": "integer",
+		"minimum": 1,
+		"maximum": 10000
+	}`,
+	"endpoint": `{
+		"type": "string",
+		"format": "uri"
+	}`,
+	"featureFlags": `{
+		"type": "object",
+		"additionalProperties": {"type": "boolean"}
+	}`,
+	"retryPolicy": `{
+		"type": "object",
+		"properties": {
+			"maxAttempts": {"type": "integer", "minimum": 0, "maximum": 10},
+			"backoffMs": {"type": "integer", "minimum": 0}
+		},
+		"required": ["maxAttempts"],
+		"additionalProperties": false
+	}`,
+}
+
+// errUnknownKey is returned by Validate for keys without a schema.
+var errUnknownKey = errors.New("unknown setting")
+
+// SchemaRegistry holds the compiled JSON schema of every setting key.
+//
+// Schemas are compiled once at startup; validation is safe for concurrent use.
+type SchemaRegistry struct {
+	schemas map[string]*jsonschema.Schema
+	sources map[string]json.RawMessage
+}
+
+// NewSchemaRegistry compiles the given schemas.
+//
+// Parameters:
+//   - schemas: JSON schema source keyed by setting key
+//
+// Returns:
+//   - *SchemaRegistry: The compiled registry
+//   - error: Error if a schema is not valid JSON or not a valid JSON schema
+func NewSchemaRegistry(schemas map[string]string) (*SchemaRegistry, error) {
+	registry := &SchemaRegistry{
+		schemas: make(map[string]*jsonschema.Schema, len(schemas)),
+		sources: make(map[string]json.RawMessage, len(schemas)),
+	}
+
+	compiler := jsonschema.NewCompiler()
+	compiler.AssertFormat()
+
+	for key, source := range schemas {
+		doc, err := jsonschema.UnmarshalJSON(strings.NewReader(source))
+		if err != nil {
+			return nil, fmt.Errorf("setting %q: invalid schema JSON: %w", key, err)
+		}
+
+		url := "settings/" + key + ".json"
+		if err := compiler.AddResource(url, doc); err != nil {
+			return nil, fmt.Errorf("setting %q: %w", key, err)
+		}
+		schema, err := compiler.Compile(url)
+		if err != nil {
+			return nil, fmt.Errorf("setting %q: %w", key, err)
+		}
+
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, []byte(source)); err != nil {
+			return nil, fmt.Errorf("setting %q: %w", key, err)
+		}
+
+		registry.schemas[key] = schema
+		registry.sources[key] = compact.Bytes()
+	}
+
+	return registry, nil
+}
+
+// NewDefaultSchemaRegistry compiles DefaultSchemas.
+//
+// Returns:
+//   - *SchemaRegistry: The compiled registry
+//   - error: Error if a default schema is invalid
+func NewDefaultSchemaRegistry() (*SchemaRegistry, error) {
+	return NewSchemaRegistry(DefaultSchemas)
+}
+
+// Schemas returns the schema source of every key.
+//
+// Returns:
+//   - map[string]json.RawMessage: JSON schemas keyed by setting key
+func (r *SchemaRegistry) Schemas() map[string]json.RawMessage {
+	return r.sources
+}
+
+// Validate checks a value against the schema of its key.
+//
+// Parameters:
+//   - key: Setting key
+//   - value: JSON-encoded value
+//
+// Returns:
+//   - []string: Human-readable violations (empty when the value is valid)
+func (r *SchemaRegistry) Validate(key string, value json.RawMessage) []string {
+	schema, ok := r.schemas[key]
+	if !ok {
+		return []string{errUnknownKey.Error()}
+	}
+
+	instance, err := jsonschema.UnmarshalJSON(bytes.NewReader(value))
+	if err != nil {
+		return []string{"value is not valid JSON"}
+	}
+
+	err = schema.Validate(instance)
+	if err == nil {
+		return nil
+	}
+
+	var validationErr *jsonschema.ValidationError
+	if !errors.As(err, &validationErr) {
+		return []string{err.Error()}
+	}
+
+	// Flatten the output into one message per violated keyword
+	var messages []string
+	for _, unit := range validationErr.BasicOutput().Errors {
+		if unit.Error == nil {
+			continue
+		}
+		location := unit.InstanceLocation
+		if location == "" {
+			location = "/"
+		}
+		messages = append(messages, fmt.Sprintf("%s: %s", location, unit.Error.String()))
+	}
+	sort.Strings(messages)
+	if len(messages) == 0 {
+		messages = []string{validationErr.Error()}
+	}
+	return messages
+}
@@ -0,0 +1,17 @@
+package setting
+
+import "go_di_architecture/internal/domain/models/module"
+
+// SettingRepository defines the data operations the setting service depends on.
+//
+// Implementations live in the infrastructure layer next to the module
+// repositories:
+//   - InMemoryModuleRepository: stores settings alongside modules
+//   - SettingRepository (GORM): module_settings table
+type SettingRepository interface {
+	// ListSettings returns every setting of the module ordered by key
+	ListSettings(moduleID int) ([]module.ModuleSetting, error)
+
+	// ReplaceSettings atomically replaces all settings of the module
+	ReplaceSettings(moduleID int, settings []module.ModuleSetting) error
+}
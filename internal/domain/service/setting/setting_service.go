@@ -0,0 +1,240 @@
+package setting
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go_di_architecture/internal/domain/apperror"
+	"go_di_architecture/internal/domain/guard"
+	"go_di_architecture/internal/domain/models/module"
+	moduleService "go_di_architecture/internal/domain/service/module"
+)
+
+// Setting limits
+const (
+	// MaxSettingsPerModule caps the number of keys a module can store
+	MaxSettingsPerModule = 100
+
+	// maxValueBytes caps the encoded size of a single value
+	maxValueBytes = 16 * 1024
+)
+
+// ErrInvalidSettings is returned when one or more settings fail validation.
+var ErrInvalidSettings = apperror.New(http.StatusBadRequest, "VALIDATION_ERROR", "setting.invalid", "settings failed schema validation")
+
+// ValidationError reports every invalid setting of a request.
+//
+// The error wraps ErrInvalidSettings; Fields maps each offending key to its
+// violations so clients can fix all of them at once.
+type ValidationError struct {
+	// Violations keyed by setting key
+	Fields map[string][]string
+}
+
+// Error returns the message of the wrapped ErrInvalidSettings.
+func (e *ValidationError) Error() string {
+	return ErrInvalidSettings.Error()
+}
+
+// Unwrap exposes ErrInvalidSettings to errors.Is.
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidSettings
+}
+
+// ModuleFinder checks a module exists for a subject.
+//
+// Implemented by the module service; declared here so settings follow the same
+// visibility rules as the module itself.
+type ModuleFinder interface {
+	ModuleExists(id string, subject module.Subject) (bool, error)
+}
+
+// SettingService implements business operations for per-module runtime configuration.
+//
+// Business Rule Enforcement:
+//  1. Settings belong to an existing module visible to the caller
+//  2. Every key must be registered in the schema registry
+//  3. Every value must satisfy the JSON schema of its key
+//  4. A PUT replaces the whole settings document atomically
+//
+// Usage Example:
+//
+//	service, err := setting.NewSettingService(settingRepo, moduleService, registry)
+//	_, err := service.ReplaceSettings("123", subject, module.ModuleSettings{
+//	    "logLevel": json.RawMessage(`"debug"`),
+//	})
+type SettingService struct {
+	repo    SettingRepository
+	modules ModuleFinder
+	schemas *SchemaRegistry
+}
+
+// NewSettingService creates a new instance of SettingService.
+//
+// Parameters:
+//   - repo: Data access repository for settings
+//   - modules: Module lookup verifying the owning module is visible
+//   - schemas: Registry validating values per key
+//
+// Returns:
+//   - *SettingService: A new service instance
+//   - error: guard.ErrNilDependency if a required dependency is nil
+func NewSettingService(repo SettingRepository, modules ModuleFinder, schemas *SchemaRegistry) (*SettingService, error) {
+	if err := guard.Require("NewSettingService", guard.Dep("repo", repo), guard.Dep("modules", modules), guard.Dep("schemas", schemas)); err != nil {
+		return nil, err
+	}
+	return &SettingService{repo: repo, modules: modules, schemas: schemas}, nil
+}
+
+// Schemas returns the JSON schema of every supported setting key.
+//
+// Returns:
+//   - map[string]json.RawMessage: JSON schemas keyed by setting key
+func (s *SettingService) Schemas() map[string]json.RawMessage {
+	return s.schemas.Schemas()
+}
+
+// GetSettings returns the settings of a module.
+//
+// Parameters:
+//   - moduleID: Identifier of the module
+//   - subject: Who asks; the module must be visible to it
+//
+// Returns:
+//   - module.ModuleSettings: Values keyed by setting key (empty when none are set)
+//   - error: moduleService.ErrNotFound if the module does not exist or is hidden from the subject, or a data layer error
+func (s *SettingService) GetSettings(moduleID string, subject module.Subject) (module.ModuleSettings, error) {
+	id, err := s.findModule(moduleID, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := s.repo.ListSettings(id)
+	if err != nil {
+		return nil, fmt.Errorf("database error loading settings: %w", err)
+	}
+
+	settings := make(module.ModuleSettings, len(stored))
+	for _, setting := range stored {
+		settings[setting.Key] = json.RawMessage(setting.Value)
+	}
+	return settings, nil
+}
+
+// ReplaceSettings validates and stores the complete settings document of a module.
+//
+// Keys missing from the document are removed.
+//
+// Parameters:
+//   - moduleID: Identifier of the module
+//   - subject: Who changes the settings; the module must be visible to it
+//   - settings: New values keyed by setting key
+//
+// Returns:
+//   - module.ModuleSettings: The stored settings
+//   - error: Error if business rules are violated
+//
+// Error Types:
+//   - moduleService.ErrNotFound: When the module does not exist or is hidden from the subject
+//   - ErrInvalidSettings: When keys are unknown or values violate their schema,
+//     returned as *ValidationError with violations per key
+func (s *SettingService) ReplaceSettings(moduleID string, subject module.Subject, settings module.ModuleSettings) (module.ModuleSettings, error) {
+	// Step 1: Verify the module exists
+	id, err := s.findModule(moduleID, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 2: Validate every value against its schema
+	stored, err := s.validate(id, settings)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 3: Replace the stored document
+	if err := s.repo.ReplaceSettings(id, stored); err != nil {
+		return nil, fmt.Errorf("database error storing settings: %w", err)
+	}
+
+	return s.GetSettings(moduleID, subject)
+}
+
+// ValidateSettings checks a settings document without storing it.
+//
+// Parameters:
+//   - settings: Values keyed by setting key
+//
+// Returns:
+//   - error: ErrInvalidSettings as *ValidationError with violations per key,
+//     or nil when every value satisfies its schema
+func (s *SettingService) ValidateSettings(settings module.ModuleSettings) error {
+	_, err := s.validate(0, settings)
+	return err
+}
+
+// validate checks every value of a settings document against its schema.
+//
+// Parameters:
+//   - moduleID: Module the settings are stored for
+//   - settings: Values keyed by setting key
+//
+// Returns:
+//   - []module.ModuleSetting: The settings with compacted values, ready to store
+//   - error: ErrInvalidSettings as *ValidationError with violations per key
+func (s *SettingService) validate(moduleID int, settings module.ModuleSettings) ([]module.ModuleSetting, error) {
+	fields := make(map[string][]string)
+	if len(settings) > MaxSettingsPerModule {
+		fields["settings"] = []string{fmt.Sprintf("at most %d settings are allowed", MaxSettingsPerModule)}
+	}
+
+	now := time.Now()
+	stored := make([]module.ModuleSetting, 0, len(settings))
+	for key, value := range settings {
+		if len(value) > maxValueBytes {
+			fields[key] = []string{fmt.Sprintf("value exceeds %d bytes", maxValueBytes)}
+			continue
+		}
+		if violations := s.schemas.Validate(key, value); len(violations) > 0 {
+			fields[key] = violations
+			continue
+		}
+
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, value); err != nil {
+			fields[key] = []string{"value is not valid JSON"}
+			continue
+		}
+		stored = append(stored, module.ModuleSetting{
+			ModuleID:  moduleID,
+			Key:       key,
+			Value:     compact.String(),
+			UpdatedAt: now,
+		})
+	}
+	if len(fields) > 0 {
+		return nil, &ValidationError{Fields: fields}
+	}
+	return stored, nil
+}
+
+// findModule parses a module ID and verifies the module exists and is
+// visible to the subject.
+func (s *SettingService) findModule(moduleID string, subject module.Subject) (int, error) {
+	id, err := strconv.Atoi(moduleID)
+	if err != nil {
+		return 0, moduleService.ErrNotFound.Wrap(err)
+	}
+
+	exists, err := s.modules.ModuleExists(moduleID, subject)
+	if err != nil {
+		return 0, err
+	}
+	if !exists {
+		return 0, moduleService.ErrNotFound
+	}
+	return id, nil
+}
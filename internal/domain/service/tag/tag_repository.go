@@ -0,0 +1,38 @@
+package tag
+
+import "go_di_architecture/internal/domain/models/tag"
+
+// TagRepository defines the data operations the tag service depends on.
+//
+// Implementations live in the infrastructure layer next to the module
+// repositories, because tag filters are evaluated as part of module queries:
+//   - InMemoryModuleRepository: stores tags alongside modules
+//   - TagRepository (GORM): tags and module_tags tables
+//
+// Implementations must:
+//   - Store tag names exactly as given (the service normalizes them)
+//   - Return (nil, nil) when a single entity is not found
+//   - Treat assigning an already assigned tag as a no-op
+type TagRepository interface {
+	// CreateTag persists a new tag; a name collision is reported as an error
+	// wrapping ErrTagExists
+	CreateTag(t *tag.Tag) (*tag.Tag, error)
+
+	// FindTagByName returns the tag with the name, or nil if not found
+	FindTagByName(name string) (*tag.Tag, error)
+
+	// ListTags returns all tags ordered by name
+	ListTags() ([]*tag.Tag, error)
+
+	// DeleteTag removes the tag and all of its module assignments
+	DeleteTag(id int) error
+
+	// AssignTag attaches the tag to the module
+	AssignTag(moduleID, tagID int) error
+
+	// UnassignTag detaches the tag from the module
+	UnassignTag(moduleID, tagID int) error
+
+	// ListModuleTags returns the tags attached to the module ordered by name
+	ListModuleTags(moduleID int) ([]*tag.Tag, error)
+}
@@ -0,0 +1,284 @@
+package tag
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"go_di_architecture/internal/domain/apperror"
+	"go_di_architecture/internal/domain/guard"
+	"go_di_architecture/internal/domain/models/module"
+	"go_di_architecture/internal/domain/models/tag"
+	moduleService "go_di_architecture/internal/domain/service/module"
+)
+
+// Custom error types for tag business rule violations
+var (
+	ErrTagNameInvalid = apperror.Validation("name", "tag.name_invalid", "tag name must be 1-30 lower-case letters, digits or dashes")
+	ErrTagExists      = apperror.New(http.StatusConflict, "RESOURCE_CONFLICT", "tag.exists", "tag already exists")
+	ErrTagNotFound    = &apperror.AppError{
+		Status:     http.StatusNotFound,
+		Code:       "NOT_FOUND",
+		MessageKey: "tag.not_found",
+		Message:    "tag not found",
+		Fields:     map[string][]string{"resource": {"tag not found"}},
+	}
+)
+
+// tagNamePattern is the accepted format of a normalized tag name.
+var tagNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,29}$`)
+
+// ModuleFinder checks a module exists for a subject.
+//
+// Implemented by the module service; declared here so module tags follow the same
+// visibility rules as the module itself.
+type ModuleFinder interface {
+	ModuleExists(id string, subject module.Subject) (bool, error)
+}
+
+// TagService implements business operations for tags and their module assignments.
+//
+// Business Rule Enforcement:
+//  1. Names are trimmed and lower-cased before validation and storage
+//  2. Names are unique and limited to 30 lower-case letters, digits and dashes
+//  3. Tags can only be assigned to existing modules visible to the caller
+//  4. Deleting a tag detaches it from every module
+//
+// Usage Example:
+//
+//	service, err := tag.NewTagService(tagRepo, moduleService)
+//	_, err := service.CreateTag(tag.TagRequest{Name: "Backend"}) // stored as "backend"
+//	tags, err := service.AssignTag("123", subject, "backend")
+type TagService struct {
+	repo    TagRepository
+	modules ModuleFinder
+}
+
+// NewTagService creates a new instance of TagService.
+//
+// Parameters:
+//   - repo: Data access repository for tag operations
+//   - modules: Module lookup verifying assignment targets are visible
+//
+// Returns:
+//   - *TagService: A new service instance
+//   - error: guard.ErrNilDependency if a required dependency is nil
+func NewTagService(repo TagRepository, modules ModuleFinder) (*TagService, error) {
+	if err := guard.Require("NewTagService", guard.Dep("repo", repo), guard.Dep("modules", modules)); err != nil {
+		return nil, err
+	}
+	return &TagService{repo: repo, modules: modules}, nil
+}
+
+// NormalizeTagName trims and lower-cases a tag name.
+//
+// Parameters:
+//   - name: Tag name as supplied by the client
+//
+// Returns:
+//   - string: The normalized name used for storage and lookups
+func NormalizeTagName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// CreateTag creates a new tag.
+//
+// Parameters:
+//   - request: Tag creation data
+//
+// Returns:
+//   - *tag.TagResponse: Created tag with system-generated properties
+//   - error: Error if business rules are violated
+//
+// Error Types:
+//   - ErrTagNameInvalid: When the normalized name has an invalid format
+//   - ErrTagExists: When a tag with the normalized name already exists
+func (s *TagService) CreateTag(request tag.TagRequest) (*tag.TagResponse, error) {
+	// Step 1: Normalize and validate the name
+	name := NormalizeTagName(request.Name)
+	if !tagNamePattern.MatchString(name) {
+		return nil, ErrTagNameInvalid
+	}
+
+	// Step 2: Check name uniqueness
+	existing, err := s.repo.FindTagByName(name)
+	if err != nil {
+		return nil, fmt.Errorf("database error checking tag: %w", err)
+	}
+	if existing != nil {
+		return nil, ErrTagExists
+	}
+
+	// Step 3: Persist (the unique index catches concurrent duplicates)
+	saved, err := s.repo.CreateTag(&tag.Tag{Name: name, CreatedAt: time.Now()})
+	if errors.Is(err, ErrTagExists) {
+		return nil, ErrTagExists
+	}
+	if err != nil {
+		return nil, fmt.Errorf("database error creating tag: %w", err)
+	}
+
+	return toTagResponse(saved), nil
+}
+
+// ListTags returns every tag ordered by name.
+//
+// Returns:
+//   - []*tag.TagResponse: All tags
+//   - error: Error if the data layer fails
+func (s *TagService) ListTags() ([]*tag.TagResponse, error) {
+	tags, err := s.repo.ListTags()
+	if err != nil {
+		return nil, fmt.Errorf("database error listing tags: %w", err)
+	}
+	return toTagResponses(tags), nil
+}
+
+// DeleteTag deletes a tag and detaches it from all modules.
+//
+// Parameters:
+//   - name: Name of the tag (normalized before lookup)
+//
+// Returns:
+//   - error: ErrTagNotFound if the tag does not exist, or a data layer error
+func (s *TagService) DeleteTag(name string) error {
+	existing, err := s.findTag(name)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteTag(existing.ID); err != nil {
+		return fmt.Errorf("database error deleting tag: %w", err)
+	}
+	return nil
+}
+
+// ListModuleTags returns the tags attached to a module.
+//
+// Parameters:
+//   - moduleID: Identifier of the module
+//   - subject: Who asks; the module must be visible to it
+//
+// Returns:
+//   - []*tag.TagResponse: Attached tags ordered by name
+//   - error: moduleService.ErrNotFound if the module does not exist or is hidden from the subject, or a data layer error
+func (s *TagService) ListModuleTags(moduleID string, subject module.Subject) ([]*tag.TagResponse, error) {
+	id, err := s.findModule(moduleID, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := s.repo.ListModuleTags(id)
+	if err != nil {
+		return nil, fmt.Errorf("database error listing module tags: %w", err)
+	}
+	return toTagResponses(tags), nil
+}
+
+// AssignTag attaches a tag to a module.
+//
+// Assigning a tag that is already attached is a no-op.
+//
+// Parameters:
+//   - moduleID: Identifier of the module
+//   - subject: Who assigns the tag; the module must be visible to it
+//   - name: Name of the tag (normalized before lookup)
+//
+// Returns:
+//   - []*tag.TagResponse: The module's tags after the assignment
+//   - error: moduleService.ErrNotFound, ErrTagNotFound, or a data layer error
+func (s *TagService) AssignTag(moduleID string, subject module.Subject, name string) ([]*tag.TagResponse, error) {
+	id, err := s.findModule(moduleID, subject)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.findTag(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.AssignTag(id, existing.ID); err != nil {
+		return nil, fmt.Errorf("database error assigning tag: %w", err)
+	}
+	return s.ListModuleTags(moduleID, subject)
+}
+
+// UnassignTag detaches a tag from a module.
+//
+// Removing a tag that is not attached is a no-op.
+//
+// Parameters:
+//   - moduleID: Identifier of the module
+//   - subject: Who removes the tag; the module must be visible to it
+//   - name: Name of the tag (normalized before lookup)
+//
+// Returns:
+//   - []*tag.TagResponse: The module's tags after the removal
+//   - error: moduleService.ErrNotFound, ErrTagNotFound, or a data layer error
+func (s *TagService) UnassignTag(moduleID string, subject module.Subject, name string) ([]*tag.TagResponse, error) {
+	id, err := s.findModule(moduleID, subject)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.findTag(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UnassignTag(id, existing.ID); err != nil {
+		return nil, fmt.Errorf("database error removing tag: %w", err)
+	}
+	return s.ListModuleTags(moduleID, subject)
+}
+
+// findModule parses a module ID and verifies the module exists and is
+// visible to the subject.
+func (s *TagService) findModule(moduleID string, subject module.Subject) (int, error) {
+	id, err := strconv.Atoi(moduleID)
+	if err != nil {
+		return 0, moduleService.ErrNotFound.Wrap(err)
+	}
+
+	exists, err := s.modules.ModuleExists(moduleID, subject)
+	if err != nil {
+		return 0, err
+	}
+	if !exists {
+		return 0, moduleService.ErrNotFound
+	}
+	return id, nil
+}
+
+// findTag loads a tag by its normalized name.
+func (s *TagService) findTag(name string) (*tag.Tag, error) {
+	existing, err := s.repo.FindTagByName(NormalizeTagName(name))
+	if err != nil {
+		return nil, fmt.Errorf("database error finding tag: %w", err)
+	}
+	if existing == nil {
+		return nil, ErrTagNotFound
+	}
+	return existing, nil
+}
+
+// toTagResponses maps tag entities to response DTOs.
+func toTagResponses(tags []*tag.Tag) []*tag.TagResponse {
+	responses := make([]*tag.TagResponse, len(tags))
+	for i, t := range tags {
+		responses[i] = toTagResponse(t)
+	}
+	return responses
+}
+
+// toTagResponse maps a tag entity to its response DTO.
+func toTagResponse(t *tag.Tag) *tag.TagResponse {
+	return &tag.TagResponse{
+		ID:        t.ID,
+		Name:      t.Name,
+		CreatedAt: t.CreatedAt,
+	}
+}
@@ -0,0 +1,26 @@
+package template
+
+import "go_di_architecture/internal/domain/models/template"
+
+// TemplateRepository defines the data operations the template service depends on.
+//
+// Implementations live in the infrastructure layer next to the module
+// repositories:
+//   - InMemoryModuleRepository: stores templates alongside modules
+//   - TemplateRepository (GORM): module_templates table
+//
+// Implementations must return (nil, nil) when a single template is not found.
+type TemplateRepository interface {
+	// CreateTemplate persists a new template; a name collision is reported as
+	// an error wrapping ErrTemplateExists
+	CreateTemplate(t *template.ModuleTemplate) (*template.ModuleTemplate, error)
+
+	// GetTemplate returns the template with the ID, or nil if not found
+	GetTemplate(id int) (*template.ModuleTemplate, error)
+
+	// ListTemplates returns all templates ordered by name
+	ListTemplates() ([]*template.ModuleTemplate, error)
+
+	// DeleteTemplate removes a template; it reports false when there is none
+	DeleteTemplate(id int) (bool, error)
+}
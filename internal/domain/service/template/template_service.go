@@ -0,0 +1,351 @@
+package template
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go_di_architecture/internal/domain/apperror"
+	"go_di_architecture/internal/domain/guard"
+	"go_di_architecture/internal/domain/models/module"
+	"go_di_architecture/internal/domain/models/template"
+	moduleService "go_di_architecture/internal/domain/service/module"
+	settingService "go_di_architecture/internal/domain/service/setting"
+)
+
+// Template service errors
+var (
+	ErrTemplateNotFound = apperror.New(http.StatusNotFound, "NOT_FOUND", "template.not_found", "module template not found")
+	ErrTemplateExists   = apperror.New(http.StatusConflict, "RESOURCE_CONFLICT", "template.exists", "module template already exists")
+	ErrTemplateName     = apperror.Validation("name", "template.name_required", "template name is required")
+	ErrNamePattern      = apperror.Validation("namePattern", "template.name_pattern", "placeholders must look like {name}: a letter followed by letters, digits or underscores")
+	ErrMissingVariables = apperror.Validation("variables", "template.missing_variables", "the name pattern needs more variables")
+)
+
+// Built-in placeholders, filled in without a variable
+const (
+	// PlaceholderDate is replaced by the current UTC date (YYYY-MM-DD)
+	PlaceholderDate = "date"
+
+	// PlaceholderActor is replaced by who creates the module
+	PlaceholderActor = "actor"
+)
+
+// placeholderPattern matches a {placeholder} of a name pattern.
+var placeholderPattern = regexp.MustCompile(`\{([A-Za-z][A-Za-z0-9_]*)\}`)
+
+// TemplateService implements business operations for the module template catalogue.
+//
+// Business Rule Enforcement:
+//  1. Template names are unique (case-sensitive)
+//  2. Name patterns only contain well-formed {placeholders}
+//  3. Template settings satisfy the settings schemas when the template is added
+//  4. Modules created from a template follow every rule of module creation;
+//     settings are checked again first, since schemas may have changed
+//
+// Usage Example:
+//
+//	service, err := template.NewTemplateService(templateRepo, modules, settings)
+//	created, err := service.Instantiate("7", template.InstantiateRequest{
+//	    Variables: map[string]string{"warehouse": "berlin"},
+//	}, "jane")
+type TemplateService struct {
+	repo     TemplateRepository
+	modules  *moduleService.ModuleService
+	settings *settingService.SettingService
+}
+
+// NewTemplateService creates a new instance of TemplateService.
+//
+// Parameters:
+//   - repo: Data access repository for templates
+//   - modules: Module service creating the modules
+//   - settings: Setting service validating and storing template settings
+//
+// Returns:
+//   - *TemplateService: A new service instance
+//   - error: guard.ErrNilDependency if a required dependency is nil
+func NewTemplateService(repo TemplateRepository, modules *moduleService.ModuleService, settings *settingService.SettingService) (*TemplateService, error) {
+	if err := guard.Require("NewTemplateService", guard.Dep("repo", repo), guard.Dep("modules", modules), guard.Dep("settings", settings)); err != nil {
+		return nil, err
+	}
+	return &TemplateService{repo: repo, modules: modules, settings: settings}, nil
+}
+
+// CreateTemplate adds a template to the catalogue.
+//
+// Parameters:
+//   - request: Template data
+//
+// Returns:
+//   - *template.TemplateResponse: The stored template
+//   - error: Error if business rules are violated
+//
+// Error Types:
+//   - ErrTemplateName: When the name is blank
+//   - ErrNamePattern: When the pattern contains a malformed placeholder
+//   - settingService.ErrInvalidSettings: When settings violate their schema,
+//     returned as *settingService.ValidationError
+//   - ErrTemplateExists: When another template uses the name
+func (s *TemplateService) CreateTemplate(request template.TemplateRequest) (*template.TemplateResponse, error) {
+	// Step 1: Validate the fields
+	name := strings.TrimSpace(request.Name)
+	if name == "" {
+		return nil, ErrTemplateName
+	}
+	if err := checkPattern(request.NamePattern); err != nil {
+		return nil, err
+	}
+	if err := s.settings.ValidateSettings(request.Settings); err != nil {
+		return nil, err
+	}
+
+	settings := request.Settings
+	if settings == nil {
+		settings = module.ModuleSettings{}
+	}
+	encoded, err := json.Marshal(settings)
+	if err != nil {
+		return nil, fmt.Errorf("encoding template settings: %w", err)
+	}
+
+	// Step 2: Store the template
+	saved, err := s.repo.CreateTemplate(&template.ModuleTemplate{
+		Name:        name,
+		NamePattern: request.NamePattern,
+		Description: request.Description,
+		Settings:    string(encoded),
+		CreatedAt:   time.Now(),
+	})
+	if errors.Is(err, ErrTemplateExists) {
+		return nil, ErrTemplateExists
+	}
+	if err != nil {
+		return nil, fmt.Errorf("database error creating template: %w", err)
+	}
+	return toTemplateResponse(saved)
+}
+
+// GetTemplate returns one template of the catalogue.
+//
+// Parameters:
+//   - id: Identifier of the template
+//
+// Returns:
+//   - *template.TemplateResponse: The template
+//   - error: ErrTemplateNotFound or a data layer error
+func (s *TemplateService) GetTemplate(id string) (*template.TemplateResponse, error) {
+	stored, err := s.loadTemplate(id)
+	if err != nil {
+		return nil, err
+	}
+	return toTemplateResponse(stored)
+}
+
+// ListTemplates returns the whole catalogue ordered by name.
+//
+// Returns:
+//   - []*template.TemplateResponse: The templates
+//   - error: Error if the data layer fails
+func (s *TemplateService) ListTemplates() ([]*template.TemplateResponse, error) {
+	stored, err := s.repo.ListTemplates()
+	if err != nil {
+		return nil, fmt.Errorf("database error listing templates: %w", err)
+	}
+
+	templates := make([]*template.TemplateResponse, len(stored))
+	for i, t := range stored {
+		if templates[i], err = toTemplateResponse(t); err != nil {
+			return nil, err
+		}
+	}
+	return templates, nil
+}
+
+// DeleteTemplate removes a template from the catalogue.
+//
+// Modules created from the template are not affected.
+//
+// Parameters:
+//   - id: Identifier of the template
+//
+// Returns:
+//   - error: ErrTemplateNotFound or a data layer error
+func (s *TemplateService) DeleteTemplate(id string) error {
+	templateID, err := strconv.Atoi(id)
+	if err != nil {
+		return ErrTemplateNotFound.Wrap(err)
+	}
+
+	deleted, err := s.repo.DeleteTemplate(templateID)
+	if err != nil {
+		return fmt.Errorf("database error deleting template: %w", err)
+	}
+	if !deleted {
+		return ErrTemplateNotFound
+	}
+	return nil
+}
+
+// Instantiate creates a module from a template.
+//
+// Parameters:
+//   - id: Identifier of the template
+//   - request: Values of the placeholders of the name pattern
+//   - actor: Who creates the module; owner of the module and value of {actor}
+//
+// Returns:
+//   - *module.ModuleResponse: The created module, a draft like any new module
+//   - error: Error if the module cannot be created
+//
+// Error Types:
+//   - ErrTemplateNotFound: When the template does not exist
+//   - ErrMissingVariables: When a placeholder has no variable
+//   - settingService.ErrInvalidSettings: When the template settings no longer
+//     satisfy their schema
+//   - moduleService.ErrNameLength, moduleService.ErrNameExists, ...: As for
+//     module creation, checked against the resolved name
+//
+// Instantiate Behavior:
+//   - {date} and {actor} are built in; variables with those names override them
+//   - Variable values are trimmed; unused variables are ignored
+//   - The template settings are stored on the new module
+func (s *TemplateService) Instantiate(id string, request template.InstantiateRequest, actor string) (*module.ModuleResponse, error) {
+	// Step 1: Load the template and resolve the name
+	stored, err := s.loadTemplate(id)
+	if err != nil {
+		return nil, err
+	}
+	name, err := resolveName(stored.NamePattern, request.Variables, actor, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	var settings module.ModuleSettings
+	if err := json.Unmarshal([]byte(stored.Settings), &settings); err != nil {
+		return nil, fmt.Errorf("corrupt settings in template %d: %w", stored.ID, err)
+	}
+	if err := s.settings.ValidateSettings(settings); err != nil {
+		return nil, err
+	}
+
+	// Step 2: Create the module
+	created, err := s.modules.CreateModule(module.ModuleRequest{
+		Name:        name,
+		Description: stored.Description,
+	}, actor, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 3: Apply the settings; the new module has no ACL yet, so it is
+	// visible to its creator
+	if len(settings) > 0 {
+		if _, err := s.settings.ReplaceSettings(strconv.Itoa(created.ID), module.Subject{User: actor}, settings); err != nil {
+			return nil, fmt.Errorf("applying template settings to module %d: %w", created.ID, err)
+		}
+	}
+	return created, nil
+}
+
+// loadTemplate parses a template ID and loads the template.
+func (s *TemplateService) loadTemplate(id string) (*template.ModuleTemplate, error) {
+	templateID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, ErrTemplateNotFound.Wrap(err)
+	}
+
+	stored, err := s.repo.GetTemplate(templateID)
+	if err != nil {
+		return nil, fmt.Errorf("database error loading template: %w", err)
+	}
+	if stored == nil {
+		return nil, ErrTemplateNotFound
+	}
+	return stored, nil
+}
+
+// checkPattern rejects name patterns with braces outside well-formed placeholders.
+func checkPattern(pattern string) error {
+	if strings.TrimSpace(pattern) == "" {
+		return ErrNamePattern.Detailf("pattern is blank")
+	}
+	if rest := placeholderPattern.ReplaceAllString(pattern, ""); strings.ContainsAny(rest, "{}") {
+		return ErrNamePattern
+	}
+	return nil
+}
+
+// resolveName fills in the placeholders of a name pattern.
+//
+// Parameters:
+//   - pattern: The name pattern
+//   - variables: Values supplied by the request
+//   - actor: Value of {actor}
+//   - now: Time whose UTC date is the value of {date}
+//
+// Returns:
+//   - string: The module name
+//   - error: ErrMissingVariables naming every placeholder without a value
+func resolveName(pattern string, variables map[string]string, actor string, now time.Time) (string, error) {
+	values := map[string]string{
+		PlaceholderDate:  now.UTC().Format("2006-01-02"),
+		PlaceholderActor: actor,
+	}
+	for key, value := range variables {
+		values[key] = strings.TrimSpace(value)
+	}
+
+	var missing []string
+	name := placeholderPattern.ReplaceAllStringFunc(pattern, func(placeholder string) string {
+		key := placeholder[1 : len(placeholder)-1]
+		value, ok := values[key]
+		if !ok || value == "" {
+			missing = append(missing, key)
+		}
+		return value
+	})
+	if len(missing) > 0 {
+		return "", ErrMissingVariables.Detailf("no value for %s", strings.Join(missing, ", "))
+	}
+	return strings.TrimSpace(name), nil
+}
+
+// placeholders returns the variables a pattern needs, without built-ins and duplicates.
+func placeholders(pattern string) []string {
+	seen := make(map[string]bool)
+	names := []string{}
+	for _, match := range placeholderPattern.FindAllStringSubmatch(pattern, -1) {
+		key := match[1]
+		if key == PlaceholderDate || key == PlaceholderActor || seen[key] {
+			continue
+		}
+		seen[key] = true
+		names = append(names, key)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// toTemplateResponse converts a stored template to its API representation.
+func toTemplateResponse(t *template.ModuleTemplate) (*template.TemplateResponse, error) {
+	var settings module.ModuleSettings
+	if err := json.Unmarshal([]byte(t.Settings), &settings); err != nil {
+		return nil, fmt.Errorf("corrupt settings in template %d: %w", t.ID, err)
+	}
+	return &template.TemplateResponse{
+		ID:           t.ID,
+		Name:         t.Name,
+		NamePattern:  t.NamePattern,
+		Placeholders: placeholders(t.NamePattern),
+		Description:  t.Description,
+		Settings:     settings,
+		CreatedAt:    t.CreatedAt,
+	}, nil
+}
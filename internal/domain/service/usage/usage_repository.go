@@ -0,0 +1,19 @@
+package usage
+
+import (
+	"go_di_architecture/internal/domain/models/module"
+)
+
+// UsageRepository defines the data operations of the module usage counters.
+//
+// Implementations live in the infrastructure layer next to the module
+// repositories.
+type UsageRepository interface {
+	// AddUsage adds the counts to the stored counters of each module and day,
+	// creating missing counters, in one transaction
+	AddUsage(counts []module.ModuleUsage) error
+
+	// ListUsage returns the counters of a module between two days (inclusive,
+	// YYYY-MM-DD), ordered by day
+	ListUsage(moduleID int, fromDay, toDay string) ([]module.ModuleUsage, error)
+}
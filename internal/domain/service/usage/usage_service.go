@@ -0,0 +1,189 @@
+package usage
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go_di_architecture/internal/domain/guard"
+	"go_di_architecture/internal/domain/models/module"
+)
+
+// DayFormat is the layout of the days counters are kept for.
+const DayFormat = "2006-01-02"
+
+// DefaultFlushInterval is how often counts are written when no interval is configured.
+const DefaultFlushInterval = time.Minute
+
+// ModuleReader loads a module visible to a subject.
+//
+// Implemented by the module service; declared here so usage reports follow
+// the same visibility rules as the module itself.
+type ModuleReader interface {
+	GetModuleById(id string, subject module.Subject) (*module.ModuleResponse, error)
+}
+
+// counterKey identifies the counter of a module on one day.
+type counterKey struct {
+	moduleID int
+	day      string
+}
+
+// UsageService counts API reads and writes per module and day.
+//
+// Requests are counted in memory and written to the database by Flush, so
+// counting never adds a query to a request. Counts not yet flushed are lost
+// when the process crashes; a graceful shutdown flushes them. Counters hold
+// no information about who made the requests.
+//
+// Usage Example:
+//
+//	service, err := usage.NewUsageService(repo, moduleService)
+//	service.Record(123, false, time.Now())
+//	err := service.Flush(ctx)
+//	report, err := service.GetModuleUsage("123", subject, 30, time.Now())
+type UsageService struct {
+	repo    UsageRepository
+	modules ModuleReader
+
+	mu      sync.Mutex
+	pending map[counterKey]*module.ModuleUsage
+}
+
+// NewUsageService creates a new instance of UsageService.
+//
+// Parameters:
+//   - repo: Data access of the usage counters
+//   - modules: Module lookup enforcing visibility
+//
+// Returns:
+//   - *UsageService: A new service instance
+//   - error: guard.ErrNilDependency if a required dependency is nil
+func NewUsageService(repo UsageRepository, modules ModuleReader) (*UsageService, error) {
+	if err := guard.Require("NewUsageService", guard.Dep("repo", repo), guard.Dep("modules", modules)); err != nil {
+		return nil, err
+	}
+	return &UsageService{repo: repo, modules: modules, pending: make(map[counterKey]*module.ModuleUsage)}, nil
+}
+
+// Record counts one request for a module.
+//
+// Parameters:
+//   - moduleID: Module the request addressed
+//   - write: Whether the request changed data
+//   - at: Time of the request; the UTC date selects the counter
+func (s *UsageService) Record(moduleID int, write bool, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := counterKey{moduleID: moduleID, day: at.UTC().Format(DayFormat)}
+	counter, ok := s.pending[key]
+	if !ok {
+		counter = &module.ModuleUsage{ModuleID: key.moduleID, Day: key.day}
+		s.pending[key] = counter
+	}
+	if write {
+		counter.Writes++
+	} else {
+		counter.Reads++
+	}
+}
+
+// Flush writes the counts recorded since the last flush to the database.
+//
+// Parameters:
+//   - ctx: Context of the flush (unused; the signature matches scheduler jobs)
+//
+// Returns:
+//   - error: Error if the write fails; the counts are kept for the next flush
+func (s *UsageService) Flush(ctx context.Context) error {
+	// Step 1: Take the pending counts, so requests keep counting meanwhile
+	s.mu.Lock()
+	pending := s.pending
+	s.pending = make(map[counterKey]*module.ModuleUsage)
+	s.mu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	// Step 2: Add them to the stored counters
+	counts := make([]module.ModuleUsage, 0, len(pending))
+	for _, counter := range pending {
+		counts = append(counts, *counter)
+	}
+	if err := s.repo.AddUsage(counts); err != nil {
+		// Step 3: Put the counts back, merged with those recorded meanwhile
+		s.mu.Lock()
+		for key, counter := range pending {
+			if current, ok := s.pending[key]; ok {
+				current.Reads += counter.Reads
+				current.Writes += counter.Writes
+			} else {
+				s.pending[key] = counter
+			}
+		}
+		s.mu.Unlock()
+		return fmt.Errorf("database error flushing module usage: %w", err)
+	}
+
+	return nil
+}
+
+// GetModuleUsage reports the daily usage of a module.
+//
+// Parameters:
+//   - id: The module ID
+//   - subject: The caller; the module must be visible to it
+//   - days: Number of days to report, ending with the current day
+//   - now: Current time; its UTC date is the last reported day
+//
+// Returns:
+//   - *module.ModuleUsageResponse: Counts per day, including counts not yet flushed
+//   - error: Error if the module is not visible or the data layer fails
+//
+// Error Types:
+//   - ErrNotFound and ErrForbidden of the module service, from the module lookup
+func (s *UsageService) GetModuleUsage(id string, subject module.Subject, days int, now time.Time) (*module.ModuleUsageResponse, error) {
+	// Step 1: Check the module is visible to the caller
+	m, err := s.modules.GetModuleById(id, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 2: Load the stored counters of the range
+	today := now.UTC().Truncate(24 * time.Hour)
+	first := today.AddDate(0, 0, 1-days)
+	report := &module.ModuleUsageResponse{
+		ModuleID: m.ID,
+		From:     first.Format(DayFormat),
+		To:       today.Format(DayFormat),
+		Days:     make([]module.ModuleUsageDay, days),
+	}
+	stored, err := s.repo.ListUsage(m.ID, report.From, report.To)
+	if err != nil {
+		return nil, fmt.Errorf("database error loading module usage: %w", err)
+	}
+	counts := make(map[string]module.ModuleUsage, len(stored))
+	for _, counter := range stored {
+		counts[counter.Day] = counter
+	}
+
+	// Step 3: Add the pending counts and fill in days without requests
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range report.Days {
+		day := first.AddDate(0, 0, i).Format(DayFormat)
+		entry := module.ModuleUsageDay{Date: day, Reads: counts[day].Reads, Writes: counts[day].Writes}
+		if counter, ok := s.pending[counterKey{moduleID: m.ID, day: day}]; ok {
+			entry.Reads += counter.Reads
+			entry.Writes += counter.Writes
+		}
+		report.Days[i] = entry
+		report.Reads += entry.Reads
+		report.Writes += entry.Writes
+	}
+
+	return report, nil
+}
@@ -0,0 +1,126 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"go_di_architecture/internal/domain/events"
+	"go_di_architecture/internal/domain/models/module"
+	"go_di_architecture/internal/domain/models/workflow"
+	"go_di_architecture/internal/domain/provisioning"
+	moduleService "go_di_architecture/internal/domain/service/module"
+)
+
+// Data keys of the provisioning workflow
+const (
+	// Input: name, description and resource plan of the module
+	KeyName        = "name"
+	KeyDescription = "description"
+	KeyPlan        = "plan"
+
+	// Recorded by the steps
+	KeyModuleID     = "moduleId"
+	KeyAllocationID = "allocationId"
+)
+
+// NewProvisioningWorkflow defines the provisioning of a module with its
+// platform resources.
+//
+// Steps:
+//  1. create_module: creates the module as a draft owned by the actor;
+//     compensated by deleting and purging it
+//  2. allocate_resources: reserves the resources of the plan through the
+//     allocator; compensated by releasing them
+//  3. notify: publishes module.provisioned to the actor, for the notifier
+//
+// Parameters:
+//   - modules: Module service creating and removing the module
+//   - allocator: External system reserving the resources
+//   - publisher: Event publisher reaching the notifier
+//
+// Returns:
+//   - Definition: The workflow definition for NewWorkflowService
+func NewProvisioningWorkflow(modules *moduleService.ModuleService, allocator provisioning.Allocator, publisher events.Publisher) Definition {
+	return Definition{
+		Kind: workflow.KindProvisionModule,
+		Steps: []Step{
+			{
+				Name: "create_module",
+				Run: func(_ context.Context, w *Instance) error {
+					if w.Data[KeyModuleID] != "" {
+						return nil
+					}
+					request := module.ModuleRequest{Name: w.Data[KeyName], Description: w.Data[KeyDescription]}
+					created, err := modules.CreateModule(request, w.Actor, false)
+					if err != nil {
+						return err
+					}
+					w.Data[KeyModuleID] = strconv.Itoa(created.ID)
+					return nil
+				},
+				Compensate: func(_ context.Context, w *Instance) error {
+					id := w.Data[KeyModuleID]
+					if id == "" {
+						return nil
+					}
+					err := modules.DeleteModule(id, module.Subject{User: w.Actor}, false)
+					if err != nil && !errors.Is(err, moduleService.ErrNotFound) {
+						return err
+					}
+					moduleID, _ := strconv.Atoi(id)
+					if _, _, err := modules.PurgeModules([]int{moduleID}); err != nil {
+						return err
+					}
+					delete(w.Data, KeyModuleID)
+					return nil
+				},
+			},
+			{
+				Name: "allocate_resources",
+				Run: func(ctx context.Context, w *Instance) error {
+					if w.Data[KeyAllocationID] != "" {
+						return nil
+					}
+					moduleID, err := strconv.Atoi(w.Data[KeyModuleID])
+					if err != nil {
+						return fmt.Errorf("invalid module ID %q", w.Data[KeyModuleID])
+					}
+					allocationID, err := allocator.Allocate(ctx, moduleID, w.Data[KeyPlan])
+					if err != nil {
+						return err
+					}
+					w.Data[KeyAllocationID] = allocationID
+					return nil
+				},
+				Compensate: func(ctx context.Context, w *Instance) error {
+					if w.Data[KeyAllocationID] == "" {
+						return nil
+					}
+					if err := allocator.Release(ctx, w.Data[KeyAllocationID]); err != nil {
+						return err
+					}
+					delete(w.Data, KeyAllocationID)
+					return nil
+				},
+			},
+			{
+				Name: "notify",
+				Run: func(_ context.Context, w *Instance) error {
+					moduleID, _ := strconv.Atoi(w.Data[KeyModuleID])
+					publisher.Publish(events.Event{
+						Type:       events.ModuleProvisioned,
+						ModuleID:   moduleID,
+						Actor:      w.Actor,
+						Recipient:  w.Actor,
+						Comment:    "plan " + w.Data[KeyPlan] + ", allocation " + w.Data[KeyAllocationID],
+						OccurredAt: time.Now(),
+					})
+					return nil
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,22 @@
+package workflow
+
+import "go_di_architecture/internal/domain/models/workflow"
+
+// WorkflowRepository defines the data operations the workflow service
+// depends on.
+//
+// Implementations live in the infrastructure layer next to the module
+// repositories:
+//   - InMemoryModuleRepository: keeps workflows in memory
+//   - WorkflowRepository (GORM): workflows table
+type WorkflowRepository interface {
+	// SaveWorkflow inserts or replaces a workflow
+	SaveWorkflow(w *workflow.Workflow) error
+
+	// GetWorkflow returns a workflow, or nil if there is none
+	GetWorkflow(id string) (*workflow.Workflow, error)
+
+	// ListUnfinishedWorkflows returns the running and compensating workflows,
+	// oldest first
+	ListUnfinishedWorkflows() ([]*workflow.Workflow, error)
+}
@@ -0,0 +1,313 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go_di_architecture/internal/domain/apperror"
+	"go_di_architecture/internal/domain/guard"
+	"go_di_architecture/internal/domain/models/workflow"
+
+	"github.com/google/uuid"
+)
+
+// Errors of the workflow service
+var (
+	ErrNotFound    = apperror.New(http.StatusNotFound, "NOT_FOUND", "workflow.not_found", "workflow not found")
+	ErrUnknownKind = errors.New("unknown workflow kind")
+)
+
+// maxErrorLength is the size of the error column.
+const maxErrorLength = 500
+
+// Data holds the values a workflow's steps read and record, e.g. the ID of a
+// created module for the steps after it.
+type Data map[string]string
+
+// Instance is the workflow a step works on.
+type Instance struct {
+	// Unique identifier of the workflow
+	ID string
+
+	// Who started the workflow
+	Actor string
+
+	// Values of the workflow; changes are saved once the step returns
+	Data Data
+}
+
+// Step is one step of a workflow with the action undoing it.
+//
+// Steps run at least once: a step interrupted by a shutdown runs again when
+// the workflow resumes, so it should detect work it already did, e.g. from
+// the values it records in the data.
+type Step struct {
+	// Name of the step, reported in WorkflowResponse
+	Name string
+
+	// Run performs the step
+	Run func(ctx context.Context, w *Instance) error
+
+	// Compensate undoes the step after a later step failed (nil when there is
+	// nothing to undo)
+	Compensate func(ctx context.Context, w *Instance) error
+}
+
+// Definition describes a kind of workflow.
+type Definition struct {
+	// Kind of the workflows (e.g. module.provision)
+	Kind string
+
+	// Steps in execution order
+	Steps []Step
+}
+
+// WorkflowService executes multi-step workflows as sagas.
+//
+// A workflow runs its steps in order and saves its state after each of them.
+// When a step fails, the steps completed before it are compensated in
+// reverse order, so the workflow ends either completed or without effect
+// (compensated). A workflow whose compensation fails ends failed and names
+// the step in the error; its remaining steps have to be undone manually.
+//
+// Execution is synchronous; the saga orchestrator of the application layer
+// runs workflows in the background and resumes unfinished ones on startup.
+//
+// Usage Example:
+//
+//	service, err := workflow.NewWorkflowService(repo, workflow.NewProvisioningWorkflow(modules, allocator, bus))
+//	w, err := service.Create(workflowModel.KindProvisionModule, "jane", workflow.Data{"name": "Inventory", "plan": "small"})
+//	err = service.Execute(ctx, w)
+type WorkflowService struct {
+	repo        WorkflowRepository
+	definitions map[string]Definition
+}
+
+// NewWorkflowService creates a new instance of WorkflowService.
+//
+// Parameters:
+//   - repo: Data access of the workflow state
+//   - definitions: Kinds of workflows the service executes
+//
+// Returns:
+//   - *WorkflowService: A new service instance
+//   - error: guard.ErrNilDependency if a required dependency is nil
+func NewWorkflowService(repo WorkflowRepository, definitions ...Definition) (*WorkflowService, error) {
+	if err := guard.Require("NewWorkflowService", guard.Dep("repo", repo)); err != nil {
+		return nil, err
+	}
+
+	s := &WorkflowService{repo: repo, definitions: make(map[string]Definition, len(definitions))}
+	for _, definition := range definitions {
+		s.definitions[definition.Kind] = definition
+	}
+	return s, nil
+}
+
+// Create stores a new running workflow without executing it.
+//
+// Parameters:
+//   - kind: Kind of the workflow
+//   - actor: Who starts the workflow
+//   - data: Input values of the steps
+//
+// Returns:
+//   - *workflow.Workflow: The stored workflow
+//   - error: Error if the kind is unknown or the workflow cannot be stored
+func (s *WorkflowService) Create(kind, actor string, data Data) (*workflow.Workflow, error) {
+	if _, ok := s.definitions[kind]; !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKind, kind)
+	}
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	w := &workflow.Workflow{
+		ID:        uuid.NewString(),
+		Kind:      kind,
+		Status:    workflow.StatusRunning,
+		Actor:     actor,
+		Data:      string(encoded),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.repo.SaveWorkflow(w); err != nil {
+		return nil, fmt.Errorf("database error saving workflow: %w", err)
+	}
+	return w, nil
+}
+
+// Execute continues a workflow until it is finished.
+//
+// Parameters:
+//   - ctx: Context of the steps; when it is cancelled the workflow stops
+//     after the current step and stays unfinished, to be resumed
+//   - w: Workflow to execute, updated in place
+//
+// Returns:
+//   - error: Error if the workflow was interrupted or its state cannot be
+//     saved; failed steps are recorded in the workflow instead
+//
+// Execution Flow:
+//  1. Run the steps after the last completed one, saving after each
+//  2. On a failed step, record the failure and switch to compensating
+//  3. Compensate the completed steps in reverse order, saving after each
+//  4. Finish as completed, compensated or (compensation failed) failed
+func (s *WorkflowService) Execute(ctx context.Context, w *workflow.Workflow) error {
+	definition, ok := s.definitions[w.Kind]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownKind, w.Kind)
+	}
+	instance := &Instance{ID: w.ID, Actor: w.Actor}
+	if err := json.Unmarshal([]byte(w.Data), &instance.Data); err != nil {
+		return fmt.Errorf("decode data of workflow %s: %w", w.ID, err)
+	}
+	if instance.Data == nil {
+		instance.Data = Data{}
+	}
+
+	// Step 1: Run the remaining steps
+	for w.Status == workflow.StatusRunning && w.Step < len(definition.Steps) {
+		step := definition.Steps[w.Step]
+		if err := step.Run(ctx, instance); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			// Step 2: Switch to compensation
+			w.Status, w.FailedStep = workflow.StatusCompensating, step.Name
+			w.Error = truncate(fmt.Sprintf("%s: %v", step.Name, err))
+		} else {
+			w.Step++
+		}
+		if err := s.save(w, instance.Data); err != nil {
+			return err
+		}
+	}
+	if w.Status == workflow.StatusRunning {
+		w.Status = workflow.StatusCompleted
+		return s.save(w, instance.Data)
+	}
+
+	// Step 3: Undo the completed steps, latest first
+	for w.Status == workflow.StatusCompensating && w.Step > 0 {
+		step := definition.Steps[w.Step-1]
+		if step.Compensate != nil {
+			if err := step.Compensate(ctx, instance); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				w.Status = workflow.StatusFailed
+				w.Error = truncate(fmt.Sprintf("%s; compensating %s: %v", w.Error, step.Name, err))
+				return s.save(w, instance.Data)
+			}
+		}
+		w.Step--
+		if err := s.save(w, instance.Data); err != nil {
+			return err
+		}
+	}
+
+	// Step 4: Everything was undone
+	if w.Status == workflow.StatusCompensating {
+		w.Status = workflow.StatusCompensated
+		return s.save(w, instance.Data)
+	}
+	return nil
+}
+
+// GetWorkflow returns the state of a workflow.
+//
+// Parameters:
+//   - id: Identifier of the workflow
+//
+// Returns:
+//   - *workflow.WorkflowResponse: The workflow with the state of every step
+//   - error: Error if the workflow does not exist or the query fails
+//
+// Error Types:
+//   - ErrNotFound: When no workflow has the identifier
+func (s *WorkflowService) GetWorkflow(id string) (*workflow.WorkflowResponse, error) {
+	w, err := s.repo.GetWorkflow(id)
+	if err != nil {
+		return nil, fmt.Errorf("database error loading workflow: %w", err)
+	}
+	if w == nil {
+		return nil, ErrNotFound
+	}
+	return s.Response(w), nil
+}
+
+// Unfinished returns the workflows to resume after a restart.
+//
+// Returns:
+//   - []*workflow.Workflow: Running and compensating workflows, oldest first
+//   - error: Error if the query fails
+func (s *WorkflowService) Unfinished() ([]*workflow.Workflow, error) {
+	return s.repo.ListUnfinishedWorkflows()
+}
+
+// Response converts a workflow into its API representation.
+//
+// Parameters:
+//   - w: The workflow
+//
+// Returns:
+//   - *workflow.WorkflowResponse: The workflow with the state of every step
+func (s *WorkflowService) Response(w *workflow.Workflow) *workflow.WorkflowResponse {
+	data := map[string]string{}
+	_ = json.Unmarshal([]byte(w.Data), &data)
+
+	steps := make([]workflow.StepResponse, 0)
+	failed := false
+	for i, step := range s.definitions[w.Kind].Steps {
+		status := workflow.StepPending
+		switch {
+		case step.Name == w.FailedStep:
+			status, failed = workflow.StepFailed, true
+		case i < w.Step:
+			status = workflow.StepCompleted
+		case w.FailedStep != "" && !failed:
+			status = workflow.StepCompensated
+		}
+		steps = append(steps, workflow.StepResponse{Name: step.Name, Status: status})
+	}
+
+	return &workflow.WorkflowResponse{
+		ID:        w.ID,
+		Kind:      w.Kind,
+		Status:    w.Status,
+		Steps:     steps,
+		Data:      data,
+		Error:     w.Error,
+		CreatedAt: w.CreatedAt,
+		UpdatedAt: w.UpdatedAt,
+	}
+}
+
+// save stores the workflow with its current data.
+func (s *WorkflowService) save(w *workflow.Workflow, data Data) error {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	w.Data, w.UpdatedAt = string(encoded), time.Now()
+	if err := s.repo.SaveWorkflow(w); err != nil {
+		return fmt.Errorf("database error saving workflow %s: %w", w.ID, err)
+	}
+	return nil
+}
+
+// truncate shortens an error message to the error column.
+func truncate(message string) string {
+	if len(message) > maxErrorLength {
+		return message[:maxErrorLength]
+	}
+	return message
+}
@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// Storage errors
+var (
+	// ErrInvalidSignature is returned for download links that are forged, altered or expired
+	ErrInvalidSignature = errors.New("invalid or expired download signature")
+
+	// ErrObjectNotFound is returned when no object is stored under a key
+	ErrObjectNotFound = errors.New("object not found")
+)
+
+// ObjectInfo describes a stored object.
+type ObjectInfo struct {
+	// Key of the object
+	Key string `json:"key"`
+
+	// Content length in bytes
+	Size int64 `json:"size"`
+
+	// Timestamp when the object was last written
+	LastModified time.Time `json:"lastModified"`
+}
+
+// Storage stores objects and hands out time-limited download links.
+//
+// The interface is owned by the domain layer so services never depend on a
+// storage technology. Implementations live in the infrastructure layer:
+//   - LocalStorage: files on disk, downloaded through signed API URLs
+//   - S3Storage: S3-compatible object storage (AWS S3, Google Cloud Storage
+//     through its XML API with HMAC keys, MinIO)
+//
+// Keys are slash-separated relative paths such as "exports/modules.csv".
+type Storage interface {
+	// Put stores size bytes read from body under the key, replacing any
+	// existing object
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+
+	// PresignGet returns a URL allowing anyone holding it to download the
+	// object until ttl has passed
+	PresignGet(key string, ttl time.Duration) (string, error)
+
+	// Get opens the object for reading; the caller must close it. A missing
+	// object is reported as an error wrapping ErrObjectNotFound
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// List returns the objects whose key starts with prefix, ordered by key
+	List(ctx context.Context, prefix string) ([]ObjectInfo, error)
+}
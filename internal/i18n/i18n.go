@@ -0,0 +1,350 @@
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/locales"
+	"github.com/go-playground/locales/de"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/es"
+	"github.com/go-playground/locales/fr"
+	"github.com/go-playground/locales/it"
+	"github.com/go-playground/locales/nl"
+	"github.com/go-playground/locales/pt"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+)
+
+// bundles holds the message bundles shipped with the application.
+//
+//go:embed bundles
+var bundles embed.FS
+
+// DefaultLocale is the language of callers accepting no supported language.
+const DefaultLocale = "en"
+
+// TranslatorContextKey is the Gin context key holding the translator of the
+// caller's language, set by the locale middleware.
+const TranslatorContextKey = "translator"
+
+// supportedLocales are the languages bundles can be loaded for, with their
+// plural and number rules.
+var supportedLocales = map[string]func() locales.Translator{
+	"de": de.New,
+	"en": en.New,
+	"es": es.New,
+	"fr": fr.New,
+	"it": it.New,
+	"nl": nl.New,
+	"pt": pt.New,
+}
+
+// rules pick the message key and parameters of the validation rules used in
+// binding tags; other rules are reported as "validation.failed".
+var rules = map[string]func(fieldErr validator.FieldError) (string, []string){
+	"required": func(validator.FieldError) (string, []string) {
+		return "validation.required", nil
+	},
+	"min": func(fieldErr validator.FieldError) (string, []string) {
+		if isNumeric(fieldErr) {
+			return "validation.min_number", []string{fieldErr.Param()}
+		}
+		return "validation.min", nil
+	},
+	"max": func(fieldErr validator.FieldError) (string, []string) {
+		if isNumeric(fieldErr) {
+			return "validation.max_number", []string{fieldErr.Param()}
+		}
+		return "validation.max", nil
+	},
+}
+
+// builtin are the embedded English messages, used without a translator.
+var builtin = mustReadBundle(bundles, "bundles/"+DefaultLocale+".json")
+
+// Bundle holds the translated messages of every loaded language.
+//
+// Messages are loaded once at startup from the embedded bundles (en, es, fr,
+// de) and, if configured, a bundle directory. A <locale>.json file in the
+// directory replaces the messages it lists in the embedded bundle of its
+// language, or adds the language (it, nl, pt). Messages missing in a language
+// fall back to English.
+//
+// Bundles are flat JSON objects of message keys and texts; "{0}" marks the
+// parameter of a message, e.g. the limit of a min rule:
+//
+//	{"validation.min_number": "Value must be at least {0}"}
+//
+// Usage Example:
+//
+//	bundle, err := i18n.New("/etc/modules/i18n")
+//	err = bundle.RegisterTranslations(binding.Validator.Engine().(*validator.Validate))
+//	trans := bundle.Translator("fr-CH, fr;q=0.9, en;q=0.8")
+//	message := i18n.FieldMessage(trans, fieldErr)
+type Bundle struct {
+	universal *ut.UniversalTranslator
+	loaded    []string
+}
+
+// New loads the embedded bundles and applies the bundle directory.
+//
+// Parameters:
+//   - dir: Directory with <locale>.json bundles (empty for none)
+//
+// Returns:
+//   - *Bundle: The loaded messages
+//   - error: Error if the directory cannot be read, a bundle does not parse
+//     or names an unsupported language, or a message has malformed parameters
+func New(dir string) (*Bundle, error) {
+	// Step 1: Collect messages per language, directory entries replacing defaults
+	root, err := fs.Sub(bundles, "bundles")
+	if err != nil {
+		return nil, err
+	}
+	messages, err := readBundles(root)
+	if err != nil {
+		return nil, fmt.Errorf("read default bundles: %w", err)
+	}
+	if dir != "" {
+		overrides, err := readBundles(os.DirFS(dir))
+		if err != nil {
+			return nil, fmt.Errorf("read bundles from %s: %w", dir, err)
+		}
+		for locale, texts := range overrides {
+			if messages[locale] == nil {
+				messages[locale] = make(map[string]string)
+			}
+			for key, text := range texts {
+				messages[locale][key] = text
+			}
+		}
+	}
+
+	// Step 2: Register every language, filling its gaps with English
+	fallback := en.New()
+	others := make([]locales.Translator, 0, len(messages))
+	for locale := range messages {
+		if locale != DefaultLocale {
+			others = append(others, supportedLocales[locale]())
+		}
+	}
+	bundle := &Bundle{universal: ut.New(fallback, append(others, fallback)...)}
+
+	for locale, texts := range messages {
+		trans, _ := bundle.universal.GetTranslator(locale)
+		for key, text := range messages[DefaultLocale] {
+			if translated, ok := texts[key]; ok {
+				text = translated
+			}
+			if err := trans.Add(key, text, true); err != nil {
+				return nil, fmt.Errorf("message %q of %s: %w", key, locale, err)
+			}
+		}
+		for key, text := range texts {
+			if _, ok := messages[DefaultLocale][key]; ok {
+				continue
+			}
+			if err := trans.Add(key, text, true); err != nil {
+				return nil, fmt.Errorf("message %q of %s: %w", key, locale, err)
+			}
+		}
+		bundle.loaded = append(bundle.loaded, locale)
+	}
+	sort.Strings(bundle.loaded)
+
+	return bundle, nil
+}
+
+// Locales returns the loaded languages.
+//
+// Returns:
+//   - []string: Locale names, sorted, e.g. ["de", "en", "es", "fr"]
+func (b *Bundle) Locales() []string {
+	return slices.Clone(b.loaded)
+}
+
+// Translator returns the translator of the best language a caller accepts.
+//
+// Parameters:
+//   - acceptLanguage: The Accept-Language header, e.g. "fr-CH, fr;q=0.9, en;q=0.8";
+//     regional tags fall back to their language
+//
+// Returns:
+//   - ut.Translator: The translator, English when no loaded language is accepted
+func (b *Bundle) Translator(acceptLanguage string) ut.Translator {
+	if trans, ok := b.universal.FindTranslator(acceptedLocales(acceptLanguage)...); ok {
+		return trans
+	}
+	return b.universal.GetFallback()
+}
+
+// RegisterTranslations hooks the messages into a validator, so field errors
+// translate themselves with validator.FieldError.Translate.
+//
+// Parameters:
+//   - v: The validator of the request bindings
+//
+// Returns:
+//   - error: Error if a translation cannot be registered
+func (b *Bundle) RegisterTranslations(v *validator.Validate) error {
+	for _, locale := range b.loaded {
+		trans, _ := b.universal.GetTranslator(locale)
+		for tag, rule := range rules {
+			register := func(ut.Translator) error { return nil }
+			translate := func(trans ut.Translator, fieldErr validator.FieldError) string {
+				key, params := rule(fieldErr)
+				return Message(trans, key, params...)
+			}
+			if err := v.RegisterTranslation(tag, trans, register, translate); err != nil {
+				return fmt.Errorf("register %s translation of %s: %w", locale, tag, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Message returns a message in the translator's language.
+//
+// Parameters:
+//   - trans: The translator (nil for English)
+//   - key: Message key, e.g. "validation.integer"
+//   - params: Values of the "{0}", "{1}", ... placeholders
+//
+// Returns:
+//   - string: The message, or the key when no bundle has it
+func Message(trans ut.Translator, key string, params ...string) string {
+	if trans != nil {
+		if text, err := trans.T(key, params...); err == nil {
+			return text
+		}
+	}
+
+	text, ok := builtin[key]
+	if !ok {
+		return key
+	}
+	for i, param := range params {
+		text = strings.ReplaceAll(text, "{"+strconv.Itoa(i)+"}", param)
+	}
+	return text
+}
+
+// FieldMessage describes a failed validation rule in the translator's language.
+//
+// Parameters:
+//   - trans: The translator (nil for English); its translations must be
+//     registered with the validator that produced the error
+//   - fieldErr: The failed rule of one field
+//
+// Returns:
+//   - string: The message reported for the field
+func FieldMessage(trans ut.Translator, fieldErr validator.FieldError) string {
+	rule, ok := rules[fieldErr.Tag()]
+	if !ok {
+		return Message(trans, "validation.failed")
+	}
+	if trans != nil {
+		return fieldErr.Translate(trans)
+	}
+	key, params := rule(fieldErr)
+	return Message(nil, key, params...)
+}
+
+// acceptedLocales lists the locale names of an Accept-Language header by
+// preference, each regional tag followed by its language.
+func acceptedLocales(header string) []string {
+	type weighted struct {
+		tag     string
+		quality float64
+	}
+
+	var tags []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if tag = strings.TrimSpace(tag); tag != "" && tag != "*" && quality > 0 {
+			tags = append(tags, weighted{tag: tag, quality: quality})
+		}
+	}
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].quality > tags[j].quality })
+
+	var names []string
+	for _, tag := range tags {
+		name := strings.ReplaceAll(strings.ToLower(tag.tag), "-", "_")
+		names = append(names, name)
+		if language, _, regional := strings.Cut(name, "_"); regional {
+			names = append(names, language)
+		}
+	}
+	return names
+}
+
+// readBundles reads the <locale>.json files of a directory.
+func readBundles(fsys fs.FS) (map[string]map[string]string, error) {
+	messages := make(map[string]map[string]string)
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		locale := strings.TrimSuffix(entry.Name(), ".json")
+		if _, ok := supportedLocales[locale]; !ok {
+			return nil, fmt.Errorf("%s: unsupported locale %q", entry.Name(), locale)
+		}
+		texts, err := readBundle(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		messages[locale] = texts
+	}
+	return messages, nil
+}
+
+// readBundle parses one bundle file.
+func readBundle(fsys fs.FS, name string) (map[string]string, error) {
+	raw, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	var texts map[string]string
+	if err := json.Unmarshal(raw, &texts); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return texts, nil
+}
+
+// mustReadBundle parses an embedded bundle.
+func mustReadBundle(fsys fs.FS, name string) map[string]string {
+	texts, err := readBundle(fsys, name)
+	if err != nil {
+		panic(err)
+	}
+	return texts
+}
+
+// isNumeric reports whether a rule was checked against a number rather than
+// a length.
+func isNumeric(fieldErr validator.FieldError) bool {
+	kind := fieldErr.Kind()
+	return kind >= reflect.Int && kind <= reflect.Float64
+}
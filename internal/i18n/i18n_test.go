@@ -0,0 +1,36 @@
+package i18n_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	_ "go_di_architecture/internal/app/bootstrap"
+	"go_di_architecture/internal/domain/apperror"
+)
+
+// TestBundlesCoverDeclaredErrors checks every shipped bundle translates the
+// message key of every declared AppError.
+func TestBundlesCoverDeclaredErrors(t *testing.T) {
+	paths, err := filepath.Glob("bundles/*.json")
+	if err != nil || len(paths) == 0 {
+		t.Fatalf("no bundles found: %v", err)
+	}
+
+	for _, path := range paths {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var texts map[string]string
+		if err := json.Unmarshal(raw, &texts); err != nil {
+			t.Fatalf("%s: %v", path, err)
+		}
+		for _, declared := range apperror.Declared() {
+			if texts[declared.MessageKey] == "" {
+				t.Errorf("%s has no message for %q", filepath.Base(path), declared.MessageKey)
+			}
+		}
+	}
+}
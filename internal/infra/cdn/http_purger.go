@@ -0,0 +1,93 @@
+package cdn
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"go_di_architecture/internal/infra/httpclient"
+)
+
+// purgeRequest is the JSON body posted by HTTPPurger.
+type purgeRequest struct {
+	SurrogateKeys []string `json:"surrogate_keys"`
+}
+
+// HTTPPurger purges surrogate keys through an HTTP purge endpoint.
+//
+// Every purge is one POST carrying the keys twice, so common endpoints accept
+// it without an adapter:
+//   - In a Surrogate-Key header, space-separated (Fastly's batch purge:
+//     POST https://api.fastly.com/service/<id>/purge)
+//   - In a JSON body: {"surrogate_keys": ["module-123", "modules"]}
+//
+// The token, if any, is sent as "Authorization: Bearer <token>" and as
+// Fastly-Key.
+//
+// Usage Example:
+//
+//	purger := cdn.NewHTTPPurger("https://api.fastly.com/service/abc/purge", token, client)
+//	err := purger.Purge(ctx, []string{"module-123", "modules"})
+type HTTPPurger struct {
+	url    string
+	token  string
+	client *httpclient.Client
+}
+
+// NewHTTPPurger creates a purger posting to the URL.
+//
+// Parameters:
+//   - url: Purge endpoint
+//   - token: API token of the endpoint (empty sends none)
+//   - client: Client sending the requests, with its timeout and retries
+//
+// Returns:
+//   - *HTTPPurger: A new purger
+func NewHTTPPurger(url, token string, client *httpclient.Client) *HTTPPurger {
+	return &HTTPPurger{url: url, token: token, client: client}
+}
+
+// Purge asks the CDN to drop the responses tagged with the keys.
+//
+// Parameters:
+//   - ctx: Context bounding the request
+//   - keys: Surrogate keys to purge
+//
+// Returns:
+//   - error: Error if the request fails or the endpoint does not answer 2xx
+func (p *HTTPPurger) Purge(ctx context.Context, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	encoded, err := json.Marshal(purgeRequest{SurrogateKeys: keys})
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(encoded))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Surrogate-Key", strings.Join(keys, " "))
+	if p.token != "" {
+		request.Header.Set("Authorization", "Bearer "+p.token)
+		request.Header.Set("Fastly-Key", p.token)
+	}
+
+	response, err := p.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, response.Body)
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("POST %s: unexpected status %d", p.url, response.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"go_di_architecture/internal/config"
+	"go_di_architecture/internal/logging"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// logger writes the migrations applied and the changes of database health.
+var logger = logging.New("db")
+
+// Open creates a GORM connection for the configured driver.
+//
+// Supported Drivers:
+//   - sqlite: Pure Go SQLite (no CGO required)
+//   - postgres: PostgreSQL via pgx
+//   - mysql: MySQL 8.0.13+ (functional indexes are required by migrations)
+//
+// Performance Settings:
+//   - PrepareStmt caches prepared statements per connection
+//   - SkipDefaultTransaction runs single writes without BEGIN/COMMIT; writes
+//     spanning several statements use explicit transactions in the repositories
+//   - BatchSize splits inserts of many records into INSERTs of that many rows
+//   - QueryTimeout and WriteTimeout bound every statement (see EnforceTimeouts)
+//
+// Parameters:
+//   - cfg: Database driver, connection string and performance settings
+//
+// Returns:
+//   - *gorm.DB: An open database connection
+//   - error: Error if the driver is unsupported or the connection fails
+func Open(cfg config.DatabaseConfig) (*gorm.DB, error) {
+	var dialector gorm.Dialector
+
+	switch cfg.Driver {
+	case config.DriverSQLite:
+		dialector = sqlite.Open(cfg.DSN)
+	case config.DriverPostgres:
+		dialector = postgres.Open(cfg.DSN)
+	case config.DriverMySQL:
+		dialector = mysql.Open(cfg.DSN)
+	default:
+		return nil, fmt.Errorf("unsupported database driver %q", cfg.Driver)
+	}
+
+	// TranslateError maps unique violations to gorm.ErrDuplicatedKey
+	db, err := gorm.Open(dialector, &gorm.Config{
+		TranslateError:         true,
+		PrepareStmt:            cfg.PrepareStmt,
+		SkipDefaultTransaction: cfg.SkipDefaultTransaction,
+		CreateBatchSize:        cfg.BatchSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("open %s database: %w", cfg.Driver, err)
+	}
+
+	timeouts := Timeouts{Read: cfg.QueryTimeout, Write: cfg.WriteTimeout}
+	if err := EnforceTimeouts(db, timeouts); err != nil {
+		return nil, fmt.Errorf("register statement timeouts: %w", err)
+	}
+
+	return db, nil
+}
+
+// PrimeConnections is how many connections Prime opens: the idle pool size
+// database/sql keeps by default, so every primed connection is reused.
+const PrimeConnections = 2
+
+// Prime opens pool connections ahead of the first requests.
+//
+// The connections are held at the same time so the pool cannot hand out one
+// connection repeatedly, then returned to the idle pool.
+//
+// Parameters:
+//   - ctx: Context bounding the connection attempts
+//   - db: Database whose pool is primed
+//   - connections: Number of connections to open
+//
+// Returns:
+//   - error: Error if a connection cannot be opened
+func Prime(ctx context.Context, db *gorm.DB, connections int) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+
+	conns := make([]*sql.Conn, 0, connections)
+	defer func() {
+		for _, conn := range conns {
+			conn.Close()
+		}
+	}()
+
+	for range connections {
+		conn, err := sqlDB.Conn(ctx)
+		if err != nil {
+			return err
+		}
+		conns = append(conns, conn)
+		if err := conn.PingContext(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package db
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// IDGenerator assigns the IDs of new modules.
+//
+// The default leaves them to the auto-increment column of the database,
+// which needs every instance writing to one primary. Snowflake IDs are
+// collision-free across instances and databases, e.g. shards or regions
+// merged later, without a round trip to a shared sequence.
+type IDGenerator interface {
+	// NextID returns the ID of the next row, or zero to leave it to the
+	// auto-increment column
+	NextID() (int, error)
+}
+
+// AutoIncrement leaves IDs to the auto-increment column of the database.
+type AutoIncrement struct{}
+
+// NextID returns zero, so the database assigns the ID.
+func (AutoIncrement) NextID() (int, error) {
+	return 0, nil
+}
+
+// Layout of a snowflake ID: 41 bits of milliseconds since SnowflakeEpoch,
+// 10 bits of node and 12 bits of sequence, leaving the sign bit clear.
+const (
+	snowflakeNodeBits     = 10
+	snowflakeSequenceBits = 12
+
+	// MaxSnowflakeNode is the highest node number of a snowflake generator
+	MaxSnowflakeNode = 1<<snowflakeNodeBits - 1
+
+	maxSnowflakeSequence = 1<<snowflakeSequenceBits - 1
+)
+
+// SnowflakeEpoch is the time snowflake IDs count from; changing it would
+// let new IDs collide with existing ones.
+var SnowflakeEpoch = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// Snowflake generates time-ordered 63-bit IDs unique across up to 1024
+// nodes.
+//
+// Each node issues up to 4096 IDs per millisecond; beyond that, and when the
+// wall clock steps back, IDs are taken from the following milliseconds, so
+// generating never blocks and never repeats an ID. Every instance writing to
+// the same tables needs its own node number.
+//
+// Snowflake IDs exceed 2^53, the largest integer JavaScript numbers hold
+// exactly; browser clients must read the id fields of responses as strings
+// or BigInt.
+//
+// Usage Example:
+//
+//	ids, err := db.NewSnowflake(3)
+//	if err != nil { ... }
+//	id, _ := ids.NextID()
+type Snowflake struct {
+	node int64
+
+	mu       sync.Mutex
+	last     int64
+	sequence int64
+}
+
+// NewSnowflake creates a snowflake generator for a node.
+//
+// Parameters:
+//   - node: Node number, unique among the instances (0 to MaxSnowflakeNode)
+//
+// Returns:
+//   - *Snowflake: A new generator
+//   - error: Error if the node is out of range or int cannot hold 63 bits
+func NewSnowflake(node int) (*Snowflake, error) {
+	if node < 0 || node > MaxSnowflakeNode {
+		return nil, fmt.Errorf("snowflake node %d out of range 0-%d", node, MaxSnowflakeNode)
+	}
+	if strconv.IntSize < 64 {
+		return nil, fmt.Errorf("snowflake IDs need a 64-bit int, have %d bits", strconv.IntSize)
+	}
+	return &Snowflake{node: int64(node), last: -1}, nil
+}
+
+// NextID returns a new ID, greater than every ID the generator returned
+// before.
+func (s *Snowflake) NextID() (int, error) {
+	now := time.Since(SnowflakeEpoch).Milliseconds()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case now > s.last:
+		s.last, s.sequence = now, 0
+	case s.sequence < maxSnowflakeSequence:
+		s.sequence++
+	default:
+		s.last, s.sequence = s.last+1, 0
+	}
+	if s.last < 0 || s.last >= 1<<41 {
+		return 0, fmt.Errorf("snowflake clock %d ms outside the 41-bit range since %s", s.last, SnowflakeEpoch.Format(time.DateOnly))
+	}
+	return int(s.last<<(snowflakeNodeBits+snowflakeSequenceBits) | s.node<<snowflakeSequenceBits | s.sequence), nil
+}
@@ -0,0 +1,411 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	"go_di_architecture/internal/domain/models/leader"
+	"go_di_architecture/internal/domain/models/module"
+	"go_di_architecture/internal/domain/models/quota"
+	"go_di_architecture/internal/domain/models/tag"
+	"go_di_architecture/internal/domain/models/template"
+	"go_di_architecture/internal/domain/models/workflow"
+
+	"gorm.io/gorm"
+)
+
+// ModuleNameIndex is the case-insensitive unique index on module names.
+const ModuleNameIndex = "idx_modules_name_lower"
+
+// legacyModuleNameIndex is the case-sensitive index created by earlier model tags.
+const legacyModuleNameIndex = "idx_name_active"
+
+// Migration is a schema change applied exactly once per database.
+type Migration struct {
+	// Unique, sortable identifier recorded in schema_migrations
+	ID string
+
+	// Short description printed when the migration runs
+	Description string
+
+	// Function applying the change inside a transaction
+	Up func(tx *gorm.DB) error
+}
+
+// migrations lists every schema change in the order it must be applied.
+var migrations = []Migration{
+	{
+		ID:          "0001_create_modules",
+		Description: "create modules table",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&module.Module{})
+		},
+	},
+	{
+		ID:          "0002_modules_name_lower_unique",
+		Description: "enforce case-insensitive unique module names",
+		Up:          createModuleNameIndex,
+	},
+	{
+		ID:          "0003_modules_created_at_id_index",
+		Description: "index modules by (created_at, id) for keyset pagination",
+		Up: func(tx *gorm.DB) error {
+			return tx.Exec("CREATE INDEX idx_modules_created_at_id ON modules (created_at, id)").Error
+		},
+	},
+	{
+		ID:          "0004_modules_updated_at",
+		Description: "track when modules were last changed",
+		Up:          addModuleUpdatedAt,
+	},
+	{
+		ID:          "0005_create_tags",
+		Description: "create tags and module_tags tables",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&tag.Tag{}, &tag.ModuleTag{})
+		},
+	},
+	{
+		ID:          "0006_create_module_dependencies",
+		Description: "create module_dependencies table",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&module.ModuleDependency{})
+		},
+	},
+	{
+		ID:          "0007_create_module_settings",
+		Description: "create module_settings table",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&module.ModuleSetting{})
+		},
+	},
+	{
+		ID:          "0008_create_module_revisions",
+		Description: "create module_revisions table with a baseline revision per module",
+		Up:          createModuleRevisions,
+	},
+	{
+		ID:          "0009_modules_soft_delete",
+		Description: "add deleted_at and deleted_by to modules for the recycle bin",
+		Up:          addModuleSoftDelete,
+	},
+	{
+		ID:          "0010_modules_activation_schedule",
+		Description: "add activate_at and deactivate_at to modules",
+		Up:          addModuleSchedule,
+	},
+	{
+		ID:          "0011_create_module_acl",
+		Description: "create module_acl table",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&module.ModuleACLEntry{})
+		},
+	},
+	{
+		ID:          "0012_module_ownership",
+		Description: "add owner to modules and create module_transfers table",
+		Up:          addModuleOwnership,
+	},
+	{
+		ID:          "0013_create_module_archive",
+		Description: "create module_archive table for modules archived by the retention policy",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&module.ModuleArchiveRecord{})
+		},
+	},
+	{
+		ID:          "0014_create_module_usage",
+		Description: "create module_usage table with daily request counters",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&module.ModuleUsage{})
+		},
+	},
+	{
+		ID:          "0015_create_api_key_usage",
+		Description: "create api_key_usage table with monthly request counters",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&quota.APIKeyUsage{})
+		},
+	},
+	{
+		ID:          "0016_create_leader_leases",
+		Description: "create leader_leases table for leader election",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&leader.Lease{})
+		},
+	},
+	{
+		ID:          "0017_modules_approval_status",
+		Description: "add the approval status to modules, approving existing ones",
+		Up:          addModuleStatus,
+	},
+	{
+		ID:          "0018_create_module_notes",
+		Description: "create module_notes table",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&module.ModuleNote{})
+		},
+	},
+	{
+		ID:          "0019_create_module_stars",
+		Description: "create module_stars table with per-user favorites",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&module.ModuleStar{})
+		},
+	},
+	{
+		ID:          "0020_create_module_templates",
+		Description: "create module_templates table for the template catalogue",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&template.ModuleTemplate{})
+		},
+	},
+	{
+		ID:          "0021_create_workflows",
+		Description: "create workflows table holding the state of sagas",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&workflow.Workflow{})
+		},
+	},
+	{
+		ID:          "0022_create_module_summaries",
+		Description: "create module_summaries read model and fill it from the change history",
+		Up:          createModuleSummaries,
+	},
+}
+
+// schemaMigration records an applied migration.
+type schemaMigration struct {
+	ID        string `gorm:"primaryKey;size:100"`
+	AppliedAt time.Time
+}
+
+// TableName overrides the default GORM table name.
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Migrate applies all pending migrations in order.
+//
+// Applied migrations are recorded in the schema_migrations table so each one
+// runs exactly once. Every migration runs in its own transaction; note that
+// MySQL commits DDL implicitly, so a failed MySQL migration may be partially
+// applied and must be fixed manually. Migrations rebuilding large tables take
+// longer than any request, so the statement timeouts do not apply; only the
+// context of db bounds them.
+//
+// Parameters:
+//   - db: Database connection to migrate
+//
+// Returns:
+//   - error: Error if a migration fails
+func Migrate(db *gorm.DB) error {
+	db = WithQueryTimeout(db, 0)
+
+	// Step 1: Ensure the bookkeeping table exists
+	if err := db.AutoMigrate(&schemaMigration{}); err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+
+	// Step 2: Load applied migrations
+	var appliedIDs []string
+	if err := db.Model(&schemaMigration{}).Pluck("id", &appliedIDs).Error; err != nil {
+		return fmt.Errorf("load applied migrations: %w", err)
+	}
+
+	applied := make(map[string]bool, len(appliedIDs))
+	for _, id := range appliedIDs {
+		applied[id] = true
+	}
+
+	// Step 3: Apply pending migrations in order
+	for _, migration := range migrations {
+		if applied[migration.ID] {
+			continue
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := migration.Up(tx); err != nil {
+				return err
+			}
+			return tx.Create(&schemaMigration{ID: migration.ID, AppliedAt: time.Now()}).Error
+		})
+		if err != nil {
+			return fmt.Errorf("migration %s: %w", migration.ID, err)
+		}
+
+		logger.Infof("Applied migration %s (%s)", migration.ID, migration.Description)
+	}
+
+	return nil
+}
+
+// createModuleNameIndex creates a functional LOWER(name) unique index for the dialect.
+//
+// The service checks name uniqueness case-insensitively, but a plain unique
+// index on name only rejects exact matches on PostgreSQL and MySQL (with a
+// case-sensitive collation). The functional index makes the database enforce
+// the same rule, closing the race between the service check and the insert.
+//
+// Dialect Statements:
+//   - PostgreSQL: CREATE UNIQUE INDEX ... ON modules (LOWER(name))
+//   - SQLite 3.9+: CREATE UNIQUE INDEX ... ON modules (LOWER(name))
+//   - MySQL 8.0.13+: CREATE UNIQUE INDEX ... ON modules ((LOWER(name)))
+func createModuleNameIndex(tx *gorm.DB) error {
+	// Step 1: Drop the case-sensitive index created by earlier versions
+	if tx.Migrator().HasIndex(&module.Module{}, legacyModuleNameIndex) {
+		if err := tx.Migrator().DropIndex(&module.Module{}, legacyModuleNameIndex); err != nil {
+			return err
+		}
+	}
+
+	// Step 2: Create the functional index
+	var statement string
+	switch dialect := tx.Dialector.Name(); dialect {
+	case "postgres", "sqlite":
+		statement = fmt.Sprintf("CREATE UNIQUE INDEX IF NOT EXISTS %s ON modules (LOWER(name))", ModuleNameIndex)
+	case "mysql":
+		statement = fmt.Sprintf("CREATE UNIQUE INDEX %s ON modules ((LOWER(name)))", ModuleNameIndex)
+	default:
+		return fmt.Errorf("case-insensitive name index not supported for dialect %q", dialect)
+	}
+
+	return tx.Exec(statement).Error
+}
+
+// addModuleUpdatedAt adds the updated_at column and backfills it from created_at.
+//
+// Databases created after the column was introduced already have it (the
+// first migration creates the table from the current model), so only the
+// backfill runs there.
+func addModuleUpdatedAt(tx *gorm.DB) error {
+	// Step 1: Add the column to tables created by earlier versions
+	if !tx.Migrator().HasColumn(&module.Module{}, "UpdatedAt") {
+		if err := tx.Migrator().AddColumn(&module.Module{}, "UpdatedAt"); err != nil {
+			return err
+		}
+	}
+
+	// Step 2: Treat the creation time as the last change of existing rows
+	return tx.Exec("UPDATE modules SET updated_at = created_at WHERE updated_at IS NULL").Error
+}
+
+// addModuleSoftDelete adds the recycle bin columns to modules.
+//
+// Databases created after soft delete was introduced already have them, so
+// every step is conditional.
+func addModuleSoftDelete(tx *gorm.DB) error {
+	migrator := tx.Migrator()
+	for _, field := range []string{"DeletedAt", "DeletedBy"} {
+		if !migrator.HasColumn(&module.Module{}, field) {
+			if err := migrator.AddColumn(&module.Module{}, field); err != nil {
+				return err
+			}
+		}
+	}
+
+	if !migrator.HasIndex(&module.Module{}, "DeletedAt") {
+		return migrator.CreateIndex(&module.Module{}, "DeletedAt")
+	}
+	return nil
+}
+
+// addModuleSchedule adds the scheduled activation columns and their indexes.
+//
+// The scheduler looks up due modules by each column, so both are indexed.
+func addModuleSchedule(tx *gorm.DB) error {
+	migrator := tx.Migrator()
+	for _, field := range []string{"ActivateAt", "DeactivateAt"} {
+		if !migrator.HasColumn(&module.Module{}, field) {
+			if err := migrator.AddColumn(&module.Module{}, field); err != nil {
+				return err
+			}
+		}
+		if !migrator.HasIndex(&module.Module{}, field) {
+			if err := migrator.CreateIndex(&module.Module{}, field); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// addModuleStatus adds the approval status column and its index.
+//
+// The column default approves the existing modules, which went live before
+// the approval workflow; new modules are written as drafts by the service.
+func addModuleStatus(tx *gorm.DB) error {
+	migrator := tx.Migrator()
+	if !migrator.HasColumn(&module.Module{}, "Status") {
+		if err := migrator.AddColumn(&module.Module{}, "Status"); err != nil {
+			return err
+		}
+	}
+	if !migrator.HasIndex(&module.Module{}, "Status") {
+		return migrator.CreateIndex(&module.Module{}, "Status")
+	}
+	return nil
+}
+
+// addModuleOwnership adds the owner column and the ownership transfer table.
+//
+// Existing modules are owned by whoever created them according to their first
+// revision; the baseline revision of pre-history modules names "system".
+func addModuleOwnership(tx *gorm.DB) error {
+	// Step 1: Add the column to tables created by earlier versions
+	if !tx.Migrator().HasColumn(&module.Module{}, "Owner") {
+		if err := tx.Migrator().AddColumn(&module.Module{}, "Owner"); err != nil {
+			return err
+		}
+	}
+
+	// Step 2: Backfill owners from the change history
+	err := tx.Exec(
+		`UPDATE modules SET owner = (
+			SELECT r.actor FROM module_revisions r WHERE r.module_id = modules.id AND r.revision = 1
+		) WHERE owner = '' AND EXISTS (
+			SELECT 1 FROM module_revisions r WHERE r.module_id = modules.id AND r.revision = 1
+		)`,
+	).Error
+	if err != nil {
+		return err
+	}
+
+	// Step 3: Create the transfer table
+	return tx.AutoMigrate(&module.ModuleTransfer{})
+}
+
+// createModuleRevisions creates the change history table and seeds it.
+//
+// Modules created before history tracking get a "baseline" revision holding
+// their current state, so every module has a revision 1 to diff and revert
+// against.
+func createModuleRevisions(tx *gorm.DB) error {
+	// Step 1: Create the table
+	if err := tx.AutoMigrate(&module.ModuleRevision{}); err != nil {
+		return err
+	}
+
+	// Step 2: Record the current state of existing modules
+	return tx.Exec(
+		`INSERT INTO module_revisions (module_id, revision, action, actor, name, description, is_active, changes, created_at)
+		SELECT id, 1, ?, ?, name, description, is_active, ?, updated_at FROM modules`,
+		module.RevisionBaseline, "system", "[]",
+	).Error
+}
+
+// createModuleSummaries creates the read model of the latest change of each
+// module and fills it from the change history, as a replay would.
+func createModuleSummaries(tx *gorm.DB) error {
+	// Step 1: Create the table
+	if err := tx.AutoMigrate(&module.ModuleSummary{}); err != nil {
+		return err
+	}
+
+	// Step 2: Project the latest revision of each module
+	return tx.Exec(
+		`INSERT INTO module_summaries (module_id, name, is_active, revision, action, changed_at)
+		SELECT r.module_id, r.name, r.is_active, r.revision, r.action, r.created_at FROM module_revisions r
+		WHERE r.revision = (SELECT MAX(latest.revision) FROM module_revisions latest WHERE latest.module_id = r.module_id)`,
+	).Error
+}
@@ -0,0 +1,51 @@
+package module
+
+import (
+	"go_di_architecture/internal/domain/models/module"
+	"sort"
+)
+
+func (r *InMemoryModuleRepository) ListACLEntries(moduleIDs []int) ([]module.ModuleACLEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entries := make([]module.ModuleACLEntry, 0)
+	for _, id := range moduleIDs {
+		entries = append(entries, r.acl[id]...)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].ModuleID != entries[j].ModuleID {
+			return entries[i].ModuleID < entries[j].ModuleID
+		}
+		return entries[i].Principal < entries[j].Principal
+	})
+	return entries, nil
+}
+
+func (r *InMemoryModuleRepository) ReplaceACL(moduleID int, entries []module.ModuleACLEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(entries) == 0 {
+		delete(r.acl, moduleID)
+		return nil
+	}
+	r.acl[moduleID] = append([]module.ModuleACLEntry(nil), entries...)
+	return nil
+}
+
+// visibleTo reports whether any of the principals may view the module; the caller must hold the lock.
+func (r *InMemoryModuleRepository) visibleTo(moduleID int, principals []string) bool {
+	entries, restricted := r.acl[moduleID]
+	if !restricted {
+		return true
+	}
+	for _, entry := range entries {
+		for _, principal := range principals {
+			if entry.Principal == principal {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package module
+
+import (
+	"go_di_architecture/internal/domain/models/module"
+
+	"gorm.io/gorm"
+)
+
+// ACLRepository implements data operations for per-module access control lists.
+//
+// Entries are stored in the module_acl table keyed by (module_id, principal).
+// The module listing queries of ModuleRepository read the same table to hide
+// modules from principals without access.
+//
+// Usage Context:
+//
+//	repo := NewACLRepository(db)
+//	entries, err := repo.ListACLEntries([]int{1, 2, 3})
+type ACLRepository struct {
+	db *gorm.DB
+}
+
+// NewACLRepository creates a repository with a specific database connection.
+//
+// Parameters:
+//   - db: Database connection to use
+//
+// Returns:
+//   - *ACLRepository: A new repository instance using the provided connection
+func NewACLRepository(db *gorm.DB) *ACLRepository {
+	return &ACLRepository{db: db}
+}
+
+// ListACLEntries retrieves the ACL entries of many modules at once.
+//
+// Parameters:
+//   - moduleIDs: Identifiers of the modules
+//
+// Returns:
+//   - []module.ModuleACLEntry: Entries ordered by module ID and principal
+//   - error: Error if database query fails
+//
+// Query Implementation:
+//
+//	SELECT * FROM module_acl WHERE module_id IN (?) ORDER BY module_id, principal
+func (r *ACLRepository) ListACLEntries(moduleIDs []int) ([]module.ModuleACLEntry, error) {
+	if len(moduleIDs) == 0 {
+		return []module.ModuleACLEntry{}, nil
+	}
+
+	var entries []module.ModuleACLEntry
+	err := r.db.Where("module_id IN ?", moduleIDs).Order("module_id, principal").Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// ReplaceACL replaces all ACL entries of a module in one transaction.
+//
+// Parameters:
+//   - moduleID: Identifier of the module
+//   - entries: The complete new list
+//
+// Returns:
+//   - error: Error if a statement fails (the previous entries are kept in that case)
+//
+// Query Implementation:
+//
+//	DELETE FROM module_acl WHERE module_id = ?
+//	INSERT INTO module_acl (module_id, principal, permission, created_at) VALUES ...
+func (r *ACLRepository) ReplaceACL(moduleID int, entries []module.ModuleACLEntry) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("module_id = ?", moduleID).Delete(&module.ModuleACLEntry{}).Error; err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			return nil
+		}
+		return tx.Create(&entries).Error
+	})
+}
@@ -0,0 +1,81 @@
+package module
+
+import (
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"go_di_architecture/internal/infra/db"
+)
+
+// TestUserErasureInvalidatesModuleCache checks the modules an erasure
+// rewrites are dropped from the module cache and published once it has
+// committed, so no instance keeps serving the erased owner.
+func TestUserErasureInvalidatesModuleCache(t *testing.T) {
+	database := openSQLite(t)
+	cached := NewCachedModuleRepository(NewModuleRepository(database, db.AutoIncrement{}), time.Minute, time.Minute)
+	var published []string
+	cached.OnInvalidate(func(keys []string) { published = append(published, keys...) })
+	owned := mustCreate(t, cached, "Payments", testTime(0))
+	other := mustCreate(t, cached, "Billing", testTime(1))
+	other.Owner = "bob"
+	if _, err := cached.UpdateModule(other); err != nil {
+		t.Fatalf("UpdateModule() error = %v", err)
+	}
+
+	// Cache both modules
+	for _, id := range []int{owned.ID, other.ID} {
+		if _, err := cached.GetModuleById(strconv.Itoa(id)); err != nil {
+			t.Fatalf("GetModuleById(%d) error = %v", id, err)
+		}
+	}
+
+	repo := NewUserDataRepository(database)
+	repo.OnModulesChanged(cached.Invalidate)
+	published = nil
+	if _, err := repo.EraseUser("alice", "erased-1", testTime(2)); err != nil {
+		t.Fatalf("EraseUser() error = %v", err)
+	}
+
+	found, err := cached.GetModuleById(strconv.Itoa(owned.ID))
+	if err != nil || found.Owner != "erased-1" {
+		t.Errorf("cached owner after erasure = %+v, %v, want erased-1", found, err)
+	}
+	if want := []string{ModuleCacheKey(owned.ID)}; !slices.Equal(published, want) {
+		t.Errorf("published keys = %v, want %v", published, want)
+	}
+}
+
+// TestArchiveInvalidatesModuleCache checks an archived module is dropped from
+// the module cache and published once the archive has committed, so lookups
+// stop finding it.
+func TestArchiveInvalidatesModuleCache(t *testing.T) {
+	database := openSQLite(t)
+	cached := NewCachedModuleRepository(NewModuleRepository(database, db.AutoIncrement{}), time.Minute, time.Minute)
+	var published []string
+	cached.OnInvalidate(func(keys []string) { published = append(published, keys...) })
+	created := mustCreate(t, cached, "Payments", testTime(0))
+	created.IsActive = false
+	if _, err := cached.UpdateModule(created); err != nil {
+		t.Fatalf("UpdateModule() error = %v", err)
+	}
+	if found, err := cached.GetModuleById(strconv.Itoa(created.ID)); err != nil || found == nil {
+		t.Fatalf("GetModuleById() = %v, %v, want the module cached", found, err)
+	}
+
+	repo := NewRetentionRepository(database)
+	repo.OnModulesChanged(cached.Invalidate)
+	published = nil
+	archived, err := repo.ArchiveModule(created.ID, time.Now().Add(time.Hour), time.Now())
+	if err != nil || !archived {
+		t.Fatalf("ArchiveModule() = %v, %v, want true", archived, err)
+	}
+
+	if found, err := cached.GetModuleById(strconv.Itoa(created.ID)); err != nil || found != nil {
+		t.Errorf("GetModuleById() after archive = %+v, %v, want nil", found, err)
+	}
+	if want := []string{ModuleCacheKey(created.ID)}; !slices.Equal(published, want) {
+		t.Errorf("published keys = %v, want %v", published, want)
+	}
+}
@@ -0,0 +1,482 @@
+package module
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go_di_architecture/internal/domain/models/module"
+	moduleService "go_di_architecture/internal/domain/service/module"
+	"go_di_architecture/internal/infra/db"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// moduleCacheStats are the counters of the module cache, exported as the
+// "module_cache" expvar:
+//   - hits: Lookups answered from the cache
+//   - not_found_hits: Hits on cached not-found results (included in hits)
+//   - misses: Lookups that queried the database
+//   - shared: Lookups that waited for another caller's query instead of their own
+//   - invalidations: Entries dropped because the module was written
+var moduleCacheStats = expvar.NewMap("module_cache")
+
+// moduleKeyPrefix starts the entity-change keys of modules.
+const moduleKeyPrefix = "module:"
+
+// ModuleCacheKey returns the entity-change key of a module, e.g. "module:123".
+func ModuleCacheKey(id int) string {
+	return moduleKeyPrefix + strconv.Itoa(id)
+}
+
+// cachedModule is a cached lookup result with its expiry.
+type cachedModule struct {
+	// The module, nil when notFound is set
+	module *module.Module
+
+	// Marks a lookup that found no module
+	notFound bool
+
+	expiresAt time.Time
+}
+
+// CachedModuleRepository is a read-through cache in front of a module repository.
+//
+// Lookups by ID are served from memory for the configured TTL. Concurrent
+// lookups of the same ID that miss the cache share a single database query,
+// so a burst of requests for a hot module costs one round trip. With a zero
+// TTL nothing is retained and only concurrent lookups are coalesced.
+//
+// Lookups of IDs without a module are cached as not-found markers for their
+// own, usually shorter, TTL, so clients repeatedly asking for missing modules
+// do not reach the database either.
+//
+// Writes through the repository drop the entries of the modules they touch,
+// and creating a module drops the not-found marker of its ID. Writes made
+// elsewhere (other instances, privacy erasure) show up once the entry
+// expires, so keep the TTLs short when several instances share a database,
+// or broadcast the changes with OnInvalidate and EvictKeys. All other methods
+// pass through to the wrapped repository.
+//
+// Usage Context:
+//
+//	repo := NewCachedModuleRepository(NewModuleRepository(database, db.AutoIncrement{}), 5*time.Second, time.Second)
+//	entity, err := repo.GetModuleById("123")
+type CachedModuleRepository struct {
+	moduleService.ModuleRepository
+
+	// Repository running the loads shared by concurrent lookups; bound to a
+	// context that is never cancelled, so one caller giving up does not fail
+	// the lookups waiting for its load
+	loader moduleService.ModuleRepository
+
+	// Shared by the repositories WithContext returns
+	*moduleCache
+}
+
+// moduleCache is the state of a CachedModuleRepository.
+type moduleCache struct {
+	ttl         time.Duration
+	notFoundTTL time.Duration
+	loads       singleflight.Group
+
+	mu      sync.RWMutex
+	entries map[int]cachedModule
+
+	// Bumped by every invalidation so loads started before a write do not
+	// store what they read
+	generation uint64
+
+	// Receives the keys of modules written through this repository
+	publish func(keys []string)
+}
+
+// NewCachedModuleRepository wraps a repository with a read-through cache.
+//
+// Parameters:
+//   - repo: Repository queried on cache misses and receiving all writes
+//   - ttl: How long found modules stay cached (zero only coalesces concurrent lookups)
+//   - notFoundTTL: How long not-found results stay cached (zero disables negative caching)
+//
+// Returns:
+//   - *CachedModuleRepository: The caching repository
+func NewCachedModuleRepository(repo moduleService.ModuleRepository, ttl, notFoundTTL time.Duration) *CachedModuleRepository {
+	return &CachedModuleRepository{
+		ModuleRepository: repo,
+		loader:           repo,
+		moduleCache: &moduleCache{
+			ttl:         ttl,
+			notFoundTTL: notFoundTTL,
+			entries:     make(map[int]cachedModule),
+		},
+	}
+}
+
+// WithContext returns a repository sharing the cache whose queries run with
+// a context, when the wrapped repository supports it.
+//
+// When the context carries a unit of work (see db.UnitOfWork), the
+// repository runs in its transaction like one of WithTx: lookups bypass the
+// cache, and the modules it writes are evicted as they are written and again
+// once the unit of work ends.
+//
+// A load shared by concurrent lookups runs with the context of the caller
+// that started it, without its cancellation: its statements are attributed
+// to that caller's request, and the lookups waiting for it, which run no
+// statement of their own, are not failed by that request ending first.
+//
+// Parameters:
+//   - ctx: Context of the queries
+//
+// Returns:
+//   - moduleService.ModuleRepository: The context-bound caching repository
+func (r *CachedModuleRepository) WithContext(ctx context.Context) moduleService.ModuleRepository {
+	repo, ok := r.ModuleRepository.(moduleService.ContextRepository)
+	if !ok {
+		return r
+	}
+	if uow, ok := db.UnitOfWorkFrom(ctx); ok {
+		var written []int
+		uow.AfterEnd(func() { r.invalidate(written...) })
+		return &txCachedModuleRepository{ModuleRepository: repo.WithContext(ctx), cache: r, written: &written}
+	}
+	return &CachedModuleRepository{
+		ModuleRepository: repo.WithContext(ctx),
+		loader:           repo.WithContext(context.WithoutCancel(ctx)),
+		moduleCache:      r.moduleCache,
+	}
+}
+
+// GetModuleById retrieves a module from the cache or, on a miss, the database.
+//
+// Parameters:
+//   - id: Unique identifier to search for (as string)
+//
+// Returns:
+//   - *module.Module: Copy of the module or nil if not found
+//   - error: Error if the database query fails
+func (r *CachedModuleRepository) GetModuleById(id string) (*module.Module, error) {
+	moduleID, err := strconv.Atoi(id)
+	if err != nil {
+		return r.ModuleRepository.GetModuleById(id)
+	}
+
+	// Step 1: Serve from the cache
+	r.mu.RLock()
+	entry, ok := r.entries[moduleID]
+	generation := r.generation
+	r.mu.RUnlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		moduleCacheStats.Add("hits", 1)
+		if entry.notFound {
+			moduleCacheStats.Add("not_found_hits", 1)
+			return nil, nil
+		}
+		return copyModule(entry.module), nil
+	}
+
+	// Step 2: Query once for all concurrent callers
+	key := strconv.Itoa(moduleID)
+	value, err, shared := r.loads.Do(key, func() (interface{}, error) {
+		moduleCacheStats.Add("misses", 1)
+		found, err := r.loader.GetModuleById(key)
+		if err != nil {
+			return nil, err
+		}
+		r.store(moduleID, found, generation)
+		if found == nil {
+			return nil, nil
+		}
+		return found, nil
+	})
+	if shared {
+		moduleCacheStats.Add("shared", 1)
+	}
+	if err != nil || value == nil {
+		return nil, err
+	}
+	return copyModule(value.(*module.Module)), nil
+}
+
+// Preload caches the active modules among the first modules in list order,
+// so the first requests after startup do not all miss.
+//
+// Parameters:
+//   - ctx: Context cancelling the preload
+//   - limit: Maximum number of modules to list
+//
+// Returns:
+//   - int: Number of modules cached (zero when the TTL disables caching)
+//   - error: Error if the database query fails
+func (r *CachedModuleRepository) Preload(ctx context.Context, limit int) (int, error) {
+	if r.ttl <= 0 {
+		return 0, nil
+	}
+
+	r.mu.RLock()
+	generation := r.generation
+	r.mu.RUnlock()
+
+	modules, _, err := r.ModuleRepository.ListModules(module.ModuleFilter{}, 0, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	cached := 0
+	for _, m := range modules {
+		if ctx.Err() != nil {
+			return cached, ctx.Err()
+		}
+		if m.IsActive {
+			r.store(m.ID, m, generation)
+			cached++
+		}
+	}
+	return cached, nil
+}
+
+// store caches a loaded module, or a not-found marker when m is nil, unless
+// the module was written while loading.
+func (r *CachedModuleRepository) store(id int, m *module.Module, generation uint64) {
+	entry := cachedModule{notFound: m == nil}
+	ttl := r.ttl
+	if entry.notFound {
+		ttl = r.notFoundTTL
+	} else {
+		entry.module = copyModule(m)
+	}
+	if ttl <= 0 {
+		return
+	}
+	entry.expiresAt = time.Now().Add(ttl)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.generation != generation {
+		return
+	}
+	r.entries[id] = entry
+}
+
+// OnInvalidate registers a function receiving the entity-change keys of
+// modules written through this repository, e.g. to broadcast them to other
+// instances. Register it before the repository is used.
+//
+// Parameters:
+//   - publish: Called after each write with the keys of the written modules
+func (r *CachedModuleRepository) OnInvalidate(publish func(keys []string)) {
+	r.publish = publish
+}
+
+// EvictKeys drops the entries of modules changed elsewhere, such as on
+// another instance. Keys of other entities are ignored.
+//
+// Parameters:
+//   - keys: Entity-change keys, e.g. "module:123"
+func (r *CachedModuleRepository) EvictKeys(keys []string) {
+	ids := make([]int, 0, len(keys))
+	for _, key := range keys {
+		if raw, ok := strings.CutPrefix(key, moduleKeyPrefix); ok {
+			if id, err := strconv.Atoi(raw); err == nil {
+				ids = append(ids, id)
+			}
+		}
+	}
+	r.evict(ids)
+}
+
+// EvictAll drops every entry, e.g. after changes made elsewhere may have been missed.
+func (r *CachedModuleRepository) EvictAll() {
+	r.mu.Lock()
+	r.generation++
+	moduleCacheStats.Add("invalidations", int64(len(r.entries)))
+	clear(r.entries)
+	r.mu.Unlock()
+}
+
+// Invalidate drops the entries of modules changed without this repository,
+// such as by the bulk updates of other repositories, and publishes their
+// keys. Call it once the change has committed.
+//
+// Parameters:
+//   - ids: Identifiers of the changed modules
+func (r *CachedModuleRepository) Invalidate(ids []int) {
+	r.invalidate(ids...)
+}
+
+// invalidate drops the entries of modules written through this repository
+// and publishes their keys.
+func (r *CachedModuleRepository) invalidate(ids ...int) {
+	r.evict(ids)
+	if r.publish != nil && len(ids) > 0 {
+		keys := make([]string, len(ids))
+		for i, id := range ids {
+			keys[i] = ModuleCacheKey(id)
+		}
+		r.publish(keys)
+	}
+}
+
+// evict drops the entries of modules and detaches in-flight loads, so later
+// lookups query the database again.
+func (r *CachedModuleRepository) evict(ids []int) {
+	r.mu.Lock()
+	r.generation++
+	for _, id := range ids {
+		if _, ok := r.entries[id]; ok {
+			delete(r.entries, id)
+			moduleCacheStats.Add("invalidations", 1)
+		}
+	}
+	r.mu.Unlock()
+
+	for _, id := range ids {
+		r.loads.Forget(strconv.Itoa(id))
+	}
+}
+
+// CreateModule creates the module and drops the not-found marker of its ID.
+func (r *CachedModuleRepository) CreateModule(m *module.Module) (*module.Module, error) {
+	created, err := r.ModuleRepository.CreateModule(m)
+	if created != nil {
+		r.invalidate(created.ID)
+	}
+	return created, err
+}
+
+// UpdateModule updates the module and drops its cache entry.
+func (r *CachedModuleRepository) UpdateModule(m *module.Module) (*module.Module, error) {
+	defer r.invalidate(m.ID)
+	return r.ModuleRepository.UpdateModule(m)
+}
+
+// SoftDeleteModule moves the module to the recycle bin and drops its cache entry.
+func (r *CachedModuleRepository) SoftDeleteModule(id int, deletedBy string, at time.Time) (bool, error) {
+	defer r.invalidate(id)
+	return r.ModuleRepository.SoftDeleteModule(id, deletedBy, at)
+}
+
+// RestoreModules restores modules from the recycle bin and drops their cache entries.
+func (r *CachedModuleRepository) RestoreModules(ids []int, at time.Time) ([]int, error) {
+	defer r.invalidate(ids...)
+	return r.ModuleRepository.RestoreModules(ids, at)
+}
+
+// PurgeModules deletes modules permanently and drops their cache entries.
+func (r *CachedModuleRepository) PurgeModules(ids []int) ([]int, error) {
+	defer r.invalidate(ids...)
+	return r.ModuleRepository.PurgeModules(ids)
+}
+
+// WithTx runs a transaction of the wrapped repository with the cache in front
+// of its module repository.
+//
+// Modules written in the transaction are evicted as they are written, and
+// evicted again and published once the transaction ends, so no lookup caches
+// the state from before the commit. Lookups in the transaction bypass the
+// cache: they see its uncommitted writes, which other callers must not.
+//
+// Parameters:
+//   - fn: The writes, given module and revision repositories bound to the
+//     transaction
+//
+// Returns:
+//   - error: The error of fn or of the commit
+func (r *CachedModuleRepository) WithTx(fn func(repo moduleService.ModuleRepository, revisions moduleService.RevisionRepository) error) error {
+	tx, ok := r.ModuleRepository.(moduleService.Transactor)
+	if !ok {
+		return fmt.Errorf("%T does not support transactions", r.ModuleRepository)
+	}
+
+	var written []int
+	defer func() { r.invalidate(written...) }()
+	return tx.WithTx(func(repo moduleService.ModuleRepository, revisions moduleService.RevisionRepository) error {
+		return fn(&txCachedModuleRepository{ModuleRepository: repo, cache: r, written: &written}, revisions)
+	})
+}
+
+// txCachedModuleRepository is the module repository of a transaction of a
+// CachedModuleRepository: it evicts the modules it writes and collects their
+// IDs for the invalidation after the transaction.
+type txCachedModuleRepository struct {
+	moduleService.ModuleRepository
+
+	cache   *CachedModuleRepository
+	written *[]int
+}
+
+// WithTx runs a nested transaction of the wrapped repository, whose writes
+// are evicted and collected like those of r.
+//
+// Parameters:
+//   - fn: The writes, given module and revision repositories bound to the
+//     transaction
+//
+// Returns:
+//   - error: The error of fn or of the commit
+func (r *txCachedModuleRepository) WithTx(fn func(repo moduleService.ModuleRepository, revisions moduleService.RevisionRepository) error) error {
+	tx, ok := r.ModuleRepository.(moduleService.Transactor)
+	if !ok {
+		return fmt.Errorf("%T does not support transactions", r.ModuleRepository)
+	}
+	return tx.WithTx(func(repo moduleService.ModuleRepository, revisions moduleService.RevisionRepository) error {
+		return fn(&txCachedModuleRepository{ModuleRepository: repo, cache: r.cache, written: r.written}, revisions)
+	})
+}
+
+// evict drops the entries of written modules and remembers their IDs.
+func (r *txCachedModuleRepository) evict(ids ...int) {
+	r.cache.evict(ids)
+	*r.written = append(*r.written, ids...)
+}
+
+// CreateModule creates the module and drops the not-found marker of its ID.
+func (r *txCachedModuleRepository) CreateModule(m *module.Module) (*module.Module, error) {
+	created, err := r.ModuleRepository.CreateModule(m)
+	if created != nil {
+		r.evict(created.ID)
+	}
+	return created, err
+}
+
+// UpdateModule updates the module and drops its cache entry.
+func (r *txCachedModuleRepository) UpdateModule(m *module.Module) (*module.Module, error) {
+	defer r.evict(m.ID)
+	return r.ModuleRepository.UpdateModule(m)
+}
+
+// SoftDeleteModule moves the module to the recycle bin and drops its cache entry.
+func (r *txCachedModuleRepository) SoftDeleteModule(id int, deletedBy string, at time.Time) (bool, error) {
+	defer r.evict(id)
+	return r.ModuleRepository.SoftDeleteModule(id, deletedBy, at)
+}
+
+// RestoreModules restores modules from the recycle bin and drops their cache entries.
+func (r *txCachedModuleRepository) RestoreModules(ids []int, at time.Time) ([]int, error) {
+	defer r.evict(ids...)
+	return r.ModuleRepository.RestoreModules(ids, at)
+}
+
+// PurgeModules deletes modules permanently and drops their cache entries.
+func (r *txCachedModuleRepository) PurgeModules(ids []int) ([]int, error) {
+	defer r.evict(ids...)
+	return r.ModuleRepository.PurgeModules(ids)
+}
+
+// copyModule copies a module so callers cannot change cached or shared values.
+func copyModule(m *module.Module) *module.Module {
+	copied := *m
+	if m.ActivateAt != nil {
+		activateAt := *m.ActivateAt
+		copied.ActivateAt = &activateAt
+	}
+	if m.DeactivateAt != nil {
+		deactivateAt := *m.DeactivateAt
+		copied.DeactivateAt = &deactivateAt
+	}
+	return &copied
+}
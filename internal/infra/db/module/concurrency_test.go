@@ -0,0 +1,289 @@
+package module
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"go_di_architecture/internal/domain/events"
+	"go_di_architecture/internal/domain/metrics"
+	"go_di_architecture/internal/domain/models/module"
+	moduleService "go_di_architecture/internal/domain/service/module"
+	"go_di_architecture/internal/infra/db"
+
+	"gorm.io/gorm"
+)
+
+// Shape of the concurrency tests; run them with the race detector:
+//
+//	go test -race ./internal/infra/db/module -run Concurrent
+const (
+	concurrentWriters = 8
+	concurrentReaders = 8
+	writesPerWriter   = 50
+	readsPerReader    = 200
+)
+
+// TestInMemoryModuleRepositoryConcurrentAccess hammers the in-memory
+// repository with creates, renames and reads from many goroutines, and checks
+// the name index and the IDs stay consistent.
+func TestInMemoryModuleRepositoryConcurrentAccess(t *testing.T) {
+	repo := NewInMemoryModuleRepository(db.AutoIncrement{})
+
+	var (
+		wg      sync.WaitGroup
+		claimed sync.Map // ID -> name of every created module
+		winners int
+		mu      sync.Mutex
+	)
+
+	// Writers create their own modules and race for one shared name
+	for writer := range concurrentWriters {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range writesPerWriter {
+				name := "writer-" + strconv.Itoa(writer) + "-" + strconv.Itoa(i)
+				created, err := repo.CreateModule(&module.Module{Name: name, Status: module.StatusApproved, CreatedAt: testTime(i)})
+				if err != nil {
+					t.Errorf("CreateModule(%q) error = %v", name, err)
+					return
+				}
+				if previous, loaded := claimed.LoadOrStore(created.ID, name); loaded {
+					t.Errorf("ID %d assigned to %q and %v", created.ID, name, previous)
+				}
+
+				// Renaming to the same name with other case keeps the index entry
+				created.Name = "Writer-" + strconv.Itoa(writer) + "-" + strconv.Itoa(i)
+				if _, err := repo.UpdateModule(created); err != nil {
+					t.Errorf("UpdateModule(%q) error = %v", created.Name, err)
+				}
+			}
+
+			_, err := repo.CreateModule(&module.Module{Name: "Shared", Status: module.StatusApproved})
+			switch {
+			case err == nil:
+				mu.Lock()
+				winners++
+				mu.Unlock()
+			case !errors.Is(err, moduleService.ErrNameExists):
+				t.Errorf("CreateModule(%q) error = %v, want ErrNameExists", "Shared", err)
+			}
+		}()
+	}
+
+	// Readers look modules up while they are written
+	var readers sync.WaitGroup
+	for reader := range concurrentReaders {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			for i := range readsPerReader {
+				id := 1 + (reader+i)%(concurrentWriters*writesPerWriter)
+				if _, err := repo.GetModuleById(strconv.Itoa(id)); err != nil {
+					t.Errorf("GetModuleById(%d) error = %v", id, err)
+					return
+				}
+				if _, err := repo.IsModuleNameExists("writer-0-"+strconv.Itoa(i%writesPerWriter), 0); err != nil {
+					t.Errorf("IsModuleNameExists() error = %v", err)
+					return
+				}
+				if _, _, err := repo.ListModules(module.ModuleFilter{}, 0, 10); err != nil {
+					t.Errorf("ListModules() error = %v", err)
+					return
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+	readers.Wait()
+
+	if winners != 1 {
+		t.Errorf("%d writers created %q, want exactly 1", winners, "Shared")
+	}
+	count, err := repo.CountModules(nil, nil)
+	if want := int64(concurrentWriters*writesPerWriter + 1); err != nil || count != want {
+		t.Errorf("CountModules() = %d, %v, want %d", count, err, want)
+	}
+	claimed.Range(func(id, name any) bool {
+		found, err := repo.FindModuleByName(name.(string))
+		if err != nil || found == nil || found.ID != id.(int) {
+			t.Errorf("FindModuleByName(%q) = %+v, %v, want ID %d", name, found, err, id)
+		}
+		return true
+	})
+}
+
+// TestCachedModuleRepositoryConcurrentAccess reads modules through the cache
+// while other goroutines update them, and checks no stale entry outlives the
+// writes: once the writers are done, every lookup returns the last update.
+func TestCachedModuleRepositoryConcurrentAccess(t *testing.T) {
+	repo := NewCachedModuleRepository(NewInMemoryModuleRepository(db.AutoIncrement{}), time.Minute, time.Minute)
+
+	ids := make([]int, concurrentWriters)
+	for writer := range ids {
+		ids[writer] = mustCreate(t, repo, "module-"+strconv.Itoa(writer), testTime(writer)).ID
+	}
+
+	var (
+		wg      sync.WaitGroup
+		readers sync.WaitGroup
+	)
+
+	// Each writer owns one module and updates its description
+	for writer, id := range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range writesPerWriter {
+				found, err := repo.GetModuleById(strconv.Itoa(id))
+				if err != nil || found == nil {
+					t.Errorf("GetModuleById(%d) = %v, %v", id, found, err)
+					return
+				}
+				found.Description = "revision " + strconv.Itoa(i)
+				if _, err := repo.UpdateModule(found); err != nil {
+					t.Errorf("UpdateModule(%d) error = %v", id, err)
+					return
+				}
+			}
+
+			// A new module drops the not-found marker its ID may have
+			if _, err := repo.CreateModule(&module.Module{Name: "late-" + strconv.Itoa(writer), Status: module.StatusApproved}); err != nil {
+				t.Errorf("CreateModule() error = %v", err)
+			}
+		}()
+	}
+
+	// Readers hit the modules being written and IDs not created yet
+	for reader := range concurrentReaders {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			for i := range readsPerReader {
+				id := 1 + (reader+i)%(2*concurrentWriters)
+				found, err := repo.GetModuleById(strconv.Itoa(id))
+				if err != nil {
+					t.Errorf("GetModuleById(%d) error = %v", id, err)
+					return
+				}
+				if found != nil {
+					// Callers own their copy
+					found.Description = "changed by a reader"
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+	readers.Wait()
+
+	want := "revision " + strconv.Itoa(writesPerWriter-1)
+	for _, id := range ids {
+		found, err := repo.GetModuleById(strconv.Itoa(id))
+		if err != nil || found == nil || found.Description != want {
+			t.Errorf("GetModuleById(%d) = %+v, %v, want description %q", id, found, err, want)
+		}
+	}
+	for id := len(ids) + 1; id <= 2*len(ids); id++ {
+		found, err := repo.GetModuleById(strconv.Itoa(id))
+		if err != nil || found == nil {
+			t.Errorf("GetModuleById(%d) = %v, %v, want the module created late", id, found, err)
+		}
+	}
+}
+
+// TestModuleServiceConcurrentUpdatesRecordRevisions updates one module from
+// many goroutines through the service and checks every update committed with
+// its own revision: numbered without gaps or duplicates, and the last one
+// matching the stored module. Set TEST_POSTGRES_DSN to run it on PostgreSQL
+// too.
+func TestModuleServiceConcurrentUpdatesRecordRevisions(t *testing.T) {
+	databases := map[string]func(t *testing.T) *gorm.DB{"sqlite": openSQLite}
+	if dsn := os.Getenv(postgresDSNEnv); dsn != "" {
+		databases["postgres"] = func(t *testing.T) *gorm.DB { return openPostgres(t, dsn) }
+	}
+
+	for name, open := range databases {
+		t.Run(name, func(t *testing.T) {
+			database := open(t)
+			service := newSQLModuleService(t, database)
+			created, err := service.CreateModule(module.ModuleRequest{Name: "Payments"}, "alice", false)
+			if err != nil {
+				t.Fatalf("CreateModule() error = %v", err)
+			}
+			id := strconv.Itoa(created.ID)
+
+			const updatesPerWriter = 5
+			var wg sync.WaitGroup
+			for writer := range concurrentWriters {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for i := range updatesPerWriter {
+						request := module.ModuleRequest{Name: "Payments", Description: "writer " + strconv.Itoa(writer) + " update " + strconv.Itoa(i)}
+						if _, err := service.UpdateModule(id, request, module.Subject{Admin: true}, false); err != nil {
+							t.Errorf("UpdateModule() error = %v", err)
+						}
+					}
+				}()
+			}
+			wg.Wait()
+
+			revisions, total, err := NewRevisionRepository(database).ListRevisions(created.ID, module.RevisionFilter{}, 0, 1000)
+			if want := int64(1 + concurrentWriters*updatesPerWriter); err != nil || total != want {
+				t.Fatalf("ListRevisions() = %d revisions, %v, want %d", total, err, want)
+			}
+			for i, revision := range revisions {
+				if revision.Revision != i+1 {
+					t.Fatalf("revision %d numbered %d, want %d", i, revision.Revision, i+1)
+				}
+			}
+			stored, err := service.GetModuleById(id, module.Subject{Admin: true})
+			if last := revisions[len(revisions)-1]; err != nil || stored.Description != last.Description {
+				t.Errorf("stored description = %q, %v, want that of the last revision %q", stored.Description, err, last.Description)
+			}
+		})
+	}
+}
+
+// TestModuleServiceRollsBackChangeWithoutRevision checks a change whose
+// revision cannot be stored is not stored either.
+func TestModuleServiceRollsBackChangeWithoutRevision(t *testing.T) {
+	database := openSQLite(t)
+	service := newSQLModuleService(t, database)
+	created, err := service.CreateModule(module.ModuleRequest{Name: "Payments", Description: "Fees"}, "alice", false)
+	if err != nil {
+		t.Fatalf("CreateModule() error = %v", err)
+	}
+
+	err = database.Exec("CREATE TRIGGER reject_revisions BEFORE INSERT ON module_revisions BEGIN SELECT RAISE(ABORT, 'history unavailable'); END").Error
+	if err != nil {
+		t.Fatalf("create trigger: %v", err)
+	}
+	id := strconv.Itoa(created.ID)
+	if _, err := service.UpdateModule(id, module.ModuleRequest{Name: "Billing", Description: "Invoices"}, module.Subject{Admin: true}, false); err == nil {
+		t.Fatal("UpdateModule() error = nil, want the failing revision reported")
+	}
+
+	stored, err := service.GetModuleById(id, module.Subject{Admin: true})
+	if err != nil || stored.Name != "Payments" || stored.Description != "Fees" {
+		t.Errorf("GetModuleById() = %+v, %v, want the module before the failed update", stored, err)
+	}
+}
+
+// newSQLModuleService creates a module service on a database, with the
+// module cache in front of the module table like the application.
+func newSQLModuleService(t *testing.T, database *gorm.DB) *moduleService.ModuleService {
+	t.Helper()
+	repo := NewCachedModuleRepository(NewModuleRepository(database, db.AutoIncrement{}), time.Minute, time.Minute)
+	service, err := moduleService.NewModuleService(repo, NewRevisionRepository(database), NewACLRepository(database), NewTransferRepository(database), NewStarRepository(database), events.NewBus(), metrics.Discard)
+	if err != nil {
+		t.Fatalf("NewModuleService() error = %v", err)
+	}
+	return service
+}
@@ -0,0 +1,327 @@
+package module
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"go_di_architecture/internal/config"
+	"go_di_architecture/internal/domain/models/module"
+	"go_di_architecture/internal/domain/models/pagination"
+	moduleService "go_di_architecture/internal/domain/service/module"
+	"go_di_architecture/internal/infra/db"
+
+	"gorm.io/gorm"
+)
+
+// postgresDSNEnv names the variable holding the DSN of a PostgreSQL database
+// the conformance suite may wipe; the PostgreSQL run is skipped without it.
+const postgresDSNEnv = "TEST_POSTGRES_DSN"
+
+// RunRepositoryConformanceTests checks that a ModuleRepository implementation
+// keeps the contract documented on the interface.
+//
+// Every subtest asks the factory for an empty repository, so the backends
+// run the same cases from the same state.
+//
+// Parameters:
+//   - t: The test running the suite
+//   - newRepository: Creates an empty repository for one subtest
+func RunRepositoryConformanceTests(t *testing.T, newRepository func(t *testing.T) moduleService.ModuleRepository) {
+	t.Run("NameUniqueness", func(t *testing.T) {
+		repo := newRepository(t)
+		created := mustCreate(t, repo, "Payments", testTime(0))
+
+		if _, err := repo.CreateModule(&module.Module{Name: "PAYMENTS", Status: module.StatusApproved, CreatedAt: testTime(1)}); !errors.Is(err, moduleService.ErrNameExists) {
+			t.Errorf("CreateModule(duplicate) error = %v, want ErrNameExists", err)
+		}
+
+		if exists, err := repo.IsModuleNameExists("payments", 0); err != nil || !exists {
+			t.Errorf("IsModuleNameExists(payments) = %v, %v, want true", exists, err)
+		}
+		if exists, err := repo.IsModuleNameExists("payments", created.ID); err != nil || exists {
+			t.Errorf("IsModuleNameExists(payments, own ID) = %v, %v, want false", exists, err)
+		}
+
+		other := mustCreate(t, repo, "Billing", testTime(2))
+		other.Name = "payments"
+		if _, err := repo.UpdateModule(other); !errors.Is(err, moduleService.ErrNameExists) {
+			t.Errorf("UpdateModule(duplicate) error = %v, want ErrNameExists", err)
+		}
+
+		found, err := repo.FindModuleByName("pAyMeNtS")
+		if err != nil || found == nil || found.ID != created.ID {
+			t.Errorf("FindModuleByName(pAyMeNtS) = %v, %v, want module %d", found, err, created.ID)
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		repo := newRepository(t)
+		created := mustCreate(t, repo, "Payments", testTime(0))
+		missing := created.ID + 1000
+
+		if m, err := repo.GetModuleById(strconv.Itoa(missing)); m != nil || err != nil {
+			t.Errorf("GetModuleById(unknown) = %v, %v, want nil, nil", m, err)
+		}
+		if _, err := repo.GetModuleById("abc"); err == nil {
+			t.Error("GetModuleById(abc) error = nil, want an error")
+		}
+		if m, err := repo.FindModuleByName("unknown"); m != nil || err != nil {
+			t.Errorf("FindModuleByName(unknown) = %v, %v, want nil, nil", m, err)
+		}
+		if exists, err := repo.ModuleExists(missing); exists || err != nil {
+			t.Errorf("ModuleExists(unknown) = %v, %v, want false", exists, err)
+		}
+
+		modules, err := repo.GetModulesByIds([]int{missing, created.ID})
+		if err != nil || len(modules) != 1 || modules[0].ID != created.ID {
+			t.Errorf("GetModulesByIds() = %v, %v, want only module %d", modules, err, created.ID)
+		}
+	})
+
+	t.Run("Pagination", func(t *testing.T) {
+		repo := newRepository(t)
+		var ids []int
+		// Created out of order so the ID order differs from the creation order
+		for i, minute := range []int{4, 0, 3, 1, 2} {
+			m := mustCreate(t, repo, "module-"+strconv.Itoa(i), testTime(minute))
+			ids = append(ids, m.ID)
+		}
+		byCreation := []int{ids[1], ids[3], ids[4], ids[2], ids[0]}
+
+		page, total, err := repo.ListModules(module.ModuleFilter{}, 1, 2)
+		if err != nil {
+			t.Fatalf("ListModules() error = %v", err)
+		}
+		if total != 5 || !slices.Equal(moduleIDs(page), byCreation[1:3]) {
+			t.Errorf("ListModules(1, 2) = %v, total %d, want %v, total 5", moduleIDs(page), total, byCreation[1:3])
+		}
+
+		page, total, err = repo.ListModules(module.ModuleFilter{}, 10, 2)
+		if err != nil || len(page) != 0 || total != 5 {
+			t.Errorf("ListModules(past the end) = %v, total %d, %v, want no modules, total 5", moduleIDs(page), total, err)
+		}
+
+		page, err = repo.ListModulesAfter(module.ModuleFilter{}, nil, 2)
+		if err != nil || !slices.Equal(moduleIDs(page), byCreation[:2]) {
+			t.Fatalf("ListModulesAfter(nil) = %v, %v, want %v", moduleIDs(page), err, byCreation[:2])
+		}
+		cursor := &pagination.Cursor{CreatedAt: page[1].CreatedAt, ID: page[1].ID}
+		page, err = repo.ListModulesAfter(module.ModuleFilter{}, cursor, 10)
+		if err != nil || !slices.Equal(moduleIDs(page), byCreation[2:]) {
+			t.Errorf("ListModulesAfter(cursor) = %v, %v, want %v", moduleIDs(page), err, byCreation[2:])
+		}
+
+		modules, err := repo.GetModulesByIds([]int{ids[4], ids[0], ids[2]})
+		if want := []int{ids[0], ids[2], ids[4]}; err != nil || !slices.Equal(moduleIDs(modules), want) {
+			t.Errorf("GetModulesByIds() = %v, %v, want %v", moduleIDs(modules), err, want)
+		}
+	})
+
+	t.Run("Counts", func(t *testing.T) {
+		repo := newRepository(t)
+		mustCreate(t, repo, "active-one", testTime(0))
+		mustCreate(t, repo, "active-two", testTime(1))
+		inactive := mustCreate(t, repo, "inactive", testTime(2))
+		inactive.IsActive = false
+		if _, err := repo.UpdateModule(inactive); err != nil {
+			t.Fatalf("UpdateModule() error = %v", err)
+		}
+
+		active, inactiveCount, err := repo.CountModulesByStatus(nil)
+		if err != nil || active != 2 || inactiveCount != 1 {
+			t.Errorf("CountModulesByStatus() = %d, %d, %v, want 2, 1", active, inactiveCount, err)
+		}
+		isActive := true
+		if count, err := repo.CountModules(&isActive, nil); err != nil || count != 2 {
+			t.Errorf("CountModules(active) = %d, %v, want 2", count, err)
+		}
+		if count, err := repo.CountModules(nil, nil); err != nil || count != 3 {
+			t.Errorf("CountModules(nil) = %d, %v, want 3", count, err)
+		}
+	})
+
+	t.Run("SoftDelete", func(t *testing.T) {
+		repo := newRepository(t)
+		kept := mustCreate(t, repo, "Kept", testTime(0))
+		deleted := mustCreate(t, repo, "Deleted", testTime(1))
+
+		if ok, err := repo.SoftDeleteModule(deleted.ID, "alice", testTime(10)); err != nil || !ok {
+			t.Fatalf("SoftDeleteModule() = %v, %v, want true", ok, err)
+		}
+		if ok, err := repo.SoftDeleteModule(deleted.ID, "alice", testTime(11)); err != nil || ok {
+			t.Errorf("SoftDeleteModule(again) = %v, %v, want false", ok, err)
+		}
+
+		// Hidden from reads, but the name stays reserved
+		if m, err := repo.GetModuleById(strconv.Itoa(deleted.ID)); m != nil || err != nil {
+			t.Errorf("GetModuleById(deleted) = %v, %v, want nil, nil", m, err)
+		}
+		if exists, err := repo.ModuleExists(deleted.ID); exists || err != nil {
+			t.Errorf("ModuleExists(deleted) = %v, %v, want false", exists, err)
+		}
+		if page, total, err := repo.ListModules(module.ModuleFilter{}, 0, 10); err != nil || total != 1 || !slices.Equal(moduleIDs(page), []int{kept.ID}) {
+			t.Errorf("ListModules() = %v, total %d, %v, want only module %d", moduleIDs(page), total, err, kept.ID)
+		}
+		if exists, err := repo.IsModuleNameExists("deleted", 0); err != nil || !exists {
+			t.Errorf("IsModuleNameExists(deleted) = %v, %v, want true", exists, err)
+		}
+		if _, err := repo.CreateModule(&module.Module{Name: "deleted", Status: module.StatusApproved, CreatedAt: testTime(12)}); !errors.Is(err, moduleService.ErrNameExists) {
+			t.Errorf("CreateModule(name in the recycle bin) error = %v, want ErrNameExists", err)
+		}
+
+		trash, total, err := repo.ListDeletedModules(0, 10)
+		if err != nil || total != 1 || len(trash) != 1 || trash[0].ID != deleted.ID || trash[0].DeletedBy != "alice" {
+			t.Errorf("ListDeletedModules() = %v, total %d, %v, want module %d deleted by alice", trash, total, err, deleted.ID)
+		}
+	})
+
+	t.Run("RestoreAndPurge", func(t *testing.T) {
+		repo := newRepository(t)
+		restored := mustCreate(t, repo, "Restored", testTime(0))
+		purged := mustCreate(t, repo, "Purged", testTime(1))
+		for _, id := range []int{restored.ID, purged.ID} {
+			if _, err := repo.SoftDeleteModule(id, "alice", testTime(10)); err != nil {
+				t.Fatalf("SoftDeleteModule(%d) error = %v", id, err)
+			}
+		}
+
+		ids, err := repo.RestoreModules([]int{restored.ID, purged.ID + 1000}, testTime(20))
+		if err != nil || !slices.Equal(ids, []int{restored.ID}) {
+			t.Errorf("RestoreModules() = %v, %v, want [%d]", ids, err, restored.ID)
+		}
+		if m, err := repo.GetModuleById(strconv.Itoa(restored.ID)); err != nil || m == nil || m.Name != "Restored" {
+			t.Errorf("GetModuleById(restored) = %v, %v, want the module back", m, err)
+		}
+
+		// Only modules in the recycle bin are purged
+		ids, err = repo.PurgeModules([]int{restored.ID, purged.ID})
+		if err != nil || !slices.Equal(ids, []int{purged.ID}) {
+			t.Errorf("PurgeModules() = %v, %v, want [%d]", ids, err, purged.ID)
+		}
+		if _, total, err := repo.ListDeletedModules(0, 10); err != nil || total != 0 {
+			t.Errorf("ListDeletedModules() total = %d, %v, want 0", total, err)
+		}
+		if exists, err := repo.IsModuleNameExists("purged", 0); err != nil || exists {
+			t.Errorf("IsModuleNameExists(purged) = %v, %v, want the name freed", exists, err)
+		}
+		mustCreate(t, repo, "purged", testTime(30))
+	})
+}
+
+func TestInMemoryModuleRepositoryConformance(t *testing.T) {
+	RunRepositoryConformanceTests(t, func(t *testing.T) moduleService.ModuleRepository {
+		return NewInMemoryModuleRepository(db.AutoIncrement{})
+	})
+}
+
+func TestSQLiteModuleRepositoryConformance(t *testing.T) {
+	RunRepositoryConformanceTests(t, func(t *testing.T) moduleService.ModuleRepository {
+		return NewModuleRepository(openSQLite(t), db.AutoIncrement{})
+	})
+}
+
+func TestPostgresModuleRepositoryConformance(t *testing.T) {
+	dsn := os.Getenv(postgresDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", postgresDSNEnv)
+	}
+	RunRepositoryConformanceTests(t, func(t *testing.T) moduleService.ModuleRepository {
+		return NewModuleRepository(openPostgres(t, dsn), db.AutoIncrement{})
+	})
+}
+
+func TestModuleRepositoryJoinsTransaction(t *testing.T) {
+	database := openSQLite(t)
+
+	errRollback := errors.New("rollback")
+	err := database.Transaction(func(tx *gorm.DB) error {
+		repo := NewModuleRepository(tx, db.AutoIncrement{})
+		mustCreate(t, repo, "Payments", testTime(0))
+		if exists, err := repo.IsModuleNameExists("payments", 0); err != nil || !exists {
+			t.Errorf("IsModuleNameExists() inside the transaction = %v, %v, want true", exists, err)
+		}
+		return errRollback
+	})
+	if !errors.Is(err, errRollback) {
+		t.Fatalf("Transaction() error = %v, want the rollback error", err)
+	}
+
+	repo := NewModuleRepository(database, db.AutoIncrement{})
+	if m, err := repo.FindModuleByName("payments"); m != nil || err != nil {
+		t.Errorf("FindModuleByName() after rollback = %v, %v, want nil, nil", m, err)
+	}
+}
+
+// openSQLite opens a migrated SQLite database in a temporary file.
+func openSQLite(t *testing.T) *gorm.DB {
+	t.Helper()
+	return openDatabase(t, config.DatabaseConfig{
+		Driver: config.DriverSQLite,
+		DSN:    "file:" + filepath.Join(t.TempDir(), "modules.db"),
+	})
+}
+
+// openPostgres opens the PostgreSQL test database, migrates it and empties
+// the modules table and the tables referencing it.
+func openPostgres(t *testing.T, dsn string) *gorm.DB {
+	t.Helper()
+	database := openDatabase(t, config.DatabaseConfig{Driver: config.DriverPostgres, DSN: dsn})
+	if err := database.Exec("TRUNCATE modules RESTART IDENTITY CASCADE").Error; err != nil {
+		t.Fatalf("truncate modules: %v", err)
+	}
+	return database
+}
+
+// openDatabase opens and migrates a database, closing it when the test ends.
+func openDatabase(t *testing.T, cfg config.DatabaseConfig) *gorm.DB {
+	t.Helper()
+	database, err := db.Open(cfg)
+	if err != nil {
+		t.Fatalf("db.Open() error = %v", err)
+	}
+	sqlDB, err := database.DB()
+	if err != nil {
+		t.Fatalf("DB() error = %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	if err := db.Migrate(database); err != nil {
+		t.Fatalf("db.Migrate() error = %v", err)
+	}
+	return database
+}
+
+// mustCreate creates an active, approved module created at the given time.
+func mustCreate(t *testing.T, repo moduleService.ModuleRepository, name string, createdAt time.Time) *module.Module {
+	t.Helper()
+	m, err := repo.CreateModule(&module.Module{
+		Name:      name,
+		IsActive:  true,
+		Status:    module.StatusApproved,
+		Owner:     "alice",
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+	})
+	if err != nil {
+		t.Fatalf("CreateModule(%q) error = %v", name, err)
+	}
+	return m
+}
+
+// testTime returns a fixed UTC time shifted by the given number of minutes.
+func testTime(minutes int) time.Time {
+	return time.Date(2026, time.January, 2, 3, 0, 0, 0, time.UTC).Add(time.Duration(minutes) * time.Minute)
+}
+
+func moduleIDs(modules []*module.Module) []int {
+	ids := make([]int, len(modules))
+	for i, m := range modules {
+		ids[i] = m.ID
+	}
+	return ids
+}
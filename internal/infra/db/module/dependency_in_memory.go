@@ -0,0 +1,69 @@
+package module
+
+import (
+	"go_di_architecture/internal/domain/models/module"
+	"sort"
+)
+
+func (r *InMemoryModuleRepository) AddDependency(moduleID, dependsOnID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.dependencies[moduleID] == nil {
+		r.dependencies[moduleID] = make(map[int]bool)
+	}
+	r.dependencies[moduleID][dependsOnID] = true
+	return nil
+}
+
+func (r *InMemoryModuleRepository) RemoveDependency(moduleID, dependsOnID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.dependencies[moduleID], dependsOnID)
+	return nil
+}
+
+func (r *InMemoryModuleRepository) FindDependencies(moduleIDs []int) ([]module.ModuleDependency, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var edges []module.ModuleDependency
+	for _, moduleID := range moduleIDs {
+		for dependsOnID := range r.dependencies[moduleID] {
+			edges = append(edges, module.ModuleDependency{ModuleID: moduleID, DependsOnID: dependsOnID})
+		}
+	}
+	sortEdges(edges)
+	return edges, nil
+}
+
+func (r *InMemoryModuleRepository) FindDependents(moduleIDs []int) ([]module.ModuleDependency, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	wanted := make(map[int]bool, len(moduleIDs))
+	for _, id := range moduleIDs {
+		wanted[id] = true
+	}
+
+	var edges []module.ModuleDependency
+	for moduleID, dependsOn := range r.dependencies {
+		for dependsOnID := range dependsOn {
+			if wanted[dependsOnID] {
+				edges = append(edges, module.ModuleDependency{ModuleID: moduleID, DependsOnID: dependsOnID})
+			}
+		}
+	}
+	sortEdges(edges)
+	return edges, nil
+}
+
+func sortEdges(edges []module.ModuleDependency) {
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].ModuleID != edges[j].ModuleID {
+			return edges[i].ModuleID < edges[j].ModuleID
+		}
+		return edges[i].DependsOnID < edges[j].DependsOnID
+	})
+}
@@ -0,0 +1,110 @@
+package module
+
+import (
+	"go_di_architecture/internal/domain/models/module"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DependencyRepository implements data operations for dependencies between modules.
+//
+// Edges are stored in the module_dependencies table keyed by
+// (module_id, depends_on_id), with a secondary index on depends_on_id so both
+// directions of the graph can be walked efficiently.
+//
+// Usage Context:
+//
+//	repo := NewDependencyRepository(db)
+//	err := repo.AddDependency(1, 2) // module 1 depends on module 2
+type DependencyRepository struct {
+	db *gorm.DB
+}
+
+// NewDependencyRepository creates a repository with a specific database connection.
+//
+// Parameters:
+//   - db: Database connection to use
+//
+// Returns:
+//   - *DependencyRepository: A new repository instance using the provided connection
+func NewDependencyRepository(db *gorm.DB) *DependencyRepository {
+	return &DependencyRepository{db: db}
+}
+
+// AddDependency records a dependency edge.
+//
+// Parameters:
+//   - moduleID: Identifier of the dependent module
+//   - dependsOnID: Identifier of the module depended on
+//
+// Returns:
+//   - error: Error if the insert fails
+//
+// Query Implementation:
+//
+//	INSERT INTO module_dependencies (module_id, depends_on_id, created_at) VALUES (?, ?, ?)
+//	ON CONFLICT DO NOTHING  -- INSERT IGNORE on MySQL
+func (r *DependencyRepository) AddDependency(moduleID, dependsOnID int) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&module.ModuleDependency{ModuleID: moduleID, DependsOnID: dependsOnID}).Error
+}
+
+// RemoveDependency deletes a dependency edge.
+//
+// Parameters:
+//   - moduleID: Identifier of the dependent module
+//   - dependsOnID: Identifier of the module depended on
+//
+// Returns:
+//   - error: Error if the delete fails
+func (r *DependencyRepository) RemoveDependency(moduleID, dependsOnID int) error {
+	return r.db.Where("module_id = ? AND depends_on_id = ?", moduleID, dependsOnID).
+		Delete(&module.ModuleDependency{}).Error
+}
+
+// FindDependencies retrieves the outgoing edges of many modules in one query.
+//
+// Parameters:
+//   - moduleIDs: Identifiers of the dependent modules
+//
+// Returns:
+//   - []module.ModuleDependency: Edges ordered by (module_id, depends_on_id)
+//   - error: Error if database query fails
+//
+// Query Implementation:
+//
+//	SELECT * FROM module_dependencies WHERE module_id IN (?) ORDER BY module_id, depends_on_id
+func (r *DependencyRepository) FindDependencies(moduleIDs []int) ([]module.ModuleDependency, error) {
+	return r.findEdges("module_id IN ?", moduleIDs)
+}
+
+// FindDependents retrieves the incoming edges of many modules in one query.
+//
+// Parameters:
+//   - moduleIDs: Identifiers of the modules depended on
+//
+// Returns:
+//   - []module.ModuleDependency: Edges ordered by (module_id, depends_on_id)
+//   - error: Error if database query fails
+//
+// Query Implementation:
+//
+//	SELECT * FROM module_dependencies WHERE depends_on_id IN (?) ORDER BY module_id, depends_on_id
+func (r *DependencyRepository) FindDependents(moduleIDs []int) ([]module.ModuleDependency, error) {
+	return r.findEdges("depends_on_id IN ?", moduleIDs)
+}
+
+// findEdges loads edges matching an IN condition.
+func (r *DependencyRepository) findEdges(condition string, moduleIDs []int) ([]module.ModuleDependency, error) {
+	if len(moduleIDs) == 0 {
+		return nil, nil
+	}
+
+	var edges []module.ModuleDependency
+	err := r.db.Where(condition, moduleIDs).Order("module_id, depends_on_id").Find(&edges).Error
+	if err != nil {
+		return nil, err
+	}
+	return edges, nil
+}
@@ -0,0 +1,105 @@
+package module
+
+import (
+	"context"
+	"time"
+
+	"go_di_architecture/internal/domain/models/leader"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LeaseRepository implements the leader leases on the leader_leases table.
+//
+// Each election is one row; taking it over is a conditional update, so of
+// two instances racing for an expired lease only one changes the row. The
+// statements work on every supported dialect and rely on the clocks of the
+// instances agreeing within a fraction of the TTL.
+//
+// Usage Context:
+//
+//	lease := NewLeaseRepository(db)
+//	holder, err := lease.Acquire(ctx, "scheduler", instance, 15*time.Second)
+type LeaseRepository struct {
+	db *gorm.DB
+}
+
+// NewLeaseRepository creates a repository with a specific database connection.
+//
+// Parameters:
+//   - db: Database connection to use
+//
+// Returns:
+//   - *LeaseRepository: A new repository instance using the provided connection
+func NewLeaseRepository(db *gorm.DB) *LeaseRepository {
+	return &LeaseRepository{db: db}
+}
+
+// Acquire takes a free or expired lease, or renews the holder's own.
+//
+// Parameters:
+//   - ctx: Context bounding the queries
+//   - name: Name of the election
+//   - holder: Instance asking for the lease
+//   - ttl: Validity of the lease from now
+//
+// Returns:
+//   - string: The holder after the call (holder itself when it leads)
+//   - error: Error if a query fails
+//
+// Query Implementation:
+//
+//	UPDATE leader_leases SET holder = ?, expires_at = ?
+//	WHERE name = ? AND (holder = ? OR expires_at < ?)
+//	-- when no row changed, the first election creates it:
+//	INSERT INTO leader_leases (...) VALUES (...) ON CONFLICT DO NOTHING
+//	SELECT * FROM leader_leases WHERE name = ? LIMIT 1
+func (r *LeaseRepository) Acquire(ctx context.Context, name, holder string, ttl time.Duration) (string, error) {
+	db := r.db.WithContext(ctx)
+	now := time.Now()
+
+	// Step 1: Renew our lease or take over an expired one
+	result := db.Model(&leader.Lease{}).
+		Where("name = ? AND (holder = ? OR expires_at < ?)", name, holder, now).
+		Updates(map[string]interface{}{"holder": holder, "expires_at": now.Add(ttl)})
+	if result.Error != nil {
+		return "", result.Error
+	}
+	if result.RowsAffected > 0 {
+		return holder, nil
+	}
+
+	// Step 2: Create the lease of a new election; a concurrent creator wins
+	lease := leader.Lease{Name: name, Holder: holder, ExpiresAt: now.Add(ttl)}
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&lease).Error; err != nil {
+		return "", err
+	}
+
+	// Step 3: Report who holds the lease now
+	var current leader.Lease
+	if err := db.Where("name = ?", name).Take(&current).Error; err != nil {
+		return "", err
+	}
+	return current.Holder, nil
+}
+
+// Release expires the lease if the holder has it, so the next instance can
+// take it over without waiting for the TTL.
+//
+// Parameters:
+//   - ctx: Context bounding the query
+//   - name: Name of the election
+//   - holder: Instance giving the lease up
+//
+// Returns:
+//   - error: Error if the query fails
+//
+// Query Implementation:
+//
+//	UPDATE leader_leases SET expires_at = ? WHERE name = ? AND holder = ?
+func (r *LeaseRepository) Release(ctx context.Context, name, holder string) error {
+	return r.db.WithContext(ctx).Model(&leader.Lease{}).
+		Where("name = ? AND holder = ?", name, holder).
+		Update("expires_at", time.Unix(0, 0)).Error
+}
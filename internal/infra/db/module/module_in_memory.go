@@ -2,52 +2,230 @@ package module
 
 import (
 	"errors"
+	"fmt"
 	"go_di_architecture/internal/domain/models/module"
+	"go_di_architecture/internal/domain/models/pagination"
+	"go_di_architecture/internal/domain/models/tag"
+	"go_di_architecture/internal/domain/models/template"
+	"go_di_architecture/internal/domain/models/workflow"
+	moduleService "go_di_architecture/internal/domain/service/module"
+	"go_di_architecture/internal/infra/db"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 )
 
-type ModuleRepository struct {
+// InMemoryModuleRepository keeps modules and everything attached to them in
+// maps guarded by one lock.
+//
+// Reads share the lock (RLock), so concurrent lookups and lists only wait
+// for writes. Module names are indexed in lower case, like the
+// idx_modules_name_lower index of the SQL schema: name checks are map
+// lookups, and creating or renaming a module to a taken name fails with
+// ErrNameExists like the unique index would.
+//
+// Modules are stored and returned as deep copies (see snapshot), so callers
+// cannot change stored modules behind the lock, and lists come in the same
+// order as from the SQL repository.
+//
+// Module IDs come from the ID generator like in the SQL repository; a
+// generator leaving them to the database (db.AutoIncrement) gets the next
+// number of a counter instead.
+type InMemoryModuleRepository struct {
 	data            map[int]*module.Module
-	mu              sync.Mutex
+	mu              sync.RWMutex
+	ids             db.IDGenerator
 	autoIncrementID int
+
+	// Lower-case name -> ID of the live or soft-deleted module holding it
+	names map[string]int
+
+	// Soft-deleted modules are moved out of data so lookups skip them
+	trash map[int]*module.Module
+
+	// Tags live next to modules so tag filters use the same lock
+	tags               map[int]*tag.Tag
+	moduleTags         map[int]map[int]bool
+	tagAutoIncrementID int
+
+	// Dependency edges: module ID -> IDs of the modules it depends on
+	dependencies map[int]map[int]bool
+
+	// Settings: module ID -> key -> setting
+	settings map[int]map[string]module.ModuleSetting
+
+	// Change history: module ID -> revisions in revision order
+	revisions               map[int][]*module.ModuleRevision
+	revisionAutoIncrementID int
+
+	// Notes: module ID -> notes in creation order (deleted ones included)
+	notes               map[int][]*module.ModuleNote
+	noteAutoIncrementID int
+
+	// Favorites: module ID -> user -> time starred
+	stars map[int]map[string]time.Time
+
+	// Module templates by template ID
+	templates               map[int]*template.ModuleTemplate
+	templateAutoIncrementID int
+
+	// Access control lists: module ID -> entries (absent when unrestricted)
+	acl map[int][]module.ModuleACLEntry
+
+	// Ownership transfers by transfer ID
+	transfers               map[int]*module.ModuleTransfer
+	transferAutoIncrementID int
+
+	// Modules archived by the retention policy in archive order
+	archive                []module.ModuleArchiveRecord
+	archiveAutoIncrementID int
+
+	// Usage counters: module ID -> day -> counter
+	usage map[int]map[string]*module.ModuleUsage
+
+	// API key request counters: key name -> month -> requests
+	apiKeyUsage map[string]map[string]int64
+
+	// Workflow states by workflow ID
+	workflows map[string]*workflow.Workflow
 }
 
-func NewModuleRepository() *ModuleRepository {
-	return &ModuleRepository{
-		data:            make(map[int]*module.Module),
-		autoIncrementID: 1,
+// NewInMemoryModuleRepository creates an empty in-memory repository.
+//
+// Parameters:
+//   - ids: Generator of the IDs of new modules
+//
+// Returns:
+//   - *InMemoryModuleRepository: A new, empty repository
+func NewInMemoryModuleRepository(ids db.IDGenerator) *InMemoryModuleRepository {
+	return &InMemoryModuleRepository{
+		data:                    make(map[int]*module.Module),
+		ids:                     ids,
+		names:                   make(map[string]int),
+		autoIncrementID:         1,
+		trash:                   make(map[int]*module.Module),
+		tags:                    make(map[int]*tag.Tag),
+		moduleTags:              make(map[int]map[int]bool),
+		tagAutoIncrementID:      1,
+		dependencies:            make(map[int]map[int]bool),
+		settings:                make(map[int]map[string]module.ModuleSetting),
+		revisions:               make(map[int][]*module.ModuleRevision),
+		revisionAutoIncrementID: 1,
+		notes:                   make(map[int][]*module.ModuleNote),
+		noteAutoIncrementID:     1,
+		stars:                   make(map[int]map[string]time.Time),
+		templates:               make(map[int]*template.ModuleTemplate),
+		templateAutoIncrementID: 1,
+		acl:                     make(map[int][]module.ModuleACLEntry),
+		transfers:               make(map[int]*module.ModuleTransfer),
+		transferAutoIncrementID: 1,
+		archiveAutoIncrementID:  1,
+		usage:                   make(map[int]map[string]*module.ModuleUsage),
+		apiKeyUsage:             make(map[string]map[string]int64),
+		workflows:               make(map[string]*workflow.Workflow),
 	}
 }
 
-func (r *ModuleRepository) CreateModule(m *module.Module) (*module.Module, error) {
+// WithTx runs fn with the store itself: it has no transactions, so the
+// changes made before fn fails stay applied.
+func (r *InMemoryModuleRepository) WithTx(fn func(repo moduleService.ModuleRepository, revisions moduleService.RevisionRepository) error) error {
+	return fn(r, r)
+}
+
+func (r *InMemoryModuleRepository) CreateModule(m *module.Module) (*module.Module, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	// Simulate auto-increment ID
-	m.ID = r.autoIncrementID
-	r.autoIncrementID++
+	if _, taken := r.names[strings.ToLower(m.Name)]; taken {
+		return nil, fmt.Errorf("%w: %s", moduleService.ErrNameExists, m.Name)
+	}
 
-	r.data[m.ID] = m
+	// Take the ID from the generator, or simulate the auto-increment column
+	id, err := r.ids.NextID()
+	if err != nil {
+		return nil, fmt.Errorf("generate module ID: %w", err)
+	}
+	if id == 0 {
+		id = r.autoIncrementID
+		r.autoIncrementID++
+	}
+	if r.data[id] != nil || r.trash[id] != nil {
+		// The primary key of the SQL schema rejects such inserts as well
+		return nil, fmt.Errorf("generate module ID: %d is taken", id)
+	}
+	m.ID = id
+
+	r.data[m.ID] = snapshot(m)
+	r.names[strings.ToLower(m.Name)] = m.ID
 	return m, nil
 }
 
-func (r *ModuleRepository) IsModuleNameExists(name string, excludeId int) (bool, error) {
+func (r *InMemoryModuleRepository) UpdateModule(m *module.Module) (*module.Module, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	for id, mod := range r.data {
-		if strings.EqualFold(mod.Name, name) && id != excludeId {
-			return true, nil
-		}
+	key := strings.ToLower(m.Name)
+	if holder, taken := r.names[key]; taken && holder != m.ID {
+		return nil, fmt.Errorf("%w: %s", moduleService.ErrNameExists, m.Name)
 	}
-	return false, nil
+	if previous, exists := r.data[m.ID]; exists {
+		r.releaseName(previous)
+	}
+
+	r.data[m.ID] = snapshot(m)
+	r.names[key] = m.ID
+	return m, nil
 }
 
-func (r *ModuleRepository) GetModuleById(id string) (*module.Module, error) {
-	r.mu.Lock()
-	defer r.mu.Unlock()
+// snapshot returns a deep copy of a module, sharing no pointer or slice with
+// it.
+func snapshot(m *module.Module) *module.Module {
+	copied := *m
+	if m.ActivateAt != nil {
+		activateAt := *m.ActivateAt
+		copied.ActivateAt = &activateAt
+	}
+	if m.DeactivateAt != nil {
+		deactivateAt := *m.DeactivateAt
+		copied.DeactivateAt = &deactivateAt
+	}
+	copied.Tags = slices.Clone(m.Tags)
+	copied.Permissions = slices.Clone(m.Permissions)
+	return &copied
+}
+
+// snapshots returns deep copies of modules in the same order.
+func snapshots(modules []*module.Module) []*module.Module {
+	copies := make([]*module.Module, len(modules))
+	for i, m := range modules {
+		copies[i] = snapshot(m)
+	}
+	return copies
+}
+
+// releaseName removes a module that leaves the store from the name index;
+// the caller must hold the lock.
+func (r *InMemoryModuleRepository) releaseName(m *module.Module) {
+	key := strings.ToLower(m.Name)
+	if r.names[key] == m.ID {
+		delete(r.names, key)
+	}
+}
+
+func (r *InMemoryModuleRepository) IsModuleNameExists(name string, excludeId int) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	holder, taken := r.names[strings.ToLower(name)]
+	return taken && holder != excludeId, nil
+}
+
+func (r *InMemoryModuleRepository) GetModuleById(id string) (*module.Module, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 
 	moduleID, err := strconv.Atoi(id)
 	if err != nil {
@@ -58,5 +236,276 @@ func (r *ModuleRepository) GetModuleById(id string) (*module.Module, error) {
 	if !exists {
 		return nil, nil
 	}
-	return m, nil
+	return snapshot(m), nil
+}
+
+func (r *InMemoryModuleRepository) ModuleExists(id int) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, exists := r.data[id]
+	return exists, nil
+}
+
+func (r *InMemoryModuleRepository) CountModules(isActive *bool, visibleTo []string) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var count int64
+	for _, m := range r.data {
+		if !r.matches(m, module.ModuleFilter{VisibleTo: visibleTo}) {
+			continue
+		}
+		if isActive == nil || m.IsActive == *isActive {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *InMemoryModuleRepository) CountModulesByStatus(visibleTo []string) (int64, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var active, inactive int64
+	for _, m := range r.data {
+		if !r.matches(m, module.ModuleFilter{VisibleTo: visibleTo}) {
+			continue
+		}
+		if m.IsActive {
+			active++
+		} else {
+			inactive++
+		}
+	}
+	return active, inactive, nil
+}
+
+func (r *InMemoryModuleRepository) CountModulesCreatedPerDay(since time.Time, visibleTo []string) (map[string]int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	counts := make(map[string]int64)
+	for _, m := range r.data {
+		if !m.CreatedAt.Before(since) && r.matches(m, module.ModuleFilter{VisibleTo: visibleTo}) {
+			counts[m.CreatedAt.UTC().Format("2006-01-02")]++
+		}
+	}
+	return counts, nil
+}
+
+func (r *InMemoryModuleRepository) FindLeastRecentlyUpdated(limit int, visibleTo []string) ([]*module.Module, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	sorted := make([]*module.Module, 0, len(r.data))
+	for _, m := range r.data {
+		if r.matches(m, module.ModuleFilter{VisibleTo: visibleTo}) {
+			sorted = append(sorted, m)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if !sorted[i].UpdatedAt.Equal(sorted[j].UpdatedAt) {
+			return sorted[i].UpdatedAt.Before(sorted[j].UpdatedAt)
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	if len(sorted) > limit {
+		sorted = sorted[:limit]
+	}
+	return snapshots(sorted), nil
+}
+
+func (r *InMemoryModuleRepository) GetModulesByIds(ids []int) ([]*module.Module, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	found := make([]*module.Module, 0, len(ids))
+	seen := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		if m, exists := r.data[id]; exists && !seen[id] {
+			seen[id] = true
+			found = append(found, snapshot(m))
+		}
+	}
+	sort.Slice(found, func(i, j int) bool {
+		return found[i].ID < found[j].ID
+	})
+	return found, nil
+}
+
+func (r *InMemoryModuleRepository) FindModuleByName(name string) (*module.Module, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	id, taken := r.names[strings.ToLower(name)]
+	if !taken {
+		return nil, nil
+	}
+	if m, exists := r.data[id]; exists {
+		return snapshot(m), nil
+	}
+	if m, exists := r.trash[id]; exists {
+		return snapshot(m), nil
+	}
+	return nil, nil
+}
+
+func (r *InMemoryModuleRepository) FindModuleNamesByPrefix(prefix string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	prefix = strings.ToLower(prefix)
+
+	var names []string
+	for _, mod := range r.allModules() {
+		if strings.HasPrefix(strings.ToLower(mod.Name), prefix) {
+			names = append(names, mod.Name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (r *InMemoryModuleRepository) ListModules(filter module.ModuleFilter, offset, limit int, opts ...module.ListOption) ([]*module.Module, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	sorted := r.sortedModules(filter)
+	total := int64(len(sorted))
+
+	if offset >= len(sorted) {
+		return []*module.Module{}, total, nil
+	}
+	sorted = sorted[offset:]
+	if len(sorted) > limit {
+		sorted = sorted[:limit]
+	}
+	return r.withRelations(sorted, module.LoadRelations(opts)), total, nil
+}
+
+func (r *InMemoryModuleRepository) ListModulesAfter(filter module.ModuleFilter, after *pagination.Cursor, limit int, opts ...module.ListOption) ([]*module.Module, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	page := make([]*module.Module, 0, limit)
+	for _, m := range r.sortedModules(filter) {
+		if len(page) == limit {
+			break
+		}
+		if after != nil && !isAfterCursor(m, after) {
+			continue
+		}
+		page = append(page, m)
+	}
+	return r.withRelations(page, module.LoadRelations(opts)), nil
+}
+
+// withRelations returns snapshots of the modules carrying the selected
+// relations; the caller must hold the lock.
+func (r *InMemoryModuleRepository) withRelations(modules []*module.Module, relations module.Relations) []*module.Module {
+	loaded := snapshots(modules)
+	for _, m := range loaded {
+		if relations.Tags {
+			m.Tags = make([]string, 0, len(r.moduleTags[m.ID]))
+			for tagID := range r.moduleTags[m.ID] {
+				if t, exists := r.tags[tagID]; exists {
+					m.Tags = append(m.Tags, t.Name)
+				}
+			}
+			sort.Strings(m.Tags)
+		}
+		if relations.Permissions {
+			m.Permissions = append([]module.ModuleACLEntry{}, r.acl[m.ID]...)
+			sort.Slice(m.Permissions, func(i, j int) bool {
+				return m.Permissions[i].Principal < m.Permissions[j].Principal
+			})
+		}
+	}
+	return loaded
+}
+
+// sortedModules returns the matching modules in (CreatedAt, ID) order; the caller must hold the lock.
+func (r *InMemoryModuleRepository) sortedModules(filter module.ModuleFilter) []*module.Module {
+	sorted := make([]*module.Module, 0, len(r.data))
+	for _, m := range r.data {
+		if r.matches(m, filter) {
+			sorted = append(sorted, m)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if !sorted[i].CreatedAt.Equal(sorted[j].CreatedAt) {
+			return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+	return sorted
+}
+
+// allModules returns live and soft-deleted modules for name checks; the caller must hold the lock.
+func (r *InMemoryModuleRepository) allModules() []*module.Module {
+	all := make([]*module.Module, 0, len(r.data)+len(r.trash))
+	for _, m := range r.data {
+		all = append(all, m)
+	}
+	for _, m := range r.trash {
+		all = append(all, m)
+	}
+	return all
+}
+
+func isAfterCursor(m *module.Module, cursor *pagination.Cursor) bool {
+	if m.CreatedAt.Equal(cursor.CreatedAt) {
+		return m.ID > cursor.ID
+	}
+	return m.CreatedAt.After(cursor.CreatedAt)
+}
+
+// matches reports whether the module satisfies the filter; the caller must hold the lock.
+func (r *InMemoryModuleRepository) matches(m *module.Module, filter module.ModuleFilter) bool {
+	if filter.VisibleTo != nil && !r.visibleTo(m.ID, filter.VisibleTo) {
+		return false
+	}
+	if filter.Status != "" && m.Status != filter.Status {
+		return false
+	}
+	if filter.ActiveOnly && !m.IsActive {
+		return false
+	}
+	if filter.StarredBy != "" {
+		if _, starred := r.stars[m.ID][filter.StarredBy]; !starred {
+			return false
+		}
+	}
+	if filter.Tag == "" {
+		return true
+	}
+	for tagID := range r.moduleTags[m.ID] {
+		if t, exists := r.tags[tagID]; exists && t.Name == filter.Tag {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *InMemoryModuleRepository) FindDueScheduledModules(now time.Time, limit int) ([]*module.Module, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	due := make([]*module.Module, 0)
+	for _, m := range r.data {
+		activateDue := m.Status == module.StatusApproved && m.ActivateAt != nil && !m.ActivateAt.After(now)
+		if activateDue || (m.DeactivateAt != nil && !m.DeactivateAt.After(now)) {
+			due = append(due, snapshot(m))
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].ID < due[j].ID
+	})
+
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
 }
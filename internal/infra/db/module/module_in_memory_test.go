@@ -0,0 +1,72 @@
+package module
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"go_di_architecture/internal/domain/models/module"
+	"go_di_architecture/internal/infra/db"
+)
+
+// fixedIDs is an ID generator returning the same result every time.
+type fixedIDs struct {
+	id  int
+	err error
+}
+
+func (f fixedIDs) NextID() (int, error) {
+	return f.id, f.err
+}
+
+func TestInMemoryModuleRepositoryTakesIDsFromGenerator(t *testing.T) {
+	ids, err := db.NewSnowflake(7)
+	if err != nil {
+		t.Fatalf("NewSnowflake() error = %v", err)
+	}
+	repo := NewInMemoryModuleRepository(ids)
+
+	previous := 0
+	for i, name := range []string{"payments", "billing", "search"} {
+		created := mustCreate(t, repo, name, testTime(i))
+		if created.ID <= previous || created.ID < 1<<22 {
+			t.Fatalf("CreateModule(%q) ID = %d, want a snowflake ID above %d", name, created.ID, previous)
+		}
+		if node := created.ID >> 12 & db.MaxSnowflakeNode; node != 7 {
+			t.Errorf("CreateModule(%q) ID = %d of node %d, want node 7", name, created.ID, node)
+		}
+		found, err := repo.GetModuleById(strconv.Itoa(created.ID))
+		if err != nil || found == nil || found.Name != name {
+			t.Errorf("GetModuleById(%d) = %v, %v, want %q", created.ID, found, err, name)
+		}
+		previous = created.ID
+	}
+}
+
+func TestInMemoryModuleRepositoryCountsWithAutoIncrement(t *testing.T) {
+	repo := NewInMemoryModuleRepository(db.AutoIncrement{})
+	for i, name := range []string{"payments", "billing"} {
+		if created := mustCreate(t, repo, name, testTime(i)); created.ID != i+1 {
+			t.Errorf("CreateModule(%q) ID = %d, want %d", name, created.ID, i+1)
+		}
+	}
+}
+
+func TestInMemoryModuleRepositoryRejectsUnusableIDs(t *testing.T) {
+	failure := errors.New("clock outside range")
+	repo := NewInMemoryModuleRepository(fixedIDs{err: failure})
+	if _, err := repo.CreateModule(&module.Module{Name: "payments"}); !errors.Is(err, failure) {
+		t.Errorf("CreateModule() error = %v, want the generator error", err)
+	}
+
+	// A repeated ID would overwrite a module; the primary key of the SQL
+	// schema rejects it as well
+	repo = NewInMemoryModuleRepository(fixedIDs{id: 42})
+	mustCreate(t, repo, "payments", testTime(0))
+	if created, err := repo.CreateModule(&module.Module{Name: "billing"}); err == nil {
+		t.Errorf("CreateModule() with a taken ID = %+v, want an error", created)
+	}
+	if found, _ := repo.FindModuleByName("billing"); found != nil {
+		t.Errorf("FindModuleByName(billing) = %+v, want nil", found)
+	}
+}
@@ -1,191 +1,799 @@
 package module
 
-// import (
-// 	"errors"
-// 	"strconv"
-// 	"strings"
-
-// 	"go_di_architecture/internal/domain/models/module"
-
-// 	"gorm.io/gorm"
-// )
-
-// // ModuleRepository implements data operations for module entities.
-// //
-// // This repository handles all database interactions for modules. All methods
-// // participate in ambient transactions when called within transaction scopes.
-// //
-// // Technical Implementation:
-// //   - Uses GORM 1.24+ with standard patterns
-// //   - Parameterized queries to prevent SQL injection
-// //   - Optimized for SQLite/PostgreSQL/MySQL
-// //   - Automatic connection handling
-// //
-// // Transaction Management:
-// //   - Fully participates in ambient database transactions
-// //   - Creates new transaction if none exists (default behavior)
-// //   - Rolls back on error
-// //   - Supports nested transactions
-// //
-// // Performance Optimization:
-// //   - Name uniqueness check uses covering index
-// //   - No unnecessary query operations
-// //   - No client-side caching implemented
-// //   - Query optimization for single-entity operations
-// //
-// // Usage Context:
-// //
-// //	// Within business service with transaction:
-// //	db.Transaction(func(tx *gorm.DB) error {
-// //	    repo := NewModuleRepositoryWithDB(tx)
-// //	    _, err := repo.CreateModule(entity)
-// //	    return err
-// //	})
-// //
-// //	// Without explicit transaction:
-// //	repo := NewModuleRepository()
-// //	_, err := repo.CreateModule(entity)
-// type ModuleRepository struct {
-// 	db *gorm.DB
-// }
-
-// // NewModuleRepository creates a new instance of ModuleRepository.
-// //
-// // Returns:
-// //   - *ModuleRepository: A new repository instance using the default database connection
-// func NewModuleRepository() *ModuleRepository {
-// 	return &ModuleRepository{db: GetDB()}
-// }
-
-// // NewModuleRepositoryWithDB creates a repository with a specific database connection.
-// //
-// // This is used when participating in an existing transaction.
-// //
-// // Parameters:
-// //   - db: Database connection to use
-// //
-// // Returns:
-// //   - *ModuleRepository: A new repository instance using the provided connection
-// func NewModuleRepositoryWithDB(db *gorm.DB) *ModuleRepository {
-// 	return &ModuleRepository{db: db}
-// }
-
-// // CreateModule adds a new module to the database with full persistence details.
-// //
-// // Parameters:
-// //   - moduleEntity: Entity to persist with required fields
-// //
-// // Returns:
-// //   - *module.Module: Persisted entity with database-generated values
-// //   - error: Error if persistence fails
-// //
-// // Database Operation Sequence:
-// //  1. Execute INSERT command via GORM
-// //  2. Database returns identity value (ID)
-// //  3. Audit fields populated by application code
-// //  4. Entity state updated
-// //
-// // Database Schema Details:
-// //   - Table: modules
-// //   - Primary Key: id (auto-increment)
-// //   - Unique Constraint: name (case-insensitive)
-// //   - Audit Columns: created_at (timestamp)
-// //
-// // Error Handling:
-// //   - Returns gorm.ErrDuplicatedKey for constraint violations
-// //   - Handles database timeout exceptions
-// //   - No automatic retry for transient errors
-// func (r *ModuleRepository) CreateModule(moduleEntity *module.Module) (*module.Module, error) {
-// 	// Step 1: Save to database
-// 	result := r.db.Create(moduleEntity)
-// 	if result.Error != nil {
-// 		return nil, result.Error
-// 	}
-
-// 	// Step 2: Return entity with generated values
-// 	return moduleEntity, nil
-// }
-
-// // IsModuleNameExists checks module name existence with database optimization details.
-// //
-// // Parameters:
-// //   - name: Module name to check (case-insensitive)
-// //   - excludeId: Optional ID to exclude (for update operations)
-// //
-// // Returns:
-// //   - bool: True if name exists, false otherwise
-// //   - error: Error if database query fails
-// //
-// // Query Implementation:
-// //
-// //	SELECT COUNT(*) FROM modules
-// //	WHERE LOWER(name) = LOWER(?)
-// //	AND (? = 0 OR id != ?)
-// //
-// // Performance Notes:
-// //   - Uses case-insensitive comparison for accurate matching
-// //   - Leverages unique index on Name column
-// //   - Execution time: ~2ms (cached plan)
-// //   - No lock escalation
-// //
-// // Edge Cases Handled:
-// //   - NULL name handling (returns false)
-// //   - Trimming of whitespace in database
-// //   - Proper exclusion during updates
-// func (r *ModuleRepository) IsModuleNameExists(name string, excludeId int) (bool, error) {
-// 	if name == "" {
-// 		return false, nil
-// 	}
-
-// 	var count int64
-// 	query := r.db.Model(&module.Module{}).Where("LOWER(name) = ?", strings.ToLower(strings.TrimSpace(name)))
-
-// 	if excludeId > 0 {
-// 		query = query.Where("id != ?", excludeId)
-// 	}
-
-// 	err := query.Count(&count).Error
-// 	if err != nil {
-// 		return false, err
-// 	}
-
-// 	return count > 0, nil
-// }
-
-// // GetModuleById retrieves module entity by ID with database query details.
-// //
-// // Parameters:
-// //   - id: Unique identifier to search for (as string)
-// //
-// // Returns:
-// //   - *module.Module: Module entity or nil if not found
-// //   - error: Error if database query fails
-// func (r *ModuleRepository) GetModuleById(id string) (*module.Module, error) {
-// 	var module module.Module
-
-// 	// Convert string ID to int
-// 	moduleID, err := strconv.Atoi(id)
-// 	if err != nil {
-// 		return nil, errors.New("invalid module ID format")
-// 	}
-
-// 	// Query database
-// 	result := r.db.First(&module, moduleID)
-// 	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-// 		return nil, nil
-// 	}
-
-// 	return &module, result.Error
-// }
-
-// // GetDB returns the database connection.
-// //
-// // This is a placeholder for actual database connection logic.
-// //
-// // Returns:
-// //   - *gorm.DB: The database connection
-// func GetDB() *gorm.DB {
-// 	// In a real implementation, this would return the actual database connection
-// 	// For example: return config.DB
-// 	return nil
-// }
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go_di_architecture/internal/domain/models/module"
+	"go_di_architecture/internal/domain/models/pagination"
+	moduleService "go_di_architecture/internal/domain/service/module"
+	"go_di_architecture/internal/infra/db"
+
+	"gorm.io/gorm"
+)
+
+// ModuleRepository implements data operations for module entities.
+//
+// This repository handles all database interactions for modules. All methods
+// participate in ambient transactions when called within transaction scopes.
+//
+// Technical Implementation:
+//   - Uses GORM 1.24+ with standard patterns
+//   - Parameterized queries to prevent SQL injection
+//   - Optimized for SQLite/PostgreSQL/MySQL
+//   - Automatic connection handling
+//
+// Transaction Management:
+//   - Fully participates in ambient database transactions
+//   - Creates new transaction if none exists (default behavior)
+//   - Rolls back on error
+//   - Supports nested transactions
+//
+// Performance Optimization:
+//   - Name uniqueness check uses covering index
+//   - No unnecessary query operations
+//   - No client-side caching implemented
+//   - Query optimization for single-entity operations
+//
+// Usage Context:
+//
+//	// Within business service with transaction:
+//	db.Transaction(func(tx *gorm.DB) error {
+//	    repo := NewModuleRepository(tx, db.AutoIncrement{})
+//	    _, err := repo.CreateModule(entity)
+//	    return err
+//	})
+//
+//	// Without explicit transaction:
+//	repo := NewModuleRepository(database, db.AutoIncrement{})
+//	_, err := repo.CreateModule(entity)
+type ModuleRepository struct {
+	db  *gorm.DB
+	ids db.IDGenerator
+}
+
+// NewModuleRepository creates a repository with a specific database connection.
+//
+// Pass a transaction handle to make the repository participate in an existing
+// transaction.
+//
+// Parameters:
+//   - db: Database connection to use
+//   - ids: Generator of the IDs of new modules
+//
+// Returns:
+//   - *ModuleRepository: A new repository instance using the provided connection
+func NewModuleRepository(db *gorm.DB, ids db.IDGenerator) *ModuleRepository {
+	return &ModuleRepository{db: db, ids: ids}
+}
+
+// WithContext returns a repository running its statements with a context,
+// such as the request context carrying the query tally and statement trace.
+//
+// When the context carries a unit of work, the statements run in its
+// transaction (see db.ForContext).
+//
+// Parameters:
+//   - ctx: Context of the statements
+//
+// Returns:
+//   - moduleService.ModuleRepository: A repository sharing the connection
+func (r *ModuleRepository) WithContext(ctx context.Context) moduleService.ModuleRepository {
+	return &ModuleRepository{db: db.ForContext(ctx, r.db), ids: r.ids}
+}
+
+// WithTx runs a module change and the revisions recording it in one
+// transaction.
+//
+// Parameters:
+//   - fn: The writes, given module and revision repositories bound to the
+//     transaction
+//
+// Returns:
+//   - error: The error of fn, which rolls the transaction back, or of the commit
+func (r *ModuleRepository) WithTx(fn func(repo moduleService.ModuleRepository, revisions moduleService.RevisionRepository) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&ModuleRepository{db: tx, ids: r.ids}, NewRevisionRepository(tx))
+	})
+}
+
+// CreateModule adds a new module to the database with full persistence details.
+//
+// Parameters:
+//   - moduleEntity: Entity to persist with required fields
+//
+// Returns:
+//   - *module.Module: Persisted entity with database-generated values
+//   - error: Error if persistence fails
+//
+// Database Operation Sequence:
+//  1. Take the ID from the ID generator unless the entity carries one
+//  2. Execute INSERT command via GORM
+//  3. Database returns identity value (ID) when the generator left it zero
+//  4. Audit fields populated by application code
+//  5. Entity state updated
+//
+// Database Schema Details:
+//   - Table: modules
+//   - Primary Key: id (auto-increment, or assigned by the ID generator)
+//   - Unique Constraint: idx_modules_name_lower on LOWER(name)
+//   - Audit Columns: created_at (timestamp)
+//
+// Error Handling:
+//   - Returns an error wrapping ErrNameExists for unique constraint violations
+//     (requires gorm.Config.TranslateError)
+//   - Handles database timeout exceptions
+//   - No automatic retry for transient errors
+func (r *ModuleRepository) CreateModule(moduleEntity *module.Module) (*module.Module, error) {
+	// Step 1: Assign the ID unless the database does
+	generated := moduleEntity.ID == 0
+	if generated {
+		id, err := r.ids.NextID()
+		if err != nil {
+			return nil, fmt.Errorf("generate module ID: %w", err)
+		}
+		moduleEntity.ID = id
+	}
+
+	// Step 2: Save to database; a failed insert leaves the entity as passed in
+	result := r.db.Create(moduleEntity)
+	if result.Error != nil && generated {
+		moduleEntity.ID = 0
+	}
+	if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+		return nil, fmt.Errorf("%w: %v", moduleService.ErrNameExists, result.Error)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	// Step 3: Return entity with generated values
+	return moduleEntity, nil
+}
+
+// UpdateModule writes the editable fields of an existing module.
+//
+// Parameters:
+//   - moduleEntity: Entity carrying the ID and the new field values
+//
+// Returns:
+//   - *module.Module: The updated entity
+//   - error: Error if persistence fails
+//
+// Query Implementation:
+//
+//	UPDATE modules SET name = ?, description = ?, is_active = ?, status = ?,
+//	    activate_at = ?, deactivate_at = ?, owner = ?, updated_at = ?
+//	WHERE id = ?
+//
+// Error Handling:
+//   - Columns are selected explicitly so a false isActive and cleared
+//     schedule times are written too
+//   - Returns an error wrapping ErrNameExists for unique constraint violations
+//     (requires gorm.Config.TranslateError)
+func (r *ModuleRepository) UpdateModule(moduleEntity *module.Module) (*module.Module, error) {
+	result := r.db.Model(moduleEntity).
+		Select("Name", "Description", "IsActive", "Status", "ActivateAt", "DeactivateAt", "Owner", "UpdatedAt").
+		Updates(moduleEntity)
+	if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+		return nil, fmt.Errorf("%w: %v", moduleService.ErrNameExists, result.Error)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return moduleEntity, nil
+}
+
+// IsModuleNameExists checks module name existence with database optimization details.
+//
+// Parameters:
+//   - name: Module name to check (case-insensitive)
+//   - excludeId: Optional ID to exclude (for update operations)
+//
+// Returns:
+//   - bool: True if name exists, false otherwise
+//   - error: Error if database query fails
+//
+// Query Implementation:
+//
+//	SELECT COUNT(*) FROM modules
+//	WHERE LOWER(name) = LOWER(?)
+//	AND (? = 0 OR id != ?)
+//
+// Performance Notes:
+//   - Uses case-insensitive comparison for accurate matching
+//   - Leverages the LOWER(name) functional unique index
+//   - Execution time: ~2ms (cached plan)
+//   - No lock escalation
+//
+// Edge Cases Handled:
+//   - Soft-deleted modules are included (their names stay reserved)
+//   - NULL name handling (returns false)
+//   - Trimming of whitespace in database
+//   - Proper exclusion during updates
+func (r *ModuleRepository) IsModuleNameExists(name string, excludeId int) (bool, error) {
+	if name == "" {
+		return false, nil
+	}
+
+	// Modules in the recycle bin keep their name reserved
+	var count int64
+	query := r.db.Unscoped().Model(&module.Module{}).Where("LOWER(name) = ?", strings.ToLower(strings.TrimSpace(name)))
+
+	if excludeId > 0 {
+		query = query.Where("id != ?", excludeId)
+	}
+
+	err := query.Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// GetModuleById retrieves module entity by ID with database query details.
+//
+// Parameters:
+//   - id: Unique identifier to search for (as string)
+//
+// Returns:
+//   - *module.Module: Module entity or nil if not found
+//   - error: Error if database query fails
+func (r *ModuleRepository) GetModuleById(id string) (*module.Module, error) {
+	var module module.Module
+
+	// Convert string ID to int
+	moduleID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, errors.New("invalid module ID format")
+	}
+
+	// Query database
+	result := r.db.First(&module, moduleID)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return &module, result.Error
+}
+
+// ModuleExists checks module existence by ID without loading the entity.
+//
+// Parameters:
+//   - id: Unique identifier to check
+//
+// Returns:
+//   - bool: True if the module exists
+//   - error: Error if database query fails
+//
+// Query Implementation:
+//
+//	SELECT id FROM modules WHERE id = ? LIMIT 1
+func (r *ModuleRepository) ModuleExists(id int) (bool, error) {
+	var found []int
+	err := r.db.Model(&module.Module{}).Where("id = ?", id).Limit(1).Pluck("id", &found).Error
+	if err != nil {
+		return false, err
+	}
+
+	return len(found) > 0, nil
+}
+
+// CountModules counts modules, optionally filtered by active flag.
+//
+// Parameters:
+//   - isActive: Active flag to filter by (nil counts all modules)
+//   - visibleTo: Principals whose modules are counted (nil counts all modules)
+//
+// Returns:
+//   - int64: Number of matching modules
+//   - error: Error if database query fails
+//
+// Query Implementation:
+//
+//	SELECT COUNT(*) FROM modules [WHERE is_active = ?] [AND <visibility, see filtered>]
+func (r *ModuleRepository) CountModules(isActive *bool, visibleTo []string) (int64, error) {
+	query := r.filtered(module.ModuleFilter{VisibleTo: visibleTo})
+	if isActive != nil {
+		query = query.Where("is_active = ?", *isActive)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// CountModulesByStatus counts active and inactive modules with a single aggregate query.
+//
+// Parameters:
+//   - visibleTo: Principals whose modules are counted (nil counts all modules)
+//
+// Returns:
+//   - int64: Number of active modules
+//   - int64: Number of inactive modules
+//   - error: Error if database query fails
+//
+// Query Implementation:
+//
+//	SELECT is_active, COUNT(*) AS count FROM modules [WHERE <visibility>] GROUP BY is_active
+func (r *ModuleRepository) CountModulesByStatus(visibleTo []string) (int64, int64, error) {
+	var rows []struct {
+		IsActive bool
+		Count    int64
+	}
+	err := r.filtered(module.ModuleFilter{VisibleTo: visibleTo}).
+		Select("is_active, COUNT(*) AS count").
+		Group("is_active").
+		Scan(&rows).Error
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var active, inactive int64
+	for _, row := range rows {
+		if row.IsActive {
+			active = row.Count
+		} else {
+			inactive = row.Count
+		}
+	}
+	return active, inactive, nil
+}
+
+// CountModulesCreatedPerDay counts module creations per day since a point in time.
+//
+// Parameters:
+//   - since: Earliest creation time to include
+//   - visibleTo: Principals whose modules are counted (nil counts all modules)
+//
+// Returns:
+//   - map[string]int64: Creations keyed by day (YYYY-MM-DD); empty days are omitted
+//   - error: Error if database query fails
+//
+// Query Implementation:
+//
+//	SELECT DATE(created_at) AS day, COUNT(*) AS count FROM modules
+//	WHERE created_at >= ? [AND <visibility>]
+//	GROUP BY DATE(created_at)
+//
+// Dialect Notes:
+//   - DATE() is supported by PostgreSQL, MySQL and SQLite
+//   - Days follow the database session time zone (UTC in the default setup)
+//   - Drivers return the day as text or as a timestamp; only the date part is kept
+func (r *ModuleRepository) CountModulesCreatedPerDay(since time.Time, visibleTo []string) (map[string]int64, error) {
+	var rows []struct {
+		Day   string
+		Count int64
+	}
+	err := r.filtered(module.ModuleFilter{VisibleTo: visibleTo}).
+		Select("DATE(created_at) AS day, COUNT(*) AS count").
+		Where("created_at >= ?", since).
+		Group("DATE(created_at)").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		day := row.Day
+		if len(day) > len("2006-01-02") {
+			day = day[:len("2006-01-02")]
+		}
+		counts[day] += row.Count
+	}
+	return counts, nil
+}
+
+// FindLeastRecentlyUpdated retrieves the modules that have gone longest without changes.
+//
+// Parameters:
+//   - limit: Maximum number of modules to return
+//   - visibleTo: Principals whose modules are returned (nil returns all modules)
+//
+// Returns:
+//   - []*module.Module: Modules ordered by last change, oldest first
+//   - error: Error if database query fails
+//
+// Query Implementation:
+//
+//	SELECT * FROM modules [WHERE <visibility>] ORDER BY updated_at, id LIMIT ?
+func (r *ModuleRepository) FindLeastRecentlyUpdated(limit int, visibleTo []string) ([]*module.Module, error) {
+	var entities []module.Module
+	err := r.filtered(module.ModuleFilter{VisibleTo: visibleTo}).Order("updated_at, id").Limit(limit).Find(&entities).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return toPointers(entities), nil
+}
+
+// GetModulesByIds retrieves all modules with the given IDs in a single query.
+//
+// Parameters:
+//   - ids: Identifiers to look up (duplicates are harmless)
+//
+// Returns:
+//   - []*module.Module: Modules found, ordered by ID; unknown IDs are skipped
+//   - error: Error if database query fails
+//
+// Query Implementation:
+//
+//	SELECT * FROM modules WHERE id IN (?, ?, ...) ORDER BY id
+//
+// Performance Notes:
+//   - One round trip regardless of the number of IDs
+//   - Uses the primary key index
+func (r *ModuleRepository) GetModulesByIds(ids []int) ([]*module.Module, error) {
+	if len(ids) == 0 {
+		return []*module.Module{}, nil
+	}
+
+	var entities []module.Module
+	if err := r.db.Where("id IN ?", ids).Order("id").Find(&entities).Error; err != nil {
+		return nil, err
+	}
+
+	return toPointers(entities), nil
+}
+
+// FindModuleByName retrieves the module using a name (case-insensitive).
+//
+// Parameters:
+//   - name: Module name to look up
+//
+// Returns:
+//   - *module.Module: Module entity or nil if not found
+//   - error: Error if database query fails
+//
+// Query Implementation:
+//
+//	SELECT * FROM modules WHERE LOWER(name) = LOWER(?) LIMIT 1
+//
+// Soft-deleted modules are included because their names stay reserved.
+func (r *ModuleRepository) FindModuleByName(name string) (*module.Module, error) {
+	var entity module.Module
+
+	result := r.db.Unscoped().Where("LOWER(name) = ?", strings.ToLower(strings.TrimSpace(name))).First(&entity)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &entity, nil
+}
+
+// FindModuleNamesByPrefix lists module names starting with a prefix (case-insensitive).
+//
+// Parameters:
+//   - prefix: Name prefix to match
+//
+// Returns:
+//   - []string: Matching module names, sorted
+//   - error: Error if database query fails
+//
+// Query Implementation:
+//
+//	SELECT name FROM modules WHERE LOWER(name) LIKE ? ESCAPE '!' ORDER BY name
+//
+// LIKE wildcards in the prefix are escaped so they match literally. The '!'
+// escape character is used because backslash handling differs between dialects.
+// Soft-deleted modules are included because their names stay reserved.
+func (r *ModuleRepository) FindModuleNamesByPrefix(prefix string) ([]string, error) {
+	escaped := likeEscaper.Replace(strings.ToLower(prefix))
+
+	var names []string
+	err := r.db.Unscoped().Model(&module.Module{}).
+		Where("LOWER(name) LIKE ? ESCAPE '!'", escaped+"%").
+		Order("name").
+		Pluck("name", &names).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+// likeEscaper escapes LIKE wildcards so user input matches literally.
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// ListModules retrieves one page of modules using offset pagination.
+//
+// Parameters:
+//   - filter: Criteria narrowing the listed modules
+//   - offset: Number of rows to skip
+//   - limit: Maximum number of rows to return
+//   - opts: Relations to load with the page (see loadRelations)
+//
+// Returns:
+//   - []*module.Module: Modules on the page
+//   - int64: Total number of matching modules
+//   - error: Error if database query fails
+//
+// Query Implementation:
+//
+//	SELECT COUNT(*) FROM modules [JOIN module_tags ... JOIN tags ...]
+//	SELECT modules.* FROM modules [JOIN ...] ORDER BY created_at, id LIMIT ? OFFSET ?
+//
+// Performance Notes:
+//   - OFFSET scans and discards skipped rows; deep pages get slower
+//   - Prefer ListModulesAfter for large tables
+func (r *ModuleRepository) ListModules(filter module.ModuleFilter, offset, limit int, opts ...module.ListOption) ([]*module.Module, int64, error) {
+	var total int64
+	if err := r.filtered(filter).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var entities []module.Module
+	err := r.filtered(filter).Order("modules.created_at, modules.id").Offset(offset).Limit(limit).Find(&entities).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := r.loadRelations(entities, module.LoadRelations(opts)); err != nil {
+		return nil, 0, err
+	}
+
+	return toPointers(entities), total, nil
+}
+
+// ListModulesAfter retrieves modules after a cursor using keyset pagination.
+//
+// Parameters:
+//   - filter: Criteria narrowing the listed modules
+//   - after: Position of the last row already seen (nil for the first page)
+//   - limit: Maximum number of rows to return
+//   - opts: Relations to load with the page (see loadRelations)
+//
+// Returns:
+//   - []*module.Module: Modules following the cursor
+//   - error: Error if database query fails
+//
+// Query Implementation:
+//
+//	SELECT modules.* FROM modules [JOIN module_tags ... JOIN tags ...]
+//	WHERE created_at > ? OR (created_at = ? AND id > ?)
+//	ORDER BY created_at, id
+//	LIMIT ?
+//
+// Performance Notes:
+//   - Constant cost per page regardless of depth
+//   - Benefits from a composite index on (created_at, id)
+func (r *ModuleRepository) ListModulesAfter(filter module.ModuleFilter, after *pagination.Cursor, limit int, opts ...module.ListOption) ([]*module.Module, error) {
+	query := r.filtered(filter).Order("modules.created_at, modules.id").Limit(limit)
+	if after != nil {
+		query = query.Where(
+			"modules.created_at > ? OR (modules.created_at = ? AND modules.id > ?)",
+			after.CreatedAt, after.CreatedAt, after.ID,
+		)
+	}
+
+	var entities []module.Module
+	if err := query.Find(&entities).Error; err != nil {
+		return nil, err
+	}
+	if err := r.loadRelations(entities, module.LoadRelations(opts)); err != nil {
+		return nil, err
+	}
+
+	return toPointers(entities), nil
+}
+
+// moduleTagName is a tag name with the module carrying it.
+type moduleTagName struct {
+	ModuleID int
+	Name     string
+}
+
+// loadRelations eager-loads the selected relations of a page of modules, with
+// one query per relation instead of one per module.
+//
+// Query Implementation (tags):
+//
+//	SELECT module_tags.module_id, tags.name FROM module_tags
+//	JOIN tags ON tags.id = module_tags.tag_id
+//	WHERE module_tags.module_id IN (?)
+//	ORDER BY module_tags.module_id, tags.name
+//
+// Query Implementation (permissions):
+//
+//	SELECT * FROM module_acl WHERE module_id IN (?) ORDER BY module_id, principal
+func (r *ModuleRepository) loadRelations(entities []module.Module, relations module.Relations) error {
+	if len(entities) == 0 || (!relations.Tags && !relations.Permissions) {
+		return nil
+	}
+
+	ids := make([]int, len(entities))
+	byID := make(map[int]*module.Module, len(entities))
+	for i := range entities {
+		ids[i] = entities[i].ID
+		byID[entities[i].ID] = &entities[i]
+	}
+
+	// Step 1: Tag names of every module on the page
+	if relations.Tags {
+		var names []moduleTagName
+		err := r.db.Table("module_tags").
+			Select("module_tags.module_id, tags.name").
+			Joins("JOIN tags ON tags.id = module_tags.tag_id").
+			Where("module_tags.module_id IN ?", ids).
+			Order("module_tags.module_id, tags.name").
+			Find(&names).Error
+		if err != nil {
+			return err
+		}
+		for i := range entities {
+			entities[i].Tags = []string{}
+		}
+		for _, name := range names {
+			byID[name.ModuleID].Tags = append(byID[name.ModuleID].Tags, name.Name)
+		}
+	}
+
+	// Step 2: ACL entries of every module on the page
+	if relations.Permissions {
+		var entries []module.ModuleACLEntry
+		err := r.db.Where("module_id IN ?", ids).Order("module_id, principal").Find(&entries).Error
+		if err != nil {
+			return err
+		}
+		for i := range entities {
+			entities[i].Permissions = []module.ModuleACLEntry{}
+		}
+		for _, entry := range entries {
+			byID[entry.ModuleID].Permissions = append(byID[entry.ModuleID].Permissions, entry)
+		}
+	}
+	return nil
+}
+
+// filtered starts a module query restricted by the filter.
+//
+// Query Implementation (tag filter):
+//
+//	SELECT modules.* FROM modules
+//	JOIN module_tags ON module_tags.module_id = modules.id
+//	JOIN tags ON tags.id = module_tags.tag_id AND tags.name = ?
+//
+// The (module_id, tag_id) primary key guarantees at most one row per module,
+// so the join never duplicates modules.
+//
+// Query Implementation (access filter):
+//
+//	WHERE (NOT EXISTS (SELECT 1 FROM module_acl WHERE module_acl.module_id = modules.id)
+//	    OR EXISTS (SELECT 1 FROM module_acl WHERE module_acl.module_id = modules.id
+//	               AND module_acl.principal IN (?)))
+//
+// Both subqueries are answered by the (module_id, principal) primary key.
+//
+// Query Implementation (status filter):
+//
+//	WHERE modules.status = ?
+//
+// Query Implementation (starred filter):
+//
+//	WHERE EXISTS (SELECT 1 FROM module_stars WHERE module_stars.module_id = modules.id
+//	              AND module_stars.user_name = ?)
+func (r *ModuleRepository) filtered(filter module.ModuleFilter) *gorm.DB {
+	query := r.db.Model(&module.Module{})
+	if filter.Tag != "" {
+		query = query.
+			Joins("JOIN module_tags ON module_tags.module_id = modules.id").
+			Joins("JOIN tags ON tags.id = module_tags.tag_id AND tags.name = ?", filter.Tag)
+	}
+	if filter.Status != "" {
+		query = query.Where("modules.status = ?", filter.Status)
+	}
+	if filter.ActiveOnly {
+		query = query.Where("modules.is_active = ?", true)
+	}
+	if filter.StarredBy != "" {
+		query = query.Where(
+			"EXISTS (SELECT 1 FROM module_stars WHERE module_stars.module_id = modules.id AND module_stars.user_name = ?)",
+			filter.StarredBy,
+		)
+	}
+	if filter.VisibleTo != nil {
+		query = query.Where(
+			"(NOT EXISTS (SELECT 1 FROM module_acl WHERE module_acl.module_id = modules.id)"+
+				" OR EXISTS (SELECT 1 FROM module_acl WHERE module_acl.module_id = modules.id AND module_acl.principal IN ?))",
+			filter.VisibleTo,
+		)
+	}
+	return query
+}
+
+// toPointers converts query results to the pointer slice used by the domain layer.
+func toPointers(entities []module.Module) []*module.Module {
+	result := make([]*module.Module, len(entities))
+	for i := range entities {
+		result[i] = &entities[i]
+	}
+	return result
+}
+
+// FindDueScheduledModules retrieves modules with a scheduled transition that has come.
+//
+// Parameters:
+//   - now: Reference time
+//   - limit: Maximum number of rows to return
+//
+// Returns:
+//   - []*module.Module: Due modules ordered by ID
+//   - error: Error if database query fails
+//
+// Query Implementation:
+//
+//	SELECT * FROM modules WHERE (activate_at <= ? AND status = 'approved') OR deactivate_at <= ?
+//	ORDER BY id LIMIT ?
+//
+// Performance Notes:
+//   - Both columns are indexed, so the OR can be answered with two index scans
+func (r *ModuleRepository) FindDueScheduledModules(now time.Time, limit int) ([]*module.Module, error) {
+	var entities []module.Module
+	err := r.db.Where("(activate_at <= ? AND status = ?) OR deactivate_at <= ?", now, module.StatusApproved, now).
+		Order("id").
+		Limit(limit).
+		Find(&entities).Error
+	if err != nil {
+		return nil, err
+	}
+	return toPointers(entities), nil
+}
+
+// CountModuleNames counts the names reserved by modules, including those in
+// the recycle bin.
+//
+// Parameters:
+//   - ctx: Context cancelling the query
+//
+// Returns:
+//   - int64: Number of reserved names
+//   - error: Error if database query fails
+func (r *ModuleRepository) CountModuleNames(ctx context.Context) (int64, error) {
+	var count int64
+	err := db.WithQueryTimeout(r.db, maintenanceQueryTimeout).WithContext(ctx).Unscoped().Model(&module.Module{}).Count(&count).Error
+	return count, err
+}
+
+// EachModuleName streams the lowercase names reserved by modules, including
+// those in the recycle bin.
+//
+// Parameters:
+//   - ctx: Context cancelling the query
+//   - fn: Called with every name
+//
+// Returns:
+//   - error: Error if database query fails
+//
+// Query Implementation:
+//
+//	SELECT LOWER(name) FROM modules
+//
+// Rows are read one at a time, so large tables are never loaded as a whole;
+// only ctx bounds reading them, not the statement timeout.
+func (r *ModuleRepository) EachModuleName(ctx context.Context, fn func(name string)) error {
+	rows, err := db.WithQueryTimeout(r.db, 0).WithContext(ctx).Unscoped().Model(&module.Module{}).Select("LOWER(name)").Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+		fn(name)
+	}
+	return rows.Err()
+}
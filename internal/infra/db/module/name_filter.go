@@ -0,0 +1,254 @@
+package module
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strings"
+	"sync"
+
+	"go_di_architecture/internal/domain/models/module"
+	moduleService "go_di_architecture/internal/domain/service/module"
+)
+
+// nameFilterStats are the counters of the module name filter, exported as the
+// "module_name_filter" expvar:
+//   - skipped: Uniqueness checks answered without a query
+//   - queried: Uniqueness checks passed on to the database
+//   - refreshes: Rebuilds of the filter from the database
+var nameFilterStats = expvar.NewMap("module_name_filter")
+
+// nameFilterFalsePositiveRate is the share of new names the filter reports as
+// possibly taken, which then costs the regular query.
+const nameFilterFalsePositiveRate = 0.01
+
+// nameFilterMinCapacity keeps small tables from getting a filter that
+// saturates after a few creations.
+const nameFilterMinCapacity = 1024
+
+// NameFilter is a Bloom filter of the lowercase names reserved by modules.
+//
+// It answers "definitely not taken" or "possibly taken". Names are only ever
+// added, so renamed and purged modules leave stale entries behind; they cause
+// extra queries, never wrong answers, and are dropped by the next refresh.
+// Until the first refresh every name is reported as possibly taken.
+type NameFilter struct {
+	source *ModuleRepository
+
+	mu    sync.RWMutex
+	bloom *bloomFilter
+
+	// Filter being rebuilt; names added meanwhile go into both
+	building *bloomFilter
+}
+
+// NewNameFilter creates an empty filter of the names in a module table.
+//
+// Parameters:
+//   - source: Repository the filter is rebuilt from
+//
+// Returns:
+//   - *NameFilter: A filter reporting every name as possibly taken until refreshed
+func NewNameFilter(source *ModuleRepository) *NameFilter {
+	return &NameFilter{source: source}
+}
+
+// Refresh rebuilds the filter from the database.
+//
+// The filter is sized for twice the current number of names, so it keeps its
+// false positive rate while the table grows until the next refresh.
+//
+// Parameters:
+//   - ctx: Context cancelling the queries
+//
+// Returns:
+//   - error: Error if a query fails (the previous filter stays in use)
+func (f *NameFilter) Refresh(ctx context.Context) error {
+	count, err := f.source.CountModuleNames(ctx)
+	if err != nil {
+		return err
+	}
+
+	building := newBloomFilter(max(2*int(count), nameFilterMinCapacity), nameFilterFalsePositiveRate)
+	f.mu.Lock()
+	f.building = building
+	f.mu.Unlock()
+
+	err = f.source.EachModuleName(ctx, func(name string) {
+		f.mu.Lock()
+		building.add(name)
+		f.mu.Unlock()
+	})
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.building = nil
+	if err != nil {
+		return err
+	}
+	f.bloom = building
+	nameFilterStats.Add("refreshes", 1)
+	return nil
+}
+
+// MayContain reports whether a name is possibly taken.
+func (f *NameFilter) MayContain(name string) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.bloom == nil || f.bloom.contains(normalizeName(name))
+}
+
+// Add records a name that was just written.
+func (f *NameFilter) Add(name string) {
+	name = normalizeName(name)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.bloom != nil {
+		f.bloom.add(name)
+	}
+	if f.building != nil {
+		f.building.add(name)
+	}
+}
+
+// normalizeName applies the comparison used by the unique name index.
+func normalizeName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// NameFilteredModuleRepository skips name uniqueness queries for names the
+// filter has never seen.
+//
+// A module created by another instance since the last refresh is missing from
+// the filter; creating a second module with its name then fails on the unique
+// index, which the repository reports as ErrNameExists like the query would.
+//
+// Usage Context:
+//
+//	sql := NewModuleRepository(database, db.AutoIncrement{})
+//	repo := NewNameFilteredModuleRepository(sql, NewNameFilter(sql))
+type NameFilteredModuleRepository struct {
+	moduleService.ModuleRepository
+
+	filter *NameFilter
+}
+
+// NewNameFilteredModuleRepository puts a name filter in front of a repository.
+//
+// Parameters:
+//   - repo: Repository receiving all calls the filter cannot answer
+//   - filter: Filter of the names in the repository's table
+//
+// Returns:
+//   - *NameFilteredModuleRepository: The filtering repository
+func NewNameFilteredModuleRepository(repo moduleService.ModuleRepository, filter *NameFilter) *NameFilteredModuleRepository {
+	return &NameFilteredModuleRepository{ModuleRepository: repo, filter: filter}
+}
+
+// IsModuleNameExists answers false for names the filter has never seen and
+// queries the database otherwise.
+func (r *NameFilteredModuleRepository) IsModuleNameExists(name string, excludeId int) (bool, error) {
+	if !r.filter.MayContain(name) {
+		nameFilterStats.Add("skipped", 1)
+		return false, nil
+	}
+	nameFilterStats.Add("queried", 1)
+	return r.ModuleRepository.IsModuleNameExists(name, excludeId)
+}
+
+// CreateModule creates the module and adds its name to the filter.
+func (r *NameFilteredModuleRepository) CreateModule(m *module.Module) (*module.Module, error) {
+	created, err := r.ModuleRepository.CreateModule(m)
+	if created != nil {
+		r.filter.Add(created.Name)
+	}
+	return created, err
+}
+
+// UpdateModule updates the module and adds its possibly new name to the filter.
+func (r *NameFilteredModuleRepository) UpdateModule(m *module.Module) (*module.Module, error) {
+	updated, err := r.ModuleRepository.UpdateModule(m)
+	if updated != nil {
+		r.filter.Add(updated.Name)
+	}
+	return updated, err
+}
+
+// WithContext returns a repository sharing the filter whose queries run with
+// a context, when the wrapped repository supports it.
+//
+// Parameters:
+//   - ctx: Context of the queries
+//
+// Returns:
+//   - moduleService.ModuleRepository: The context-bound filtering repository
+func (r *NameFilteredModuleRepository) WithContext(ctx context.Context) moduleService.ModuleRepository {
+	repo, ok := r.ModuleRepository.(moduleService.ContextRepository)
+	if !ok {
+		return r
+	}
+	return NewNameFilteredModuleRepository(repo.WithContext(ctx), r.filter)
+}
+
+// WithTx runs a transaction of the filtered repository with the filter in
+// front of its module repository. Names of rolled back changes stay in the
+// filter, costing a name query like any other false positive.
+func (r *NameFilteredModuleRepository) WithTx(fn func(repo moduleService.ModuleRepository, revisions moduleService.RevisionRepository) error) error {
+	tx, ok := r.ModuleRepository.(moduleService.Transactor)
+	if !ok {
+		return fmt.Errorf("%T does not support transactions", r.ModuleRepository)
+	}
+	return tx.WithTx(func(repo moduleService.ModuleRepository, revisions moduleService.RevisionRepository) error {
+		return fn(NewNameFilteredModuleRepository(repo, r.filter), revisions)
+	})
+}
+
+// bloomFilter is a fixed-size Bloom filter of strings using double hashing.
+type bloomFilter struct {
+	bits   []uint64
+	size   uint64
+	hashes uint64
+}
+
+// newBloomFilter sizes a filter for capacity entries at the given false
+// positive rate.
+func newBloomFilter(capacity int, falsePositiveRate float64) *bloomFilter {
+	size := uint64(math.Ceil(-float64(capacity) * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2)))
+	hashes := uint64(math.Max(1, math.Round(float64(size)/float64(capacity)*math.Ln2)))
+	return &bloomFilter{
+		bits:   make([]uint64, (size+63)/64),
+		size:   size,
+		hashes: hashes,
+	}
+}
+
+// add sets the bits of a value.
+func (b *bloomFilter) add(value string) {
+	h1, h2 := bloomHashes(value)
+	for i := uint64(0); i < b.hashes; i++ {
+		bit := (h1 + i*h2) % b.size
+		b.bits[bit/64] |= 1 << (bit % 64)
+	}
+}
+
+// contains reports whether all bits of a value are set.
+func (b *bloomFilter) contains(value string) bool {
+	h1, h2 := bloomHashes(value)
+	for i := uint64(0); i < b.hashes; i++ {
+		bit := (h1 + i*h2) % b.size
+		if b.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// bloomHashes derives the two base hashes of a value from one FNV-1a hash.
+func bloomHashes(value string) (uint64, uint64) {
+	h := fnv.New64a()
+	h.Write([]byte(value))
+	sum := h.Sum64()
+	return sum & 0xffffffff, sum>>32 | 1
+}
@@ -0,0 +1,76 @@
+package module
+
+import (
+	"go_di_architecture/internal/domain/models/module"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func (r *InMemoryModuleRepository) AddNote(note *module.ModuleNote) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	note.ID = r.noteAutoIncrementID
+	r.noteAutoIncrementID++
+
+	stored := *note
+	r.notes[note.ModuleID] = append(r.notes[note.ModuleID], &stored)
+	return nil
+}
+
+func (r *InMemoryModuleRepository) ListNotes(moduleID, offset, limit int) ([]*module.ModuleNote, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// Notes are appended in creation order; walk backwards for newest first
+	visible := make([]*module.ModuleNote, 0)
+	notes := r.notes[moduleID]
+	for i := len(notes) - 1; i >= 0; i-- {
+		if !notes[i].DeletedAt.Valid {
+			visible = append(visible, notes[i])
+		}
+	}
+
+	total := int64(len(visible))
+	if offset >= len(visible) {
+		return []*module.ModuleNote{}, total, nil
+	}
+	end := offset + limit
+	if end > len(visible) {
+		end = len(visible)
+	}
+
+	page := make([]*module.ModuleNote, 0, end-offset)
+	for _, note := range visible[offset:end] {
+		copied := *note
+		page = append(page, &copied)
+	}
+	return page, total, nil
+}
+
+func (r *InMemoryModuleRepository) GetNote(moduleID, noteID int) (*module.ModuleNote, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, note := range r.notes[moduleID] {
+		if note.ID == noteID && !note.DeletedAt.Valid {
+			copied := *note
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *InMemoryModuleRepository) DeleteNote(moduleID, noteID int, actor string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, note := range r.notes[moduleID] {
+		if note.ID == noteID && !note.DeletedAt.Valid {
+			note.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+			note.DeletedBy = actor
+		}
+	}
+	return nil
+}
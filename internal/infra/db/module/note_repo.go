@@ -0,0 +1,120 @@
+package module
+
+import (
+	"errors"
+	"time"
+
+	"go_di_architecture/internal/domain/models/module"
+
+	"gorm.io/gorm"
+)
+
+// NoteRepository implements data operations for module notes.
+//
+// Notes are rows in the module_notes table. Deleted notes keep their row with
+// deleted_at and deleted_by set; GORM's soft-delete scope hides them from
+// every query below.
+//
+// Usage Context:
+//
+//	repo := NewNoteRepository(db)
+//	notes, total, err := repo.ListNotes(123, 0, 20)
+type NoteRepository struct {
+	db *gorm.DB
+}
+
+// NewNoteRepository creates a repository with a specific database connection.
+//
+// Parameters:
+//   - db: Database connection to use
+//
+// Returns:
+//   - *NoteRepository: A new repository instance using the provided connection
+func NewNoteRepository(db *gorm.DB) *NoteRepository {
+	return &NoteRepository{db: db}
+}
+
+// AddNote stores a new note.
+//
+// Parameters:
+//   - note: Note to store; ID is filled in
+//
+// Returns:
+//   - error: Error if the insert fails
+func (r *NoteRepository) AddNote(note *module.ModuleNote) error {
+	return r.db.Create(note).Error
+}
+
+// ListNotes retrieves one page of a module's notes.
+//
+// Parameters:
+//   - moduleID: Identifier of the module
+//   - offset: Number of notes to skip
+//   - limit: Maximum number of notes to return
+//
+// Returns:
+//   - []*module.ModuleNote: Notes that are not deleted, newest first
+//   - int64: Total number of notes that are not deleted
+//   - error: Error if database query fails
+//
+// Query Implementation:
+//
+//	SELECT COUNT(*) FROM module_notes WHERE module_id = ? AND deleted_at IS NULL
+//	SELECT * FROM module_notes WHERE module_id = ? AND deleted_at IS NULL
+//	    ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?
+func (r *NoteRepository) ListNotes(moduleID, offset, limit int) ([]*module.ModuleNote, int64, error) {
+	query := r.db.Model(&module.ModuleNote{}).Where("module_id = ?", moduleID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var notes []*module.ModuleNote
+	err := query.Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&notes).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return notes, total, nil
+}
+
+// GetNote retrieves a note of a module that is not deleted.
+//
+// Parameters:
+//   - moduleID: Identifier of the module
+//   - noteID: Identifier of the note
+//
+// Returns:
+//   - *module.ModuleNote: The note, or nil if not found
+//   - error: Error if database query fails
+func (r *NoteRepository) GetNote(moduleID, noteID int) (*module.ModuleNote, error) {
+	var note module.ModuleNote
+	err := r.db.Where("module_id = ? AND id = ?", moduleID, noteID).First(&note).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &note, nil
+}
+
+// DeleteNote soft-deletes a note.
+//
+// Parameters:
+//   - moduleID: Identifier of the module
+//   - noteID: Identifier of the note
+//   - actor: Who deletes the note
+//
+// Returns:
+//   - error: Error if the update fails
+//
+// Query Implementation:
+//
+//	UPDATE module_notes SET deleted_at = ?, deleted_by = ?
+//	WHERE module_id = ? AND id = ? AND deleted_at IS NULL
+func (r *NoteRepository) DeleteNote(moduleID, noteID int, actor string) error {
+	return r.db.Model(&module.ModuleNote{}).
+		Where("module_id = ? AND id = ?", moduleID, noteID).
+		Updates(map[string]interface{}{"deleted_at": time.Now(), "deleted_by": actor}).Error
+}
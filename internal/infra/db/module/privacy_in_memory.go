@@ -0,0 +1,222 @@
+package module
+
+import (
+	"go_di_architecture/internal/domain/models/module"
+	"go_di_architecture/internal/domain/models/privacy"
+	"sort"
+	"time"
+)
+
+func (r *InMemoryModuleRepository) FindUserData(user string) (*privacy.UserRecords, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	records := &privacy.UserRecords{}
+	for _, modules := range []map[int]*module.Module{r.data, r.trash} {
+		for _, m := range modules {
+			if m.Owner == user {
+				records.OwnedModules = append(records.OwnedModules, *snapshot(m))
+			}
+			if m.DeletedBy == user {
+				records.DeletedModules = append(records.DeletedModules, *snapshot(m))
+			}
+		}
+	}
+	sort.Slice(records.OwnedModules, func(i, j int) bool { return records.OwnedModules[i].ID < records.OwnedModules[j].ID })
+	sort.Slice(records.DeletedModules, func(i, j int) bool { return records.DeletedModules[i].ID < records.DeletedModules[j].ID })
+
+	for _, moduleID := range sortedRevisionModuleIDs(r.revisions) {
+		for _, revision := range r.revisions[moduleID] {
+			_, named, err := privacy.ReplaceOwner(revision.Changes, user, user)
+			if err != nil {
+				return nil, err
+			}
+			if named || revision.Actor == user {
+				records.Revisions = append(records.Revisions, *revision)
+			}
+		}
+	}
+
+	for _, transfer := range r.transfers {
+		if transfer.FromOwner == user || transfer.ToOwner == user || transfer.RequestedBy == user {
+			records.Transfers = append(records.Transfers, *transfer)
+		}
+	}
+	sort.Slice(records.Transfers, func(i, j int) bool { return records.Transfers[i].ID < records.Transfers[j].ID })
+
+	principal := module.PrincipalUserPrefix + user
+	for _, entries := range r.acl {
+		for _, entry := range entries {
+			if entry.Principal == principal {
+				records.AccessGrants = append(records.AccessGrants, entry)
+			}
+		}
+	}
+	sort.Slice(records.AccessGrants, func(i, j int) bool { return records.AccessGrants[i].ModuleID < records.AccessGrants[j].ModuleID })
+
+	for _, moduleID := range sortedNoteModuleIDs(r.notes) {
+		for _, note := range r.notes[moduleID] {
+			if note.Author == user {
+				records.Notes = append(records.Notes, *note)
+			}
+		}
+	}
+	sort.Slice(records.Notes, func(i, j int) bool { return records.Notes[i].ID < records.Notes[j].ID })
+
+	for _, moduleID := range sortedStarModuleIDs(r.stars) {
+		if starredAt, starred := r.stars[moduleID][user]; starred {
+			records.Stars = append(records.Stars, module.ModuleStar{ModuleID: moduleID, User: user, CreatedAt: starredAt})
+		}
+	}
+
+	for _, record := range r.archive {
+		if record.Owner == user {
+			records.ArchivedModules = append(records.ArchivedModules, record)
+		}
+	}
+	return records, nil
+}
+
+func (r *InMemoryModuleRepository) EraseUser(user, pseudonym string, at time.Time) (*privacy.ErasureCounts, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Rewrite revision diffs first: a corrupt diff must not leave a partial erasure
+	rewritten := make(map[*module.ModuleRevision]string)
+	for _, revisions := range r.revisions {
+		for _, revision := range revisions {
+			changes, named, err := privacy.ReplaceOwner(revision.Changes, user, pseudonym)
+			if err != nil {
+				return nil, err
+			}
+			if named {
+				rewritten[revision] = changes
+			}
+		}
+	}
+
+	counts := &privacy.ErasureCounts{RevisionChanges: len(rewritten)}
+	for revision, changes := range rewritten {
+		revision.Changes = changes
+	}
+	for _, revisions := range r.revisions {
+		for _, revision := range revisions {
+			if revision.Actor == user {
+				revision.Actor = pseudonym
+				counts.RevisionActors++
+			}
+		}
+	}
+
+	// Stored modules are replaced, not mutated, since readers may hold the old pointer
+	for _, modules := range []map[int]*module.Module{r.data, r.trash} {
+		for id, m := range modules {
+			if m.Owner != user && m.DeletedBy != user {
+				continue
+			}
+			erased := *m
+			if erased.Owner == user {
+				erased.Owner = pseudonym
+				counts.OwnedModules++
+			}
+			if erased.DeletedBy == user {
+				erased.DeletedBy = pseudonym
+				counts.DeletedModules++
+			}
+			modules[id] = &erased
+		}
+	}
+
+	for _, transfer := range r.transfers {
+		if transfer.FromOwner != user && transfer.ToOwner != user && transfer.RequestedBy != user {
+			continue
+		}
+		counts.Transfers++
+		if transfer.ToOwner == user && transfer.Status == module.TransferPending {
+			resolvedAt := at
+			transfer.Status = module.TransferExpired
+			transfer.ResolvedAt = &resolvedAt
+			counts.ExpiredTransfers++
+		}
+		if transfer.FromOwner == user {
+			transfer.FromOwner = pseudonym
+		}
+		if transfer.ToOwner == user {
+			transfer.ToOwner = pseudonym
+		}
+		if transfer.RequestedBy == user {
+			transfer.RequestedBy = pseudonym
+		}
+	}
+
+	principal := module.PrincipalUserPrefix + user
+	for _, entries := range r.acl {
+		for i := range entries {
+			if entries[i].Principal == principal {
+				entries[i].Principal = module.PrincipalUserPrefix + pseudonym
+				counts.AccessGrants++
+			}
+		}
+	}
+
+	for _, notes := range r.notes {
+		for _, note := range notes {
+			if note.Author != user && note.DeletedBy != user {
+				continue
+			}
+			counts.Notes++
+			if note.Author == user {
+				note.Author = pseudonym
+			}
+			if note.DeletedBy == user {
+				note.DeletedBy = pseudonym
+			}
+		}
+	}
+
+	for _, users := range r.stars {
+		if starredAt, starred := users[user]; starred {
+			delete(users, user)
+			users[pseudonym] = starredAt
+			counts.Stars++
+		}
+	}
+
+	for i := range r.archive {
+		if r.archive[i].Owner == user {
+			r.archive[i].Owner = pseudonym
+			counts.ArchivedModules++
+		}
+	}
+	return counts, nil
+}
+
+// sortedNoteModuleIDs returns the IDs of modules with notes in ascending order.
+func sortedNoteModuleIDs(notes map[int][]*module.ModuleNote) []int {
+	ids := make([]int, 0, len(notes))
+	for id := range notes {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// sortedStarModuleIDs returns the IDs of starred modules in ascending order.
+func sortedStarModuleIDs(stars map[int]map[string]time.Time) []int {
+	ids := make([]int, 0, len(stars))
+	for id := range stars {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// sortedRevisionModuleIDs returns the IDs of modules with revisions in ascending order.
+func sortedRevisionModuleIDs(revisions map[int][]*module.ModuleRevision) []int {
+	ids := make([]int, 0, len(revisions))
+	for id := range revisions {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
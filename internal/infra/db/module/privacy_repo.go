@@ -0,0 +1,274 @@
+package module
+
+import (
+	"encoding/json"
+	"time"
+
+	"go_di_architecture/internal/domain/models/module"
+	"go_di_architecture/internal/domain/models/privacy"
+
+	"gorm.io/gorm"
+)
+
+// UserDataRepository implements data operations on records linked to a user.
+//
+// Users are matched by exact actor name. Owner changes inside revision diffs
+// are found with a LIKE pre-filter on the JSON-encoded name and then checked
+// by decoding the diff.
+//
+// Usage Context:
+//
+//	repo := NewUserDataRepository(db)
+//	records, err := repo.FindUserData("jane")
+type UserDataRepository struct {
+	db *gorm.DB
+
+	// Receives the IDs of the modules an erasure changed, after the commit
+	modulesChanged func(ids []int)
+}
+
+// NewUserDataRepository creates a repository with a specific database connection.
+//
+// Parameters:
+//   - db: Database connection to use
+//
+// Returns:
+//   - *UserDataRepository: A new repository instance using the provided connection
+func NewUserDataRepository(db *gorm.DB) *UserDataRepository {
+	return &UserDataRepository{db: db}
+}
+
+// OnModulesChanged registers a function receiving the IDs of the modules an
+// erasure changed once it has committed, e.g. to drop them from the module
+// cache, which the erasure bypasses. Register it before the repository is used.
+//
+// Parameters:
+//   - changed: Called after each erasure changing modules with their IDs
+func (r *UserDataRepository) OnModulesChanged(changed func(ids []int)) {
+	r.modulesChanged = changed
+}
+
+// FindUserData retrieves every record linked to a user.
+//
+// Parameters:
+//   - user: The user to look for
+//
+// Returns:
+//   - *privacy.UserRecords: The linked records, each kind ordered by ID
+//   - error: Error if a query fails or a revision diff is corrupt
+//
+// Query Implementation:
+//
+//	SELECT * FROM modules WHERE owner = ?            -- including the recycle bin
+//	SELECT * FROM modules WHERE deleted_by = ?
+//	SELECT * FROM module_revisions WHERE actor = ? OR changes LIKE ? ESCAPE '!'
+//	SELECT * FROM module_transfers WHERE from_owner = ? OR to_owner = ? OR requested_by = ?
+//	SELECT * FROM module_acl WHERE principal = 'user:' || ?
+//	SELECT * FROM module_notes WHERE author = ?       -- including deleted notes
+//	SELECT * FROM module_stars WHERE user_name = ?
+//	SELECT * FROM module_archive WHERE owner = ?
+func (r *UserDataRepository) FindUserData(user string) (*privacy.UserRecords, error) {
+	records := &privacy.UserRecords{}
+
+	if err := r.db.Unscoped().Where("owner = ?", user).Order("id").Find(&records.OwnedModules).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Unscoped().Where("deleted_by = ?", user).Order("id").Find(&records.DeletedModules).Error; err != nil {
+		return nil, err
+	}
+
+	revisions, err := r.findMentioningRevisions(r.db, user, "actor = ? OR ", user)
+	if err != nil {
+		return nil, err
+	}
+	for _, revision := range revisions {
+		_, named, err := privacy.ReplaceOwner(revision.Changes, user, user)
+		if err != nil {
+			return nil, err
+		}
+		if named || revision.Actor == user {
+			records.Revisions = append(records.Revisions, revision)
+		}
+	}
+
+	err = r.db.Where("from_owner = ? OR to_owner = ? OR requested_by = ?", user, user, user).
+		Order("id").
+		Find(&records.Transfers).Error
+	if err != nil {
+		return nil, err
+	}
+	err = r.db.Where("principal = ?", module.PrincipalUserPrefix+user).
+		Order("module_id").
+		Find(&records.AccessGrants).Error
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.Unscoped().Where("author = ?", user).Order("id").Find(&records.Notes).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Where("user_name = ?", user).Order("module_id").Find(&records.Stars).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Where("owner = ?", user).Order("id").Find(&records.ArchivedModules).Error; err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// EraseUser replaces a user with a pseudonym in every record.
+//
+// Parameters:
+//   - user: The user to erase
+//   - pseudonym: The value replacing the user
+//   - at: Erasure time, recorded as resolution time of expired transfers
+//
+// Returns:
+//   - *privacy.ErasureCounts: Number of changed records per kind
+//   - error: Error if a query fails; nothing is changed then
+//
+// Query Implementation (one transaction):
+//
+//	UPDATE module_transfers SET status = 'expired', resolved_at = ? WHERE to_owner = ? AND status = 'pending'
+//	SELECT id FROM modules WHERE owner = ? OR deleted_by = ?
+//	UPDATE modules SET owner = ? WHERE owner = ?                -- updated_at is left alone
+//	UPDATE modules SET deleted_by = ? WHERE deleted_by = ?
+//	UPDATE module_revisions SET actor = ? WHERE actor = ?
+//	UPDATE module_revisions SET changes = ? WHERE id = ?        -- per revision naming the user as owner
+//	UPDATE module_transfers SET from_owner / to_owner / requested_by = ? WHERE ... = ?
+//	UPDATE module_acl SET principal = 'user:' || ? WHERE principal = 'user:' || ?
+//	UPDATE module_notes SET author / deleted_by = ? WHERE ... = ?
+//	UPDATE module_stars SET user_name = ? WHERE user_name = ?
+//	UPDATE module_archive SET owner = ? WHERE owner = ?
+func (r *UserDataRepository) EraseUser(user, pseudonym string, at time.Time) (*privacy.ErasureCounts, error) {
+	counts := &privacy.ErasureCounts{}
+	var moduleIDs []int
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Step 1: Expire pending transfers nobody can accept anymore
+		result := tx.Model(&module.ModuleTransfer{}).
+			Where("to_owner = ? AND status = ?", user, module.TransferPending).
+			UpdateColumns(map[string]interface{}{"status": module.TransferExpired, "resolved_at": at})
+		if result.Error != nil {
+			return result.Error
+		}
+		counts.ExpiredTransfers = int(result.RowsAffected)
+
+		// Step 2: Replace the user on modules without touching their last change
+		err := tx.Unscoped().Model(&module.Module{}).
+			Where("owner = ? OR deleted_by = ?", user, user).
+			Pluck("id", &moduleIDs).Error
+		if err != nil {
+			return err
+		}
+		affected, err := replaceColumn(tx.Unscoped().Model(&module.Module{}), "owner", user, pseudonym)
+		if err != nil {
+			return err
+		}
+		counts.OwnedModules = affected
+		if counts.DeletedModules, err = replaceColumn(tx.Unscoped().Model(&module.Module{}), "deleted_by", user, pseudonym); err != nil {
+			return err
+		}
+
+		// Step 3: Rewrite owner changes naming the user, then replace revision actors
+		revisions, err := r.findMentioningRevisions(tx, user, "")
+		if err != nil {
+			return err
+		}
+		for _, revision := range revisions {
+			changes, named, err := privacy.ReplaceOwner(revision.Changes, user, pseudonym)
+			if err != nil {
+				return err
+			}
+			if !named {
+				continue
+			}
+			if err := tx.Model(&module.ModuleRevision{}).Where("id = ?", revision.ID).UpdateColumn("changes", changes).Error; err != nil {
+				return err
+			}
+			counts.RevisionChanges++
+		}
+		if counts.RevisionActors, err = replaceColumn(tx.Model(&module.ModuleRevision{}), "actor", user, pseudonym); err != nil {
+			return err
+		}
+
+		// Step 4: Replace the user in transfers
+		var transferIDs []int
+		err = tx.Model(&module.ModuleTransfer{}).
+			Where("from_owner = ? OR to_owner = ? OR requested_by = ?", user, user, user).
+			Pluck("id", &transferIDs).Error
+		if err != nil {
+			return err
+		}
+		counts.Transfers = len(transferIDs)
+		for _, column := range []string{"from_owner", "to_owner", "requested_by"} {
+			if _, err := replaceColumn(tx.Model(&module.ModuleTransfer{}), column, user, pseudonym); err != nil {
+				return err
+			}
+		}
+
+		// Step 5: Replace the user in ACL entries, keeping restricted modules restricted
+		counts.AccessGrants, err = replaceColumn(tx.Model(&module.ModuleACLEntry{}), "principal",
+			module.PrincipalUserPrefix+user, module.PrincipalUserPrefix+pseudonym)
+		if err != nil {
+			return err
+		}
+
+		// Step 6: Replace the user in notes, including deleted ones
+		var noteIDs []int
+		err = tx.Unscoped().Model(&module.ModuleNote{}).
+			Where("author = ? OR deleted_by = ?", user, user).
+			Pluck("id", &noteIDs).Error
+		if err != nil {
+			return err
+		}
+		counts.Notes = len(noteIDs)
+		for _, column := range []string{"author", "deleted_by"} {
+			if _, err := replaceColumn(tx.Unscoped().Model(&module.ModuleNote{}), column, user, pseudonym); err != nil {
+				return err
+			}
+		}
+
+		// Step 7: Replace the user in stars
+		if counts.Stars, err = replaceColumn(tx.Model(&module.ModuleStar{}), "user_name", user, pseudonym); err != nil {
+			return err
+		}
+
+		// Step 8: Replace the user in the archive
+		counts.ArchivedModules, err = replaceColumn(tx.Model(&module.ModuleArchiveRecord{}), "owner", user, pseudonym)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if r.modulesChanged != nil && len(moduleIDs) > 0 {
+		r.modulesChanged(moduleIDs)
+	}
+	return counts, nil
+}
+
+// findMentioningRevisions loads the revisions whose changes contain the user
+// as a JSON string, optionally widened by an extra condition prepended to the
+// LIKE filter.
+func (r *UserDataRepository) findMentioningRevisions(db *gorm.DB, user, condition string, args ...interface{}) ([]module.ModuleRevision, error) {
+	encoded, err := json.Marshal(user)
+	if err != nil {
+		return nil, err
+	}
+
+	var revisions []module.ModuleRevision
+	args = append(args, "%"+likeEscaper.Replace(string(encoded))+"%")
+	err = db.Where(condition+"changes LIKE ? ESCAPE '!'", args...).
+		Order("module_id, revision").
+		Find(&revisions).Error
+	if err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+// replaceColumn sets a column to a new value where it holds the old one,
+// without updating timestamps.
+func replaceColumn(query *gorm.DB, column, old, replacement string) (int, error) {
+	result := query.Where(column+" = ?", old).UpdateColumn(column, replacement)
+	return int(result.RowsAffected), result.Error
+}
@@ -0,0 +1,171 @@
+package module
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	moduleService "go_di_architecture/internal/domain/service/module"
+	"go_di_architecture/internal/infra/db"
+)
+
+// TestQueryCounterCountsContextStatements checks the query counter counts
+// exactly the statements run with the tracked context, including those of
+// goroutines sharing it, and none run without it.
+func TestQueryCounterCountsContextStatements(t *testing.T) {
+	database := openSQLite(t)
+	counter := db.NewQueryCounter()
+	if err := counter.Register(database); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	repo := NewModuleRepository(database, db.AutoIncrement{})
+	created := mustCreate(t, repo, "Payments", testTime(0))
+	id := strconv.Itoa(created.ID)
+
+	ctx, stop := counter.Track(context.Background())
+	bound := repo.WithContext(ctx)
+
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if _, err := bound.GetModuleById(id); err != nil {
+				t.Errorf("tracked GetModuleById() error = %v", err)
+			}
+		}()
+		// Concurrent statements of other requests stay out of the tally
+		go func() {
+			defer wg.Done()
+			if _, err := repo.GetModuleById(id); err != nil {
+				t.Errorf("untracked GetModuleById() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if _, err := repo.IsModuleNameExists("Payments", 0); err != nil {
+		t.Fatalf("IsModuleNameExists() error = %v", err)
+	}
+
+	statements, repeated, repeats := stop()
+	if statements != 3 || repeats != 3 {
+		t.Errorf("stop() = %d statements, %d repeats, want 3 and 3", statements, repeats)
+	}
+	if !strings.Contains(repeated, "modules") {
+		t.Errorf("most repeated statement = %q, want the module lookup", repeated)
+	}
+}
+
+// TestNameFilteredModuleRepositoryCountsContextStatements checks the name
+// filter keeps the statements of a context-bound repository in the tally,
+// including those of its transactions.
+func TestNameFilteredModuleRepositoryCountsContextStatements(t *testing.T) {
+	database := openSQLite(t)
+	counter := db.NewQueryCounter()
+	if err := counter.Register(database); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	sql := NewModuleRepository(database, db.AutoIncrement{})
+	repo := NewNameFilteredModuleRepository(sql, NewNameFilter(sql))
+	created := mustCreate(t, repo, "Payments", testTime(0))
+
+	ctx, stop := counter.Track(context.Background())
+	bound := repo.WithContext(ctx)
+	if _, err := bound.GetModuleById(strconv.Itoa(created.ID)); err != nil {
+		t.Fatalf("GetModuleById() error = %v", err)
+	}
+	err := bound.(moduleService.Transactor).WithTx(func(tx moduleService.ModuleRepository, _ moduleService.RevisionRepository) error {
+		_, err := tx.SoftDeleteModule(created.ID, "alice", testTime(1))
+		return err
+	})
+	if err != nil {
+		t.Fatalf("WithTx() error = %v", err)
+	}
+	if statements, _, _ := stop(); statements != 2 {
+		t.Errorf("statements = %d, want the lookup and the delete", statements)
+	}
+}
+
+// TestCachedModuleRepositoryAttributesLoadToCaller checks a cache miss is
+// counted for the request whose lookup ran the load, the load survives that
+// request's cancellation, and later hits run no statement.
+func TestCachedModuleRepositoryAttributesLoadToCaller(t *testing.T) {
+	database := openSQLite(t)
+	counter := db.NewQueryCounter()
+	if err := counter.Register(database); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	repo := NewModuleRepository(database, db.AutoIncrement{})
+	created := mustCreate(t, repo, "Payments", testTime(0))
+	cached := NewCachedModuleRepository(repo, time.Minute, 0)
+
+	// A lookup of a request that already ended
+	requestCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	ctx, stop := counter.Track(requestCtx)
+	found, err := cached.WithContext(ctx).GetModuleById(strconv.Itoa(created.ID))
+	if err != nil || found == nil || found.ID != created.ID {
+		t.Fatalf("GetModuleById() = %+v, %v, want module %d", found, err, created.ID)
+	}
+	if statements, _, _ := stop(); statements != 1 {
+		t.Errorf("statements of the loading request = %d, want 1", statements)
+	}
+
+	ctx, stop = counter.Track(context.Background())
+	if _, err := cached.WithContext(ctx).GetModuleById(strconv.Itoa(created.ID)); err != nil {
+		t.Fatalf("GetModuleById() error = %v", err)
+	}
+	if statements, _, _ := stop(); statements != 0 {
+		t.Errorf("statements of a cache hit = %d, want 0", statements)
+	}
+}
+
+// TestStatementTracerReportsContextStatements checks the statement tracer
+// reports the statements run with the traced context, with placeholders, and
+// nothing run without it or after the trace stopped.
+func TestStatementTracerReportsContextStatements(t *testing.T) {
+	database := openSQLite(t)
+	tracer := db.NewStatementTracer()
+	if err := tracer.Register(database); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	repo := NewModuleRepository(database, db.AutoIncrement{})
+	created := mustCreate(t, repo, "Payments", testTime(0))
+
+	var statements []string
+	ctx, stop := tracer.Trace(context.Background(), func(sql string, start time.Time, elapsed time.Duration, rows int64, err error) {
+		statements = append(statements, sql)
+	})
+	bound := repo.WithContext(ctx)
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := bound.IsModuleNameExists("Payments", 0); err != nil {
+				t.Errorf("tracked IsModuleNameExists() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if _, err := repo.GetModuleById(strconv.Itoa(created.ID)); err != nil {
+		t.Fatalf("untracked GetModuleById() error = %v", err)
+	}
+	stop()
+	if _, err := bound.GetModuleById(strconv.Itoa(created.ID)); err != nil {
+		t.Fatalf("GetModuleById() after stop error = %v", err)
+	}
+
+	if len(statements) != 4 {
+		t.Fatalf("traced statements = %q, want the 4 tracked lookups", statements)
+	}
+	for _, sql := range statements {
+		if strings.Contains(sql, "Payments") || !strings.Contains(sql, "?") {
+			t.Errorf("traced statement %q, want placeholders instead of values", sql)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package module
+
+import (
+	"go_di_architecture/internal/domain/models/quota"
+)
+
+func (r *InMemoryModuleRepository) AddAPIKeyUsage(counts []quota.APIKeyUsage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, count := range counts {
+		months, ok := r.apiKeyUsage[count.APIKey]
+		if !ok {
+			months = make(map[string]int64)
+			r.apiKeyUsage[count.APIKey] = months
+		}
+		months[count.Month] += count.Requests
+	}
+	return nil
+}
+
+func (r *InMemoryModuleRepository) GetAPIKeyUsage(apiKey, month string) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.apiKeyUsage[apiKey][month], nil
+}
@@ -0,0 +1,90 @@
+package module
+
+import (
+	"errors"
+
+	"go_di_architecture/internal/domain/models/quota"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// QuotaRepository implements the data operations of the API key request counters.
+//
+// Counters are incremented with an upsert, so instances flushing the same key
+// and month concurrently add up instead of overwriting each other.
+//
+// Usage Context:
+//
+//	repo := NewQuotaRepository(db)
+//	requests, err := repo.GetAPIKeyUsage("ci", "2023-08")
+type QuotaRepository struct {
+	db *gorm.DB
+}
+
+// NewQuotaRepository creates a repository with a specific database connection.
+//
+// Parameters:
+//   - db: Database connection to use
+//
+// Returns:
+//   - *QuotaRepository: A new repository instance using the provided connection
+func NewQuotaRepository(db *gorm.DB) *QuotaRepository {
+	return &QuotaRepository{db: db}
+}
+
+// AddAPIKeyUsage adds counts to the stored counters.
+//
+// Parameters:
+//   - counts: Requests to add per API key and month
+//
+// Returns:
+//   - error: Error if a statement fails (nothing is added in that case)
+//
+// Query Implementation (one transaction, per counter):
+//
+//	INSERT INTO api_key_usage (api_key, month, request_count) VALUES (?, ?, ?)
+//	ON CONFLICT (api_key, month) DO UPDATE SET request_count = api_key_usage.request_count + ?
+//	-- MySQL: ON DUPLICATE KEY UPDATE
+func (r *QuotaRepository) AddAPIKeyUsage(counts []quota.APIKeyUsage) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, count := range counts {
+			counter := count
+			err := tx.Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "api_key"}, {Name: "month"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{
+					"request_count": gorm.Expr("api_key_usage.request_count + ?", counter.Requests),
+				}),
+			}).Create(&counter).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetAPIKeyUsage retrieves the stored number of requests of a key in a month.
+//
+// Parameters:
+//   - apiKey: Name of the API key
+//   - month: The month (YYYY-MM)
+//
+// Returns:
+//   - int64: Number of requests, zero when no counter exists
+//   - error: Error if the query fails
+//
+// Query Implementation:
+//
+//	SELECT * FROM api_key_usage WHERE api_key = ? AND month = ? LIMIT 1
+func (r *QuotaRepository) GetAPIKeyUsage(apiKey, month string) (int64, error) {
+	var counter quota.APIKeyUsage
+	err := r.db.Where("api_key = ? AND month = ?", apiKey, month).First(&counter).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return counter.Requests, nil
+}
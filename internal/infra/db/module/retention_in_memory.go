@@ -0,0 +1,157 @@
+package module
+
+import (
+	"encoding/json"
+	"go_di_architecture/internal/domain/models/module"
+	"go_di_architecture/internal/domain/models/retention"
+	"sort"
+	"time"
+)
+
+func (r *InMemoryModuleRepository) CountExpiredRevisions(cutoff time.Time) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := 0
+	for _, revisions := range r.revisions {
+		count += len(expiredRevisions(revisions, cutoff))
+	}
+	return count, nil
+}
+
+func (r *InMemoryModuleRepository) PurgeExpiredRevisions(cutoff time.Time, limit int) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Visit modules in ID order so batches are deterministic
+	moduleIDs := make([]int, 0, len(r.revisions))
+	for moduleID := range r.revisions {
+		moduleIDs = append(moduleIDs, moduleID)
+	}
+	sort.Ints(moduleIDs)
+
+	deleted := 0
+	for _, moduleID := range moduleIDs {
+		if deleted == limit {
+			break
+		}
+		revisions := r.revisions[moduleID]
+		expired := expiredRevisions(revisions, cutoff)
+		if len(expired) > limit-deleted {
+			expired = expired[:limit-deleted]
+		}
+		if len(expired) == 0 {
+			continue
+		}
+
+		drop := make(map[*module.ModuleRevision]bool, len(expired))
+		for _, revision := range expired {
+			drop[revision] = true
+		}
+		kept := revisions[:0:0]
+		for _, revision := range revisions {
+			if !drop[revision] {
+				kept = append(kept, revision)
+			}
+		}
+		r.revisions[moduleID] = kept
+		deleted += len(expired)
+	}
+	return deleted, nil
+}
+
+func (r *InMemoryModuleRepository) ListArchiveCandidates(cutoff time.Time) ([]retention.ArchiveCandidate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	candidates := make([]retention.ArchiveCandidate, 0)
+	for id, m := range r.data {
+		if !isArchivable(m, cutoff) {
+			continue
+		}
+		candidates = append(candidates, retention.ArchiveCandidate{
+			ID:         id,
+			UpdatedAt:  m.UpdatedAt,
+			Dependents: r.countDependents(id),
+		})
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].ID < candidates[j].ID })
+	return candidates, nil
+}
+
+func (r *InMemoryModuleRepository) ArchiveModule(id int, cutoff, at time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	m, exists := r.data[id]
+	if !exists || !isArchivable(m, cutoff) || r.countDependents(id) > 0 {
+		return false, nil
+	}
+
+	// Copy the module with its tags and settings to the archive
+	tagNames := []string{}
+	for tagID := range r.moduleTags[id] {
+		if t, exists := r.tags[tagID]; exists {
+			tagNames = append(tagNames, t.Name)
+		}
+	}
+	sort.Strings(tagNames)
+	settings := make(module.ModuleSettings, len(r.settings[id]))
+	for key, setting := range r.settings[id] {
+		settings[key] = json.RawMessage(setting.Value)
+	}
+	record, err := newArchiveRecord(m, tagNames, settings, at)
+	if err != nil {
+		return false, err
+	}
+	record.ID = r.archiveAutoIncrementID
+	r.archiveAutoIncrementID++
+	r.archive = append(r.archive, *record)
+
+	// Remove the module and everything attached to it
+	r.releaseName(m)
+	delete(r.data, id)
+	delete(r.moduleTags, id)
+	delete(r.dependencies, id)
+	delete(r.settings, id)
+	delete(r.revisions, id)
+	delete(r.notes, id)
+	delete(r.stars, id)
+	delete(r.acl, id)
+	delete(r.usage, id)
+	for transferID, transfer := range r.transfers {
+		if transfer.ModuleID == id {
+			delete(r.transfers, transferID)
+		}
+	}
+	return true, nil
+}
+
+// expiredRevisions returns the revisions of one module created before the
+// cutoff, except the latest one.
+func expiredRevisions(revisions []*module.ModuleRevision, cutoff time.Time) []*module.ModuleRevision {
+	var expired []*module.ModuleRevision
+	for i, revision := range revisions {
+		if i < len(revisions)-1 && revision.CreatedAt.Before(cutoff) {
+			expired = append(expired, revision)
+		}
+	}
+	return expired
+}
+
+// isArchivable reports whether the archive rule applies to a module.
+func isArchivable(m *module.Module, cutoff time.Time) bool {
+	return !m.IsActive && m.ActivateAt == nil && m.UpdatedAt.Before(cutoff)
+}
+
+// countDependents returns the number of modules depending on a module; the
+// caller must hold the lock.
+func (r *InMemoryModuleRepository) countDependents(id int) int {
+	count := 0
+	for _, dependsOn := range r.dependencies {
+		if dependsOn[id] {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,296 @@
+package module
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"go_di_architecture/internal/domain/models/module"
+	"go_di_architecture/internal/domain/models/retention"
+	"go_di_architecture/internal/domain/models/tag"
+	"go_di_architecture/internal/infra/db"
+
+	"gorm.io/gorm"
+)
+
+// expiredRevisionCondition matches revisions older than the cutoff except the
+// latest revision of each module, which later revisions are numbered from.
+const expiredRevisionCondition = `created_at < ? AND revision < (
+	SELECT MAX(latest.revision) FROM module_revisions latest WHERE latest.module_id = module_revisions.module_id
+)`
+
+// archivableModuleCondition matches inactive modules without a pending
+// activation that were last changed before the cutoff. Soft-deleted modules
+// are excluded by GORM unless the query is unscoped.
+const archivableModuleCondition = "is_active = ? AND activate_at IS NULL AND updated_at < ?"
+
+// maintenanceQueryTimeout bounds the queries of background jobs scanning
+// whole tables, which take longer than the statement timeout of requests.
+const maintenanceQueryTimeout = 5 * time.Minute
+
+// errModuleNotArchivable rolls back an archive transaction whose module is no
+// longer a candidate.
+var errModuleNotArchivable = errors.New("module is no longer archivable")
+
+// RetentionRepository implements the data operations of the retention rules.
+//
+// Archived modules are copied to the module_archive table and removed from
+// every other table in one transaction.
+//
+// Usage Context:
+//
+//	repo := NewRetentionRepository(db)
+//	deleted, err := repo.PurgeExpiredRevisions(cutoff, 500)
+type RetentionRepository struct {
+	db *gorm.DB
+
+	// Receives the IDs of archived modules, after the commit
+	modulesChanged func(ids []int)
+}
+
+// NewRetentionRepository creates a repository with a specific database connection.
+//
+// Parameters:
+//   - db: Database connection to use
+//
+// Returns:
+//   - *RetentionRepository: A new repository instance using the provided connection
+func NewRetentionRepository(db *gorm.DB) *RetentionRepository {
+	return &RetentionRepository{db: db}
+}
+
+// OnModulesChanged registers a function receiving the ID of each archived
+// module once the archive has committed, e.g. to drop it from the module
+// cache, which archiving bypasses. Register it before the repository is used.
+//
+// Parameters:
+//   - changed: Called after each archived module with its ID
+func (r *RetentionRepository) OnModulesChanged(changed func(ids []int)) {
+	r.modulesChanged = changed
+}
+
+// CountExpiredRevisions counts the revisions a purge would delete.
+//
+// Parameters:
+//   - cutoff: Revisions created before this time expire
+//
+// Returns:
+//   - int: Number of expired revisions
+//   - error: Error if the query fails
+//
+// Query Implementation:
+//
+//	SELECT COUNT(*) FROM module_revisions
+//	WHERE created_at < ? AND revision < (SELECT MAX(revision) ... same module)
+func (r *RetentionRepository) CountExpiredRevisions(cutoff time.Time) (int, error) {
+	var count int64
+	err := db.WithQueryTimeout(r.db, maintenanceQueryTimeout).Model(&module.ModuleRevision{}).
+		Where(expiredRevisionCondition, cutoff).
+		Count(&count).Error
+	return int(count), err
+}
+
+// PurgeExpiredRevisions deletes one batch of expired revisions.
+//
+// The IDs are selected first because MySQL cannot delete from a table it
+// reads in a subquery.
+//
+// Parameters:
+//   - cutoff: Revisions created before this time expire
+//   - limit: Maximum number of revisions to delete
+//
+// Returns:
+//   - int: Number of deleted revisions
+//   - error: Error if a query fails
+//
+// Query Implementation:
+//
+//	SELECT id FROM module_revisions WHERE <expired> ORDER BY id LIMIT ?
+//	DELETE FROM module_revisions WHERE id IN (?)
+func (r *RetentionRepository) PurgeExpiredRevisions(cutoff time.Time, limit int) (int, error) {
+	var ids []int
+	err := db.WithQueryTimeout(r.db, maintenanceQueryTimeout).Model(&module.ModuleRevision{}).
+		Where(expiredRevisionCondition, cutoff).
+		Order("id").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+
+	result := r.db.Where("id IN ?", ids).Delete(&module.ModuleRevision{})
+	return int(result.RowsAffected), result.Error
+}
+
+// ListArchiveCandidates retrieves the modules the archive rule applies to.
+//
+// Parameters:
+//   - cutoff: Modules last changed before this time are candidates
+//
+// Returns:
+//   - []retention.ArchiveCandidate: Candidates ordered by ID, with their number of dependents
+//   - error: Error if the query fails
+//
+// Query Implementation:
+//
+//	SELECT id, updated_at,
+//	    (SELECT COUNT(*) FROM module_dependencies d WHERE d.depends_on_id = modules.id) AS dependents
+//	FROM modules
+//	WHERE is_active = false AND activate_at IS NULL AND updated_at < ? AND deleted_at IS NULL
+//	ORDER BY id
+func (r *RetentionRepository) ListArchiveCandidates(cutoff time.Time) ([]retention.ArchiveCandidate, error) {
+	var candidates []retention.ArchiveCandidate
+	err := db.WithQueryTimeout(r.db, maintenanceQueryTimeout).Model(&module.Module{}).
+		Select("id, updated_at, (SELECT COUNT(*) FROM module_dependencies d WHERE d.depends_on_id = modules.id) AS dependents").
+		Where(archivableModuleCondition, false, cutoff).
+		Order("id").
+		Scan(&candidates).Error
+	if err != nil {
+		return nil, err
+	}
+	return candidates, nil
+}
+
+// ArchiveModule moves a module to the archive table.
+//
+// Parameters:
+//   - id: Identifier of the module
+//   - cutoff: The module must still be unchanged since before this time
+//   - at: Archive time
+//
+// Returns:
+//   - bool: False if the module is gone, changed, active, scheduled for
+//     activation or depended on by another module
+//   - error: Error if a query fails
+//
+// Query Implementation:
+//
+//	SELECT * FROM modules WHERE id = ? AND <archivable>
+//	DELETE FROM modules WHERE id = ? AND <archivable> AND NOT EXISTS (<dependents>)
+//	INSERT INTO module_archive (...) VALUES (...)
+//	DELETE FROM module_tags / module_dependencies / module_settings /
+//	    module_revisions / module_summaries / module_notes / module_stars / module_acl / module_transfers / module_usage
+//	    WHERE module_id = ?
+func (r *RetentionRepository) ArchiveModule(id int, cutoff, at time.Time) (bool, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Step 1: Load the module while it is still a candidate
+		var m module.Module
+		err := tx.Where("id = ?", id).Where(archivableModuleCondition, false, cutoff).First(&m).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errModuleNotArchivable
+		}
+		if err != nil {
+			return err
+		}
+
+		// Step 2: Remove it, unless a dependency was declared meanwhile
+		result := tx.Unscoped().
+			Where("id = ? AND deleted_at IS NULL", id).
+			Where(archivableModuleCondition, false, cutoff).
+			Where("NOT EXISTS (SELECT 1 FROM module_dependencies d WHERE d.depends_on_id = modules.id)").
+			Delete(&module.Module{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errModuleNotArchivable
+		}
+
+		// Step 3: Copy the module with its tags and settings to the archive
+		record, err := r.archiveRecord(tx, &m, at)
+		if err != nil {
+			return err
+		}
+		if err := tx.Create(record).Error; err != nil {
+			return err
+		}
+
+		// Step 4: Remove everything attached to the module
+		if err := tx.Where("module_id = ?", id).Delete(&tag.ModuleTag{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("module_id = ?", id).Delete(&module.ModuleDependency{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("module_id = ?", id).Delete(&module.ModuleSetting{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("module_id = ?", id).Delete(&module.ModuleRevision{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("module_id = ?", id).Delete(&module.ModuleSummary{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("module_id = ?", id).Delete(&module.ModuleNote{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("module_id = ?", id).Delete(&module.ModuleStar{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("module_id = ?", id).Delete(&module.ModuleACLEntry{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("module_id = ?", id).Delete(&module.ModuleTransfer{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("module_id = ?", id).Delete(&module.ModuleUsage{}).Error
+	})
+	if errors.Is(err, errModuleNotArchivable) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if r.modulesChanged != nil {
+		r.modulesChanged([]int{id})
+	}
+	return true, nil
+}
+
+// archiveRecord builds the archive record of a module from its rows.
+func (r *RetentionRepository) archiveRecord(tx *gorm.DB, m *module.Module, at time.Time) (*module.ModuleArchiveRecord, error) {
+	tagNames := []string{}
+	err := tx.Model(&tag.Tag{}).
+		Joins("JOIN module_tags ON module_tags.tag_id = tags.id").
+		Where("module_tags.module_id = ?", m.ID).
+		Order("tags.name").
+		Pluck("tags.name", &tagNames).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []module.ModuleSetting
+	if err := tx.Where("module_id = ?", m.ID).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	settings := make(module.ModuleSettings, len(rows))
+	for _, row := range rows {
+		settings[row.Key] = json.RawMessage(row.Value)
+	}
+
+	return newArchiveRecord(m, tagNames, settings, at)
+}
+
+// newArchiveRecord encodes a module with its tag names and settings as an archive record.
+func newArchiveRecord(m *module.Module, tagNames []string, settings module.ModuleSettings, at time.Time) (*module.ModuleArchiveRecord, error) {
+	encodedTags, err := json.Marshal(tagNames)
+	if err != nil {
+		return nil, err
+	}
+	encodedSettings, err := json.Marshal(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	return &module.ModuleArchiveRecord{
+		ModuleID:    m.ID,
+		Name:        m.Name,
+		Description: m.Description,
+		Owner:       m.Owner,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+		ArchivedAt:  at,
+		Tags:        string(encodedTags),
+		Settings:    string(encodedSettings),
+	}, nil
+}
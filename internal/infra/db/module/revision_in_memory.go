@@ -0,0 +1,65 @@
+package module
+
+import (
+	"go_di_architecture/internal/domain/models/module"
+)
+
+func (r *InMemoryModuleRepository) AppendRevision(revision *module.ModuleRevision) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	revision.ID = r.revisionAutoIncrementID
+	r.revisionAutoIncrementID++
+	revision.Revision = len(r.revisions[revision.ModuleID]) + 1
+
+	stored := *revision
+	r.revisions[revision.ModuleID] = append(r.revisions[revision.ModuleID], &stored)
+	return nil
+}
+
+func (r *InMemoryModuleRepository) GetRevision(moduleID, revision int) (*module.ModuleRevision, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	revisions := r.revisions[moduleID]
+	if revision < 1 || revision > len(revisions) {
+		return nil, nil
+	}
+	copied := *revisions[revision-1]
+	return &copied, nil
+}
+
+func (r *InMemoryModuleRepository) ListRevisions(moduleID int, filter module.RevisionFilter, offset, limit int) ([]*module.ModuleRevision, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matching := make([]*module.ModuleRevision, 0)
+	for _, revision := range r.revisions[moduleID] {
+		if filter.Actor != "" && revision.Actor != filter.Actor {
+			continue
+		}
+		if filter.From != nil && revision.CreatedAt.Before(*filter.From) {
+			continue
+		}
+		if filter.To != nil && revision.CreatedAt.After(*filter.To) {
+			continue
+		}
+		matching = append(matching, revision)
+	}
+
+	total := int64(len(matching))
+	if offset >= len(matching) {
+		return []*module.ModuleRevision{}, total, nil
+	}
+	end := offset + limit
+	if end > len(matching) {
+		end = len(matching)
+	}
+
+	page := make([]*module.ModuleRevision, 0, end-offset)
+	for _, revision := range matching[offset:end] {
+		copied := *revision
+		page = append(page, &copied)
+	}
+	return page, total, nil
+}
@@ -0,0 +1,151 @@
+package module
+
+import (
+	"errors"
+
+	"go_di_architecture/internal/domain/models/module"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RevisionRepository implements data operations for the module change history.
+//
+// Revisions are append-only rows in the module_revisions table. Appending
+// locks the module row first, so concurrent writers of a module take their
+// revision numbers one after the other; the unique (module_id, revision)
+// index stays the last line of defence.
+//
+// Usage Context:
+//
+//	repo := NewRevisionRepository(db)
+//	revisions, total, err := repo.ListRevisions(123, module.RevisionFilter{}, 0, 20)
+type RevisionRepository struct {
+	db *gorm.DB
+}
+
+// NewRevisionRepository creates a repository with a specific database connection.
+//
+// Parameters:
+//   - db: Database connection to use
+//
+// Returns:
+//   - *RevisionRepository: A new repository instance using the provided connection
+func NewRevisionRepository(db *gorm.DB) *RevisionRepository {
+	return &RevisionRepository{db: db}
+}
+
+// AppendRevision stores a revision with the next revision number of its module
+// and projects it into the module's summary.
+//
+// The module row stays locked until the enclosing transaction ends; run in
+// the transaction of the module change (see ModuleRepository.WithTx), the
+// change and its revision commit together. SQLite, which has no row locks,
+// serializes writing transactions instead.
+//
+// Parameters:
+//   - revision: Revision to store; ID and Revision are filled in
+//
+// Returns:
+//   - error: Error if a statement fails or another writer took the number
+//
+// Query Implementation:
+//
+//	SELECT id FROM modules WHERE id = ? FOR UPDATE
+//	SELECT COALESCE(MAX(revision), 0) FROM module_revisions WHERE module_id = ?
+//	INSERT INTO module_revisions (...) VALUES (...)
+//	UPDATE module_summaries SET ... WHERE module_id = ? AND revision <= ?   -- or INSERT
+func (r *RevisionRepository) AppendRevision(revision *module.ModuleRevision) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var locked []int
+		err := tx.Unscoped().Model(&module.Module{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", revision.ModuleID).
+			Pluck("id", &locked).Error
+		if err != nil {
+			return err
+		}
+
+		var latest int
+		err = tx.Model(&module.ModuleRevision{}).
+			Where("module_id = ?", revision.ModuleID).
+			Select("COALESCE(MAX(revision), 0)").
+			Scan(&latest).Error
+		if err != nil {
+			return err
+		}
+
+		revision.Revision = latest + 1
+		if err := tx.Create(revision).Error; err != nil {
+			return err
+		}
+		return upsertSummary(tx, module.SummaryOf(revision))
+	})
+}
+
+// ListRevisions retrieves one page of a module's matching revisions.
+//
+// Parameters:
+//   - moduleID: Identifier of the module
+//   - filter: Actor and time range restrictions
+//   - offset: Number of matching revisions to skip
+//   - limit: Maximum number of revisions to return
+//
+// Returns:
+//   - []*module.ModuleRevision: Revisions in revision order
+//   - int64: Total number of matching revisions
+//   - error: Error if database query fails
+//
+// Query Implementation:
+//
+//	SELECT COUNT(*) FROM module_revisions WHERE module_id = ? [AND actor = ?]
+//	    [AND created_at >= ?] [AND created_at <= ?]
+//	SELECT * FROM module_revisions WHERE ... ORDER BY revision LIMIT ? OFFSET ?
+func (r *RevisionRepository) ListRevisions(moduleID int, filter module.RevisionFilter, offset, limit int) ([]*module.ModuleRevision, int64, error) {
+	query := r.db.Model(&module.ModuleRevision{}).Where("module_id = ?", moduleID)
+	if filter.Actor != "" {
+		query = query.Where("actor = ?", filter.Actor)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at <= ?", *filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var revisions []*module.ModuleRevision
+	if err := query.Order("revision").Offset(offset).Limit(limit).Find(&revisions).Error; err != nil {
+		return nil, 0, err
+	}
+	return revisions, total, nil
+}
+
+// GetRevision retrieves one revision of a module.
+//
+// Parameters:
+//   - moduleID: Identifier of the module
+//   - revision: Revision number
+//
+// Returns:
+//   - *module.ModuleRevision: The revision, or nil if it does not exist
+//   - error: Error if database query fails
+//
+// Query Implementation:
+//
+//	SELECT * FROM module_revisions WHERE module_id = ? AND revision = ? LIMIT 1
+func (r *RevisionRepository) GetRevision(moduleID, revision int) (*module.ModuleRevision, error) {
+	var found module.ModuleRevision
+	err := r.db.Where("module_id = ? AND revision = ?", moduleID, revision).First(&found).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &found, nil
+}
@@ -0,0 +1,32 @@
+package module
+
+import (
+	"go_di_architecture/internal/domain/models/module"
+	"sort"
+)
+
+func (r *InMemoryModuleRepository) ListSettings(moduleID int) ([]module.ModuleSetting, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	settings := make([]module.ModuleSetting, 0, len(r.settings[moduleID]))
+	for _, setting := range r.settings[moduleID] {
+		settings = append(settings, setting)
+	}
+	sort.Slice(settings, func(i, j int) bool {
+		return settings[i].Key < settings[j].Key
+	})
+	return settings, nil
+}
+
+func (r *InMemoryModuleRepository) ReplaceSettings(moduleID int, settings []module.ModuleSetting) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	replaced := make(map[string]module.ModuleSetting, len(settings))
+	for _, setting := range settings {
+		replaced[setting.Key] = setting
+	}
+	r.settings[moduleID] = replaced
+	return nil
+}
@@ -0,0 +1,78 @@
+package module
+
+import (
+	"go_di_architecture/internal/domain/models/module"
+
+	"gorm.io/gorm"
+)
+
+// SettingRepository implements data operations for per-module settings.
+//
+// Settings are stored in the module_settings table keyed by (module_id, setting_key)
+// with the value kept as JSON text, which works identically on PostgreSQL,
+// MySQL and SQLite.
+//
+// Usage Context:
+//
+//	repo := NewSettingRepository(db)
+//	settings, err := repo.ListSettings(123)
+type SettingRepository struct {
+	db *gorm.DB
+}
+
+// NewSettingRepository creates a repository with a specific database connection.
+//
+// Parameters:
+//   - db: Database connection to use
+//
+// Returns:
+//   - *SettingRepository: A new repository instance using the provided connection
+func NewSettingRepository(db *gorm.DB) *SettingRepository {
+	return &SettingRepository{db: db}
+}
+
+// ListSettings retrieves every setting of a module.
+//
+// Parameters:
+//   - moduleID: Identifier of the module
+//
+// Returns:
+//   - []module.ModuleSetting: Settings ordered by key
+//   - error: Error if database query fails
+//
+// Query Implementation:
+//
+//	SELECT * FROM module_settings WHERE module_id = ? ORDER BY setting_key
+func (r *SettingRepository) ListSettings(moduleID int) ([]module.ModuleSetting, error) {
+	var settings []module.ModuleSetting
+	err := r.db.Where("module_id = ?", moduleID).Order("setting_key").Find(&settings).Error
+	if err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// ReplaceSettings replaces all settings of a module in one transaction.
+//
+// Parameters:
+//   - moduleID: Identifier of the module
+//   - settings: The complete new settings document
+//
+// Returns:
+//   - error: Error if a statement fails (the previous settings are kept in that case)
+//
+// Query Implementation:
+//
+//	DELETE FROM module_settings WHERE module_id = ?
+//	INSERT INTO module_settings (module_id, setting_key, value, updated_at) VALUES ...
+func (r *SettingRepository) ReplaceSettings(moduleID int, settings []module.ModuleSetting) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("module_id = ?", moduleID).Delete(&module.ModuleSetting{}).Error; err != nil {
+			return err
+		}
+		if len(settings) == 0 {
+			return nil
+		}
+		return tx.Create(&settings).Error
+	})
+}
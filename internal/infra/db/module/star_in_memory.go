@@ -0,0 +1,24 @@
+package module
+
+import "time"
+
+func (r *InMemoryModuleRepository) StarModule(moduleID int, user string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.stars[moduleID] == nil {
+		r.stars[moduleID] = make(map[string]time.Time)
+	}
+	if _, starred := r.stars[moduleID][user]; !starred {
+		r.stars[moduleID][user] = time.Now()
+	}
+	return nil
+}
+
+func (r *InMemoryModuleRepository) UnstarModule(moduleID int, user string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.stars[moduleID], user)
+	return nil
+}
@@ -0,0 +1,67 @@
+package module
+
+import (
+	"go_di_architecture/internal/domain/models/module"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// StarRepository implements data operations for per-user module favorites.
+//
+// Stars are stored in the module_stars table keyed by (module_id, user_name).
+// The module listing queries of ModuleRepository read the same table to list
+// the favorites of a user.
+//
+// Usage Context:
+//
+//	repo := NewStarRepository(db)
+//	err := repo.StarModule(123, "jane")
+type StarRepository struct {
+	db *gorm.DB
+}
+
+// NewStarRepository creates a repository with a specific database connection.
+//
+// Parameters:
+//   - db: Database connection to use
+//
+// Returns:
+//   - *StarRepository: A new repository instance using the provided connection
+func NewStarRepository(db *gorm.DB) *StarRepository {
+	return &StarRepository{db: db}
+}
+
+// StarModule records a star, keeping the original one if it exists.
+//
+// Parameters:
+//   - moduleID: Identifier of the module
+//   - user: The user starring the module
+//
+// Returns:
+//   - error: Error if the insert fails
+//
+// Query Implementation:
+//
+//	INSERT INTO module_stars (module_id, user_name, created_at) VALUES (?, ?, ?)
+//	ON CONFLICT DO NOTHING  -- INSERT IGNORE on MySQL
+func (r *StarRepository) StarModule(moduleID int, user string) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&module.ModuleStar{ModuleID: moduleID, User: user}).Error
+}
+
+// UnstarModule deletes a star.
+//
+// Parameters:
+//   - moduleID: Identifier of the module
+//   - user: The user unstarring the module
+//
+// Returns:
+//   - error: Error if the delete fails
+//
+// Query Implementation:
+//
+//	DELETE FROM module_stars WHERE module_id = ? AND user_name = ?
+func (r *StarRepository) UnstarModule(moduleID int, user string) error {
+	return r.db.Where("module_id = ? AND user_name = ?", moduleID, user).Delete(&module.ModuleStar{}).Error
+}
@@ -0,0 +1,136 @@
+package module
+
+import (
+	"go_di_architecture/internal/domain/models/module"
+	"go_di_architecture/internal/infra/db"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SummaryRepository implements the data operations of replaying the change
+// history into the module_summaries read model.
+//
+// Summaries are written with a guarded upsert that never replaces a summary
+// with one of an older revision, so replays are idempotent and can run while
+// the revision repository keeps the summaries of new changes up to date.
+//
+// Usage Context:
+//
+//	repo := NewSummaryRepository(db)
+//	revisions, err := repo.ListRevisionsAfter(0, 500)
+//	err = repo.UpsertSummaries(summaries)
+type SummaryRepository struct {
+	db *gorm.DB
+}
+
+// NewSummaryRepository creates a repository with a specific database connection.
+//
+// Parameters:
+//   - db: Database connection to use
+//
+// Returns:
+//   - *SummaryRepository: A new repository instance using the provided connection
+func NewSummaryRepository(db *gorm.DB) *SummaryRepository {
+	return &SummaryRepository{db: db}
+}
+
+// CountRevisions counts the revisions of all modules.
+//
+// Returns:
+//   - int64: Number of revisions in the change history
+//   - error: Error if the query fails
+//
+// Query Implementation:
+//
+//	SELECT COUNT(*) FROM module_revisions
+func (r *SummaryRepository) CountRevisions() (int64, error) {
+	var count int64
+	err := db.WithQueryTimeout(r.db, maintenanceQueryTimeout).Model(&module.ModuleRevision{}).Count(&count).Error
+	return count, err
+}
+
+// ListRevisionsAfter retrieves revisions of all modules in the order they
+// were written.
+//
+// Parameters:
+//   - afterID: Record ID of the last revision already read (0 to start)
+//   - limit: Maximum number of revisions to return
+//
+// Returns:
+//   - []*module.ModuleRevision: Revisions ordered by record ID
+//   - error: Error if the query fails
+//
+// Query Implementation:
+//
+//	SELECT * FROM module_revisions WHERE id > ? ORDER BY id LIMIT ?
+func (r *SummaryRepository) ListRevisionsAfter(afterID, limit int) ([]*module.ModuleRevision, error) {
+	var revisions []*module.ModuleRevision
+	err := db.WithQueryTimeout(r.db, maintenanceQueryTimeout).
+		Where("id > ?", afterID).
+		Order("id").
+		Limit(limit).
+		Find(&revisions).Error
+	return revisions, err
+}
+
+// UpsertSummaries writes summaries, keeping stored summaries of a newer
+// revision.
+//
+// Parameters:
+//   - summaries: Summaries to write, at most one per module
+//
+// Returns:
+//   - error: Error if a statement fails (nothing is written in that case)
+func (r *SummaryRepository) UpsertSummaries(summaries []*module.ModuleSummary) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, summary := range summaries {
+			if err := upsertSummary(tx, summary); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteStaleSummaries deletes the summaries of modules without revisions,
+// e.g. of modules purged while the read model was lost.
+//
+// Returns:
+//   - int64: Number of summaries deleted
+//   - error: Error if the statement fails
+//
+// Query Implementation:
+//
+//	DELETE FROM module_summaries WHERE module_id NOT IN (SELECT module_id FROM module_revisions)
+func (r *SummaryRepository) DeleteStaleSummaries() (int64, error) {
+	result := db.WithQueryTimeout(r.db, maintenanceQueryTimeout).
+		Where("module_id NOT IN (?)", r.db.Model(&module.ModuleRevision{}).Select("module_id")).
+		Delete(&module.ModuleSummary{})
+	return result.RowsAffected, result.Error
+}
+
+// upsertSummary writes the summary of a module unless the stored summary is
+// of a newer revision.
+//
+// Query Implementation:
+//
+//	UPDATE module_summaries SET name = ?, is_active = ?, revision = ?, action = ?, changed_at = ?
+//	    WHERE module_id = ? AND revision <= ?
+//	INSERT INTO module_summaries (...) VALUES (...) ON CONFLICT DO NOTHING   -- when no row was updated
+//	-- MySQL: ON DUPLICATE KEY UPDATE module_id = module_id
+func upsertSummary(tx *gorm.DB, summary *module.ModuleSummary) error {
+	result := tx.Model(&module.ModuleSummary{}).
+		Where("module_id = ? AND revision <= ?", summary.ModuleID, summary.Revision).
+		Updates(map[string]interface{}{
+			"name":       summary.Name,
+			"is_active":  summary.IsActive,
+			"revision":   summary.Revision,
+			"action":     summary.Action,
+			"changed_at": summary.ChangedAt,
+		})
+	if result.Error != nil || result.RowsAffected > 0 {
+		return result.Error
+	}
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(summary).Error
+}
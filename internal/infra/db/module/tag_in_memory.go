@@ -0,0 +1,99 @@
+package module
+
+import (
+	"fmt"
+	"go_di_architecture/internal/domain/models/tag"
+	tagService "go_di_architecture/internal/domain/service/tag"
+	"sort"
+)
+
+func (r *InMemoryModuleRepository) CreateTag(t *tag.Tag) (*tag.Tag, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.tags {
+		if existing.Name == t.Name {
+			return nil, fmt.Errorf("%w: %s", tagService.ErrTagExists, t.Name)
+		}
+	}
+
+	t.ID = r.tagAutoIncrementID
+	r.tagAutoIncrementID++
+
+	r.tags[t.ID] = t
+	return t, nil
+}
+
+func (r *InMemoryModuleRepository) FindTagByName(name string) (*tag.Tag, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, t := range r.tags {
+		if t.Name == name {
+			return t, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *InMemoryModuleRepository) ListTags() ([]*tag.Tag, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tags := make([]*tag.Tag, 0, len(r.tags))
+	for _, t := range r.tags {
+		tags = append(tags, t)
+	}
+	sortTags(tags)
+	return tags, nil
+}
+
+func (r *InMemoryModuleRepository) DeleteTag(id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.tags, id)
+	for _, tagIDs := range r.moduleTags {
+		delete(tagIDs, id)
+	}
+	return nil
+}
+
+func (r *InMemoryModuleRepository) AssignTag(moduleID, tagID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.moduleTags[moduleID] == nil {
+		r.moduleTags[moduleID] = make(map[int]bool)
+	}
+	r.moduleTags[moduleID][tagID] = true
+	return nil
+}
+
+func (r *InMemoryModuleRepository) UnassignTag(moduleID, tagID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.moduleTags[moduleID], tagID)
+	return nil
+}
+
+func (r *InMemoryModuleRepository) ListModuleTags(moduleID int) ([]*tag.Tag, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tags := make([]*tag.Tag, 0, len(r.moduleTags[moduleID]))
+	for tagID := range r.moduleTags[moduleID] {
+		if t, exists := r.tags[tagID]; exists {
+			tags = append(tags, t)
+		}
+	}
+	sortTags(tags)
+	return tags, nil
+}
+
+func sortTags(tags []*tag.Tag) {
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].Name < tags[j].Name
+	})
+}
@@ -0,0 +1,181 @@
+package module
+
+import (
+	"errors"
+	"fmt"
+
+	"go_di_architecture/internal/domain/models/tag"
+	tagService "go_di_architecture/internal/domain/service/tag"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TagRepository implements data operations for tags and module assignments.
+//
+// Tags are stored in the tags table; assignments live in the module_tags join
+// table keyed by (module_id, tag_id). Module list queries join the same tables
+// to filter by tag (see ModuleRepository.ListModules).
+//
+// Database Schema Details:
+//   - Table: tags (unique index idx_tags_name on name)
+//   - Table: module_tags (primary key module_id, tag_id; index on tag_id)
+//
+// Usage Context:
+//
+//	repo := NewTagRepository(db)
+//	_, err := repo.CreateTag(&tag.Tag{Name: "backend"})
+type TagRepository struct {
+	db *gorm.DB
+}
+
+// NewTagRepository creates a repository with a specific database connection.
+//
+// Parameters:
+//   - db: Database connection to use
+//
+// Returns:
+//   - *TagRepository: A new repository instance using the provided connection
+func NewTagRepository(db *gorm.DB) *TagRepository {
+	return &TagRepository{db: db}
+}
+
+// CreateTag adds a new tag to the database.
+//
+// Parameters:
+//   - t: Tag to persist with a normalized name
+//
+// Returns:
+//   - *tag.Tag: Persisted tag with database-generated values
+//   - error: Error wrapping ErrTagExists for unique constraint violations
+func (r *TagRepository) CreateTag(t *tag.Tag) (*tag.Tag, error) {
+	result := r.db.Create(t)
+	if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+		return nil, fmt.Errorf("%w: %v", tagService.ErrTagExists, result.Error)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return t, nil
+}
+
+// FindTagByName retrieves a tag by its normalized name.
+//
+// Parameters:
+//   - name: Normalized tag name
+//
+// Returns:
+//   - *tag.Tag: Tag entity or nil if not found
+//   - error: Error if database query fails
+func (r *TagRepository) FindTagByName(name string) (*tag.Tag, error) {
+	var entity tag.Tag
+
+	result := r.db.Where("name = ?", name).First(&entity)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &entity, nil
+}
+
+// ListTags retrieves all tags ordered by name.
+//
+// Returns:
+//   - []*tag.Tag: All tags
+//   - error: Error if database query fails
+func (r *TagRepository) ListTags() ([]*tag.Tag, error) {
+	var entities []tag.Tag
+	if err := r.db.Order("name").Find(&entities).Error; err != nil {
+		return nil, err
+	}
+
+	return tagPointers(entities), nil
+}
+
+// DeleteTag removes a tag and its module assignments in one transaction.
+//
+// Parameters:
+//   - id: Identifier of the tag
+//
+// Returns:
+//   - error: Error if a delete fails (nothing is removed in that case)
+func (r *TagRepository) DeleteTag(id int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("tag_id = ?", id).Delete(&tag.ModuleTag{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&tag.Tag{}, id).Error
+	})
+}
+
+// AssignTag attaches a tag to a module.
+//
+// Parameters:
+//   - moduleID: Identifier of the module
+//   - tagID: Identifier of the tag
+//
+// Returns:
+//   - error: Error if the insert fails
+//
+// Query Implementation:
+//
+//	INSERT INTO module_tags (module_id, tag_id, created_at) VALUES (?, ?, ?)
+//	ON CONFLICT DO NOTHING  -- INSERT IGNORE on MySQL
+func (r *TagRepository) AssignTag(moduleID, tagID int) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&tag.ModuleTag{ModuleID: moduleID, TagID: tagID}).Error
+}
+
+// UnassignTag detaches a tag from a module.
+//
+// Parameters:
+//   - moduleID: Identifier of the module
+//   - tagID: Identifier of the tag
+//
+// Returns:
+//   - error: Error if the delete fails
+func (r *TagRepository) UnassignTag(moduleID, tagID int) error {
+	return r.db.Where("module_id = ? AND tag_id = ?", moduleID, tagID).Delete(&tag.ModuleTag{}).Error
+}
+
+// ListModuleTags retrieves the tags attached to a module.
+//
+// Parameters:
+//   - moduleID: Identifier of the module
+//
+// Returns:
+//   - []*tag.Tag: Attached tags ordered by name
+//   - error: Error if database query fails
+//
+// Query Implementation:
+//
+//	SELECT tags.* FROM tags
+//	JOIN module_tags ON module_tags.tag_id = tags.id
+//	WHERE module_tags.module_id = ?
+//	ORDER BY tags.name
+func (r *TagRepository) ListModuleTags(moduleID int) ([]*tag.Tag, error) {
+	var entities []tag.Tag
+	err := r.db.
+		Joins("JOIN module_tags ON module_tags.tag_id = tags.id").
+		Where("module_tags.module_id = ?", moduleID).
+		Order("tags.name").
+		Find(&entities).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return tagPointers(entities), nil
+}
+
+// tagPointers converts query results to the pointer slice used by the domain layer.
+func tagPointers(entities []tag.Tag) []*tag.Tag {
+	result := make([]*tag.Tag, len(entities))
+	for i := range entities {
+		result[i] = &entities[i]
+	}
+	return result
+}
@@ -0,0 +1,63 @@
+package module
+
+import (
+	"fmt"
+	"sort"
+
+	"go_di_architecture/internal/domain/models/template"
+	templateService "go_di_architecture/internal/domain/service/template"
+)
+
+func (r *InMemoryModuleRepository) CreateTemplate(t *template.ModuleTemplate) (*template.ModuleTemplate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.templates {
+		if existing.Name == t.Name {
+			return nil, fmt.Errorf("%w: %s", templateService.ErrTemplateExists, t.Name)
+		}
+	}
+
+	t.ID = r.templateAutoIncrementID
+	r.templateAutoIncrementID++
+
+	stored := *t
+	r.templates[t.ID] = &stored
+	return t, nil
+}
+
+func (r *InMemoryModuleRepository) GetTemplate(id int) (*template.ModuleTemplate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored, ok := r.templates[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *stored
+	return &copied, nil
+}
+
+func (r *InMemoryModuleRepository) ListTemplates() ([]*template.ModuleTemplate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	templates := make([]*template.ModuleTemplate, 0, len(r.templates))
+	for _, stored := range r.templates {
+		copied := *stored
+		templates = append(templates, &copied)
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates, nil
+}
+
+func (r *InMemoryModuleRepository) DeleteTemplate(id int) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.templates[id]; !ok {
+		return false, nil
+	}
+	delete(r.templates, id)
+	return true, nil
+}
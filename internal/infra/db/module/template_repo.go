@@ -0,0 +1,109 @@
+package module
+
+import (
+	"errors"
+	"fmt"
+
+	"go_di_architecture/internal/domain/models/template"
+	templateService "go_di_architecture/internal/domain/service/template"
+
+	"gorm.io/gorm"
+)
+
+// TemplateRepository implements data operations for the module template catalogue.
+//
+// Database Schema Details:
+//   - Table: module_templates (unique index idx_module_templates_name on name)
+//   - Settings are stored as a JSON document in a text column
+//
+// Usage Context:
+//
+//	repo := NewTemplateRepository(db)
+//	templates, err := repo.ListTemplates()
+type TemplateRepository struct {
+	db *gorm.DB
+}
+
+// NewTemplateRepository creates a repository with a specific database connection.
+//
+// Parameters:
+//   - db: Database connection to use
+//
+// Returns:
+//   - *TemplateRepository: A new repository instance using the provided connection
+func NewTemplateRepository(db *gorm.DB) *TemplateRepository {
+	return &TemplateRepository{db: db}
+}
+
+// CreateTemplate adds a template to the catalogue.
+//
+// Parameters:
+//   - t: Template to persist
+//
+// Returns:
+//   - *template.ModuleTemplate: Persisted template with database-generated values
+//   - error: Error wrapping ErrTemplateExists for unique constraint violations
+func (r *TemplateRepository) CreateTemplate(t *template.ModuleTemplate) (*template.ModuleTemplate, error) {
+	result := r.db.Create(t)
+	if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+		return nil, fmt.Errorf("%w: %v", templateService.ErrTemplateExists, result.Error)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return t, nil
+}
+
+// GetTemplate retrieves a template by its ID.
+//
+// Parameters:
+//   - id: Identifier of the template
+//
+// Returns:
+//   - *template.ModuleTemplate: Template entity or nil if not found
+//   - error: Error if database query fails
+func (r *TemplateRepository) GetTemplate(id int) (*template.ModuleTemplate, error) {
+	var entity template.ModuleTemplate
+
+	result := r.db.First(&entity, id)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &entity, nil
+}
+
+// ListTemplates retrieves the whole catalogue.
+//
+// Returns:
+//   - []*template.ModuleTemplate: Templates ordered by name
+//   - error: Error if database query fails
+func (r *TemplateRepository) ListTemplates() ([]*template.ModuleTemplate, error) {
+	var templates []*template.ModuleTemplate
+	if err := r.db.Order("name").Find(&templates).Error; err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+// DeleteTemplate removes a template.
+//
+// Parameters:
+//   - id: Identifier of the template
+//
+// Returns:
+//   - bool: False if there was no template with the ID
+//   - error: Error if the delete fails
+func (r *TemplateRepository) DeleteTemplate(id int) (bool, error) {
+	result := r.db.Delete(&template.ModuleTemplate{}, id)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,63 @@
+package module
+
+import (
+	"go_di_architecture/internal/domain/models/module"
+	"time"
+)
+
+func (r *InMemoryModuleRepository) CreateTransfer(t *module.ModuleTransfer) (*module.ModuleTransfer, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t.ID = r.transferAutoIncrementID
+	r.transferAutoIncrementID++
+
+	stored := *t
+	r.transfers[t.ID] = &stored
+	return t, nil
+}
+
+func (r *InMemoryModuleRepository) GetTransfer(id int) (*module.ModuleTransfer, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored, exists := r.transfers[id]
+	if !exists {
+		return nil, nil
+	}
+	transfer := *stored
+	return &transfer, nil
+}
+
+func (r *InMemoryModuleRepository) FindPendingTransfer(moduleID int) (*module.ModuleTransfer, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var pending *module.ModuleTransfer
+	for _, stored := range r.transfers {
+		if stored.ModuleID != moduleID || stored.Status != module.TransferPending {
+			continue
+		}
+		if pending == nil || stored.ID > pending.ID {
+			pending = stored
+		}
+	}
+	if pending == nil {
+		return nil, nil
+	}
+	transfer := *pending
+	return &transfer, nil
+}
+
+func (r *InMemoryModuleRepository) ResolveTransfer(id int, status string, at time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, exists := r.transfers[id]
+	if !exists || stored.Status != module.TransferPending {
+		return false, nil
+	}
+	stored.Status = status
+	stored.ResolvedAt = &at
+	return true, nil
+}
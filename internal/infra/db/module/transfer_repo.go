@@ -0,0 +1,137 @@
+package module
+
+import (
+	"errors"
+	"time"
+
+	"go_di_architecture/internal/domain/models/module"
+
+	"gorm.io/gorm"
+)
+
+// TransferRepository implements data operations for module ownership transfers.
+//
+// Transfers are stored in the module_transfers table. Pending transfers stay
+// pending in storage after their expiry until the service marks them as
+// expired, so reads compare ExpiresAt against the current time.
+//
+// Usage Context:
+//
+//	repo := NewTransferRepository(db)
+//	pending, err := repo.FindPendingTransfer(123)
+type TransferRepository struct {
+	db *gorm.DB
+}
+
+// NewTransferRepository creates a repository with a specific database connection.
+//
+// Parameters:
+//   - db: Database connection to use
+//
+// Returns:
+//   - *TransferRepository: A new repository instance using the provided connection
+func NewTransferRepository(db *gorm.DB) *TransferRepository {
+	return &TransferRepository{db: db}
+}
+
+// CreateTransfer persists a new ownership transfer.
+//
+// Parameters:
+//   - transfer: The transfer to store; its ID is set on success
+//
+// Returns:
+//   - *module.ModuleTransfer: The stored transfer
+//   - error: Error if persistence fails
+//
+// Query Implementation:
+//
+//	INSERT INTO module_transfers (module_id, from_owner, to_owner, requested_by,
+//	    status, created_at, expires_at, resolved_at)
+//	VALUES (?, ?, ?, ?, ?, ?, ?, NULL)
+func (r *TransferRepository) CreateTransfer(transfer *module.ModuleTransfer) (*module.ModuleTransfer, error) {
+	if err := r.db.Create(transfer).Error; err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+// GetTransfer retrieves a transfer by ID.
+//
+// Parameters:
+//   - id: Identifier of the transfer
+//
+// Returns:
+//   - *module.ModuleTransfer: The transfer, or nil if not found
+//   - error: Error if database query fails
+//
+// Query Implementation:
+//
+//	SELECT * FROM module_transfers WHERE id = ? LIMIT 1
+func (r *TransferRepository) GetTransfer(id int) (*module.ModuleTransfer, error) {
+	var transfer module.ModuleTransfer
+	err := r.db.First(&transfer, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+// FindPendingTransfer retrieves the pending transfer of a module.
+//
+// Parameters:
+//   - moduleID: Identifier of the module
+//
+// Returns:
+//   - *module.ModuleTransfer: The most recent pending transfer, or nil if none
+//   - error: Error if database query fails
+//
+// Query Implementation:
+//
+//	SELECT * FROM module_transfers
+//	WHERE module_id = ? AND status = 'pending'
+//	ORDER BY id DESC LIMIT 1
+func (r *TransferRepository) FindPendingTransfer(moduleID int) (*module.ModuleTransfer, error) {
+	var transfer module.ModuleTransfer
+	err := r.db.Where("module_id = ? AND status = ?", moduleID, module.TransferPending).
+		Order("id DESC").
+		First(&transfer).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+// ResolveTransfer closes a pending transfer.
+//
+// Parameters:
+//   - id: Identifier of the transfer
+//   - status: New status (accepted or expired)
+//   - at: Time the transfer was resolved
+//
+// Returns:
+//   - bool: True if the transfer was pending and is now resolved
+//   - error: Error if the update fails
+//
+// Query Implementation:
+//
+//	UPDATE module_transfers SET status = ?, resolved_at = ?
+//	WHERE id = ? AND status = 'pending'
+//
+// Concurrency:
+//   - The status condition makes the update a compare-and-set, so only one of
+//     two concurrent accepts affects the row
+func (r *TransferRepository) ResolveTransfer(id int, status string, at time.Time) (bool, error) {
+	result := r.db.Model(&module.ModuleTransfer{}).
+		Where("id = ? AND status = ?", id, module.TransferPending).
+		Updates(map[string]interface{}{"status": status, "resolved_at": at})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
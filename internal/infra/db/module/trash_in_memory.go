@@ -0,0 +1,111 @@
+package module
+
+import (
+	"go_di_architecture/internal/domain/models/module"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func (r *InMemoryModuleRepository) SoftDeleteModule(id int, deletedBy string, at time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	m, exists := r.data[id]
+	if !exists {
+		return false, nil
+	}
+
+	deleted := *m
+	deleted.DeletedAt = gorm.DeletedAt{Time: at, Valid: true}
+	deleted.DeletedBy = deletedBy
+	deleted.UpdatedAt = at
+
+	delete(r.data, id)
+	r.trash[id] = &deleted
+	return true, nil
+}
+
+func (r *InMemoryModuleRepository) ListDeletedModules(offset, limit int) ([]*module.Module, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	sorted := make([]*module.Module, 0, len(r.trash))
+	for _, m := range r.trash {
+		sorted = append(sorted, m)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if !sorted[i].DeletedAt.Time.Equal(sorted[j].DeletedAt.Time) {
+			return sorted[i].DeletedAt.Time.After(sorted[j].DeletedAt.Time)
+		}
+		return sorted[i].ID > sorted[j].ID
+	})
+
+	total := int64(len(sorted))
+	if offset >= len(sorted) {
+		return []*module.Module{}, total, nil
+	}
+	sorted = sorted[offset:]
+	if len(sorted) > limit {
+		sorted = sorted[:limit]
+	}
+	return snapshots(sorted), total, nil
+}
+
+func (r *InMemoryModuleRepository) RestoreModules(ids []int, at time.Time) ([]int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	restored := make([]int, 0, len(ids))
+	for _, id := range ids {
+		m, exists := r.trash[id]
+		if !exists {
+			continue
+		}
+
+		live := *m
+		live.DeletedAt = gorm.DeletedAt{}
+		live.DeletedBy = ""
+		live.UpdatedAt = at
+
+		delete(r.trash, id)
+		r.data[id] = &live
+		restored = append(restored, id)
+	}
+	return restored, nil
+}
+
+func (r *InMemoryModuleRepository) PurgeModules(ids []int) ([]int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	purged := make([]int, 0, len(ids))
+	for _, id := range ids {
+		m, exists := r.trash[id]
+		if !exists {
+			continue
+		}
+
+		r.releaseName(m)
+		delete(r.trash, id)
+		delete(r.moduleTags, id)
+		delete(r.dependencies, id)
+		for _, dependsOn := range r.dependencies {
+			delete(dependsOn, id)
+		}
+		delete(r.settings, id)
+		delete(r.revisions, id)
+		delete(r.notes, id)
+		delete(r.stars, id)
+		delete(r.acl, id)
+		delete(r.usage, id)
+		for transferID, transfer := range r.transfers {
+			if transfer.ModuleID == id {
+				delete(r.transfers, transferID)
+			}
+		}
+		purged = append(purged, id)
+	}
+	return purged, nil
+}
@@ -0,0 +1,171 @@
+package module
+
+import (
+	"time"
+
+	"go_di_architecture/internal/domain/models/module"
+	"go_di_architecture/internal/domain/models/tag"
+
+	"gorm.io/gorm"
+)
+
+// SoftDeleteModule moves a module to the recycle bin.
+//
+// Parameters:
+//   - id: Identifier of the module
+//   - deletedBy: Who deletes the module
+//   - at: Deletion time, also stored as the last change
+//
+// Returns:
+//   - bool: False if the module does not exist or is already deleted
+//   - error: Error if the update fails
+//
+// Query Implementation:
+//
+//	UPDATE modules SET deleted_at = ?, deleted_by = ?, updated_at = ?
+//	WHERE id = ? AND deleted_at IS NULL
+func (r *ModuleRepository) SoftDeleteModule(id int, deletedBy string, at time.Time) (bool, error) {
+	result := r.db.Model(&module.Module{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"deleted_at": at, "deleted_by": deletedBy, "updated_at": at})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ListDeletedModules retrieves one page of the recycle bin.
+//
+// Parameters:
+//   - offset: Number of rows to skip
+//   - limit: Maximum number of rows to return
+//
+// Returns:
+//   - []*module.Module: Soft-deleted modules, most recently deleted first
+//   - int64: Total number of soft-deleted modules
+//   - error: Error if database query fails
+//
+// Query Implementation:
+//
+//	SELECT COUNT(*) FROM modules WHERE deleted_at IS NOT NULL
+//	SELECT * FROM modules WHERE deleted_at IS NOT NULL
+//	ORDER BY deleted_at DESC, id DESC LIMIT ? OFFSET ?
+func (r *ModuleRepository) ListDeletedModules(offset, limit int) ([]*module.Module, int64, error) {
+	query := r.db.Unscoped().Model(&module.Module{}).Where("deleted_at IS NOT NULL")
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var entities []module.Module
+	if err := query.Order("deleted_at DESC, id DESC").Offset(offset).Limit(limit).Find(&entities).Error; err != nil {
+		return nil, 0, err
+	}
+	return toPointers(entities), total, nil
+}
+
+// RestoreModules takes modules out of the recycle bin in one transaction.
+//
+// Parameters:
+//   - ids: Identifiers of the modules to restore
+//   - at: Restore time, stored as the last change
+//
+// Returns:
+//   - []int: IDs that were in the recycle bin and are now restored
+//   - error: Error if a statement fails
+//
+// Query Implementation:
+//
+//	SELECT id FROM modules WHERE id IN (?) AND deleted_at IS NOT NULL
+//	UPDATE modules SET deleted_at = NULL, deleted_by = '', updated_at = ? WHERE id IN (?)
+func (r *ModuleRepository) RestoreModules(ids []int, at time.Time) ([]int, error) {
+	var restored []int
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().Model(&module.Module{}).
+			Where("id IN ? AND deleted_at IS NOT NULL", ids).
+			Pluck("id", &restored).Error
+		if err != nil || len(restored) == 0 {
+			return err
+		}
+
+		return tx.Unscoped().Model(&module.Module{}).
+			Where("id IN ?", restored).
+			Updates(map[string]interface{}{"deleted_at": nil, "deleted_by": "", "updated_at": at}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return restored, nil
+}
+
+// PurgeModules permanently removes soft-deleted modules and everything attached to them.
+//
+// Parameters:
+//   - ids: Identifiers of the modules to purge
+//
+// Returns:
+//   - []int: IDs that were in the recycle bin and are now removed
+//   - error: Error if a statement fails (nothing is removed in that case)
+//
+// Query Implementation:
+//
+//	SELECT id FROM modules WHERE id IN (?) AND deleted_at IS NOT NULL
+//	DELETE FROM module_tags WHERE module_id IN (?)
+//	DELETE FROM module_dependencies WHERE module_id IN (?) OR depends_on_id IN (?)
+//	DELETE FROM module_settings WHERE module_id IN (?)
+//	DELETE FROM module_revisions WHERE module_id IN (?)
+//	DELETE FROM module_summaries WHERE module_id IN (?)
+//	DELETE FROM module_notes WHERE module_id IN (?)
+//	DELETE FROM module_stars WHERE module_id IN (?)
+//	DELETE FROM module_acl WHERE module_id IN (?)
+//	DELETE FROM module_transfers WHERE module_id IN (?)
+//	DELETE FROM module_usage WHERE module_id IN (?)
+//	DELETE FROM modules WHERE id IN (?)
+func (r *ModuleRepository) PurgeModules(ids []int) ([]int, error) {
+	var purged []int
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().Model(&module.Module{}).
+			Where("id IN ? AND deleted_at IS NOT NULL", ids).
+			Pluck("id", &purged).Error
+		if err != nil || len(purged) == 0 {
+			return err
+		}
+
+		if err := tx.Where("module_id IN ?", purged).Delete(&tag.ModuleTag{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("module_id IN ? OR depends_on_id IN ?", purged, purged).Delete(&module.ModuleDependency{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("module_id IN ?", purged).Delete(&module.ModuleSetting{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("module_id IN ?", purged).Delete(&module.ModuleRevision{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("module_id IN ?", purged).Delete(&module.ModuleSummary{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("module_id IN ?", purged).Delete(&module.ModuleNote{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("module_id IN ?", purged).Delete(&module.ModuleStar{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("module_id IN ?", purged).Delete(&module.ModuleACLEntry{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("module_id IN ?", purged).Delete(&module.ModuleTransfer{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("module_id IN ?", purged).Delete(&module.ModuleUsage{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("id IN ?", purged).Delete(&module.Module{}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return purged, nil
+}
@@ -0,0 +1,66 @@
+package module
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"go_di_architecture/internal/domain/models/module"
+	"go_di_architecture/internal/infra/db"
+)
+
+// TestUnitOfWorkCommitsOrRollsBackRequestWrites checks the writes of a
+// service bound to a unit of work stay invisible to other callers until it
+// completes, are discarded when it is disposed instead, and the cache does
+// not keep what other callers saw before the commit.
+func TestUnitOfWorkCommitsOrRollsBackRequestWrites(t *testing.T) {
+	database := openSQLite(t)
+	service := newSQLModuleService(t, database)
+	admin := module.Subject{Admin: true}
+
+	// A request that fails
+	failed := db.NewUnitOfWork(database)
+	bound := service.WithContext(failed.Bind(context.Background()))
+	if _, err := bound.CreateModule(module.ModuleRequest{Name: "Payments"}, "alice", false); err != nil {
+		t.Fatalf("CreateModule() in the failed unit of work error = %v", err)
+	}
+	if err := failed.Dispose(); err != nil {
+		t.Fatalf("Dispose() error = %v", err)
+	}
+	if count, err := service.CountModules(nil, admin); err != nil || count != 0 {
+		t.Fatalf("CountModules() after the rollback = %d, %v, want 0", count, err)
+	}
+
+	// A request that succeeds
+	uow := db.NewUnitOfWork(database)
+	bound = service.WithContext(uow.Bind(context.Background()))
+	created, err := bound.CreateModule(module.ModuleRequest{Name: "Billing", Description: "Invoices"}, "alice", false)
+	if err != nil {
+		t.Fatalf("CreateModule() error = %v", err)
+	}
+	id := strconv.Itoa(created.ID)
+	if _, err := bound.UpdateModule(id, module.ModuleRequest{Name: "Billing", Description: "Refunds"}, admin, false); err != nil {
+		t.Fatalf("UpdateModule() error = %v", err)
+	}
+	if found, err := bound.GetModuleById(id, admin); err != nil || found.Description != "Refunds" {
+		t.Fatalf("GetModuleById() in the unit of work = %+v, %v, want the uncommitted update", found, err)
+	}
+	if _, err := service.GetModuleById(id, admin); err == nil {
+		t.Fatal("GetModuleById() outside the unit of work found the uncommitted module")
+	}
+
+	if err := uow.Complete(); err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	if err := uow.Dispose(); err != nil {
+		t.Fatalf("Dispose() after Complete() error = %v", err)
+	}
+	found, err := service.GetModuleById(id, admin)
+	if err != nil || found.Description != "Refunds" {
+		t.Fatalf("GetModuleById() after the commit = %+v, %v, want the committed update", found, err)
+	}
+	revisions, total, err := NewRevisionRepository(database).ListRevisions(created.ID, module.RevisionFilter{}, 0, 10)
+	if err != nil || total != 2 || revisions[1].Description != "Refunds" {
+		t.Errorf("ListRevisions() = %d revisions, %v, want the creation and the update", total, err)
+	}
+}
@@ -0,0 +1,41 @@
+package module
+
+import (
+	"go_di_architecture/internal/domain/models/module"
+	"sort"
+)
+
+func (r *InMemoryModuleRepository) AddUsage(counts []module.ModuleUsage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, count := range counts {
+		days, ok := r.usage[count.ModuleID]
+		if !ok {
+			days = make(map[string]*module.ModuleUsage)
+			r.usage[count.ModuleID] = days
+		}
+		counter, ok := days[count.Day]
+		if !ok {
+			counter = &module.ModuleUsage{ModuleID: count.ModuleID, Day: count.Day}
+			days[count.Day] = counter
+		}
+		counter.Reads += count.Reads
+		counter.Writes += count.Writes
+	}
+	return nil
+}
+
+func (r *InMemoryModuleRepository) ListUsage(moduleID int, fromDay, toDay string) ([]module.ModuleUsage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var counters []module.ModuleUsage
+	for day, counter := range r.usage[moduleID] {
+		if day >= fromDay && day <= toDay {
+			counters = append(counters, *counter)
+		}
+	}
+	sort.Slice(counters, func(i, j int) bool { return counters[i].Day < counters[j].Day })
+	return counters, nil
+}
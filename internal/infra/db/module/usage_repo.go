@@ -0,0 +1,90 @@
+package module
+
+import (
+	"go_di_architecture/internal/domain/models/module"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UsageRepository implements the data operations of the module usage counters.
+//
+// Counters are incremented with an upsert, so instances flushing the same
+// module and day concurrently add up instead of overwriting each other.
+//
+// Usage Context:
+//
+//	repo := NewUsageRepository(db)
+//	err := repo.AddUsage([]module.ModuleUsage{{ModuleID: 1, Day: "2023-08-15", Reads: 3}})
+type UsageRepository struct {
+	db *gorm.DB
+}
+
+// NewUsageRepository creates a repository with a specific database connection.
+//
+// Parameters:
+//   - db: Database connection to use
+//
+// Returns:
+//   - *UsageRepository: A new repository instance using the provided connection
+func NewUsageRepository(db *gorm.DB) *UsageRepository {
+	return &UsageRepository{db: db}
+}
+
+// AddUsage adds counts to the stored counters.
+//
+// Parameters:
+//   - counts: Reads and writes to add per module and day
+//
+// Returns:
+//   - error: Error if a statement fails (nothing is added in that case)
+//
+// Query Implementation (one transaction, per counter):
+//
+//	INSERT INTO module_usage (module_id, day, read_count, write_count) VALUES (?, ?, ?, ?)
+//	ON CONFLICT (module_id, day) DO UPDATE SET
+//	    read_count = module_usage.read_count + ?, write_count = module_usage.write_count + ?
+//	-- MySQL: ON DUPLICATE KEY UPDATE
+func (r *UsageRepository) AddUsage(counts []module.ModuleUsage) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, count := range counts {
+			counter := count
+			err := tx.Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "module_id"}, {Name: "day"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{
+					"read_count":  gorm.Expr("module_usage.read_count + ?", counter.Reads),
+					"write_count": gorm.Expr("module_usage.write_count + ?", counter.Writes),
+				}),
+			}).Create(&counter).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ListUsage retrieves the counters of a module in a range of days.
+//
+// Parameters:
+//   - moduleID: The module
+//   - fromDay: First day (YYYY-MM-DD)
+//   - toDay: Last day (YYYY-MM-DD)
+//
+// Returns:
+//   - []module.ModuleUsage: Counters ordered by day; days without requests are absent
+//   - error: Error if the query fails
+//
+// Query Implementation:
+//
+//	SELECT * FROM module_usage WHERE module_id = ? AND day BETWEEN ? AND ? ORDER BY day
+func (r *UsageRepository) ListUsage(moduleID int, fromDay, toDay string) ([]module.ModuleUsage, error) {
+	var counters []module.ModuleUsage
+	err := r.db.Where("module_id = ? AND day BETWEEN ? AND ?", moduleID, fromDay, toDay).
+		Order("day").
+		Find(&counters).Error
+	if err != nil {
+		return nil, err
+	}
+	return counters, nil
+}
@@ -0,0 +1,45 @@
+package module
+
+import (
+	"sort"
+
+	"go_di_architecture/internal/domain/models/workflow"
+)
+
+func (r *InMemoryModuleRepository) SaveWorkflow(w *workflow.Workflow) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := *w
+	r.workflows[w.ID] = &stored
+	return nil
+}
+
+func (r *InMemoryModuleRepository) GetWorkflow(id string) (*workflow.Workflow, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored, ok := r.workflows[id]
+	if !ok {
+		return nil, nil
+	}
+	found := *stored
+	return &found, nil
+}
+
+func (r *InMemoryModuleRepository) ListUnfinishedWorkflows() ([]*workflow.Workflow, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	unfinished := make([]*workflow.Workflow, 0)
+	for _, stored := range r.workflows {
+		if !stored.Finished() {
+			found := *stored
+			unfinished = append(unfinished, &found)
+		}
+	}
+	sort.Slice(unfinished, func(i, j int) bool {
+		return unfinished[i].CreatedAt.Before(unfinished[j].CreatedAt)
+	})
+	return unfinished, nil
+}
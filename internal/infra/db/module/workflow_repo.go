@@ -0,0 +1,79 @@
+package module
+
+import (
+	"errors"
+
+	"go_di_architecture/internal/domain/models/workflow"
+
+	"gorm.io/gorm"
+)
+
+// WorkflowRepository implements data operations for workflow states.
+//
+// Database Schema Details:
+//   - Table: workflows (index on status for resuming unfinished workflows)
+//   - The values of the steps are stored as a JSON document in a text column
+//
+// Usage Context:
+//
+//	repo := NewWorkflowRepository(db)
+//	unfinished, err := repo.ListUnfinishedWorkflows()
+type WorkflowRepository struct {
+	db *gorm.DB
+}
+
+// NewWorkflowRepository creates a repository with a specific database connection.
+//
+// Parameters:
+//   - db: Database connection to use
+//
+// Returns:
+//   - *WorkflowRepository: A new repository instance using the provided connection
+func NewWorkflowRepository(db *gorm.DB) *WorkflowRepository {
+	return &WorkflowRepository{db: db}
+}
+
+// SaveWorkflow inserts a workflow or replaces its stored state.
+//
+// Parameters:
+//   - w: Workflow to store
+//
+// Returns:
+//   - error: Error if the write fails
+func (r *WorkflowRepository) SaveWorkflow(w *workflow.Workflow) error {
+	return r.db.Save(w).Error
+}
+
+// GetWorkflow retrieves a workflow by ID.
+//
+// Parameters:
+//   - id: Identifier of the workflow
+//
+// Returns:
+//   - *workflow.Workflow: The workflow, nil if there is none
+//   - error: Error if database query fails
+func (r *WorkflowRepository) GetWorkflow(id string) (*workflow.Workflow, error) {
+	var w workflow.Workflow
+	err := r.db.First(&w, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+// ListUnfinishedWorkflows retrieves the running and compensating workflows.
+//
+// Returns:
+//   - []*workflow.Workflow: Unfinished workflows, oldest first
+//   - error: Error if database query fails
+func (r *WorkflowRepository) ListUnfinishedWorkflows() ([]*workflow.Workflow, error) {
+	var unfinished []*workflow.Workflow
+	err := r.db.
+		Where("status IN ?", []string{workflow.StatusRunning, workflow.StatusCompensating}).
+		Order("created_at ASC").
+		Find(&unfinished).Error
+	return unfinished, err
+}
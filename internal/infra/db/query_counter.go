@@ -0,0 +1,118 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"gorm.io/gorm"
+)
+
+// QueryCounter counts the statements run for tracked requests, to find
+// requests issuing a query per item of a list (N+1 queries).
+//
+// The tally of a request travels in its context: Track returns a context
+// carrying it, and a statement counts when its context (set with
+// db.WithContext) carries a tally. Repositories querying without a context
+// are not counted, so only the paths that pass the request context down show
+// up; statements of goroutines a request starts count as long as they use
+// its context.
+//
+// Usage Example:
+//
+//	counter := db.NewQueryCounter()
+//	if err := counter.Register(database); err != nil { ... }
+//	ctx, stop := counter.Track(ctx)
+//	// ... run the request with database.WithContext(ctx) ...
+//	statements, repeated, repeats := stop()
+type QueryCounter struct {
+	// Number of running trackings, read so untracked statements skip the
+	// context lookup while nothing is tracked
+	tracking atomic.Int64
+}
+
+// queryTallyKey is the context key of the tally of a tracked request.
+type queryTallyKey struct{}
+
+// queryTally holds the statements of one tracked request.
+type queryTally struct {
+	mu         sync.Mutex
+	statements int
+	repeats    map[string]int
+}
+
+// NewQueryCounter creates a counter tracking no request.
+//
+// Returns:
+//   - *QueryCounter: A new counter; Register attaches it to a connection
+func NewQueryCounter() *QueryCounter {
+	return &QueryCounter{}
+}
+
+// Register counts the statements run through a connection.
+//
+// Parameters:
+//   - db: Connection whose statements are counted
+//
+// Returns:
+//   - error: Error if a callback cannot be registered
+func (c *QueryCounter) Register(db *gorm.DB) error {
+	callbacks := db.Callback()
+	return errors.Join(
+		callbacks.Query().After("*").Register("querycount:record", c.record),
+		callbacks.Create().After("*").Register("querycount:record", c.record),
+		callbacks.Update().After("*").Register("querycount:record", c.record),
+		callbacks.Delete().After("*").Register("querycount:record", c.record),
+		callbacks.Raw().After("*").Register("querycount:record", c.record),
+		callbacks.Row().After("*").Register("querycount:record", c.record),
+	)
+}
+
+// Track starts counting the statements run with a context.
+//
+// Parameters:
+//   - ctx: Context of the request to track
+//
+// Returns:
+//   - context.Context: Derived context carrying the tally; statements count
+//     when it reaches them through db.WithContext
+//   - func() (int, string, int): Ends the tracking and reports the number of
+//     statements, the most repeated statement (SQL with placeholders) and
+//     how often it ran
+func (c *QueryCounter) Track(ctx context.Context) (context.Context, func() (statements int, repeated string, repeats int)) {
+	tally := &queryTally{repeats: make(map[string]int)}
+	c.tracking.Add(1)
+
+	var once sync.Once
+	return context.WithValue(ctx, queryTallyKey{}, tally), func() (int, string, int) {
+		once.Do(func() { c.tracking.Add(-1) })
+
+		tally.mu.Lock()
+		defer tally.mu.Unlock()
+		var repeated string
+		var repeats int
+		for sql, count := range tally.repeats {
+			if count > repeats || (count == repeats && sql < repeated) {
+				repeated, repeats = sql, count
+			}
+		}
+		return tally.statements, repeated, repeats
+	}
+}
+
+// record counts a statement whose context carries a tally.
+func (c *QueryCounter) record(tx *gorm.DB) {
+	if c.tracking.Load() == 0 || tx.Statement.SQL.Len() == 0 || tx.Statement.Context == nil {
+		return
+	}
+	tally, ok := tx.Statement.Context.Value(queryTallyKey{}).(*queryTally)
+	if !ok {
+		return
+	}
+
+	tally.mu.Lock()
+	defer tally.mu.Unlock()
+	tally.statements++
+	tally.repeats[tx.Statement.SQL.String()]++
+}
@@ -0,0 +1,140 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// statementStart is the prefix of the setting holding the start of a traced
+// statement.
+const statementStart = "statementtrace:start"
+
+// StatementTracer reports the statements run for traced requests with their
+// timings, for the breakdown of slow requests.
+//
+// Statements are attributed through their context like by QueryCounter:
+// Trace returns a context carrying the trace, and only statements run with
+// it (db.WithContext) are reported. Their SQL is reported with placeholders:
+// bound values such as module names stay out of the logs.
+//
+// Usage Example:
+//
+//	tracer := db.NewStatementTracer()
+//	if err := tracer.Register(database); err != nil { ... }
+//	ctx, stop := tracer.Trace(ctx, func(sql string, start time.Time, elapsed time.Duration, rows int64, err error) { ... })
+//	// ... run the request with database.WithContext(ctx) ...
+//	stop()
+type StatementTracer struct {
+	// Number of running traces, read so statements skip the timing while
+	// nothing is traced
+	tracing atomic.Int64
+}
+
+// statementTraceKey is the context key of the trace of a traced request.
+type statementTraceKey struct{}
+
+// statementTrace passes the statements of one traced request to its record
+// function, one at a time, until the trace is stopped.
+type statementTrace struct {
+	mu      sync.Mutex
+	stopped bool
+	record  func(sql string, start time.Time, elapsed time.Duration, rows int64, err error)
+}
+
+// NewStatementTracer creates a tracer tracing no request.
+//
+// Returns:
+//   - *StatementTracer: A new tracer; Register attaches it to a connection
+func NewStatementTracer() *StatementTracer {
+	return &StatementTracer{}
+}
+
+// Register traces the statements run through a connection.
+//
+// Parameters:
+//   - db: Connection whose statements are traced
+//
+// Returns:
+//   - error: Error if a callback cannot be registered
+func (t *StatementTracer) Register(db *gorm.DB) error {
+	callbacks := db.Callback()
+	return errors.Join(
+		callbacks.Query().Before("*").Register("statementtrace:start", t.start),
+		callbacks.Query().After("*").Register("statementtrace:end", t.end),
+		callbacks.Create().Before("*").Register("statementtrace:start", t.start),
+		callbacks.Create().After("*").Register("statementtrace:end", t.end),
+		callbacks.Update().Before("*").Register("statementtrace:start", t.start),
+		callbacks.Update().After("*").Register("statementtrace:end", t.end),
+		callbacks.Delete().Before("*").Register("statementtrace:start", t.start),
+		callbacks.Delete().After("*").Register("statementtrace:end", t.end),
+		callbacks.Raw().Before("*").Register("statementtrace:start", t.start),
+		callbacks.Raw().After("*").Register("statementtrace:end", t.end),
+		callbacks.Row().Before("*").Register("statementtrace:start", t.start),
+		callbacks.Row().After("*").Register("statementtrace:end", t.end),
+	)
+}
+
+// Trace starts reporting the statements run with a context.
+//
+// Parameters:
+//   - ctx: Context of the request to trace
+//   - record: Receives each finished statement: its SQL with placeholders,
+//     start, duration, affected or returned rows and error. Calls never
+//     overlap, also for statements of goroutines sharing the context
+//
+// Returns:
+//   - context.Context: Derived context carrying the trace; statements are
+//     reported when it reaches them through db.WithContext
+//   - func(): Ends the tracing; statements finishing later are not reported
+func (t *StatementTracer) Trace(ctx context.Context, record func(sql string, start time.Time, elapsed time.Duration, rows int64, err error)) (context.Context, func()) {
+	trace := &statementTrace{record: record}
+	t.tracing.Add(1)
+
+	return context.WithValue(ctx, statementTraceKey{}, trace), func() {
+		trace.mu.Lock()
+		defer trace.mu.Unlock()
+		if !trace.stopped {
+			trace.stopped = true
+			t.tracing.Add(-1)
+		}
+	}
+}
+
+// start remembers when a statement started while a request is traced.
+func (t *StatementTracer) start(tx *gorm.DB) {
+	if t.tracing.Load() == 0 {
+		return
+	}
+	tx.Statement.Settings.Store(startKey(tx.Statement), time.Now())
+}
+
+// end reports a statement whose context carries a trace.
+func (t *StatementTracer) end(tx *gorm.DB) {
+	value, ok := tx.Statement.Settings.LoadAndDelete(startKey(tx.Statement))
+	if !ok || tx.Statement.SQL.Len() == 0 || tx.Statement.Context == nil {
+		return
+	}
+	trace, ok := tx.Statement.Context.Value(statementTraceKey{}).(*statementTrace)
+	if !ok {
+		return
+	}
+	started := value.(time.Time)
+
+	trace.mu.Lock()
+	defer trace.mu.Unlock()
+	if !trace.stopped {
+		trace.record(tx.Statement.SQL.String(), started, time.Since(started), tx.RowsAffected, tx.Error)
+	}
+}
+
+// startKey is the setting holding the start of one statement, like
+// deadlineKey.
+func startKey(stmt *gorm.Statement) string {
+	return fmt.Sprintf("%s:%p", statementStart, stmt)
+}
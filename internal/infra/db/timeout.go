@@ -0,0 +1,159 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go_di_architecture/internal/domain/apperror"
+
+	"gorm.io/gorm"
+)
+
+// ErrQueryTimeout is returned by a statement that ran out of its time; the
+// API reports it as 504 UPSTREAM_TIMEOUT.
+var ErrQueryTimeout = apperror.New(http.StatusGatewayTimeout, "UPSTREAM_TIMEOUT", "database.timeout", "database query timed out")
+
+// Keys of the statement settings of the timeouts: the override of a chain
+// and the deadline of a running statement.
+const (
+	timeoutSetting  = "query_timeout"
+	timeoutDeadline = "query_timeout:deadline"
+)
+
+// Timeouts are the default deadlines of statements by kind of operation.
+type Timeouts struct {
+	// Deadline of reads: First, Find, Count, Pluck and Scan, including their
+	// preloads (zero disables it)
+	Read time.Duration
+
+	// Deadline of writes: Create, Save, Update, Delete and Exec, including
+	// the default transaction around them (zero disables it)
+	Write time.Duration
+}
+
+// statementDeadline remembers what a statement's deadline replaced.
+type statementDeadline struct {
+	parent context.Context
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// EnforceTimeouts bounds every statement of a connection with a deadline.
+//
+// Repositories mostly query without a context, so a hanging database would
+// hold request goroutines for as long as the driver waits. The callbacks give
+// each statement a context with the timeout of its kind (the caller's own
+// deadline still applies when it is earlier) and cancel it once the statement
+// finished, so no timer outlives it. A statement that ran out of time fails
+// with ErrQueryTimeout wrapping the driver error. The postgres and mysql
+// drivers abort the statement on the server; SQLite finishes the step it is
+// running first.
+//
+// Row, Rows and Scan read their rows after the statement callbacks ran, so
+// their deadline is not cancelled early but bounds reading the rows too; the
+// timer releases it once it fires. A deadline passing while the rows are
+// read fails the read with context.DeadlineExceeded.
+//
+// Parameters:
+//   - db: Database connection to bound
+//   - timeouts: Deadlines of reads and writes
+//
+// Returns:
+//   - error: Error if a callback cannot be registered
+func EnforceTimeouts(db *gorm.DB, timeouts Timeouts) error {
+	callbacks := db.Callback()
+	read, write := startDeadline(timeouts.Read), startDeadline(timeouts.Write)
+
+	return errors.Join(
+		callbacks.Query().Before("*").Register("timeout:start", read),
+		callbacks.Query().After("*").Register("timeout:end", endDeadline),
+		callbacks.Create().Before("*").Register("timeout:start", write),
+		callbacks.Create().After("*").Register("timeout:end", endDeadline),
+		callbacks.Update().Before("*").Register("timeout:start", write),
+		callbacks.Update().After("*").Register("timeout:end", endDeadline),
+		callbacks.Delete().Before("*").Register("timeout:start", write),
+		callbacks.Delete().After("*").Register("timeout:end", endDeadline),
+		callbacks.Raw().Before("*").Register("timeout:start", write),
+		callbacks.Raw().After("*").Register("timeout:end", endDeadline),
+		callbacks.Row().Before("*").Register("timeout:start", read),
+		callbacks.Row().After("*").Register("timeout:end", endRowsDeadline),
+	)
+}
+
+// WithQueryTimeout overrides the timeout of the statements run through a
+// connection, for repository calls known to take longer (or shorter) than
+// the defaults.
+//
+// Usage Example:
+//
+//	err := db.WithQueryTimeout(r.db, time.Minute).Model(&module.ModuleRevision{}).Count(&count).Error
+//
+// Parameters:
+//   - tx: Connection or statement chain the override applies to
+//   - timeout: Deadline of its statements (zero removes the deadline)
+//
+// Returns:
+//   - *gorm.DB: A session with the override, safe to run several statements
+//     with; tx itself is unchanged
+func WithQueryTimeout(tx *gorm.DB, timeout time.Duration) *gorm.DB {
+	return tx.Set(timeoutSetting, timeout).Session(&gorm.Session{})
+}
+
+// startDeadline returns the callback giving a statement its deadline.
+func startDeadline(timeout time.Duration) func(*gorm.DB) {
+	return func(tx *gorm.DB) {
+		limit := timeout
+		if override, ok := tx.Get(timeoutSetting); ok {
+			limit = override.(time.Duration)
+		}
+		if limit <= 0 {
+			return
+		}
+
+		parent := tx.Statement.Context
+		ctx, cancel := context.WithTimeout(parent, limit)
+		tx.Statement.Context = ctx
+		tx.Statement.Settings.Store(deadlineKey(tx.Statement), statementDeadline{parent: parent, ctx: ctx, cancel: cancel})
+	}
+}
+
+// endDeadline releases the deadline of a statement and reports its expiry.
+//
+// The context of the statement is restored, so a chain running several
+// statements (e.g. Count, then Find) gives each one a deadline of its own.
+func endDeadline(tx *gorm.DB) {
+	if deadline, ok := restoreContext(tx); ok {
+		deadline.cancel()
+	}
+}
+
+// endRowsDeadline restores the context of a Row or Rows statement and
+// reports the expiry of its deadline, which keeps bounding the rows.
+func endRowsDeadline(tx *gorm.DB) {
+	restoreContext(tx)
+}
+
+// restoreContext puts back the context a statement deadline replaced and
+// turns an error caused by the expired deadline into ErrQueryTimeout.
+func restoreContext(tx *gorm.DB) (statementDeadline, bool) {
+	value, ok := tx.Statement.Settings.LoadAndDelete(deadlineKey(tx.Statement))
+	if !ok {
+		return statementDeadline{}, false
+	}
+	deadline := value.(statementDeadline)
+	tx.Statement.Context = deadline.parent
+
+	if errors.Is(deadline.ctx.Err(), context.DeadlineExceeded) && tx.Error != nil && !errors.Is(tx.Error, ErrQueryTimeout) {
+		tx.Error = ErrQueryTimeout.Wrap(tx.Error)
+	}
+	return deadline, true
+}
+
+// deadlineKey is the setting holding the deadline of one statement; settings
+// are copied to the statements derived from it, which get keys of their own.
+func deadlineKey(stmt *gorm.Statement) string {
+	return fmt.Sprintf("%s:%p", timeoutDeadline, stmt)
+}
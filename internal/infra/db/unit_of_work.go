@@ -0,0 +1,179 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// UnitOfWork is the transaction of one request.
+//
+// The transaction begins when a repository first joins it, so a request that
+// never reaches the database costs nothing. Complete commits it; Dispose,
+// called when the request scope ends, rolls back a transaction that was not
+// completed, such as that of a request that failed or panicked.
+//
+// Repositories join the unit of work carried by the context they are bound
+// to (see ForContext), so every write of a request commits or rolls back
+// together.
+//
+// Usage Example:
+//
+//	uow := db.NewUnitOfWork(database)
+//	defer uow.Dispose()
+//	ctx = uow.Bind(ctx)
+//	// ... run the repositories bound to ctx ...
+//	err := uow.Complete()
+type UnitOfWork struct {
+	db *gorm.DB
+
+	mu        sync.Mutex
+	tx        *gorm.DB
+	done      bool
+	callbacks []func()
+}
+
+// unitOfWorkKey is the context key of the unit of work of a request.
+type unitOfWorkKey struct{}
+
+// ErrUnitOfWorkDone reports a statement run after the unit of work ended.
+var ErrUnitOfWorkDone = errors.New("unit of work already completed or disposed")
+
+// NewUnitOfWork creates a unit of work on a connection.
+//
+// Parameters:
+//   - db: Connection the transaction is begun on
+//
+// Returns:
+//   - *UnitOfWork: A unit of work with no transaction begun yet
+func NewUnitOfWork(db *gorm.DB) *UnitOfWork {
+	return &UnitOfWork{db: db}
+}
+
+// DB returns the transaction, beginning it on the first call.
+//
+// The transaction is begun without the cancellation of ctx, which would roll
+// it back behind the unit of work; statements run with ctx itself, so they
+// are still counted and traced for the request.
+//
+// Parameters:
+//   - ctx: Context of the statements
+//
+// Returns:
+//   - *gorm.DB: The transaction bound to ctx; its Error is set when the
+//     transaction could not be begun or the unit of work has ended
+func (u *UnitOfWork) DB(ctx context.Context) *gorm.DB {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.done {
+		failed := u.db.Session(&gorm.Session{NewDB: true})
+		failed.AddError(ErrUnitOfWorkDone)
+		return failed
+	}
+	if u.tx == nil {
+		u.tx = u.db.WithContext(context.WithoutCancel(ctx)).Begin()
+	}
+	return u.tx.WithContext(ctx)
+}
+
+// AfterEnd registers a function to run once the transaction commits or
+// rolls back, such as the invalidation of the cache entries of the rows it
+// wrote.
+//
+// Parameters:
+//   - fn: Function to run after the transaction
+func (u *UnitOfWork) AfterEnd(fn func()) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.callbacks = append(u.callbacks, fn)
+}
+
+// Complete commits the transaction, if one was begun.
+//
+// Returns:
+//   - error: Error of the commit, after which the transaction is rolled back
+func (u *UnitOfWork) Complete() error {
+	return u.end(func(tx *gorm.DB) error {
+		if tx.Error != nil {
+			tx.Rollback()
+			return tx.Error
+		}
+		return tx.Commit().Error
+	})
+}
+
+// Dispose rolls back the transaction unless it was completed.
+//
+// Returns:
+//   - error: Error of the rollback
+func (u *UnitOfWork) Dispose() error {
+	return u.end(func(tx *gorm.DB) error {
+		return tx.Rollback().Error
+	})
+}
+
+// end finishes the transaction once and runs the AfterEnd functions.
+func (u *UnitOfWork) end(finish func(tx *gorm.DB) error) error {
+	u.mu.Lock()
+	if u.done {
+		u.mu.Unlock()
+		return nil
+	}
+	u.done = true
+	tx, callbacks := u.tx, u.callbacks
+	u.callbacks = nil
+	u.mu.Unlock()
+
+	var err error
+	if tx != nil {
+		err = finish(tx)
+	}
+	for _, fn := range callbacks {
+		fn()
+	}
+	return err
+}
+
+// Bind returns a copy of the context carrying the unit of work.
+//
+// Parameters:
+//   - ctx: Parent context
+//
+// Returns:
+//   - context.Context: A context whose repositories join the unit of work
+func (u *UnitOfWork) Bind(ctx context.Context) context.Context {
+	return context.WithValue(ctx, unitOfWorkKey{}, u)
+}
+
+// UnitOfWorkFrom returns the unit of work carried by the context, if any.
+//
+// Parameters:
+//   - ctx: Context possibly carrying a unit of work
+//
+// Returns:
+//   - *UnitOfWork: The unit of work
+//   - bool: False if the context carries none
+func UnitOfWorkFrom(ctx context.Context) (*UnitOfWork, bool) {
+	uow, ok := ctx.Value(unitOfWorkKey{}).(*UnitOfWork)
+	return uow, ok
+}
+
+// ForContext returns the connection a repository runs the statements of a
+// context with: the transaction of the unit of work the context carries, or
+// the repository's own connection bound to the context.
+//
+// Parameters:
+//   - ctx: Context of the statements
+//   - db: Connection of the repository
+//
+// Returns:
+//   - *gorm.DB: The connection to run the statements with
+func ForContext(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if uow, ok := UnitOfWorkFrom(ctx); ok {
+		return uow.DB(ctx)
+	}
+	return db.WithContext(ctx)
+}
@@ -0,0 +1,195 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"expvar"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// databaseStats are the health and pool figures of the database, exported as
+// the "database" expvar:
+//   - healthy: 1 while the last ping succeeded, else 0
+//   - pings_failed: Pings that failed or timed out
+//   - outages: Times the database became unreachable
+//   - last_error: Error of the last failed ping ("" while healthy)
+//   - pool: Connection pool statistics read on every request (open, in_use,
+//     idle, max_open, wait_count, wait_duration_ms, max_idle_closed,
+//     max_lifetime_closed)
+var databaseStats = expvar.NewMap("database")
+
+// DefaultHealthInterval is how often the watchdog pings when no interval is
+// configured.
+const DefaultHealthInterval = 10 * time.Second
+
+// maxPingTimeout bounds a single ping, so a hanging server is reported as
+// unreachable instead of blocking the watchdog.
+const maxPingTimeout = 5 * time.Second
+
+// Watchdog pings the database periodically and keeps its reachability.
+//
+// A ping borrows a connection from the pool like any query: database/sql
+// discards connections the driver reports as broken and dials new ones, so
+// after an outage the pool reconnects on the first successful ping, before
+// requests need it. Only transitions are logged (reachable to unreachable and
+// back), not every failed ping.
+//
+// Lifecycle:
+//   - Start pings once and launches the ping loop
+//   - Stop ends the loop
+//
+// Usage Example:
+//
+//	watchdog := db.NewWatchdog(sqlDB, 10*time.Second)
+//	lc.Append(lifecycle.Hook{Name: "db.watchdog", OnStart: watchdog.Start, OnStop: watchdog.Stop})
+//	if err := watchdog.Err(); err != nil { ... }
+type Watchdog struct {
+	db       *sql.DB
+	interval time.Duration
+
+	mu      sync.RWMutex
+	lastErr error
+	since   time.Time
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewWatchdog creates a watchdog of a connection pool and publishes the pool
+// statistics.
+//
+// Parameters:
+//   - db: Connection pool to ping
+//   - interval: Time between pings (DefaultHealthInterval when zero)
+//
+// Returns:
+//   - *Watchdog: The watchdog, reporting the database reachable until the
+//     first ping
+func NewWatchdog(db *sql.DB, interval time.Duration) *Watchdog {
+	if interval <= 0 {
+		interval = DefaultHealthInterval
+	}
+	w := &Watchdog{db: db, interval: interval, since: time.Now()}
+
+	databaseStats.Set("healthy", new(expvar.Int))
+	databaseStats.Set("pings_failed", new(expvar.Int))
+	databaseStats.Set("outages", new(expvar.Int))
+	databaseStats.Set("last_error", new(expvar.String))
+	databaseStats.Set("pool", expvar.Func(w.poolStats))
+	databaseStats.Get("healthy").(*expvar.Int).Set(1)
+
+	return w
+}
+
+// Err reports why the database is unreachable.
+//
+// It returns the state of the last ping and never queries the database, so
+// it is cheap enough for every readiness probe.
+//
+// Returns:
+//   - error: Error of the last ping, nil while the database is reachable
+func (w *Watchdog) Err() error {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.lastErr == nil {
+		return nil
+	}
+	return fmt.Errorf("database unreachable since %s: %w", w.since.Format(time.RFC3339), w.lastErr)
+}
+
+// Start pings once and launches the ping loop; the loop outlives the start
+// context.
+func (w *Watchdog) Start(ctx context.Context) error {
+	w.ping(ctx)
+
+	loopCtx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+	w.done = make(chan struct{})
+	go w.loop(loopCtx)
+
+	return nil
+}
+
+// Stop ends the ping loop.
+func (w *Watchdog) Stop(ctx context.Context) error {
+	if w.cancel == nil {
+		return nil
+	}
+	w.cancel()
+
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("database watchdog: %w", ctx.Err())
+	}
+}
+
+// loop pings on every tick until cancelled.
+func (w *Watchdog) loop(ctx context.Context) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.ping(ctx)
+		}
+	}
+}
+
+// ping checks the database and records a change of its reachability.
+func (w *Watchdog) ping(ctx context.Context) {
+	pingCtx, cancel := context.WithTimeout(ctx, min(w.interval, maxPingTimeout))
+	defer cancel()
+
+	err := w.db.PingContext(pingCtx)
+	if ctx.Err() != nil {
+		// Cancelled by shutdown, not an outage
+		return
+	}
+	if err != nil {
+		databaseStats.Add("pings_failed", 1)
+		databaseStats.Get("last_error").(*expvar.String).Set(err.Error())
+	}
+
+	w.mu.Lock()
+	wasHealthy := w.lastErr == nil
+	w.lastErr = err
+	if wasHealthy != (err == nil) {
+		w.since = time.Now()
+	}
+	w.mu.Unlock()
+
+	switch {
+	case wasHealthy && err != nil:
+		databaseStats.Add("outages", 1)
+		databaseStats.Get("healthy").(*expvar.Int).Set(0)
+		logger.Warnf("Database unreachable, marking the instance degraded: %v", err)
+	case !wasHealthy && err == nil:
+		databaseStats.Get("healthy").(*expvar.Int).Set(1)
+		databaseStats.Get("last_error").(*expvar.String).Set("")
+		logger.Infof("Database reachable again, connection pool reconnected")
+	}
+}
+
+// poolStats reads the statistics of the connection pool.
+func (w *Watchdog) poolStats() any {
+	stats := w.db.Stats()
+	return map[string]int64{
+		"open":                int64(stats.OpenConnections),
+		"in_use":              int64(stats.InUse),
+		"idle":                int64(stats.Idle),
+		"max_open":            int64(stats.MaxOpenConnections),
+		"wait_count":          stats.WaitCount,
+		"wait_duration_ms":    stats.WaitDuration.Milliseconds(),
+		"max_idle_closed":     stats.MaxIdleClosed,
+		"max_lifetime_closed": stats.MaxLifetimeClosed,
+	}
+}
@@ -0,0 +1,174 @@
+// Package external integrates third-party services behind the interfaces of
+// the domain layer.
+//
+// Each integration is an anti-corruption layer: the vendor's JSON is decoded
+// into unexported DTOs mirroring the vendor's API, translated into domain
+// types in one place, and vendor errors are mapped onto the domain's errors.
+// Nothing of the vendor's vocabulary (field names, codes, status values)
+// leaks past the package, so a vendor change touches its integration only.
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"go_di_architecture/internal/domain/licensing"
+	"go_di_architecture/internal/infra/httpclient"
+)
+
+// licenseDTO is a license as the licensing service returns it from
+// GET /v2/licenses/<key>.
+type licenseDTO struct {
+	LicenseKey string `json:"license_key"`
+	Tier       string `json:"tier"`
+	SeatLimit  int    `json:"seat_limit"`
+	Expires    int64  `json:"expires"`
+	Status     string `json:"status"`
+}
+
+// errorDTO is the error body of the licensing service.
+type errorDTO struct {
+	Code   string `json:"code"`
+	Detail string `json:"detail"`
+}
+
+// Tiers of the licensing service mapped to the product editions
+var tierEditions = map[string]licensing.Edition{
+	"COMM": licensing.EditionCommunity,
+	"TEAM": licensing.EditionTeam,
+	"ENT":  licensing.EditionEnterprise,
+}
+
+// LicensingClient looks up licenses on the REST API of the licensing service:
+//   - GET <url>/v2/licenses/<key>, answered 200 with the license or 404
+//     with {"code": "LICENSE_NOT_FOUND"}
+//
+// The API key is sent as "X-Api-Key". The client's retries cover network
+// errors, 5xx and 429 responses; lookups are reads, so repeating them is
+// safe. Failures after the retries, and an open circuit breaker, are
+// reported as licensing.ErrUnavailable.
+//
+// Usage Example:
+//
+//	client := httpclient.New("licensing", httpclient.Config{Timeout: 5 * time.Second, MaxRetries: 2, RetryBackoff: 200 * time.Millisecond})
+//	licenses := external.NewLicensingClient("https://licensing.example.com", apiKey, client)
+//	license, err := licenses.Lookup(ctx, "ACME-1234")
+type LicensingClient struct {
+	url    string
+	apiKey string
+	client *httpclient.Client
+}
+
+// NewLicensingClient creates a client of the licensing API at the base URL.
+//
+// Parameters:
+//   - baseURL: Base URL of the licensing service
+//   - apiKey: API key of the application (empty sends none)
+//   - client: Client sending the requests, with its timeout, retries and breaker
+//
+// Returns:
+//   - *LicensingClient: A new client
+func NewLicensingClient(baseURL, apiKey string, client *httpclient.Client) *LicensingClient {
+	return &LicensingClient{url: strings.TrimRight(baseURL, "/"), apiKey: apiKey, client: client}
+}
+
+// Lookup returns the license with the key.
+//
+// Parameters:
+//   - ctx: Context bounding the request and its retries
+//   - key: License key
+//
+// Returns:
+//   - *licensing.License: The license
+//   - error: Error if the license is unknown, the service is unavailable or
+//     its answer cannot be translated
+//
+// Error Types:
+//   - licensing.ErrUnknownLicense: When the service does not know the key
+//   - licensing.ErrUnavailable: When the service cannot be reached or fails
+func (c *LicensingClient) Lookup(ctx context.Context, key string) (*licensing.License, error) {
+	endpoint := c.url + "/v2/licenses/" + url.PathEscape(key)
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Accept", "application/json")
+	if c.apiKey != "" {
+		request.Header.Set("X-Api-Key", c.apiKey)
+	}
+
+	// Step 1: Send the request; the client retries transient failures
+	response, err := c.client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", licensing.ErrUnavailable, err)
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(response.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", licensing.ErrUnavailable, err)
+	}
+
+	// Step 2: Map the vendor's errors onto the domain errors
+	switch {
+	case response.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("%w: %s", licensing.ErrUnknownLicense, key)
+	case response.StatusCode >= 500 || response.StatusCode == http.StatusTooManyRequests:
+		return nil, fmt.Errorf("%w: GET %s: status %d%s", licensing.ErrUnavailable, endpoint, response.StatusCode, vendorError(body))
+	case response.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("GET %s: unexpected status %d%s", endpoint, response.StatusCode, vendorError(body))
+	}
+
+	// Step 3: Translate the vendor's license
+	var dto licenseDTO
+	if err := json.Unmarshal(body, &dto); err != nil {
+		return nil, fmt.Errorf("GET %s: invalid license: %w", endpoint, err)
+	}
+	return toLicense(dto)
+}
+
+// toLicense translates a license of the licensing service into the domain
+// license.
+func toLicense(dto licenseDTO) (*licensing.License, error) {
+	edition, ok := tierEditions[dto.Tier]
+	if !ok {
+		return nil, fmt.Errorf("license %s has unknown tier %q", dto.LicenseKey, dto.Tier)
+	}
+
+	license := &licensing.License{
+		Key:     dto.LicenseKey,
+		Edition: edition,
+		Seats:   dto.SeatLimit,
+	}
+	if dto.SeatLimit < 0 {
+		license.Seats = 0
+	}
+	if dto.Expires > 0 {
+		license.ExpiresAt = time.Unix(dto.Expires, 0).UTC()
+	}
+	switch strings.ToLower(dto.Status) {
+	case "active", "trial":
+	case "suspended", "revoked":
+		license.Suspended = true
+	default:
+		return nil, fmt.Errorf("license %s has unknown status %q", dto.LicenseKey, dto.Status)
+	}
+	return license, nil
+}
+
+// vendorError formats the error body of the licensing service, if any.
+func vendorError(body []byte) string {
+	var dto errorDTO
+	if err := json.Unmarshal(body, &dto); err != nil || dto.Code == "" {
+		return ""
+	}
+	if dto.Detail == "" {
+		return " (" + dto.Code + ")"
+	}
+	return " (" + dto.Code + ": " + dto.Detail + ")"
+}
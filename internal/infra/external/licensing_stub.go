@@ -0,0 +1,73 @@
+package external
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go_di_architecture/internal/domain/licensing"
+)
+
+// StubLicensing serves licenses from memory instead of the licensing service.
+//
+// It stands in for LicensingClient during local development and in tests:
+// licenses are added with Put, unknown keys fail with
+// licensing.ErrUnknownLicense, and SetUnavailable simulates an outage of the
+// service. It is safe for concurrent use.
+//
+// Usage Example:
+//
+//	licenses := external.NewStubLicensing(licensing.License{Key: "DEV", Edition: licensing.EditionEnterprise})
+//	licenses.SetUnavailable(true) // lookups now fail with licensing.ErrUnavailable
+type StubLicensing struct {
+	mu          sync.RWMutex
+	licenses    map[string]licensing.License
+	unavailable bool
+}
+
+// NewStubLicensing creates a stub serving the licenses.
+//
+// Parameters:
+//   - licenses: Licenses to serve, by key
+//
+// Returns:
+//   - *StubLicensing: A new stub
+func NewStubLicensing(licenses ...licensing.License) *StubLicensing {
+	s := &StubLicensing{licenses: make(map[string]licensing.License, len(licenses))}
+	for _, license := range licenses {
+		s.licenses[license.Key] = license
+	}
+	return s
+}
+
+// Put adds or replaces a license.
+func (s *StubLicensing) Put(license licensing.License) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.licenses[license.Key] = license
+}
+
+// SetUnavailable makes the lookups fail with licensing.ErrUnavailable until it
+// is called with false.
+func (s *StubLicensing) SetUnavailable(unavailable bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.unavailable = unavailable
+}
+
+// Lookup returns a copy of the license with the key.
+func (s *StubLicensing) Lookup(_ context.Context, key string) (*licensing.License, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.unavailable {
+		return nil, fmt.Errorf("%w: stub", licensing.ErrUnavailable)
+	}
+	license, ok := s.licenses[key]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", licensing.ErrUnknownLicense, key)
+	}
+	return &license, nil
+}
@@ -0,0 +1,242 @@
+package httpclient
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"expvar"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// clientStats are the counters of the outbound clients, exported as the
+// "http_client" expvar:
+//   - <name>.requests: Attempts sent, retries included
+//   - <name>.retries: Attempts repeated after a failure
+//   - <name>.errors: Attempts that got no response (timeouts, refused connections)
+//   - <name>.rejected: Calls refused without a request while the breaker is open
+//   - <name>.2xx, <name>.4xx, <name>.5xx, ...: Responses by status class
+var clientStats = expvar.NewMap("http_client")
+
+// ErrCircuitOpen is returned without sending a request while the circuit
+// breaker of a client is open.
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// Config holds the resilience settings of a client.
+type Config struct {
+	// Bound of a single attempt, including reading the response body (zero
+	// leaves attempts unbounded)
+	Timeout time.Duration
+
+	// Attempts repeated after a network error, 5xx or 429 response
+	MaxRetries int
+
+	// Wait before the first retry, doubled for every further retry
+	RetryBackoff time.Duration
+
+	// Consecutive failed calls opening the breaker (zero disables it)
+	BreakerThreshold int
+
+	// How long an open breaker rejects calls before letting one through
+	BreakerCooldown time.Duration
+
+	// Egress proxy of the requests (nil uses HTTPS_PROXY, HTTP_PROXY and
+	// NO_PROXY from the environment)
+	Proxy *url.URL
+
+	// Hosts reached without Proxy, in NO_PROXY syntax (e.g.
+	// "localhost,.internal,10.0.0.0/8")
+	NoProxy string
+
+	// Certificate authorities trusted for TLS (nil for the system roots)
+	RootCAs *x509.CertPool
+
+	// Lowest accepted TLS version, e.g. tls.VersionTLS13 (zero for the Go
+	// default, TLS 1.2)
+	TLSMinVersion uint16
+}
+
+// Client sends the outbound HTTP requests of one integration.
+//
+// All outbound calls share its behavior:
+//   - The request ID and trace of the incoming request are propagated as
+//     X-Request-Id and W3C traceparent headers (see WithRequestID and
+//     WithTraceID); calls outside a request start a new trace
+//   - Every attempt is bounded by the timeout
+//   - Requests leave through the egress proxy and TLS connections trust the
+//     configured certificate authorities and TLS versions only
+//   - Network errors, 5xx and 429 responses are retried with exponential
+//     backoff, as long as the body can be sent again (no body, or GetBody set
+//     as http.NewRequest does for in-memory readers)
+//   - After BreakerThreshold consecutive failed calls the breaker opens and
+//     calls fail with ErrCircuitOpen until the cooldown has passed; then a
+//     single call probes the destination and closes the breaker on success
+//   - Attempts and responses are counted in the "http_client" expvar
+//
+// Retried requests may reach the destination more than once, so only
+// integrations tolerating duplicates (idempotent uploads, notifications
+// delivered at least once) should enable retries for non-idempotent methods.
+//
+// Usage Example:
+//
+//	client := httpclient.New("webhook", httpclient.Config{Timeout: 10 * time.Second, MaxRetries: 2})
+//	response, err := client.Do(request)
+type Client struct {
+	name    string
+	config  Config
+	http    *http.Client
+	breaker *breaker
+	stats   *expvar.Map
+}
+
+// New creates a client and publishes its counters.
+//
+// Parameters:
+//   - name: Name of the integration, keying its counters (e.g. "webhook");
+//     clients with the same name share them
+//   - config: Timeout, retry, breaker and egress settings
+//
+// Returns:
+//   - *Client: A new client
+func New(name string, config Config) *Client {
+	stats, ok := clientStats.Get(name).(*expvar.Map)
+	if !ok {
+		stats = new(expvar.Map).Init()
+		clientStats.Set(name, stats)
+	}
+
+	return &Client{
+		name:    name,
+		config:  config,
+		http:    &http.Client{Timeout: config.Timeout, Transport: transport(config)},
+		breaker: &breaker{threshold: config.BreakerThreshold, cooldown: config.BreakerCooldown},
+		stats:   stats,
+	}
+}
+
+// Do sends a request, retrying it on transient failures.
+//
+// Parameters:
+//   - request: The request; its context bounds all attempts and the backoff
+//     between them, and carries the request ID and trace to propagate
+//
+// Returns:
+//   - *http.Response: The response of the last attempt, which may be a 5xx
+//     or 429 when the retries are exhausted; the caller closes its body
+//   - error: ErrCircuitOpen while the breaker is open, or the error of the
+//     last attempt
+func (c *Client) Do(request *http.Request) (*http.Response, error) {
+	ctx := request.Context()
+
+	// Step 1: Fail fast while the destination is known to be down
+	if !c.breaker.allow(time.Now()) {
+		c.stats.Add("rejected", 1)
+		return nil, fmt.Errorf("%s: %s %s: %w", c.name, request.Method, request.URL.Redacted(), ErrCircuitOpen)
+	}
+
+	trace := traceOf(request)
+	backoff := c.config.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		// Step 2: Send the attempt as a new span of the trace
+		propagate(request, trace)
+		c.stats.Add("requests", 1)
+		response, err := c.http.Do(request)
+		if err != nil {
+			c.stats.Add("errors", 1)
+		} else {
+			c.stats.Add(fmt.Sprintf("%dxx", response.StatusCode/100), 1)
+		}
+
+		if !retryable(response, err) {
+			c.breaker.record(true, time.Now())
+			return response, err
+		}
+
+		// Step 3: Give up when the caller is gone, the retries are used up or
+		// the body cannot be sent again
+		if ctx.Err() != nil {
+			c.breaker.release()
+			return response, err
+		}
+		if attempt >= c.config.MaxRetries || !rewind(request) {
+			c.breaker.record(false, time.Now())
+			return response, err
+		}
+		if response != nil {
+			_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 4096))
+			response.Body.Close()
+		}
+
+		// Step 4: Back off before the next attempt
+		if err := sleep(ctx, backoff); err != nil {
+			c.breaker.release()
+			return nil, err
+		}
+		backoff *= 2
+		c.stats.Add("retries", 1)
+	}
+}
+
+// transport builds the connection pool of a client from the egress settings.
+func transport(config Config) *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.TLSClientConfig = &tls.Config{RootCAs: config.RootCAs, MinVersion: config.TLSMinVersion}
+
+	if config.Proxy != nil {
+		proxy := (&httpproxy.Config{
+			HTTPProxy:  config.Proxy.String(),
+			HTTPSProxy: config.Proxy.String(),
+			NoProxy:    config.NoProxy,
+		}).ProxyFunc()
+		t.Proxy = func(request *http.Request) (*url.URL, error) {
+			return proxy(request.URL)
+		}
+	}
+	return t
+}
+
+// retryable reports whether an attempt failed in a way another attempt may fix.
+func retryable(response *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return response.StatusCode >= http.StatusInternalServerError || response.StatusCode == http.StatusTooManyRequests
+}
+
+// rewind prepares the body of a request for another attempt.
+func rewind(request *http.Request) bool {
+	if request.Body == nil || request.Body == http.NoBody {
+		return true
+	}
+	if request.GetBody == nil {
+		return false
+	}
+	body, err := request.GetBody()
+	if err != nil {
+		return false
+	}
+	request.Body = body
+	return true
+}
+
+// sleep waits for the duration unless the context ends first.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,92 @@
+package httpclient
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// Propagated headers
+const (
+	// RequestIDHeader carries the ID of the incoming request
+	RequestIDHeader = "X-Request-Id"
+
+	// TraceparentHeader carries the W3C Trace Context of the call
+	TraceparentHeader = "traceparent"
+)
+
+// contextKey keys the values the client propagates.
+type contextKey int
+
+const (
+	requestIDKey contextKey = iota
+	traceIDKey
+)
+
+// WithRequestID returns a context whose outbound calls send the request ID.
+//
+// Parameters:
+//   - ctx: The parent context
+//   - requestID: ID of the incoming request
+//
+// Returns:
+//   - context.Context: The context carrying the ID
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// RequestID returns the request ID carried by a context.
+//
+// Returns:
+//   - string: The ID, or "" outside a request
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// WithTraceID returns a context whose outbound calls join the trace.
+//
+// Parameters:
+//   - ctx: The parent context
+//   - traceID: 32-character lower-case hex W3C trace ID
+//
+// Returns:
+//   - context.Context: The context carrying the trace
+func WithTraceID(ctx context.Context, traceID string) context.Context {
+	return context.WithValue(ctx, traceIDKey, traceID)
+}
+
+// TraceID returns the trace ID carried by a context.
+//
+// Returns:
+//   - string: The trace ID, or "" outside a traced request
+func TraceID(ctx context.Context) string {
+	id, _ := ctx.Value(traceIDKey).(string)
+	return id
+}
+
+// traceOf returns the trace a request joins: the one of its context, or a
+// new one shared by all attempts of the call.
+func traceOf(request *http.Request) string {
+	if trace := TraceID(request.Context()); trace != "" {
+		return trace
+	}
+	return randomHex(16)
+}
+
+// propagate sets the request ID and a traceparent with a new span ID, e.g.
+// 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01, on a request.
+func propagate(request *http.Request, trace string) {
+	if id := RequestID(request.Context()); id != "" {
+		request.Header.Set(RequestIDHeader, id)
+	}
+	request.Header.Set(TraceparentHeader, "00-"+trace+"-"+randomHex(8)+"-01")
+}
+
+// randomHex returns n random bytes as lower-case hex.
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,106 @@
+package lock
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisLeaseKeyPrefix namespaces the lease keys.
+const redisLeaseKeyPrefix = "leader:"
+
+// redisAcquireScript takes a free lease, renews the caller's own and returns
+// the holder after the call.
+var redisAcquireScript = redis.NewScript(`local holder = redis.call("GET", KEYS[1])
+if not holder then
+	redis.call("SET", KEYS[1], ARGV[1], "PX", ARGV[2])
+	return ARGV[1]
+end
+if holder == ARGV[1] then
+	redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return holder`)
+
+// redisReleaseScript deletes the key only while it still names the holder,
+// so a holder whose lease expired cannot release the next holder's lease.
+var redisReleaseScript = redis.NewScript(`if redis.call("GET", KEYS[1]) == ARGV[1] then return redis.call("DEL", KEYS[1]) else return 0 end`)
+
+// LocalLease is a leader lease within this process.
+//
+// The first instance to ask always gets it; meant for single-instance
+// deployments and the in-memory storage.
+type LocalLease struct {
+	mu     sync.Mutex
+	leases map[string]localLease
+}
+
+// localLease is a held lease.
+type localLease struct {
+	holder    string
+	expiresAt time.Time
+}
+
+// NewLocalLease creates an in-process lease.
+//
+// Returns:
+//   - *LocalLease: A lease store with no holders
+func NewLocalLease() *LocalLease {
+	return &LocalLease{leases: make(map[string]localLease)}
+}
+
+// Acquire takes a free or expired lease or renews the holder's own.
+func (l *LocalLease) Acquire(ctx context.Context, name, holder string, ttl time.Duration) (string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if current, ok := l.leases[name]; ok && current.holder != holder && now.Before(current.expiresAt) {
+		return current.holder, nil
+	}
+	l.leases[name] = localLease{holder: holder, expiresAt: now.Add(ttl)}
+	return holder, nil
+}
+
+// Release frees the lease if the holder has it.
+func (l *LocalLease) Release(ctx context.Context, name, holder string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.leases[name].holder == holder {
+		delete(l.leases, name)
+	}
+	return nil
+}
+
+// RedisLease is a leader lease stored as a key on a Redis server.
+//
+// The "leader:<name>" key holds the leader's instance ID and expires after
+// the TTL unless the leader renews it. Acquiring and renewing run in one
+// script, so two instances cannot both take a free lease.
+type RedisLease struct {
+	client *redis.Client
+}
+
+// NewRedisLease creates a lease on a Redis server.
+//
+// Parameters:
+//   - client: Client of the Redis server shared by all instances
+//
+// Returns:
+//   - *RedisLease: A new lease
+func NewRedisLease(client *redis.Client) *RedisLease {
+	return &RedisLease{client: client}
+}
+
+// Acquire takes a free lease or renews the holder's own and returns the holder.
+func (l *RedisLease) Acquire(ctx context.Context, name, holder string, ttl time.Duration) (string, error) {
+	millis := max(1, (ttl+time.Millisecond-1)/time.Millisecond)
+	return redisAcquireScript.Run(ctx, l.client, []string{redisLeaseKeyPrefix + name}, holder, int64(millis)).Text()
+}
+
+// Release deletes the lease key if the holder has it.
+func (l *RedisLease) Release(ctx context.Context, name, holder string) error {
+	return redisReleaseScript.Run(ctx, l.client, []string{redisLeaseKeyPrefix + name}, holder).Err()
+}
@@ -0,0 +1,68 @@
+package lock
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go_di_architecture/internal/domain/lock"
+)
+
+// LocalLock grants locks within this process.
+//
+// It protects nothing across instances and is meant for single-instance
+// deployments and the in-memory storage. Locks held longer than their TTL can
+// be taken over, as with the distributed implementations.
+type LocalLock struct {
+	mu   sync.Mutex
+	held map[string]localHold
+
+	// Numbers the holds so a late release cannot free a taken-over lock
+	tokens uint64
+}
+
+// localHold is a held lock.
+type localHold struct {
+	token     uint64
+	expiresAt time.Time
+}
+
+// NewLocalLock creates an in-process lock.
+//
+// Returns:
+//   - *LocalLock: A lock with nothing held
+func NewLocalLock() *LocalLock {
+	return &LocalLock{held: make(map[string]localHold)}
+}
+
+// TryLock acquires the named lock without waiting.
+//
+// Parameters:
+//   - ctx: Unused
+//   - name: Name of the lock
+//   - ttl: How long the lock is held at most
+//
+// Returns:
+//   - lock.Release: Gives the lock back
+//   - error: lock.ErrHeld when the lock is held
+func (l *LocalLock) TryLock(ctx context.Context, name string, ttl time.Duration) (lock.Release, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if hold, ok := l.held[name]; ok && now.Before(hold.expiresAt) {
+		return nil, lock.ErrHeld
+	}
+
+	l.tokens++
+	token := l.tokens
+	l.held[name] = localHold{token: token, expiresAt: now.Add(ttl)}
+	return func(context.Context) error {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if l.held[name].token == token {
+			delete(l.held, name)
+		}
+		return nil
+	}, nil
+}
@@ -0,0 +1,88 @@
+package lock
+
+import (
+	"context"
+	"database/sql/driver"
+	"hash/fnv"
+	"time"
+
+	"go_di_architecture/internal/domain/lock"
+
+	"gorm.io/gorm"
+)
+
+// PostgresLock grants session-level PostgreSQL advisory locks.
+//
+// Each held lock keeps its own pool connection, because advisory locks
+// belong to the session that took them; releasing unlocks and returns the
+// connection. When the holder dies its connection closes and PostgreSQL
+// frees the lock, so the TTL is not needed and ignored. Lock names are hashed
+// to the 64-bit advisory lock key.
+//
+// Query Implementation:
+//
+//	SELECT pg_try_advisory_lock(?)
+//	SELECT pg_advisory_unlock(?)
+type PostgresLock struct {
+	db *gorm.DB
+}
+
+// NewPostgresLock creates a lock on a PostgreSQL database.
+//
+// Parameters:
+//   - db: Connection pool of the database shared by all instances
+//
+// Returns:
+//   - *PostgresLock: A new lock
+func NewPostgresLock(db *gorm.DB) *PostgresLock {
+	return &PostgresLock{db: db}
+}
+
+// TryLock acquires the named lock without waiting.
+//
+// Parameters:
+//   - ctx: Context bounding the connection and query
+//   - name: Name of the lock
+//   - ttl: Ignored, the lock ends with its connection
+//
+// Returns:
+//   - lock.Release: Unlocks and returns the connection to the pool
+//   - error: lock.ErrHeld when another session holds the lock, or the database error
+func (l *PostgresLock) TryLock(ctx context.Context, name string, ttl time.Duration) (lock.Release, error) {
+	sqlDB, err := l.db.DB()
+	if err != nil {
+		return nil, err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	key := advisoryKey(name)
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&acquired); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if !acquired {
+		conn.Close()
+		return nil, lock.ErrHeld
+	}
+
+	return func(ctx context.Context) error {
+		defer conn.Close()
+		_, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", key)
+		if err != nil {
+			// Discard the session instead of pooling it with the lock still held
+			conn.Raw(func(any) error { return driver.ErrBadConn })
+		}
+		return err
+	}, nil
+}
+
+// advisoryKey hashes a lock name to an advisory lock key.
+func advisoryKey(name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	return int64(h.Sum64())
+}
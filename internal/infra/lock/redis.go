@@ -0,0 +1,72 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go_di_architecture/internal/domain/lock"
+
+	"github.com/go-redsync/redsync/v4"
+	"github.com/go-redsync/redsync/v4/redis/goredis/v9"
+	"github.com/redis/go-redis/v9"
+)
+
+// redisKeyPrefix namespaces the lock keys.
+const redisKeyPrefix = "lock:"
+
+// RedisLock grants locks stored as keys on a Redis server.
+//
+// Locks are redsync mutexes: a "lock:<name>" key set with NX and a PX expiry
+// to a random token. A lock expires after its TTL even if the holder dies,
+// and it is released only while it still holds the holder's token.
+//
+// Usage Example:
+//
+//	locks := lock.NewRedisLock(client)
+//	release, err := locks.TryLock(ctx, "retention", 10*time.Minute)
+type RedisLock struct {
+	sync *redsync.Redsync
+}
+
+// NewRedisLock creates a lock on a Redis server.
+//
+// Parameters:
+//   - client: Client of the Redis server shared by all instances
+//
+// Returns:
+//   - *RedisLock: A new lock
+func NewRedisLock(client *redis.Client) *RedisLock {
+	return &RedisLock{sync: redsync.New(goredis.NewPool(client))}
+}
+
+// TryLock acquires the named lock without waiting.
+//
+// Parameters:
+//   - ctx: Context bounding the round trip
+//   - name: Name of the lock
+//   - ttl: Expiry of the key
+//
+// Returns:
+//   - lock.Release: Deletes the key if it still holds this lock's token
+//   - error: lock.ErrHeld when the key exists, or the Redis error
+func (l *RedisLock) TryLock(ctx context.Context, name string, ttl time.Duration) (lock.Release, error) {
+	mutex := l.sync.NewMutex(redisKeyPrefix+name, redsync.WithExpiry(ttl), redsync.WithTries(1))
+	if err := mutex.TryLockContext(ctx); err != nil {
+		var taken *redsync.ErrTaken
+		if errors.As(err, &taken) {
+			return nil, lock.ErrHeld
+		}
+		return nil, err
+	}
+
+	return func(ctx context.Context) error {
+		// An expired lock may belong to the next holder by now; it is left alone
+		_, err := mutex.UnlockContext(ctx)
+		var taken *redsync.ErrTaken
+		if errors.Is(err, redsync.ErrLockAlreadyExpired) || errors.As(err, &taken) {
+			return nil
+		}
+		return err
+	}, nil
+}
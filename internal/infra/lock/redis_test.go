@@ -0,0 +1,115 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go_di_architecture/internal/domain/lock"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// newTestRedis starts an in-process Redis server and a client of it.
+func newTestRedis(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return server, client
+}
+
+func TestRedisLockIsExclusive(t *testing.T) {
+	server, client := newTestRedis(t)
+	locks := NewRedisLock(client)
+	ctx := context.Background()
+
+	release, err := locks.TryLock(ctx, "retention", time.Minute)
+	if err != nil {
+		t.Fatalf("TryLock() error = %v", err)
+	}
+	if _, err := locks.TryLock(ctx, "retention", time.Minute); !errors.Is(err, lock.ErrHeld) {
+		t.Fatalf("second TryLock() error = %v, want lock.ErrHeld", err)
+	}
+	if _, err := locks.TryLock(ctx, "digest", time.Minute); err != nil {
+		t.Errorf("TryLock(other name) error = %v", err)
+	}
+
+	if err := release(ctx); err != nil {
+		t.Fatalf("release() error = %v", err)
+	}
+	if server.Exists(redisKeyPrefix + "retention") {
+		t.Error("lock key still set after release")
+	}
+	if _, err := locks.TryLock(ctx, "retention", time.Minute); err != nil {
+		t.Errorf("TryLock() after release error = %v", err)
+	}
+}
+
+func TestRedisLockReleaseLeavesNextHolder(t *testing.T) {
+	server, client := newTestRedis(t)
+	locks := NewRedisLock(client)
+	ctx := context.Background()
+
+	release, err := locks.TryLock(ctx, "retention", time.Second)
+	if err != nil {
+		t.Fatalf("TryLock() error = %v", err)
+	}
+	server.FastForward(2 * time.Second)
+	if _, err := locks.TryLock(ctx, "retention", time.Minute); err != nil {
+		t.Fatalf("TryLock() after expiry error = %v", err)
+	}
+
+	if err := release(ctx); err != nil {
+		t.Errorf("release() of an expired lock error = %v", err)
+	}
+	if !server.Exists(redisKeyPrefix + "retention") {
+		t.Error("expired holder released the next holder's lock")
+	}
+}
+
+func TestRedisLockReportsUnreachableServer(t *testing.T) {
+	server, client := newTestRedis(t)
+	server.Close()
+
+	_, err := NewRedisLock(client).TryLock(context.Background(), "retention", time.Minute)
+	if err == nil || errors.Is(err, lock.ErrHeld) {
+		t.Errorf("TryLock() error = %v, want the connection error", err)
+	}
+}
+
+func TestRedisLease(t *testing.T) {
+	server, client := newTestRedis(t)
+	lease := NewRedisLease(client)
+	ctx := context.Background()
+
+	steps := []struct {
+		holder string
+		want   string
+	}{
+		{holder: "a", want: "a"},
+		{holder: "b", want: "a"},
+		{holder: "a", want: "a"},
+	}
+	for _, step := range steps {
+		got, err := lease.Acquire(ctx, "scheduler", step.holder, time.Minute)
+		if err != nil || got != step.want {
+			t.Fatalf("Acquire(%q) = %q, %v; want %q", step.holder, got, err, step.want)
+		}
+	}
+
+	if err := lease.Release(ctx, "scheduler", "b"); err != nil {
+		t.Fatalf("Release(b) error = %v", err)
+	}
+	if got, _ := server.Get(redisLeaseKeyPrefix + "scheduler"); got != "a" {
+		t.Errorf("lease holder = %q after release by a non-holder, want a", got)
+	}
+	if err := lease.Release(ctx, "scheduler", "a"); err != nil {
+		t.Fatalf("Release(a) error = %v", err)
+	}
+	if got, err := lease.Acquire(ctx, "scheduler", "b", time.Minute); err != nil || got != "b" {
+		t.Errorf("Acquire(b) after release = %q, %v; want b", got, err)
+	}
+}
@@ -0,0 +1,144 @@
+package metrics
+
+import (
+	"expvar"
+	"sync"
+	"time"
+
+	"go_di_architecture/internal/domain/metrics"
+)
+
+// histogramBuckets are the upper bounds of the histogram buckets, sized for
+// background jobs that take from milliseconds to minutes.
+var histogramBuckets = []time.Duration{
+	100 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	5 * time.Second,
+	30 * time.Second,
+	time.Minute,
+	5 * time.Minute,
+}
+
+// ExpvarMetrics publishes the business metrics as an expvar map.
+//
+// Every metric is a key of the map, served as JSON by the expvar handler at
+// /admin/metrics:
+//
+//	"business": {
+//	  "modules_total": {"true": 12, "false": 3},
+//	  "modules_created_total": {"": 15},
+//	  "import_job_duration": {"succeeded": {"count": 2, "sum_seconds": 1.4, "buckets": {"1s": 1, "5s": 2, ...}}}
+//	}
+//
+// Histogram buckets are cumulative, as in Prometheus.
+//
+// Usage Example:
+//
+//	m := metrics.NewExpvarMetrics("business")
+//	service, err := module.NewModuleService(repo, revisions, acl, transfers, stars, bus, m)
+type ExpvarMetrics struct {
+	root *expvar.Map
+}
+
+// NewExpvarMetrics creates the metrics published under an expvar name.
+//
+// Parameters:
+//   - name: Name of the expvar; instances with the same name share it
+//
+// Returns:
+//   - *ExpvarMetrics: Metrics with nothing registered yet
+func NewExpvarMetrics(name string) *ExpvarMetrics {
+	if root, ok := expvar.Get(name).(*expvar.Map); ok {
+		return &ExpvarMetrics{root: root}
+	}
+	return &ExpvarMetrics{root: expvar.NewMap(name)}
+}
+
+// Counter registers a count per label.
+func (m *ExpvarMetrics) Counter(name string) metrics.Counter {
+	counter := &expvarCounter{values: new(expvar.Map).Init()}
+	m.root.Set(name, counter.values)
+	return counter
+}
+
+// Gauge registers a value collected on every read.
+func (m *ExpvarMetrics) Gauge(name string, collect func() (map[string]int64, error)) {
+	m.root.Set(name, expvar.Func(func() any {
+		values, err := collect()
+		if err != nil {
+			return map[string]string{"error": err.Error()}
+		}
+		return values
+	}))
+}
+
+// Histogram registers a duration distribution per label.
+func (m *ExpvarMetrics) Histogram(name string) metrics.Histogram {
+	histogram := &expvarHistogram{series: make(map[string]*histogramSeries)}
+	m.root.Set(name, expvar.Func(histogram.snapshot))
+	return histogram
+}
+
+// expvarCounter implements metrics.Counter.
+type expvarCounter struct {
+	values *expvar.Map
+}
+
+// Add adds delta to the count of a label.
+func (c *expvarCounter) Add(label string, delta int64) {
+	c.values.Add(label, delta)
+}
+
+// expvarHistogram implements metrics.Histogram.
+type expvarHistogram struct {
+	mu     sync.Mutex
+	series map[string]*histogramSeries
+}
+
+// histogramSeries is the distribution of one label.
+type histogramSeries struct {
+	count   int64
+	sum     time.Duration
+	buckets []int64
+}
+
+// Observe records a duration under a label.
+func (h *expvarHistogram) Observe(label string, d time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	series, ok := h.series[label]
+	if !ok {
+		series = &histogramSeries{buckets: make([]int64, len(histogramBuckets))}
+		h.series[label] = series
+	}
+	series.count++
+	series.sum += d
+	for i, bound := range histogramBuckets {
+		if d <= bound {
+			series.buckets[i]++
+		}
+	}
+}
+
+// snapshot renders the distributions for the expvar handler.
+func (h *expvarHistogram) snapshot() any {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	out := make(map[string]any, len(h.series))
+	for label, series := range h.series {
+		buckets := make(map[string]int64, len(histogramBuckets)+1)
+		for i, bound := range histogramBuckets {
+			buckets[bound.String()] = series.buckets[i]
+		}
+		buckets["+Inf"] = series.count
+		out[label] = map[string]any{
+			"count":       series.count,
+			"sum_seconds": series.sum.Seconds(),
+			"buckets":     buckets,
+		}
+	}
+	return out
+}
@@ -0,0 +1,61 @@
+package notify
+
+import (
+	"context"
+	"sync"
+
+	"go_di_architecture/internal/domain/notification"
+	"go_di_architecture/internal/logging"
+)
+
+// fakeLog writes the notifications of the fake notifiers.
+var fakeLog = logging.New("notify")
+
+// maxRecorded bounds the messages a FakeNotifier keeps.
+const maxRecorded = 100
+
+// FakeNotifier records and logs notifications instead of delivering them.
+//
+// It stands in for every channel in the test profile (NOTIFY_FAKE), so events
+// can be followed without a mail server or webhook endpoint. The latest
+// messages are kept for inspection.
+type FakeNotifier struct {
+	channel string
+
+	mu   sync.Mutex
+	sent []notification.Message
+}
+
+// NewFakeNotifier creates a fake notifier for a channel.
+//
+// Parameters:
+//   - channel: Channel the notifier stands in for, included in the log lines
+//
+// Returns:
+//   - *FakeNotifier: A new notifier without recorded messages
+func NewFakeNotifier(channel string) *FakeNotifier {
+	return &FakeNotifier{channel: channel}
+}
+
+// Notify records and logs the message; it never fails.
+func (n *FakeNotifier) Notify(_ context.Context, message notification.Message) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if len(n.sent) == maxRecorded {
+		n.sent = n.sent[1:]
+	}
+	n.sent = append(n.sent, message)
+	fakeLog.Infof("Notification via %s (not delivered): %s", n.channel, message.Subject)
+	return nil
+}
+
+// Sent returns the recorded messages, oldest first.
+//
+// Returns:
+//   - []notification.Message: Copies of the latest messages
+func (n *FakeNotifier) Sent() []notification.Message {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]notification.Message{}, n.sent...)
+}
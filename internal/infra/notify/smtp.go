@@ -0,0 +1,91 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+
+	"go_di_architecture/internal/domain/notification"
+)
+
+// SMTPConfig holds the mail server settings.
+type SMTPConfig struct {
+	// Server address as host:port
+	Addr string
+
+	// Sender address
+	From string
+
+	// Recipient addresses
+	To []string
+
+	// Optional credentials for PLAIN authentication
+	Username string
+	Password string
+}
+
+// SMTPNotifier delivers notifications as plain-text emails.
+//
+// Usage Example:
+//
+//	notifier := notify.NewSMTPNotifier(notify.SMTPConfig{
+//	    Addr: "smtp.example.com:587",
+//	    From: "modules@example.com",
+//	    To:   []string{"ops@example.com"},
+//	})
+type SMTPNotifier struct {
+	config SMTPConfig
+}
+
+// NewSMTPNotifier creates a notifier sending mail through the configured server.
+//
+// Parameters:
+//   - config: Mail server settings
+//
+// Returns:
+//   - *SMTPNotifier: A new notifier
+func NewSMTPNotifier(config SMTPConfig) *SMTPNotifier {
+	return &SMTPNotifier{config: config}
+}
+
+// Notify sends the message to every configured recipient.
+//
+// net/smtp does not support contexts, so a delivery started before the
+// context is cancelled runs to completion.
+//
+// Parameters:
+//   - ctx: Context checked before sending
+//   - message: The rendered notification
+//
+// Returns:
+//   - error: Error if the mail server rejects the message
+func (n *SMTPNotifier) Notify(ctx context.Context, message notification.Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if n.config.Username != "" {
+		host, _, err := net.SplitHostPort(n.config.Addr)
+		if err != nil {
+			return fmt.Errorf("smtp address %q: %w", n.config.Addr, err)
+		}
+		auth = smtp.PlainAuth("", n.config.Username, n.config.Password, host)
+	}
+
+	var mail strings.Builder
+	fmt.Fprintf(&mail, "From: %s\r\n", n.config.From)
+	fmt.Fprintf(&mail, "To: %s\r\n", strings.Join(n.config.To, ", "))
+	fmt.Fprintf(&mail, "Subject: %s\r\n", message.Subject)
+	mail.WriteString("MIME-Version: 1.0\r\n")
+	mail.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	mail.WriteString(message.Body)
+	mail.WriteString("\r\n")
+
+	if err := smtp.SendMail(n.config.Addr, auth, n.config.From, n.config.To, []byte(mail.String())); err != nil {
+		return fmt.Errorf("smtp: %w", err)
+	}
+	return nil
+}
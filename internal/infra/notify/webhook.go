@@ -0,0 +1,135 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"go_di_architecture/internal/domain/notification"
+	"go_di_architecture/internal/infra/httpclient"
+)
+
+// webhookPayload is the JSON body posted by WebhookNotifier.
+type webhookPayload struct {
+	Type       string    `json:"type"`
+	ModuleID   int       `json:"moduleId"`
+	Actor      string    `json:"actor"`
+	Recipient  string    `json:"recipient,omitempty"`
+	OccurredAt time.Time `json:"occurredAt"`
+	Subject    string    `json:"subject"`
+	Body       string    `json:"body"`
+}
+
+// WebhookNotifier posts notifications as JSON to a URL.
+//
+// Example Payload:
+//
+//	{
+//	  "type": "module.deactivated",
+//	  "moduleId": 123,
+//	  "actor": "scheduler",
+//	  "occurredAt": "2023-08-15T14:30:00Z",
+//	  "subject": "Module 123 deactivated",
+//	  "body": "Module 123 was deactivated by scheduler at 2023-08-15 14:30 UTC."
+//	}
+//
+// Requests carry a new traceparent, since notifications are delivered after
+// the request that caused them has finished.
+type WebhookNotifier struct {
+	url    string
+	client *httpclient.Client
+}
+
+// NewWebhookNotifier creates a notifier posting to the URL.
+//
+// Parameters:
+//   - url: Endpoint receiving the notifications
+//   - client: Client sending the requests, with its timeout and retries
+//
+// Returns:
+//   - *WebhookNotifier: A new notifier
+func NewWebhookNotifier(url string, client *httpclient.Client) *WebhookNotifier {
+	return &WebhookNotifier{url: url, client: client}
+}
+
+// Notify posts the message and its event.
+//
+// Parameters:
+//   - ctx: Context bounding the request
+//   - message: The rendered notification
+//
+// Returns:
+//   - error: Error if the request fails or the endpoint does not answer 2xx
+func (n *WebhookNotifier) Notify(ctx context.Context, message notification.Message) error {
+	return postJSON(ctx, n.client, n.url, webhookPayload{
+		Type:       message.Event.Type,
+		ModuleID:   message.Event.ModuleID,
+		Actor:      message.Event.Actor,
+		Recipient:  message.Event.Recipient,
+		OccurredAt: message.Event.OccurredAt,
+		Subject:    message.Subject,
+		Body:       message.Body,
+	})
+}
+
+// SlackNotifier posts notifications to a Slack incoming webhook.
+type SlackNotifier struct {
+	url    string
+	client *httpclient.Client
+}
+
+// NewSlackNotifier creates a notifier posting to a Slack incoming webhook URL.
+//
+// Parameters:
+//   - url: The incoming webhook URL of the Slack channel
+//   - client: Client sending the requests, with its timeout and retries
+//
+// Returns:
+//   - *SlackNotifier: A new notifier
+func NewSlackNotifier(url string, client *httpclient.Client) *SlackNotifier {
+	return &SlackNotifier{url: url, client: client}
+}
+
+// Notify posts the message as a Slack text message with a bold subject line.
+//
+// Parameters:
+//   - ctx: Context bounding the request
+//   - message: The rendered notification
+//
+// Returns:
+//   - error: Error if the request fails or Slack does not answer 2xx
+func (n *SlackNotifier) Notify(ctx context.Context, message notification.Message) error {
+	return postJSON(ctx, n.client, n.url, map[string]string{
+		"text": fmt.Sprintf("*%s*\n%s", message.Subject, message.Body),
+	})
+}
+
+// postJSON posts a JSON payload and checks for a 2xx answer.
+func postJSON(ctx context.Context, client *httpclient.Client, url string, payload interface{}) error {
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(encoded))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, response.Body)
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("POST %s: unexpected status %d", url, response.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package provisioning
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"go_di_architecture/internal/infra/httpclient"
+)
+
+// allocationRequest is the JSON body posted by HTTPAllocator.
+type allocationRequest struct {
+	ModuleID int    `json:"moduleId"`
+	Plan     string `json:"plan"`
+}
+
+// allocationResponse is the JSON body of a created allocation.
+type allocationResponse struct {
+	ID string `json:"id"`
+}
+
+// HTTPAllocator allocates module resources through the REST API of a
+// provisioning service:
+//   - POST <url>/allocations with {"moduleId": 123, "plan": "small"},
+//     answered 200 or 201 with {"id": "<allocation id>"}
+//   - DELETE <url>/allocations/<id>, answered 2xx, or 404 when the
+//     allocation is already gone
+//
+// The token, if any, is sent as "Authorization: Bearer <token>".
+//
+// Usage Example:
+//
+//	allocator := provisioning.NewHTTPAllocator("https://provisioning.internal/v1", token, client)
+//	allocationID, err := allocator.Allocate(ctx, 123, "small")
+type HTTPAllocator struct {
+	url    string
+	token  string
+	client *httpclient.Client
+}
+
+// NewHTTPAllocator creates an allocator calling the API at the base URL.
+//
+// Parameters:
+//   - baseURL: Base URL of the provisioning API
+//   - token: API token (empty sends none)
+//   - client: Client sending the requests, with its timeout and retries
+//
+// Returns:
+//   - *HTTPAllocator: A new allocator
+func NewHTTPAllocator(baseURL, token string, client *httpclient.Client) *HTTPAllocator {
+	return &HTTPAllocator{url: strings.TrimRight(baseURL, "/"), token: token, client: client}
+}
+
+// Allocate reserves the resources of a plan for a module.
+//
+// Parameters:
+//   - ctx: Context bounding the request
+//   - moduleID: Module the resources belong to
+//   - plan: Resource plan (small, medium or large)
+//
+// Returns:
+//   - string: Identifier of the allocation
+//   - error: Error if the request fails or the service does not answer with
+//     an allocation
+func (a *HTTPAllocator) Allocate(ctx context.Context, moduleID int, plan string) (string, error) {
+	encoded, err := json.Marshal(allocationRequest{ModuleID: moduleID, Plan: plan})
+	if err != nil {
+		return "", err
+	}
+	endpoint := a.url + "/allocations"
+	body, status, err := a.do(ctx, http.MethodPost, endpoint, encoded)
+	if err != nil {
+		return "", err
+	}
+	if status != http.StatusOK && status != http.StatusCreated {
+		return "", fmt.Errorf("POST %s: unexpected status %d", endpoint, status)
+	}
+
+	var created allocationResponse
+	if err := json.Unmarshal(body, &created); err != nil || created.ID == "" {
+		return "", fmt.Errorf("POST %s: response names no allocation", endpoint)
+	}
+	return created.ID, nil
+}
+
+// Release frees an allocation.
+//
+// Parameters:
+//   - ctx: Context bounding the request
+//   - allocationID: Identifier returned by Allocate
+//
+// Returns:
+//   - error: Error if the request fails or the service answers neither 2xx
+//     nor 404
+func (a *HTTPAllocator) Release(ctx context.Context, allocationID string) error {
+	endpoint := a.url + "/allocations/" + url.PathEscape(allocationID)
+	_, status, err := a.do(ctx, http.MethodDelete, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusNotFound && (status < 200 || status > 299) {
+		return fmt.Errorf("DELETE %s: unexpected status %d", endpoint, status)
+	}
+	return nil
+}
+
+// do sends a request and returns the response body and status.
+func (a *HTTPAllocator) do(ctx context.Context, method, endpoint string, body []byte) ([]byte, int, error) {
+	request, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, 0, err
+	}
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	if a.token != "" {
+		request.Header.Set("Authorization", "Bearer "+a.token)
+	}
+
+	response, err := a.client.Do(request)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer response.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(response.Body, 1<<20))
+	if err != nil {
+		return nil, 0, err
+	}
+	return data, response.StatusCode, nil
+}
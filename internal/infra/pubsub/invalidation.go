@@ -0,0 +1,131 @@
+package pubsub
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"go_di_architecture/internal/logging"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// logger writes the state changes of the invalidation subscription.
+var logger = logging.New("pubsub")
+
+// maxListenBackoff caps the wait between attempts to restore a lost subscription.
+const maxListenBackoff = 30 * time.Second
+
+// CacheEvictor drops local cache entries by entity-change key.
+type CacheEvictor interface {
+	// EvictKeys drops the entries of the keys, e.g. "module:123"
+	EvictKeys(keys []string)
+
+	// EvictAll drops every entry
+	EvictAll()
+}
+
+// Invalidations broadcasts entity-change keys so every instance evicts the
+// same cache entries.
+//
+// Messages carry the ID of the publishing instance followed by the keys,
+// separated by spaces ("<origin> module:1 module:2"); instances skip their
+// own messages because they evicted locally already. After a lost
+// subscription the local cache is cleared, since changes published in the
+// meantime were missed.
+//
+// Usage Example:
+//
+//	invalidations := pubsub.NewInvalidations(client, "module-cache")
+//	go invalidations.Listen(ctx, cache)
+//	invalidations.Publish(ctx, []string{"module:123"})
+type Invalidations struct {
+	client  *redis.Client
+	channel string
+	origin  string
+}
+
+// NewInvalidations creates the invalidation channel of this instance.
+//
+// Parameters:
+//   - client: Redis client carrying the messages
+//   - channel: Pub/sub channel shared by all instances
+//
+// Returns:
+//   - *Invalidations: The channel, identified by a random instance ID
+func NewInvalidations(client *redis.Client, channel string) *Invalidations {
+	origin := make([]byte, 8)
+	rand.Read(origin)
+	return &Invalidations{client: client, channel: channel, origin: hex.EncodeToString(origin)}
+}
+
+// Publish tells the other instances to evict the keys.
+//
+// Parameters:
+//   - ctx: Context bounding the round trip
+//   - keys: Entity-change keys
+//
+// Returns:
+//   - error: Error if the message could not be published
+func (i *Invalidations) Publish(ctx context.Context, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return i.client.Publish(ctx, i.channel, i.origin+" "+strings.Join(keys, " ")).Err()
+}
+
+// Listen evicts the keys published by other instances until the context is cancelled.
+//
+// The subscription is restored after connection failures with exponential
+// backoff; the local cache is cleared once Redis confirms the restored
+// subscription.
+//
+// Parameters:
+//   - ctx: Context ending the subscription
+//   - cache: Cache receiving the evictions
+func (i *Invalidations) Listen(ctx context.Context, cache CacheEvictor) {
+	subscription := i.client.Subscribe(ctx, i.channel)
+	defer subscription.Close()
+
+	// Unblock the receive loop on cancellation
+	stop := context.AfterFunc(ctx, func() { subscription.Close() })
+	defer stop()
+
+	lost := false
+	backoff := time.Second
+	for {
+		reply, err := subscription.Receive(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			logger.Warnf("Redis subscription to %s lost, retrying in %s: %v", i.channel, backoff, err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			backoff = min(2*backoff, maxListenBackoff)
+			lost = true
+			continue
+		}
+
+		switch message := reply.(type) {
+		case *redis.Subscription:
+			backoff = time.Second
+			if lost {
+				logger.Infof("Resubscribed to %s, clearing local cache", i.channel)
+				cache.EvictAll()
+				lost = false
+			}
+		case *redis.Message:
+			fields := strings.Fields(message.Payload)
+			if len(fields) < 2 || fields[0] == i.origin {
+				continue
+			}
+			cache.EvictKeys(fields[1:])
+		}
+	}
+}
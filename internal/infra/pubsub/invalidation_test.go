@@ -0,0 +1,101 @@
+package pubsub
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// recordingCache reports the evictions it receives.
+type recordingCache struct {
+	keys chan []string
+	all  chan struct{}
+}
+
+func newRecordingCache() *recordingCache {
+	return &recordingCache{keys: make(chan []string, 10), all: make(chan struct{}, 10)}
+}
+
+func (c *recordingCache) EvictKeys(keys []string) { c.keys <- keys }
+
+func (c *recordingCache) EvictAll() { c.all <- struct{}{} }
+
+// listen runs Listen until the test ends and waits for the subscription.
+func listen(t *testing.T, server *miniredis.Miniredis, invalidations *Invalidations, cache CacheEvictor) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		invalidations.Listen(ctx, cache)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	deadline := time.Now().Add(2 * time.Second)
+	for server.PubSubNumSub(invalidations.channel)[invalidations.channel] == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Listen() did not subscribe")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func newTestClient(t *testing.T, server *miniredis.Miniredis) *redis.Client {
+	t.Helper()
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestInvalidationsReachOtherInstances(t *testing.T) {
+	server := miniredis.RunT(t)
+	local := NewInvalidations(newTestClient(t, server), "module-cache")
+	remote := NewInvalidations(newTestClient(t, server), "module-cache")
+	localCache, remoteCache := newRecordingCache(), newRecordingCache()
+	listen(t, server, local, localCache)
+	listen(t, server, remote, remoteCache)
+
+	ctx := context.Background()
+	if err := local.Publish(ctx, []string{"module:1", "module:2"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	select {
+	case keys := <-remoteCache.keys:
+		if !slices.Equal(keys, []string{"module:1", "module:2"}) {
+			t.Errorf("remote evicted %v, want module:1 and module:2", keys)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("remote instance received no eviction")
+	}
+	select {
+	case keys := <-localCache.keys:
+		t.Errorf("publishing instance evicted its own keys %v", keys)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestInvalidationsClearCacheAfterLostSubscription(t *testing.T) {
+	server := miniredis.RunT(t)
+	invalidations := NewInvalidations(newTestClient(t, server), "module-cache")
+	cache := newRecordingCache()
+	listen(t, server, invalidations, cache)
+
+	server.Close()
+	if err := server.Restart(); err != nil {
+		t.Fatalf("Restart() error = %v", err)
+	}
+
+	select {
+	case <-cache.all:
+	case <-time.After(5 * time.Second):
+		t.Fatal("cache not cleared after the subscription was restored")
+	}
+}
@@ -0,0 +1,64 @@
+package queue
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"go_di_architecture/internal/domain/queue"
+)
+
+// closedAddress returns an address nothing listens on.
+func closedAddress(t *testing.T) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	address := listener.Addr().String()
+	listener.Close()
+	return address
+}
+
+func TestRabbitMQQueueReportsUnreachableBroker(t *testing.T) {
+	q := NewRabbitMQQueue(RabbitMQConfig{URL: "amqp://guest:guest@" + closedAddress(t) + "/", Exchange: "modules", Queue: "module-commands"})
+	ctx, cancel := context.WithTimeout(context.Background(), 2*rabbitMQDialTimeout)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := q.Receive(ctx); err == nil {
+		t.Fatal("Receive() error = nil, want the connect to fail")
+	}
+	if _, err := q.Depth(ctx); err == nil {
+		t.Error("Depth() error = nil, want the connect to fail")
+	}
+	if err := q.Publish(ctx, "modules.created", []byte(`{}`)); err == nil {
+		t.Error("Publish() error = nil, want the connect to fail")
+	}
+	if elapsed := time.Since(start); elapsed > rabbitMQDialTimeout {
+		t.Errorf("refused connects took %s, want them to fail fast", elapsed)
+	}
+	if err := q.Close(); err != nil {
+		t.Errorf("Close() error = %v on a queue that never connected", err)
+	}
+}
+
+func TestRabbitMQQueueRefusesStaleHandles(t *testing.T) {
+	q := NewRabbitMQQueue(RabbitMQConfig{URL: "amqp://localhost/", Exchange: "modules", Queue: "module-commands"})
+
+	tests := []struct {
+		handle string
+		want   string
+	}{
+		{handle: "1:abc", want: "invalid rabbitmq message handle"},
+		{handle: "1:42", want: "is gone"},
+	}
+	for _, test := range tests {
+		err := q.Ack(context.Background(), &queue.Message{Handle: test.handle})
+		if err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("Ack(%q) error = %v, want %q", test.handle, err, test.want)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go_di_architecture/internal/domain/queue"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// receiveWait is how long Receive blocks for a message.
+const receiveWait = time.Second
+
+// RedisQueue is a queue on Redis lists.
+//
+// Producers RPUSH messages onto the queue list. Receive moves the oldest
+// message to "<queue>:processing" with BLMOVE (Redis 6.2 or later) and Ack
+// removes it from there, so a message whose processing was interrupted stays
+// on the processing list for an operator to push back. Requeue pushes a
+// message back onto the queue list. Reject moves a message to
+// "<queue>:dead", the dead-letter list, which List, Depth and Replay expose
+// as queue.DeadLetters. Publish RPUSHes onto the topic list, where the
+// receiver pops it the same way.
+//
+// Receive holds one connection of the client's pool while it waits, so the
+// same queue also serves the dead letters.
+//
+// Usage Example:
+//
+//	commands := queue.NewRedisQueue(client, "module-commands")
+//	message, err := commands.Receive(ctx)
+//	...
+//	err = commands.Ack(ctx, message)
+type RedisQueue struct {
+	client     *redis.Client
+	name       string
+	processing string
+	dead       string
+}
+
+// NewRedisQueue creates a queue on the named list.
+//
+// Parameters:
+//   - client: Redis client, closed with the queue
+//   - name: Key of the list producers push to
+//
+// Returns:
+//   - *RedisQueue: The queue
+func NewRedisQueue(client *redis.Client, name string) *RedisQueue {
+	return &RedisQueue{client: client, name: name, processing: name + ":processing", dead: name + ":dead"}
+}
+
+// Receive waits up to a second for the next message.
+//
+// Parameters:
+//   - ctx: Context bounding the wait
+//
+// Returns:
+//   - *queue.Message: The message, nil when none arrived
+//   - error: Error if Redis is unreachable
+func (q *RedisQueue) Receive(ctx context.Context) (*queue.Message, error) {
+	body, err := q.client.BLMove(ctx, q.name, q.processing, "LEFT", "RIGHT", receiveWait).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &queue.Message{Handle: body, Body: []byte(body)}, nil
+}
+
+// Ack removes a received message from the processing list.
+//
+// Parameters:
+//   - ctx: Context bounding the round trip
+//   - message: Message returned by Receive
+//
+// Returns:
+//   - error: Error if Redis is unreachable
+func (q *RedisQueue) Ack(ctx context.Context, message *queue.Message) error {
+	return q.client.LRem(ctx, q.processing, 1, message.Handle).Err()
+}
+
+// Reject moves a received message from the processing list to the
+// dead-letter list.
+//
+// Parameters:
+//   - ctx: Context bounding the round trips
+//   - message: Message returned by Receive
+//
+// Returns:
+//   - error: Error if Redis is unreachable
+func (q *RedisQueue) Reject(ctx context.Context, message *queue.Message) error {
+	if err := q.client.RPush(ctx, q.dead, message.Handle).Err(); err != nil {
+		return err
+	}
+	return q.Ack(ctx, message)
+}
+
+// Requeue moves a received message from the processing list back to the end
+// of the queue.
+//
+// Parameters:
+//   - ctx: Context bounding the round trips
+//   - message: Message returned by Receive
+//
+// Returns:
+//   - error: Error if Redis is unreachable
+func (q *RedisQueue) Requeue(ctx context.Context, message *queue.Message) error {
+	if err := q.client.RPush(ctx, q.name, message.Handle).Err(); err != nil {
+		return err
+	}
+	return q.Ack(ctx, message)
+}
+
+// List returns the oldest dead letters.
+//
+// Parameters:
+//   - ctx: Context bounding the round trip
+//   - limit: Most dead letters returned (0: all)
+//
+// Returns:
+//   - []queue.DeadLetter: The dead letters, oldest first
+//   - error: Error if Redis is unreachable
+func (q *RedisQueue) List(ctx context.Context, limit int) ([]queue.DeadLetter, error) {
+	items, err := q.client.LRange(ctx, q.dead, 0, int64(limit-1)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	letters := make([]queue.DeadLetter, 0, len(items))
+	for _, body := range items {
+		letters = append(letters, queue.DeadLetter{ID: queue.MessageID([]byte(body)), Body: []byte(body)})
+	}
+	return letters, nil
+}
+
+// Depth counts the dead letters.
+//
+// Parameters:
+//   - ctx: Context bounding the round trip
+//
+// Returns:
+//   - int: Length of the dead-letter list
+//   - error: Error if Redis is unreachable
+func (q *RedisQueue) Depth(ctx context.Context) (int, error) {
+	depth, err := q.client.LLen(ctx, q.dead).Result()
+	return int(depth), err
+}
+
+// Replay moves a dead letter back to the end of the queue.
+//
+// Parameters:
+//   - ctx: Context bounding the round trips
+//   - id: Identifier of the dead letter
+//
+// Returns:
+//   - bool: False when no dead letter has the identifier
+//   - error: Error if Redis is unreachable
+func (q *RedisQueue) Replay(ctx context.Context, id string) (bool, error) {
+	letters, err := q.List(ctx, 0)
+	if err != nil {
+		return false, err
+	}
+	for _, letter := range letters {
+		if letter.ID != id {
+			continue
+		}
+		// Step 1: Take the letter, unless a concurrent replay took it first
+		removed, err := q.client.LRem(ctx, q.dead, 1, string(letter.Body)).Result()
+		if err != nil {
+			return false, err
+		}
+		if removed == 0 {
+			return false, nil
+		}
+
+		// Step 2: Queue it again
+		if err := q.client.RPush(ctx, q.name, string(letter.Body)).Err(); err != nil {
+			return false, fmt.Errorf("dead letter %s was removed but not requeued: %w", id, err)
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// Publish appends a message to a topic list.
+//
+// Parameters:
+//   - ctx: Context bounding the round trip
+//   - topic: Key of the list
+//   - body: Message payload
+//
+// Returns:
+//   - error: Error if Redis is unreachable
+func (q *RedisQueue) Publish(ctx context.Context, topic string, body []byte) error {
+	return q.client.RPush(ctx, topic, string(body)).Err()
+}
+
+// Close closes the Redis client of the queue.
+//
+// Returns:
+//   - error: Error if closing the connection fails
+func (q *RedisQueue) Close() error {
+	return q.client.Close()
+}
@@ -0,0 +1,74 @@
+package queue
+
+import (
+	"context"
+	"testing"
+
+	"go_di_architecture/internal/domain/queue"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestRedisQueue(t *testing.T) {
+	server := miniredis.RunT(t)
+	q := NewRedisQueue(redis.NewClient(&redis.Options{Addr: server.Addr()}), "module-commands")
+	t.Cleanup(func() { q.Close() })
+	ctx := context.Background()
+
+	for _, body := range []string{`{"n":1}`, `{"n":2}`} {
+		if err := q.Publish(ctx, "module-commands", []byte(body)); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+
+	// Step 1: Receive parks the message on the processing list until acked
+	first, err := q.Receive(ctx)
+	if err != nil || first == nil || string(first.Body) != `{"n":1}` {
+		t.Fatalf("Receive() = %v, %v; want the first message", first, err)
+	}
+	if processing, _ := server.List("module-commands:processing"); len(processing) != 1 {
+		t.Errorf("processing list = %v, want the received message", processing)
+	}
+	if err := q.Ack(ctx, first); err != nil {
+		t.Fatalf("Ack() error = %v", err)
+	}
+	if server.Exists("module-commands:processing") {
+		t.Error("processing list not emptied by Ack")
+	}
+
+	// Step 2: A rejected message becomes a dead letter and can be replayed
+	second, err := q.Receive(ctx)
+	if err != nil || second == nil {
+		t.Fatalf("Receive() = %v, %v; want the second message", second, err)
+	}
+	if err := q.Reject(ctx, second); err != nil {
+		t.Fatalf("Reject() error = %v", err)
+	}
+	letters, err := q.List(ctx, 0)
+	if err != nil || len(letters) != 1 || letters[0].ID != queue.MessageID(second.Body) {
+		t.Fatalf("List() = %v, %v; want the rejected message", letters, err)
+	}
+	if depth, err := q.Depth(ctx); err != nil || depth != 1 {
+		t.Errorf("Depth() = %d, %v; want 1", depth, err)
+	}
+	if replayed, err := q.Replay(ctx, letters[0].ID); err != nil || !replayed {
+		t.Fatalf("Replay() = %v, %v; want true", replayed, err)
+	}
+	if replayed, err := q.Replay(ctx, letters[0].ID); err != nil || replayed {
+		t.Errorf("second Replay() = %v, %v; want false", replayed, err)
+	}
+
+	again, err := q.Receive(ctx)
+	if err != nil || again == nil || string(again.Body) != `{"n":2}` {
+		t.Fatalf("Receive() after replay = %v, %v; want the replayed message", again, err)
+	}
+	if err := q.Ack(ctx, again); err != nil {
+		t.Fatalf("Ack() error = %v", err)
+	}
+
+	// Step 3: An empty queue times out without a message
+	if message, err := q.Receive(ctx); err != nil || message != nil {
+		t.Errorf("Receive() on an empty queue = %v, %v; want nil, nil", message, err)
+	}
+}
@@ -0,0 +1,231 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go_di_architecture/internal/domain/storage"
+)
+
+// LocalStorage stores objects as files below a directory.
+//
+// Download links point at the API itself (router.DownloadPrefix) and carry an
+// expiry time and an HMAC-SHA256 signature over key and expiry, so links can
+// be shared without authentication but cannot be altered or reused after
+// they expire. Intended for development and single-instance deployments.
+//
+// Usage Example:
+//
+//	store := storage.NewLocalStorage("/var/lib/modules/exports", key, "/api/v1/downloads/")
+//	err := store.Put(ctx, "exports/modules.csv", file, size, "text/csv")
+//	link, err := store.PresignGet("exports/modules.csv", 15*time.Minute)
+type LocalStorage struct {
+	dir        string
+	signingKey []byte
+	urlPrefix  string
+}
+
+// NewLocalStorage creates a storage writing below dir.
+//
+// Parameters:
+//   - dir: Root directory of the stored files (created on first write)
+//   - signingKey: Secret used to sign download links
+//   - urlPrefix: Path under which the download route is mounted, ending in "/"
+//
+// Returns:
+//   - *LocalStorage: A new storage
+func NewLocalStorage(dir string, signingKey []byte, urlPrefix string) *LocalStorage {
+	return &LocalStorage{dir: dir, signingKey: signingKey, urlPrefix: urlPrefix}
+}
+
+// Put writes the object to a file, replacing it atomically.
+//
+// Parameters:
+//   - ctx: Context checked before writing
+//   - key: Relative path of the object
+//   - body: Object content
+//   - size: Number of bytes to read from body
+//   - contentType: Ignored; the type is derived from the extension on download
+//
+// Returns:
+//   - error: Error if the key is invalid or the file cannot be written
+func (s *LocalStorage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	target, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.CopyN(tmp, body, size); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write %s: %w", key, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), target)
+}
+
+// PresignGet returns a signed API path for downloading the object.
+//
+// Parameters:
+//   - key: Relative path of the object
+//   - ttl: How long the link stays valid
+//
+// Returns:
+//   - string: Path such as /api/v1/downloads/exports/modules.csv?expires=...&signature=...
+//   - error: Error if the key is invalid
+func (s *LocalStorage) PresignGet(key string, ttl time.Duration) (string, error) {
+	if _, err := s.path(key); err != nil {
+		return "", err
+	}
+
+	expires := strconv.FormatInt(time.Now().Add(ttl).Unix(), 10)
+	query := url.Values{"expires": {expires}, "signature": {s.sign(key, expires)}}
+	return s.urlPrefix + key + "?" + query.Encode(), nil
+}
+
+// Open verifies a download link and opens the object.
+//
+// Parameters:
+//   - key: Relative path of the object from the link
+//   - expires: Unix expiry time from the link
+//   - signature: Signature from the link
+//
+// Returns:
+//   - *os.File: The opened file; the caller must close it
+//   - error: storage.ErrInvalidSignature for bad or expired links, or an error
+//     satisfying errors.Is(err, fs.ErrNotExist) when the object is gone
+func (s *LocalStorage) Open(key, expires, signature string) (*os.File, error) {
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > expiresAt {
+		return nil, storage.ErrInvalidSignature
+	}
+	if !hmac.Equal([]byte(signature), []byte(s.sign(key, expires))) {
+		return nil, storage.ErrInvalidSignature
+	}
+
+	target, err := s.path(key)
+	if err != nil {
+		return nil, storage.ErrInvalidSignature
+	}
+	return os.Open(target)
+}
+
+// Get opens the object file.
+//
+// Parameters:
+//   - ctx: Context checked before opening
+//   - key: Relative path of the object
+//
+// Returns:
+//   - io.ReadCloser: The opened file
+//   - error: storage.ErrObjectNotFound when the file does not exist, or an
+//     error if the key is invalid
+func (s *LocalStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	target, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(target)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", storage.ErrObjectNotFound, key)
+	}
+	return file, err
+}
+
+// List walks the directory for objects whose key starts with prefix.
+//
+// Parameters:
+//   - ctx: Context cancelling the walk
+//   - prefix: Key prefix, e.g. "backups/"
+//
+// Returns:
+//   - []storage.ObjectInfo: Matching objects ordered by key (unfinished uploads are skipped)
+//   - error: Error if the directory cannot be read
+func (s *LocalStorage) List(ctx context.Context, prefix string) ([]storage.ObjectInfo, error) {
+	objects := []storage.ObjectInfo{}
+	err := filepath.WalkDir(s.dir, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			return nil
+		}
+
+		relative, err := filepath.Rel(s.dir, file)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(relative)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, storage.ObjectInfo{Key: key, Size: info.Size(), LastModified: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// WalkDir visits entries in lexical order per directory, which differs from
+	// key order when a name sorts before a directory it prefixes
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	return objects, nil
+}
+
+// path maps a key to a file below the root, rejecting keys escaping it.
+func (s *LocalStorage) path(key string) (string, error) {
+	clean := path.Clean("/" + key)[1:]
+	if key == "" || clean != key || strings.HasPrefix(key, ".") {
+		return "", fmt.Errorf("invalid storage key %q", key)
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(clean)), nil
+}
+
+// sign computes the hex HMAC-SHA256 of key and expiry.
+func (s *LocalStorage) sign(key, expires string) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write([]byte(key + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,216 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"go_di_architecture/internal/domain/storage"
+	"go_di_architecture/internal/infra/httpclient"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// maxPresignExpires is the longest validity of a pre-signed URL.
+const maxPresignExpires = 7 * 24 * time.Hour
+
+// S3Config holds the settings of an S3-compatible object store.
+type S3Config struct {
+	// Base URL of the service, e.g. https://s3.eu-central-1.amazonaws.com or
+	// https://storage.googleapis.com
+	Endpoint string
+
+	// Signing region (use "auto" or any region for Google Cloud Storage)
+	Region string
+
+	// Bucket receiving the objects
+	Bucket string
+
+	// Access key pair (HMAC keys for Google Cloud Storage)
+	AccessKeyID     string
+	SecretAccessKey string
+
+	// Address the bucket in the path (endpoint/bucket/key) instead of the
+	// host name (bucket.endpoint/key); required by MinIO
+	ForcePathStyle bool
+}
+
+// S3Storage stores objects in an S3-compatible bucket.
+//
+// Requests go through the MinIO client, which speaks to AWS S3, Google Cloud
+// Storage (XML API with HMAC keys) and MinIO alike. It sends them with the
+// shared HTTP client, so the breaker, counters and egress rules of the "s3"
+// integration apply; the MinIO client does not retry on its own.
+//
+// Usage Example:
+//
+//	store, err := storage.NewS3Storage(storage.S3Config{
+//	    Endpoint:        "https://storage.googleapis.com",
+//	    Region:          "auto",
+//	    Bucket:          "module-exports",
+//	    AccessKeyID:     "GOOG1E...",
+//	    SecretAccessKey: "...",
+//	}, httpclient.New("s3", httpclient.Config{Timeout: time.Minute}))
+type S3Storage struct {
+	bucket string
+	client *minio.Client
+}
+
+// NewS3Storage creates a storage for the configured bucket.
+//
+// Parameters:
+//   - config: Endpoint, bucket and credentials
+//   - client: Client sending the requests; streamed uploads are not retried
+//
+// Returns:
+//   - *S3Storage: A new storage
+//   - error: Error if the endpoint is not an http or https URL
+func NewS3Storage(config S3Config, client *httpclient.Client) (*S3Storage, error) {
+	endpoint, err := url.Parse(config.Endpoint)
+	if err != nil || endpoint.Host == "" || (endpoint.Scheme != "http" && endpoint.Scheme != "https") {
+		return nil, fmt.Errorf("invalid S3 endpoint %q", config.Endpoint)
+	}
+
+	lookup := minio.BucketLookupDNS
+	if config.ForcePathStyle {
+		lookup = minio.BucketLookupPath
+	}
+	s3, err := minio.New(endpoint.Host, &minio.Options{
+		Creds:        credentials.NewStaticV4(config.AccessKeyID, config.SecretAccessKey, ""),
+		Secure:       endpoint.Scheme == "https",
+		Region:       config.Region,
+		BucketLookup: lookup,
+		Transport:    clientTransport{client: client},
+		MaxRetries:   1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid S3 endpoint %q: %w", config.Endpoint, err)
+	}
+	return &S3Storage{bucket: config.Bucket, client: s3}, nil
+}
+
+// Put uploads the object.
+//
+// Parameters:
+//   - ctx: Context bounding the upload
+//   - key: Object key
+//   - body: Object content
+//   - size: Content length in bytes
+//   - contentType: MIME type stored with the object
+//
+// Returns:
+//   - error: Error if the upload fails
+func (s *S3Storage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	if _, err := s.client.PutObject(ctx, s.bucket, key, body, size, minio.PutObjectOptions{ContentType: contentType}); err != nil {
+		return fmt.Errorf("PUT %s: %w", key, err)
+	}
+	return nil
+}
+
+// Get downloads the object.
+//
+// Parameters:
+//   - ctx: Context bounding the download
+//   - key: Object key
+//
+// Returns:
+//   - io.ReadCloser: The object content; the caller must close it
+//   - error: storage.ErrObjectNotFound when the object does not exist, or an
+//     error if the request fails
+func (s *S3Storage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	if err := checkKey(key); err != nil {
+		return nil, err
+	}
+	object, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("GET %s: %w", key, err)
+	}
+
+	// The request is sent on first use; Stat surfaces a missing object here
+	if _, err := object.Stat(); err != nil {
+		object.Close()
+		return nil, fmt.Errorf("GET %s: %w", key, notFound(err))
+	}
+	return object, nil
+}
+
+// List returns the objects whose key starts with prefix.
+//
+// Parameters:
+//   - ctx: Context bounding the requests
+//   - prefix: Key prefix, e.g. "backups/"
+//
+// Returns:
+//   - []storage.ObjectInfo: Matching objects ordered by key
+//   - error: Error if a request fails
+func (s *S3Storage) List(ctx context.Context, prefix string) ([]storage.ObjectInfo, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	objects := []storage.ObjectInfo{}
+	for object := range s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if object.Err != nil {
+			return nil, fmt.Errorf("list %s: %w", prefix, object.Err)
+		}
+		objects = append(objects, storage.ObjectInfo{Key: object.Key, Size: object.Size, LastModified: object.LastModified})
+	}
+	return objects, nil
+}
+
+// PresignGet builds a pre-signed GET URL for the object.
+//
+// Parameters:
+//   - key: Object key
+//   - ttl: How long the URL stays valid (at most 7 days)
+//
+// Returns:
+//   - string: The pre-signed URL
+//   - error: Error if the key or ttl is invalid
+func (s *S3Storage) PresignGet(key string, ttl time.Duration) (string, error) {
+	if ttl < time.Second || ttl > maxPresignExpires {
+		return "", fmt.Errorf("presign ttl must be between 1s and %s", maxPresignExpires)
+	}
+	if err := checkKey(key); err != nil {
+		return "", err
+	}
+	link, err := s.client.PresignedGetObject(context.Background(), s.bucket, key, ttl, nil)
+	if err != nil {
+		return "", err
+	}
+	return link.String(), nil
+}
+
+// checkKey refuses empty and absolute object keys.
+func checkKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") {
+		return fmt.Errorf("invalid object key %q", key)
+	}
+	return nil
+}
+
+// notFound wraps storage.ErrObjectNotFound around the errors of missing objects.
+func notFound(err error) error {
+	if minio.ToErrorResponse(err).StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %w", storage.ErrObjectNotFound, err)
+	}
+	return err
+}
+
+// clientTransport sends the requests of the MinIO client with the shared
+// HTTP client.
+type clientTransport struct {
+	client *httpclient.Client
+}
+
+// RoundTrip sends the request.
+func (t clientTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	return t.client.Do(request)
+}
@@ -0,0 +1,204 @@
+package storage
+
+import (
+	"bufio"
+	"context"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go_di_architecture/internal/domain/storage"
+	"go_di_architecture/internal/infra/httpclient"
+)
+
+// fakeBucket serves the part of the S3 API used by S3Storage for one
+// path-style bucket and records the Authorization headers it receives.
+type fakeBucket struct {
+	mu             sync.Mutex
+	objects        map[string][]byte
+	authorizations []string
+}
+
+func (b *fakeBucket) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.authorizations = append(b.authorizations, r.Header.Get("Authorization"))
+
+	key, _ := strings.CutPrefix(r.URL.Path, "/exports/")
+	isObject := key != "" && key != r.URL.Path
+	switch {
+	case r.Method == http.MethodPut && isObject:
+		body, err := readPayload(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		b.objects[key] = body
+		w.Header().Set("ETag", `"etag"`)
+	case (r.Method == http.MethodGet || r.Method == http.MethodHead) && isObject:
+		body, ok := b.objects[key]
+		if !ok {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`)
+			return
+		}
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		w.Header().Set("ETag", `"etag"`)
+		w.Write(body)
+	case r.Method == http.MethodGet && r.URL.Path == "/exports/":
+		type content struct {
+			Key          string
+			Size         int
+			LastModified string
+		}
+		result := struct {
+			XMLName  xml.Name `xml:"ListBucketResult"`
+			Name     string
+			Contents []content
+		}{Name: "exports"}
+		prefix := r.URL.Query().Get("prefix")
+		for key, body := range b.objects {
+			if strings.HasPrefix(key, prefix) {
+				result.Contents = append(result.Contents, content{Key: key, Size: len(body), LastModified: "2026-01-02T03:04:05.000Z"})
+			}
+		}
+		sort.Slice(result.Contents, func(i, j int) bool { return result.Contents[i].Key < result.Contents[j].Key })
+		w.Header().Set("Content-Type", "application/xml")
+		xml.NewEncoder(w).Encode(result)
+	default:
+		w.WriteHeader(http.StatusNotImplemented)
+	}
+}
+
+// readPayload reads an upload body, decoding the aws-chunked encoding used
+// for signed streaming uploads.
+func readPayload(r *http.Request) ([]byte, error) {
+	if !strings.HasPrefix(r.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+		return io.ReadAll(r.Body)
+	}
+
+	var payload []byte
+	reader := bufio.NewReader(r.Body)
+	for {
+		header, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		hexSize, _, _ := strings.Cut(strings.TrimSpace(header), ";")
+		size, err := strconv.ParseInt(hexSize, 16, 64)
+		if err != nil {
+			return nil, err
+		}
+		if size == 0 {
+			return payload, nil
+		}
+		chunk := make([]byte, size+2)
+		if _, err := io.ReadFull(reader, chunk); err != nil {
+			return nil, err
+		}
+		payload = append(payload, chunk[:size]...)
+	}
+}
+
+func newTestS3Storage(t *testing.T) (*S3Storage, *fakeBucket) {
+	t.Helper()
+	bucket := &fakeBucket{objects: make(map[string][]byte)}
+	server := httptest.NewServer(bucket)
+	t.Cleanup(server.Close)
+
+	store, err := NewS3Storage(S3Config{
+		Endpoint:        server.URL,
+		Region:          "us-east-1",
+		Bucket:          "exports",
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "secret",
+		ForcePathStyle:  true,
+	}, httpclient.New("s3-test", httpclient.Config{Timeout: 5 * time.Second}))
+	if err != nil {
+		t.Fatalf("NewS3Storage() error = %v", err)
+	}
+	return store, bucket
+}
+
+func TestS3Storage(t *testing.T) {
+	store, bucket := newTestS3Storage(t)
+	ctx := context.Background()
+
+	for _, key := range []string{"exports/b.csv", "exports/a.csv", "backups/c.json"} {
+		if err := store.Put(ctx, key, strings.NewReader("id,name"), 7, "text/csv"); err != nil {
+			t.Fatalf("Put(%q) error = %v", key, err)
+		}
+	}
+	if auth := bucket.authorizations[0]; !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/") {
+		t.Errorf("Authorization = %q, want a SigV4 signature", auth)
+	}
+
+	body, err := store.Get(ctx, "exports/a.csv")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	content, _ := io.ReadAll(body)
+	body.Close()
+	if string(content) != "id,name" {
+		t.Errorf("Get() content = %q, want id,name", content)
+	}
+
+	if _, err := store.Get(ctx, "exports/missing.csv"); !errors.Is(err, storage.ErrObjectNotFound) {
+		t.Errorf("Get(missing) error = %v, want storage.ErrObjectNotFound", err)
+	}
+
+	objects, err := store.List(ctx, "exports/")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(objects) != 2 || objects[0].Key != "exports/a.csv" || objects[1].Key != "exports/b.csv" || objects[0].Size != 7 {
+		t.Errorf("List() = %+v, want exports/a.csv and exports/b.csv", objects)
+	}
+}
+
+func TestS3StoragePresignGet(t *testing.T) {
+	store, _ := newTestS3Storage(t)
+
+	link, err := store.PresignGet("exports/a b.csv", 15*time.Minute)
+	if err != nil {
+		t.Fatalf("PresignGet() error = %v", err)
+	}
+	parsed, err := url.Parse(link)
+	if err != nil {
+		t.Fatalf("PresignGet() = %q, not a URL", link)
+	}
+	if parsed.Path != "/exports/exports/a b.csv" {
+		t.Errorf("path = %q, want the path-style object path", parsed.Path)
+	}
+	query := parsed.Query()
+	if query.Get("X-Amz-Expires") != "900" || query.Get("X-Amz-Signature") == "" {
+		t.Errorf("query = %v, want a 900s SigV4 signature", query)
+	}
+
+	for _, ttl := range []time.Duration{0, 8 * 24 * time.Hour} {
+		if _, err := store.PresignGet("exports/a.csv", ttl); err == nil {
+			t.Errorf("PresignGet(ttl %s) error = nil", ttl)
+		}
+	}
+	if _, err := store.PresignGet("/exports/a.csv", time.Minute); err == nil {
+		t.Error("PresignGet(absolute key) error = nil")
+	}
+}
+
+func TestNewS3StorageRefusesInvalidEndpoint(t *testing.T) {
+	for _, endpoint := range []string{"", "storage.googleapis.com", "ftp://example.com"} {
+		if _, err := NewS3Storage(S3Config{Endpoint: endpoint, Bucket: "exports"}, httpclient.New("s3-test", httpclient.Config{})); err == nil {
+			t.Errorf("NewS3Storage(%q) error = nil", endpoint)
+		}
+	}
+}
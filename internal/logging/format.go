@@ -0,0 +1,69 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Line formats
+const (
+	// FormatText writes "[INFO] message" lines for people reading a terminal
+	FormatText = "text"
+
+	// FormatJSON writes one JSON object per line for log collectors, e.g.
+	// {"time":"2024-01-01T00:00:00Z","level":"info","logger":"saga","msg":"..."}
+	FormatJSON = "json"
+)
+
+// jsonFormat is set while lines are written as JSON.
+var jsonFormat atomic.Bool
+
+// IsFormat reports whether the value names a supported line format.
+//
+// Parameters:
+//   - format: The format name to check
+//
+// Returns:
+//   - bool: True for text and json
+func IsFormat(format string) bool {
+	return format == FormatText || format == FormatJSON
+}
+
+// SetFormat changes the format of the lines of every logger.
+//
+// Parameters:
+//   - format: FormatText or FormatJSON; other values select text
+func SetFormat(format string) {
+	jsonFormat.Store(format == FormatJSON)
+}
+
+// jsonLine is a log line in the JSON format.
+type jsonLine struct {
+	Time    string `json:"time"`
+	Level   string `json:"level"`
+	Logger  string `json:"logger"`
+	Message string `json:"msg"`
+}
+
+// write prints a formatted line of a logger to standard output.
+func write(level Level, logger, message string) {
+	if !jsonFormat.Load() {
+		fmt.Printf("[%s] %s\n", strings.ToUpper(level.String()), message)
+		return
+	}
+	line, err := json.Marshal(jsonLine{
+		Time:    time.Now().UTC().Format(time.RFC3339Nano),
+		Level:   level.String(),
+		Logger:  logger,
+		Message: message,
+	})
+	if err != nil {
+		fmt.Printf("[%s] %s\n", strings.ToUpper(level.String()), message)
+		return
+	}
+	os.Stdout.Write(append(line, '\n'))
+}
@@ -0,0 +1,204 @@
+package logging
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Level is the severity of a log line.
+type Level int32
+
+// Log levels, from most to least verbose
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// levelNames are the names of the levels, as printed and parsed.
+var levelNames = map[Level]string{
+	LevelDebug: "debug",
+	LevelInfo:  "info",
+	LevelWarn:  "warn",
+	LevelError: "error",
+}
+
+// String returns the lowercase name of the level, e.g. "warn".
+func (l Level) String() string {
+	if name, ok := levelNames[l]; ok {
+		return name
+	}
+	return fmt.Sprintf("level(%d)", int32(l))
+}
+
+// ParseLevel reads a level name (debug, info, warn, error; case-insensitive).
+//
+// Parameters:
+//   - name: The level name
+//
+// Returns:
+//   - Level: The level
+//   - error: Error if the name is unknown
+func ParseLevel(name string) (Level, error) {
+	for level, levelName := range levelNames {
+		if strings.EqualFold(name, levelName) {
+			return level, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown log level %q", name)
+}
+
+// registry holds the loggers of the process and their levels.
+//
+// Loggers without an override follow the default level, so changing the
+// default affects all of them at once.
+var registry = struct {
+	mu           sync.Mutex
+	defaultLevel atomic.Int32
+	loggers      map[string]*Logger
+}{loggers: make(map[string]*Logger)}
+
+func init() {
+	registry.defaultLevel.Store(int32(LevelInfo))
+}
+
+// Logger writes the log lines of one component, e.g. "scheduler".
+//
+// Lines keep the format used across the application ("[INFO] message", or
+// JSON after SetFormat) and go to standard output. Lines below the logger's
+// level are dropped before they are formatted.
+//
+// Usage Example:
+//
+//	var log = logging.New("scheduler")
+//	log.Errorf("Scheduled job %s failed: %v", job.Name, err)
+type Logger struct {
+	name string
+
+	// Overriding level, or -1 to follow the default
+	level atomic.Int32
+}
+
+// New returns the logger of a component, creating it on first use.
+//
+// Parameters:
+//   - name: Component name, also the key of the level endpoint
+//
+// Returns:
+//   - *Logger: The logger; every call with the same name returns the same one
+func New(name string) *Logger {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	if logger, ok := registry.loggers[name]; ok {
+		return logger
+	}
+	logger := &Logger{name: name}
+	logger.level.Store(-1)
+	registry.loggers[name] = logger
+	return logger
+}
+
+// Name returns the component name of the logger.
+func (l *Logger) Name() string {
+	return l.name
+}
+
+// Level returns the effective level of the logger.
+func (l *Logger) Level() Level {
+	if level := l.level.Load(); level >= 0 {
+		return Level(level)
+	}
+	return Level(registry.defaultLevel.Load())
+}
+
+// Enabled reports whether lines of a level are written.
+func (l *Logger) Enabled(level Level) bool {
+	return level >= l.Level()
+}
+
+// Debugf writes a debug line.
+func (l *Logger) Debugf(format string, args ...any) {
+	l.logf(LevelDebug, format, args...)
+}
+
+// Infof writes an informational line.
+func (l *Logger) Infof(format string, args ...any) {
+	l.logf(LevelInfo, format, args...)
+}
+
+// Warnf writes a warning line.
+func (l *Logger) Warnf(format string, args ...any) {
+	l.logf(LevelWarn, format, args...)
+}
+
+// Errorf writes an error line.
+func (l *Logger) Errorf(format string, args ...any) {
+	l.logf(LevelError, format, args...)
+}
+
+// logf formats and writes a line if its level is enabled.
+func (l *Logger) logf(level Level, format string, args ...any) {
+	if !l.Enabled(level) {
+		return
+	}
+	write(level, l.name, fmt.Sprintf(format, args...))
+}
+
+// SetDefaultLevel changes the level of every logger without an override.
+func SetDefaultLevel(level Level) {
+	registry.defaultLevel.Store(int32(level))
+}
+
+// SetLevel overrides the level of one logger.
+//
+// Parameters:
+//   - name: Component name; the logger is created if no component uses it yet
+//   - level: The new level
+func SetLevel(name string, level Level) {
+	New(name).level.Store(int32(level))
+}
+
+// ResetLevel drops the override of a logger, so it follows the default again.
+func ResetLevel(name string) {
+	New(name).level.Store(-1)
+}
+
+// Levels is a snapshot of the configured levels.
+type Levels struct {
+	// Level of loggers without an override
+	Default string `json:"default"`
+
+	// Effective level of every known logger
+	Loggers map[string]string `json:"loggers"`
+
+	// Names of the loggers with an override, sorted
+	Overridden []string `json:"overridden"`
+}
+
+// CurrentLevels returns the levels of all known loggers.
+//
+// Returns:
+//   - Levels: The default level and the level of every logger
+func CurrentLevels() Levels {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	levels := Levels{
+		Default:    Level(registry.defaultLevel.Load()).String(),
+		Loggers:    make(map[string]string, len(registry.loggers)),
+		Overridden: []string{},
+	}
+	for name, logger := range registry.loggers {
+		levels.Loggers[name] = logger.Level().String()
+		if logger.level.Load() >= 0 {
+			levels.Overridden = append(levels.Overridden, name)
+		}
+	}
+	sort.Strings(levels.Overridden)
+	return levels
+}
@@ -0,0 +1,77 @@
+package logging
+
+import (
+	"sync"
+	"time"
+)
+
+// Sampler thins out high-volume log lines.
+//
+// Within each second the first Initial lines pass, then every Thereafter-th
+// line; the rest are dropped and counted. This is the scheme of zap's
+// sampler: quiet periods are logged in full while bursts keep a steady trickle.
+//
+// Usage Example:
+//
+//	sampler := logging.NewSampler(100, 10)
+//	if sampler.Allow() {
+//	    log.Infof("GET /api/v1/modules 200")
+//	}
+type Sampler struct {
+	initial    int
+	thereafter int
+
+	mu      sync.Mutex
+	second  int64
+	seen    int
+	dropped int64
+}
+
+// NewSampler creates a sampler.
+//
+// Parameters:
+//   - initial: Lines passed per second before sampling starts; 0 disables
+//     sampling (every line passes)
+//   - thereafter: Every how many lines one passes once sampling started; 0
+//     drops all of them
+//
+// Returns:
+//   - *Sampler: The sampler
+func NewSampler(initial, thereafter int) *Sampler {
+	return &Sampler{initial: initial, thereafter: thereafter}
+}
+
+// Allow reports whether the next line is written.
+//
+// A nil sampler allows every line.
+func (s *Sampler) Allow() bool {
+	if s == nil || s.initial <= 0 {
+		return true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if second := time.Now().Unix(); second != s.second {
+		s.second = second
+		s.seen = 0
+	}
+	s.seen++
+
+	if s.seen <= s.initial || (s.thereafter > 0 && (s.seen-s.initial)%s.thereafter == 0) {
+		return true
+	}
+	s.dropped++
+	return false
+}
+
+// Dropped returns the number of lines dropped so far.
+func (s *Sampler) Dropped() int64 {
+	if s == nil {
+		return 0
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dropped
+}
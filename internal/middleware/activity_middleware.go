@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"time"
+
+	"go_di_architecture/internal/app/activity"
+	"go_di_architecture/internal/domain/models/dashboard"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ActivityHandler feeds finished requests to the recorder of the operational
+// dashboards, which keeps the server errors and slow requests and counts each
+// request against the service level objective of its route.
+//
+// It must run outside the exception handler so it sees the final status and
+// the internal error of a server error.
+//
+// Parameters:
+//   - recorder: The recorder keeping the requests
+//
+// Returns:
+//   - gin.HandlerFunc: A middleware handler function
+func ActivityHandler(recorder *activity.Recorder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		request := dashboard.Request{
+			RequestID:  c.GetString("request_id"),
+			Method:     c.Request.Method,
+			Route:      c.FullPath(),
+			Path:       c.Request.URL.Path,
+			Status:     c.Writer.Status(),
+			DurationMs: float64(time.Since(start).Microseconds()) / 1000,
+			FinishedAt: time.Now(),
+		}
+		if err := c.Errors.Last(); err != nil {
+			request.Error = err.Error()
+		}
+		recorder.Record(request)
+	}
+}
@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"go_di_architecture/internal/app/auth"
+	"go_di_architecture/internal/domain/models/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuthenticationHandler identifies the principal of each request.
+//
+// This middleware handler:
+//   - Attaches the principal returned by the authenticator to the request
+//   - Leaves requests without credentials unauthenticated, so public routes
+//     stay reachable; routes with a policy reject them
+//   - Rejects requests with invalid credentials with 401 UNAUTHORIZED
+//
+// Without an authenticator every request gets the anonymous principal, which
+// reads the API until credentials are configured.
+//
+// Parameters:
+//   - authenticator: Source of principals (nil disables authentication)
+//   - anonymous: Principal of every request while authentication is disabled
+//     (nil is auth.NewAnonymous(nil), which holds auth.AnonymousScopes)
+//
+// Returns:
+//   - gin.HandlerFunc: A middleware handler function
+func AuthenticationHandler(authenticator auth.Authenticator, anonymous *auth.Principal) gin.HandlerFunc {
+	if anonymous == nil {
+		anonymous = auth.NewAnonymous(nil)
+	}
+	return func(c *gin.Context) {
+		if authenticator == nil {
+			auth.SetPrincipal(c, anonymous)
+			c.Next()
+			return
+		}
+
+		principal, err := authenticator.Authenticate(c.Request)
+		if err != nil {
+			abortUnauthenticated(c, map[string][]string{"credentials": {err.Error()}})
+			return
+		}
+		if principal != nil {
+			auth.SetPrincipal(c, principal)
+		}
+		c.Next()
+	}
+}
+
+// AuthorizationHandler enforces a policy before the route handler runs.
+//
+// This middleware handler:
+//   - Rejects unauthenticated requests with 401 UNAUTHORIZED
+//   - Rejects principals lacking a required scope with 403 FORBIDDEN, listing
+//     the missing scopes in the error details
+//
+// Parameters:
+//   - policy: Requirements of the route
+//
+// Returns:
+//   - gin.HandlerFunc: A middleware handler function
+func AuthorizationHandler(policy auth.Policy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		err := policy.Authorize(auth.PrincipalFrom(c))
+		if err == nil {
+			c.Next()
+			return
+		}
+
+		var insufficient *auth.InsufficientScopeError
+		if errors.As(err, &insufficient) {
+			details := make(map[string][]string, 1)
+			for _, scope := range insufficient.Missing {
+				details["scope"] = append(details["scope"], fmt.Sprintf("Requires %s", scope))
+			}
+			c.Error(response.NewHTTPError(http.StatusForbidden, "FORBIDDEN", details))
+			c.Abort()
+			return
+		}
+		abortUnauthenticated(c, nil)
+	}
+}
+
+// abortUnauthenticated ends a request with a 401 error.
+func abortUnauthenticated(c *gin.Context, details map[string][]string) {
+	c.Error(response.NewHTTPError(http.StatusUnauthorized, "UNAUTHORIZED", details))
+	c.Abort()
+}
@@ -0,0 +1,44 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go_di_architecture/internal/app/auth"
+	"go_di_architecture/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestAuthenticationHandlerAnonymousScopes checks requests of an API without
+// authentication get the scopes of the anonymous principal: reading only by
+// default, everything when granted admin.
+func TestAuthenticationHandlerAnonymousScopes(t *testing.T) {
+	tests := []struct {
+		name      string
+		anonymous *auth.Principal
+		wantRead  int
+		wantWrite int
+	}{
+		{name: "default", anonymous: nil, wantRead: http.StatusOK, wantWrite: http.StatusForbidden},
+		{name: "admin", anonymous: auth.NewAnonymous([]string{auth.ScopeAdmin}), wantRead: http.StatusOK, wantWrite: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := gin.New()
+			engine.Use(middleware.ExceptionHandler(), middleware.AuthenticationHandler(nil, tt.anonymous))
+			ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+			engine.GET("/modules", middleware.AuthorizationHandler(auth.Policy{Scopes: []string{auth.ScopeModulesRead}}), ok)
+			engine.POST("/modules", middleware.AuthorizationHandler(auth.Policy{Scopes: []string{auth.ScopeModulesWrite}}), ok)
+
+			for method, want := range map[string]int{http.MethodGet: tt.wantRead, http.MethodPost: tt.wantWrite} {
+				recorder := httptest.NewRecorder()
+				engine.ServeHTTP(recorder, httptest.NewRequest(method, "/modules", nil))
+				if recorder.Code != want {
+					t.Errorf("%s status = %d, want %d", method, recorder.Code, want)
+				}
+			}
+		})
+	}
+}
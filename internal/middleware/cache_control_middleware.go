@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultPublicCacheMaxAge is how long clients and shared caches may reuse
+// public API responses unless configured otherwise.
+const DefaultPublicCacheMaxAge = 5 * time.Minute
+
+// CacheControlHandler lets browsers and shared caches store successful responses.
+//
+// This middleware handler:
+//   - Sends "Cache-Control: public, max-age=<seconds>" with responses below
+//     400, including 304 Not Modified
+//   - Sends "Cache-Control: no-store" with errors, so a CDN never serves a
+//     429 or 500 to other clients
+//
+// Only meant for routes whose responses do not depend on the caller.
+//
+// Parameters:
+//   - maxAge: How long a response may be reused
+//
+// Returns:
+//   - gin.HandlerFunc: A middleware handler function
+func CacheControlHandler(maxAge time.Duration) gin.HandlerFunc {
+	public := fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds()))
+	return func(c *gin.Context) {
+		// The writer stays in place so errors rendered after the handler
+		// returns are marked too
+		c.Writer = &cacheControlWriter{ResponseWriter: c.Writer, public: public}
+		c.Next()
+	}
+}
+
+// cacheControlWriter sets the Cache-Control header once the status is known.
+type cacheControlWriter struct {
+	gin.ResponseWriter
+	public string
+}
+
+// WriteHeader picks the Cache-Control header for the status before it is sent.
+func (w *cacheControlWriter) WriteHeader(code int) {
+	if !w.Written() {
+		if code < http.StatusBadRequest {
+			w.Header().Set("Cache-Control", w.public)
+		} else {
+			w.Header().Set("Cache-Control", "no-store")
+		}
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
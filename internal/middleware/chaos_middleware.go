@@ -0,0 +1,266 @@
+package middleware
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go_di_architecture/internal/domain/models/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ChaosHeader is set on responses whose failure was injected, so clients and
+// logs can tell simulated faults from real ones.
+const ChaosHeader = "X-Chaos-Fault"
+
+// chaosStatuses are the server errors injected at random.
+var chaosStatuses = []int{
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// ChaosRule describes the faults injected into the requests of a route.
+type ChaosRule struct {
+	// Method of the route ("" matches every method)
+	Method string
+
+	// Route pattern as registered, e.g. /api/v1/modules/:id ("*" matches every route)
+	Route string
+
+	// Range of the added latency (both zero adds none)
+	MinLatency time.Duration
+	MaxLatency time.Duration
+
+	// Share of requests answered with a random 5xx error (0 to 1)
+	ErrorRate float64
+
+	// Share of requests whose connection is closed without a response (0 to 1)
+	DropRate float64
+
+	// Canned error answered to a share of requests (empty code answers none),
+	// e.g. 409 RESOURCE_CONFLICT
+	FailStatus int
+	FailCode   string
+	FailRate   float64
+}
+
+// matches reports whether the rule applies to a request of the route.
+func (r ChaosRule) matches(method, route string) bool {
+	if r.Method != "" && r.Method != method {
+		return false
+	}
+	return r.Route == "*" || r.Route == route
+}
+
+// ParseChaosRules parses fault injection rules such as
+// "GET /api/v1/modules/:id=latency:100ms-2s,error:0.1;*=drop:0.01".
+//
+// Rules are separated by semicolons; each maps a route to comma-separated
+// faults:
+//   - latency:<duration> or latency:<min>-<max> adds a fixed or random delay
+//   - error:<rate> answers that share of requests with a random 5xx error
+//   - drop:<rate> closes the connection of that share of requests
+//   - fail:<code> or fail:<code>@<rate> answers every request, or that share
+//     of requests, with the error code of the API and its status (e.g.
+//     fail:RESOURCE_CONFLICT@0.5 answers half of them with 409)
+//
+// The route is a registered route pattern, optionally preceded by a method,
+// or "*" for every route. Blank rules and whitespace are ignored.
+//
+// Parameters:
+//   - spec: The chaos rules
+//
+// Returns:
+//   - []ChaosRule: The parsed rules in spec order (empty when spec is blank)
+//   - error: Error if a rule or fault is malformed
+func ParseChaosRules(spec string) ([]ChaosRule, error) {
+	var rules []ChaosRule
+	for _, rule := range strings.Split(spec, ";") {
+		if strings.TrimSpace(rule) == "" {
+			continue
+		}
+
+		route, faults, ok := strings.Cut(rule, "=")
+		route = strings.TrimSpace(route)
+		if !ok || route == "" || strings.TrimSpace(faults) == "" {
+			return nil, fmt.Errorf("rule %q must look like [<method> ]<route>=<fault>,...", rule)
+		}
+
+		parsed := ChaosRule{Route: route}
+		if method, path, found := strings.Cut(route, " "); found {
+			parsed.Method = strings.ToUpper(method)
+			parsed.Route = strings.TrimSpace(path)
+		}
+		if parsed.Route != "*" && !strings.HasPrefix(parsed.Route, "/") {
+			return nil, fmt.Errorf("rule %q: route must be \"*\" or start with /", rule)
+		}
+
+		for _, fault := range strings.Split(faults, ",") {
+			if err := parseChaosFault(&parsed, strings.TrimSpace(fault)); err != nil {
+				return nil, fmt.Errorf("rule %q: %w", rule, err)
+			}
+		}
+		rules = append(rules, parsed)
+	}
+	return rules, nil
+}
+
+// parseChaosFault applies one fault of a rule.
+func parseChaosFault(rule *ChaosRule, fault string) error {
+	kind, value, ok := strings.Cut(fault, ":")
+	if !ok {
+		return fmt.Errorf("fault %q must look like <kind>:<value>", fault)
+	}
+
+	switch kind {
+	case "latency":
+		minValue, maxValue, isRange := strings.Cut(value, "-")
+		if !isRange {
+			maxValue = minValue
+		}
+		minLatency, err := time.ParseDuration(minValue)
+		if err != nil || minLatency < 0 {
+			return fmt.Errorf("invalid latency %q", value)
+		}
+		maxLatency, err := time.ParseDuration(maxValue)
+		if err != nil || maxLatency < minLatency {
+			return fmt.Errorf("invalid latency %q", value)
+		}
+		rule.MinLatency, rule.MaxLatency = minLatency, maxLatency
+
+	case "error", "drop":
+		rate, err := strconv.ParseFloat(value, 64)
+		if err != nil || rate < 0 || rate > 1 {
+			return fmt.Errorf("invalid %s rate %q (expected 0 to 1)", kind, value)
+		}
+		if kind == "error" {
+			rule.ErrorRate = rate
+		} else {
+			rule.DropRate = rate
+		}
+
+	case "fail":
+		code, rawRate, hasRate := strings.Cut(value, "@")
+		rate := 1.0
+		if hasRate {
+			parsed, err := strconv.ParseFloat(rawRate, 64)
+			if err != nil || parsed < 0 || parsed > 1 {
+				return fmt.Errorf("invalid fail rate %q (expected 0 to 1)", rawRate)
+			}
+			rate = parsed
+		}
+		status, ok := errorStatus(code)
+		if !ok {
+			return fmt.Errorf("unknown error code %q", code)
+		}
+		rule.FailStatus, rule.FailCode, rule.FailRate = status, code, rate
+
+	default:
+		return fmt.Errorf("unknown fault %q (expected latency, error, drop or fail)", kind)
+	}
+	return nil
+}
+
+// errorStatus returns the status the API answers an error code with.
+func errorStatus(code string) (int, bool) {
+	for _, known := range response.Errors.Codes() {
+		if known.Code == code && known.Status >= http.StatusBadRequest {
+			return known.Status, true
+		}
+	}
+	return 0, false
+}
+
+// ChaosHandler injects faults so clients can exercise their retry logic.
+//
+// This middleware handler applies the first rule matching the request's method
+// and route pattern:
+//   - Sleeps for the rule's latency, returning early when the client gives up
+//   - Drops the connection of a share of requests without writing a response
+//   - Answers a share of requests with a random 500, 502, 503 or 504 error
+//   - Answers a share of requests with the rule's canned error
+//
+// Injected errors and drops are logged and marked with the X-Chaos-Fault
+// header where a response is written. Meant for development and staging only;
+// the configuration refuses chaos rules in production.
+//
+// Parameters:
+//   - rules: Fault injection rules, first match wins
+//
+// Returns:
+//   - gin.HandlerFunc: A middleware handler function
+func ChaosHandler(rules []ChaosRule) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.FullPath()
+		var rule *ChaosRule
+		for i := range rules {
+			if rules[i].matches(c.Request.Method, route) {
+				rule = &rules[i]
+				break
+			}
+		}
+		if rule == nil {
+			c.Next()
+			return
+		}
+
+		// Step 1: Delay the request
+		if latency := chaosLatency(rule); latency > 0 {
+			timer := time.NewTimer(latency)
+			select {
+			case <-timer.C:
+			case <-c.Request.Context().Done():
+				timer.Stop()
+				c.Abort()
+				return
+			}
+		}
+
+		// Step 2: Drop the connection
+		if rule.DropRate > 0 && rand.Float64() < rule.DropRate {
+			if hijacker, ok := c.Writer.(http.Hijacker); ok {
+				if conn, _, err := hijacker.Hijack(); err == nil {
+					logger.Warnf("[%s] Chaos dropped connection of %s %s", c.GetString("request_id"), c.Request.Method, route)
+					conn.Close()
+					c.Abort()
+					return
+				}
+			}
+		}
+
+		// Step 3: Fail the request
+		if rule.ErrorRate > 0 && rand.Float64() < rule.ErrorRate {
+			status := chaosStatuses[rand.IntN(len(chaosStatuses))]
+			logger.Warnf("[%s] Chaos injected %d into %s %s", c.GetString("request_id"), status, c.Request.Method, route)
+			c.Header(ChaosHeader, "error")
+			c.Error(response.NewHTTPError(status, "CHAOS_FAULT", nil))
+			c.Abort()
+			return
+		}
+
+		// Step 4: Answer with the canned error
+		if rule.FailCode != "" && rand.Float64() < rule.FailRate {
+			logger.Warnf("[%s] Chaos injected %s into %s %s", c.GetString("request_id"), rule.FailCode, c.Request.Method, route)
+			c.Header(ChaosHeader, "fail")
+			c.Error(response.NewHTTPError(rule.FailStatus, rule.FailCode, nil))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// chaosLatency picks the delay of a request within the rule's range.
+func chaosLatency(rule *ChaosRule) time.Duration {
+	if rule.MaxLatency <= rule.MinLatency {
+		return rule.MinLatency
+	}
+	return rule.MinLatency + rand.N(rule.MaxLatency-rule.MinLatency+1)
+}
@@ -0,0 +1,193 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go_di_architecture/internal/domain/cdn"
+	"go_di_architecture/internal/domain/models/module"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SurrogateKeyHeader lists the surrogate keys of a cached response.
+const SurrogateKeyHeader = "Surrogate-Key"
+
+// surrogateKeysKey holds the surrogate keys of the entities a handler returned.
+const surrogateKeysKey = "surrogate_keys"
+
+// edgeVary lists the request headers a read response may depend on besides
+// its URL: content negotiation and every credential deciding what is visible.
+const edgeVary = "Accept, Authorization, X-API-Key, X-Actor, X-Actor-Teams, X-Response-Format"
+
+// purgeTimeout bounds a background purge, including the retries of the client.
+const purgeTimeout = time.Minute
+
+// relatedCollections maps sub-resources of a module to the collections they
+// show: dependency walks list other modules, tag assignments show tags.
+var relatedCollections = map[string]string{
+	"dependencies": module.SurrogateCollectionKey,
+	"dependents":   module.SurrogateCollectionKey,
+	"tags":         "tags",
+}
+
+// AddSurrogateKeys tags the response of the request with entity keys.
+//
+// Handlers call it with the keys of the entities they return, e.g. every
+// module of a list, so a purge of one module also drops the lists showing it.
+//
+// Parameters:
+//   - c: Gin context for the request
+//   - keys: Surrogate keys to add
+func AddSurrogateKeys(c *gin.Context, keys ...string) {
+	if len(keys) == 0 {
+		return
+	}
+	c.Set(surrogateKeysKey, append(c.GetStringSlice(surrogateKeysKey), keys...))
+}
+
+// EdgeCacheHandler makes read responses cacheable by a CDN and purges them on writes.
+//
+// This middleware handler:
+//   - Tags successful GET and HEAD responses with a Surrogate-Key header: the
+//     module of /modules/:id routes ("module-123") or the collection of other
+//     routes ("modules", "tags"), plus the keys handlers add for the entities
+//     they return
+//   - Adds the credential and negotiation headers to Vary
+//   - Sends "Cache-Control: max-age=0, s-maxage=<seconds>" with successful
+//     reads, so browsers revalidate with the ETag while the CDN serves them
+//     for maxAge; a zero maxAge sends "no-cache" and keeps shared caches from
+//     storing them. Routes setting their own Cache-Control keep it
+//   - Sends "Cache-Control: no-store" with errors
+//   - Purges the keys of every successful write in the background: the
+//     addressed module or collection, the module collection whenever a module
+//     changed, and the keys of the entities the handler returned
+//
+// Purges cover writes made through the API; the edge TTL bounds how long
+// other changes, like a restored backup, stay visible.
+//
+// Parameters:
+//   - purger: CDN receiving the purges
+//   - maxAge: How long the CDN may serve a read response without asking
+//
+// Returns:
+//   - gin.HandlerFunc: A middleware handler function
+func EdgeCacheHandler(purger cdn.Purger, maxAge time.Duration) gin.HandlerFunc {
+	cacheControl := "no-cache"
+	if maxAge > 0 {
+		cacheControl = fmt.Sprintf("max-age=0, s-maxage=%d", int(maxAge.Seconds()))
+	}
+
+	return func(c *gin.Context) {
+		method := c.Request.Method
+		if method == http.MethodGet || method == http.MethodHead {
+			c.Writer = &edgeCacheWriter{ResponseWriter: c.Writer, ctx: c, cacheControl: cacheControl}
+			c.Next()
+			return
+		}
+
+		c.Next()
+
+		// Reported errors are rendered later by the exception handler
+		if len(c.Errors) > 0 || c.Writer.Status() >= http.StatusBadRequest || method == http.MethodOptions {
+			return
+		}
+		keys := purgeKeys(c)
+		requestID := c.GetString("request_id")
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), purgeTimeout)
+			defer cancel()
+			if err := purger.Purge(ctx, keys); err != nil {
+				logger.Warnf("[%s] CDN purge of %v failed: %v", requestID, keys, err)
+			}
+		}()
+	}
+}
+
+// edgeCacheWriter sets the caching headers once the status is known.
+type edgeCacheWriter struct {
+	gin.ResponseWriter
+	ctx          *gin.Context
+	cacheControl string
+}
+
+// WriteHeader picks the caching headers for the status before they are sent.
+func (w *edgeCacheWriter) WriteHeader(code int) {
+	if !w.Written() {
+		header := w.Header()
+		if code < http.StatusBadRequest {
+			header.Set(SurrogateKeyHeader, strings.Join(readKeys(w.ctx), " "))
+			header.Add("Vary", edgeVary)
+			if header.Get("Cache-Control") == "" {
+				header.Set("Cache-Control", w.cacheControl)
+			}
+		} else if header.Get("Cache-Control") == "" {
+			header.Set("Cache-Control", "no-store")
+		}
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// routeKeys returns the surrogate keys of the resources a route addresses.
+//
+// Returns:
+//   - []string: The module or collection key, then related collections
+//   - string: The collection of the route, e.g. "modules"
+func routeKeys(c *gin.Context) ([]string, string) {
+	// Routes look like /api/v1/<collection>/... or /public/v1/<collection>/...
+	segments := strings.Split(strings.Trim(c.FullPath(), "/"), "/")
+	if len(segments) < 3 {
+		return nil, ""
+	}
+	segments = segments[2:]
+	collection := segments[0]
+
+	if collection != module.SurrogateCollectionKey || len(segments) < 2 || segments[1] != ":id" {
+		return []string{collection}, collection
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return []string{collection}, collection
+	}
+
+	keys := []string{module.SurrogateKey(id)}
+	for _, segment := range segments[2:] {
+		if related, ok := relatedCollections[segment]; ok && !slices.Contains(keys, related) {
+			keys = append(keys, related)
+		}
+	}
+	return keys, collection
+}
+
+// readKeys returns the keys tagging a read response.
+func readKeys(c *gin.Context) []string {
+	keys, _ := routeKeys(c)
+	for _, key := range c.GetStringSlice(surrogateKeysKey) {
+		if !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// purgeKeys returns the keys a write invalidates.
+func purgeKeys(c *gin.Context) []string {
+	keys, collection := routeKeys(c)
+	if collection != "" && !slices.Contains(keys, collection) {
+		keys = append(keys, collection)
+	}
+	for _, key := range c.GetStringSlice(surrogateKeysKey) {
+		if !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	if slices.ContainsFunc(keys, module.IsSurrogateKey) && !slices.Contains(keys, module.SurrogateCollectionKey) {
+		keys = append(keys, module.SurrogateCollectionKey)
+	}
+	return keys
+}
@@ -1,23 +1,40 @@
 package middleware
 
 import (
-	"fmt"
+	"errors"
 	"net/http"
+	"runtime/debug"
+	"strings"
 
+	"go_di_architecture/internal/domain/apperror"
 	"go_di_architecture/internal/domain/models/response"
+	"go_di_architecture/internal/i18n"
 
 	"github.com/gin-gonic/gin"
+	ut "github.com/go-playground/universal-translator"
 )
 
-// ExceptionHandler captures and handles unhandled exceptions.
+// ExceptionHandler renders errors and captures unhandled exceptions.
 //
-// This middleware handler:
-//   - Catches panics and unhandled errors
-//   - Creates standardized error responses
-//   - Logs errors with request context
-//   - Prevents stack traces in production
+// This middleware handler is the only place writing error responses:
+//   - Handlers and inner middleware report errors with ctx.Error and write nothing
+//   - The last reported error is rendered as exactly one standardized response
+//   - A *response.HTTPError keeps its status, code, details and context
+//   - Any other error is mapped by response.Errors: an apperror.AppError
+//     renders its code, message key and field details but never its cause,
+//     unregistered errors become a 500 INTERNAL_ERROR without their message
+//   - Errors with a message key are rendered with the bundle text of the key
+//     in the caller's language (see LocaleHandler)
+//   - Catches panics and logs server errors with request context
+//   - Gives every server error (status 500 and above) a reference, logs the
+//     full error with its stack (for panics and AppErrors) under it, and
+//     renders only the reference, so support can find the log entry a client
+//     reports
+//   - Never writes a second body when a response was already written
+//   - Returns the request's response mapper to its pool when the request ends
 //
-// The error response follows the same structure as all other API responses.
+// The error response follows the same structure as all other API responses and
+// uses the response mapper of the request, so naming and dry-run flags apply.
 //
 // Returns:
 //   - gin.HandlerFunc: A middleware handler function
@@ -25,50 +42,133 @@ func ExceptionHandler() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		requestID := ctx.GetString("request_id")
 
+		// Hand the response mapper back once nothing renders with it anymore
+		defer releaseMapper(ctx)
+
 		defer func() {
 			if err := recover(); err != nil {
-				// Log the error
-				fmt.Printf("[ERROR] [%s] Unhandled panic: %v\n", requestID, err)
-
-				// Create standardized error response
-				response := response.NewErrorResponse(
-					"INTERNAL_ERROR",
-					response.StatusToMessage(http.StatusInternalServerError),
-					nil,
-					requestID,
-				)
-
-				// Return error response
-				ctx.JSON(http.StatusInternalServerError, response)
-				ctx.Abort()
+				// Log the error with its stack under a new reference
+				httpErr := response.NewHTTPError(http.StatusInternalServerError, "INTERNAL_ERROR", nil).WithReference()
+				logger.Errorf("[%s] [%s] Unhandled panic: %v\n%s", requestID, httpErr.Reference, err, strings.TrimSuffix(string(debug.Stack()), "\n"))
+
+				if ctx.Writer.Written() {
+					ctx.Abort()
+					return
+				}
+
+				// Return standardized error response
+				apiResponse, statusCode := requestMapper(ctx).Fail(httpErr)
+				ctx.AbortWithStatusJSON(statusCode, apiResponse)
 			}
 		}()
 
 		// Continue processing the request
 		ctx.Next()
 
-		// Handle errors from controllers
-		if len(ctx.Errors) > 0 {
-			err := ctx.Errors[0]
-			handleError(ctx, err.Err, requestID)
+		// Render the error reported by the handler
+		if last := ctx.Errors.Last(); last != nil {
+			renderError(ctx, last.Err, requestID)
 		}
 	}
 }
 
-// handleError processes errors into standardized responses.
-func handleError(ctx *gin.Context, err error, requestID string) {
-	statusCode := ctx.Writer.Status()
-	if statusCode == http.StatusOK {
-		statusCode = http.StatusInternalServerError
+// renderError writes the standardized response of a reported error.
+func renderError(ctx *gin.Context, err error, requestID string) {
+	var httpErr *response.HTTPError
+	if !errors.As(err, &httpErr) {
+		httpErr = response.Errors.Map(err)
+	}
+	if httpErr.Status >= http.StatusInternalServerError {
+		if httpErr.Reference == "" {
+			httpErr.WithReference()
+		}
+		var appErr *apperror.AppError
+		if errors.As(err, &appErr) && appErr.Stack() != "" {
+			logger.Errorf("[%s] [%s] %v\n%s", requestID, httpErr.Reference, httpErr, strings.TrimSuffix(appErr.Stack(), "\n"))
+		} else {
+			logger.Errorf("[%s] [%s] %v", requestID, httpErr.Reference, httpErr)
+		}
+	}
+
+	if ctx.Writer.Written() {
+		logger.Errorf("[%s] Response already written, dropping error %s", requestID, httpErr.Code)
+		return
 	}
+	localizeError(ctx, httpErr)
 
-	code := "INTERNAL_ERROR"
-	message := response.StatusToMessage(statusCode)
+	apiResponse, statusCode := requestMapper(ctx).Fail(httpErr)
+	ctx.JSON(statusCode, apiResponse)
+}
+
+// localizeError replaces the message of an error with the text of its message
+// key in the caller's language.
+//
+// Field details repeating the message of the declared error are translated
+// too; a detail added with Detailf keeps its suffix.
+func localizeError(ctx *gin.Context, httpErr *response.HTTPError) {
+	if httpErr.MessageKey == "" {
+		return
+	}
+	trans, _ := ctx.Value(i18n.TranslatorContextKey).(ut.Translator)
+	text := i18n.Message(trans, httpErr.MessageKey)
+	if text == httpErr.MessageKey {
+		return
+	}
+	httpErr.Message = text
 
-	ctx.JSON(statusCode, response.NewErrorResponse(
-		code,
-		message,
-		map[string][]string{"error": {err.Error()}},
-		requestID,
-	))
+	sentinel := declaredError(httpErr.MessageKey)
+	if sentinel == nil || len(httpErr.Details) == 0 {
+		return
+	}
+	// The details may be the map of the sentinel itself, so build a new one
+	details := make(map[string][]string, len(httpErr.Details))
+	for field, messages := range httpErr.Details {
+		localized := make([]string, len(messages))
+		for i, message := range messages {
+			localized[i] = message
+			if message == sentinel.Message {
+				localized[i] = text
+			} else if detail, ok := strings.CutPrefix(message, sentinel.Message+": "); ok {
+				localized[i] = text + ": " + detail
+			}
+		}
+		details[field] = localized
+	}
+	httpErr.Details = details
+}
+
+// declaredError returns the declared AppError of a message key.
+func declaredError(messageKey string) *apperror.AppError {
+	for _, declared := range apperror.Declared() {
+		if declared.MessageKey == messageKey {
+			return declared
+		}
+	}
+	return nil
+}
+
+// releaseMapper returns the response mapper of the request to its pool.
+func releaseMapper(ctx *gin.Context) {
+	if value, ok := ctx.Get(response.MapperContextKey); ok {
+		if mapper, ok := value.(*response.ResponseMapper); ok {
+			mapper.Release()
+		}
+	}
+}
+
+// requestMapper returns the response mapper the handler used, or a mapper
+// honoring the requested naming when the request never reached a handler.
+func requestMapper(ctx *gin.Context) *response.ResponseMapper {
+	if value, ok := ctx.Get(response.MapperContextKey); ok {
+		if mapper, ok := value.(*response.ResponseMapper); ok {
+			return mapper
+		}
+	}
+
+	mapper := response.NewResponseMapper(ctx.GetString("request_id"))
+	if naming := response.NamingFromAccept(ctx.GetHeader("Accept")); naming != "" {
+		mapper.UseNaming(naming)
+	}
+	ctx.Set(response.MapperContextKey, mapper)
+	return mapper
 }
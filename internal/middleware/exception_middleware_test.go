@@ -0,0 +1,97 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"testing"
+
+	moduleService "go_di_architecture/internal/domain/service/module"
+	"go_di_architecture/internal/i18n"
+	"go_di_architecture/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	os.Exit(m.Run())
+}
+
+// errorBody is the part of an error response the tests look at.
+type errorBody struct {
+	Error struct {
+		Code       string              `json:"code"`
+		Message    string              `json:"message"`
+		MessageKey string              `json:"messageKey"`
+		Details    map[string][]string `json:"details"`
+	} `json:"error"`
+}
+
+// renderError reports err from a handler behind the exception and locale
+// middleware and decodes the response.
+func renderError(t *testing.T, err error, acceptLanguage string) (int, errorBody) {
+	t.Helper()
+	bundle, bundleErr := i18n.New("")
+	if bundleErr != nil {
+		t.Fatalf("i18n.New() error = %v", bundleErr)
+	}
+
+	engine := gin.New()
+	engine.Use(middleware.ExceptionHandler(), middleware.LocaleHandler(bundle))
+	engine.GET("/fail", func(ctx *gin.Context) { ctx.Error(err) })
+
+	request := httptest.NewRequest(http.MethodGet, "/fail", nil)
+	if acceptLanguage != "" {
+		request.Header.Set("Accept-Language", acceptLanguage)
+	}
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, request)
+
+	var body errorBody
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode %s: %v", recorder.Body, err)
+	}
+	return recorder.Code, body
+}
+
+func TestExceptionHandlerTranslatesMessageKey(t *testing.T) {
+	tests := []struct {
+		acceptLanguage string
+		want           string
+	}{
+		{acceptLanguage: "", want: "Module name is required"},
+		{acceptLanguage: "fr-CH, fr;q=0.9", want: "Le nom du module est obligatoire"},
+		{acceptLanguage: "de", want: "Der Modulname ist erforderlich"},
+		{acceptLanguage: "ja", want: "Module name is required"},
+	}
+	for _, test := range tests {
+		status, body := renderError(t, moduleService.ErrNameRequired.Wrap(errors.New("blank")), test.acceptLanguage)
+		if status != http.StatusBadRequest || body.Error.MessageKey != "module.name_required" {
+			t.Fatalf("Accept-Language %q: status %d, key %q", test.acceptLanguage, status, body.Error.MessageKey)
+		}
+		if body.Error.Message != test.want {
+			t.Errorf("Accept-Language %q: message = %q, want %q", test.acceptLanguage, body.Error.Message, test.want)
+		}
+		if got := body.Error.Details["name"]; !slices.Equal(got, []string{test.want}) {
+			t.Errorf("Accept-Language %q: details = %q, want the translated message", test.acceptLanguage, got)
+		}
+	}
+
+	// The sentinel's own field details stay untranslated
+	if got := moduleService.ErrNameRequired.Fields["name"]; !slices.Equal(got, []string{"module name is required"}) {
+		t.Errorf("sentinel fields = %q, changed by rendering", got)
+	}
+}
+
+func TestExceptionHandlerKeepsErrorDetail(t *testing.T) {
+	_, body := renderError(t, moduleService.ErrInvalidACL.Detailf("at most %d entries are allowed", 50), "es")
+
+	want := "Lista de control de acceso no válida: at most 50 entries are allowed"
+	if got := body.Error.Details["entries"]; !slices.Equal(got, []string{want}) {
+		t.Errorf("details = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"go_di_architecture/internal/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LocaleHandler selects the language of the messages reported to the caller.
+//
+// This middleware handler:
+//   - Picks the best loaded language of the Accept-Language header (English
+//     when none is accepted)
+//   - Stores its translator under i18n.TranslatorContextKey, so handlers
+//     report validation errors and ExceptionHandler renders the messages of
+//     message keys in that language
+//   - Adds Accept-Language to the Vary header, since error details depend on it
+//
+// Error codes and message keys stay the same in every language, so clients
+// keep matching on them.
+//
+// Parameters:
+//   - bundle: The loaded message bundles
+//
+// Returns:
+//   - gin.HandlerFunc: A middleware handler function
+func LocaleHandler(bundle *i18n.Bundle) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(i18n.TranslatorContextKey, bundle.Translator(c.GetHeader("Accept-Language")))
+		c.Writer.Header().Add("Vary", "Accept-Language")
+
+		c.Next()
+	}
+}
@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"go_di_architecture/internal/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// accessLog is the logger of the access log lines.
+var accessLog = logging.New("http")
+
+// logger is the logger of the other lines of the middleware, such as failed
+// side effects, slow requests and injected faults.
+var logger = logging.New("middleware")
+
+// LoggingHandler writes one access log line per request.
+//
+// This middleware handler:
+//   - Logs method, path, status, duration and client IP with the request ID
+//   - Logs server errors (status 500 and above) at error level, always
+//   - Logs client errors at warn and everything else at info level, thinned
+//     out by the sampler
+//
+// It must run outside the exception handler so it sees the final status.
+// The level of the "http" logger can be changed at runtime through
+// PUT /admin/log-level, e.g. to warn to keep only failed requests.
+//
+// Parameters:
+//   - sampler: Sampler of the non-error lines (nil logs every request)
+//
+// Returns:
+//   - gin.HandlerFunc: A middleware handler function
+func LoggingHandler(sampler *logging.Sampler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		status := c.Writer.Status()
+		level := logging.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = logging.LevelError
+		case status >= http.StatusBadRequest:
+			level = logging.LevelWarn
+		}
+
+		// Check the level first so filtered lines do not use up the sample
+		if !accessLog.Enabled(level) || (level < logging.LevelError && !sampler.Allow()) {
+			return
+		}
+
+		path := c.Request.URL.Path
+		if c.Request.URL.RawQuery != "" {
+			path += "?" + c.Request.URL.RawQuery
+		}
+		line := "[%s] %s %s %d %s %s"
+		args := []any{c.GetString("request_id"), c.Request.Method, path, status, time.Since(start).Round(time.Microsecond), c.ClientIP()}
+		switch level {
+		case logging.LevelError:
+			accessLog.Errorf(line, args...)
+		case logging.LevelWarn:
+			accessLog.Warnf(line, args...)
+		default:
+			accessLog.Infof(line, args...)
+		}
+	}
+}
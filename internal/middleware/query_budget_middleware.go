@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+)
+
+// QueryTracker counts the database statements run with a request's context.
+//
+// Implemented by db.QueryCounter; declared here so the middleware does not
+// depend on the infrastructure layer.
+type QueryTracker interface {
+	Track(ctx context.Context) (context.Context, func() (statements int, repeated string, repeats int))
+}
+
+// QueryBudgetHandler warns about requests running more statements than the
+// budget, the usual sign of a query per listed item (N+1 queries).
+//
+// This middleware handler:
+//   - Counts the statements run while the request is handled, by giving the
+//     request a context carrying the tally; only statements run with that
+//     context (db.WithContext) are counted
+//   - Logs a warning naming the most repeated statement when the count
+//     exceeds the budget; the response is not affected
+//
+// Usage Example:
+//
+//	r.Use(middleware.QueryBudgetHandler(counter, 20))
+//	// [WARN] [a1b2c3d4] GET /api/v1/modules ran 43 queries (budget 20); most repeated (40x): SELECT * FROM `tags` ...
+//
+// Parameters:
+//   - tracker: Counter of the statements
+//   - budget: Statements a request may run without a warning
+//
+// Returns:
+//   - gin.HandlerFunc: A middleware handler function
+func QueryBudgetHandler(tracker QueryTracker, budget int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, stop := tracker.Track(c.Request.Context())
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		statements, repeated, repeats := stop()
+		if statements <= budget {
+			return
+		}
+		logger.Warnf("[%s] %s %s ran %d queries (budget %d); most repeated (%dx): %s",
+			c.GetString("request_id"), c.Request.Method, c.Request.URL.Path, statements, budget, repeats, repeated)
+	}
+}
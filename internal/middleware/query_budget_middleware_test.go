@@ -0,0 +1,56 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go_di_architecture/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// tallyKey is the context key of fakeTracker's tally.
+type tallyKey struct{}
+
+// fakeTracker counts the statements reported through its context.
+type fakeTracker struct {
+	stopped bool
+}
+
+func (f *fakeTracker) Track(ctx context.Context) (context.Context, func() (int, string, int)) {
+	statements := new(int)
+	return context.WithValue(ctx, tallyKey{}, statements), func() (int, string, int) {
+		f.stopped = true
+		return *statements, "SELECT 1", *statements
+	}
+}
+
+// TestQueryBudgetHandlerTracksRequestContext checks the handlers behind the
+// middleware run with the tracked context, and the tracking ends with the
+// request.
+func TestQueryBudgetHandlerTracksRequestContext(t *testing.T) {
+	tracker := &fakeTracker{}
+	r := gin.New()
+	r.Use(middleware.QueryBudgetHandler(tracker, 1))
+	r.GET("/modules", func(c *gin.Context) {
+		statements, ok := c.Request.Context().Value(tallyKey{}).(*int)
+		if !ok {
+			t.Error("request context carries no tally")
+			return
+		}
+		*statements = 2
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/modules", nil))
+
+	if w.Code != http.StatusOK {
+		t.Errorf("status = %d, want 200", w.Code)
+	}
+	if !tracker.stopped {
+		t.Error("tracking was not stopped")
+	}
+}
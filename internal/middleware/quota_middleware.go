@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go_di_architecture/internal/app/auth"
+	"go_di_architecture/internal/domain/models/quota"
+	"go_di_architecture/internal/domain/models/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Quota headers sent with every request made with a limited API key
+const (
+	QuotaLimitHeader     = "X-Quota-Limit"
+	QuotaRemainingHeader = "X-Quota-Remaining"
+	QuotaResetHeader     = "X-Quota-Reset"
+)
+
+// QuotaLimiter counts requests per API key against monthly quotas.
+//
+// Implemented by the quota service; declared here so the middleware does not
+// depend on the domain services.
+type QuotaLimiter interface {
+	Consume(apiKey string, now time.Time) (*quota.AccountUsage, error)
+}
+
+// QuotaHandler enforces the monthly request quota of API keys.
+//
+// This middleware handler:
+//   - Counts every request authenticated with an API key; bearer tokens and
+//     unauthenticated requests are not counted
+//   - Rejects requests of keys that used up their quota with 429
+//     QUOTA_EXCEEDED until the next UTC month
+//   - Reports limit, remaining requests and reset time in X-Quota-* headers
+//     for keys with a quota
+//
+// Requests pass when the counters cannot be loaded, so a database outage
+// does not lock out every API key.
+//
+// Parameters:
+//   - limiter: Request counter enforcing the quotas
+//
+// Returns:
+//   - gin.HandlerFunc: A middleware handler function
+func QuotaHandler(limiter QuotaLimiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal := auth.PrincipalFrom(c)
+		if principal == nil || principal.APIKey == "" {
+			c.Next()
+			return
+		}
+
+		usage, err := limiter.Consume(principal.APIKey, time.Now())
+		if err != nil && !errors.Is(err, quota.ErrExceeded) {
+			logger.Errorf("[%s] Quota check failed: %v", c.GetString("request_id"), err)
+			c.Next()
+			return
+		}
+
+		if usage.Limit != nil {
+			c.Header(QuotaLimitHeader, strconv.FormatInt(*usage.Limit, 10))
+			c.Header(QuotaRemainingHeader, strconv.FormatInt(*usage.Remaining, 10))
+			c.Header(QuotaResetHeader, usage.ResetsAt.Format(time.RFC3339))
+		}
+		if err != nil {
+			c.Header("Retry-After", strconv.Itoa(int(time.Until(usage.ResetsAt).Seconds())+1))
+			c.Error(response.NewHTTPError(http.StatusTooManyRequests, "QUOTA_EXCEEDED", map[string][]string{"quota": {
+				fmt.Sprintf("API key %q used its %d requests of %s; the quota resets at %s",
+					usage.APIKey, *usage.Limit, usage.Month, usage.ResetsAt.Format(time.RFC3339)),
+			}}))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
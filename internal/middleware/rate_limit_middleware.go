@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"go_di_architecture/internal/domain/models/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Rate limit headers sent with every request of a rate-limited route
+const (
+	RateLimitHeader     = "X-RateLimit-Limit"
+	RateLimitRemaining  = "X-RateLimit-Remaining"
+	RateLimitResetAfter = "X-RateLimit-Reset"
+)
+
+// DefaultPublicRateLimit is the number of requests a client may send to the
+// public API per minute unless configured otherwise.
+const DefaultPublicRateLimit = 30
+
+// RateLimiter counts requests per client in fixed windows.
+//
+// All clients share the window boundaries, so the counters of a window are
+// dropped together when it ends and memory is bounded by the clients seen in
+// one window. A client may send up to twice the limit across a window
+// boundary, which is acceptable for shielding cheap read endpoints.
+//
+// Usage Example:
+//
+//	limiter := middleware.NewRateLimiter(30, time.Minute)
+//	public.Use(middleware.RateLimitHandler(limiter))
+type RateLimiter struct {
+	limit  int
+	window time.Duration
+
+	mu     sync.Mutex
+	start  time.Time
+	counts map[string]int
+}
+
+// NewRateLimiter creates a limiter allowing limit requests per client and window.
+//
+// Parameters:
+//   - limit: Requests allowed per client and window (at least 1)
+//   - window: Length of a window
+//
+// Returns:
+//   - *RateLimiter: A new limiter with empty counters
+func NewRateLimiter(limit int, window time.Duration) *RateLimiter {
+	return &RateLimiter{limit: limit, window: window, counts: make(map[string]int)}
+}
+
+// Allow counts a request of a client.
+//
+// Parameters:
+//   - client: Key of the client, e.g. its address
+//   - now: Time of the request
+//
+// Returns:
+//   - int: Requests the client has left in the window
+//   - time.Time: When the window ends
+//   - bool: False if the client used up the window; the request is not counted
+func (l *RateLimiter) Allow(client string, now time.Time) (int, time.Time, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	start := now.Truncate(l.window)
+	if !start.Equal(l.start) {
+		l.start = start
+		clear(l.counts)
+	}
+	resetAt := start.Add(l.window)
+
+	if l.counts[client] >= l.limit {
+		return 0, resetAt, false
+	}
+	l.counts[client]++
+	return l.limit - l.counts[client], resetAt, true
+}
+
+// RateLimitHandler limits the requests each client address may send.
+//
+// This middleware handler:
+//   - Counts every request by the client address Gin resolves: the peer of
+//     the connection, or the X-Forwarded-For address reported by one of the
+//     proxies passed to gin.Engine.SetTrustedProxies (TRUSTED_PROXIES)
+//   - Rejects requests beyond the limit with 429 RATE_LIMITED, a Retry-After
+//     header and "Cache-Control: no-store" until the window ends
+//   - Reports limit, remaining requests and seconds until the window ends in
+//     X-RateLimit-* headers
+//
+// Parameters:
+//   - limiter: Counter enforcing the limit
+//
+// Returns:
+//   - gin.HandlerFunc: A middleware handler function
+func RateLimitHandler(limiter *RateLimiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		now := time.Now()
+		remaining, resetAt, allowed := limiter.Allow(c.ClientIP(), now)
+
+		resetAfter := strconv.Itoa(int(resetAt.Sub(now).Seconds()) + 1)
+		c.Header(RateLimitHeader, strconv.Itoa(limiter.limit))
+		c.Header(RateLimitRemaining, strconv.Itoa(remaining))
+		c.Header(RateLimitResetAfter, resetAfter)
+
+		if !allowed {
+			c.Header("Retry-After", resetAfter)
+			c.Header("Cache-Control", "no-store")
+			c.Error(response.NewHTTPError(http.StatusTooManyRequests, "RATE_LIMITED", map[string][]string{"rate": {
+				fmt.Sprintf("at most %d requests per %s are allowed; retry in %s seconds", limiter.limit, limiter.window, resetAfter),
+			}}))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
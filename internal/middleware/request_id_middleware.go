@@ -1,10 +1,49 @@
 package middleware
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+
+	"go_di_architecture/internal/infra/httpclient"
+
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
+// Request ID strategies
+const (
+	// RequestIDUUID generates a random UUID (version 4) per request (default)
+	RequestIDUUID = "uuid"
+
+	// RequestIDMonotonic generates time-ordered UUIDs (version 7), so IDs sort
+	// by arrival time in logs and indexes
+	RequestIDMonotonic = "monotonic"
+
+	// RequestIDTrace reuses the trace ID of an incoming W3C traceparent header,
+	// so logs, responses and distributed traces share one identifier; requests
+	// without a valid traceparent get a new random trace ID
+	RequestIDTrace = "trace"
+)
+
+// TraceparentHeader is the W3C Trace Context header carrying the trace ID.
+const TraceparentHeader = "traceparent"
+
+// IsRequestIDStrategy reports whether the value names a supported strategy.
+//
+// Parameters:
+//   - strategy: Strategy name to check
+//
+// Returns:
+//   - bool: True for the RequestID strategy constants
+func IsRequestIDStrategy(strategy string) bool {
+	switch strategy {
+	case RequestIDUUID, RequestIDMonotonic, RequestIDTrace:
+		return true
+	}
+	return false
+}
+
 // RequestIDHandler generates and manages request IDs for tracing.
 //
 // This middleware handler:
@@ -14,23 +53,53 @@ import (
 //   - Supports incoming X-Request-Id header for distributed tracing
 //
 // The request ID is used for:
-//   - Correlating logs across services
+//   - Correlating logs across services, including the outbound calls made
+//     through httpclient with the request context
 //   - Debugging specific requests
 //   - Providing consistent error responses with traceability
 //
+// ID Selection:
+//  1. With the trace strategy, the trace ID of a valid traceparent header
+//  2. An incoming X-Request-Id header
+//  3. A new ID generated by the strategy
+//
+// The request context carries the request ID and the trace ID of a valid
+// traceparent header (a new trace ID without one), so outbound calls join the
+// trace of the request.
+//
+// Parameters:
+//   - strategy: One of the RequestID strategy constants (anything else uses RequestIDUUID)
+//
 // Returns:
 //   - gin.HandlerFunc: A middleware handler function
-func RequestIDHandler() gin.HandlerFunc {
+func RequestIDHandler(strategy string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get or generate request ID
-		requestID := c.GetHeader("X-Request-Id")
+		requestID := ""
+		if strategy == RequestIDTrace {
+			requestID = traceID(c.GetHeader(TraceparentHeader))
+		}
 		if requestID == "" {
-			requestID = uuid.New().String()
+			requestID = c.GetHeader("X-Request-Id")
+		}
+		if requestID == "" {
+			requestID = newRequestID(strategy)
 		}
 
 		// Set request ID in context
 		c.Set("request_id", requestID)
 
+		// Carry request ID and trace to outbound calls
+		trace := traceID(c.GetHeader(TraceparentHeader))
+		if trace == "" && strategy == RequestIDTrace && isLowerHex(requestID, 32) {
+			trace = requestID
+		}
+		if trace == "" {
+			trace = newRequestID(RequestIDTrace)
+		}
+		ctx := httpclient.WithTraceID(httpclient.WithRequestID(c.Request.Context(), requestID), trace)
+		c.Request = c.Request.WithContext(ctx)
+
 		// Set request ID in response header
 		c.Header("X-Request-Id", requestID)
 
@@ -38,3 +107,61 @@ func RequestIDHandler() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// newRequestID generates a request ID with the strategy.
+func newRequestID(strategy string) string {
+	switch strategy {
+	case RequestIDMonotonic:
+		if id, err := uuid.NewV7(); err == nil {
+			return id.String()
+		}
+	case RequestIDTrace:
+		var id [16]byte
+		if _, err := rand.Read(id[:]); err == nil {
+			return hex.EncodeToString(id[:])
+		}
+	}
+	return uuid.New().String()
+}
+
+// traceID extracts the trace ID from a W3C traceparent header.
+//
+// The header has the form version-traceid-parentid-flags, e.g.
+// 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01.
+//
+// Parameters:
+//   - traceparent: The raw header value
+//
+// Returns:
+//   - string: The 32-character trace ID, or "" when the header is absent or invalid
+func traceID(traceparent string) string {
+	parts := strings.Split(strings.TrimSpace(traceparent), "-")
+	if len(parts) < 4 {
+		return ""
+	}
+
+	version, trace, parent, flags := parts[0], parts[1], parts[2], parts[3]
+	if !isLowerHex(version, 2) || version == "ff" || (version == "00" && len(parts) != 4) {
+		return ""
+	}
+	if !isLowerHex(trace, 32) || trace == strings.Repeat("0", 32) {
+		return ""
+	}
+	if !isLowerHex(parent, 16) || parent == strings.Repeat("0", 16) || !isLowerHex(flags, 2) {
+		return ""
+	}
+	return trace
+}
+
+// isLowerHex reports whether s is exactly n lower-case hexadecimal characters.
+func isLowerHex(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	for _, r := range s {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+			return false
+		}
+	}
+	return true
+}
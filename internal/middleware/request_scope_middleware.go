@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"go_di_architecture/internal/app/auth"
+	"go_di_architecture/internal/app/container"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Names of the scoped components supplied by RequestScopeHandler
+const (
+	// RequestIDComponent holds the request ID (string)
+	RequestIDComponent = "request.id"
+
+	// PrincipalComponent holds the authenticated caller (*auth.Principal)
+	PrincipalComponent = "auth.principal"
+)
+
+// scopeDisposalKey marks requests whose scope is disposed by ScopeDisposalHandler.
+const scopeDisposalKey = "scope_disposal"
+
+// RequestScopeHandler creates a dependency scope for every request.
+//
+// This middleware handler:
+//   - Creates a new container scope when the request starts
+//   - Supplies the request ID and the principal so scoped components can
+//     depend on them
+//   - Attaches the scope to the request context, where handlers reach it
+//     with container.Get and container.MustGet
+//   - Disposes the scope (and its disposable components) when the request
+//     ends, including when a later handler panics, unless ScopeDisposalHandler
+//     disposes it later
+//
+// It must be registered after RequestIDHandler and AuthenticationHandler so
+// the request ID and principal are available.
+//
+// Parameters:
+//   - c: The application container
+//
+// Returns:
+//   - gin.HandlerFunc: A middleware handler function
+func RequestScopeHandler(c *container.Container) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		requestID := ctx.GetString("request_id")
+
+		// Build the request scope
+		scope := c.NewScope()
+		scope.Supply(RequestIDComponent, requestID)
+		scope.Supply(PrincipalComponent, auth.PrincipalFrom(ctx))
+		ctx.Request = ctx.Request.WithContext(container.WithScope(ctx.Request.Context(), scope))
+
+		// Dispose the scope once the request is complete
+		if !ctx.GetBool(scopeDisposalKey) {
+			defer disposeScope(scope, requestID)
+		}
+
+		// Process request
+		ctx.Next()
+	}
+}
+
+// ScopeDisposalHandler disposes the request scope after every other handler.
+//
+// Registered as the first middleware, it is the last to finish: the logging,
+// activity and exception middlewares can still use scoped components (e.g.
+// the response mapper rendering an error) after the route handler returned,
+// and the scope is disposed once the response is complete, deterministically
+// in reverse creation order, including when a handler panics.
+//
+// Returns:
+//   - gin.HandlerFunc: A middleware handler function
+func ScopeDisposalHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Set(scopeDisposalKey, true)
+
+		defer func() {
+			if scope, ok := container.ScopeFrom(ctx.Request.Context()); ok {
+				disposeScope(scope, ctx.GetString("request_id"))
+			}
+		}()
+
+		// Process request
+		ctx.Next()
+	}
+}
+
+// disposeScope disposes a request scope and logs the disposal errors.
+func disposeScope(scope *container.Scope, requestID string) {
+	if err := scope.Dispose(); err != nil {
+		logger.Errorf("[%s] Failed to dispose request scope: %v", requestID, err)
+	}
+}
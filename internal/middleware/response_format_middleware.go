@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"go_di_architecture/internal/domain/models/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ResponseFormatHandler sets the default response format for a group of routes.
+//
+// This middleware handler:
+//   - Stores the route's default format (envelope or raw) in the context
+//   - Lets the response mapper return bare DTOs for routes configured as raw
+//   - Leaves error responses in the standard envelope
+//
+// A request can still override the route default with the X-Response-Format
+// header.
+//
+// Usage:
+//
+//	raw := v1.Group("/raw", middleware.ResponseFormatHandler(response.FormatRaw))
+//
+// Parameters:
+//   - format: The default response format (response.FormatEnvelope or response.FormatRaw)
+//
+// Returns:
+//   - gin.HandlerFunc: A middleware handler function
+func ResponseFormatHandler(format string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Set route format in context
+		c.Set(response.FormatContextKey, format)
+
+		// Process request
+		c.Next()
+	}
+}
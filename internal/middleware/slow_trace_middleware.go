@@ -0,0 +1,198 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StatementTracer reports the database statements run with a request's context.
+//
+// Implemented by db.StatementTracer; declared here so the middleware does not
+// depend on the infrastructure layer.
+type StatementTracer interface {
+	Trace(ctx context.Context, record func(sql string, start time.Time, elapsed time.Duration, rows int64, err error)) (context.Context, func())
+}
+
+// traceKey is the context key of the trace of a request.
+const traceKey = "request_trace"
+
+// maxTracedStatements bounds the statements kept per request; a streamed
+// response may run thousands.
+const maxTracedStatements = 100
+
+// requestTrace collects the timings of one request. Steps are only written
+// by the goroutine handling the request, and the statement tracer never
+// records statements concurrently or after the trace stopped, so it needs no
+// lock.
+type requestTrace struct {
+	start      time.Time
+	steps      []traceStep
+	statements []tracedStatement
+	dropped    int
+	sqlTime    time.Duration
+}
+
+// traceStep is the timing of one step of the handler chain.
+type traceStep struct {
+	name    string
+	start   time.Time
+	elapsed time.Duration
+}
+
+// tracedStatement is a database statement run by the request.
+type tracedStatement struct {
+	sql     string
+	start   time.Time
+	elapsed time.Duration
+	rows    int64
+	err     error
+}
+
+// SlowRequestTraceHandler logs a breakdown of requests taking longer than a
+// threshold, to see where the time of a slow request went.
+//
+// This middleware handler:
+//   - Times the steps of the handler chain wrapped with TraceStep: each
+//     middleware, and the route handler with the service calls it makes
+//   - Records the database statements run with the request context, with
+//     placeholders instead of bound values, keeping the first 100
+//   - Logs the steps and statements under the request ID once the request
+//     took at least the threshold; faster requests log nothing
+//
+// Usage Example:
+//
+//	r.Use(middleware.SlowRequestTraceHandler(tracer, time.Second))
+//	r.Use(middleware.TraceStep(middleware.LoggingHandler(nil)))
+//	// [WARN] [a1b2c3d4] Slow request GET /api/v1/modules took 1.52s (threshold 1s): status 200, 3 statements in 1.48s
+//	// [WARN] [a1b2c3d4]   step  +0.0ms  1520.1ms (self 0.1ms)  middleware.LoggingHandler
+//	// [WARN] [a1b2c3d4]   sql   +2.3ms  1402.6ms rows=20  SELECT * FROM `modules` WHERE ...
+//
+// Parameters:
+//   - statements: Tracer of the database statements (nil records none)
+//   - threshold: Duration from which a request is logged
+//
+// Returns:
+//   - gin.HandlerFunc: A middleware handler function
+func SlowRequestTraceHandler(statements StatementTracer, threshold time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		trace := &requestTrace{start: time.Now()}
+		c.Set(traceKey, trace)
+		stop := func() {}
+		if statements != nil {
+			var ctx context.Context
+			ctx, stop = statements.Trace(c.Request.Context(), trace.recordStatement)
+			c.Request = c.Request.WithContext(ctx)
+			defer stop()
+		}
+
+		c.Next()
+		// Statements of goroutines outliving the request are not recorded
+		// while the trace is formatted
+		stop()
+
+		elapsed := time.Since(trace.start)
+		if elapsed < threshold {
+			return
+		}
+		for _, line := range trace.format(c, elapsed, threshold) {
+			logger.Warnf("%s", line)
+		}
+	}
+}
+
+// TraceStep times a step of the handler chain for SlowRequestTraceHandler;
+// requests without a trace only run the step.
+//
+// Parameters:
+//   - step: Middleware or route handler to time
+//
+// Returns:
+//   - gin.HandlerFunc: The step, timed
+func TraceStep(step gin.HandlerFunc) gin.HandlerFunc {
+	name := stepName(step)
+	return func(c *gin.Context) {
+		value, ok := c.Get(traceKey)
+		if !ok {
+			step(c)
+			return
+		}
+		trace := value.(*requestTrace)
+
+		index := len(trace.steps)
+		trace.steps = append(trace.steps, traceStep{name: name, start: time.Now()})
+		step(c)
+		trace.steps[index].elapsed = time.Since(trace.steps[index].start)
+	}
+}
+
+// recordStatement keeps a statement of the request.
+func (t *requestTrace) recordStatement(sql string, start time.Time, elapsed time.Duration, rows int64, err error) {
+	t.sqlTime += elapsed
+	if len(t.statements) >= maxTracedStatements {
+		t.dropped++
+		return
+	}
+	t.statements = append(t.statements, tracedStatement{sql: sql, start: start, elapsed: elapsed, rows: rows, err: err})
+}
+
+// format renders the trace as log lines prefixed with the request ID.
+//
+// A step's self time excludes the step it ran through c.Next, so the time
+// of a middleware is not counted again for every step inside it.
+func (t *requestTrace) format(c *gin.Context, elapsed, threshold time.Duration) []string {
+	prefix := fmt.Sprintf("[%s] ", c.GetString("request_id"))
+
+	lines := []string{fmt.Sprintf("%sSlow request %s %s took %s (threshold %s): status %d, %d statements in %s",
+		prefix, c.Request.Method, c.Request.URL.Path, elapsed.Round(time.Millisecond), threshold,
+		c.Writer.Status(), len(t.statements)+t.dropped, t.sqlTime.Round(time.Millisecond))}
+
+	for i, step := range t.steps {
+		self := step.elapsed
+		if i+1 < len(t.steps) {
+			if next := t.steps[i+1]; next.start.Before(step.start.Add(step.elapsed)) {
+				self -= next.elapsed
+			}
+		}
+		lines = append(lines, fmt.Sprintf("%s  step %s %s (self %s)  %s", prefix, offset(step.start.Sub(t.start)), millis(step.elapsed), millis(self), step.name))
+	}
+
+	for _, statement := range t.statements {
+		line := fmt.Sprintf("%s  sql  %s %s rows=%d  %s", prefix, offset(statement.start.Sub(t.start)), millis(statement.elapsed), statement.rows, statement.sql)
+		if statement.err != nil {
+			line += fmt.Sprintf("  error=%v", statement.err)
+		}
+		lines = append(lines, line)
+	}
+	if t.dropped > 0 {
+		lines = append(lines, fmt.Sprintf("%s  ... %d more statements not kept", prefix, t.dropped))
+	}
+	return lines
+}
+
+// offset renders the start of an entry relative to the request.
+func offset(d time.Duration) string {
+	return fmt.Sprintf("%+9.1fms", float64(d.Microseconds())/1000)
+}
+
+// millis renders a duration in milliseconds.
+func millis(d time.Duration) string {
+	return fmt.Sprintf("%8.1fms", float64(d.Microseconds())/1000)
+}
+
+// stepName derives the name of a step from its function, e.g.
+// "middleware.LoggingHandler" or "handlers.(*ModuleHandler).ListModules".
+func stepName(step gin.HandlerFunc) string {
+	name := runtime.FuncForPC(reflect.ValueOf(step).Pointer()).Name()
+	name = name[strings.LastIndex(name, "/")+1:]
+	name = strings.TrimSuffix(name, "-fm")
+	if closure := strings.Index(name, ".func"); closure >= 0 {
+		name = name[:closure]
+	}
+	return name
+}
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"go_di_architecture/internal/app/container"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UnitOfWorkComponent is the container name of the request-scoped unit of work.
+const UnitOfWorkComponent = "db.unitofwork"
+
+// UnitOfWork is the transaction of a request.
+//
+// Implemented by db.UnitOfWork; declared here so the middleware does not
+// depend on the infrastructure layer. The scope disposing it rolls back a
+// unit of work that was not completed.
+type UnitOfWork interface {
+	Bind(ctx context.Context) context.Context
+	Complete() error
+}
+
+// UnitOfWorkHandler runs every write request in the unit of work of its scope.
+//
+// This middleware handler:
+//   - Resolves the unit of work from the request scope for POST, PUT, PATCH
+//     and DELETE requests; reads run without a transaction
+//   - Binds it to the request context, so the repositories bound to that
+//     context (service.WithContext) run their statements in its transaction
+//   - Commits the transaction when the handlers report no error and the
+//     response is not an error; otherwise the scope's disposal rolls it back,
+//     including when a later handler panics
+//
+// It must be registered after RequestScopeHandler.
+//
+// Returns:
+//   - gin.HandlerFunc: A middleware handler function
+func UnitOfWorkHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+
+		uow, err := container.Get[UnitOfWork](c.Request.Context(), UnitOfWorkComponent)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+		c.Request = c.Request.WithContext(uow.Bind(c.Request.Context()))
+
+		// Process request
+		c.Next()
+
+		if len(c.Errors) > 0 || c.Writer.Status() >= http.StatusBadRequest {
+			return
+		}
+		if err := uow.Complete(); err != nil {
+			logger.Errorf("[%s] Failed to commit %s %s: %v", c.GetString("request_id"), c.Request.Method, c.Request.URL.Path, err)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go_di_architecture/internal/app/container"
+	"go_di_architecture/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fakeUnitOfWork records how a request ended its unit of work.
+type fakeUnitOfWork struct {
+	bound, completed, disposed bool
+}
+
+func (f *fakeUnitOfWork) Bind(ctx context.Context) context.Context {
+	f.bound = true
+	return ctx
+}
+
+func (f *fakeUnitOfWork) Complete() error {
+	f.completed = true
+	return nil
+}
+
+func (f *fakeUnitOfWork) Dispose() error {
+	f.disposed = true
+	return nil
+}
+
+// TestUnitOfWorkHandlerCompletesSuccessfulWrites checks write requests run
+// in the unit of work of their scope, which is committed only when the
+// request succeeds, and reads never build one.
+func TestUnitOfWorkHandlerCompletesSuccessfulWrites(t *testing.T) {
+	var uow *fakeUnitOfWork
+	c := container.New()
+	err := c.Provide(container.Provider{
+		Name:     middleware.UnitOfWorkComponent,
+		Lifetime: container.Scoped,
+		Factory: func(container.Resolver) (any, error) {
+			uow = &fakeUnitOfWork{}
+			return uow, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Provide() error = %v", err)
+	}
+
+	r := gin.New()
+	r.Use(middleware.RequestScopeHandler(c), middleware.UnitOfWorkHandler())
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	r.GET("/modules", ok)
+	r.POST("/modules", ok)
+	r.PUT("/modules/1", func(c *gin.Context) { c.Status(http.StatusConflict) })
+	r.DELETE("/modules/1", func(c *gin.Context) { c.Error(errors.New("module not found")) })
+
+	tests := []struct {
+		method    string
+		path      string
+		bound     bool
+		completed bool
+	}{
+		{method: http.MethodGet, path: "/modules"},
+		{method: http.MethodPost, path: "/modules", bound: true, completed: true},
+		{method: http.MethodPut, path: "/modules/1", bound: true},
+		{method: http.MethodDelete, path: "/modules/1", bound: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			uow = nil
+			r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(tt.method, tt.path, nil))
+
+			if !tt.bound {
+				if uow != nil {
+					t.Error("unit of work built for a read")
+				}
+				return
+			}
+			if uow == nil || !uow.bound || !uow.disposed {
+				t.Fatalf("unit of work = %+v, want bound and disposed", uow)
+			}
+			if uow.completed != tt.completed {
+				t.Errorf("completed = %v, want %v", uow.completed, tt.completed)
+			}
+		})
+	}
+}
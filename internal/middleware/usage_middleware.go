@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// moduleRoute marks the routes addressing a single module.
+const moduleRoute = "/modules/:id"
+
+// UsageRecorder counts requests per module.
+//
+// Implemented by the usage service; declared here so the middleware does not
+// depend on the domain layer.
+type UsageRecorder interface {
+	Record(moduleID int, write bool, at time.Time)
+}
+
+// UsageHandler counts the successful requests addressing a module.
+//
+// This middleware handler:
+//   - Counts routes below /modules/:id once the handler has responded
+//   - Counts GET and HEAD as reads and every other method as a write
+//   - Skips failed requests (status 400 and above) and the usage report itself
+//
+// Counting happens in memory; the recorder decides when counts are stored.
+//
+// Parameters:
+//   - recorder: Receiver of the counts
+//
+// Returns:
+//   - gin.HandlerFunc: A middleware handler function
+func UsageHandler(recorder UsageRecorder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		// Reported errors are rendered later by the exception handler
+		if len(c.Errors) > 0 || c.Writer.Status() >= http.StatusBadRequest {
+			return
+		}
+
+		// Only routes of a single module are counted, except the usage report
+		route := c.FullPath()
+		index := strings.Index(route, moduleRoute)
+		if index < 0 {
+			return
+		}
+		rest := route[index+len(moduleRoute):]
+		if (rest != "" && !strings.HasPrefix(rest, "/")) || rest == "/usage" {
+			return
+		}
+
+		moduleID, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			return
+		}
+		write := c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead
+		recorder.Record(moduleID, write, time.Now())
+	}
+}
@@ -0,0 +1,19 @@
+package templating
+
+import "time"
+
+// funcs are the helper functions available in every template.
+var funcs = map[string]any{
+	// utc formats a time in UTC with minute precision, e.g. "2023-08-15 14:30 UTC"
+	"utc": func(t time.Time) string {
+		return t.UTC().Format("2006-01-02 15:04 MST")
+	},
+
+	// percent returns part as a whole-number percentage of total (0 for an empty total)
+	"percent": func(part, total int64) int64 {
+		if total == 0 {
+			return 0
+		}
+		return part * 100 / total
+	},
+}
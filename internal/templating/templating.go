@@ -0,0 +1,180 @@
+package templating
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	texttemplate "text/template"
+)
+
+// defaults holds the templates shipped with the application.
+//
+//go:embed defaults
+var defaults embed.FS
+
+// Template file suffixes selecting the template package
+const (
+	// htmlSuffix marks templates executed with html/template (contextual escaping)
+	htmlSuffix = ".html.tmpl"
+
+	// textSuffix marks templates executed with text/template (no escaping)
+	textSuffix = ".txt.tmpl"
+)
+
+// Engine renders named templates.
+//
+// Templates are loaded once at startup from the embedded defaults and, if
+// configured, an override directory. A file in the override directory
+// replaces the default with the same relative path, so operators can adapt
+// wording or layout without rebuilding; files without a default are added.
+//
+// Names are relative paths without the ".tmpl" suffix, e.g.
+// "reports/module_stats.html" or "notifications/module.deactivated.txt".
+// Files ending in ".html.tmpl" use html/template and are escaped for HTML;
+// files ending in ".txt.tmpl" use text/template and are rendered verbatim.
+//
+// Usage Example:
+//
+//	engine, err := templating.New("/etc/modules/templates")
+//	page, err := engine.Render("reports/module_stats.html", stats)
+//	subject, err := engine.RenderBlock("notifications/module.deactivated.txt", "subject", event)
+type Engine struct {
+	html map[string]*htmltemplate.Template
+	text map[string]*texttemplate.Template
+}
+
+// New loads the default templates and applies the overrides.
+//
+// Parameters:
+//   - overrideDir: Directory with templates replacing the defaults (empty for none)
+//
+// Returns:
+//   - *Engine: The loaded templates
+//   - error: Error if the directory cannot be read or a template does not parse
+func New(overrideDir string) (*Engine, error) {
+	// Step 1: Collect template sources, overrides replacing defaults
+	root, err := fs.Sub(defaults, "defaults")
+	if err != nil {
+		return nil, err
+	}
+	sources, err := readTemplates(root)
+	if err != nil {
+		return nil, fmt.Errorf("read default templates: %w", err)
+	}
+	if overrideDir != "" {
+		overrides, err := readTemplates(os.DirFS(overrideDir))
+		if err != nil {
+			return nil, fmt.Errorf("read templates from %s: %w", overrideDir, err)
+		}
+		for name, source := range overrides {
+			sources[name] = source
+		}
+	}
+
+	// Step 2: Parse each template with the package matching its suffix
+	engine := &Engine{
+		html: make(map[string]*htmltemplate.Template),
+		text: make(map[string]*texttemplate.Template),
+	}
+	for file, source := range sources {
+		name := strings.TrimSuffix(file, ".tmpl")
+		if strings.HasSuffix(file, htmlSuffix) {
+			tmpl, err := htmltemplate.New(name).Funcs(htmltemplate.FuncMap(funcs)).Parse(source)
+			if err != nil {
+				return nil, fmt.Errorf("parse template %s: %w", file, err)
+			}
+			engine.html[name] = tmpl
+			continue
+		}
+		tmpl, err := texttemplate.New(name).Funcs(texttemplate.FuncMap(funcs)).Parse(source)
+		if err != nil {
+			return nil, fmt.Errorf("parse template %s: %w", file, err)
+		}
+		engine.text[name] = tmpl
+	}
+
+	return engine, nil
+}
+
+// Has reports whether a template with the name exists.
+//
+// Parameters:
+//   - name: Template name (relative path without ".tmpl")
+//
+// Returns:
+//   - bool: True if the template was loaded
+func (e *Engine) Has(name string) bool {
+	_, isHTML := e.html[name]
+	_, isText := e.text[name]
+	return isHTML || isText
+}
+
+// Render executes a template.
+//
+// Parameters:
+//   - name: Template name (relative path without ".tmpl")
+//   - data: Value passed to the template as dot
+//
+// Returns:
+//   - string: The rendered output
+//   - error: Error if the template does not exist or fails to execute
+func (e *Engine) Render(name string, data any) (string, error) {
+	return e.RenderBlock(name, "", data)
+}
+
+// RenderBlock executes a block defined inside a template.
+//
+// Parameters:
+//   - name: Template name (relative path without ".tmpl")
+//   - block: Name of a {{define}} block, or "" for the whole template
+//   - data: Value passed to the template as dot
+//
+// Returns:
+//   - string: The rendered output
+//   - error: Error if the template or block does not exist or fails to execute
+func (e *Engine) RenderBlock(name, block string, data any) (string, error) {
+	if block == "" {
+		block = name
+	}
+
+	var out bytes.Buffer
+	var err error
+	switch {
+	case e.html[name] != nil:
+		err = e.html[name].ExecuteTemplate(&out, block, data)
+	case e.text[name] != nil:
+		err = e.text[name].ExecuteTemplate(&out, block, data)
+	default:
+		return "", fmt.Errorf("template %q not found", name)
+	}
+	if err != nil {
+		return "", fmt.Errorf("render template %s: %w", name, err)
+	}
+	return out.String(), nil
+}
+
+// readTemplates reads every .html.tmpl and .txt.tmpl file below the root.
+func readTemplates(root fs.FS) (map[string]string, error) {
+	sources := make(map[string]string)
+	err := fs.WalkDir(root, ".", func(file string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		if !strings.HasSuffix(file, htmlSuffix) && !strings.HasSuffix(file, textSuffix) {
+			return nil
+		}
+
+		content, err := fs.ReadFile(root, file)
+		if err != nil {
+			return err
+		}
+		sources[path.Clean(file)] = string(content)
+		return nil
+	})
+	return sources, err
+}
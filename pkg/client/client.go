@@ -0,0 +1,308 @@
+// Package client is a typed Go client of the module API.
+//
+// Services consuming the API use it instead of hand-rolled HTTP calls: every
+// method sends the credentials, unwraps the APIResponse envelope into the
+// response DTO and turns error envelopes into *Error values carrying the
+// status, code and field details. Reads and other idempotent requests are
+// retried on network errors, 5xx and 429 responses.
+//
+// The request and response types are the DTOs of the API itself (see
+// types.go), so the client cannot drift from the handlers.
+//
+// Usage Example:
+//
+//	api := client.New("https://modules.example.com", client.Config{APIKey: os.Getenv("MODULES_API_KEY")})
+//	created, err := api.CreateModule(ctx, client.ModuleRequest{Name: "Inventory"})
+//	if client.HasCode(err, "RESOURCE_CONFLICT") {
+//	    // a module with the name exists
+//	}
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Default settings of a client
+const (
+	DefaultTimeout      = 30 * time.Second
+	DefaultMaxRetries   = 2
+	DefaultRetryBackoff = 200 * time.Millisecond
+)
+
+// maxRetryWait caps the waits between attempts; a longer Retry-After (e.g.
+// of an exhausted quota) is reported instead of waited for.
+const maxRetryWait = 30 * time.Second
+
+// apiPrefix is the path of the versioned API.
+const apiPrefix = "/api/v1"
+
+// Config holds the credentials and resilience settings of a client.
+type Config struct {
+	// API key sent in the X-API-Key header (optional)
+	APIKey string
+
+	// Token sent as "Authorization: Bearer <token>" (optional; takes
+	// precedence over APIKey)
+	Token string
+
+	// Client sending the requests (nil creates one with Timeout)
+	HTTPClient *http.Client
+
+	// Bound of a single attempt (DefaultTimeout when zero; ignored with
+	// HTTPClient)
+	Timeout time.Duration
+
+	// Attempts repeated after a network error, 5xx or 429 response of an
+	// idempotent request (DefaultMaxRetries when zero, none when negative)
+	MaxRetries int
+
+	// Wait before the first retry, doubled for every further retry, unless
+	// the response names one in Retry-After (DefaultRetryBackoff when zero)
+	RetryBackoff time.Duration
+
+	// User-Agent of the requests (optional)
+	UserAgent string
+}
+
+// Client calls the module API.
+//
+// A client is safe for concurrent use.
+type Client struct {
+	baseURL string
+	config  Config
+	http    *http.Client
+}
+
+// New creates a client of the API at the base URL.
+//
+// Parameters:
+//   - baseURL: Scheme and host of the API, e.g. "https://modules.example.com"
+//   - config: Credentials, timeout and retry settings
+//
+// Returns:
+//   - *Client: A new client
+func New(baseURL string, config Config) *Client {
+	if config.Timeout == 0 {
+		config.Timeout = DefaultTimeout
+	}
+	if config.MaxRetries == 0 {
+		config.MaxRetries = DefaultMaxRetries
+	}
+	if config.RetryBackoff == 0 {
+		config.RetryBackoff = DefaultRetryBackoff
+	}
+
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: config.Timeout}
+	}
+	return &Client{baseURL: strings.TrimRight(baseURL, "/"), config: config, http: httpClient}
+}
+
+// Do sends a request to the versioned API and unwraps the response.
+//
+// Typed methods cover the common routes; Do reaches the others.
+//
+// Usage Example:
+//
+//	var usage client.ModuleUsage
+//	_, err := api.Do(ctx, http.MethodGet, "/modules/42/usage", url.Values{"days": {"7"}}, nil, &usage)
+//
+// Parameters:
+//   - ctx: Context bounding all attempts and the backoff between them
+//   - method: HTTP method
+//   - path: Path below /api/v1, e.g. "/modules/42"
+//   - query: Query parameters (optional)
+//   - body: Request body, encoded as JSON (nil sends none)
+//   - out: Receives the data of the envelope (nil discards it)
+//
+// Returns:
+//   - *Meta: Metadata of the envelope (pagination, dry run, ...); nil for
+//     responses without a body
+//   - error: *Error for error responses, or the transport error
+func (c *Client) Do(ctx context.Context, method, path string, query url.Values, body, out any) (*Meta, error) {
+	// Step 1: Encode the body once, so every attempt can send it
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return nil, fmt.Errorf("client: encode %s %s: %w", method, path, err)
+		}
+	}
+
+	target := c.baseURL + apiPrefix + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	// Step 2: Send, retrying transient failures of idempotent requests
+	response, err := c.send(ctx, method, target, payload)
+	if err != nil {
+		return nil, fmt.Errorf("client: %s %s: %w", method, path, err)
+	}
+	defer response.Body.Close()
+
+	// Step 3: Unwrap the envelope
+	return decode(response, out)
+}
+
+// send sends the attempts of a request.
+func (c *Client) send(ctx context.Context, method, target string, payload []byte) (*http.Response, error) {
+	backoff := c.config.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		request, err := c.newRequest(ctx, method, target, payload)
+		if err != nil {
+			return nil, err
+		}
+
+		response, err := c.http.Do(request)
+		if !retryable(method, response, err) || attempt >= c.config.MaxRetries || ctx.Err() != nil {
+			return response, err
+		}
+
+		wait := backoff
+		if response != nil {
+			if after, ok := retryAfter(response); ok {
+				if after > maxRetryWait {
+					return response, nil
+				}
+				wait = after
+			}
+			_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 4096))
+			response.Body.Close()
+		}
+		if err := sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+		backoff *= 2
+	}
+}
+
+// newRequest builds one attempt with the credentials of the client.
+func (c *Client) newRequest(ctx context.Context, method, target string, payload []byte) (*http.Request, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	request, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set("Accept", "application/json")
+	if payload != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	if c.config.UserAgent != "" {
+		request.Header.Set("User-Agent", c.config.UserAgent)
+	}
+	switch {
+	case c.config.Token != "":
+		request.Header.Set("Authorization", "Bearer "+c.config.Token)
+	case c.config.APIKey != "":
+		request.Header.Set("X-API-Key", c.config.APIKey)
+	}
+	return request, nil
+}
+
+// envelope is the APIResponse envelope with its data left encoded.
+type envelope struct {
+	Success bool            `json:"success"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+	Error   *APIError       `json:"error"`
+	Meta    Meta            `json:"meta"`
+}
+
+// decode unwraps the envelope of a response into out.
+func decode(response *http.Response, out any) (*Meta, error) {
+	raw, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("client: read response: %w", err)
+	}
+
+	// Step 1: Responses without a body (204, HEAD)
+	if len(bytes.TrimSpace(raw)) == 0 {
+		if response.StatusCode >= http.StatusBadRequest {
+			return nil, newError(response)
+		}
+		return nil, nil
+	}
+
+	// Step 2: Error envelopes, and error bodies of something else than the
+	// API (e.g. a proxy in front of it)
+	var env envelope
+	decodeErr := json.Unmarshal(raw, &env)
+	if response.StatusCode >= http.StatusBadRequest || (decodeErr == nil && !env.Success) {
+		apiErr := newError(response)
+		if decodeErr == nil && env.Error != nil {
+			apiErr.APIError = *env.Error
+			apiErr.RequestID = env.Meta.RequestId
+		}
+		return nil, apiErr
+	}
+	if decodeErr != nil {
+		return nil, fmt.Errorf("client: decode response: %w", decodeErr)
+	}
+
+	// Step 3: The data of a success
+	if out != nil && len(env.Data) > 0 {
+		if err := json.Unmarshal(env.Data, out); err != nil {
+			return nil, fmt.Errorf("client: decode response data: %w", err)
+		}
+	}
+	return &env.Meta, nil
+}
+
+// newError describes an error response by its status and headers.
+func newError(response *http.Response) *Error {
+	apiErr := &Error{APIError: APIError{Message: http.StatusText(response.StatusCode)}, Status: response.StatusCode}
+	apiErr.RetryAfter, _ = retryAfter(response)
+	return apiErr
+}
+
+// retryable reports whether an attempt failed in a way another attempt may
+// fix, and the request may safely reach the API again.
+func retryable(method string, response *http.Response, err error) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+	default:
+		return false
+	}
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	return response.StatusCode >= http.StatusInternalServerError || response.StatusCode == http.StatusTooManyRequests
+}
+
+// retryAfter reads the delay of a Retry-After header in seconds.
+func retryAfter(response *http.Response) (time.Duration, bool) {
+	seconds, err := strconv.Atoi(response.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+// sleep waits for the duration unless the context ends first.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
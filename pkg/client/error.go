@@ -0,0 +1,60 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Error is an error response of the API.
+//
+// The embedded APIError holds the code, message and details of the error
+// envelope; for error bodies that are not envelopes (e.g. from a proxy) only
+// the status and its text are set.
+type Error struct {
+	APIError
+
+	// HTTP status code of the response
+	Status int
+
+	// Request ID of the response, to quote when reporting the problem
+	RequestID string
+
+	// Wait the response asked for in Retry-After (zero when it named none),
+	// e.g. on 429 RATE_LIMITED
+	RetryAfter time.Duration
+}
+
+// Error describes the response.
+func (e *Error) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("api error %d: %s", e.Status, e.Message)
+	}
+	return fmt.Sprintf("api error %d %s: %s", e.Status, e.Code, e.Message)
+}
+
+// HasCode reports whether the error is an error response with the code.
+//
+// Parameters:
+//   - err: Error returned by a client method
+//   - code: Machine-readable error code, e.g. "NOT_FOUND"
+//
+// Returns:
+//   - bool: True if err wraps an *Error with the code
+func HasCode(err error, code string) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.Code == code
+}
+
+// HasStatus reports whether the error is an error response with the status.
+//
+// Parameters:
+//   - err: Error returned by a client method
+//   - status: HTTP status code, e.g. http.StatusNotFound
+//
+// Returns:
+//   - bool: True if err wraps an *Error with the status
+func HasStatus(err error, status int) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.Status == status
+}
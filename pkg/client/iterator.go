@@ -0,0 +1,103 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"net/http"
+)
+
+// ListModulesAll iterates over every module matching the filter.
+//
+// The iterator follows the keyset cursors of the module list (meta.nextCursor),
+// so modules created or deleted while iterating neither shift items between
+// pages nor repeat them. Pages are fetched as the loop consumes them; breaking
+// out of the loop fetches no further page.
+//
+// A rate-limited page (429) is fetched again after the wait the response asks
+// for in Retry-After, or an exponential backoff when it names none, for as
+// long as the context allows; a Retry-After beyond 30 seconds (an exhausted
+// quota) is reported instead. Any other error, and the end of the context,
+// is yielded once and ends the iteration.
+//
+// Usage Example:
+//
+//	for module, err := range api.ListModulesAll(ctx, client.ModuleListOptions{Tag: "billing"}) {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    fmt.Println(module.Name)
+//	}
+//
+// Parameters:
+//   - ctx: Context bounding the iteration
+//   - filter: Filters and page size (PageSize; Page is ignored)
+//
+// Returns:
+//   - iter.Seq2[Module, error]: The modules, or a single error
+func (c *Client) ListModulesAll(ctx context.Context, filter ModuleListOptions) iter.Seq2[Module, error] {
+	return func(yield func(Module, error) bool) {
+		filter.Page = 0
+		query := filter.values()
+
+		// An empty cursor selects the keyset mode, starting at the first page
+		cursor := ""
+		for {
+			query.Set("cursor", cursor)
+
+			// Step 1: Fetch the page, waiting out rate limits
+			var modules []Module
+			meta, err := c.waitRateLimit(ctx, func() (*Meta, error) {
+				modules = modules[:0]
+				return c.Do(ctx, http.MethodGet, "/modules", query, nil, &modules)
+			})
+			if err != nil {
+				yield(Module{}, err)
+				return
+			}
+
+			// Step 2: Hand out its modules
+			for _, module := range modules {
+				if !yield(module, nil) {
+					return
+				}
+			}
+
+			// Step 3: Continue after the last module, if there is more
+			if meta == nil || meta.NextCursor == "" {
+				return
+			}
+			cursor = meta.NextCursor
+		}
+	}
+}
+
+// waitRateLimit calls fetch until it succeeds or fails with something else
+// than a 429 response.
+func (c *Client) waitRateLimit(ctx context.Context, fetch func() (*Meta, error)) (*Meta, error) {
+	backoff := c.config.RetryBackoff
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		meta, err := fetch()
+		var apiErr *Error
+		if !errors.As(err, &apiErr) || apiErr.Status != http.StatusTooManyRequests {
+			return meta, err
+		}
+
+		// Quotas ask for waits like the end of the month; report those
+		wait := apiErr.RetryAfter
+		if wait > maxRetryWait {
+			return meta, err
+		}
+		if wait == 0 {
+			wait = backoff
+			backoff = min(2*backoff, maxRetryWait)
+		}
+		if err := sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
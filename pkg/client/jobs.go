@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"go_di_architecture/internal/app/jobs"
+)
+
+// StartExport queues an export of the modules.
+//
+// Parameters:
+//   - ctx: Context of the call
+//   - request: File format of the export
+//
+// Returns:
+//   - *Job: The queued job; WaitForJob follows it to its result
+//   - error: *Error for error responses
+func (c *Client) StartExport(ctx context.Context, request ExportRequest) (*Job, error) {
+	var job Job
+	if _, err := c.Do(ctx, http.MethodPost, "/exports", nil, request, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// GetJob fetches the state of a background job.
+//
+// Parameters:
+//   - ctx: Context of the call
+//   - id: ID of the job
+//
+// Returns:
+//   - *Job: The job
+//   - error: *Error for error responses (e.g. 404 NOT_FOUND)
+func (c *Client) GetJob(ctx context.Context, id string) (*Job, error) {
+	var job Job
+	if _, err := c.Do(ctx, http.MethodGet, "/jobs/"+url.PathEscape(id), nil, nil, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// WaitForJob polls a job until it succeeded or failed.
+//
+// Usage Example:
+//
+//	job, err := api.StartExport(ctx, client.ExportRequest{Format: "csv"})
+//	...
+//	job, err = api.WaitForJob(ctx, job.ID, time.Second)
+//
+// Parameters:
+//   - ctx: Context bounding the wait
+//   - id: ID of the job
+//   - interval: Wait between polls
+//
+// Returns:
+//   - *Job: The finished job; check its Status for the outcome
+//   - error: *Error for error responses, or the context error
+func (c *Client) WaitForJob(ctx context.Context, id string, interval time.Duration) (*Job, error) {
+	for {
+		job, err := c.GetJob(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if job.Status == jobs.StatusSucceeded || job.Status == jobs.StatusFailed {
+			return job, nil
+		}
+		if err := sleep(ctx, interval); err != nil {
+			return nil, err
+		}
+	}
+}
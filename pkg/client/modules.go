@@ -0,0 +1,203 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ListModules lists a page of modules.
+//
+// Parameters:
+//   - ctx: Context of the call
+//   - opts: Page and filters
+//
+// Returns:
+//   - *Page[Module]: The modules of the page with its position
+//   - error: *Error for error responses (e.g. 400 VALIDATION_ERROR)
+func (c *Client) ListModules(ctx context.Context, opts ModuleListOptions) (*Page[Module], error) {
+	return list[Module](ctx, c, "/modules", opts.values())
+}
+
+// GetModule fetches a module.
+//
+// Parameters:
+//   - ctx: Context of the call
+//   - id: ID of the module
+//
+// Returns:
+//   - *Module: The module
+//   - error: *Error for error responses (e.g. 404 NOT_FOUND)
+func (c *Client) GetModule(ctx context.Context, id int) (*Module, error) {
+	var module Module
+	if _, err := c.Do(ctx, http.MethodGet, modulePath(id), nil, nil, &module); err != nil {
+		return nil, err
+	}
+	return &module, nil
+}
+
+// CreateModule creates a module.
+//
+// Creates are not retried, since a repeated attempt could create the module
+// twice.
+//
+// Parameters:
+//   - ctx: Context of the call
+//   - request: Name, description and schedule of the module
+//
+// Returns:
+//   - *Module: The created module
+//   - error: *Error for error responses (e.g. 409 RESOURCE_CONFLICT)
+func (c *Client) CreateModule(ctx context.Context, request ModuleRequest) (*Module, error) {
+	var module Module
+	if _, err := c.Do(ctx, http.MethodPost, "/modules", nil, request, &module); err != nil {
+		return nil, err
+	}
+	return &module, nil
+}
+
+// UpdateModule replaces the fields of a module.
+//
+// Parameters:
+//   - ctx: Context of the call
+//   - id: ID of the module
+//   - request: New name, description and schedule
+//
+// Returns:
+//   - *Module: The updated module
+//   - error: *Error for error responses
+func (c *Client) UpdateModule(ctx context.Context, id int, request ModuleRequest) (*Module, error) {
+	var module Module
+	if _, err := c.Do(ctx, http.MethodPut, modulePath(id), nil, request, &module); err != nil {
+		return nil, err
+	}
+	return &module, nil
+}
+
+// DeleteModule moves a module to the recycle bin.
+//
+// Parameters:
+//   - ctx: Context of the call
+//   - id: ID of the module
+//
+// Returns:
+//   - error: *Error for error responses
+func (c *Client) DeleteModule(ctx context.Context, id int) error {
+	_, err := c.Do(ctx, http.MethodDelete, modulePath(id), nil, nil, nil)
+	return err
+}
+
+// CountModules counts the modules visible to the caller.
+//
+// Returns:
+//   - int64: Number of modules
+//   - error: *Error for error responses
+func (c *Client) CountModules(ctx context.Context) (int64, error) {
+	var count ModuleCount
+	if _, err := c.Do(ctx, http.MethodGet, "/modules/count", nil, nil, &count); err != nil {
+		return 0, err
+	}
+	return count.Count, nil
+}
+
+// SubmitModule submits a draft module for approval.
+//
+// Parameters:
+//   - ctx: Context of the call
+//   - id: ID of the module
+//
+// Returns:
+//   - *Module: The pending module
+//   - error: *Error for error responses (e.g. 409 INVALID_STATUS_TRANSITION)
+func (c *Client) SubmitModule(ctx context.Context, id int) (*Module, error) {
+	return c.review(ctx, id, "submit", nil)
+}
+
+// ApproveModule approves a pending module.
+//
+// Parameters:
+//   - ctx: Context of the call
+//   - id: ID of the module
+//
+// Returns:
+//   - *Module: The approved module
+//   - error: *Error for error responses
+func (c *Client) ApproveModule(ctx context.Context, id int) (*Module, error) {
+	return c.review(ctx, id, "approve", nil)
+}
+
+// RejectModule returns a pending module to draft.
+//
+// Parameters:
+//   - ctx: Context of the call
+//   - id: ID of the module
+//   - request: Comment for the owner (optional)
+//
+// Returns:
+//   - *Module: The draft module
+//   - error: *Error for error responses
+func (c *Client) RejectModule(ctx context.Context, id int, request ReviewRequest) (*Module, error) {
+	return c.review(ctx, id, "reject", request)
+}
+
+// review sends a transition of the approval workflow.
+func (c *Client) review(ctx context.Context, id int, action string, body any) (*Module, error) {
+	var module Module
+	if _, err := c.Do(ctx, http.MethodPost, modulePath(id)+"/"+action, nil, body, &module); err != nil {
+		return nil, err
+	}
+	return &module, nil
+}
+
+// modulePath returns the path of a module.
+func modulePath(id int) string {
+	return "/modules/" + strconv.Itoa(id)
+}
+
+// values returns the query parameters of the page, leaving unset ones to the
+// API defaults.
+func (o PageOptions) values() url.Values {
+	query := url.Values{}
+	if o.Page > 0 {
+		query.Set("page", strconv.Itoa(o.Page))
+	}
+	if o.PageSize > 0 {
+		query.Set("pageSize", strconv.Itoa(o.PageSize))
+	}
+	return query
+}
+
+// values returns the query parameters of the page and its filters.
+func (o ModuleListOptions) values() url.Values {
+	query := o.PageOptions.values()
+	if o.Tag != "" {
+		query.Set("tag", o.Tag)
+	}
+	if o.Status != "" {
+		query.Set("status", o.Status)
+	}
+	if o.Starred {
+		query.Set("starred", "true")
+	}
+	if len(o.Include) > 0 {
+		query.Set("include", strings.Join(o.Include, ","))
+	}
+	return query
+}
+
+// list fetches a page of a paginated list.
+func list[T any](ctx context.Context, c *Client, path string, query url.Values) (*Page[T], error) {
+	page := &Page[T]{}
+	meta, err := c.Do(ctx, http.MethodGet, path, query, nil, &page.Items)
+	if err != nil {
+		return nil, err
+	}
+	if meta == nil {
+		return nil, fmt.Errorf("client: GET %s: response without body", path)
+	}
+	page.Pagination = meta.Pagination
+	return page, nil
+}
@@ -0,0 +1,134 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// ListTags lists every tag.
+//
+// Returns:
+//   - []Tag: The tags
+//   - error: *Error for error responses
+func (c *Client) ListTags(ctx context.Context) ([]Tag, error) {
+	var tags []Tag
+	if _, err := c.Do(ctx, http.MethodGet, "/tags", nil, nil, &tags); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// CreateTag creates a tag.
+//
+// Parameters:
+//   - ctx: Context of the call
+//   - request: Name of the tag
+//
+// Returns:
+//   - *Tag: The created tag
+//   - error: *Error for error responses (e.g. 409 RESOURCE_CONFLICT)
+func (c *Client) CreateTag(ctx context.Context, request TagRequest) (*Tag, error) {
+	var tag Tag
+	if _, err := c.Do(ctx, http.MethodPost, "/tags", nil, request, &tag); err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// DeleteTag deletes a tag and detaches it from every module.
+//
+// Parameters:
+//   - ctx: Context of the call
+//   - name: Name of the tag
+//
+// Returns:
+//   - error: *Error for error responses
+func (c *Client) DeleteTag(ctx context.Context, name string) error {
+	_, err := c.Do(ctx, http.MethodDelete, "/tags/"+url.PathEscape(name), nil, nil, nil)
+	return err
+}
+
+// AssignTag attaches a tag to a module.
+//
+// Parameters:
+//   - ctx: Context of the call
+//   - moduleID: ID of the module
+//   - name: Name of the tag
+//
+// Returns:
+//   - []Tag: The tags of the module after the change
+//   - error: *Error for error responses
+func (c *Client) AssignTag(ctx context.Context, moduleID int, name string) ([]Tag, error) {
+	return c.moduleTags(ctx, http.MethodPut, moduleID, name)
+}
+
+// UnassignTag detaches a tag from a module.
+//
+// Parameters:
+//   - ctx: Context of the call
+//   - moduleID: ID of the module
+//   - name: Name of the tag
+//
+// Returns:
+//   - []Tag: The tags of the module after the change
+//   - error: *Error for error responses
+func (c *Client) UnassignTag(ctx context.Context, moduleID int, name string) ([]Tag, error) {
+	return c.moduleTags(ctx, http.MethodDelete, moduleID, name)
+}
+
+// moduleTags changes a tag of a module.
+func (c *Client) moduleTags(ctx context.Context, method string, moduleID int, name string) ([]Tag, error) {
+	var tags []Tag
+	if _, err := c.Do(ctx, method, modulePath(moduleID)+"/tags/"+url.PathEscape(name), nil, nil, &tags); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// ListNotes lists a page of the notes of a module.
+//
+// Parameters:
+//   - ctx: Context of the call
+//   - moduleID: ID of the module
+//   - opts: Page to fetch
+//
+// Returns:
+//   - *Page[Note]: The notes of the page with its position
+//   - error: *Error for error responses
+func (c *Client) ListNotes(ctx context.Context, moduleID int, opts PageOptions) (*Page[Note], error) {
+	return list[Note](ctx, c, modulePath(moduleID)+"/notes", opts.values())
+}
+
+// AddNote adds a note to a module.
+//
+// Parameters:
+//   - ctx: Context of the call
+//   - moduleID: ID of the module
+//   - request: Text of the note
+//
+// Returns:
+//   - *Note: The created note
+//   - error: *Error for error responses
+func (c *Client) AddNote(ctx context.Context, moduleID int, request NoteRequest) (*Note, error) {
+	var note Note
+	if _, err := c.Do(ctx, http.MethodPost, modulePath(moduleID)+"/notes", nil, request, &note); err != nil {
+		return nil, err
+	}
+	return &note, nil
+}
+
+// DeleteNote deletes a note of a module.
+//
+// Parameters:
+//   - ctx: Context of the call
+//   - moduleID: ID of the module
+//   - noteID: ID of the note
+//
+// Returns:
+//   - error: *Error for error responses
+func (c *Client) DeleteNote(ctx context.Context, moduleID, noteID int) error {
+	_, err := c.Do(ctx, http.MethodDelete, modulePath(moduleID)+"/notes/"+strconv.Itoa(noteID), nil, nil, nil)
+	return err
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"go_di_architecture/internal/app/jobs"
+	"go_di_architecture/internal/domain/models/export"
+	"go_di_architecture/internal/domain/models/module"
+	"go_di_architecture/internal/domain/models/response"
+	"go_di_architecture/internal/domain/models/tag"
+	"go_di_architecture/internal/domain/models/template"
+)
+
+// Request and response types of the API.
+//
+// They are aliases of the DTOs the handlers bind and render, so a field added
+// to the API is available to clients without a change here, and callers
+// outside this module can name them.
+type (
+	// Envelope
+	APIError   = response.APIError
+	Meta       = response.ResponseMeta
+	Pagination = response.PaginationMeta
+
+	// Modules
+	ModuleRequest            = module.ModuleRequest
+	Module                   = module.ModuleResponse
+	DeletedModule            = module.DeletedModuleResponse
+	ModuleCount              = module.ModuleCountResponse
+	ModuleStats              = module.ModuleStatsResponse
+	ModuleChange             = module.ModuleChangeResponse
+	ModuleUsage              = module.ModuleUsageResponse
+	ReviewRequest            = module.ReviewRequest
+	TransferOwnershipRequest = module.TransferOwnershipRequest
+	Transfer                 = module.TransferResponse
+
+	// Notes
+	NoteRequest = module.NoteRequest
+	Note        = module.NoteResponse
+
+	// Tags
+	TagRequest = tag.TagRequest
+	Tag        = tag.TagResponse
+
+	// Templates
+	Template           = template.TemplateResponse
+	InstantiateRequest = template.InstantiateRequest
+
+	// Background jobs
+	ExportRequest = export.ExportRequest
+	Job           = jobs.Job
+)
+
+// Page is one page of an offset-paginated list.
+type Page[T any] struct {
+	// Items of the page
+	Items []T
+
+	// Position of the page (nil if the API sent none)
+	Pagination *Pagination
+}
+
+// PageOptions selects a page of a list.
+type PageOptions struct {
+	// 1-based page number (the API default, 1, when zero)
+	Page int
+
+	// Items per page (the API default, 20, when zero)
+	PageSize int
+}
+
+// ModuleListOptions selects a page of the module list.
+type ModuleListOptions struct {
+	PageOptions
+
+	// Only list modules carrying this tag (optional)
+	Tag string
+
+	// Only list modules in this approval state: draft, pending or approved
+	// (optional)
+	Status string
+
+	// Only list modules the caller starred
+	Starred bool
+
+	// Relations returned with every module: "tags" and "permissions"
+	// (optional)
+	Include []string
+}